@@ -2,19 +2,48 @@ package alert
 
 import (
 	"errors"
+	"fmt"
+	"log"
 	"strings"
+	"text/template"
+	"time"
 )
 
+// SeverityCritical is the severity of the alerts that warrant the most intrusive notifications the providers support
+// (e.g. phone calls)
+const SeverityCritical = "critical"
+
 var (
 	// ErrAlertWithInvalidDescription is the error with which Gatus will panic if an alert has an invalid character
 	ErrAlertWithInvalidDescription = errors.New("alert description must not have \" or \\")
+
+	// ErrAlertWithInvalidDescriptionTemplate is the error with which Gatus will panic if an alert has a description
+	// with template actions that cannot be parsed
+	ErrAlertWithInvalidDescriptionTemplate = errors.New("alert description has an invalid template")
+
+	// ErrAlertWithInvalidBodyExcerptMaxLength is the error with which Gatus will panic if an alert has a negative
+	// body excerpt max length
+	ErrAlertWithInvalidBodyExcerptMaxLength = errors.New("alert body-excerpt-max-length must not be negative")
+
+	// ErrAlertWithInvalidReminderInterval is the error with which Gatus will panic if an alert has a negative reminder
+	// interval
+	ErrAlertWithInvalidReminderInterval = errors.New("alert reminder-interval must not be negative")
+
+	// renderedDescriptionSanitizer replaces the characters that descriptions must not have from rendered descriptions,
+	// since they may come from the result of a health check
+	renderedDescriptionSanitizer = strings.NewReplacer(`"`, "'", `\`, "/")
 )
 
 // Alert is a core.Endpoint's alert configuration
 type Alert struct {
-	// Type of alert (required)
+	// Type of alert (required, unless Targets is set)
 	Type Type `yaml:"type"`
 
+	// Targets are the providers the alert is sent to, each with its own thresholds and severity, as an alternative to
+	// Type. Alerts with targets are expanded into one alert per target when the configuration is loaded.
+	// See Alert.ExpandTargets.
+	Targets []*Target `yaml:"targets,omitempty"`
+
 	// Enabled defines whether the alert is enabled
 	//
 	// Use Alert.IsEnabled() to retrieve the value of this field.
@@ -32,6 +61,10 @@ type Alert struct {
 	// or not for provider.ParseWithDefaultAlert to work.
 	Description *string `yaml:"description"`
 
+	// renderedDescription is the description rendered by RenderDescription, which is returned by GetDescription
+	// instead of Description until the alert is sent
+	renderedDescription *string
+
 	// SendOnResolved defines whether to send a second notification when the issue has been resolved
 	//
 	// This is a pointer, because it is populated by YAML and we need to know whether it was explicitly set to a value
@@ -41,6 +74,45 @@ type Alert struct {
 	// SuccessThreshold defines how many successful executions must happen in a row before an ongoing incident is marked as resolved
 	SuccessThreshold int `yaml:"success-threshold"`
 
+	// Conditions are the conditions of the endpoint, or the placeholders used by them (e.g. [CERTIFICATE_EXPIRATION]),
+	// that the alert is scoped to. If set, the alert is only triggered by the failure of these conditions, and is
+	// resolved once they succeed again, regardless of the outcome of the other conditions of the endpoint.
+	//
+	// If empty, the alert is triggered by the failure of any condition. Use Alert.IsScopedToConditions() to check.
+	Conditions []string `yaml:"conditions,omitempty"`
+
+	// Severity of the alert (e.g. critical, warning), which providers that mention people use to decide whether the
+	// alert should mention them. See Alert.ShouldMention.
+	Severity string `yaml:"severity,omitempty"`
+
+	// Priority overrides the priority of the notification sent when the alert is triggered, for the providers that
+	// support it (i.e. Gotify), regardless of the alert's severity.
+	//
+	// This is a pointer, because 0 is a valid priority and we need to know whether it was explicitly set or not.
+	Priority *int `yaml:"priority,omitempty"`
+
+	// IncludeBodyExcerpt defines whether to include an excerpt of the response body in the alert sent, for the
+	// providers that support it. See Alert.GetBodyExcerpt.
+	IncludeBodyExcerpt bool `yaml:"include-body-excerpt,omitempty"`
+
+	// BodyExcerptMaxLength is the maximum length, in bytes, of the excerpt of the response body included in the alert
+	// sent. Defaults to DefaultBodyExcerptMaxLength.
+	BodyExcerptMaxLength int `yaml:"body-excerpt-max-length,omitempty"`
+
+	// ReminderInterval is the interval at which a reminder is sent for as long as the alert remains triggered, so that
+	// long outages don't go unnoticed after the initial notification. If 0, no reminders are sent.
+	ReminderInterval time.Duration `yaml:"reminder-interval,omitempty"`
+
+	// NumberOfFailuresInARow is the number of evaluations in a row in which at least one of the conditions the alert
+	// is scoped to failed. Only used if the alert is scoped to conditions; otherwise, the counters of the endpoint are
+	// used instead.
+	NumberOfFailuresInARow int `yaml:"-"`
+
+	// NumberOfSuccessesInARow is the number of evaluations in a row in which none of the conditions the alert is scoped
+	// to failed. Only used if the alert is scoped to conditions; otherwise, the counters of the endpoint are used
+	// instead.
+	NumberOfSuccessesInARow int `yaml:"-"`
+
 	// ResolveKey is an optional field that is used by some providers (i.e. PagerDuty's dedup_key) to resolve
 	// ongoing/triggered incidents
 	ResolveKey string `yaml:"-"`
@@ -54,6 +126,31 @@ type Alert struct {
 	// some reason, the alert provider always returns errors when trying to send the resolved notification
 	// (SendOnResolved).
 	Triggered bool `yaml:"-"`
+
+	// TriggeredAt is when the alert was triggered, and is cleared once the alert is resolved
+	TriggeredAt time.Time `yaml:"-"`
+
+	// LastReminderAt is when the last reminder of the alert was sent, and is cleared once the alert is resolved
+	LastReminderAt time.Time `yaml:"-"`
+
+	// Acknowledgement is set when someone has acknowledged that they are handling the alert, and is cleared once the
+	// alert is resolved.
+	//
+	// While the alert is acknowledged, it will not be sent again. Use Alert.IsAcknowledged() to check whether the
+	// acknowledgement is still in effect.
+	Acknowledgement *Acknowledgement `yaml:"-"`
+}
+
+// Acknowledgement is the acknowledgement of an alert by someone
+type Acknowledgement struct {
+	// By is the name of whoever acknowledged the alert
+	By string `json:"by,omitempty"`
+
+	// Timestamp is when the alert was acknowledged
+	Timestamp time.Time `json:"timestamp"`
+
+	// ExpiresAt is when the acknowledgement expires. If nil, the acknowledgement lasts until the alert is resolved.
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
 }
 
 // ValidateAndSetDefaults validates the alert's configuration and sets the default value of fields that have one
@@ -64,20 +161,115 @@ func (alert *Alert) ValidateAndSetDefaults() error {
 	if alert.SuccessThreshold <= 0 {
 		alert.SuccessThreshold = 2
 	}
+	if alert.BodyExcerptMaxLength < 0 {
+		return ErrAlertWithInvalidBodyExcerptMaxLength
+	} else if alert.BodyExcerptMaxLength == 0 {
+		alert.BodyExcerptMaxLength = DefaultBodyExcerptMaxLength
+	}
+	if alert.ReminderInterval < 0 {
+		return ErrAlertWithInvalidReminderInterval
+	}
 	if strings.ContainsAny(alert.GetDescription(), "\"\\") {
 		return ErrAlertWithInvalidDescription
 	}
+	if alert.hasDescriptionTemplate() {
+		if _, err := alert.parseDescriptionTemplate(); err != nil {
+			return fmt.Errorf("%w: %s", ErrAlertWithInvalidDescriptionTemplate, err.Error())
+		}
+	}
 	return nil
 }
 
 // GetDescription retrieves the description of the alert
+//
+// If the description has been rendered with RenderDescription, the rendered description is returned instead.
 func (alert Alert) GetDescription() string {
+	if alert.renderedDescription != nil {
+		return *alert.renderedDescription
+	}
 	if alert.Description == nil {
 		return ""
 	}
 	return *alert.Description
 }
 
+// hasDescriptionTemplate returns whether the description of the alert has template actions
+func (alert Alert) hasDescriptionTemplate() bool {
+	return alert.Description != nil && strings.Contains(*alert.Description, "{{")
+}
+
+func (alert Alert) parseDescriptionTemplate() (*template.Template, error) {
+	return template.New("description").Option("missingkey=zero").Parse(*alert.Description)
+}
+
+// RenderDescription renders the description of the alert as a template with the data passed, so that GetDescription
+// returns the rendered description until ClearRenderedDescription is called.
+//
+// Does nothing if the description has no template actions. If the template fails to render, the description is used
+// as-is.
+func (alert *Alert) RenderDescription(data any) {
+	alert.renderedDescription = nil
+	if !alert.hasDescriptionTemplate() {
+		return
+	}
+	descriptionTemplate, err := alert.parseDescriptionTemplate()
+	if err != nil {
+		log.Printf("[alert][RenderDescription] Failed to parse description of alert of type=%s: %s", alert.Type, err.Error())
+		return
+	}
+	var rendered strings.Builder
+	if err = descriptionTemplate.Execute(&rendered, data); err != nil {
+		log.Printf("[alert][RenderDescription] Failed to render description of alert of type=%s: %s", alert.Type, err.Error())
+		return
+	}
+	description := renderedDescriptionSanitizer.Replace(rendered.String())
+	alert.renderedDescription = &description
+}
+
+// RenderReminderDescription prefixes the description returned by GetDescription with how long the alert has been
+// triggered for, so that the providers include it in the reminder sent, until ClearRenderedDescription is called.
+//
+// Must be called after RenderDescription, if at all.
+func (alert *Alert) RenderReminderDescription(now time.Time) {
+	description := fmt.Sprintf("Still triggered after %s", now.Sub(alert.TriggeredAt).Round(time.Minute))
+	if len(alert.GetDescription()) > 0 {
+		description += ": " + alert.GetDescription()
+	}
+	alert.renderedDescription = &description
+}
+
+// ClearRenderedDescription clears the description rendered by RenderDescription
+func (alert *Alert) ClearRenderedDescription() {
+	alert.renderedDescription = nil
+}
+
+// MarkAsTriggered marks the alert as triggered at the time passed
+func (alert *Alert) MarkAsTriggered(now time.Time) {
+	alert.Triggered = true
+	alert.TriggeredAt = now
+	alert.LastReminderAt = time.Time{}
+}
+
+// MarkAsResolved marks the alert as no longer triggered
+func (alert *Alert) MarkAsResolved() {
+	alert.Triggered = false
+	alert.TriggeredAt = time.Time{}
+	alert.LastReminderAt = time.Time{}
+}
+
+// IsReminderDue returns whether the alert has been triggered for long enough since it was triggered, or since its last
+// reminder was sent, for a reminder to be sent
+func (alert Alert) IsReminderDue(now time.Time) bool {
+	if alert.ReminderInterval <= 0 || !alert.Triggered || alert.TriggeredAt.IsZero() {
+		return false
+	}
+	lastNotifiedAt := alert.TriggeredAt
+	if alert.LastReminderAt.After(lastNotifiedAt) {
+		lastNotifiedAt = alert.LastReminderAt
+	}
+	return now.Sub(lastNotifiedAt) >= alert.ReminderInterval
+}
+
 // IsEnabled returns whether an alert is enabled or not
 // Returns true if not set
 func (alert Alert) IsEnabled() bool {
@@ -94,3 +286,47 @@ func (alert Alert) IsSendingOnResolved() bool {
 	}
 	return *alert.SendOnResolved
 }
+
+// IsScopedToConditions returns whether the alert is only triggered by the failure of specific conditions
+func (alert Alert) IsScopedToConditions() bool {
+	return len(alert.Conditions) > 0
+}
+
+// ShouldMention returns whether the alert should mention people, given the severities that the mentions of the
+// provider sending it are restricted to. If no severities are passed, every alert mentions people.
+func (alert Alert) ShouldMention(severities []string) bool {
+	if len(severities) == 0 {
+		return true
+	}
+	for _, severity := range severities {
+		if strings.EqualFold(severity, alert.Severity) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCritical returns whether the severity of the alert is SeverityCritical
+func (alert Alert) IsCritical() bool {
+	return strings.EqualFold(alert.Severity, SeverityCritical)
+}
+
+// Acknowledge marks the alert as acknowledged by the person specified
+//
+// If duration is 0, the acknowledgement lasts until the alert is resolved.
+func (alert *Alert) Acknowledge(by string, duration time.Duration) {
+	acknowledgement := &Acknowledgement{By: by, Timestamp: time.Now()}
+	if duration > 0 {
+		expiresAt := acknowledgement.Timestamp.Add(duration)
+		acknowledgement.ExpiresAt = &expiresAt
+	}
+	alert.Acknowledgement = acknowledgement
+}
+
+// IsAcknowledged returns whether the alert has been acknowledged and the acknowledgement hasn't expired yet
+func (alert Alert) IsAcknowledged() bool {
+	if alert.Acknowledgement == nil {
+		return false
+	}
+	return alert.Acknowledgement.ExpiresAt == nil || time.Now().Before(*alert.Acknowledgement.ExpiresAt)
+}
@@ -1,7 +1,9 @@
 package alert
 
 import (
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestAlert_ValidateAndSetDefaults(t *testing.T) {
@@ -72,6 +74,68 @@ func TestAlert_GetDescription(t *testing.T) {
 	}
 }
 
+func TestAlert_RenderDescription(t *testing.T) {
+	type data struct {
+		Status int
+		Body   string
+	}
+	scenarios := []struct {
+		name                string
+		description         string
+		data                any
+		expectedDescription string
+	}{
+		{
+			name:                "no-template-actions",
+			description:         "status was not 200",
+			data:                data{Status: 500},
+			expectedDescription: "status was not 200",
+		},
+		{
+			name:                "template",
+			description:         "status was {{ .Status }}, expected 200",
+			data:                data{Status: 500},
+			expectedDescription: "status was 500, expected 200",
+		},
+		{
+			name:                "template-with-characters-to-sanitize",
+			description:         "body was {{ .Body }}",
+			data:                data{Body: `{"status":"C:\\DOWN"}`},
+			expectedDescription: "body was {'status':'C://DOWN'}",
+		},
+		{
+			name:                "template-that-fails-to-render",
+			description:         "status was {{ .Unknown }}",
+			data:                data{Status: 500},
+			expectedDescription: "status was {{ .Unknown }}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			alert := Alert{Description: &scenario.description}
+			alert.RenderDescription(scenario.data)
+			if description := alert.GetDescription(); description != scenario.expectedDescription {
+				t.Errorf("expected description %q, got %q", scenario.expectedDescription, description)
+			}
+			alert.ClearRenderedDescription()
+			if description := alert.GetDescription(); description != scenario.description {
+				t.Errorf("expected description to be %q once the rendered description is cleared, got %q", scenario.description, description)
+			}
+		})
+	}
+}
+
+func TestAlert_ValidateAndSetDefaultsWithDescriptionTemplate(t *testing.T) {
+	description := "status was {{ .Result.HTTPStatus }}"
+	if err := (&Alert{Description: &description}).ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	description = "status was {{ .Result.HTTPStatus"
+	if err := (&Alert{Description: &description}).ValidateAndSetDefaults(); !errors.Is(err, ErrAlertWithInvalidDescriptionTemplate) {
+		t.Errorf("expected error %v, got %v", ErrAlertWithInvalidDescriptionTemplate, err)
+	}
+}
+
 func TestAlert_IsSendingOnResolved(t *testing.T) {
 	if (Alert{SendOnResolved: nil}).IsSendingOnResolved() {
 		t.Error("alert.IsSendingOnResolved() should've returned false, because SendOnResolved was set to nil")
@@ -83,3 +147,160 @@ func TestAlert_IsSendingOnResolved(t *testing.T) {
 		t.Error("alert.IsSendingOnResolved() should've returned true, because SendOnResolved was set to true")
 	}
 }
+
+func TestAlert_ShouldMention(t *testing.T) {
+	if !(Alert{}).ShouldMention(nil) {
+		t.Error("alert.ShouldMention() should've returned true, because mentions aren't restricted to any severity")
+	}
+	if !(Alert{Severity: "CRITICAL"}).ShouldMention([]string{"warning", "critical"}) {
+		t.Error("alert.ShouldMention() should've returned true, because the severity of the alert is one of the severities passed")
+	}
+	if (Alert{Severity: "warning"}).ShouldMention([]string{"critical"}) {
+		t.Error("alert.ShouldMention() should've returned false, because the severity of the alert isn't one of the severities passed")
+	}
+	if (Alert{}).ShouldMention([]string{"critical"}) {
+		t.Error("alert.ShouldMention() should've returned false, because the alert has no severity")
+	}
+}
+
+func TestAlert_IsAcknowledged(t *testing.T) {
+	alert := Alert{}
+	if alert.IsAcknowledged() {
+		t.Error("alert.IsAcknowledged() should've returned false, because the alert hasn't been acknowledged")
+	}
+	alert.Acknowledge("john.doe", 0)
+	if !alert.IsAcknowledged() {
+		t.Error("alert.IsAcknowledged() should've returned true, because the alert has been acknowledged without expiration")
+	}
+	if alert.Acknowledgement.By != "john.doe" || alert.Acknowledgement.ExpiresAt != nil {
+		t.Error("alert.Acknowledgement should've been by john.doe, without expiration")
+	}
+	alert.Acknowledge("jane.doe", time.Hour)
+	if !alert.IsAcknowledged() {
+		t.Error("alert.IsAcknowledged() should've returned true, because the acknowledgement hasn't expired yet")
+	}
+	expiresAt := time.Now().Add(-time.Minute)
+	alert.Acknowledgement.ExpiresAt = &expiresAt
+	if alert.IsAcknowledged() {
+		t.Error("alert.IsAcknowledged() should've returned false, because the acknowledgement has expired")
+	}
+}
+
+func TestAlert_ExpandTargets(t *testing.T) {
+	description := "description"
+	endpointAlert := &Alert{
+		Description:      &description,
+		FailureThreshold: 5,
+		SuccessThreshold: 2,
+		Severity:         "warning",
+		Targets: []*Target{
+			{Type: TypePagerDuty, FailureThreshold: 1, Severity: "critical"},
+			{Type: TypeSlack},
+		},
+	}
+	alerts, err := endpointAlert.ExpandTargets()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+	expectations := []struct {
+		alertType        Type
+		failureThreshold int
+		successThreshold int
+		severity         string
+	}{
+		{alertType: TypePagerDuty, failureThreshold: 1, successThreshold: 2, severity: "critical"},
+		{alertType: TypeSlack, failureThreshold: 5, successThreshold: 2, severity: "warning"},
+	}
+	for i, expected := range expectations {
+		if alerts[i].Type != expected.alertType || alerts[i].FailureThreshold != expected.failureThreshold || alerts[i].SuccessThreshold != expected.successThreshold || alerts[i].Severity != expected.severity {
+			t.Errorf("expected alert %d to be %+v, got type=%s; failure-threshold=%d; success-threshold=%d; severity=%s", i, expected, alerts[i].Type, alerts[i].FailureThreshold, alerts[i].SuccessThreshold, alerts[i].Severity)
+		}
+		if alerts[i].GetDescription() != description || alerts[i].HasTargets() {
+			t.Errorf("expected alert %d to inherit the description and to have no targets", i)
+		}
+	}
+	// Each target must have its own state
+	alerts[0].Triggered = true
+	if alerts[1].Triggered || endpointAlert.Triggered {
+		t.Error("expected the state of the targets to be independent")
+	}
+	// An alert without targets is returned as is
+	if alerts, err = alerts[1].ExpandTargets(); err != nil || len(alerts) != 1 || alerts[0].Type != TypeSlack {
+		t.Errorf("expected the alert without targets to be returned as is, got %v (error: %v)", alerts, err)
+	}
+	if _, err = (&Alert{Type: TypeSlack, Targets: []*Target{{Type: TypePagerDuty}}}).ExpandTargets(); err != ErrAlertWithTargetsAndType {
+		t.Errorf("expected error %v, got %v", ErrAlertWithTargetsAndType, err)
+	}
+	if _, err = (&Alert{Targets: []*Target{{FailureThreshold: 1}}}).ExpandTargets(); err != ErrAlertTargetWithNoType {
+		t.Errorf("expected error %v, got %v", ErrAlertTargetWithNoType, err)
+	}
+}
+
+func TestAlert_IsReminderDue(t *testing.T) {
+	now := time.Now()
+	scenarios := []struct {
+		name     string
+		alert    Alert
+		expected bool
+	}{
+		{
+			name:     "no-reminder-interval",
+			alert:    Alert{Triggered: true, TriggeredAt: now.Add(-2 * time.Hour)},
+			expected: false,
+		},
+		{
+			name:     "not-triggered",
+			alert:    Alert{ReminderInterval: time.Hour},
+			expected: false,
+		},
+		{
+			name:     "triggered-recently",
+			alert:    Alert{ReminderInterval: time.Hour, Triggered: true, TriggeredAt: now.Add(-30 * time.Minute)},
+			expected: false,
+		},
+		{
+			name:     "triggered-long-enough-ago",
+			alert:    Alert{ReminderInterval: time.Hour, Triggered: true, TriggeredAt: now.Add(-2 * time.Hour)},
+			expected: true,
+		},
+		{
+			name:     "reminded-recently",
+			alert:    Alert{ReminderInterval: time.Hour, Triggered: true, TriggeredAt: now.Add(-2 * time.Hour), LastReminderAt: now.Add(-30 * time.Minute)},
+			expected: false,
+		},
+		{
+			name:     "reminded-long-enough-ago",
+			alert:    Alert{ReminderInterval: time.Hour, Triggered: true, TriggeredAt: now.Add(-3 * time.Hour), LastReminderAt: now.Add(-time.Hour)},
+			expected: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := scenario.alert.IsReminderDue(now); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAlert_ValidateAndSetDefaultsWithNegativeReminderInterval(t *testing.T) {
+	alert := Alert{Type: TypeSlack, ReminderInterval: -time.Minute}
+	if err := alert.ValidateAndSetDefaults(); err != ErrAlertWithInvalidReminderInterval {
+		t.Errorf("expected error %v, got %v", ErrAlertWithInvalidReminderInterval, err)
+	}
+}
+
+func TestAlert_IsCritical(t *testing.T) {
+	if !(Alert{Severity: "CRITICAL"}).IsCritical() {
+		t.Error("expected alert with severity CRITICAL to be critical")
+	}
+	if (Alert{Severity: "warning"}).IsCritical() {
+		t.Error("expected alert with severity warning to not be critical")
+	}
+	if (Alert{}).IsCritical() {
+		t.Error("expected alert without severity to not be critical")
+	}
+}
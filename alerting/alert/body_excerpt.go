@@ -0,0 +1,22 @@
+package alert
+
+import "github.com/TwiN/gatus/v5/util"
+
+// DefaultBodyExcerptMaxLength is the default maximum length, in bytes, of the excerpt of the response body
+// included in an alert
+const DefaultBodyExcerptMaxLength = 200
+
+// GetBodyExcerpt returns the excerpt of the given response body to include in the alert, or an empty string if the
+// alert doesn't include one
+//
+// See util.Excerpt for how the excerpt is built.
+func (alert Alert) GetBodyExcerpt(body []byte) string {
+	if !alert.IncludeBodyExcerpt || len(body) == 0 {
+		return ""
+	}
+	maxLength := alert.BodyExcerptMaxLength
+	if maxLength <= 0 {
+		maxLength = DefaultBodyExcerptMaxLength
+	}
+	return util.Excerpt(body, maxLength)
+}
@@ -0,0 +1,51 @@
+package alert
+
+import "testing"
+
+func TestAlert_GetBodyExcerpt(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		alert    Alert
+		body     string
+		expected string
+	}{
+		{
+			name:     "disabled",
+			alert:    Alert{},
+			body:     "service unavailable",
+			expected: "",
+		},
+		{
+			name:     "empty-body",
+			alert:    Alert{IncludeBodyExcerpt: true},
+			body:     "",
+			expected: "",
+		},
+		{
+			name:     "short-body",
+			alert:    Alert{IncludeBodyExcerpt: true},
+			body:     "  service unavailable\n",
+			expected: "service unavailable",
+		},
+		{
+			name:     "truncated",
+			alert:    Alert{IncludeBodyExcerpt: true, BodyExcerptMaxLength: 7},
+			body:     "service unavailable",
+			expected: "service…",
+		},
+		{
+			name:     "json-secrets",
+			alert:    Alert{IncludeBodyExcerpt: true},
+			body:     `{"error":"unauthorized","access_token":"abc\"def","apiKey": "xyz"}`,
+			expected: `{"error":"unauthorized","access_token":"<redacted>","apiKey": "<redacted>"}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			excerpt := scenario.alert.GetBodyExcerpt([]byte(scenario.body))
+			if excerpt != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, excerpt)
+			}
+		})
+	}
+}
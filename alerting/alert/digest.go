@@ -0,0 +1,56 @@
+package alert
+
+import "time"
+
+// DefaultDigestWindow is the default window within which the endpoints of a group must fail for a digest to be sent
+const DefaultDigestWindow = 5 * time.Minute
+
+// DigestConfig is the configuration for summarizing the state of the endpoints of a group in a single message when
+// many of them fail at once, rather than sending an alert for each of them
+type DigestConfig struct {
+	// Threshold is the number of endpoints of a group that must fail within Window for a digest to be sent
+	Threshold int `yaml:"threshold"`
+
+	// Window is the duration within which the endpoints of a group must fail for a digest to be sent
+	Window time.Duration `yaml:"window,omitempty"`
+}
+
+// IsValid returns whether the digest configuration is valid
+func (c *DigestConfig) IsValid() bool {
+	return c.Threshold >= 2 && c.Window >= 0
+}
+
+// GetWindow returns the window of the digest, or DefaultDigestWindow if none is configured
+func (c *DigestConfig) GetWindow() time.Duration {
+	if c.Window == 0 {
+		return DefaultDigestWindow
+	}
+	return c.Window
+}
+
+// Digest is a summary of the endpoints of a group that are failing
+type Digest struct {
+	// Group is the group of the endpoints
+	Group string
+
+	// NumberOfEndpoints is the number of endpoints in the group
+	NumberOfEndpoints int
+
+	// FailingEndpoints are the endpoints of the group that are failing, in the order in which they started failing
+	FailingEndpoints []*DigestEndpoint
+
+	// Resolved is whether all endpoints of the group are healthy again
+	Resolved bool
+}
+
+// DigestEndpoint is an endpoint that is failing, as part of a Digest
+type DigestEndpoint struct {
+	// Name is the name of the endpoint
+	Name string
+
+	// FailingSince is when the alert of the endpoint was triggered
+	FailingSince time.Time
+
+	// FailedConditions are the conditions that failed when the alert of the endpoint was triggered
+	FailedConditions []string
+}
@@ -0,0 +1,69 @@
+package alert
+
+import "errors"
+
+var (
+	// ErrAlertWithTargetsAndType is the error with which Gatus will panic if an alert has both a type and targets
+	ErrAlertWithTargetsAndType = errors.New("alert must not have a type if it has targets, since each target has its own type")
+
+	// ErrAlertTargetWithNoType is the error with which Gatus will panic if a target of an alert has no type
+	ErrAlertTargetWithNoType = errors.New("alert target must have a type")
+)
+
+// Target is one of the providers an alert is sent to, along with the thresholds and the severity with which the alert
+// is sent to that provider
+//
+// Fields that aren't set are inherited from the alert.
+type Target struct {
+	// Type of the provider the alert is sent to (required)
+	Type Type `yaml:"type"`
+
+	// FailureThreshold is the number of failures in a row needed before triggering the alert for this target
+	FailureThreshold int `yaml:"failure-threshold,omitempty"`
+
+	// SuccessThreshold is the number of successes in a row needed before resolving the alert for this target
+	SuccessThreshold int `yaml:"success-threshold,omitempty"`
+
+	// Severity of the alert sent to this target
+	Severity string `yaml:"severity,omitempty"`
+}
+
+// HasTargets returns whether the alert is sent to multiple providers through targets rather than to the provider of
+// its type
+func (alert Alert) HasTargets() bool {
+	return len(alert.Targets) > 0
+}
+
+// ExpandTargets returns one alert per target of the alert, each with the type of its target and with the fields set
+// on its target overriding those of the alert
+//
+// Since each alert holds its own state (e.g. triggered), the targets are triggered and resolved independently of one
+// another. If the alert has no targets, the alert itself is returned.
+func (alert *Alert) ExpandTargets() ([]*Alert, error) {
+	if !alert.HasTargets() {
+		return []*Alert{alert}, nil
+	}
+	if len(alert.Type) > 0 {
+		return nil, ErrAlertWithTargetsAndType
+	}
+	alerts := make([]*Alert, 0, len(alert.Targets))
+	for _, target := range alert.Targets {
+		if len(target.Type) == 0 {
+			return nil, ErrAlertTargetWithNoType
+		}
+		targetAlert := *alert
+		targetAlert.Targets = nil
+		targetAlert.Type = target.Type
+		if target.FailureThreshold > 0 {
+			targetAlert.FailureThreshold = target.FailureThreshold
+		}
+		if target.SuccessThreshold > 0 {
+			targetAlert.SuccessThreshold = target.SuccessThreshold
+		}
+		if len(target.Severity) > 0 {
+			targetAlert.Severity = target.Severity
+		}
+		alerts = append(alerts, &targetAlert)
+	}
+	return alerts, nil
+}
@@ -5,9 +5,18 @@ package alert
 type Type string
 
 const (
+	// TypeAlertmanager is the Type for the alertmanager alerting provider
+	TypeAlertmanager Type = "alertmanager"
+
+	// TypeAMQP is the Type for the amqp alerting provider
+	TypeAMQP Type = "amqp"
+
 	// TypeCustom is the Type for the custom alerting provider
 	TypeCustom Type = "custom"
 
+	// TypeDatadog is the Type for the datadog alerting provider
+	TypeDatadog Type = "datadog"
+
 	// TypeDiscord is the Type for the discord alerting provider
 	TypeDiscord Type = "discord"
 
@@ -23,6 +32,15 @@ const (
 	// TypeGoogleChat is the Type for the googlechat alerting provider
 	TypeGoogleChat Type = "googlechat"
 
+	// TypeGotify is the Type for the gotify alerting provider
+	TypeGotify Type = "gotify"
+
+	// TypeLine is the Type for the line alerting provider
+	TypeLine Type = "line"
+
+	// TypeLogAnalytics is the Type for the loganalytics alerting provider
+	TypeLogAnalytics Type = "loganalytics"
+
 	// TypeMatrix is the Type for the matrix alerting provider
 	TypeMatrix Type = "matrix"
 
@@ -44,9 +62,18 @@ const (
 	// TypePushover is the Type for the pushover alerting provider
 	TypePushover Type = "pushover"
 
+	// TypeSignal is the Type for the signal alerting provider
+	TypeSignal Type = "signal"
+
 	// TypeSlack is the Type for the slack alerting provider
 	TypeSlack Type = "slack"
 
+	// TypeSplunk is the Type for the splunk alerting provider
+	TypeSplunk Type = "splunk"
+
+	// TypeSquadcast is the Type for the squadcast alerting provider
+	TypeSquadcast Type = "squadcast"
+
 	// TypeTeams is the Type for the teams alerting provider
 	TypeTeams Type = "teams"
 
@@ -56,6 +83,9 @@ const (
 	// TypeTwilio is the Type for the twilio alerting provider
 	TypeTwilio Type = "twilio"
 
+	// TypeVictorOps is the Type for the victorops alerting provider
+	TypeVictorOps Type = "victorops"
+
 	// TypeWecom is the Type for the twilio alerting provider
 	TypeWecom Type = "wecom"
 )
@@ -0,0 +1,172 @@
+package alerting
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCircuitBreakerFailureThreshold is the default number of consecutive failures after which the circuit
+	// breaker of an alerting provider opens
+	DefaultCircuitBreakerFailureThreshold = 5
+
+	// DefaultCircuitBreakerCooldown is the default amount of time during which alerts are not sent to a provider
+	// whose circuit breaker is open
+	DefaultCircuitBreakerCooldown = 10 * time.Minute
+)
+
+var (
+	ErrInvalidCircuitBreakerFailureThreshold = errors.New("alerting.circuit-breaker.failure-threshold must be 1 or higher")
+	ErrInvalidCircuitBreakerCooldown         = errors.New("alerting.circuit-breaker.cooldown must be 1s or higher")
+
+	// ErrCircuitBreakerOpen is the error returned when an alert is not sent because the circuit breaker of the
+	// alerting provider is open
+	ErrCircuitBreakerOpen = errors.New("circuit breaker of alerting provider is open")
+)
+
+// CircuitBreakerState is the state of a CircuitBreaker
+type CircuitBreakerState int
+
+const (
+	// CircuitBreakerStateClosed is the state in which alerts are sent normally
+	CircuitBreakerStateClosed CircuitBreakerState = iota
+
+	// CircuitBreakerStateHalfOpen is the state in which a single alert is sent to probe whether the provider recovered
+	CircuitBreakerStateHalfOpen
+
+	// CircuitBreakerStateOpen is the state in which alerts are not sent
+	CircuitBreakerStateOpen
+)
+
+// String returns the name of the state
+func (state CircuitBreakerState) String() string {
+	switch state {
+	case CircuitBreakerStateHalfOpen:
+		return "half-open"
+	case CircuitBreakerStateOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreakerConfig is the configuration of the circuit breakers protecting the alerting providers
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failures after which the circuit breaker of a provider opens
+	FailureThreshold int `yaml:"failure-threshold,omitempty"`
+
+	// Cooldown is the amount of time during which no alerts are sent to a provider after its circuit breaker opened.
+	// Once the cooldown has elapsed, a single alert is sent to check whether the provider recovered.
+	Cooldown time.Duration `yaml:"cooldown,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the circuit breaker configuration and sets the default values if necessary
+func (cfg *CircuitBreakerConfig) ValidateAndSetDefaults() error {
+	if cfg.FailureThreshold == 0 {
+		cfg.FailureThreshold = DefaultCircuitBreakerFailureThreshold
+	} else if cfg.FailureThreshold < 0 {
+		return ErrInvalidCircuitBreakerFailureThreshold
+	}
+	if cfg.Cooldown == 0 {
+		cfg.Cooldown = DefaultCircuitBreakerCooldown
+	} else if cfg.Cooldown < time.Second {
+		return ErrInvalidCircuitBreakerCooldown
+	}
+	return nil
+}
+
+// CircuitBreaker prevents alerts from being sent to a provider that keeps failing
+//
+// The circuit breaker opens after a number of consecutive failures, and stays open for the duration of the
+// cooldown. Once the cooldown has elapsed, the circuit breaker becomes half-open and lets a single alert through: if
+// it succeeds, the circuit breaker closes, otherwise, it opens again.
+//
+// A nil CircuitBreaker always lets alerts through.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	cooldown         time.Duration
+
+	mutex               sync.Mutex
+	state               CircuitBreakerState
+	consecutiveFailures int
+	openedAt            time.Time
+	probing             bool
+}
+
+// NewCircuitBreaker creates a new CircuitBreaker
+func NewCircuitBreaker(name string, cfg *CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: cfg.FailureThreshold,
+		cooldown:         cfg.Cooldown,
+	}
+}
+
+// Allow returns whether an alert may be sent
+func (cb *CircuitBreaker) Allow() bool {
+	if cb == nil {
+		return true
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	switch cb.state {
+	case CircuitBreakerStateOpen:
+		if time.Since(cb.openedAt) < cb.cooldown {
+			return false
+		}
+		log.Printf("[alerting][CircuitBreaker] Circuit breaker of provider=%s is now half-open", cb.name)
+		cb.state, cb.probing = CircuitBreakerStateHalfOpen, true
+		return true
+	case CircuitBreakerStateHalfOpen:
+		// Only one alert may be sent at a time to probe the provider
+		if cb.probing {
+			return false
+		}
+		cb.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// RecordSuccess records that an alert was sent successfully
+func (cb *CircuitBreaker) RecordSuccess() {
+	if cb == nil {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	if cb.state != CircuitBreakerStateClosed {
+		log.Printf("[alerting][CircuitBreaker] Circuit breaker of provider=%s is now closed", cb.name)
+	}
+	cb.state, cb.consecutiveFailures, cb.probing = CircuitBreakerStateClosed, 0, false
+}
+
+// RecordFailure records that an alert failed to be sent
+func (cb *CircuitBreaker) RecordFailure() {
+	if cb == nil {
+		return
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	cb.consecutiveFailures++
+	if cb.state == CircuitBreakerStateHalfOpen || cb.consecutiveFailures >= cb.failureThreshold {
+		if cb.state != CircuitBreakerStateOpen {
+			log.Printf("[alerting][CircuitBreaker] Circuit breaker of provider=%s is now open after %d consecutive failure(s); not sending alerts for %s", cb.name, cb.consecutiveFailures, cb.cooldown)
+		}
+		cb.state, cb.openedAt, cb.probing = CircuitBreakerStateOpen, time.Now(), false
+	}
+}
+
+// State returns the current state of the circuit breaker
+func (cb *CircuitBreaker) State() CircuitBreakerState {
+	if cb == nil {
+		return CircuitBreakerStateClosed
+	}
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+	return cb.state
+}
@@ -0,0 +1,131 @@
+package alerting
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name                     string
+		cfg                      *CircuitBreakerConfig
+		expectedErr              error
+		expectedFailureThreshold int
+		expectedCooldown         time.Duration
+	}{
+		{
+			name:                     "defaults",
+			cfg:                      &CircuitBreakerConfig{},
+			expectedFailureThreshold: DefaultCircuitBreakerFailureThreshold,
+			expectedCooldown:         DefaultCircuitBreakerCooldown,
+		},
+		{
+			name:                     "custom",
+			cfg:                      &CircuitBreakerConfig{FailureThreshold: 3, Cooldown: time.Minute},
+			expectedFailureThreshold: 3,
+			expectedCooldown:         time.Minute,
+		},
+		{
+			name:        "negative-failure-threshold",
+			cfg:         &CircuitBreakerConfig{FailureThreshold: -1},
+			expectedErr: ErrInvalidCircuitBreakerFailureThreshold,
+		},
+		{
+			name:        "cooldown-too-short",
+			cfg:         &CircuitBreakerConfig{Cooldown: time.Millisecond},
+			expectedErr: ErrInvalidCircuitBreakerCooldown,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.FailureThreshold != scenario.expectedFailureThreshold {
+				t.Errorf("expected failure threshold %d, got %d", scenario.expectedFailureThreshold, scenario.cfg.FailureThreshold)
+			}
+			if scenario.cfg.Cooldown != scenario.expectedCooldown {
+				t.Errorf("expected cooldown %s, got %s", scenario.expectedCooldown, scenario.cfg.Cooldown)
+			}
+		})
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	circuitBreaker := NewCircuitBreaker("test", &CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour})
+	if !circuitBreaker.Allow() {
+		t.Fatal("expected closed circuit breaker to allow alerts")
+	}
+	circuitBreaker.RecordFailure()
+	if circuitBreaker.State() != CircuitBreakerStateClosed || !circuitBreaker.Allow() {
+		t.Fatal("expected circuit breaker to stay closed before reaching the failure threshold")
+	}
+	circuitBreaker.RecordSuccess()
+	circuitBreaker.RecordFailure()
+	if circuitBreaker.State() != CircuitBreakerStateClosed {
+		t.Fatal("expected a success to reset the number of consecutive failures")
+	}
+	circuitBreaker.RecordFailure()
+	if circuitBreaker.State() != CircuitBreakerStateOpen {
+		t.Fatalf("expected circuit breaker to be open, got %s", circuitBreaker.State())
+	}
+	if circuitBreaker.Allow() {
+		t.Fatal("expected open circuit breaker to not allow alerts during the cooldown")
+	}
+	// Pretend that the cooldown has elapsed
+	circuitBreaker.openedAt = time.Now().Add(-2 * time.Hour)
+	if !circuitBreaker.Allow() {
+		t.Fatal("expected circuit breaker to allow a probe once the cooldown has elapsed")
+	}
+	if circuitBreaker.State() != CircuitBreakerStateHalfOpen {
+		t.Fatalf("expected circuit breaker to be half-open, got %s", circuitBreaker.State())
+	}
+	if circuitBreaker.Allow() {
+		t.Fatal("expected half-open circuit breaker to only allow a single probe")
+	}
+	circuitBreaker.RecordFailure()
+	if circuitBreaker.State() != CircuitBreakerStateOpen {
+		t.Fatalf("expected failed probe to re-open the circuit breaker, got %s", circuitBreaker.State())
+	}
+	circuitBreaker.openedAt = time.Now().Add(-2 * time.Hour)
+	if !circuitBreaker.Allow() {
+		t.Fatal("expected circuit breaker to allow a probe once the cooldown has elapsed")
+	}
+	circuitBreaker.RecordSuccess()
+	if circuitBreaker.State() != CircuitBreakerStateClosed || !circuitBreaker.Allow() {
+		t.Fatalf("expected successful probe to close the circuit breaker, got %s", circuitBreaker.State())
+	}
+}
+
+func TestCircuitBreaker_Nil(t *testing.T) {
+	var circuitBreaker *CircuitBreaker
+	if !circuitBreaker.Allow() {
+		t.Error("expected nil circuit breaker to allow alerts")
+	}
+	circuitBreaker.RecordFailure()
+	circuitBreaker.RecordSuccess()
+	if circuitBreaker.State() != CircuitBreakerStateClosed {
+		t.Error("expected nil circuit breaker to be closed")
+	}
+}
+
+func TestConfig_GetCircuitBreaker(t *testing.T) {
+	if (&Config{}).GetCircuitBreaker("custom") != nil {
+		t.Error("expected no circuit breaker when circuit breakers are not configured")
+	}
+	config := &Config{CircuitBreaker: &CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Minute}}
+	circuitBreaker := config.GetCircuitBreaker("custom")
+	if circuitBreaker == nil {
+		t.Fatal("expected circuit breaker")
+	}
+	if config.GetCircuitBreaker("custom") != circuitBreaker {
+		t.Error("expected the same circuit breaker to be returned for the same provider")
+	}
+	if config.GetCircuitBreaker("slack") == circuitBreaker {
+		t.Error("expected each provider to have its own circuit breaker")
+	}
+}
@@ -4,16 +4,25 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/wecom"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/alerting/provider/alertmanager"
+	"github.com/TwiN/gatus/v5/alerting/provider/amqp"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/alerting/provider/datadog"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
 	"github.com/TwiN/gatus/v5/alerting/provider/github"
 	"github.com/TwiN/gatus/v5/alerting/provider/gitlab"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
+	"github.com/TwiN/gatus/v5/alerting/provider/gotify"
+	"github.com/TwiN/gatus/v5/alerting/provider/line"
+	"github.com/TwiN/gatus/v5/alerting/provider/loganalytics"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
@@ -21,17 +30,30 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/signal"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/alerting/provider/splunk"
+	"github.com/TwiN/gatus/v5/alerting/provider/squadcast"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/victorops"
 )
 
 // Config is the configuration for alerting providers
 type Config struct {
+	// Alertmanager is the configuration for the alertmanager alerting provider
+	Alertmanager *alertmanager.AlertProvider `yaml:"alertmanager,omitempty"`
+
+	// AMQP is the configuration for the amqp alerting provider
+	AMQP *amqp.AlertProvider `yaml:"amqp,omitempty"`
+
 	// Custom is the configuration for the custom alerting provider
 	Custom *custom.AlertProvider `yaml:"custom,omitempty"`
 
+	// Datadog is the configuration for the datadog alerting provider
+	Datadog *datadog.AlertProvider `yaml:"datadog,omitempty"`
+
 	// Discord is the configuration for the discord alerting provider
 	Discord *discord.AlertProvider `yaml:"discord,omitempty"`
 
@@ -47,6 +69,15 @@ type Config struct {
 	// GoogleChat is the configuration for the googlechat alerting provider
 	GoogleChat *googlechat.AlertProvider `yaml:"googlechat,omitempty"`
 
+	// Gotify is the configuration for the gotify alerting provider
+	Gotify *gotify.AlertProvider `yaml:"gotify,omitempty"`
+
+	// Line is the configuration for the line alerting provider
+	Line *line.AlertProvider `yaml:"line,omitempty"`
+
+	// LogAnalytics is the configuration for the loganalytics alerting provider
+	LogAnalytics *loganalytics.AlertProvider `yaml:"loganalytics,omitempty"`
+
 	// Matrix is the configuration for the matrix alerting provider
 	Matrix *matrix.AlertProvider `yaml:"matrix,omitempty"`
 
@@ -68,9 +99,18 @@ type Config struct {
 	// Pushover is the configuration for the pushover alerting provider
 	Pushover *pushover.AlertProvider `yaml:"pushover,omitempty"`
 
+	// Signal is the configuration for the signal alerting provider
+	Signal *signal.AlertProvider `yaml:"signal,omitempty"`
+
 	// Slack is the configuration for the slack alerting provider
 	Slack *slack.AlertProvider `yaml:"slack,omitempty"`
 
+	// Splunk is the configuration for the splunk alerting provider
+	Splunk *splunk.AlertProvider `yaml:"splunk,omitempty"`
+
+	// Squadcast is the configuration for the squadcast alerting provider
+	Squadcast *squadcast.AlertProvider `yaml:"squadcast,omitempty"`
+
 	// Teams is the configuration for the teams alerting provider
 	Teams *teams.AlertProvider `yaml:"teams,omitempty"`
 
@@ -80,8 +120,70 @@ type Config struct {
 	// Twilio is the configuration for the twilio alerting provider
 	Twilio *twilio.AlertProvider `yaml:"twilio,omitempty"`
 
+	// VictorOps is the configuration for the victorops alerting provider
+	VictorOps *victorops.AlertProvider `yaml:"victorops,omitempty"`
+
 	// Wecom is the configuration for the twilio alerting provider
 	Wecom *wecom.AlertProvider `yaml:"wecom,omitempty"`
+
+	// DefaultAlert is the default alert configuration applied to the alerts of every type, after the default alert of
+	// their provider. The values set on the alerts themselves and on the default alert of their provider take
+	// precedence.
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Locale is the language in which the fixed labels of the messages built by the providers are written
+	//
+	// Defaults to i18n.DefaultLocale
+	Locale i18n.Locale `yaml:"locale,omitempty"`
+
+	// CircuitBreaker is the configuration of the circuit breakers protecting the alerting providers
+	//
+	// If nil, alerts are always sent, regardless of how many times the provider failed.
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit-breaker,omitempty"`
+
+	// Routes are time windows during which alerts are handled by another provider or by the override of another group.
+	// They are evaluated in order before an alert is sent, and the first one that applies is used. If none apply, the
+	// alert is handled by the provider of its type.
+	Routes []*Route `yaml:"routes,omitempty"`
+
+	circuitBreakers      map[alert.Type]*CircuitBreaker
+	circuitBreakersMutex sync.Mutex
+}
+
+// GetCircuitBreaker returns the CircuitBreaker of the provider for the given alert.Type
+//
+// Returns nil if circuit breakers are not configured
+func (config *Config) GetCircuitBreaker(alertType alert.Type) *CircuitBreaker {
+	if config.CircuitBreaker == nil {
+		return nil
+	}
+	config.circuitBreakersMutex.Lock()
+	defer config.circuitBreakersMutex.Unlock()
+	if config.circuitBreakers == nil {
+		config.circuitBreakers = make(map[alert.Type]*CircuitBreaker)
+	}
+	circuitBreaker, exists := config.circuitBreakers[alertType]
+	if !exists {
+		circuitBreaker = NewCircuitBreaker(string(alertType), config.CircuitBreaker)
+		config.circuitBreakers[alertType] = circuitBreaker
+	}
+	return circuitBreaker
+}
+
+// GetOpenCircuitBreakers returns the alert types of the providers whose circuit breaker is not closed
+func (config *Config) GetOpenCircuitBreakers() []alert.Type {
+	config.circuitBreakersMutex.Lock()
+	defer config.circuitBreakersMutex.Unlock()
+	var alertTypes []alert.Type
+	for alertType, circuitBreaker := range config.circuitBreakers {
+		if circuitBreaker.State() != CircuitBreakerStateClosed {
+			alertTypes = append(alertTypes, alertType)
+		}
+	}
+	sort.Slice(alertTypes, func(i, j int) bool {
+		return alertTypes[i] < alertTypes[j]
+	})
+	return alertTypes
 }
 
 // GetAlertingProviderByAlertType returns an provider.AlertProvider by its corresponding alert.Type
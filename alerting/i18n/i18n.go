@@ -0,0 +1,125 @@
+package i18n
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// Locale is the language in which the labels of alert messages are written
+type Locale string
+
+const (
+	LocaleEnglish Locale = "en"
+	LocaleChinese Locale = "zh"
+
+	// DefaultLocale is the locale used if none is configured, as well as the locale that labels fall back to when
+	// they are missing from the configured locale
+	DefaultLocale = LocaleEnglish
+)
+
+var (
+	ErrUnsupportedLocale = errors.New("unsupported alerting locale")
+
+	currentLocale atomic.Value
+)
+
+// Key is the identifier of a label
+type Key string
+
+const (
+	KeyAlertTriggered        Key = "alert-triggered"
+	KeyAlertResolved         Key = "alert-resolved"
+	KeyAlertTriggeredMessage Key = "alert-triggered-message" // Expects the endpoint's name and the failure threshold
+	KeyAlertResolvedMessage  Key = "alert-resolved-message"  // Expects the endpoint's name and the success threshold
+	KeyCondition             Key = "condition"
+	KeyConditionResults      Key = "condition-results"
+	KeyEndpointInfo          Key = "endpoint-info"
+	KeyGroup                 Key = "group"
+	KeyName                  Key = "name"
+	KeyURL                   Key = "url"
+	KeyDescription           Key = "description"
+	KeyUpdateTime            Key = "update-time"
+	KeyDigestTriggered       Key = "digest-triggered" // Expects the number of failing endpoints, the number of endpoints and the group
+	KeyDigestResolved        Key = "digest-resolved"  // Expects the group
+	KeyFailingEndpoints      Key = "failing-endpoints"
+	KeySince                 Key = "since"
+	KeyBodyExcerpt           Key = "body-excerpt"
+)
+
+var translations = map[Locale]map[Key]string{
+	LocaleEnglish: {
+		KeyAlertTriggered:        "Alert Triggered",
+		KeyAlertResolved:         "Alert Resolved",
+		KeyAlertTriggeredMessage: "An alert for %s has been triggered due to having failed %d time(s) in a row",
+		KeyAlertResolvedMessage:  "An alert for %s has been resolved after passing successfully %d time(s) in a row",
+		KeyCondition:             "Condition",
+		KeyConditionResults:      "Condition results",
+		KeyEndpointInfo:          "Endpoint Info",
+		KeyGroup:                 "group",
+		KeyName:                  "name",
+		KeyURL:                   "url",
+		KeyDescription:           "describe",
+		KeyUpdateTime:            "update time",
+		KeyDigestTriggered:       "%d/%d endpoints in group=%s are down",
+		KeyDigestResolved:        "All endpoints in group=%s are back up",
+		KeyFailingEndpoints:      "Failing endpoints",
+		KeySince:                 "since",
+		KeyBodyExcerpt:           "Response body",
+	},
+	LocaleChinese: {
+		KeyAlertTriggered:        "告警触发",
+		KeyAlertResolved:         "告警恢复",
+		KeyAlertTriggeredMessage: "%s 的告警已触发，已连续失败 %d 次",
+		KeyAlertResolvedMessage:  "%s 的告警已恢复，已连续成功 %d 次",
+		KeyCondition:             "条件",
+		KeyConditionResults:      "条件结果",
+		KeyEndpointInfo:          "端点信息",
+		KeyGroup:                 "分组",
+		KeyName:                  "名称",
+		KeyURL:                   "地址",
+		KeyDescription:           "描述",
+		KeyUpdateTime:            "更新时间",
+		KeyDigestTriggered:       "分组 %[3]s 中 %[1]d/%[2]d 个端点异常",
+		KeyDigestResolved:        "分组 %s 中的所有端点已恢复",
+		KeyFailingEndpoints:      "异常端点",
+		KeySince:                 "开始于",
+		KeyBodyExcerpt:           "响应内容",
+	},
+}
+
+// IsSupported returns whether there are translations for the given locale
+func IsSupported(locale Locale) bool {
+	_, exists := translations[locale]
+	return exists
+}
+
+// SetLocale sets the locale used by Translate
+//
+// If the locale passed is empty, DefaultLocale is used.
+func SetLocale(locale Locale) error {
+	if len(locale) == 0 {
+		locale = DefaultLocale
+	}
+	if !IsSupported(locale) {
+		return ErrUnsupportedLocale
+	}
+	currentLocale.Store(locale)
+	return nil
+}
+
+// GetLocale returns the locale used by Translate
+func GetLocale() Locale {
+	if locale, ok := currentLocale.Load().(Locale); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// Translate returns the label for the given key in the current locale, falling back to DefaultLocale if the
+// current locale has no translation for that key
+func Translate(key Key) string {
+	if label, exists := translations[GetLocale()][key]; exists {
+		return label
+	}
+	return translations[DefaultLocale][key]
+}
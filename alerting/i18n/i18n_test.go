@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+)
+
+func TestSetLocale(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+	scenarios := []struct {
+		locale         Locale
+		expectedErr    error
+		expectedLocale Locale
+	}{
+		{locale: "", expectedLocale: LocaleEnglish},
+		{locale: LocaleEnglish, expectedLocale: LocaleEnglish},
+		{locale: LocaleChinese, expectedLocale: LocaleChinese},
+		{locale: "xx", expectedErr: ErrUnsupportedLocale, expectedLocale: LocaleChinese},
+	}
+	for _, scenario := range scenarios {
+		t.Run(string(scenario.locale), func(t *testing.T) {
+			if err := SetLocale(scenario.locale); err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if locale := GetLocale(); locale != scenario.expectedLocale {
+				t.Errorf("expected locale %s, got %s", scenario.expectedLocale, locale)
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer SetLocale(DefaultLocale)
+	if label := Translate(KeyConditionResults); label != "Condition results" {
+		t.Errorf("expected %s, got %s", "Condition results", label)
+	}
+	_ = SetLocale(LocaleChinese)
+	if label := Translate(KeyConditionResults); label != "条件结果" {
+		t.Errorf("expected %s, got %s", "条件结果", label)
+	}
+	// Missing keys must fall back to the default locale
+	delete(translations[LocaleChinese], KeyUpdateTime)
+	defer func() { translations[LocaleChinese][KeyUpdateTime] = "更新时间" }()
+	if label := Translate(KeyUpdateTime); label != "update time" {
+		t.Errorf("expected %s, got %s", "update time", label)
+	}
+}
+
+func TestTranslationsHaveAllKeys(t *testing.T) {
+	for locale, labels := range translations {
+		for key := range translations[DefaultLocale] {
+			if _, exists := labels[key]; !exists {
+				t.Errorf("locale %s is missing a translation for key %s", locale, key)
+			}
+		}
+	}
+}
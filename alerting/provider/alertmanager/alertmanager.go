@@ -0,0 +1,212 @@
+package alertmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// DefaultAlertName is the value of the alertname label of the alerts sent to Alertmanager
+	DefaultAlertName = "GatusEndpointUnhealthy"
+
+	// DefaultSeverity is the value of the severity label of the alerts that have no severity
+	DefaultSeverity = "critical"
+
+	// DefaultFiringDuration is the default duration after which Alertmanager considers a triggered alert resolved if
+	// it hasn't been resolved by Gatus
+	DefaultFiringDuration = 24 * time.Hour
+
+	alertsPath = "/api/v2/alerts"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Prometheus Alertmanager
+type AlertProvider struct {
+	// URLs are the base URLs of the Alertmanager instances to send the alerts to (e.g. http://alertmanager:9093).
+	// When Alertmanager runs in high availability mode, every instance should be listed, since it is up to the
+	// clients to send their alerts to all of them.
+	URLs []string `yaml:"urls"`
+
+	// Labels are additional labels to add to every alert (e.g. team), which may be used in the routes of Alertmanager.
+	// They cannot override the alertname, endpoint, group and severity labels.
+	Labels map[string]string `yaml:"labels,omitempty"`
+
+	// FiringDuration is the duration after which Alertmanager considers a triggered alert resolved if it hasn't been
+	// resolved by Gatus, which prevents alerts from firing forever should the resolved alert never reach Alertmanager.
+	// Defaults to DefaultFiringDuration.
+	FiringDuration time.Duration `yaml:"firing-duration,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group string   `yaml:"group"`
+	URLs  []string `yaml:"urls"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.FiringDuration < 0 {
+		return false
+	}
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !areValidURLs(override.URLs) {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return areValidURLs(provider.URLs)
+}
+
+// areValidURLs returns whether there is at least one URL, and whether all URLs passed are absolute HTTP(S) URLs
+func areValidURLs(urls []string) bool {
+	if len(urls) == 0 {
+		return false
+	}
+	for _, rawURL := range urls {
+		parsedURL, err := url.Parse(rawURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Send an alert using the provider
+//
+// The alert is sent to every Alertmanager instance, and an error is only returned if none of them received it, since
+// the instances of a cluster share their alerts with one another.
+//
+// Reference doc for Alertmanager: https://prometheus.io/docs/alerting/latest/clients/
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	body := provider.buildRequestBody(endpoint, alert, result, resolved, time.Now())
+	var errs []error
+	urls := provider.getURLsForGroup(endpoint.Group)
+	for _, alertmanagerURL := range urls {
+		if err := provider.send(alertmanagerURL, body); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) == len(urls) {
+		return errors.Join(errs...)
+	}
+	return nil
+}
+
+func (provider *AlertProvider) send(alertmanagerURL string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(alertmanagerURL, "/")+alertsPath, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert at %s returned status code %d: %s", alertmanagerURL, response.StatusCode, string(responseBody))
+	}
+	return nil
+}
+
+// Alert is an alert as expected by the v2 API of Alertmanager
+type Alert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    string            `json:"startsAt,omitempty"`
+	EndsAt      string            `json:"endsAt"`
+}
+
+// buildRequestBody builds the request body for the provider
+//
+// Alertmanager identifies alerts by their labels, so a resolved alert must have the same labels as the triggered one,
+// and is marked as resolved by having an endsAt in the past.
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool, now time.Time) []byte {
+	labels := make(map[string]string)
+	for name, value := range provider.Labels {
+		labels[name] = value
+	}
+	labels["alertname"] = DefaultAlertName
+	labels["endpoint"] = endpoint.Name
+	if len(endpoint.Group) > 0 {
+		labels["group"] = endpoint.Group
+	}
+	if len(alert.Severity) > 0 {
+		labels["severity"] = alert.Severity
+	} else {
+		labels["severity"] = DefaultSeverity
+	}
+	var description string
+	if resolved {
+		description = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		description = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		description += " with the following description: " + alertDescription
+	}
+	var conditions []string
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		conditions = append(conditions, fmt.Sprintf("%s - %s", prefix, conditionResult.Condition))
+	}
+	annotations := map[string]string{"description": description}
+	if len(conditions) > 0 {
+		annotations["conditions"] = strings.Join(conditions, "\n")
+	}
+	alertmanagerAlert := Alert{Labels: labels, Annotations: annotations}
+	if resolved {
+		alertmanagerAlert.EndsAt = now.UTC().Format(time.RFC3339)
+	} else {
+		firingDuration := provider.FiringDuration
+		if firingDuration == 0 {
+			firingDuration = DefaultFiringDuration
+		}
+		alertmanagerAlert.StartsAt = now.UTC().Format(time.RFC3339)
+		alertmanagerAlert.EndsAt = now.Add(firingDuration).UTC().Format(time.RFC3339)
+	}
+	body, _ := json.Marshal([]Alert{alertmanagerAlert})
+	return body
+}
+
+// getURLsForGroup returns the appropriate Alertmanager URLs for a given group
+func (provider *AlertProvider) getURLsForGroup(group string) []string {
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				return override.URLs
+			}
+		}
+	}
+	return provider.URLs
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,257 @@
+package alertmanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093", "http://alertmanager-1:9093"}},
+			Expected: true,
+		},
+		{
+			Name:     "no-urls",
+			Provider: AlertProvider{},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-url",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093", "alertmanager-1:9093"}},
+			Expected: false,
+		},
+		{
+			Name:     "negative-firing-duration",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093"}, FiringDuration: -time.Hour},
+			Expected: false,
+		},
+		{
+			Name: "valid-override",
+			Provider: AlertProvider{
+				URLs:      []string{"http://alertmanager-0:9093"},
+				Overrides: []Override{{Group: "core", URLs: []string{"http://alertmanager-core:9093"}}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "override-with-no-group",
+			Provider: AlertProvider{
+				URLs:      []string{"http://alertmanager-0:9093"},
+				Overrides: []Override{{URLs: []string{"http://alertmanager-core:9093"}}},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-duplicate-group",
+			Provider: AlertProvider{
+				URLs: []string{"http://alertmanager-0:9093"},
+				Overrides: []Override{
+					{Group: "core", URLs: []string{"http://alertmanager-core-0:9093"}},
+					{Group: "core", URLs: []string{"http://alertmanager-core-1:9093"}},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-no-urls",
+			Provider: AlertProvider{
+				URLs:      []string{"http://alertmanager-0:9093"},
+				Overrides: []Override{{Group: "core"}},
+			},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedURLs     []string
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093", "http://alertmanager-1:9093/"}},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedURLs:  []string{"http://alertmanager-0:9093/api/v2/alerts", "http://alertmanager-1:9093/api/v2/alerts"},
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-with-one-instance-down",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093", "http://alertmanager-1:9093"}},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.Host == "alertmanager-0:9093" {
+					return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedURLs:  []string{"http://alertmanager-0:9093/api/v2/alerts", "http://alertmanager-1:9093/api/v2/alerts"},
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{URLs: []string{"http://alertmanager-0:9093", "http://alertmanager-1:9093"}},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+			}),
+			ExpectedURLs:  []string{"http://alertmanager-0:9093/api/v2/alerts", "http://alertmanager-1:9093/api/v2/alerts"},
+			ExpectedError: true,
+		},
+		{
+			Name: "resolved-with-override",
+			Provider: AlertProvider{
+				URLs:      []string{"http://alertmanager-0:9093"},
+				Overrides: []Override{{Group: "core", URLs: []string{"http://alertmanager-core:9093"}}},
+			},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedURLs:  []string{"http://alertmanager-core:9093/api/v2/alerts"},
+			ExpectedError: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var urls []string
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				urls = append(urls, r.URL.String())
+				return scenario.MockRoundTripper(r)
+			})})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name", Group: "core"},
+				&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if len(urls) != len(scenario.ExpectedURLs) {
+				t.Fatalf("expected requests to %v, got %v", scenario.ExpectedURLs, urls)
+			}
+			for i := range urls {
+				if urls[i] != scenario.ExpectedURLs[i] {
+					t.Errorf("expected requests to %v, got %v", scenario.ExpectedURLs, urls)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{URLs: []string{"http://alertmanager-0:9093"}},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "[{\"labels\":{\"alertname\":\"GatusEndpointUnhealthy\",\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"severity\":\"critical\"},\"annotations\":{\"conditions\":\"❌ - [CONNECTED] == true\\n❌ - [STATUS] == 200\",\"description\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\"},\"startsAt\":\"2024-01-01T12:00:00Z\",\"endsAt\":\"2024-01-02T12:00:00Z\"}]",
+		},
+		{
+			Name:         "triggered-with-labels-severity-and-firing-duration",
+			Provider:     AlertProvider{URLs: []string{"http://alertmanager-0:9093"}, Labels: map[string]string{"team": "sre", "endpoint": "ignored"}, FiringDuration: time.Hour},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3, Severity: "warning"},
+			Resolved:     false,
+			ExpectedBody: "[{\"labels\":{\"alertname\":\"GatusEndpointUnhealthy\",\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"severity\":\"warning\",\"team\":\"sre\"},\"annotations\":{\"conditions\":\"❌ - [CONNECTED] == true\\n❌ - [STATUS] == 200\",\"description\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\"},\"startsAt\":\"2024-01-01T12:00:00Z\",\"endsAt\":\"2024-01-01T13:00:00Z\"}]",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{URLs: []string{"http://alertmanager-0:9093"}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "[{\"labels\":{\"alertname\":\"GatusEndpointUnhealthy\",\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"severity\":\"critical\"},\"annotations\":{\"conditions\":\"✅ - [CONNECTED] == true\\n✅ - [STATUS] == 200\",\"description\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\"},\"endsAt\":\"2024-01-01T12:00:00Z\"}]",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+				now,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			var out []map[string]interface{}
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getURLsForGroup(t *testing.T) {
+	provider := AlertProvider{
+		URLs:      []string{"http://alertmanager-0:9093"},
+		Overrides: []Override{{Group: "core", URLs: []string{"http://alertmanager-core:9093"}}},
+	}
+	if urls := provider.getURLsForGroup(""); len(urls) != 1 || urls[0] != "http://alertmanager-0:9093" {
+		t.Errorf("expected the default URLs, got %v", urls)
+	}
+	if urls := provider.getURLsForGroup("core"); len(urls) != 1 || urls[0] != "http://alertmanager-core:9093" {
+		t.Errorf("expected the URLs of the override, got %v", urls)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/util"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using a custom HTTP request
@@ -21,6 +22,13 @@ type AlertProvider struct {
 	Headers      map[string]string            `yaml:"headers,omitempty"`
 	Placeholders map[string]map[string]string `yaml:"placeholders,omitempty"`
 
+	// Secret is the secret with which the body of each request is signed, so that the receiver can verify that the
+	// request comes from Gatus. The signature is sent in the SignatureHeader.
+	Secret string `yaml:"secret,omitempty"`
+
+	// SignatureHeader is the name of the header in which the signature is sent. Defaults to util.DefaultSignatureHeader.
+	SignatureHeader string `yaml:"signature-header,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the provider's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
@@ -75,6 +83,13 @@ func (provider *AlertProvider) buildHTTPRequest(endpoint *core.Endpoint, alert *
 	for k, v := range provider.Headers {
 		request.Header.Set(k, v)
 	}
+	if len(provider.Secret) > 0 {
+		signatureHeader := provider.SignatureHeader
+		if len(signatureHeader) == 0 {
+			signatureHeader = util.DefaultSignatureHeader
+		}
+		request.Header.Set(signatureHeader, util.SignPayload(provider.Secret, []byte(body)))
+	}
 	return request
 }
 
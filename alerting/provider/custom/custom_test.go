@@ -10,6 +10,7 @@ import (
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/TwiN/gatus/v5/test"
+	"github.com/TwiN/gatus/v5/util"
 )
 
 func TestAlertProvider_IsValid(t *testing.T) {
@@ -203,6 +204,37 @@ func TestAlertProvider_buildHTTPRequestWithCustomPlaceholder(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildHTTPRequestWithSecret(t *testing.T) {
+	scenarios := []struct {
+		Name                    string
+		AlertProvider           *AlertProvider
+		ExpectedSignatureHeader string
+	}{
+		{
+			Name:                    "default-signature-header",
+			AlertProvider:           &AlertProvider{URL: "https://example.com", Body: "[ENDPOINT_NAME]", Secret: "secret"},
+			ExpectedSignatureHeader: "X-Gatus-Signature",
+		},
+		{
+			Name:                    "custom-signature-header",
+			AlertProvider:           &AlertProvider{URL: "https://example.com", Body: "[ENDPOINT_NAME]", Secret: "secret", SignatureHeader: "X-Hub-Signature-256"},
+			ExpectedSignatureHeader: "X-Hub-Signature-256",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := scenario.AlertProvider.buildHTTPRequest(&core.Endpoint{Name: "endpoint-name"}, &alert.Alert{}, false)
+			// The signature must be computed from the body once the placeholders are replaced
+			if expectedSignature := util.SignPayload("secret", []byte("endpoint-name")); request.Header.Get(scenario.ExpectedSignatureHeader) != expectedSignature {
+				t.Errorf("expected header %s to be %s, got %s", scenario.ExpectedSignatureHeader, expectedSignature, request.Header.Get(scenario.ExpectedSignatureHeader))
+			}
+		})
+	}
+	if request := (&AlertProvider{URL: "https://example.com"}).buildHTTPRequest(&core.Endpoint{}, &alert.Alert{}, false); len(request.Header.Get("X-Gatus-Signature")) > 0 {
+		t.Error("expected no signature to be sent without a secret")
+	}
+}
+
 func TestAlertProvider_GetAlertStatePlaceholderValueDefaults(t *testing.T) {
 	customAlertProvider := &AlertProvider{
 		URL:  "https://example.com/[ENDPOINT_NAME]?event=[ALERT_TRIGGERED_OR_RESOLVED]&description=[ALERT_DESCRIPTION]",
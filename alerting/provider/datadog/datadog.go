@@ -0,0 +1,128 @@
+package datadog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// DefaultSite is the Datadog site used if none is specified
+	DefaultSite = "datadoghq.com"
+)
+
+// AlertProvider is the configuration necessary for sending an alert as a Datadog event
+type AlertProvider struct {
+	// APIKey is the Datadog API key
+	APIKey string `yaml:"api-key"`
+
+	// Site is the Datadog site to send the events to (e.g. datadoghq.com, datadoghq.eu, us3.datadoghq.com)
+	Site string `yaml:"site,omitempty"`
+
+	// Tags is a list of tags to add to every event, in addition to the endpoint and group tags
+	Tags []string `yaml:"tags,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	return len(provider.APIKey) > 0
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, provider.getEventsURL(), buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("DD-API-KEY", provider.APIKey)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	AlertType      string   `json:"alert_type"`
+	AggregationKey string   `json:"aggregation_key"`
+	SourceTypeName string   `json:"source_type_name"`
+	Host           string   `json:"host,omitempty"`
+	Tags           []string `json:"tags"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var title, message, alertType, results string
+	if resolved {
+		title = fmt.Sprintf("Resolved: %s", endpoint.DisplayName())
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		alertType = "success"
+	} else {
+		title = fmt.Sprintf("Triggered: %s", endpoint.DisplayName())
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		alertType = "error"
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		results += fmt.Sprintf("%s - `%s`\n", prefix, conditionResult.Condition)
+	}
+	text := message
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		text += "\n\n" + alertDescription
+	}
+	if len(results) > 0 {
+		text += "\n\n" + results
+	}
+	tags := []string{"source:gatus", "endpoint:" + endpoint.Name}
+	if len(endpoint.Group) > 0 {
+		tags = append(tags, "group:"+endpoint.Group)
+	}
+	tags = append(tags, provider.Tags...)
+	body, _ := json.Marshal(Body{
+		Title:          title,
+		Text:           text,
+		AlertType:      alertType,
+		AggregationKey: "gatus-" + endpoint.Key(),
+		SourceTypeName: "gatus",
+		Host:           result.Hostname,
+		Tags:           tags,
+	})
+	return body
+}
+
+// getEventsURL returns the URL of the events API for the configured site
+func (provider *AlertProvider) getEventsURL() string {
+	site := provider.Site
+	if len(site) == 0 {
+		site = DefaultSite
+	}
+	return fmt.Sprintf("https://api.%s/api/v1/events", site)
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 )
@@ -16,6 +18,13 @@ import (
 type AlertProvider struct {
 	WebhookURL string `yaml:"webhook-url"`
 
+	// Mention is who to mention in the alerts sent (e.g. <@&123456789012345678> for a role, @here)
+	Mention string `yaml:"mention,omitempty"`
+
+	// MentionSeverities are the severities of the alerts that mention Mention (e.g. [critical]). If empty, every alert
+	// mentions it.
+	MentionSeverities []string `yaml:"mention-severities,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
@@ -86,10 +95,10 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	var message, results string
 	var colorCode int
 	if resolved {
-		message = fmt.Sprintf("An alert for **%s** has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf(i18n.Translate(i18n.KeyAlertResolvedMessage), "**"+endpoint.DisplayName()+"**", alert.SuccessThreshold)
 		colorCode = 3066993
 	} else {
-		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		message = fmt.Sprintf(i18n.Translate(i18n.KeyAlertTriggeredMessage), "**"+endpoint.DisplayName()+"**", alert.FailureThreshold)
 		colorCode = 15158332
 	}
 	for _, conditionResult := range result.ConditionResults {
@@ -105,20 +114,32 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
 		description = ":\n> " + alertDescription
 	}
+	var content string
+	if len(provider.Mention) > 0 && alert.ShouldMention(provider.MentionSeverities) {
+		content = provider.Mention
+	}
+	fields := []Field{
+		{
+			Name:   i18n.Translate(i18n.KeyConditionResults),
+			Value:  results,
+			Inline: false,
+		},
+	}
+	if bodyExcerpt := alert.GetBodyExcerpt(result.Body); !resolved && len(bodyExcerpt) > 0 {
+		fields = append(fields, Field{
+			Name:   i18n.Translate(i18n.KeyBodyExcerpt),
+			Value:  "```" + strings.ReplaceAll(bodyExcerpt, "`", "'") + "```",
+			Inline: false,
+		})
+	}
 	body, _ := json.Marshal(Body{
-		Content: "",
+		Content: content,
 		Embeds: []Embed{
 			{
 				Title:       ":helmet_with_white_cross: Gatus",
 				Description: message + description,
 				Color:       colorCode,
-				Fields: []Field{
-					{
-						Name:   "Condition results",
-						Value:  results,
-						Inline: false,
-					},
-				},
+				Fields:      fields,
 			},
 		},
 	})
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/TwiN/gatus/v5/test"
@@ -153,6 +154,27 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
 		},
+		{
+			Name:         "triggered-with-mention",
+			Provider:     AlertProvider{Mention: "<@&123456789012345678>"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\\u003c@\\u0026123456789012345678\\u003e\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
+		},
+		{
+			Name:         "triggered-with-mention-restricted-to-other-severity",
+			Provider:     AlertProvider{Mention: "<@&123456789012345678>", MentionSeverities: []string{"critical"}},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false}]}]}",
+		},
+		{
+			Name:         "triggered-with-body-excerpt",
+			Provider:     AlertProvider{},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, IncludeBodyExcerpt: true},
+			Resolved:     false,
+			ExpectedBody: "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"color\":15158332,\"fields\":[{\"name\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n:x: - `[BODY] != \\\"\\\"`\\n\",\"inline\":false},{\"name\":\"Response body\",\"value\":\"```Bad Gateway```\",\"inline\":false}]}]}",
+		},
 		{
 			Name:         "resolved",
 			Provider:     AlertProvider{},
@@ -167,6 +189,7 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 				&core.Endpoint{Name: "endpoint-name"},
 				&scenario.Alert,
 				&core.Result{
+					Body: []byte("Bad Gateway"),
 					ConditionResults: []*core.ConditionResult{
 						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
 						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
@@ -186,6 +209,21 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildRequestBodyWithLocale(t *testing.T) {
+	_ = i18n.SetLocale(i18n.LocaleChinese)
+	defer i18n.SetLocale(i18n.DefaultLocale)
+	body := (&AlertProvider{}).buildRequestBody(
+		&core.Endpoint{Name: "endpoint-name"},
+		&alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+		&core.Result{ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}},
+		false,
+	)
+	expectedBody := "{\"content\":\"\",\"embeds\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"description\":\"**endpoint-name** 的告警已触发，已连续失败 3 次\",\"color\":15158332,\"fields\":[{\"name\":\"条件结果\",\"value\":\":x: - `[STATUS] == 200`\\n\",\"inline\":false}]}]}"
+	if string(body) != expectedBody {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedBody, body)
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
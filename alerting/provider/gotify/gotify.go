@@ -0,0 +1,252 @@
+package gotify
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+var (
+	// ErrInvalidAlertPriority is the error returned when the priority of an alert is outside the range accepted by
+	// Gotify
+	ErrInvalidAlertPriority = errors.New("alert priority must be between 0 and 10")
+)
+
+const (
+	// DefaultTriggeredPriority is the default priority of the message sent when an alert is triggered
+	DefaultTriggeredPriority = 8
+
+	// DefaultResolvedPriority is the default priority of the message sent when an alert is resolved
+	DefaultResolvedPriority = 4
+
+	// MaximumPriority is the highest priority accepted by Gotify
+	MaximumPriority = 10
+
+	// DefaultCriticalPriority is the default priority of the message sent when an alert with a severity of
+	// alert.SeverityCritical is triggered
+	DefaultCriticalPriority = MaximumPriority
+
+	// messagePath is the path of Gotify's endpoint used to create messages
+	messagePath = "/message"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Gotify
+type AlertProvider struct {
+	// ServerURL is the URL of the Gotify server (e.g. https://gotify.example.com)
+	ServerURL string `yaml:"server-url"`
+
+	// Token is the token of the Gotify application to send the messages as
+	Token string `yaml:"token"`
+
+	// Title is the title of the messages. Defaults to "Gatus: <endpoint>"
+	Title string `yaml:"title,omitempty"`
+
+	// Priority is the priority of the messages sent
+	Priority Priority `yaml:"priority,omitempty"`
+
+	// Severities is a mapping of alert severities (e.g. critical) to the priority of the messages sent for alerts of
+	// that severity, which takes precedence over Priority and the priority of the overrides.
+	//
+	// Unless mapped, triggered alerts with a severity of alert.SeverityCritical default to DefaultCriticalPriority.
+	Severities map[string]Priority `yaml:"severities,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Priority is the priority of the messages sent, based on whether the alert was triggered or resolved
+//
+// Gotify accepts priorities from 0 to 10. As 0 is indistinguishable from a priority that has not been set, it is
+// replaced by the default priority.
+type Priority struct {
+	// Triggered is the priority of the message sent when an alert is triggered. Defaults to DefaultTriggeredPriority
+	Triggered int `yaml:"triggered,omitempty"`
+
+	// Resolved is the priority of the message sent when an alert is resolved. Defaults to DefaultResolvedPriority
+	Resolved int `yaml:"resolved,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group    string   `yaml:"group"`
+	Priority Priority `yaml:"priority"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	if len(provider.Token) == 0 {
+		return false
+	}
+	parsedURL, err := url.Parse(provider.ServerURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return false
+	}
+	if !provider.Priority.isValid() {
+		return false
+	}
+	for _, priority := range provider.Severities {
+		if !priority.isValid() {
+			return false
+		}
+	}
+	registeredGroups := make(map[string]bool)
+	for _, override := range provider.Overrides {
+		if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !override.Priority.isValid() {
+			return false
+		}
+		registeredGroups[override.Group] = true
+	}
+	return true
+}
+
+// isValid returns whether the priorities are within the range accepted by Gotify
+func (priority Priority) isValid() bool {
+	return priority.Triggered >= 0 && priority.Triggered <= MaximumPriority && priority.Resolved >= 0 && priority.Resolved <= MaximumPriority
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	if alert.Priority != nil && (*alert.Priority < 0 || *alert.Priority > MaximumPriority) {
+		return ErrInvalidAlertPriority
+	}
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(provider.ServerURL, "/")+messagePath, buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Gotify-Key", provider.Token)
+	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	Title    string `json:"title"`
+	Message  string `json:"message"`
+	Priority int    `json:"priority"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var message, results string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✓"
+		} else {
+			prefix = "✕"
+		}
+		results += fmt.Sprintf("\n%s - %s", prefix, conditionResult.Condition)
+	}
+	if description := alert.GetDescription(); len(description) > 0 {
+		message += " with the following description: " + description
+	}
+	message += results
+	title := provider.Title
+	if len(title) == 0 {
+		title = "Gatus: " + endpoint.DisplayName()
+	}
+	body, _ := json.Marshal(Body{
+		Title:    title,
+		Message:  message,
+		Priority: provider.getPriority(endpoint.Group, alert, resolved),
+	})
+	return body
+}
+
+// getPriority returns the priority of the message for the given group, alert and alert state
+//
+// From highest to lowest precedence, the priority is that of the alert (triggered only), of the alert's severity, of
+// the group's override and of the provider.
+func (provider *AlertProvider) getPriority(group string, alert *alert.Alert, resolved bool) int {
+	if !resolved && alert.Priority != nil {
+		return *alert.Priority
+	}
+	priority := provider.getPriorityForGroup(group)
+	if severityPriority, exists := provider.getPriorityForSeverity(alert.Severity); exists {
+		if severityPriority.Triggered != 0 {
+			priority.Triggered = severityPriority.Triggered
+		}
+		if severityPriority.Resolved != 0 {
+			priority.Resolved = severityPriority.Resolved
+		}
+	} else if alert.IsCritical() {
+		priority.Triggered = DefaultCriticalPriority
+	}
+	if resolved {
+		if priority.Resolved == 0 {
+			return DefaultResolvedPriority
+		}
+		return priority.Resolved
+	}
+	if priority.Triggered == 0 {
+		return DefaultTriggeredPriority
+	}
+	return priority.Triggered
+}
+
+// getPriorityForGroup returns the priorities configured for the given group, which may be 0 if not set
+func (provider *AlertProvider) getPriorityForGroup(group string) Priority {
+	priority := provider.Priority
+	for _, override := range provider.Overrides {
+		if group == override.Group {
+			if override.Priority.Triggered != 0 {
+				priority.Triggered = override.Priority.Triggered
+			}
+			if override.Priority.Resolved != 0 {
+				priority.Resolved = override.Priority.Resolved
+			}
+			break
+		}
+	}
+	return priority
+}
+
+// getPriorityForSeverity returns the priorities mapped to the given severity, if any
+func (provider *AlertProvider) getPriorityForSeverity(severity string) (Priority, bool) {
+	if len(severity) == 0 {
+		return Priority{}, false
+	}
+	for mappedSeverity, priority := range provider.Severities {
+		if strings.EqualFold(mappedSeverity, severity) {
+			return priority, true
+		}
+	}
+	return Priority{}, false
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,310 @@
+package gotify
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Expected: true,
+		},
+		{
+			Name:     "valid-with-priority",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Priority: Priority{Triggered: 10, Resolved: 1}},
+			Expected: true,
+		},
+		{
+			Name:     "no-token",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com"},
+			Expected: false,
+		},
+		{
+			Name:     "no-server-url",
+			Provider: AlertProvider{Token: "token"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-server-url-scheme",
+			Provider: AlertProvider{ServerURL: "gotify.example.com", Token: "token"},
+			Expected: false,
+		},
+		{
+			Name:     "triggered-priority-too-high",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Priority: Priority{Triggered: 11}},
+			Expected: false,
+		},
+		{
+			Name:     "resolved-priority-negative",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Priority: Priority{Resolved: -1}},
+			Expected: false,
+		},
+		{
+			Name:     "valid-severities",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Severities: map[string]Priority{"critical": {Triggered: 10}, "info": {Triggered: 2, Resolved: 1}}},
+			Expected: true,
+		},
+		{
+			Name:     "severity-with-invalid-priority",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Severities: map[string]Priority{"critical": {Triggered: 11}}},
+			Expected: false,
+		},
+		{
+			Name:     "valid-override",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Overrides: []Override{{Group: "core", Priority: Priority{Triggered: 10}}}},
+			Expected: true,
+		},
+		{
+			Name:     "override-with-no-group",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Overrides: []Override{{Priority: Priority{Triggered: 10}}}},
+			Expected: false,
+		},
+		{
+			Name:     "override-with-duplicate-group",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Overrides: []Override{{Group: "core"}, {Group: "core"}}},
+			Expected: false,
+		},
+		{
+			Name:     "override-with-invalid-priority",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Overrides: []Override{{Group: "core", Priority: Priority{Triggered: 42}}}},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	invalidPriority := 11
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com/", Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.Path != "/message" || r.Header.Get("X-Gotify-Key") != "token" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-error",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "triggered-with-invalid-alert-priority",
+			Provider: AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Priority: &invalidPriority},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	alertPriority := 9
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"title\":\"Gatus: group/endpoint-name\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\\n✕ - [CONNECTED] == true\\n✕ - [STATUS] == 200\",\"priority\":8}",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token"},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"title\":\"Gatus: group/endpoint-name\",\"message\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\\n✓ - [CONNECTED] == true\\n✓ - [STATUS] == 200\",\"priority\":4}",
+		},
+		{
+			Name:         "triggered-with-custom-title-and-priority",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Title: "Production", Priority: Priority{Triggered: 10}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"title\":\"Production\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\\n✕ - [CONNECTED] == true\\n✕ - [STATUS] == 200\",\"priority\":10}",
+		},
+		{
+			Name:         "resolved-with-override",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Priority: Priority{Resolved: 2}, Overrides: []Override{{Group: "group", Priority: Priority{Resolved: 6}}}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"title\":\"Gatus: group/endpoint-name\",\"message\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\\n✓ - [CONNECTED] == true\\n✓ - [STATUS] == 200\",\"priority\":6}",
+		},
+		{
+			Name:         "triggered-with-severity",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Severities: map[string]Priority{"info": {Triggered: 3}}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3, Severity: "info"},
+			Resolved:     false,
+			ExpectedBody: "{\"title\":\"Gatus: group/endpoint-name\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\\n✕ - [CONNECTED] == true\\n✕ - [STATUS] == 200\",\"priority\":3}",
+		},
+		{
+			Name:         "triggered-with-alert-priority",
+			Provider:     AlertProvider{ServerURL: "https://gotify.example.com", Token: "token", Severities: map[string]Priority{"info": {Triggered: 3}}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3, Severity: "info", Priority: &alertPriority},
+			Resolved:     false,
+			ExpectedBody: "{\"title\":\"Gatus: group/endpoint-name\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\\n✕ - [CONNECTED] == true\\n✕ - [STATUS] == 200\",\"priority\":9}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getPriority(t *testing.T) {
+	zero := 0
+	provider := AlertProvider{
+		Priority:   Priority{Triggered: 7},
+		Severities: map[string]Priority{"info": {Triggered: 2}, "warning": {Triggered: 6, Resolved: 1}},
+		Overrides:  []Override{{Group: "core", Priority: Priority{Triggered: 9, Resolved: 3}}},
+	}
+	scenarios := []struct {
+		Name             string
+		Group            string
+		Alert            alert.Alert
+		Resolved         bool
+		ExpectedPriority int
+	}{
+		{Name: "triggered", Alert: alert.Alert{}, ExpectedPriority: 7},
+		{Name: "resolved", Alert: alert.Alert{}, Resolved: true, ExpectedPriority: DefaultResolvedPriority},
+		{Name: "triggered-with-override", Group: "core", Alert: alert.Alert{}, ExpectedPriority: 9},
+		{Name: "resolved-with-override", Group: "core", Alert: alert.Alert{}, Resolved: true, ExpectedPriority: 3},
+		{Name: "triggered-with-severity", Group: "core", Alert: alert.Alert{Severity: "INFO"}, ExpectedPriority: 2},
+		{Name: "resolved-with-severity-without-resolved-priority", Group: "core", Alert: alert.Alert{Severity: "info"}, Resolved: true, ExpectedPriority: 3},
+		{Name: "resolved-with-severity", Alert: alert.Alert{Severity: "warning"}, Resolved: true, ExpectedPriority: 1},
+		{Name: "triggered-with-unmapped-severity", Alert: alert.Alert{Severity: "unknown"}, ExpectedPriority: 7},
+		{Name: "triggered-with-critical-severity", Alert: alert.Alert{Severity: alert.SeverityCritical}, ExpectedPriority: DefaultCriticalPriority},
+		{Name: "resolved-with-critical-severity", Alert: alert.Alert{Severity: alert.SeverityCritical}, Resolved: true, ExpectedPriority: DefaultResolvedPriority},
+		{Name: "triggered-with-alert-priority", Alert: alert.Alert{Severity: "info", Priority: &zero}, ExpectedPriority: 0},
+		{Name: "resolved-with-alert-priority", Alert: alert.Alert{Severity: "warning", Priority: &zero}, Resolved: true, ExpectedPriority: 1},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if priority := provider.getPriority(scenario.Group, &scenario.Alert, scenario.Resolved); priority != scenario.ExpectedPriority {
+				t.Errorf("expected %d, got %d", scenario.ExpectedPriority, priority)
+			}
+		})
+	}
+	criticalProvider := AlertProvider{Severities: map[string]Priority{"critical": {Triggered: 9}}}
+	if priority := criticalProvider.getPriority("", &alert.Alert{Severity: alert.SeverityCritical}, false); priority != 9 {
+		t.Errorf("expected mapped critical severity to take precedence over the default, got %d", priority)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
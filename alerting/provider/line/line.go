@@ -0,0 +1,203 @@
+package line
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// APINotify is the API used to send alerts through Line Notify
+	APINotify = "notify"
+
+	// APIMessaging is the API used to send alerts through a bot of the Line Messaging API
+	APIMessaging = "messaging"
+
+	notifyAPIURL    = "https://notify-api.line.me/api/notify"
+	messagingAPIURL = "https://api.line.me/v2/bot/message/push"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Line
+type AlertProvider struct {
+	// API is the Line API used to send the alerts. Can be either APINotify or APIMessaging. Defaults to APINotify
+	API string `yaml:"api,omitempty"`
+
+	// Token is the access token of Line Notify, or the channel access token of the bot if API is APIMessaging
+	Token string `yaml:"token"`
+
+	// To is the ID of the user, group or room to send the alerts to. Only used if API is APIMessaging
+	To string `yaml:"to,omitempty"`
+
+	// ResolvedSticker is the sticker to send along with the message when an alert is resolved
+	ResolvedSticker *Sticker `yaml:"resolved-sticker,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Sticker is a Line sticker
+//
+// See https://developers.line.biz/en/docs/messaging-api/sticker-list/ for the list of available stickers
+type Sticker struct {
+	PackageID string `yaml:"package-id"`
+	StickerID string `yaml:"sticker-id"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group string `yaml:"group"`
+	Token string `yaml:"token"`
+	To    string `yaml:"to,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if len(provider.API) == 0 {
+		provider.API = APINotify
+	}
+	if provider.API != APINotify && provider.API != APIMessaging {
+		return false
+	}
+	if provider.ResolvedSticker != nil && (len(provider.ResolvedSticker.PackageID) == 0 || len(provider.ResolvedSticker.StickerID) == 0) {
+		return false
+	}
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.Token) == 0 {
+				return false
+			}
+			if provider.API == APIMessaging && len(override.To) == 0 {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	if provider.API == APIMessaging && len(provider.To) == 0 {
+		return false
+	}
+	return len(provider.Token) > 0
+}
+
+// Send an alert using the provider
+//
+// Reference doc for Line Notify: https://notify-bot.line.me/doc/en/
+// Reference doc for the Line Messaging API: https://developers.line.biz/en/reference/messaging-api/#send-push-message
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	token, to := provider.getTokenAndRecipientForGroup(endpoint.Group)
+	var request *http.Request
+	var err error
+	if provider.API == APIMessaging {
+		body := provider.buildMessagingRequestBody(to, endpoint, alert, result, resolved)
+		request, err = http.NewRequest(http.MethodPost, messagingAPIURL, bytes.NewBuffer(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+	} else {
+		body := provider.buildNotifyRequestBody(endpoint, alert, result, resolved)
+		request, err = http.NewRequest(http.MethodPost, notifyAPIURL, bytes.NewBufferString(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type MessagingBody struct {
+	To       string    `json:"to"`
+	Messages []Message `json:"messages"`
+}
+
+type Message struct {
+	Type      string `json:"type"`
+	Text      string `json:"text,omitempty"`
+	PackageID string `json:"packageId,omitempty"`
+	StickerID string `json:"stickerId,omitempty"`
+}
+
+// buildNotifyRequestBody builds the form-encoded request body for Line Notify
+func (provider *AlertProvider) buildNotifyRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) string {
+	values := url.Values{}
+	// Line Notify prepends the name of the token to the message, so a line break is added to separate them
+	values.Set("message", "\n"+provider.buildMessage(endpoint, alert, result, resolved))
+	if resolved && provider.ResolvedSticker != nil {
+		values.Set("stickerPackageId", provider.ResolvedSticker.PackageID)
+		values.Set("stickerId", provider.ResolvedSticker.StickerID)
+	}
+	return values.Encode()
+}
+
+// buildMessagingRequestBody builds the JSON request body for the Line Messaging API
+func (provider *AlertProvider) buildMessagingRequestBody(to string, endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	messages := []Message{{Type: "text", Text: provider.buildMessage(endpoint, alert, result, resolved)}}
+	if resolved && provider.ResolvedSticker != nil {
+		messages = append(messages, Message{Type: "sticker", PackageID: provider.ResolvedSticker.PackageID, StickerID: provider.ResolvedSticker.StickerID})
+	}
+	body, _ := json.Marshal(MessagingBody{To: to, Messages: messages})
+	return body
+}
+
+// buildMessage builds the text of the message sent by the provider
+func (provider *AlertProvider) buildMessage(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) string {
+	var message, results string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		message += " with the following description: " + alertDescription
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		results += fmt.Sprintf("\n%s - %s", prefix, conditionResult.Condition)
+	}
+	if len(results) > 0 {
+		message += "\n\nCondition results:" + results
+	}
+	return message
+}
+
+// getTokenAndRecipientForGroup returns the appropriate token and recipient for a given group
+func (provider *AlertProvider) getTokenAndRecipientForGroup(group string) (string, string) {
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				return override.Token, override.To
+			}
+		}
+	}
+	return provider.Token, provider.To
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
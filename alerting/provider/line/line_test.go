@@ -0,0 +1,330 @@
+package line
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid-notify",
+			Provider: AlertProvider{Token: "token"},
+			Expected: true,
+		},
+		{
+			Name:     "valid-messaging",
+			Provider: AlertProvider{API: APIMessaging, Token: "token", To: "U00000000000000000000000000000000"},
+			Expected: true,
+		},
+		{
+			Name:     "no-token",
+			Provider: AlertProvider{},
+			Expected: false,
+		},
+		{
+			Name:     "messaging-without-recipient",
+			Provider: AlertProvider{API: APIMessaging, Token: "token"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-api",
+			Provider: AlertProvider{API: "invalid", Token: "token"},
+			Expected: false,
+		},
+		{
+			Name:     "valid-resolved-sticker",
+			Provider: AlertProvider{Token: "token", ResolvedSticker: &Sticker{PackageID: "446", StickerID: "1989"}},
+			Expected: true,
+		},
+		{
+			Name:     "resolved-sticker-without-sticker-id",
+			Provider: AlertProvider{Token: "token", ResolvedSticker: &Sticker{PackageID: "446"}},
+			Expected: false,
+		},
+		{
+			Name: "valid-override",
+			Provider: AlertProvider{
+				Token:     "token",
+				Overrides: []Override{{Group: "core", Token: "core-token"}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "override-with-no-group",
+			Provider: AlertProvider{
+				Token:     "token",
+				Overrides: []Override{{Token: "core-token"}},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-duplicate-group",
+			Provider: AlertProvider{
+				Token: "token",
+				Overrides: []Override{
+					{Group: "core", Token: "core-token"},
+					{Group: "core", Token: "other-token"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-no-token",
+			Provider: AlertProvider{
+				Token:     "token",
+				Overrides: []Override{{Group: "core"}},
+			},
+			Expected: false,
+		},
+		{
+			Name: "messaging-override-without-recipient",
+			Provider: AlertProvider{
+				API:       APIMessaging,
+				Token:     "token",
+				To:        "U00000000000000000000000000000000",
+				Overrides: []Override{{Group: "core", Token: "core-token"}},
+			},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered-notify",
+			Provider: AlertProvider{API: APINotify, Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != notifyAPIURL || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-notify-error",
+			Provider: AlertProvider{API: APINotify, Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved-messaging",
+			Provider: AlertProvider{API: APIMessaging, Token: "token", To: "U00000000000000000000000000000000"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != messagingAPIURL || r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-messaging-error",
+			Provider: AlertProvider{API: APIMessaging, Token: "token", To: "U00000000000000000000000000000000"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildNotifyRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	scenarios := []struct {
+		Name                     string
+		Provider                 AlertProvider
+		Alert                    alert.Alert
+		Resolved                 bool
+		ExpectedMessage          string
+		ExpectedStickerPackageID string
+		ExpectedStickerID        string
+	}{
+		{
+			Name:            "triggered",
+			Provider:        AlertProvider{Token: "token", ResolvedSticker: &Sticker{PackageID: "446", StickerID: "1989"}},
+			Alert:           alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:        false,
+			ExpectedMessage: "\nAn alert for endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\n\nCondition results:\n❌ - [CONNECTED] == true\n❌ - [STATUS] == 200",
+		},
+		{
+			Name:            "resolved",
+			Provider:        AlertProvider{Token: "token"},
+			Alert:           alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:        true,
+			ExpectedMessage: "\nAn alert for endpoint-name has been resolved after passing successfully 5 time(s) in a row\n\nCondition results:\n✅ - [CONNECTED] == true\n✅ - [STATUS] == 200",
+		},
+		{
+			Name:                     "resolved-with-sticker",
+			Provider:                 AlertProvider{Token: "token", ResolvedSticker: &Sticker{PackageID: "446", StickerID: "1989"}},
+			Alert:                    alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:                 true,
+			ExpectedMessage:          "\nAn alert for endpoint-name has been resolved after passing successfully 5 time(s) in a row\n\nCondition results:\n✅ - [CONNECTED] == true\n✅ - [STATUS] == 200",
+			ExpectedStickerPackageID: "446",
+			ExpectedStickerID:        "1989",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildNotifyRequestBody(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			values, err := url.ParseQuery(body)
+			if err != nil {
+				t.Fatal("expected body to be form-encoded, got error:", err.Error())
+			}
+			if message := values.Get("message"); message != scenario.ExpectedMessage {
+				t.Errorf("expected message:\n%s\ngot:\n%s", scenario.ExpectedMessage, message)
+			}
+			if packageID := values.Get("stickerPackageId"); packageID != scenario.ExpectedStickerPackageID {
+				t.Errorf("expected sticker package ID %s, got %s", scenario.ExpectedStickerPackageID, packageID)
+			}
+			if stickerID := values.Get("stickerId"); stickerID != scenario.ExpectedStickerID {
+				t.Errorf("expected sticker ID %s, got %s", scenario.ExpectedStickerID, stickerID)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildMessagingRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{API: APIMessaging, Token: "token", To: "U0", ResolvedSticker: &Sticker{PackageID: "446", StickerID: "1989"}},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"to\":\"U0\",\"messages\":[{\"type\":\"text\",\"text\":\"An alert for endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\\n\\nCondition results:\\n❌ - [CONNECTED] == true\\n❌ - [STATUS] == 200\"}]}",
+		},
+		{
+			Name:         "resolved-with-sticker",
+			Provider:     AlertProvider{API: APIMessaging, Token: "token", To: "U0", ResolvedSticker: &Sticker{PackageID: "446", StickerID: "1989"}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"to\":\"U0\",\"messages\":[{\"type\":\"text\",\"text\":\"An alert for endpoint-name has been resolved after passing successfully 5 time(s) in a row\\n\\nCondition results:\\n✅ - [CONNECTED] == true\\n✅ - [STATUS] == 200\"},{\"type\":\"sticker\",\"packageId\":\"446\",\"stickerId\":\"1989\"}]}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildMessagingRequestBody(
+				scenario.Provider.To,
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getTokenAndRecipientForGroup(t *testing.T) {
+	provider := AlertProvider{
+		API:       APIMessaging,
+		Token:     "token",
+		To:        "U0",
+		Overrides: []Override{{Group: "core", Token: "core-token", To: "U1"}},
+	}
+	if token, to := provider.getTokenAndRecipientForGroup(""); token != "token" || to != "U0" {
+		t.Errorf("expected token and U0, got %s and %s", token, to)
+	}
+	if token, to := provider.getTokenAndRecipientForGroup("core"); token != "core-token" || to != "U1" {
+		t.Errorf("expected core-token and U1, got %s and %s", token, to)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
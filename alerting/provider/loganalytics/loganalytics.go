@@ -0,0 +1,208 @@
+package loganalytics
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// DefaultLogType is the log type used if none is configured, which results in the records being stored in the
+	// Gatus_CL custom log table
+	DefaultLogType = "Gatus"
+
+	// resource is the resource of the Data Collector API, which is part of what is signed
+	resource = "/api/logs"
+
+	// apiVersion is the version of the Data Collector API used
+	apiVersion = "2016-04-01"
+)
+
+// logTypeRegex is the pattern a log type must match to be accepted by the Data Collector API
+var logTypeRegex = regexp.MustCompile(`^[a-zA-Z0-9_]{1,100}$`)
+
+// AlertProvider is the configuration necessary for sending an alert as a custom log record to an Azure Monitor Log
+// Analytics workspace (e.g. the workspace of Microsoft Sentinel) through the Data Collector API
+type AlertProvider struct {
+	// WorkspaceID is the ID of the Log Analytics workspace
+	WorkspaceID string `yaml:"workspace-id"`
+
+	// SharedKey is the primary or secondary key of the workspace, which is base64-encoded
+	SharedKey string `yaml:"shared-key"`
+
+	// LogType is the name of the custom log the records are stored in, to which Log Analytics appends _CL
+	//
+	// Defaults to DefaultLogType
+	LogType string `yaml:"log-type,omitempty"`
+
+	// URL is the URL to send the records to instead of the Data Collector API of the workspace, such as the one of a
+	// sovereign cloud or of a log sink that accepts the same requests
+	//
+	// Defaults to https://<WorkspaceID>.ods.opinsights.azure.com/api/logs?api-version=2016-04-01
+	URL string `yaml:"url,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	if len(provider.WorkspaceID) == 0 || len(provider.SharedKey) == 0 {
+		return false
+	}
+	if _, err := base64.StdEncoding.DecodeString(provider.SharedKey); err != nil {
+		return false
+	}
+	if len(provider.LogType) > 0 && !logTypeRegex.MatchString(provider.LogType) {
+		return false
+	}
+	if len(provider.URL) > 0 {
+		parsedURL, err := url.Parse(provider.URL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	request, err := provider.buildRequest(endpoint, alert, result, resolved, time.Now())
+	if err != nil {
+		return err
+	}
+	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Record struct {
+	Timestamp   string            `json:"timestamp"`
+	Endpoint    string            `json:"endpoint"`
+	Group       string            `json:"group,omitempty"`
+	Hostname    string            `json:"hostname,omitempty"`
+	State       string            `json:"state"`
+	Message     string            `json:"message"`
+	Description string            `json:"description,omitempty"`
+	Conditions  []ConditionResult `json:"conditions"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+}
+
+// buildRequest builds the signed request sending the alert as a custom log record
+//
+// Reference: https://learn.microsoft.com/en-us/azure/azure-monitor/logs/data-collector-api
+func (provider *AlertProvider) buildRequest(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool, now time.Time) (*http.Request, error) {
+	body := provider.buildRequestBody(endpoint, alert, result, resolved, now)
+	request, err := http.NewRequest(http.MethodPost, provider.getURL(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	date := now.UTC().Format(http.TimeFormat)
+	signature, err := provider.sign(len(body), date)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Log-Type", provider.getLogType())
+	request.Header.Set("x-ms-date", date)
+	request.Header.Set("time-generated-field", "timestamp")
+	request.Header.Set("Authorization", "SharedKey "+provider.WorkspaceID+":"+signature)
+	return request, nil
+}
+
+// buildRequestBody builds the request body for the provider, which is an array with a single record
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool, now time.Time) []byte {
+	var message, state string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		state = "resolved"
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		state = "triggered"
+	}
+	conditionResults := make([]ConditionResult, 0, len(result.ConditionResults))
+	for _, conditionResult := range result.ConditionResults {
+		conditionResults = append(conditionResults, ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+		})
+	}
+	timestamp := result.Timestamp
+	if timestamp.IsZero() {
+		timestamp = now
+	}
+	body, _ := json.Marshal([]Record{{
+		Timestamp:   timestamp.UTC().Format(time.RFC3339),
+		Endpoint:    endpoint.Name,
+		Group:       endpoint.Group,
+		Hostname:    result.Hostname,
+		State:       state,
+		Message:     message,
+		Description: alert.GetDescription(),
+		Conditions:  conditionResults,
+	}})
+	return body
+}
+
+// sign returns the signature of a request with a JSON body of the length passed, sent at the date passed
+func (provider *AlertProvider) sign(contentLength int, date string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(provider.SharedKey)
+	if err != nil {
+		return "", err
+	}
+	stringToSign := http.MethodPost + "\n" + strconv.Itoa(contentLength) + "\napplication/json\nx-ms-date:" + date + "\n" + resource
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// getURL returns the URL to send the records to
+func (provider *AlertProvider) getURL() string {
+	if len(provider.URL) > 0 {
+		return provider.URL
+	}
+	return "https://" + provider.WorkspaceID + ".ods.opinsights.azure.com" + resource + "?api-version=" + apiVersion
+}
+
+// getLogType returns the log type of the records
+func (provider *AlertProvider) getLogType() string {
+	if len(provider.LogType) > 0 {
+		return provider.LogType
+	}
+	return DefaultLogType
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,253 @@
+package loganalytics
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+const (
+	workspaceID = "00000000-0000-0000-0000-000000000000"
+	sharedKey   = "c2VjcmV0" // base64 of "secret"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey},
+			Expected: true,
+		},
+		{
+			Name:     "valid-with-log-type-and-url",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey, LogType: "Gatus_Alerts", URL: "https://logs.example.com/api/logs"},
+			Expected: true,
+		},
+		{
+			Name:     "no-workspace-id",
+			Provider: AlertProvider{SharedKey: sharedKey},
+			Expected: false,
+		},
+		{
+			Name:     "no-shared-key",
+			Provider: AlertProvider{WorkspaceID: workspaceID},
+			Expected: false,
+		},
+		{
+			Name:     "shared-key-not-base64",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: "not base64!"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-log-type",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey, LogType: "gatus-alerts"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-url-scheme",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey, URL: "logs.example.com/api/logs"},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.Host != workspaceID+".ods.opinsights.azure.com" || r.URL.Path != "/api/logs" || r.Header.Get("Log-Type") != DefaultLogType {
+					return &http.Response{StatusCode: http.StatusForbidden, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey, URL: "https://logs.example.com/ingest"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.String() != "https://logs.example.com/ingest" {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-error",
+			Provider: AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequest(t *testing.T) {
+	description := "description-1"
+	provider := AlertProvider{WorkspaceID: workspaceID, SharedKey: sharedKey, LogType: "GatusAlerts"}
+	now := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	request, err := provider.buildRequest(
+		&core.Endpoint{Name: "endpoint-name", Group: "group"},
+		&alert.Alert{Description: &description, SuccessThreshold: 5, FailureThreshold: 3},
+		&core.Result{},
+		false,
+		now,
+	)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if request.URL.String() != "https://"+workspaceID+".ods.opinsights.azure.com/api/logs?api-version=2016-04-01" {
+		t.Errorf("unexpected URL %s", request.URL.String())
+	}
+	if date := request.Header.Get("x-ms-date"); date != "Mon, 02 Jan 2006 15:04:05 GMT" {
+		t.Errorf("expected x-ms-date to be Mon, 02 Jan 2006 15:04:05 GMT, got %s", date)
+	}
+	if logType := request.Header.Get("Log-Type"); logType != "GatusAlerts" {
+		t.Errorf("expected Log-Type to be GatusAlerts, got %s", logType)
+	}
+	if request.Header.Get("time-generated-field") != "timestamp" {
+		t.Errorf("expected time-generated-field to be timestamp, got %s", request.Header.Get("time-generated-field"))
+	}
+	expectedSignature, _ := provider.sign(int(request.ContentLength), "Mon, 02 Jan 2006 15:04:05 GMT")
+	if authorization := request.Header.Get("Authorization"); authorization != "SharedKey "+workspaceID+":"+expectedSignature {
+		t.Errorf("unexpected Authorization header %s", authorization)
+	}
+}
+
+func TestAlertProvider_sign(t *testing.T) {
+	signature, err := (&AlertProvider{SharedKey: sharedKey}).sign(100, "Mon, 02 Jan 2006 15:04:05 GMT")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if expected := "5q9Q3A+1+gN0EIS7GEHx4MJ9wDkuyYwushhrNkS9wEc="; signature != expected {
+		t.Errorf("expected signature %s, got %s", expected, signature)
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "[{\"timestamp\":\"2006-01-02T15:04:05Z\",\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"state\":\"triggered\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\",\"description\":\"description-1\",\"conditions\":[{\"condition\":\"[CONNECTED] == true\",\"success\":false},{\"condition\":\"[STATUS] == 200\",\"success\":false}]}]",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "[{\"timestamp\":\"2006-01-02T15:04:05Z\",\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"state\":\"resolved\",\"message\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\",\"description\":\"description-2\",\"conditions\":[{\"condition\":\"[CONNECTED] == true\",\"success\":true},{\"condition\":\"[STATUS] == 200\",\"success\":true}]}]",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+				time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			var out []map[string]interface{}
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
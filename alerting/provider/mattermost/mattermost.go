@@ -12,10 +12,24 @@ import (
 	"github.com/TwiN/gatus/v5/core"
 )
 
+const (
+	defaultUsername = "gatus"
+	defaultIconURL  = "https://raw.githubusercontent.com/TwiN/gatus/master/.github/assets/logo.png"
+)
+
 // AlertProvider is the configuration necessary for sending an alert using Mattermost
 type AlertProvider struct {
 	WebhookURL string `yaml:"webhook-url"`
 
+	// Channel is the channel to post the alerts to (optional, defaults to the webhook's channel)
+	Channel string `yaml:"channel,omitempty"`
+
+	// Username is the username to post the alerts with (optional, defaults to gatus)
+	Username string `yaml:"username,omitempty"`
+
+	// IconURL is the URL of the icon to post the alerts with (optional, defaults to Gatus' logo)
+	IconURL string `yaml:"icon-url,omitempty"`
+
 	// ClientConfig is the configuration of the client used to communicate with the provider's target
 	ClientConfig *client.Config `yaml:"client,omitempty"`
 
@@ -27,9 +41,14 @@ type AlertProvider struct {
 }
 
 // Override is a case under which the default integration is overridden
+//
+// Fields left empty fall back on the provider's configuration.
 type Override struct {
 	Group      string `yaml:"group"`
 	WebhookURL string `yaml:"webhook-url"`
+	Channel    string `yaml:"channel,omitempty"`
+	Username   string `yaml:"username,omitempty"`
+	IconURL    string `yaml:"icon-url,omitempty"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -40,7 +59,7 @@ func (provider *AlertProvider) IsValid() bool {
 	if provider.Overrides != nil {
 		registeredGroups := make(map[string]bool)
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || (len(override.WebhookURL) == 0 && len(override.Channel) == 0 && len(override.Username) == 0 && len(override.IconURL) == 0) {
 				return false
 			}
 			registeredGroups[override.Group] = true
@@ -73,6 +92,7 @@ type Body struct {
 	Text        string       `json:"text"`
 	Username    string       `json:"username"`
 	IconURL     string       `json:"icon_url"`
+	Channel     string       `json:"channel,omitempty"`
 	Attachments []Attachment `json:"attachments"`
 }
 
@@ -116,8 +136,9 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	}
 	body, _ := json.Marshal(Body{
 		Text:     "",
-		Username: "gatus",
-		IconURL:  "https://raw.githubusercontent.com/TwiN/gatus/master/.github/assets/logo.png",
+		Username: provider.getUsernameForGroup(endpoint.Group),
+		IconURL:  provider.getIconURLForGroup(endpoint.Group),
+		Channel:  provider.getChannelForGroup(endpoint.Group),
 		Attachments: []Attachment{
 			{
 				Title:    ":helmet_with_white_cross: Gatus",
@@ -140,16 +161,52 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 
 // getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
 func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
-	if provider.Overrides != nil {
-		for _, override := range provider.Overrides {
-			if group == override.Group {
-				return override.WebhookURL
-			}
-		}
+	if override := provider.getOverrideForGroup(group); override != nil && len(override.WebhookURL) > 0 {
+		return override.WebhookURL
 	}
 	return provider.WebhookURL
 }
 
+// getChannelForGroup returns the appropriate channel for a given group
+func (provider *AlertProvider) getChannelForGroup(group string) string {
+	if override := provider.getOverrideForGroup(group); override != nil && len(override.Channel) > 0 {
+		return override.Channel
+	}
+	return provider.Channel
+}
+
+// getUsernameForGroup returns the appropriate username for a given group
+func (provider *AlertProvider) getUsernameForGroup(group string) string {
+	if override := provider.getOverrideForGroup(group); override != nil && len(override.Username) > 0 {
+		return override.Username
+	}
+	if len(provider.Username) > 0 {
+		return provider.Username
+	}
+	return defaultUsername
+}
+
+// getIconURLForGroup returns the appropriate icon URL for a given group
+func (provider *AlertProvider) getIconURLForGroup(group string) string {
+	if override := provider.getOverrideForGroup(group); override != nil && len(override.IconURL) > 0 {
+		return override.IconURL
+	}
+	if len(provider.IconURL) > 0 {
+		return provider.IconURL
+	}
+	return defaultIconURL
+}
+
+// getOverrideForGroup returns the override for a given group, or nil if there is none
+func (provider *AlertProvider) getOverrideForGroup(group string) *Override {
+	for i := range provider.Overrides {
+		if group == provider.Overrides[i].Group {
+			return &provider.Overrides[i]
+		}
+	}
+	return nil
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
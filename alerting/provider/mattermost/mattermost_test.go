@@ -61,6 +61,19 @@ func TestAlertProvider_IsValidWithOverride(t *testing.T) {
 	if !providerWithValidOverride.IsValid() {
 		t.Error("provider should've been valid")
 	}
+
+	providerWithChannelOnlyOverride := AlertProvider{
+		WebhookURL: "http://example.com",
+		Overrides: []Override{
+			{
+				Channel: "channel",
+				Group:   "group",
+			},
+		},
+	}
+	if !providerWithChannelOnlyOverride.IsValid() {
+		t.Error("provider should've been valid")
+	}
 }
 
 func TestAlertProvider_Send(t *testing.T) {
@@ -189,6 +202,65 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildRequestBodyWithChannelUsernameAndIconURL(t *testing.T) {
+	provider := AlertProvider{
+		WebhookURL: "http://example.com",
+		Channel:    "alerts",
+		Username:   "status-bot",
+		Overrides: []Override{
+			{
+				Group:    "core",
+				Channel:  "core-alerts",
+				Username: "core-bot",
+				IconURL:  "https://example.com/core.png",
+			},
+		},
+	}
+	scenarios := []struct {
+		Name             string
+		Group            string
+		ExpectedChannel  string
+		ExpectedUsername string
+		ExpectedIconURL  string
+	}{
+		{
+			Name:             "no-override",
+			Group:            "",
+			ExpectedChannel:  "alerts",
+			ExpectedUsername: "status-bot",
+			ExpectedIconURL:  defaultIconURL,
+		},
+		{
+			Name:             "override",
+			Group:            "core",
+			ExpectedChannel:  "core-alerts",
+			ExpectedUsername: "core-bot",
+			ExpectedIconURL:  "https://example.com/core.png",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := provider.buildRequestBody(&core.Endpoint{Name: "endpoint-name", Group: scenario.Group}, &alert.Alert{FailureThreshold: 3}, &core.Result{}, false)
+			var out Body
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if out.Channel != scenario.ExpectedChannel {
+				t.Errorf("expected channel to be %s, got %s", scenario.ExpectedChannel, out.Channel)
+			}
+			if out.Username != scenario.ExpectedUsername {
+				t.Errorf("expected username to be %s, got %s", scenario.ExpectedUsername, out.Username)
+			}
+			if out.IconURL != scenario.ExpectedIconURL {
+				t.Errorf("expected icon URL to be %s, got %s", scenario.ExpectedIconURL, out.IconURL)
+			}
+			if webhookURL := provider.getWebhookURLForGroup(scenario.Group); webhookURL != "http://example.com" {
+				t.Errorf("expected webhook URL to fall back on the provider's, got %s", webhookURL)
+			}
+		})
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -3,9 +3,12 @@ package messagebird
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -13,7 +16,11 @@ import (
 )
 
 const (
-	restAPIURL = "https://rest.messagebird.com/messages"
+	restAPIURL      = "https://rest.messagebird.com/messages"
+	voiceRestAPIURL = "https://rest.messagebird.com/voicemessages"
+
+	// DefaultVoiceLanguage is the default language used to read the message during voice calls
+	DefaultVoiceLanguage = "en-us"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Messagebird
@@ -22,20 +29,70 @@ type AlertProvider struct {
 	Originator string `yaml:"originator"`
 	Recipients string `yaml:"recipients"`
 
+	// Voice defines whether to also call the recipients when an alert with a critical severity is triggered, in which
+	// case the alert is read using text-to-speech. Resolved alerts are only sent by SMS.
+	//
+	// Requires the originator to be a phone number.
+	Voice bool `yaml:"voice,omitempty"`
+
+	// VoiceLanguage is the language used to read the message during voice calls. Defaults to DefaultVoiceLanguage
+	VoiceLanguage string `yaml:"voice-language,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 }
 
 // IsValid returns whether the provider's configuration is valid
 func (provider *AlertProvider) IsValid() bool {
+	// Unlike SMS, voice messages cannot be sent with an alphanumeric originator
+	if provider.Voice && !isPhoneNumber(provider.Originator) {
+		return false
+	}
 	return len(provider.AccessKey) > 0 && len(provider.Originator) > 0 && len(provider.Recipients) > 0
 }
 
+// isPhoneNumber returns whether the value passed is a phone number in international format, with or without the
+// leading +
+func isPhoneNumber(value string) bool {
+	value = strings.TrimPrefix(value, "+")
+	if len(value) == 0 {
+		return false
+	}
+	for _, character := range value {
+		if character < '0' || character > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // Send an alert using the provider
 // Reference doc for messagebird: https://developers.messagebird.com/api/sms-messaging/#send-outbound-sms
+//
+// If voice is enabled, triggered alerts with a critical severity also result in a voice call.
+// Reference doc: https://developers.messagebird.com/api/voice-messaging/#send-a-voice-message
+//
+// The alert is considered sent as long as either the SMS or the call went through, since returning an error would
+// cause both of them to be sent again on the next evaluation of the endpoint.
 func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
-	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, restAPIURL, buffer)
+	err := provider.send(restAPIURL, provider.buildRequestBody(endpoint, alert, result, resolved))
+	if !provider.Voice || resolved || !alert.IsCritical() {
+		return err
+	}
+	// Call even if the SMS could not be sent, since the call is what's most likely to get someone's attention
+	voiceErr := provider.send(voiceRestAPIURL, provider.buildVoiceRequestBody(endpoint, alert))
+	if err != nil && voiceErr != nil {
+		return errors.Join(err, fmt.Errorf("failed to place voice call: %w", voiceErr))
+	} else if err != nil {
+		log.Printf("[messagebird][Send] Failed to send SMS, but the voice call was placed: %s", err.Error())
+	} else if voiceErr != nil {
+		log.Printf("[messagebird][Send] Failed to place voice call, but the SMS was sent: %s", voiceErr.Error())
+	}
+	return nil
+}
+
+func (provider *AlertProvider) send(url string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -75,6 +132,36 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	return body
 }
 
+type VoiceBody struct {
+	Originator string `json:"originator"`
+	Recipients string `json:"recipients"`
+	Body       string `json:"body"`
+	Language   string `json:"language"`
+}
+
+// buildVoiceRequestBody builds the request body for a voice call
+func (provider *AlertProvider) buildVoiceRequestBody(endpoint *core.Endpoint, alert *alert.Alert) []byte {
+	message := fmt.Sprintf("This is an alert from Gatus. %s is unhealthy.", endpoint.DisplayName())
+	if description := alert.GetDescription(); len(description) > 0 {
+		message += " " + description + "."
+	}
+	body, _ := json.Marshal(VoiceBody{
+		Originator: provider.Originator,
+		Recipients: provider.Recipients,
+		Body:       message,
+		Language:   provider.getVoiceLanguage(),
+	})
+	return body
+}
+
+// getVoiceLanguage returns the language used to read the message during voice calls
+func (provider *AlertProvider) getVoiceLanguage() string {
+	if len(provider.VoiceLanguage) == 0 {
+		return DefaultVoiceLanguage
+	}
+	return provider.VoiceLanguage
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
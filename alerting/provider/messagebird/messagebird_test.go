@@ -26,6 +26,20 @@ func TestMessagebirdAlertProvider_IsValid(t *testing.T) {
 	}
 }
 
+func TestMessagebirdAlertProvider_IsValidWithVoice(t *testing.T) {
+	providerWithAlphanumericOriginator := AlertProvider{AccessKey: "1", Originator: "Gatus", Recipients: "31619191919", Voice: true}
+	if providerWithAlphanumericOriginator.IsValid() {
+		t.Error("provider shouldn't have been valid, because voice calls require the originator to be a phone number")
+	}
+	validProvider := AlertProvider{AccessKey: "1", Originator: "+31619191918", Recipients: "31619191919", Voice: true}
+	if !validProvider.IsValid() {
+		t.Error("provider should've been valid")
+	}
+	if len(validProvider.VoiceLanguage) != 0 {
+		t.Errorf("expected the voice language to be left untouched by the validation, got %s", validProvider.VoiceLanguage)
+	}
+}
+
 func TestAlertProvider_Send(t *testing.T) {
 	defer client.InjectHTTPClient(nil)
 	firstDescription := "description-1"
@@ -103,6 +117,120 @@ func TestAlertProvider_Send(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_SendWithVoice(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	description := "description-1"
+	scenarios := []struct {
+		Name                  string
+		Resolved              bool
+		Severity              string
+		SMSStatusCode         int
+		VoiceStatusCode       int
+		ExpectedRequestedURLs []string
+		ExpectedError         bool
+	}{
+		{
+			Name:                  "triggered-critical",
+			Severity:              alert.SeverityCritical,
+			SMSStatusCode:         http.StatusOK,
+			VoiceStatusCode:       http.StatusOK,
+			ExpectedRequestedURLs: []string{restAPIURL, voiceRestAPIURL},
+		},
+		{
+			Name:                  "triggered-critical-with-voice-error",
+			Severity:              alert.SeverityCritical,
+			SMSStatusCode:         http.StatusOK,
+			VoiceStatusCode:       http.StatusUnprocessableEntity,
+			ExpectedRequestedURLs: []string{restAPIURL, voiceRestAPIURL},
+		},
+		{
+			Name:                  "triggered-critical-with-sms-error",
+			Severity:              alert.SeverityCritical,
+			SMSStatusCode:         http.StatusUnprocessableEntity,
+			VoiceStatusCode:       http.StatusOK,
+			ExpectedRequestedURLs: []string{restAPIURL, voiceRestAPIURL},
+		},
+		{
+			Name:                  "triggered-critical-with-sms-and-voice-errors",
+			Severity:              alert.SeverityCritical,
+			SMSStatusCode:         http.StatusUnprocessableEntity,
+			VoiceStatusCode:       http.StatusUnprocessableEntity,
+			ExpectedRequestedURLs: []string{restAPIURL, voiceRestAPIURL},
+			ExpectedError:         true,
+		},
+		{
+			Name:                  "triggered-warning",
+			Severity:              "warning",
+			SMSStatusCode:         http.StatusOK,
+			VoiceStatusCode:       http.StatusOK,
+			ExpectedRequestedURLs: []string{restAPIURL},
+		},
+		{
+			Name:                  "triggered-warning-with-sms-error",
+			Severity:              "warning",
+			SMSStatusCode:         http.StatusUnprocessableEntity,
+			VoiceStatusCode:       http.StatusOK,
+			ExpectedRequestedURLs: []string{restAPIURL},
+			ExpectedError:         true,
+		},
+		{
+			Name:                  "resolved-critical",
+			Resolved:              true,
+			Severity:              alert.SeverityCritical,
+			SMSStatusCode:         http.StatusOK,
+			VoiceStatusCode:       http.StatusOK,
+			ExpectedRequestedURLs: []string{restAPIURL},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var requestedURLs []string
+			client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				requestedURLs = append(requestedURLs, r.URL.String())
+				if r.URL.String() == voiceRestAPIURL {
+					return &http.Response{StatusCode: scenario.VoiceStatusCode, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: scenario.SMSStatusCode, Body: http.NoBody}
+			})})
+			provider := AlertProvider{AccessKey: "1", Originator: "31619191918", Recipients: "31619191919", Voice: true}
+			err := provider.Send(&core.Endpoint{Name: "endpoint-name"}, &alert.Alert{Description: &description, Severity: scenario.Severity}, &core.Result{}, scenario.Resolved)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if len(requestedURLs) != len(scenario.ExpectedRequestedURLs) {
+				t.Fatalf("expected requests to %v, got %v", scenario.ExpectedRequestedURLs, requestedURLs)
+			}
+			for i := range requestedURLs {
+				if requestedURLs[i] != scenario.ExpectedRequestedURLs[i] {
+					t.Errorf("expected requests to %v, got %v", scenario.ExpectedRequestedURLs, requestedURLs)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildVoiceRequestBody(t *testing.T) {
+	description := "description-1"
+	provider := AlertProvider{AccessKey: "1", Originator: "2", Recipients: "3", Voice: true, VoiceLanguage: "en-gb"}
+	body := provider.buildVoiceRequestBody(&core.Endpoint{Name: "endpoint-name", Group: "core"}, &alert.Alert{Description: &description})
+	expectedBody := "{\"originator\":\"2\",\"recipients\":\"3\",\"body\":\"This is an alert from Gatus. core/endpoint-name is unhealthy. description-1.\",\"language\":\"en-gb\"}"
+	if string(body) != expectedBody {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedBody, body)
+	}
+	// Without a voice language, the default one must be used
+	provider.VoiceLanguage = ""
+	var voiceBody VoiceBody
+	if err := json.Unmarshal(provider.buildVoiceRequestBody(&core.Endpoint{Name: "endpoint-name"}, &alert.Alert{}), &voiceBody); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if voiceBody.Language != DefaultVoiceLanguage {
+		t.Errorf("expected language to be %s, got %s", DefaultVoiceLanguage, voiceBody.Language)
+	}
+}
+
 func TestAlertProvider_buildRequestBody(t *testing.T) {
 	firstDescription := "description-1"
 	secondDescription := "description-2"
@@ -2,12 +2,18 @@ package provider
 
 import (
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/alertmanager"
+	"github.com/TwiN/gatus/v5/alerting/provider/amqp"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/alerting/provider/datadog"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
 	"github.com/TwiN/gatus/v5/alerting/provider/github"
 	"github.com/TwiN/gatus/v5/alerting/provider/gitlab"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
+	"github.com/TwiN/gatus/v5/alerting/provider/gotify"
+	"github.com/TwiN/gatus/v5/alerting/provider/line"
+	"github.com/TwiN/gatus/v5/alerting/provider/loganalytics"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
@@ -15,10 +21,14 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/signal"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/alerting/provider/splunk"
+	"github.com/TwiN/gatus/v5/alerting/provider/squadcast"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/victorops"
 	"github.com/TwiN/gatus/v5/alerting/provider/wecom"
 	"github.com/TwiN/gatus/v5/core"
 )
@@ -35,6 +45,18 @@ type AlertProvider interface {
 	Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error
 }
 
+// DigestProvider is the interface that providers supporting digests should implement
+//
+// When enough endpoints of a group fail at once, the alerts of providers implementing this interface are replaced by a
+// single digest summarizing the state of the group.
+type DigestProvider interface {
+	// GetDigestConfig returns the digest configuration of the provider, or nil if digests are disabled
+	GetDigestConfig() *alert.DigestConfig
+
+	// SendDigest sends a digest using the provider
+	SendDigest(digest *alert.Digest) error
+}
+
 // ParseWithDefaultAlert parses an Endpoint alert by using the provider's default alert as a baseline
 func ParseWithDefaultAlert(providerDefaultAlert, endpointAlert *alert.Alert) {
 	if providerDefaultAlert == nil || endpointAlert == nil {
@@ -55,16 +77,37 @@ func ParseWithDefaultAlert(providerDefaultAlert, endpointAlert *alert.Alert) {
 	if endpointAlert.SuccessThreshold == 0 {
 		endpointAlert.SuccessThreshold = providerDefaultAlert.SuccessThreshold
 	}
+	if len(endpointAlert.Severity) == 0 {
+		endpointAlert.Severity = providerDefaultAlert.Severity
+	}
+	if endpointAlert.Priority == nil {
+		endpointAlert.Priority = providerDefaultAlert.Priority
+	}
+	if !endpointAlert.IncludeBodyExcerpt {
+		endpointAlert.IncludeBodyExcerpt = providerDefaultAlert.IncludeBodyExcerpt
+	}
+	if endpointAlert.BodyExcerptMaxLength == 0 {
+		endpointAlert.BodyExcerptMaxLength = providerDefaultAlert.BodyExcerptMaxLength
+	}
+	if endpointAlert.ReminderInterval == 0 {
+		endpointAlert.ReminderInterval = providerDefaultAlert.ReminderInterval
+	}
 }
 
 var (
 	// Validate interface implementation on compile
+	_ AlertProvider = (*alertmanager.AlertProvider)(nil)
+	_ AlertProvider = (*amqp.AlertProvider)(nil)
 	_ AlertProvider = (*custom.AlertProvider)(nil)
+	_ AlertProvider = (*datadog.AlertProvider)(nil)
 	_ AlertProvider = (*discord.AlertProvider)(nil)
 	_ AlertProvider = (*email.AlertProvider)(nil)
 	_ AlertProvider = (*github.AlertProvider)(nil)
 	_ AlertProvider = (*gitlab.AlertProvider)(nil)
 	_ AlertProvider = (*googlechat.AlertProvider)(nil)
+	_ AlertProvider = (*gotify.AlertProvider)(nil)
+	_ AlertProvider = (*line.AlertProvider)(nil)
+	_ AlertProvider = (*loganalytics.AlertProvider)(nil)
 	_ AlertProvider = (*matrix.AlertProvider)(nil)
 	_ AlertProvider = (*mattermost.AlertProvider)(nil)
 	_ AlertProvider = (*messagebird.AlertProvider)(nil)
@@ -72,9 +115,15 @@ var (
 	_ AlertProvider = (*opsgenie.AlertProvider)(nil)
 	_ AlertProvider = (*pagerduty.AlertProvider)(nil)
 	_ AlertProvider = (*pushover.AlertProvider)(nil)
+	_ AlertProvider = (*signal.AlertProvider)(nil)
 	_ AlertProvider = (*slack.AlertProvider)(nil)
+	_ AlertProvider = (*splunk.AlertProvider)(nil)
+	_ AlertProvider = (*squadcast.AlertProvider)(nil)
 	_ AlertProvider = (*teams.AlertProvider)(nil)
 	_ AlertProvider = (*telegram.AlertProvider)(nil)
 	_ AlertProvider = (*twilio.AlertProvider)(nil)
+	_ AlertProvider = (*victorops.AlertProvider)(nil)
 	_ AlertProvider = (*wecom.AlertProvider)(nil)
+
+	_ DigestProvider = (*wecom.AlertProvider)(nil)
 )
@@ -148,6 +148,9 @@ func TestParseWithDefaultAlert(t *testing.T) {
 			if scenario.EndpointAlert.SuccessThreshold != scenario.ExpectedOutputAlert.SuccessThreshold {
 				t.Errorf("expected EndpointAlert.SuccessThreshold to be %v, got %v", scenario.ExpectedOutputAlert.SuccessThreshold, scenario.EndpointAlert.SuccessThreshold)
 			}
+			if scenario.EndpointAlert.Severity != scenario.ExpectedOutputAlert.Severity {
+				t.Errorf("expected EndpointAlert.Severity to be %v, got %v", scenario.ExpectedOutputAlert.Severity, scenario.EndpointAlert.Severity)
+			}
 		})
 	}
 }
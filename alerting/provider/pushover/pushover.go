@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -15,6 +17,24 @@ import (
 const (
 	restAPIURL      = "https://api.pushover.net/1/messages.json"
 	defaultPriority = 0
+
+	// EmergencyPriority is the priority of messages that must be acknowledged by the user, and that are repeated until
+	// they are or until they expire
+	EmergencyPriority = 2
+
+	// MinimumRetry is the minimum interval at which Pushover accepts to repeat an emergency message
+	MinimumRetry = 30 * time.Second
+
+	// MaximumExpire is the maximum duration during which Pushover accepts to repeat an emergency message
+	MaximumExpire = 3 * time.Hour
+
+	// DefaultCriticalRetry is the interval at which the emergency messages of critical alerts are repeated if Retry is
+	// not set
+	DefaultCriticalRetry = time.Minute
+
+	// DefaultCriticalExpire is the duration during which the emergency messages of critical alerts are repeated if
+	// Expire is not set
+	DefaultCriticalExpire = time.Hour
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Pushover
@@ -34,10 +54,25 @@ type AlertProvider struct {
 	// default: 0
 	Priority int `yaml:"priority,omitempty"`
 
+	// Severities is a mapping of alert severities (e.g. critical) to the priority of the messages sent when alerts of
+	// that severity are triggered, which takes precedence over Priority.
+	// Unless mapped, triggered alerts with a severity of alert.SeverityCritical are sent with EmergencyPriority.
+	Severities map[string]int `yaml:"severities,omitempty"`
+
 	// Sound of the messages (see: https://pushover.net/api#sounds)
 	// default: "" (pushover)
 	Sound string `yaml:"sound,omitempty"`
 
+	// Retry is how often emergency messages are repeated until they are acknowledged
+	// Required if Priority or one of the Severities is EmergencyPriority, and must be at least MinimumRetry.
+	// default: DefaultCriticalRetry for critical alerts
+	Retry time.Duration `yaml:"retry,omitempty"`
+
+	// Expire is how long emergency messages are repeated for if they are not acknowledged
+	// Required if Priority or one of the Severities is EmergencyPriority, and must not exceed MaximumExpire.
+	// default: DefaultCriticalExpire for critical alerts
+	Expire time.Duration `yaml:"expire,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 }
@@ -47,7 +82,27 @@ func (provider *AlertProvider) IsValid() bool {
 	if provider.Priority == 0 {
 		provider.Priority = defaultPriority
 	}
-	return len(provider.ApplicationToken) == 30 && len(provider.UserKey) == 30 && provider.Priority >= -2 && provider.Priority <= 2
+	isEmergencyPriorityConfigured := provider.Priority == EmergencyPriority
+	for _, priority := range provider.Severities {
+		if !isValidPriority(priority) {
+			return false
+		}
+		if priority == EmergencyPriority {
+			isEmergencyPriorityConfigured = true
+		}
+	}
+	if isEmergencyPriorityConfigured && (provider.Retry == 0 || provider.Expire == 0) {
+		return false
+	}
+	if (provider.Retry != 0 && provider.Retry < MinimumRetry) || provider.Expire < 0 || provider.Expire > MaximumExpire {
+		return false
+	}
+	return len(provider.ApplicationToken) == 30 && len(provider.UserKey) == 30 && isValidPriority(provider.Priority)
+}
+
+// isValidPriority returns whether the priority is within the range accepted by Pushover
+func isValidPriority(priority int) bool {
+	return priority >= -2 && priority <= EmergencyPriority
 }
 
 // Send an alert using the provider
@@ -78,6 +133,8 @@ type Body struct {
 	Message  string `json:"message"`
 	Priority int    `json:"priority"`
 	Sound    string `json:"sound,omitempty"`
+	Retry    int    `json:"retry,omitempty"`
+	Expire   int    `json:"expire,omitempty"`
 }
 
 // buildRequestBody builds the request body for the provider
@@ -88,24 +145,59 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	} else {
 		message = fmt.Sprintf("TRIGGERED: %s - %s", endpoint.DisplayName(), alert.GetDescription())
 	}
-	body, _ := json.Marshal(Body{
+	body := Body{
 		Token:    provider.ApplicationToken,
 		User:     provider.UserKey,
 		Title:    provider.Title,
 		Message:  message,
-		Priority: provider.priority(),
+		Priority: provider.getPriority(alert, resolved),
 		Sound:    provider.Sound,
-	})
-	return body
+	}
+	if body.Priority == EmergencyPriority {
+		body.Retry = int(provider.getRetry(alert).Seconds())
+		body.Expire = int(provider.getExpire(alert).Seconds())
+	}
+	output, _ := json.Marshal(body)
+	return output
 }
 
-func (provider *AlertProvider) priority() int {
+// getPriority returns the priority of the message for the given alert and alert state
+//
+// The priority mapped to the severity of the alert, if any, only applies to the message sent when the alert is
+// triggered.
+func (provider *AlertProvider) getPriority(alert *alert.Alert, resolved bool) int {
+	if !resolved && len(alert.Severity) > 0 {
+		for severity, priority := range provider.Severities {
+			if strings.EqualFold(severity, alert.Severity) {
+				return priority
+			}
+		}
+		if alert.IsCritical() {
+			return EmergencyPriority
+		}
+	}
 	if provider.Priority == 0 {
 		return defaultPriority
 	}
 	return provider.Priority
 }
 
+// getRetry returns how often the emergency message sent for the given alert is repeated
+func (provider *AlertProvider) getRetry(alert *alert.Alert) time.Duration {
+	if provider.Retry == 0 && alert.IsCritical() {
+		return DefaultCriticalRetry
+	}
+	return provider.Retry
+}
+
+// getExpire returns how long the emergency message sent for the given alert is repeated for
+func (provider *AlertProvider) getExpire(alert *alert.Alert) time.Duration {
+	if provider.Expire == 0 && alert.IsCritical() {
+		return DefaultCriticalExpire
+	}
+	return provider.Expire
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
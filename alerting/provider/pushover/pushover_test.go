@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
@@ -38,6 +39,66 @@ func TestPushoverAlertProvider_IsInvalid(t *testing.T) {
 	}
 }
 
+func TestPushoverAlertProvider_IsValidWithEmergencyPriority(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Retry    time.Duration
+		Expire   time.Duration
+		Expected bool
+	}{
+		{Name: "with-retry-and-expire", Retry: time.Minute, Expire: time.Hour, Expected: true},
+		{Name: "without-retry-and-expire", Expected: false},
+		{Name: "without-expire", Retry: time.Minute, Expected: false},
+		{Name: "with-retry-lower-than-minimum", Retry: 10 * time.Second, Expire: time.Hour, Expected: false},
+		{Name: "with-expire-higher-than-maximum", Retry: time.Minute, Expire: 4 * time.Hour, Expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			provider := AlertProvider{
+				ApplicationToken: "aTokenWithLengthOf30characters",
+				UserKey:          "aTokenWithLengthOf30characters",
+				Priority:         EmergencyPriority,
+				Retry:            scenario.Retry,
+				Expire:           scenario.Expire,
+			}
+			if provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestPushoverAlertProvider_IsValidWithSeverities(t *testing.T) {
+	scenarios := []struct {
+		Name       string
+		Severities map[string]int
+		Retry      time.Duration
+		Expire     time.Duration
+		Expected   bool
+	}{
+		{Name: "with-severities", Severities: map[string]int{"critical": 1, "info": -1}, Expected: true},
+		{Name: "with-severity-priority-too-high", Severities: map[string]int{"critical": 3}, Expected: false},
+		{Name: "with-emergency-severity-and-retry-and-expire", Severities: map[string]int{"warning": EmergencyPriority}, Retry: time.Minute, Expire: time.Hour, Expected: true},
+		{Name: "with-emergency-severity-without-retry-and-expire", Severities: map[string]int{"warning": EmergencyPriority}, Expected: false},
+		{Name: "without-severities-and-with-retry-lower-than-minimum", Retry: 10 * time.Second, Expected: false},
+		{Name: "without-severities-and-with-expire-higher-than-maximum", Expire: 4 * time.Hour, Expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			provider := AlertProvider{
+				ApplicationToken: "aTokenWithLengthOf30characters",
+				UserKey:          "aTokenWithLengthOf30characters",
+				Severities:       scenario.Severities,
+				Retry:            scenario.Retry,
+				Expire:           scenario.Expire,
+			}
+			if provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, provider.IsValid())
+			}
+		})
+	}
+}
+
 func TestAlertProvider_Send(t *testing.T) {
 	defer client.InjectHTTPClient(nil)
 	firstDescription := "description-1"
@@ -146,6 +207,55 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     true,
 			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters2\",\"user\":\"TokenWithLengthOf30Characters5\",\"title\":\"Gatus Notifications\",\"message\":\"RESOLVED: endpoint-name - description-2\",\"priority\":2,\"sound\":\"falling\"}",
 		},
+		{
+			Name:         "triggered-emergency",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4", Priority: 2, Sound: "siren", Retry: time.Minute, Expire: time.Hour},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":2,\"sound\":\"siren\",\"retry\":60,\"expire\":3600}",
+		},
+		{
+			Name:         "retry-and-expire-ignored-without-emergency-priority",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4", Priority: 1, Retry: time.Minute, Expire: time.Hour},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":1}",
+		},
+		{
+			Name:         "triggered-critical-with-default-retry-and-expire",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "critical"},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":2,\"retry\":60,\"expire\":3600}",
+		},
+		{
+			Name:         "triggered-critical-with-retry-and-expire",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4", Retry: 30 * time.Second, Expire: 2 * time.Hour},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "critical"},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":2,\"retry\":30,\"expire\":7200}",
+		},
+		{
+			Name:         "resolved-critical",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "critical"},
+			Resolved:     true,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"RESOLVED: endpoint-name - description-1\",\"priority\":0}",
+		},
+		{
+			Name:         "triggered-critical-with-mapped-severity",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4", Severities: map[string]int{"critical": 1}},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "Critical"},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":1}",
+		},
+		{
+			Name:         "triggered-emergency-with-mapped-severity",
+			Provider:     AlertProvider{ApplicationToken: "TokenWithLengthOf30Characters1", UserKey: "TokenWithLengthOf30Characters4", Severities: map[string]int{"warning": 2}, Retry: 5 * time.Minute, Expire: 3 * time.Hour},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "warning"},
+			Resolved:     false,
+			ExpectedBody: "{\"token\":\"TokenWithLengthOf30Characters1\",\"user\":\"TokenWithLengthOf30Characters4\",\"message\":\"TRIGGERED: endpoint-name - description-1\",\"priority\":2,\"retry\":300,\"expire\":10800}",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
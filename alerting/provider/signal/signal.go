@@ -0,0 +1,136 @@
+package signal
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// sendPath is the path of signal-cli-rest-api's endpoint used to send messages
+	sendPath = "/v2/send"
+
+	// groupRecipientPrefix is the prefix signal-cli-rest-api expects for recipients that are groups
+	groupRecipientPrefix = "group."
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Signal through signal-cli-rest-api
+type AlertProvider struct {
+	// URL is the base URL of the signal-cli-rest-api instance (e.g. http://signal-cli-rest-api:8080)
+	URL string `yaml:"url"`
+
+	// Number is the phone number of the Signal account registered with signal-cli-rest-api
+	Number string `yaml:"number"`
+
+	// Recipients is the list of phone numbers to send the alerts to
+	Recipients []string `yaml:"recipients,omitempty"`
+
+	// Groups is the list of IDs of the groups to send the alerts to, as returned by signal-cli-rest-api's
+	// /v1/groups/{number} endpoint
+	Groups []string `yaml:"groups,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	if len(provider.Number) == 0 || len(provider.Recipients)+len(provider.Groups) == 0 {
+		return false
+	}
+	parsedURL, err := url.Parse(provider.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return false
+	}
+	return true
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(provider.URL, "/")+sendPath, buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	Message    string   `json:"message"`
+	Number     string   `json:"number"`
+	Recipients []string `json:"recipients"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var message, results string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		results += fmt.Sprintf("\n%s - %s", prefix, conditionResult.Condition)
+	}
+	text := "⛑ Gatus\n" + message
+	if description := alert.GetDescription(); len(description) > 0 {
+		text += "\n\nDescription:\n" + description
+	}
+	if len(results) > 0 {
+		text += "\n\nCondition results:" + results
+	}
+	body, _ := json.Marshal(Body{
+		Message:    text,
+		Number:     provider.Number,
+		Recipients: provider.getRecipients(),
+	})
+	return body
+}
+
+// getRecipients returns the phone numbers and the groups to send the alerts to
+func (provider *AlertProvider) getRecipients() []string {
+	recipients := make([]string, 0, len(provider.Recipients)+len(provider.Groups))
+	recipients = append(recipients, provider.Recipients...)
+	for _, group := range provider.Groups {
+		if !strings.HasPrefix(group, groupRecipientPrefix) {
+			group = groupRecipientPrefix + group
+		}
+		recipients = append(recipients, group)
+	}
+	return recipients
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
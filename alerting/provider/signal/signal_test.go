@@ -0,0 +1,195 @@
+package signal
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Expected: true,
+		},
+		{
+			Name:     "valid-with-groups-only",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555", Groups: []string{"group.abc"}},
+			Expected: true,
+		},
+		{
+			Name:     "no-recipients",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555"},
+			Expected: false,
+		},
+		{
+			Name:     "no-number",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Recipients: []string{"+15555555556"}},
+			Expected: false,
+		},
+		{
+			Name:     "no-url",
+			Provider: AlertProvider{Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-url-scheme",
+			Provider: AlertProvider{URL: "signal-cli-rest-api:8080", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080/", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.URL.Path != "/v2/send" {
+					return &http.Response{StatusCode: http.StatusNotFound, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusCreated, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-error",
+			Provider: AlertProvider{URL: "http://signal-cli-rest-api:8080", Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{Number: "+15555555555", Recipients: []string{"+15555555556"}},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"message\":\"⛑ Gatus\\nAn alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\\n\\nDescription:\\ndescription-1\\n\\nCondition results:\\n❌ - [CONNECTED] == true\\n❌ - [STATUS] == 200\",\"number\":\"+15555555555\",\"recipients\":[\"+15555555556\"]}",
+		},
+		{
+			Name:         "resolved-with-groups",
+			Provider:     AlertProvider{Number: "+15555555555", Recipients: []string{"+15555555556"}, Groups: []string{"abc", "group.def"}},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"message\":\"⛑ Gatus\\nAn alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\\n\\nCondition results:\\n✅ - [CONNECTED] == true\\n✅ - [STATUS] == 200\",\"number\":\"+15555555555\",\"recipients\":[\"+15555555556\",\"group.abc\",\"group.def\"]}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
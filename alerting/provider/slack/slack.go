@@ -6,15 +6,38 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 )
 
+const apiURL = "https://slack.com/api/chat.postMessage"
+
 // AlertProvider is the configuration necessary for sending an alert using Slack
 type AlertProvider struct {
 	WebhookURL string `yaml:"webhook-url"` // Slack webhook URL
+
+	// Token is the token of the Slack bot to send messages with, instead of the webhook URL
+	Token string `yaml:"token,omitempty"`
+
+	// Channel is the channel to send messages to if Token is set
+	Channel string `yaml:"channel,omitempty"`
+
+	// ThreadResolvedAlerts determines whether resolved alerts are sent as a reply to the thread of the triggered alert.
+	// Requires Token to be set, since messages sent through a webhook URL can't be replied to.
+	ThreadResolvedAlerts bool `yaml:"thread-resolved-alerts,omitempty"`
+
+	// Mention is who to mention in the alerts sent (e.g. <!subteam^S0123456789> for a user group, <@U0123456789> for a
+	// user, <!here>, <!channel>)
+	Mention string `yaml:"mention,omitempty"`
+
+	// MentionSeverities are the severities of the alerts that mention Mention (e.g. [critical]). If empty, every alert
+	// mentions it.
+	MentionSeverities []string `yaml:"mention-severities,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 	// Overrides is a list of Override that may be prioritized over the default configuration
@@ -25,6 +48,7 @@ type AlertProvider struct {
 type Override struct {
 	Group      string `yaml:"group"`
 	WebhookURL string `yaml:"webhook-url"`
+	Channel    string `yaml:"channel,omitempty"`
 }
 
 // IsValid returns whether the provider's configuration is valid
@@ -32,17 +56,26 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" {
+				return false
+			}
+			if (len(provider.Token) == 0 && len(override.WebhookURL) == 0) || (len(provider.Token) > 0 && len(override.Channel) == 0) {
 				return false
 			}
 			registeredGroups[override.Group] = true
 		}
 	}
-	return len(provider.WebhookURL) > 0
+	if len(provider.Token) > 0 {
+		return len(provider.Channel) > 0
+	}
+	return len(provider.WebhookURL) > 0 && !provider.ThreadResolvedAlerts
 }
 
 // Send an alert using the provider
 func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	if len(provider.Token) > 0 {
+		return provider.sendWithToken(endpoint, alert, result, resolved)
+	}
 	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
 	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(endpoint.Group), buffer)
 	if err != nil {
@@ -61,11 +94,65 @@ func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert,
 	return err
 }
 
+// sendWithToken sends an alert through the chat.postMessage method of the Slack API, which, unlike webhooks, returns
+// the timestamp of the message sent. If ThreadResolvedAlerts is true, the timestamp of the triggered alert is stored
+// in the ResolveKey of the alert, so that the resolved alert can be sent as a reply to it. If the timestamp is
+// unknown (e.g. because the triggered alert was sent before Gatus was restarted), the resolved alert is sent as a new
+// message instead.
+func (provider *AlertProvider) sendWithToken(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	body := provider.buildBody(endpoint, alert, result, resolved)
+	body.Channel = provider.getChannelForGroup(endpoint.Group)
+	if resolved && provider.ThreadResolvedAlerts {
+		body.ThreadTS = alert.ResolveKey
+	}
+	payload, _ := json.Marshal(body)
+	request, err := http.NewRequest(http.MethodPost, apiURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	request.Header.Set("Authorization", "Bearer "+provider.Token)
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	responseBody, _ := io.ReadAll(response.Body)
+	if response.StatusCode > 399 {
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	var apiResponse APIResponse
+	if err = json.Unmarshal(responseBody, &apiResponse); err != nil {
+		return fmt.Errorf("failed to parse response from provider alert: %w", err)
+	}
+	if !apiResponse.OK {
+		return fmt.Errorf("call to provider alert returned error: %s", apiResponse.Error)
+	}
+	if provider.ThreadResolvedAlerts {
+		if resolved {
+			// The alert has been resolved and there's no error, so we can clear the alert's ResolveKey
+			alert.ResolveKey = ""
+		} else {
+			alert.ResolveKey = apiResponse.TS
+		}
+	}
+	return nil
+}
+
 type Body struct {
+	Channel     string       `json:"channel,omitempty"`
+	ThreadTS    string       `json:"thread_ts,omitempty"`
 	Text        string       `json:"text"`
 	Attachments []Attachment `json:"attachments"`
 }
 
+// APIResponse is the response of the chat.postMessage method of the Slack API
+type APIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+	TS    string `json:"ts,omitempty"`
+}
+
 type Attachment struct {
 	Title  string  `json:"title"`
 	Text   string  `json:"text"`
@@ -82,12 +169,18 @@ type Field struct {
 
 // buildRequestBody builds the request body for the provider
 func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	body, _ := json.Marshal(provider.buildBody(endpoint, alert, result, resolved))
+	return body
+}
+
+// buildBody builds the message sent by the provider
+func (provider *AlertProvider) buildBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) Body {
 	var message, color, results string
 	if resolved {
-		message = fmt.Sprintf("An alert for *%s* has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		message = fmt.Sprintf(i18n.Translate(i18n.KeyAlertResolvedMessage), "*"+endpoint.DisplayName()+"*", alert.SuccessThreshold)
 		color = "#36A64F"
 	} else {
-		message = fmt.Sprintf("An alert for *%s* has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		message = fmt.Sprintf(i18n.Translate(i18n.KeyAlertTriggeredMessage), "*"+endpoint.DisplayName()+"*", alert.FailureThreshold)
 		color = "#DD0000"
 	}
 	for _, conditionResult := range result.ConditionResults {
@@ -103,25 +196,36 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
 		description = ":\n> " + alertDescription
 	}
-	body, _ := json.Marshal(Body{
-		Text: "",
+	var text string
+	if len(provider.Mention) > 0 && alert.ShouldMention(provider.MentionSeverities) {
+		text = provider.Mention
+	}
+	fields := []Field{
+		{
+			Title: i18n.Translate(i18n.KeyConditionResults),
+			Value: results,
+			Short: false,
+		},
+	}
+	if bodyExcerpt := alert.GetBodyExcerpt(result.Body); !resolved && len(bodyExcerpt) > 0 {
+		fields = append(fields, Field{
+			Title: i18n.Translate(i18n.KeyBodyExcerpt),
+			Value: "```" + strings.ReplaceAll(bodyExcerpt, "`", "'") + "```",
+			Short: false,
+		})
+	}
+	return Body{
+		Text: text,
 		Attachments: []Attachment{
 			{
-				Title: ":helmet_with_white_cross: Gatus",
-				Text:  message + description,
-				Short: false,
-				Color: color,
-				Fields: []Field{
-					{
-						Title: "Condition results",
-						Value: results,
-						Short: false,
-					},
-				},
+				Title:  ":helmet_with_white_cross: Gatus",
+				Text:   message + description,
+				Short:  false,
+				Color:  color,
+				Fields: fields,
 			},
 		},
-	})
-	return body
+	}
 }
 
 // getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
@@ -136,6 +240,18 @@ func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
 	return provider.WebhookURL
 }
 
+// getChannelForGroup returns the appropriate channel for a given group
+func (provider *AlertProvider) getChannelForGroup(group string) string {
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				return override.Channel
+			}
+		}
+	}
+	return provider.Channel
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
@@ -2,7 +2,9 @@ package slack
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
@@ -136,6 +138,89 @@ func TestAlertProvider_Send(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_IsValidWithToken(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{Name: "token-and-channel", Provider: AlertProvider{Token: "xoxb-token", Channel: "#alerts"}, Expected: true},
+		{Name: "token-and-channel-with-thread", Provider: AlertProvider{Token: "xoxb-token", Channel: "#alerts", ThreadResolvedAlerts: true}, Expected: true},
+		{Name: "token-without-channel", Provider: AlertProvider{Token: "xoxb-token"}, Expected: false},
+		{Name: "webhook-url-with-thread", Provider: AlertProvider{WebhookURL: "https://example.com", ThreadResolvedAlerts: true}, Expected: false},
+		{Name: "token-with-channel-override", Provider: AlertProvider{Token: "xoxb-token", Channel: "#alerts", Overrides: []Override{{Group: "group", Channel: "#group-alerts"}}}, Expected: true},
+		{Name: "token-with-webhook-url-override", Provider: AlertProvider{Token: "xoxb-token", Channel: "#alerts", Overrides: []Override{{Group: "group", WebhookURL: "https://example.com"}}}, Expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %v, got %v", scenario.Expected, scenario.Provider.IsValid())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_SendWithTokenAndThreadResolvedAlerts(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	provider := AlertProvider{Token: "xoxb-token", Channel: "#alerts", ThreadResolvedAlerts: true, Overrides: []Override{{Group: "group", Channel: "#group-alerts"}}}
+	endpoint := &core.Endpoint{Name: "endpoint-name", Group: "group"}
+	result := &core.Result{ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	var lastBody Body
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		if r.URL.String() != apiURL {
+			t.Errorf("expected request to be sent to %s, got %s", apiURL, r.URL.String())
+		}
+		if r.Header.Get("Authorization") != "Bearer xoxb-token" {
+			t.Errorf("expected Authorization header to be set, got %s", r.Header.Get("Authorization"))
+		}
+		lastBody = Body{}
+		_ = json.NewDecoder(r.Body).Decode(&lastBody)
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok":true,"channel":"C123","ts":"1700000000.000100"}`))}
+	})})
+	customAlert := &alert.Alert{SuccessThreshold: 5, FailureThreshold: 3}
+	if err := provider.Send(endpoint, customAlert, result, false); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if lastBody.Channel != "#group-alerts" || lastBody.ThreadTS != "" {
+		t.Errorf("expected triggered alert to be sent to #group-alerts as a new message, got channel=%s thread_ts=%s", lastBody.Channel, lastBody.ThreadTS)
+	}
+	if customAlert.ResolveKey != "1700000000.000100" {
+		t.Errorf("expected ResolveKey to be the ts of the triggered alert, got %s", customAlert.ResolveKey)
+	}
+	if err := provider.Send(endpoint, customAlert, result, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if lastBody.ThreadTS != "1700000000.000100" {
+		t.Errorf("expected resolved alert to be sent as a reply to the triggered alert, got thread_ts=%s", lastBody.ThreadTS)
+	}
+	if customAlert.ResolveKey != "" {
+		t.Errorf("expected ResolveKey to be cleared once the alert was resolved, got %s", customAlert.ResolveKey)
+	}
+	// If the ts of the triggered alert is unknown, the resolved alert should be sent as a new message
+	if err := provider.Send(endpoint, customAlert, result, true); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if lastBody.ThreadTS != "" {
+		t.Errorf("expected resolved alert to be sent as a new message, got thread_ts=%s", lastBody.ThreadTS)
+	}
+}
+
+func TestAlertProvider_SendWithTokenAndError(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"ok":false,"error":"channel_not_found"}`))}
+	})})
+	provider := AlertProvider{Token: "xoxb-token", Channel: "#alerts", ThreadResolvedAlerts: true}
+	customAlert := &alert.Alert{SuccessThreshold: 5, FailureThreshold: 3}
+	err := provider.Send(&core.Endpoint{Name: "endpoint-name"}, customAlert, &core.Result{}, false)
+	if err == nil || !strings.Contains(err.Error(), "channel_not_found") {
+		t.Errorf("expected error containing channel_not_found, got %v", err)
+	}
+	if customAlert.ResolveKey != "" {
+		t.Errorf("expected ResolveKey to remain empty, got %s", customAlert.ResolveKey)
+	}
+}
+
 func TestAlertProvider_buildRequestBody(t *testing.T) {
 	firstDescription := "description-1"
 	secondDescription := "description-2"
@@ -155,6 +240,22 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
 		},
+		{
+			Name:         "triggered-with-mention",
+			Provider:     AlertProvider{Mention: "<!here>", MentionSeverities: []string{"critical"}},
+			Endpoint:     core.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "critical"},
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\\u003c!here\\u003e\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
+		},
+		{
+			Name:         "triggered-with-mention-restricted-to-other-severity",
+			Provider:     AlertProvider{Mention: "<!here>", MentionSeverities: []string{"critical"}},
+			Endpoint:     core.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, Severity: "warning"},
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
+		},
 		{
 			Name:         "triggered-with-group",
 			Provider:     AlertProvider{},
@@ -163,6 +264,14 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 			Resolved:     false,
 			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *group/name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false}]}]}",
 		},
+		{
+			Name:         "triggered-with-body-excerpt",
+			Provider:     AlertProvider{},
+			Endpoint:     core.Endpoint{Name: "name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3, IncludeBodyExcerpt: true},
+			Resolved:     false,
+			ExpectedBody: "{\"text\":\"\",\"attachments\":[{\"title\":\":helmet_with_white_cross: Gatus\",\"text\":\"An alert for *name* has been triggered due to having failed 3 time(s) in a row:\\n\\u003e description-1\",\"short\":false,\"color\":\"#DD0000\",\"fields\":[{\"title\":\"Condition results\",\"value\":\":x: - `[CONNECTED] == true`\\n:x: - `[STATUS] == 200`\\n\",\"short\":false},{\"title\":\"Response body\",\"value\":\"```Bad Gateway```\",\"short\":false}]}]}",
+		},
 		{
 			Name:         "resolved",
 			Provider:     AlertProvider{},
@@ -186,6 +295,7 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 				&scenario.Endpoint,
 				&scenario.Alert,
 				&core.Result{
+					Body: []byte("Bad Gateway"),
 					ConditionResults: []*core.ConditionResult{
 						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
 						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
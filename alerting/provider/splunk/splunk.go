@@ -0,0 +1,159 @@
+package splunk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// eventCollectorPath is the path of the HTTP Event Collector's event endpoint
+	eventCollectorPath = "/services/collector/event"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Splunk's HTTP Event Collector
+type AlertProvider struct {
+	// URL is the URL of the HTTP Event Collector (e.g. https://splunk.example.com:8088)
+	//
+	// If the URL has no path, /services/collector/event is used.
+	URL string `yaml:"url"`
+
+	// Token is the HTTP Event Collector token
+	Token string `yaml:"token"`
+
+	// Index is the index in which the events will be stored
+	//
+	// If empty, the default index of the token is used.
+	Index string `yaml:"index,omitempty"`
+
+	// SourceType is the source type of the events
+	//
+	// If empty, the default source type of the token is used.
+	SourceType string `yaml:"sourcetype,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the provider's target
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	if provider.ClientConfig == nil {
+		provider.ClientConfig = client.GetDefaultConfig()
+	}
+	if len(provider.Token) == 0 {
+		return false
+	}
+	parsedURL, err := url.Parse(provider.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return false
+	}
+	return true
+}
+
+// Send an alert using the provider
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, provider.getEventCollectorURL(), buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Splunk "+provider.Token)
+	response, err := client.GetHTTPClient(provider.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	Time       int64  `json:"time,omitempty"`
+	Host       string `json:"host,omitempty"`
+	Source     string `json:"source"`
+	SourceType string `json:"sourcetype,omitempty"`
+	Index      string `json:"index,omitempty"`
+	Event      Event  `json:"event"`
+}
+
+type Event struct {
+	Endpoint    string            `json:"endpoint"`
+	Group       string            `json:"group,omitempty"`
+	State       string            `json:"state"`
+	Message     string            `json:"message"`
+	Description string            `json:"description,omitempty"`
+	Conditions  []ConditionResult `json:"conditions"`
+}
+
+type ConditionResult struct {
+	Condition string `json:"condition"`
+	Success   bool   `json:"success"`
+}
+
+// buildRequestBody builds the request body for the provider
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var message, state string
+	if resolved {
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		state = "resolved"
+	} else {
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		state = "triggered"
+	}
+	conditionResults := make([]ConditionResult, 0, len(result.ConditionResults))
+	for _, conditionResult := range result.ConditionResults {
+		conditionResults = append(conditionResults, ConditionResult{
+			Condition: conditionResult.Condition,
+			Success:   conditionResult.Success,
+		})
+	}
+	var timestamp int64
+	if !result.Timestamp.IsZero() {
+		timestamp = result.Timestamp.Unix()
+	}
+	body, _ := json.Marshal(Body{
+		Time:       timestamp,
+		Host:       result.Hostname,
+		Source:     "gatus",
+		SourceType: provider.SourceType,
+		Index:      provider.Index,
+		Event: Event{
+			Endpoint:    endpoint.Name,
+			Group:       endpoint.Group,
+			State:       state,
+			Message:     message,
+			Description: alert.GetDescription(),
+			Conditions:  conditionResults,
+		},
+	})
+	return body
+}
+
+// getEventCollectorURL returns the URL to send the events to
+func (provider *AlertProvider) getEventCollectorURL() string {
+	parsedURL, err := url.Parse(provider.URL)
+	if err != nil || len(strings.Trim(parsedURL.Path, "/")) > 0 {
+		return provider.URL
+	}
+	return strings.TrimSuffix(provider.URL, "/") + eventCollectorPath
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,204 @@
+package splunk
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "00000000-0000-0000-0000-000000000000"},
+			Expected: true,
+		},
+		{
+			Name:     "no-token",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088"},
+			Expected: false,
+		},
+		{
+			Name:     "no-url",
+			Provider: AlertProvider{Token: "00000000-0000-0000-0000-000000000000"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-url-scheme",
+			Provider: AlertProvider{URL: "splunk.example.com:8088", Token: "00000000-0000-0000-0000-000000000000"},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				if r.Header.Get("Authorization") != "Splunk token" || r.URL.Path != "/services/collector/event" {
+					return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "token"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "token"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-error",
+			Provider: AlertProvider{URL: "https://splunk.example.com:8088", Token: "token"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{Index: "monitoring", SourceType: "gatus:alert"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"source\":\"gatus\",\"sourcetype\":\"gatus:alert\",\"index\":\"monitoring\",\"event\":{\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"state\":\"triggered\",\"message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row\",\"description\":\"description-1\",\"conditions\":[{\"condition\":\"[CONNECTED] == true\",\"success\":false},{\"condition\":\"[STATUS] == 200\",\"success\":false}]}}",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"source\":\"gatus\",\"event\":{\"endpoint\":\"endpoint-name\",\"group\":\"group\",\"state\":\"resolved\",\"message\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\",\"description\":\"description-2\",\"conditions\":[{\"condition\":\"[CONNECTED] == true\",\"success\":true},{\"condition\":\"[STATUS] == 200\",\"success\":true}]}}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getEventCollectorURL(t *testing.T) {
+	scenarios := []struct {
+		URL      string
+		Expected string
+	}{
+		{URL: "https://splunk.example.com:8088", Expected: "https://splunk.example.com:8088/services/collector/event"},
+		{URL: "https://splunk.example.com:8088/", Expected: "https://splunk.example.com:8088/services/collector/event"},
+		{URL: "https://splunk.example.com/custom/path", Expected: "https://splunk.example.com/custom/path"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.URL, func(t *testing.T) {
+			if got := (&AlertProvider{URL: scenario.URL}).getEventCollectorURL(); got != scenario.Expected {
+				t.Errorf("expected %s, got %s", scenario.Expected, got)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
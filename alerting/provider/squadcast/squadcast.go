@@ -0,0 +1,147 @@
+package squadcast
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using Squadcast
+type AlertProvider struct {
+	// WebhookURL is the URL of the incident webhook of the Squadcast service to send the alerts to
+	WebhookURL string `yaml:"webhook-url"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group      string `yaml:"group"`
+	WebhookURL string `yaml:"webhook-url"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !isValidWebhookURL(override.WebhookURL) {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return isValidWebhookURL(provider.WebhookURL)
+}
+
+// isValidWebhookURL returns whether the URL passed is an absolute HTTP(S) URL
+func isValidWebhookURL(webhookURL string) bool {
+	parsedURL, err := url.Parse(webhookURL)
+	return err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") && len(parsedURL.Host) > 0
+}
+
+// Send an alert using the provider
+//
+// Reference doc for Squadcast: https://support.squadcast.com/integrations/incident-webhook-incident-webhook-api
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(endpoint.Group), buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	Message     string            `json:"message"`
+	Description string            `json:"description"`
+	Status      string            `json:"status"`
+	EventID     string            `json:"event_id"`
+	Tags        map[string]string `json:"tags"`
+}
+
+// buildRequestBody builds the request body for the provider
+//
+// The event ID is derived from the endpoint, so that Squadcast resolves the incident that was created when the alert
+// was triggered.
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var message, status, results string
+	if resolved {
+		message = fmt.Sprintf("RESOLVED: %s", endpoint.DisplayName())
+		status = "resolve"
+	} else {
+		message = fmt.Sprintf("TRIGGERED: %s", endpoint.DisplayName())
+		status = "trigger"
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		results += fmt.Sprintf("\n%s - `%s`", prefix, conditionResult.Condition)
+	}
+	var description string
+	if resolved {
+		description = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		description = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		description += " with the following description: " + alertDescription
+	}
+	if len(results) > 0 {
+		description += "\n\nCondition results:" + results
+	}
+	tags := map[string]string{"endpoint": endpoint.Name}
+	if len(endpoint.Group) > 0 {
+		tags["group"] = endpoint.Group
+	}
+	body, _ := json.Marshal(Body{
+		Message:     message,
+		Description: description,
+		Status:      status,
+		EventID:     endpoint.Key(),
+		Tags:        tags,
+	})
+	return body
+}
+
+// getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
+func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				return override.WebhookURL
+			}
+		}
+	}
+	return provider.WebhookURL
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -6,16 +6,33 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 )
 
+const (
+	// ModeConnector is the mode for sending alerts to an Office 365 connector webhook using a MessageCard
+	//
+	// Office 365 connectors are being retired by Microsoft in favor of Workflows. See ModeWorkflow.
+	ModeConnector = "connector"
+
+	// ModeWorkflow is the mode for sending alerts to a Workflow (Power Automate) webhook using an Adaptive Card
+	ModeWorkflow = "workflow"
+
+	adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+)
+
 // AlertProvider is the configuration necessary for sending an alert using Teams
 type AlertProvider struct {
 	WebhookURL string `yaml:"webhook-url"`
 
+	// Mode is the format of the webhook, either ModeConnector (default) or ModeWorkflow
+	Mode string `yaml:"mode,omitempty"`
+
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 
@@ -34,18 +51,41 @@ func (provider *AlertProvider) IsValid() bool {
 	registeredGroups := make(map[string]bool)
 	if provider.Overrides != nil {
 		for _, override := range provider.Overrides {
-			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 || !provider.isValidWebhookURL(override.WebhookURL) {
 				return false
 			}
 			registeredGroups[override.Group] = true
 		}
 	}
-	return len(provider.WebhookURL) > 0
+	if len(provider.Mode) > 0 && provider.Mode != ModeConnector && provider.Mode != ModeWorkflow {
+		return false
+	}
+	return len(provider.WebhookURL) > 0 && provider.isValidWebhookURL(provider.WebhookURL)
+}
+
+// isValidWebhookURL returns whether the webhook URL passed has the shape expected for the mode of the provider
+//
+// Workflow webhooks are always HTTPS and hosted on either Azure Logic Apps or the Power Platform.
+func (provider *AlertProvider) isValidWebhookURL(webhookURL string) bool {
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil || len(parsedURL.Host) == 0 {
+		return false
+	}
+	if provider.Mode == ModeWorkflow {
+		host := strings.ToLower(parsedURL.Hostname())
+		return parsedURL.Scheme == "https" && (strings.HasSuffix(host, ".logic.azure.com") || strings.HasSuffix(host, ".powerplatform.com"))
+	}
+	return parsedURL.Scheme == "http" || parsedURL.Scheme == "https"
 }
 
 // Send an alert using the provider
 func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
-	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	var buffer *bytes.Buffer
+	if provider.Mode == ModeWorkflow {
+		buffer = bytes.NewBuffer(provider.buildWorkflowRequestBody(endpoint, alert, result, resolved))
+	} else {
+		buffer = bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	}
 	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(endpoint.Group), buffer)
 	if err != nil {
 		return err
@@ -117,6 +157,96 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 	return body
 }
 
+type WorkflowBody struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+type Attachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []CardElement `json:"body"`
+}
+
+// CardElement is an element of the body of an AdaptiveCard, such as a TextBlock, a FactSet or a Container
+type CardElement struct {
+	Type   string        `json:"type"`
+	Text   string        `json:"text,omitempty"`
+	Size   string        `json:"size,omitempty"`
+	Weight string        `json:"weight,omitempty"`
+	Color  string        `json:"color,omitempty"`
+	Wrap   bool          `json:"wrap,omitempty"`
+	Style  string        `json:"style,omitempty"`
+	Facts  []Fact        `json:"facts,omitempty"`
+	Items  []CardElement `json:"items,omitempty"`
+}
+
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+// buildWorkflowRequestBody builds the request body for the provider when Mode is ModeWorkflow
+func (provider *AlertProvider) buildWorkflowRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var message, state, color, style string
+	if resolved {
+		message = fmt.Sprintf("An alert for **%s** has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+		state, color, style = "Resolved", "Good", "good"
+	} else {
+		message = fmt.Sprintf("An alert for **%s** has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+		state, color, style = "Triggered", "Attention", "attention"
+	}
+	facts := []Fact{{Title: "State", Value: state}, {Title: "Endpoint", Value: endpoint.Name}}
+	if len(endpoint.Group) > 0 {
+		facts = append(facts, Fact{Title: "Group", Value: endpoint.Group})
+	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		facts = append(facts, Fact{Title: "Description", Value: alertDescription})
+	}
+	cardBody := []CardElement{
+		{
+			Type:  "Container",
+			Style: style,
+			Items: []CardElement{
+				{Type: "TextBlock", Text: "\U0001F6A8 Gatus", Size: "Medium", Weight: "Bolder"},
+				{Type: "TextBlock", Text: message, Color: color, Wrap: true},
+			},
+		},
+		{Type: "FactSet", Facts: facts},
+	}
+	if len(result.ConditionResults) > 0 {
+		cardBody = append(cardBody, CardElement{Type: "TextBlock", Text: "Condition results", Weight: "Bolder"})
+		for _, conditionResult := range result.ConditionResults {
+			if conditionResult.Success {
+				cardBody = append(cardBody, CardElement{Type: "TextBlock", Text: "\u2705 " + conditionResult.Condition, Color: "Good", Wrap: true})
+			} else {
+				cardBody = append(cardBody, CardElement{Type: "TextBlock", Text: "\u274C " + conditionResult.Condition, Color: "Attention", Wrap: true})
+			}
+		}
+	}
+	body, _ := json.Marshal(WorkflowBody{
+		Type: "message",
+		Attachments: []Attachment{
+			{
+				ContentType: adaptiveCardContentType,
+				Content: AdaptiveCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    cardBody,
+				},
+			},
+		},
+	})
+	return body
+}
+
 // getWebhookURLForGroup returns the appropriate Webhook URL integration to for a given group
 func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
 	if provider.Overrides != nil {
@@ -22,6 +22,66 @@ func TestAlertDefaultProvider_IsValid(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_IsValidWithMode(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "connector",
+			Provider: AlertProvider{Mode: ModeConnector, WebhookURL: "https://example.webhook.office.com/webhookb2/xxx"},
+			Expected: true,
+		},
+		{
+			Name:     "connector-with-invalid-url",
+			Provider: AlertProvider{Mode: ModeConnector, WebhookURL: "example.webhook.office.com"},
+			Expected: false,
+		},
+		{
+			Name:     "workflow-logic-apps",
+			Provider: AlertProvider{Mode: ModeWorkflow, WebhookURL: "https://prod-01.westus.logic.azure.com:443/workflows/xxx/triggers/manual/paths/invoke"},
+			Expected: true,
+		},
+		{
+			Name:     "workflow-power-platform",
+			Provider: AlertProvider{Mode: ModeWorkflow, WebhookURL: "https://default123.45.environment.api.powerplatform.com:443/powerautomate/automations/direct/workflows/xxx"},
+			Expected: true,
+		},
+		{
+			Name:     "workflow-with-connector-url",
+			Provider: AlertProvider{Mode: ModeWorkflow, WebhookURL: "https://example.webhook.office.com/webhookb2/xxx"},
+			Expected: false,
+		},
+		{
+			Name:     "workflow-without-https",
+			Provider: AlertProvider{Mode: ModeWorkflow, WebhookURL: "http://prod-01.westus.logic.azure.com/workflows/xxx"},
+			Expected: false,
+		},
+		{
+			Name: "workflow-with-invalid-override-url",
+			Provider: AlertProvider{
+				Mode:       ModeWorkflow,
+				WebhookURL: "https://prod-01.westus.logic.azure.com/workflows/xxx",
+				Overrides:  []Override{{Group: "group", WebhookURL: "https://example.com"}},
+			},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-mode",
+			Provider: AlertProvider{Mode: "invalid", WebhookURL: "https://example.com"},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, scenario.Provider.IsValid())
+			}
+		})
+	}
+}
+
 func TestAlertProvider_IsValidWithOverride(t *testing.T) {
 	providerWithInvalidOverrideGroup := AlertProvider{
 		Overrides: []Override{
@@ -101,6 +161,20 @@ func TestAlertProvider_Send(t *testing.T) {
 			}),
 			ExpectedError: false,
 		},
+		{
+			Name:     "triggered-workflow",
+			Provider: AlertProvider{Mode: ModeWorkflow},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				body := make(map[string]interface{})
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["type"] != "message" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
 		{
 			Name:     "resolved-error",
 			Provider: AlertProvider{},
@@ -185,6 +259,55 @@ func TestAlertProvider_buildRequestBody(t *testing.T) {
 	}
 }
 
+func TestAlertProvider_buildWorkflowRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name         string
+		Endpoint     core.Endpoint
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Endpoint:     core.Endpoint{Name: "endpoint-name"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"type\":\"message\",\"attachments\":[{\"contentType\":\"application/vnd.microsoft.card.adaptive\",\"content\":{\"$schema\":\"http://adaptivecards.io/schemas/adaptive-card.json\",\"type\":\"AdaptiveCard\",\"version\":\"1.4\",\"body\":[{\"type\":\"Container\",\"style\":\"attention\",\"items\":[{\"type\":\"TextBlock\",\"text\":\"\U0001F6A8 Gatus\",\"size\":\"Medium\",\"weight\":\"Bolder\"},{\"type\":\"TextBlock\",\"text\":\"An alert for **endpoint-name** has been triggered due to having failed 3 time(s) in a row\",\"color\":\"Attention\",\"wrap\":true}]},{\"type\":\"FactSet\",\"facts\":[{\"title\":\"State\",\"value\":\"Triggered\"},{\"title\":\"Endpoint\",\"value\":\"endpoint-name\"},{\"title\":\"Description\",\"value\":\"description-1\"}]},{\"type\":\"TextBlock\",\"text\":\"Condition results\",\"weight\":\"Bolder\"},{\"type\":\"TextBlock\",\"text\":\"\u274C [CONNECTED] == true\",\"color\":\"Attention\",\"wrap\":true},{\"type\":\"TextBlock\",\"text\":\"\u274C [STATUS] == 200\",\"color\":\"Attention\",\"wrap\":true}]}}]}",
+		},
+		{
+			Name:         "resolved-with-group",
+			Endpoint:     core.Endpoint{Name: "endpoint-name", Group: "group"},
+			Alert:        alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"type\":\"message\",\"attachments\":[{\"contentType\":\"application/vnd.microsoft.card.adaptive\",\"content\":{\"$schema\":\"http://adaptivecards.io/schemas/adaptive-card.json\",\"type\":\"AdaptiveCard\",\"version\":\"1.4\",\"body\":[{\"type\":\"Container\",\"style\":\"good\",\"items\":[{\"type\":\"TextBlock\",\"text\":\"\U0001F6A8 Gatus\",\"size\":\"Medium\",\"weight\":\"Bolder\"},{\"type\":\"TextBlock\",\"text\":\"An alert for **group/endpoint-name** has been resolved after passing successfully 5 time(s) in a row\",\"color\":\"Good\",\"wrap\":true}]},{\"type\":\"FactSet\",\"facts\":[{\"title\":\"State\",\"value\":\"Resolved\"},{\"title\":\"Endpoint\",\"value\":\"endpoint-name\"},{\"title\":\"Group\",\"value\":\"group\"},{\"title\":\"Description\",\"value\":\"description-2\"}]},{\"type\":\"TextBlock\",\"text\":\"Condition results\",\"weight\":\"Bolder\"},{\"type\":\"TextBlock\",\"text\":\"\u2705 [CONNECTED] == true\",\"color\":\"Good\",\"wrap\":true},{\"type\":\"TextBlock\",\"text\":\"\u2705 [STATUS] == 200\",\"color\":\"Good\",\"wrap\":true}]}}]}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := (&AlertProvider{Mode: ModeWorkflow}).buildWorkflowRequestBody(
+				&scenario.Endpoint,
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
 func TestAlertProvider_GetDefaultAlert(t *testing.T) {
 	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
 		t.Error("expected default alert to be not nil")
@@ -0,0 +1,146 @@
+package victorops
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	restEndpointURL = "https://alert.victorops.com/integrations/generic/20131114/alert"
+)
+
+// AlertProvider is the configuration necessary for sending an alert using VictorOps (Splunk On-Call)
+type AlertProvider struct {
+	// APIKey is the key of the REST integration
+	APIKey string `yaml:"api-key"`
+
+	// RoutingKey is the routing key used to route the alerts to the right team
+	RoutingKey string `yaml:"routing-key"`
+
+	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
+	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
+
+	// Overrides is a list of Override that may be prioritized over the default configuration
+	Overrides []Override `yaml:"overrides,omitempty"`
+}
+
+// Override is a case under which the default integration is overridden
+type Override struct {
+	Group      string `yaml:"group"`
+	RoutingKey string `yaml:"routing-key"`
+}
+
+// IsValid returns whether the provider's configuration is valid
+func (provider *AlertProvider) IsValid() bool {
+	registeredGroups := make(map[string]bool)
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || !isValidKey(override.RoutingKey) {
+				return false
+			}
+			registeredGroups[override.Group] = true
+		}
+	}
+	return isValidKey(provider.APIKey) && isValidKey(provider.RoutingKey)
+}
+
+// isValidKey returns whether the key passed can be used as a segment of the URL of the REST endpoint
+func isValidKey(key string) bool {
+	return len(key) > 0 && !strings.ContainsAny(key, "/?# ")
+}
+
+// Send an alert using the provider
+//
+// Reference doc for VictorOps: https://help.victorops.com/knowledge-base/rest-endpoint-integration-guide/
+func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
+	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
+	request, err := http.NewRequest(http.MethodPost, provider.getURLForGroup(endpoint.Group), buffer)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := client.GetHTTPClient(nil).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 399 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("call to provider alert returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return err
+}
+
+type Body struct {
+	MessageType       string `json:"message_type"`
+	EntityID          string `json:"entity_id"`
+	EntityDisplayName string `json:"entity_display_name"`
+	StateMessage      string `json:"state_message"`
+	MonitoringTool    string `json:"monitoring_tool"`
+}
+
+// buildRequestBody builds the request body for the provider
+//
+// The entity ID is derived from the endpoint, so that VictorOps resolves the incident that was created when the alert
+// was triggered.
+func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
+	var messageType, message, results string
+	if resolved {
+		messageType = "RECOVERY"
+		message = fmt.Sprintf("An alert for %s has been resolved after passing successfully %d time(s) in a row", endpoint.DisplayName(), alert.SuccessThreshold)
+	} else {
+		messageType = "CRITICAL"
+		message = fmt.Sprintf("An alert for %s has been triggered due to having failed %d time(s) in a row", endpoint.DisplayName(), alert.FailureThreshold)
+	}
+	if alertDescription := alert.GetDescription(); len(alertDescription) > 0 {
+		message += " with the following description: " + alertDescription
+	}
+	for _, conditionResult := range result.ConditionResults {
+		var prefix string
+		if conditionResult.Success {
+			prefix = "✅"
+		} else {
+			prefix = "❌"
+		}
+		results += fmt.Sprintf("\n%s - `%s`", prefix, conditionResult.Condition)
+	}
+	if len(results) > 0 {
+		message += "\n\nCondition results:" + results
+	}
+	body, _ := json.Marshal(Body{
+		MessageType:       messageType,
+		EntityID:          endpoint.Key(),
+		EntityDisplayName: endpoint.DisplayName(),
+		StateMessage:      message,
+		MonitoringTool:    "Gatus",
+	})
+	return body
+}
+
+// getURLForGroup returns the URL of the REST endpoint with the appropriate routing key for a given group
+func (provider *AlertProvider) getURLForGroup(group string) string {
+	routingKey := provider.RoutingKey
+	if provider.Overrides != nil {
+		for _, override := range provider.Overrides {
+			if group == override.Group {
+				routingKey = override.RoutingKey
+				break
+			}
+		}
+	}
+	return restEndpointURL + "/" + url.PathEscape(provider.APIKey) + "/" + url.PathEscape(routingKey)
+}
+
+// GetDefaultAlert returns the provider's default alert configuration
+func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
+	return provider.DefaultAlert
+}
@@ -0,0 +1,235 @@
+package victorops
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValid(t *testing.T) {
+	scenarios := []struct {
+		Name     string
+		Provider AlertProvider
+		Expected bool
+	}{
+		{
+			Name:     "valid",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Expected: true,
+		},
+		{
+			Name:     "no-api-key",
+			Provider: AlertProvider{RoutingKey: "ops"},
+			Expected: false,
+		},
+		{
+			Name:     "no-routing-key",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000"},
+			Expected: false,
+		},
+		{
+			Name:     "invalid-routing-key",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops/infra"},
+			Expected: false,
+		},
+		{
+			Name: "valid-override",
+			Provider: AlertProvider{
+				APIKey:     "00000000-0000-0000-0000-000000000000",
+				RoutingKey: "ops",
+				Overrides:  []Override{{Group: "core", RoutingKey: "core-team"}},
+			},
+			Expected: true,
+		},
+		{
+			Name: "override-with-no-group",
+			Provider: AlertProvider{
+				APIKey:     "00000000-0000-0000-0000-000000000000",
+				RoutingKey: "ops",
+				Overrides:  []Override{{RoutingKey: "core-team"}},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-duplicate-group",
+			Provider: AlertProvider{
+				APIKey:     "00000000-0000-0000-0000-000000000000",
+				RoutingKey: "ops",
+				Overrides: []Override{
+					{Group: "core", RoutingKey: "core-team"},
+					{Group: "core", RoutingKey: "other-team"},
+				},
+			},
+			Expected: false,
+		},
+		{
+			Name: "override-with-no-routing-key",
+			Provider: AlertProvider{
+				APIKey:     "00000000-0000-0000-0000-000000000000",
+				RoutingKey: "ops",
+				Overrides:  []Override{{Group: "core"}},
+			},
+			Expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if scenario.Provider.IsValid() != scenario.Expected {
+				t.Errorf("expected %t, got %t", scenario.Expected, !scenario.Expected)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_Send(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	firstDescription := "description-1"
+	secondDescription := "description-2"
+	scenarios := []struct {
+		Name             string
+		Provider         AlertProvider
+		Alert            alert.Alert
+		Resolved         bool
+		MockRoundTripper test.MockRoundTripper
+		ExpectedError    bool
+	}{
+		{
+			Name:     "triggered",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "triggered-error",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:    alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: false,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+		{
+			Name:     "resolved",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}
+			}),
+			ExpectedError: false,
+		},
+		{
+			Name:     "resolved-error",
+			Provider: AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:    alert.Alert{Description: &secondDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved: true,
+			MockRoundTripper: test.MockRoundTripper(func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+			}),
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			err := scenario.Provider.Send(
+				&core.Endpoint{Name: "endpoint-name"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBody(t *testing.T) {
+	firstDescription := "description-1"
+	scenarios := []struct {
+		Name         string
+		Provider     AlertProvider
+		Alert        alert.Alert
+		Resolved     bool
+		ExpectedBody string
+	}{
+		{
+			Name:         "triggered",
+			Provider:     AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:        alert.Alert{Description: &firstDescription, SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     false,
+			ExpectedBody: "{\"message_type\":\"CRITICAL\",\"entity_id\":\"group_endpoint-name\",\"entity_display_name\":\"group/endpoint-name\",\"state_message\":\"An alert for group/endpoint-name has been triggered due to having failed 3 time(s) in a row with the following description: description-1\\n\\nCondition results:\\n❌ - `[CONNECTED] == true`\\n❌ - `[STATUS] == 200`\",\"monitoring_tool\":\"Gatus\"}",
+		},
+		{
+			Name:         "resolved",
+			Provider:     AlertProvider{APIKey: "00000000-0000-0000-0000-000000000000", RoutingKey: "ops"},
+			Alert:        alert.Alert{SuccessThreshold: 5, FailureThreshold: 3},
+			Resolved:     true,
+			ExpectedBody: "{\"message_type\":\"RECOVERY\",\"entity_id\":\"group_endpoint-name\",\"entity_display_name\":\"group/endpoint-name\",\"state_message\":\"An alert for group/endpoint-name has been resolved after passing successfully 5 time(s) in a row\\n\\nCondition results:\\n✅ - `[CONNECTED] == true`\\n✅ - `[STATUS] == 200`\",\"monitoring_tool\":\"Gatus\"}",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body := scenario.Provider.buildRequestBody(
+				&core.Endpoint{Name: "endpoint-name", Group: "group"},
+				&scenario.Alert,
+				&core.Result{
+					ConditionResults: []*core.ConditionResult{
+						{Condition: "[CONNECTED] == true", Success: scenario.Resolved},
+						{Condition: "[STATUS] == 200", Success: scenario.Resolved},
+					},
+				},
+				scenario.Resolved,
+			)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\ngot:\n%s", scenario.ExpectedBody, body)
+			}
+			out := make(map[string]interface{})
+			if err := json.Unmarshal(body, &out); err != nil {
+				t.Error("expected body to be valid JSON, got error:", err.Error())
+			}
+		})
+	}
+}
+
+func TestAlertProvider_getURLForGroup(t *testing.T) {
+	provider := AlertProvider{
+		APIKey:     "00000000-0000-0000-0000-000000000000",
+		RoutingKey: "ops",
+		Overrides:  []Override{{Group: "core", RoutingKey: "core-team"}},
+	}
+	if url := provider.getURLForGroup(""); url != "https://alert.victorops.com/integrations/generic/20131114/alert/00000000-0000-0000-0000-000000000000/ops" {
+		t.Error("expected default routing key to be used, got", url)
+	}
+	if url := provider.getURLForGroup("core"); url != "https://alert.victorops.com/integrations/generic/20131114/alert/00000000-0000-0000-0000-000000000000/core-team" {
+		t.Error("expected override routing key to be used, got", url)
+	}
+}
+
+func TestAlertProvider_GetDefaultAlert(t *testing.T) {
+	if (AlertProvider{DefaultAlert: &alert.Alert{}}).GetDefaultAlert() == nil {
+		t.Error("expected default alert to be not nil")
+	}
+	if (AlertProvider{DefaultAlert: nil}).GetDefaultAlert() != nil {
+		t.Error("expected default alert to be nil")
+	}
+}
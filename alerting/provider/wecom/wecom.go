@@ -5,20 +5,36 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/core"
 	"io"
 	"net/http"
+	"strings"
+	"text/template"
 	"time"
 )
 
 // AlertProvider is the configuration necessary for sending an alert using Slack
 type AlertProvider struct {
-	WebhookURL string `yaml:"webhook-url"` // Slack webhook URL
+	// WebhookURL is the WeCom webhook URL
+	//
+	// It may be a template rendered with the Name, the Group and the Key of the endpoint the alert is for when the alert
+	// is sent (e.g. https://example.org/{{ .Group }}), so that a single definition routes the alerts of every group.
+	// Digests are only rendered with the Group.
+	WebhookURL string `yaml:"webhook-url"`
+	// MentionedList is the list of IDs of the users to mention in the alerts sent
+	MentionedList []string `yaml:"mentioned-list,omitempty"`
+	// MentionSeverities are the severities of the alerts that mention the users of MentionedList (e.g. [critical]).
+	// If empty, every alert mentions them.
+	MentionSeverities []string `yaml:"mention-severities,omitempty"`
 	// DefaultAlert is the default alert configuration to use for endpoints with an alert of the appropriate type
 	DefaultAlert *alert.Alert `yaml:"default-alert,omitempty"`
 	// Overrides is a list of Override that may be prioritized over the default configuration
 	Overrides []Override `yaml:"overrides,omitempty"`
+	// Digest is the configuration for summarizing the state of a group in a single message when many of its
+	// endpoints fail at once
+	Digest *alert.DigestConfig `yaml:"digest,omitempty"`
 }
 
 // Override is a case under which the default integration is overridden
@@ -35,16 +51,45 @@ func (provider *AlertProvider) IsValid() bool {
 			if isAlreadyRegistered := registeredGroups[override.Group]; isAlreadyRegistered || override.Group == "" || len(override.WebhookURL) == 0 {
 				return false
 			}
+			if _, err := parseWebhookURLTemplate(override.WebhookURL); err != nil {
+				return false
+			}
 			registeredGroups[override.Group] = true
 		}
 	}
+	if provider.Digest != nil && !provider.Digest.IsValid() {
+		return false
+	}
+	if _, err := parseWebhookURLTemplate(provider.WebhookURL); err != nil {
+		return false
+	}
 	return len(provider.WebhookURL) > 0
 }
 
 // Send an alert using the provider
 func (provider *AlertProvider) Send(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) error {
-	buffer := bytes.NewBuffer(provider.buildRequestBody(endpoint, alert, result, resolved))
-	request, err := http.NewRequest(http.MethodPost, provider.getWebhookURLForGroup(endpoint.Group), buffer)
+	webhookURL, err := renderWebhookURL(provider.getWebhookURLForGroup(endpoint.Group), map[string]string{
+		"Name":  endpoint.Name,
+		"Group": endpoint.Group,
+		"Key":   endpoint.Key(),
+	})
+	if err != nil {
+		return err
+	}
+	return provider.send(webhookURL, provider.buildRequestBody(endpoint, alert, result, resolved))
+}
+
+// SendDigest sends a digest of the state of a group using the provider
+func (provider *AlertProvider) SendDigest(digest *alert.Digest) error {
+	webhookURL, err := renderWebhookURL(provider.getWebhookURLForGroup(digest.Group), map[string]string{"Group": digest.Group})
+	if err != nil {
+		return err
+	}
+	return provider.send(webhookURL, provider.buildDigestRequestBody(digest))
+}
+
+func (provider *AlertProvider) send(webhookURL string, body []byte) error {
+	request, err := http.NewRequest(http.MethodPost, webhookURL, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
@@ -73,11 +118,11 @@ type Markdown struct {
 func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *alert.Alert, result *core.Result, resolved bool) []byte {
 	var title, conditions, message string
 	if resolved {
-		title = fmt.Sprint("# <font color=\"info\">Alert Resolved</font>\n")
+		title = fmt.Sprintf("# <font color=\"info\">%s</font>\n", i18n.Translate(i18n.KeyAlertResolved))
 	} else {
-		title = fmt.Sprint("# <font color=\"warning\">Alert Triggered</font>\n")
+		title = fmt.Sprintf("# <font color=\"warning\">%s</font>\n", i18n.Translate(i18n.KeyAlertTriggered))
 	}
-	conditions = "## Condition:\n"
+	conditions = fmt.Sprintf("## %s:\n", i18n.Translate(i18n.KeyCondition))
 	for _, conditionResult := range result.ConditionResults {
 		var prefix string
 		if conditionResult.Success {
@@ -92,13 +137,48 @@ func (provider *AlertProvider) buildRequestBody(endpoint *core.Endpoint, alert *
 		description = alertDescription
 	}
 	var info string
-	info = "## Endpoint Info\n"
-	info += fmt.Sprintf("> group: <font color=\"comment\">%s</font>\n", endpoint.Group)
-	info += fmt.Sprintf("> name: <font color=\"comment\">%s</font>\n", endpoint.Name)
-	info += fmt.Sprintf("> url: [%s](%s)\n", endpoint.URL, endpoint.URL)
-	info += fmt.Sprintf("> describe: <font color=\"comment\">%s</font>\n", description)
-	info += fmt.Sprintf("> update time: %s\n\n", genUTC8time())
+	info = fmt.Sprintf("## %s\n", i18n.Translate(i18n.KeyEndpointInfo))
+	info += fmt.Sprintf("> %s: <font color=\"comment\">%s</font>\n", i18n.Translate(i18n.KeyGroup), endpoint.Group)
+	info += fmt.Sprintf("> %s: <font color=\"comment\">%s</font>\n", i18n.Translate(i18n.KeyName), endpoint.Name)
+	info += fmt.Sprintf("> %s: [%s](%s)\n", i18n.Translate(i18n.KeyURL), endpoint.URL, endpoint.URL)
+	info += fmt.Sprintf("> %s: <font color=\"comment\">%s</font>\n", i18n.Translate(i18n.KeyDescription), description)
+	info += fmt.Sprintf("> %s: %s\n\n", i18n.Translate(i18n.KeyUpdateTime), genUTC8time())
 	message = title + info + conditions
+	if bodyExcerpt := alert.GetBodyExcerpt(result.Body); !resolved && len(bodyExcerpt) > 0 {
+		message += fmt.Sprintf("## %s:\n> %s\n", i18n.Translate(i18n.KeyBodyExcerpt), strings.ReplaceAll(bodyExcerpt, "\n", "\n> "))
+	}
+	if len(provider.MentionedList) > 0 && alert.ShouldMention(provider.MentionSeverities) {
+		for _, userID := range provider.MentionedList {
+			message += fmt.Sprintf("<@%s>", userID)
+		}
+		message += "\n"
+	}
+	body, _ := json.Marshal(Body{
+		Msgtype: "markdown",
+		Markdown: Markdown{
+			Content: message,
+		},
+	})
+	return body
+}
+
+// buildDigestRequestBody builds the request body of a digest for the provider
+func (provider *AlertProvider) buildDigestRequestBody(digest *alert.Digest) []byte {
+	var message string
+	if digest.Resolved {
+		message = fmt.Sprintf("# <font color=\"info\">%s</font>\n", fmt.Sprintf(i18n.Translate(i18n.KeyDigestResolved), digest.Group))
+	} else {
+		message = fmt.Sprintf("# <font color=\"warning\">%s</font>\n", fmt.Sprintf(i18n.Translate(i18n.KeyDigestTriggered), len(digest.FailingEndpoints), digest.NumberOfEndpoints, digest.Group))
+		message += fmt.Sprintf("## %s:\n", i18n.Translate(i18n.KeyFailingEndpoints))
+		for _, failingEndpoint := range digest.FailingEndpoints {
+			message += fmt.Sprintf("> ❌ **%s** (%s %s)", failingEndpoint.Name, i18n.Translate(i18n.KeySince), formatUTC8Time(failingEndpoint.FailingSince))
+			for _, failedCondition := range failingEndpoint.FailedConditions {
+				message += fmt.Sprintf(" `%s`", failedCondition)
+			}
+			message += "\n"
+		}
+	}
+	message += fmt.Sprintf("\n> %s: %s\n", i18n.Translate(i18n.KeyUpdateTime), genUTC8time())
 	body, _ := json.Marshal(Body{
 		Msgtype: "markdown",
 		Markdown: Markdown{
@@ -120,13 +200,46 @@ func (provider *AlertProvider) getWebhookURLForGroup(group string) string {
 	return provider.WebhookURL
 }
 
+// parseWebhookURLTemplate parses a webhook URL as a template, which fails on references to data that doesn't exist
+// rather than rendering them as empty
+func parseWebhookURLTemplate(webhookURL string) (*template.Template, error) {
+	return template.New("webhook-url").Option("missingkey=error").Parse(webhookURL)
+}
+
+// renderWebhookURL renders a webhook URL with the data passed
+//
+// The webhook URL is returned as-is if it has no template actions.
+func renderWebhookURL(webhookURL string, data map[string]string) (string, error) {
+	if !strings.Contains(webhookURL, "{{") {
+		return webhookURL, nil
+	}
+	webhookURLTemplate, err := parseWebhookURLTemplate(webhookURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid webhook-url template: %w", err)
+	}
+	var rendered strings.Builder
+	if err = webhookURLTemplate.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render webhook-url template: %w", err)
+	}
+	return rendered.String(), nil
+}
+
 // GetDefaultAlert returns the provider's default alert configuration
 func (provider AlertProvider) GetDefaultAlert() *alert.Alert {
 	return provider.DefaultAlert
 }
 
+// GetDigestConfig returns the provider's digest configuration
+func (provider AlertProvider) GetDigestConfig() *alert.DigestConfig {
+	return provider.Digest
+}
+
 func genUTC8time() string {
 	timelocal := time.FixedZone("UTC", 3600*8)
 	time.Local = timelocal
 	return time.Now().Local().Format("2006-01-02 15:04:05")
 }
+
+func formatUTC8Time(t time.Time) string {
+	return t.In(time.FixedZone("UTC", 3600*8)).Format("2006-01-02 15:04:05")
+}
@@ -0,0 +1,203 @@
+package wecom
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestAlertProvider_IsValidWithDigest(t *testing.T) {
+	if !(&AlertProvider{WebhookURL: "https://example.com", Digest: &alert.DigestConfig{Threshold: 3, Window: time.Minute}}).IsValid() {
+		t.Error("provider should've been valid")
+	}
+	if (&AlertProvider{WebhookURL: "https://example.com", Digest: &alert.DigestConfig{Threshold: 1}}).IsValid() {
+		t.Error("provider shouldn't have been valid, because the threshold of a digest must be at least 2")
+	}
+}
+
+func TestAlertProvider_IsValidWithWebhookURLTemplate(t *testing.T) {
+	if !(&AlertProvider{WebhookURL: "https://example.com/{{ .Group }}"}).IsValid() {
+		t.Error("provider should've been valid")
+	}
+	if (&AlertProvider{WebhookURL: "https://example.com/{{ .Group "}).IsValid() {
+		t.Error("provider shouldn't have been valid, because the template of webhook-url cannot be parsed")
+	}
+	if (&AlertProvider{WebhookURL: "https://example.com", Overrides: []Override{{Group: "core", WebhookURL: "https://example.com/{{ end }}"}}}).IsValid() {
+		t.Error("provider shouldn't have been valid, because the template of the webhook-url of the override cannot be parsed")
+	}
+}
+
+func TestAlertProvider_SendWithWebhookURLTemplate(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	var requestedURL string
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		requestedURL = r.URL.String()
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})})
+	provider := AlertProvider{
+		WebhookURL: "https://example.com/send?key={{ .Group }}-{{ .Name }}",
+		Overrides:  []Override{{Group: "core", WebhookURL: "https://example.com/core/{{ .Key }}"}},
+	}
+	scenarios := []struct {
+		Name        string
+		Endpoint    core.Endpoint
+		ExpectedURL string
+	}{
+		{
+			Name:        "default",
+			Endpoint:    core.Endpoint{Name: "api", Group: "payments"},
+			ExpectedURL: "https://example.com/send?key=payments-api",
+		},
+		{
+			Name:        "override",
+			Endpoint:    core.Endpoint{Name: "api", Group: "core"},
+			ExpectedURL: "https://example.com/core/core_api",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := provider.Send(&scenario.Endpoint, &alert.Alert{}, &core.Result{}, false); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if requestedURL != scenario.ExpectedURL {
+				t.Errorf("expected request to be sent to %s, got %s", scenario.ExpectedURL, requestedURL)
+			}
+		})
+	}
+	// Digests don't have an endpoint, so a template referencing one cannot be rendered
+	if err := provider.SendDigest(&alert.Digest{Group: "payments"}); err == nil || !strings.Contains(err.Error(), "failed to render webhook-url template") {
+		t.Errorf("expected an error rendering the webhook-url template, got %v", err)
+	}
+}
+
+func TestRenderWebhookURL(t *testing.T) {
+	scenarios := []struct {
+		Name          string
+		WebhookURL    string
+		ExpectedURL   string
+		ExpectedError bool
+	}{
+		{
+			Name:        "no-template",
+			WebhookURL:  "https://example.com/send?key={key}",
+			ExpectedURL: "https://example.com/send?key={key}",
+		},
+		{
+			Name:        "template",
+			WebhookURL:  "https://example.com/{{ .Group }}",
+			ExpectedURL: "https://example.com/payments",
+		},
+		{
+			Name:          "unresolved-template",
+			WebhookURL:    "https://example.com/{{ .Tag }}",
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-template",
+			WebhookURL:    "https://example.com/{{ .Group ",
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			webhookURL, err := renderWebhookURL(scenario.WebhookURL, map[string]string{"Group": "payments"})
+			if scenario.ExpectedError && err == nil {
+				t.Error("expected error, got none")
+			}
+			if !scenario.ExpectedError && err != nil {
+				t.Error("expected no error, got", err.Error())
+			}
+			if webhookURL != scenario.ExpectedURL {
+				t.Errorf("expected %s, got %s", scenario.ExpectedURL, webhookURL)
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildDigestRequestBody(t *testing.T) {
+	failingSince := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		Name             string
+		Digest           alert.Digest
+		ExpectedContents []string
+	}{
+		{
+			Name: "triggered",
+			Digest: alert.Digest{Group: "payments", NumberOfEndpoints: 5, FailingEndpoints: []*alert.DigestEndpoint{
+				{Name: "api", FailingSince: failingSince, FailedConditions: []string{"[STATUS] == 200"}},
+				{Name: "worker", FailingSince: failingSince, FailedConditions: []string{"[CONNECTED] == true"}},
+			}},
+			ExpectedContents: []string{
+				"# <font color=\"warning\">2/5 endpoints in group=payments are down</font>\n## Failing endpoints:\n",
+				"> ❌ **api** (since 2024-01-01 08:00:00) `[STATUS] == 200`\n",
+				"> ❌ **worker** (since 2024-01-01 08:00:00) `[CONNECTED] == true`\n",
+			},
+		},
+		{
+			Name:             "resolved",
+			Digest:           alert.Digest{Group: "payments", NumberOfEndpoints: 5, Resolved: true},
+			ExpectedContents: []string{"# <font color=\"info\">All endpoints in group=payments are back up</font>\n"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			var body Body
+			if err := json.Unmarshal((&AlertProvider{}).buildDigestRequestBody(&scenario.Digest), &body); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if body.Msgtype != "markdown" {
+				t.Errorf("expected msgtype to be markdown, got %s", body.Msgtype)
+			}
+			for _, expectedContent := range scenario.ExpectedContents {
+				if !strings.Contains(body.Markdown.Content, expectedContent) {
+					t.Errorf("expected content to contain %q, got %q", expectedContent, body.Markdown.Content)
+				}
+			}
+		})
+	}
+}
+
+func TestAlertProvider_buildRequestBodyWithMentions(t *testing.T) {
+	provider := AlertProvider{WebhookURL: "https://example.com", MentionedList: []string{"alice", "bob"}, MentionSeverities: []string{"critical"}}
+	endpoint := &core.Endpoint{Name: "endpoint-name"}
+	result := &core.Result{ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	var body Body
+	if err := json.Unmarshal(provider.buildRequestBody(endpoint, &alert.Alert{Severity: "critical"}, result, false), &body); err != nil {
+		t.Fatal("expected body to be valid JSON, got error:", err.Error())
+	}
+	if !strings.HasSuffix(body.Markdown.Content, "<@alice><@bob>\n") {
+		t.Errorf("expected content of critical alert to mention alice and bob, got %q", body.Markdown.Content)
+	}
+	if err := json.Unmarshal(provider.buildRequestBody(endpoint, &alert.Alert{Severity: "warning"}, result, false), &body); err != nil {
+		t.Fatal("expected body to be valid JSON, got error:", err.Error())
+	}
+	if strings.Contains(body.Markdown.Content, "<@") {
+		t.Errorf("expected content of warning alert to not mention anyone, got %q", body.Markdown.Content)
+	}
+}
+
+func TestAlertProvider_buildRequestBodyWithBodyExcerpt(t *testing.T) {
+	provider := AlertProvider{WebhookURL: "https://example.com"}
+	endpoint := &core.Endpoint{Name: "endpoint-name"}
+	result := &core.Result{Body: []byte("<html>\nBad Gateway\n</html>"), ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	var body Body
+	if err := json.Unmarshal(provider.buildRequestBody(endpoint, &alert.Alert{IncludeBodyExcerpt: true}, result, false), &body); err != nil {
+		t.Fatal("expected body to be valid JSON, got error:", err.Error())
+	}
+	if !strings.HasSuffix(body.Markdown.Content, "## Response body:\n> <html>\n> Bad Gateway\n> </html>\n") {
+		t.Errorf("expected content of triggered alert to include the body excerpt, got %q", body.Markdown.Content)
+	}
+	if err := json.Unmarshal(provider.buildRequestBody(endpoint, &alert.Alert{IncludeBodyExcerpt: true}, result, true), &body); err != nil {
+		t.Fatal("expected body to be valid JSON, got error:", err.Error())
+	}
+	if strings.Contains(body.Markdown.Content, "Bad Gateway") {
+		t.Errorf("expected content of resolved alert to not include the body excerpt, got %q", body.Markdown.Content)
+	}
+}
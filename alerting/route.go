@@ -0,0 +1,191 @@
+package alerting
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+)
+
+var (
+	ErrRouteWithoutTarget            = errors.New("alerting.routes[] must have a provider, a group or both")
+	ErrInvalidRouteTime              = errors.New("alerting.routes[].start and alerting.routes[].end must be in the format hh:mm, between 00:00 and 23:59 inclusively (e.g. 08:00)")
+	ErrInvalidRouteDay               = errors.New("alerting.routes[].every must only contain days of the week (e.g. Monday)")
+	ErrRouteWithUnconfiguredProvider = errors.New("alerting.routes[].provider must be a configured provider")
+	ErrRoutesWithGaps                = errors.New("alerting.routes must cover the whole week for alerts whose provider is not configured, since there is no provider to fall back to outside of the routes")
+)
+
+// Route is a time window during which alerts are handled by another provider, by the override of another group, or
+// both. This is useful for teams handing off between regions, where each region has its own on-call channel.
+type Route struct {
+	// Types are the types of the alerts the route applies to. If empty, the route applies to alerts of every type.
+	Types []alert.Type `yaml:"types,omitempty"`
+
+	// Start is the time of the day at which the route starts applying (e.g. 00:00)
+	Start string `yaml:"start"`
+
+	// End is the time of the day at which the route stops applying (e.g. 08:00). If it is not after Start, the route
+	// spans midnight, and if it is equal to Start, the route applies for the whole day.
+	End string `yaml:"end"`
+
+	// Every is a list of days of the week on which the route starts applying. Every day if empty.
+	Every []string `yaml:"every,omitempty"`
+
+	// Timezone is the timezone Start, End and Every are in (e.g. Asia/Tokyo). Defaults to UTC.
+	Timezone string `yaml:"timezone,omitempty"`
+
+	// Provider is the type of the provider that handles the alerts during the route. Defaults to the type of the alert.
+	Provider alert.Type `yaml:"provider,omitempty"`
+
+	// Group is the group whose override of the provider handles the alerts during the route. Defaults to the group of
+	// the endpoint.
+	Group string `yaml:"group,omitempty"`
+
+	start    time.Duration
+	end      time.Duration
+	days     map[time.Weekday]bool
+	location *time.Location
+}
+
+// ValidateAndSetDefaults validates the route and sets the default values if necessary
+func (route *Route) ValidateAndSetDefaults() error {
+	if len(route.Provider) == 0 && len(route.Group) == 0 {
+		return ErrRouteWithoutTarget
+	}
+	var err error
+	if route.start, err = parseTimeOfDay(route.Start); err != nil {
+		return err
+	}
+	if route.end, err = parseTimeOfDay(route.End); err != nil {
+		return err
+	}
+	route.days = make(map[time.Weekday]bool)
+	for _, day := range route.Every {
+		weekday, ok := parseWeekday(day)
+		if !ok {
+			return ErrInvalidRouteDay
+		}
+		route.days[weekday] = true
+	}
+	if len(route.Timezone) == 0 {
+		route.location = time.UTC
+	} else if route.location, err = time.LoadLocation(route.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %s for alerting.routes[]: %w", route.Timezone, err)
+	}
+	return nil
+}
+
+// AppliesTo returns whether the route applies to alerts of the given type at the given time
+func (route *Route) AppliesTo(alertType alert.Type, now time.Time) bool {
+	if len(route.Types) > 0 {
+		hasType := false
+		for _, routeAlertType := range route.Types {
+			if routeAlertType == alertType {
+				hasType = true
+				break
+			}
+		}
+		if !hasType {
+			return false
+		}
+	}
+	now = now.In(route.location)
+	timeOfDay := time.Duration(now.Hour())*time.Hour + time.Duration(now.Minute())*time.Minute + time.Duration(now.Second())*time.Second
+	var day time.Weekday
+	if route.start < route.end {
+		if timeOfDay < route.start || timeOfDay >= route.end {
+			return false
+		}
+		day = now.Weekday()
+	} else if timeOfDay >= route.start {
+		day = now.Weekday()
+	} else if timeOfDay < route.end {
+		// The route spans midnight and started on the previous day
+		day = (now.Weekday() + 6) % 7
+	} else {
+		return false
+	}
+	return len(route.days) == 0 || route.days[day]
+}
+
+// GetProvider returns the type of the provider that handles the alerts of the given type during the route
+//
+// Returns the given type if the route is nil or has no provider.
+func (route *Route) GetProvider(alertType alert.Type) alert.Type {
+	if route == nil || len(route.Provider) == 0 {
+		return alertType
+	}
+	return route.Provider
+}
+
+// GetGroup returns the group whose override of the provider handles the alerts of endpoints of the given group during
+// the route
+//
+// Returns the given group if the route is nil or has no group.
+func (route *Route) GetGroup(group string) string {
+	if route == nil || len(route.Group) == 0 {
+		return group
+	}
+	return route.Group
+}
+
+// GetRoute returns the first route that applies to alerts of the given type at the given time, or nil if there are
+// none, in which case the alerts are handled by the provider of their type
+func (config *Config) GetRoute(alertType alert.Type, now time.Time) *Route {
+	for _, route := range config.Routes {
+		if route.AppliesTo(alertType, now) {
+			return route
+		}
+	}
+	return nil
+}
+
+// ValidateRoutes validates the routes and sets their default values if necessary
+//
+// Must be called after the providers have been validated, since the providers the routes point to must be configured.
+// Because alerts that no route applies to are handled by the provider of their type, the routes applying to alerts
+// of the given types whose provider is not configured must cover the whole week.
+func (config *Config) ValidateRoutes(alertTypes []alert.Type) error {
+	for _, route := range config.Routes {
+		if err := route.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if len(route.Provider) > 0 && config.GetAlertingProviderByAlertType(route.Provider) == nil {
+			return fmt.Errorf("%w: %s", ErrRouteWithUnconfiguredProvider, route.Provider)
+		}
+	}
+	if len(config.Routes) == 0 {
+		return nil
+	}
+	for _, alertType := range alertTypes {
+		if config.GetAlertingProviderByAlertType(alertType) != nil {
+			continue
+		}
+		// Check every minute of the coming week, so that daylight saving time transitions are taken into account
+		now := time.Now().Truncate(time.Minute)
+		for t := now; t.Before(now.Add(7 * 24 * time.Hour)); t = t.Add(time.Minute) {
+			if route := config.GetRoute(alertType, t); config.GetAlertingProviderByAlertType(route.GetProvider(alertType)) == nil {
+				return fmt.Errorf("%w: no route sends alerts of type %s to a configured provider on %s at %s UTC", ErrRoutesWithGaps, alertType, t.UTC().Weekday(), t.UTC().Format("15:04"))
+			}
+		}
+	}
+	return nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil || len(s) != 5 {
+		return 0, ErrInvalidRouteTime
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if day.String() == s {
+			return day, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,209 @@
+package alerting
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/discord"
+	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+)
+
+func TestRoute_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		route       *Route
+		expectedErr error
+	}{
+		{
+			name:  "valid",
+			route: &Route{Start: "00:00", End: "08:00", Every: []string{"Monday", "Friday"}, Timezone: "Asia/Tokyo", Group: "apac"},
+		},
+		{
+			name:        "no-provider-and-no-group",
+			route:       &Route{Start: "00:00", End: "08:00"},
+			expectedErr: ErrRouteWithoutTarget,
+		},
+		{
+			name:        "invalid-start",
+			route:       &Route{Start: "8:00", End: "16:00", Group: "emea"},
+			expectedErr: ErrInvalidRouteTime,
+		},
+		{
+			name:        "invalid-end",
+			route:       &Route{Start: "08:00", End: "24:00", Group: "emea"},
+			expectedErr: ErrInvalidRouteTime,
+		},
+		{
+			name:        "invalid-day",
+			route:       &Route{Start: "08:00", End: "16:00", Every: []string{"monday"}, Group: "emea"},
+			expectedErr: ErrInvalidRouteDay,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.route.ValidateAndSetDefaults(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+	if err := (&Route{Start: "00:00", End: "08:00", Timezone: "Mars/Olympus_Mons", Group: "apac"}).ValidateAndSetDefaults(); err == nil {
+		t.Error("expected an error for an invalid timezone, got none")
+	}
+}
+
+func TestRoute_AppliesTo(t *testing.T) {
+	// 2024-01-01 is a Monday
+	scenarios := []struct {
+		name     string
+		route    *Route
+		now      time.Time
+		expected bool
+	}{
+		{
+			name:     "within-window",
+			route:    &Route{Start: "00:00", End: "08:00", Group: "apac"},
+			now:      time.Date(2024, 1, 1, 7, 59, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "at-end-of-window",
+			route:    &Route{Start: "00:00", End: "08:00", Group: "apac"},
+			now:      time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "window-spanning-midnight-before-midnight",
+			route:    &Route{Start: "16:00", End: "00:00", Every: []string{"Monday"}, Group: "americas"},
+			now:      time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "window-spanning-midnight-after-midnight",
+			route:    &Route{Start: "22:00", End: "02:00", Every: []string{"Monday"}, Group: "americas"},
+			now:      time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "window-spanning-midnight-that-started-on-another-day",
+			route:    &Route{Start: "22:00", End: "02:00", Every: []string{"Tuesday"}, Group: "americas"},
+			now:      time.Date(2024, 1, 2, 1, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+		{
+			name:     "whole-day",
+			route:    &Route{Start: "00:00", End: "00:00", Every: []string{"Saturday", "Sunday"}, Group: "weekend"},
+			now:      time.Date(2024, 1, 6, 12, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "timezone",
+			route:    &Route{Start: "09:00", End: "17:00", Timezone: "Asia/Tokyo", Group: "apac"},
+			now:      time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+			expected: true,
+		},
+		{
+			name:     "other-type",
+			route:    &Route{Types: []alert.Type{alert.TypeSlack}, Start: "00:00", End: "00:00", Group: "apac"},
+			now:      time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+			expected: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.route.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if applies := scenario.route.AppliesTo(alert.TypeDiscord, scenario.now); applies != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, applies)
+			}
+		})
+	}
+}
+
+func TestConfig_GetRoute(t *testing.T) {
+	config := &Config{
+		Discord: &discord.AlertProvider{WebhookURL: "https://example.com"},
+		Routes: []*Route{
+			{Start: "00:00", End: "08:00", Group: "apac"},
+			{Start: "00:00", End: "16:00", Group: "emea"},
+		},
+	}
+	if err := config.ValidateRoutes(nil); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if route := config.GetRoute(alert.TypeDiscord, time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)); route.GetGroup("core") != "apac" {
+		t.Error("expected the first route that applies to be returned")
+	}
+	if route := config.GetRoute(alert.TypeDiscord, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)); route.GetGroup("core") != "emea" {
+		t.Error("expected the second route to be returned")
+	}
+	route := config.GetRoute(alert.TypeDiscord, time.Date(2024, 1, 1, 20, 0, 0, 0, time.UTC))
+	if route != nil {
+		t.Error("expected no route to be returned")
+	}
+	if route.GetGroup("core") != "core" || route.GetProvider(alert.TypeDiscord) != alert.TypeDiscord {
+		t.Error("expected the group and the provider to fall back to those of the alert when no route applies")
+	}
+}
+
+func TestConfig_ValidateRoutes(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *Config
+		alertTypes  []alert.Type
+		expectedErr error
+	}{
+		{
+			name: "gaps-with-default-provider",
+			config: &Config{
+				Slack:  &slack.AlertProvider{WebhookURL: "https://example.com"},
+				Routes: []*Route{{Start: "00:00", End: "08:00", Group: "apac"}},
+			},
+			alertTypes: []alert.Type{alert.TypeSlack},
+		},
+		{
+			name: "no-gaps-without-default-provider",
+			config: &Config{
+				Discord: &discord.AlertProvider{WebhookURL: "https://example.com"},
+				Routes: []*Route{
+					{Types: []alert.Type{alert.TypeSlack}, Start: "00:00", End: "12:00", Provider: alert.TypeDiscord},
+					{Types: []alert.Type{alert.TypeSlack}, Start: "12:00", End: "00:00", Provider: alert.TypeDiscord, Group: "americas"},
+				},
+			},
+			alertTypes: []alert.Type{alert.TypeSlack},
+		},
+		{
+			name: "gaps-without-default-provider",
+			config: &Config{
+				Discord: &discord.AlertProvider{WebhookURL: "https://example.com"},
+				Routes:  []*Route{{Start: "00:00", End: "08:00", Every: []string{"Monday"}, Provider: alert.TypeDiscord}},
+			},
+			alertTypes:  []alert.Type{alert.TypeSlack},
+			expectedErr: ErrRoutesWithGaps,
+		},
+		{
+			name: "group-only-route-without-default-provider",
+			config: &Config{
+				Routes: []*Route{{Start: "00:00", End: "00:00", Group: "apac"}},
+			},
+			alertTypes:  []alert.Type{alert.TypeSlack},
+			expectedErr: ErrRoutesWithGaps,
+		},
+		{
+			name: "unconfigured-provider",
+			config: &Config{
+				Routes: []*Route{{Start: "00:00", End: "08:00", Provider: alert.TypeDiscord}},
+			},
+			expectedErr: ErrRouteWithUnconfiguredProvider,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateRoutes(scenario.alertTypes); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
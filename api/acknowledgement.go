@@ -0,0 +1,98 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AlertAcknowledgement is the acknowledgement state of a single alert of an endpoint
+type AlertAcknowledgement struct {
+	Type            alert.Type             `json:"type"`
+	Description     string                 `json:"description,omitempty"`
+	Triggered       bool                   `json:"triggered"`
+	Acknowledgement *alert.Acknowledgement `json:"acknowledgement,omitempty"`
+}
+
+// EndpointAlertAcknowledgements handles requests to retrieve the acknowledgement state of the alerts of an endpoint
+func EndpointAlertAcknowledgements(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		endpoint := cfg.GetEndpointByKey(c.Params("key"))
+		if endpoint == nil {
+			return c.Status(404).SendString("endpoint not found")
+		}
+		acknowledgements := make([]AlertAcknowledgement, 0, len(endpoint.Alerts))
+		watchdog.WithAlertState(endpoint, func() {
+			for _, endpointAlert := range endpoint.Alerts {
+				acknowledgements = append(acknowledgements, newAlertAcknowledgement(endpointAlert))
+			}
+		})
+		return c.Status(200).JSON(acknowledgements)
+	}
+}
+
+// AcknowledgeEndpointAlerts handles requests to acknowledge the ongoing alerts of an endpoint
+//
+// The alerts are acknowledged by the user the request was authenticated as, and the optional "duration" query parameter
+// is how long the acknowledgement lasts. If no duration is specified, the acknowledgement lasts until the alerts are
+// resolved.
+func AcknowledgeEndpointAlerts(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		endpoint := cfg.GetEndpointByKey(c.Params("key"))
+		if endpoint == nil {
+			return c.Status(404).SendString("endpoint not found")
+		}
+		var duration time.Duration
+		if len(c.Query("duration")) > 0 {
+			var err error
+			if duration, err = time.ParseDuration(c.Query("duration")); err != nil || duration <= 0 {
+				return c.Status(400).SendString("duration must be a valid positive duration (e.g. 2h)")
+			}
+		}
+		by := cfg.Security.GetUsername(c)
+		acknowledgements := make([]AlertAcknowledgement, 0, len(endpoint.Alerts))
+		// The state of the alerts is also modified by the watchdog whenever the endpoint is evaluated
+		watchdog.WithAlertState(endpoint, func() {
+			for _, endpointAlert := range endpoint.Alerts {
+				if !isOngoing(endpoint, endpointAlert) {
+					continue
+				}
+				endpointAlert.Acknowledge(by, duration)
+				acknowledgements = append(acknowledgements, newAlertAcknowledgement(endpointAlert))
+			}
+		})
+		if len(acknowledgements) == 0 {
+			return c.Status(409).SendString("endpoint has no ongoing alert to acknowledge")
+		}
+		log.Printf("[api][AcknowledgeEndpointAlerts] Acknowledged %d alert(s) for endpoint with key=%s", len(acknowledgements), endpoint.Key())
+		return c.Status(200).JSON(acknowledgements)
+	}
+}
+
+// isOngoing returns whether the alert has been triggered, or would have been triggered if it could've been sent
+//
+// Must be called through watchdog.WithAlertState.
+func isOngoing(endpoint *core.Endpoint, endpointAlert *alert.Alert) bool {
+	numberOfFailuresInARow := endpoint.NumberOfFailuresInARow
+	if endpointAlert.IsScopedToConditions() {
+		numberOfFailuresInARow = endpointAlert.NumberOfFailuresInARow
+	}
+	return endpointAlert.IsEnabled() && (endpointAlert.Triggered || numberOfFailuresInARow >= endpointAlert.FailureThreshold)
+}
+
+func newAlertAcknowledgement(endpointAlert *alert.Alert) AlertAcknowledgement {
+	acknowledgement := AlertAcknowledgement{
+		Type:        endpointAlert.Type,
+		Description: endpointAlert.GetDescription(),
+		Triggered:   endpointAlert.Triggered,
+	}
+	if endpointAlert.IsAcknowledged() {
+		acknowledgement.Acknowledgement = endpointAlert.Acknowledgement
+	}
+	return acknowledgement
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestAcknowledgeEndpointAlerts(t *testing.T) {
+	cfg := newConfigWithSecurity()
+	cfg.Endpoints = []*core.Endpoint{
+		{
+			Name:  "frontend",
+			Group: "core",
+			Alerts: []*alert.Alert{
+				{Type: alert.TypeSlack, FailureThreshold: 3, Triggered: true},
+				{Type: alert.TypePagerDuty, FailureThreshold: 5},
+			},
+			NumberOfFailuresInARow: 3,
+		},
+		{
+			Name:  "backend",
+			Group: "core",
+			Alerts: []*alert.Alert{
+				{Type: alert.TypeSlack, FailureThreshold: 3},
+			},
+		},
+	}
+	api := New(cfg)
+	router := api.Router()
+	type Scenario struct {
+		Name                          string
+		Method                        string
+		Path                          string
+		ExpectedCode                  int
+		ExpectedNumberOfAcknowledged  int
+		ExpectedNumberOfAlertsInReply int
+	}
+	scenarios := []Scenario{
+		{
+			Name:                          "acknowledgements-before-acknowledging",
+			Method:                        http.MethodGet,
+			Path:                          "/api/v1/endpoints/core_frontend/acknowledgements",
+			ExpectedCode:                  http.StatusOK,
+			ExpectedNumberOfAcknowledged:  0,
+			ExpectedNumberOfAlertsInReply: 2,
+		},
+		{
+			Name:         "acknowledge-invalid-key",
+			Method:       http.MethodPost,
+			Path:         "/api/v1/endpoints/invalid_key/acknowledge",
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "acknowledge-invalid-duration",
+			Method:       http.MethodPost,
+			Path:         "/api/v1/endpoints/core_frontend/acknowledge?duration=invalid",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "acknowledge-endpoint-with-no-ongoing-alert",
+			Method:       http.MethodPost,
+			Path:         "/api/v1/endpoints/core_backend/acknowledge",
+			ExpectedCode: http.StatusConflict,
+		},
+		{
+			Name:                          "acknowledge",
+			Method:                        http.MethodPost,
+			Path:                          "/api/v1/endpoints/core_frontend/acknowledge?by=jane.doe&duration=1h",
+			ExpectedCode:                  http.StatusOK,
+			ExpectedNumberOfAcknowledged:  1,
+			ExpectedNumberOfAlertsInReply: 1,
+		},
+		{
+			Name:                          "acknowledgements-after-acknowledging",
+			Method:                        http.MethodGet,
+			Path:                          "/api/v1/endpoints/core_frontend/acknowledgements",
+			ExpectedCode:                  http.StatusOK,
+			ExpectedNumberOfAcknowledged:  1,
+			ExpectedNumberOfAlertsInReply: 2,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var acknowledgements []AlertAcknowledgement
+			if err := json.Unmarshal(body, &acknowledgements); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if len(acknowledgements) != scenario.ExpectedNumberOfAlertsInReply {
+				t.Errorf("expected %d alerts, got %d", scenario.ExpectedNumberOfAlertsInReply, len(acknowledgements))
+			}
+			numberOfAcknowledged := 0
+			for _, acknowledgement := range acknowledgements {
+				if acknowledgement.Acknowledgement != nil {
+					numberOfAcknowledged++
+					// The alerts must be acknowledged by the authenticated user rather than by whoever the request claims
+					if acknowledgement.Acknowledgement.By != "john.doe" {
+						t.Errorf("expected acknowledgement to be by %s, got %s", "john.doe", acknowledgement.Acknowledgement.By)
+					}
+					if acknowledgement.Acknowledgement.ExpiresAt == nil {
+						t.Error("expected acknowledgement to have an expiration")
+					}
+				}
+			}
+			if numberOfAcknowledged != scenario.ExpectedNumberOfAcknowledged {
+				t.Errorf("expected %d acknowledged alerts, got %d", scenario.ExpectedNumberOfAcknowledged, numberOfAcknowledged)
+			}
+		})
+	}
+}
+
+func TestAcknowledgeEndpointAlertsWhileHandlingAlerting(t *testing.T) {
+	t.Setenv("MOCK_ALERT_PROVIDER", "true")
+	endpoint := &core.Endpoint{
+		Name:       "frontend",
+		Group:      "core",
+		Conditions: []core.Condition{"[STATUS] == 200"},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack, FailureThreshold: 1, SuccessThreshold: 1},
+			{Type: alert.TypeSlack, FailureThreshold: 1, SuccessThreshold: 1, Conditions: []string{"[STATUS]"}},
+		},
+	}
+	cfg := newConfigWithSecurity()
+	cfg.Endpoints = []*core.Endpoint{endpoint}
+	cfg.Alerting = &alerting.Config{Slack: &slack.AlertProvider{WebhookURL: "https://example.com"}}
+	api := New(cfg)
+	router := api.Router()
+	// The watchdog modifies the state of the alerts while the API acknowledges them, which must not be a data race
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			result := &core.Result{Success: i%2 == 1, ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: i%2 == 1}}}
+			result.RestoreConditions(endpoint.Conditions)
+			watchdog.HandleAlerting(endpoint, result, cfg.Alerting, false, false)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/endpoints/core_frontend/acknowledge", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusConflict {
+			t.Fatalf("expected %d or %d, got %d", http.StatusOK, http.StatusConflict, response.StatusCode)
+		}
+	}
+	<-done
+}
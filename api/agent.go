@@ -0,0 +1,40 @@
+package api
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ReportAgentResult handles requests from agents reporting the result of a check of an endpoint assigned to them
+//
+// Agents authenticate with their token rather than through the security configuration, and may only report results
+// for the endpoints that have them in their agents.
+func ReportAgentResult(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		reportingAgent := agent.Authenticate(cfg.Agents, strings.TrimPrefix(c.Get("Authorization"), "Bearer "))
+		if reportingAgent == nil {
+			return c.Status(401).SendString("invalid agent token")
+		}
+		var report agent.Report
+		if err := json.Unmarshal(c.Body(), &report); err != nil || report.Result == nil {
+			return c.Status(400).SendString("body must be a report with a result")
+		}
+		endpoint := cfg.GetEndpointByKey(report.Key)
+		if endpoint == nil {
+			return c.Status(404).SendString("endpoint not found")
+		}
+		if !endpoint.IsCheckedByAgent(reportingAgent.Name) {
+			return c.Status(403).SendString("endpoint is not assigned to agent")
+		}
+		if !endpoint.IsEnabled() {
+			return c.Status(409).SendString("endpoint is disabled")
+		}
+		watchdog.HandleAgentResult(endpoint, report.ToResult(reportingAgent.Name), cfg)
+		return c.SendStatus(200)
+	}
+}
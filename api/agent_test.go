@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestReportAgentResult(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Agents: []*agent.Agent{
+			{Name: "eu-west", Token: "eu-west-token"},
+			{Name: "us-east", Token: "us-east-token"},
+		},
+		Endpoints: []*core.Endpoint{
+			{Name: "frontend", Group: "core", Agents: []string{"eu-west"}, AgentFailureThreshold: 1},
+		},
+		Maintenance: &maintenance.Config{},
+	}
+	router := New(cfg).Router()
+	type Scenario struct {
+		Name         string
+		Token        string
+		Body         interface{}
+		ExpectedCode int
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "no-token",
+			Body:         agent.Report{Key: "core_frontend", Result: &core.Result{Success: true}},
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "invalid-token",
+			Token:        "invalid",
+			Body:         agent.Report{Key: "core_frontend", Result: &core.Result{Success: true}},
+			ExpectedCode: http.StatusUnauthorized,
+		},
+		{
+			Name:         "no-result",
+			Token:        "eu-west-token",
+			Body:         agent.Report{Key: "core_frontend"},
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "unknown-endpoint",
+			Token:        "eu-west-token",
+			Body:         agent.Report{Key: "core_backend", Result: &core.Result{Success: true}},
+			ExpectedCode: http.StatusNotFound,
+		},
+		{
+			Name:         "endpoint-not-assigned-to-agent",
+			Token:        "us-east-token",
+			Body:         agent.Report{Key: "core_frontend", Result: &core.Result{Success: true}},
+			ExpectedCode: http.StatusForbidden,
+		},
+		{
+			Name:         "valid",
+			Token:        "eu-west-token",
+			Body:         agent.Report{Key: "core_frontend", Result: &core.Result{Success: true}},
+			ExpectedCode: http.StatusOK,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			body, _ := json.Marshal(scenario.Body)
+			request := httptest.NewRequest(http.MethodPost, agent.ResultsPath, bytes.NewReader(body))
+			if len(scenario.Token) > 0 {
+				request.Header.Set("Authorization", "Bearer "+scenario.Token)
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+		})
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey("core_frontend", paging.NewEndpointStatusParams().WithResults(1, 10))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || endpointStatus.Results[0].Agent != "eu-west" {
+		t.Errorf("expected 1 result reported by agent eu-west, got %v", endpointStatus.Results)
+	}
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AlertingSilence is the response returned by the alerting silence handlers
+type AlertingSilence struct {
+	// Silenced is whether all alerts are currently silenced
+	Silenced bool `json:"silenced"`
+
+	// Until is the time until which all alerts are silenced, if Silenced is true
+	Until *time.Time `json:"until,omitempty"`
+}
+
+// GetAlertingSilence handles requests to retrieve whether all alerts are currently silenced
+func GetAlertingSilence(c *fiber.Ctx) error {
+	silencedUntil, err := store.Get().GetAlertingSilence()
+	if err != nil {
+		log.Printf("[api][GetAlertingSilence] Failed to retrieve alerting silence: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).JSON(newAlertingSilence(silencedUntil))
+}
+
+// SilenceAlerting handles requests to silence all alerts for the duration specified by the duration query parameter
+func SilenceAlerting(c *fiber.Ctx) error {
+	duration, err := time.ParseDuration(c.Query("duration"))
+	if err != nil || duration <= 0 {
+		return c.Status(400).SendString("duration must be a valid positive duration (e.g. 2h)")
+	}
+	silencedUntil := time.Now().Add(duration)
+	if err = store.Get().SetAlertingSilence(silencedUntil); err != nil {
+		log.Printf("[api][SilenceAlerting] Failed to silence alerting: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Printf("[api][SilenceAlerting] Silenced all alerts until %s", silencedUntil.Format(time.RFC3339))
+	return c.Status(200).JSON(newAlertingSilence(silencedUntil))
+}
+
+// UnsilenceAlerting handles requests to clear the silence on all alerts
+func UnsilenceAlerting(c *fiber.Ctx) error {
+	if err := store.Get().SetAlertingSilence(time.Time{}); err != nil {
+		log.Printf("[api][UnsilenceAlerting] Failed to clear alerting silence: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Println("[api][UnsilenceAlerting] Cleared alerting silence")
+	return c.Status(200).JSON(newAlertingSilence(time.Time{}))
+}
+
+func newAlertingSilence(silencedUntil time.Time) AlertingSilence {
+	if !time.Now().Before(silencedUntil) {
+		return AlertingSilence{Silenced: false}
+	}
+	return AlertingSilence{Silenced: true, Until: &silencedUntil}
+}
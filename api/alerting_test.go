@@ -0,0 +1,101 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestAlertingSilence(t *testing.T) {
+	defer store.Get().Clear()
+	api := New(newConfigWithSecurity())
+	router := api.Router()
+	type Scenario struct {
+		Name             string
+		Method           string
+		Path             string
+		ExpectedCode     int
+		ExpectedSilenced bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:             "not-silenced",
+			Method:           http.MethodGet,
+			Path:             "/api/v1/alerting/silence",
+			ExpectedCode:     http.StatusOK,
+			ExpectedSilenced: false,
+		},
+		{
+			Name:         "silence-without-duration",
+			Method:       http.MethodPost,
+			Path:         "/api/v1/alerting/silence",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "silence-with-invalid-duration",
+			Method:       http.MethodPost,
+			Path:         "/api/v1/alerting/silence?duration=-2h",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:             "silence",
+			Method:           http.MethodPost,
+			Path:             "/api/v1/alerting/silence?duration=2h",
+			ExpectedCode:     http.StatusOK,
+			ExpectedSilenced: true,
+		},
+		{
+			Name:             "silenced",
+			Method:           http.MethodGet,
+			Path:             "/api/v1/alerting/silence",
+			ExpectedCode:     http.StatusOK,
+			ExpectedSilenced: true,
+		},
+		{
+			Name:             "unsilence",
+			Method:           http.MethodDelete,
+			Path:             "/api/v1/alerting/silence",
+			ExpectedCode:     http.StatusOK,
+			ExpectedSilenced: false,
+		},
+		{
+			Name:             "unsilenced",
+			Method:           http.MethodGet,
+			Path:             "/api/v1/alerting/silence",
+			ExpectedCode:     http.StatusOK,
+			ExpectedSilenced: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var silence AlertingSilence
+			if err := json.Unmarshal(body, &silence); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if silence.Silenced != scenario.ExpectedSilenced {
+				t.Errorf("expected silenced to be %v, got %v", scenario.ExpectedSilenced, silence.Silenced)
+			}
+			if silence.Silenced && silence.Until == nil {
+				t.Error("expected until to be set")
+			}
+		})
+	}
+}
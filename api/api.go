@@ -5,6 +5,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/TwiN/gatus/v5/config"
 	static "github.com/TwiN/gatus/v5/web"
@@ -59,6 +60,15 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	}
 	// Define main router
 	apiRouter := app.Group("/api")
+	if cfg.UI != nil && cfg.UI.CORS != nil {
+		// Must be applied before the security middleware, otherwise preflight requests would be rejected
+		apiRouter.Use(cors.New(cors.Config{
+			AllowOrigins:     strings.Join(cfg.UI.CORS.AllowedOrigins, ","),
+			AllowMethods:     strings.Join(cfg.UI.CORS.AllowedMethods, ","),
+			AllowHeaders:     strings.Join(cfg.UI.CORS.AllowedHeaders, ","),
+			AllowCredentials: cfg.UI.CORS.AllowCredentials,
+		}))
+	}
 	////////////////////////
 	// UNPROTECTED ROUTES //
 	////////////////////////
@@ -68,6 +78,10 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/uptimes/:duration/badge.svg", UptimeBadge)
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/badge.svg", ResponseTimeBadge(cfg))
 	unprotectedAPIRouter.Get("/v1/endpoints/:key/response-times/:duration/chart.svg", ResponseTimeChart)
+	// Unprotected so that Gatus can monitor itself even if security is configured
+	unprotectedAPIRouter.Get("/v1/self/health", SelfHealth(cfg))
+	// Unprotected so that agents can report results with their token, which is checked by the handler itself
+	unprotectedAPIRouter.Post("/v1/agents/results", ReportAgentResult(cfg))
 	// SPA
 	app.Get("/", SinglePageApplication(cfg.UI))
 	app.Get("/endpoints/:name", SinglePageApplication(cfg.UI))
@@ -77,6 +91,8 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 		statusCode, body := healthHandler.GetResponseStatusCodeAndBody()
 		return c.Status(statusCode).Send(body)
 	})
+	app.Get("/livez", Liveness)
+	app.Get("/readyz", Readiness(cfg))
 	// Everything else falls back on static content
 	app.Use(redirect.New(redirect.Config{
 		Rules: map[string]string{
@@ -107,6 +123,20 @@ func (a *API) createRouter(cfg *config.Config) *fiber.App {
 		}
 	}
 	protectedAPIRouter.Get("/v1/endpoints/statuses", EndpointStatuses(cfg))
-	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus)
+	protectedAPIRouter.Post("/v1/endpoints/probe", RequireSecurity(cfg.Security), ProbeRateLimiter(cfg.Security), ProbeEndpoint)
+	protectedAPIRouter.Get("/v1/endpoints/:key/statuses", EndpointStatus(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/events", EndpointEvents)
+	protectedAPIRouter.Get("/v1/endpoints/:key/annotations", EndpointAnnotations)
+	protectedAPIRouter.Post("/v1/endpoints/:key/annotations", RequireSecurity(cfg.Security), CreateEndpointAnnotation)
+	protectedAPIRouter.Get("/v1/endpoints/:key/response-time", EndpointResponseTime(cfg))
+	protectedAPIRouter.Get("/v1/endpoints/:key/acknowledgements", EndpointAlertAcknowledgements(cfg))
+	protectedAPIRouter.Post("/v1/endpoints/:key/acknowledge", RequireSecurity(cfg.Security), AcknowledgeEndpointAlerts(cfg))
+	protectedAPIRouter.Get("/v1/groups", GroupHealths)
+	protectedAPIRouter.Get("/v1/maintenance/status", GetMaintenanceStatus(cfg))
+	protectedAPIRouter.Get("/v1/alerting/silence", GetAlertingSilence)
+	protectedAPIRouter.Post("/v1/alerting/silence", RequireSecurity(cfg.Security), SilenceAlerting)
+	protectedAPIRouter.Delete("/v1/alerting/silence", RequireSecurity(cfg.Security), UnsilenceAlerting)
+	protectedAPIRouter.Get("/v1/config/storage", GetStorageLimits)
+	protectedAPIRouter.Put("/v1/config/storage", RequireSecurity(cfg.Security), UpdateStorageLimits)
 	return app
 }
@@ -11,6 +11,19 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// newConfigWithSecurity returns a configuration with basic authentication, which is required by the routes that change
+// the state of Gatus. Requests must be authenticated with the username john.doe and the password hunter2.
+func newConfigWithSecurity() *config.Config {
+	return &config.Config{
+		Security: &security.Config{
+			Basic: &security.BasicConfig{
+				Username:                        "john.doe",
+				PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+			},
+		},
+	}
+}
+
 func TestNew(t *testing.T) {
 	type Scenario struct {
 		Name         string
@@ -119,3 +132,107 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestNewWithoutSecurity(t *testing.T) {
+	api := New(&config.Config{})
+	router := api.Router()
+	type Scenario struct {
+		Method string
+		Path   string
+	}
+	scenarios := []Scenario{
+		{Method: http.MethodPost, Path: "/api/v1/endpoints/probe"},
+		{Method: http.MethodPost, Path: "/api/v1/endpoints/group_name/annotations"},
+		{Method: http.MethodPost, Path: "/api/v1/endpoints/group_name/acknowledge"},
+		{Method: http.MethodPost, Path: "/api/v1/alerting/silence?duration=2h"},
+		{Method: http.MethodDelete, Path: "/api/v1/alerting/silence"},
+		{Method: http.MethodPut, Path: "/api/v1/config/storage"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Method+" "+scenario.Path, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != http.StatusForbidden {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, http.StatusForbidden, response.StatusCode)
+			}
+		})
+	}
+}
+
+func TestNewWithCORS(t *testing.T) {
+	type Scenario struct {
+		Name                     string
+		CORS                     *ui.CORSConfig
+		Method                   string
+		Origin                   string
+		ExpectedAllowOrigin      string
+		ExpectedAllowCredentials string
+	}
+	scenarios := []Scenario{
+		{
+			Name:                "no-cors",
+			CORS:                nil,
+			Method:              "GET",
+			Origin:              "https://example.org",
+			ExpectedAllowOrigin: "",
+		},
+		{
+			Name:                "allowed-origin",
+			CORS:                &ui.CORSConfig{AllowedOrigins: []string{"https://example.org"}},
+			Method:              "GET",
+			Origin:              "https://example.org",
+			ExpectedAllowOrigin: "https://example.org",
+		},
+		{
+			Name:                "disallowed-origin",
+			CORS:                &ui.CORSConfig{AllowedOrigins: []string{"https://example.org"}},
+			Method:              "GET",
+			Origin:              "https://example.com",
+			ExpectedAllowOrigin: "",
+		},
+		{
+			Name:                "wildcard",
+			CORS:                &ui.CORSConfig{AllowedOrigins: []string{"*"}},
+			Method:              "GET",
+			Origin:              "https://example.com",
+			ExpectedAllowOrigin: "*",
+		},
+		{
+			Name:                     "preflight-with-credentials",
+			CORS:                     &ui.CORSConfig{AllowedOrigins: []string{"https://example.org"}, AllowCredentials: true},
+			Method:                   "OPTIONS",
+			Origin:                   "https://example.org",
+			ExpectedAllowOrigin:      "https://example.org",
+			ExpectedAllowCredentials: "true",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			cfg := &config.Config{UI: &ui.Config{CORS: scenario.CORS}}
+			if scenario.CORS != nil {
+				if err := scenario.CORS.ValidateAndSetDefaults(); err != nil {
+					t.Fatal("expected no error, got", err.Error())
+				}
+			}
+			router := New(cfg).Router()
+			request := httptest.NewRequest(scenario.Method, "/api/v1/config", http.NoBody)
+			request.Header.Set("Origin", scenario.Origin)
+			if scenario.Method == "OPTIONS" {
+				request.Header.Set("Access-Control-Request-Method", "GET")
+			}
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := response.Header.Get("Access-Control-Allow-Origin"); actual != scenario.ExpectedAllowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin to be %q, got %q", scenario.ExpectedAllowOrigin, actual)
+			}
+			if actual := response.Header.Get("Access-Control-Allow-Credentials"); actual != scenario.ExpectedAllowCredentials {
+				t.Errorf("expected Access-Control-Allow-Credentials to be %q, got %q", scenario.ExpectedAllowCredentials, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maximumAnnotationNoteLength is the maximum number of characters of the note of an annotation
+const maximumAnnotationNoteLength = 1024
+
+// EndpointAnnotations retrieves the annotations of an endpoint, optionally restricted to a time range through the from
+// and to query parameters (RFC3339)
+func EndpointAnnotations(c *fiber.Ctx) error {
+	from, err := extractTimeFromRequest(c, "from", time.Time{})
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	to, err := extractTimeFromRequest(c, "to", time.Now())
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	annotations, err := store.Get().GetEndpointAnnotationsByKey(c.Params("key"), from, to)
+	if err != nil {
+		if err == common.ErrEndpointNotFound {
+			return c.Status(404).SendString(err.Error())
+		} else if err == common.ErrInvalidTimeRange {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api][EndpointAnnotations] Failed to retrieve endpoint annotations: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).JSON(annotations)
+}
+
+// CreateEndpointAnnotation handles requests to attach a note to an endpoint at the current time, so that it shows on
+// the timeline of the endpoint along with its events
+//
+// The request body must be a JSON object with the note, and optionally the name of its author
+// (e.g. {"note": "rolling back deploy", "author": "john.doe"}).
+func CreateEndpointAnnotation(c *fiber.Ctx) error {
+	var request struct {
+		Note   string `json:"note"`
+		Author string `json:"author"`
+	}
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid annotation: " + err.Error())
+	}
+	annotation := &core.Annotation{
+		Timestamp: time.Now(),
+		Note:      strings.TrimSpace(request.Note),
+		Author:    strings.TrimSpace(request.Author),
+	}
+	if len(annotation.Note) == 0 {
+		return c.Status(400).SendString("note must not be empty")
+	}
+	if len([]rune(annotation.Note)) > maximumAnnotationNoteLength {
+		return c.Status(400).SendString(fmt.Sprintf("note must not be longer than %d characters", maximumAnnotationNoteLength))
+	}
+	if err := store.Get().InsertEndpointAnnotation(c.Params("key"), annotation); err != nil {
+		if err == common.ErrEndpointNotFound {
+			return c.Status(404).SendString(err.Error())
+		}
+		log.Printf("[api][CreateEndpointAnnotation] Failed to insert endpoint annotation: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Printf("[api][CreateEndpointAnnotation] Created annotation for endpoint with key=%s", c.Params("key"))
+	return c.Status(201).JSON(annotation)
+}
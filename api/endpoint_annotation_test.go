@@ -0,0 +1,172 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestCreateEndpointAnnotation(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	_ = store.Get().Insert(&core.Endpoint{Name: "name", Group: "group"}, &core.Result{Success: true, Timestamp: time.Now()})
+	api := New(newConfigWithSecurity())
+	router := api.Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		Body         string
+		ExpectedCode int
+		ExpectedBody string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "valid",
+			Path:         "/api/v1/endpoints/group_name/annotations",
+			Body:         `{"note": "rolling back deploy", "author": "john.doe"}`,
+			ExpectedCode: http.StatusCreated,
+		},
+		{
+			Name:         "empty-note",
+			Path:         "/api/v1/endpoints/group_name/annotations",
+			Body:         `{"note": "   "}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "note must not be empty",
+		},
+		{
+			Name:         "note-too-long",
+			Path:         "/api/v1/endpoints/group_name/annotations",
+			Body:         `{"note": "` + strings.Repeat("a", maximumAnnotationNoteLength+1) + `"}`,
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "note must not be longer than 1024 characters",
+		},
+		{
+			Name:         "invalid-body",
+			Path:         "/api/v1/endpoints/group_name/annotations",
+			Body:         `rolling back deploy`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/annotations",
+			Body:         `{"note": "rolling back deploy"}`,
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "endpoint not found",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", scenario.Path, strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if len(scenario.ExpectedBody) > 0 && string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\n\ngot:\n%s", scenario.ExpectedBody, string(body))
+			}
+		})
+	}
+	// The annotation created must be returned by the annotations API, as well as along with the events of the endpoint
+	request := httptest.NewRequest("GET", "/api/v1/endpoints/group_name/annotations", http.NoBody)
+	request.SetBasicAuth("john.doe", "hunter2")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer response.Body.Close()
+	var annotations []*core.Annotation
+	if err = json.NewDecoder(response.Body).Decode(&annotations); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(annotations) != 1 || annotations[0].Note != "rolling back deploy" || annotations[0].Author != "john.doe" {
+		t.Errorf("expected the annotation created to be returned, got %v", annotations)
+	}
+	endpointStatus, err := store.Get().GetEndpointStatusByKey("group_name", paging.NewEndpointStatusParams().WithEvents(1, 50))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Annotations) != 1 {
+		t.Errorf("expected the endpoint status to have 1 annotation, got %d", len(endpointStatus.Annotations))
+	}
+}
+
+func TestEndpointAnnotations(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	_ = store.Get().Insert(&core.Endpoint{Name: "name", Group: "group"}, &core.Result{Success: true, Timestamp: time.Now()})
+	_ = store.Get().InsertEndpointAnnotation("group_name", &core.Annotation{Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Note: "deploying"})
+	_ = store.Get().InsertEndpointAnnotation("group_name", &core.Annotation{Timestamp: time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC), Note: "rolling back deploy", Author: "john.doe"})
+	api := New(&config.Config{Metrics: true})
+	router := api.Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "all",
+			Path:         "/api/v1/endpoints/group_name/annotations",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"timestamp":"2024-01-01T00:00:00Z","note":"deploying"},{"timestamp":"2024-01-01T01:00:00Z","note":"rolling back deploy","author":"john.doe"}]`,
+		},
+		{
+			Name:         "time-range",
+			Path:         "/api/v1/endpoints/group_name/annotations?from=2024-01-01T00:30:00Z&to=2024-01-01T03:00:00Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"timestamp":"2024-01-01T01:00:00Z","note":"rolling back deploy","author":"john.doe"}]`,
+		},
+		{
+			Name:         "time-range-without-annotations",
+			Path:         "/api/v1/endpoints/group_name/annotations?from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[]`,
+		},
+		{
+			Name:         "from-after-to",
+			Path:         "/api/v1/endpoints/group_name/annotations?from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "'from' cannot be older than 'to'",
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/annotations",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "endpoint not found",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n%s\n\ngot:\n%s", scenario.ExpectedBody, string(body))
+			}
+		})
+	}
+}
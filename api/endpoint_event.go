@@ -0,0 +1,48 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// EndpointEvents retrieves the events of an endpoint, optionally restricted to a time range through the from and to
+// query parameters (RFC3339)
+//
+// The first page contains the most recent events, and the events of each page are sorted in chronological order.
+func EndpointEvents(c *fiber.Ctx) error {
+	from, err := extractTimeFromRequest(c, "from", time.Time{})
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	to, err := extractTimeFromRequest(c, "to", time.Now())
+	if err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	page, pageSize := extractPageAndPageSizeFromRequest(c)
+	events, err := store.Get().GetEndpointEventsByKey(c.Params("key"), from, to, page, pageSize)
+	if err != nil {
+		if err == common.ErrEndpointNotFound {
+			return c.Status(404).SendString(err.Error())
+		} else if err == common.ErrInvalidTimeRange {
+			return c.Status(400).SendString(err.Error())
+		}
+		log.Printf("[api][EndpointEvents] Failed to retrieve endpoint events: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	if events == nil {
+		events = []*core.Event{}
+	}
+	output, err := json.Marshal(events)
+	if err != nil {
+		log.Printf("[api][EndpointEvents] Unable to marshal object to JSON: %s", err.Error())
+		return c.Status(500).SendString("unable to marshal object to JSON")
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(200).Send(output)
+}
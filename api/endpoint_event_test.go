@@ -0,0 +1,96 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestEndpointEvents(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	endpoint := &core.Endpoint{Name: "name", Group: "group"}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	_ = store.Get().Insert(endpoint, &core.Result{Success: true, Timestamp: start})
+	_ = store.Get().Insert(endpoint, &core.Result{
+		Success:   false,
+		Timestamp: start.Add(time.Hour),
+		ConditionResults: []*core.ConditionResult{
+			{Condition: "[CONNECTED] == true", Success: true},
+			{Condition: "[STATUS] == 200", Success: false},
+		},
+	})
+	_ = store.Get().Insert(endpoint, &core.Result{Success: true, Timestamp: start.Add(2 * time.Hour)})
+	api := New(&config.Config{Metrics: true})
+	router := api.Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "time-range",
+			Path:         "/api/v1/endpoints/group_name/events?from=2024-01-01T00:30:00Z&to=2024-01-01T03:00:00Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"type":"UNHEALTHY","timestamp":"2024-01-01T01:00:00Z","failedConditions":["[STATUS] == 200"]},{"type":"HEALTHY","timestamp":"2024-01-01T02:00:00Z"}]`,
+		},
+		{
+			Name:         "time-range-with-pagination",
+			Path:         "/api/v1/endpoints/group_name/events?from=2024-01-01T00:00:00Z&to=2024-01-01T03:00:00Z&page=2&pageSize=2",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"type":"HEALTHY","timestamp":"2024-01-01T00:00:00Z"}]`,
+		},
+		{
+			Name:         "time-range-without-events",
+			Path:         "/api/v1/endpoints/group_name/events?from=2023-01-01T00:00:00Z&to=2023-01-02T00:00:00Z",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[]`,
+		},
+		{
+			Name:         "invalid-from",
+			Path:         "/api/v1/endpoints/group_name/events?from=yesterday",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "invalid value for parameter 'from': expected a time in the RFC3339 format",
+		},
+		{
+			Name:         "from-after-to",
+			Path:         "/api/v1/endpoints/group_name/events?from=2024-01-02T00:00:00Z&to=2024-01-01T00:00:00Z",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "'from' cannot be older than 'to'",
+		},
+		{
+			Name:         "invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/events",
+			ExpectedCode: http.StatusNotFound,
+			ExpectedBody: "endpoint not found",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Error("expected err to be nil, but was", err)
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n %s\n\ngot:\n %s", scenario.ExpectedBody, string(body))
+			}
+		})
+	}
+}
@@ -5,6 +5,11 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/config"
@@ -16,40 +21,194 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
+// EndpointStatusFields are the fields that can be selected through the fields query parameter of EndpointStatuses
+const (
+	EndpointStatusFieldResults = "results"
+	EndpointStatusFieldEvents  = "events"
+	EndpointStatusFieldUptime  = "uptime"
+)
+
+// EndpointStatusResponse is the representation of a core.EndpointStatus when specific fields are selected through the
+// fields query parameter of EndpointStatuses
+type EndpointStatusResponse struct {
+	Name     string             `json:"name,omitempty"`
+	Group    string             `json:"group,omitempty"`
+	Key      string             `json:"key"`
+	Results  []*core.Result     `json:"results,omitempty"`
+	Events   []*core.Event      `json:"events,omitempty"`
+	Uptime   map[string]float64 `json:"uptime,omitempty"`
+	Disabled bool               `json:"disabled,omitempty"`
+}
+
 // EndpointStatuses handles requests to retrieve all EndpointStatus
 // Due to how intensive this operation can be on the storage, this function leverages a cache.
+//
+// The endpoints can be filtered by group through the group query parameter, and paged through using the
+// endpointsPage and endpointsPageSize query parameters. The fields query parameter, a comma-separated list of
+// EndpointStatusFields, can be used to only retrieve the fields needed.
 func EndpointStatuses(cfg *config.Config) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		page, pageSize := extractPageAndPageSizeFromRequest(c)
-		value, exists := cache.Get(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize))
+		group := c.Query("group")
+		endpointsPage, endpointsPageSize, hasEndpointsPage := extractEndpointsPageAndPageSizeFromRequest(c)
+		fields, err := extractEndpointStatusFieldsFromRequest(c)
+		if err != nil {
+			return c.Status(400).SendString(err.Error())
+		}
+		cacheKey := fmt.Sprintf("endpoint-status-%d-%d-%s-%d-%d-%s", page, pageSize, group, endpointsPage, endpointsPageSize, strings.Join(fields, ","))
+		value, exists := cache.Get(cacheKey)
 		var data []byte
+		var numberOfEndpointStatuses int
 		if !exists {
-			endpointStatuses, err := store.Get().GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(page, pageSize))
+			params := paging.NewEndpointStatusParams().WithGroup(group)
+			if hasEndpointsPage {
+				params.WithEndpoints(endpointsPage, endpointsPageSize)
+			}
+			if fields == nil || containsField(fields, EndpointStatusFieldResults) {
+				params.WithResults(page, pageSize)
+			}
+			if containsField(fields, EndpointStatusFieldEvents) {
+				limits, _ := store.Get().GetLimits()
+				params.WithEvents(1, limits.MaximumNumberOfEvents)
+			}
+			endpointStatuses, err := store.Get().GetAllEndpointStatuses(params)
 			if err != nil {
 				log.Printf("[api][EndpointStatuses] Failed to retrieve endpoint statuses: %s", err.Error())
 				return c.Status(500).SendString(err.Error())
 			}
+			endpointStatuses = markDisabledEndpointStatuses(cfg, endpointStatuses, group, !hasEndpointsPage)
 			// ALPHA: Retrieve endpoint statuses from remote instances
-			if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
-				log.Printf("[handler][EndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
-			} else if endpointStatusesFromRemote != nil {
-				endpointStatuses = append(endpointStatuses, endpointStatusesFromRemote...)
+			// Because the endpoints of remote instances can't be paged through, they're only included if no endpoint
+			// page was requested
+			if !hasEndpointsPage {
+				if endpointStatusesFromRemote, err := getEndpointStatusesFromRemoteInstances(cfg.Remote); err != nil {
+					log.Printf("[handler][EndpointStatuses] Silently failed to retrieve endpoint statuses from remote: %s", err.Error())
+				} else if endpointStatusesFromRemote != nil {
+					for _, endpointStatus := range endpointStatusesFromRemote {
+						if len(group) == 0 || endpointStatus.Group == group {
+							endpointStatuses = append(endpointStatuses, endpointStatus)
+						}
+					}
+				}
 			}
+			numberOfEndpointStatuses = len(endpointStatuses)
 			// Marshal endpoint statuses to JSON
-			data, err = json.Marshal(endpointStatuses)
+			if fields == nil {
+				data, err = json.Marshal(endpointStatuses)
+			} else {
+				data, err = json.Marshal(buildEndpointStatusResponses(endpointStatuses, fields))
+			}
 			if err != nil {
 				log.Printf("[api][EndpointStatuses] Unable to marshal object to JSON: %s", err.Error())
 				return c.Status(500).SendString("unable to marshal object to JSON")
 			}
-			cache.SetWithTTL(fmt.Sprintf("endpoint-status-%d-%d", page, pageSize), data, cacheTTL)
+			cache.SetWithTTL(cacheKey, data, cacheTTL)
+			cache.SetWithTTL(cacheKey+"-count", numberOfEndpointStatuses, cacheTTL)
 		} else {
 			data = value.([]byte)
+			if count, exists := cache.Get(cacheKey + "-count"); exists {
+				numberOfEndpointStatuses = count.(int)
+			}
+		}
+		if hasEndpointsPage {
+			c.Set("X-Endpoints-Page", strconv.Itoa(endpointsPage))
+			c.Set("X-Endpoints-Page-Size", strconv.Itoa(endpointsPageSize))
+			// If the page is full, there may be more endpoints on the next page
+			if numberOfEndpointStatuses == endpointsPageSize {
+				c.Set("Link", fmt.Sprintf("<%s>; rel=\"next\"", buildNextEndpointsPageURL(c, endpointsPage)))
+			}
 		}
 		c.Set("Content-Type", "application/json")
 		return c.Status(200).Send(data)
 	}
 }
 
+// extractEndpointStatusFieldsFromRequest returns the fields selected through the fields query parameter
+//
+// If the fields query parameter is not set, nil is returned, meaning that the default fields should be returned.
+func extractEndpointStatusFieldsFromRequest(c *fiber.Ctx) ([]string, error) {
+	fieldsParameter := c.Query("fields")
+	if len(fieldsParameter) == 0 {
+		return nil, nil
+	}
+	fields := make([]string, 0)
+	for _, field := range strings.Split(fieldsParameter, ",") {
+		field = strings.TrimSpace(field)
+		switch field {
+		case EndpointStatusFieldResults, EndpointStatusFieldEvents, EndpointStatusFieldUptime:
+			if !containsField(fields, field) {
+				fields = append(fields, field)
+			}
+		case "":
+		default:
+			return nil, fmt.Errorf("invalid field '%s': supported fields are %s, %s and %s", field, EndpointStatusFieldResults, EndpointStatusFieldEvents, EndpointStatusFieldUptime)
+		}
+	}
+	sort.Strings(fields)
+	return fields, nil
+}
+
+func containsField(fields []string, field string) bool {
+	for _, f := range fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// buildEndpointStatusResponses converts endpoint statuses to EndpointStatusResponse with only the fields selected
+func buildEndpointStatusResponses(endpointStatuses []*core.EndpointStatus, fields []string) []*EndpointStatusResponse {
+	responses := make([]*EndpointStatusResponse, 0, len(endpointStatuses))
+	for _, endpointStatus := range endpointStatuses {
+		response := &EndpointStatusResponse{
+			Name:     endpointStatus.Name,
+			Group:    endpointStatus.Group,
+			Key:      endpointStatus.Key,
+			Disabled: endpointStatus.Disabled,
+		}
+		if containsField(fields, EndpointStatusFieldResults) {
+			response.Results = endpointStatus.Results
+		}
+		if containsField(fields, EndpointStatusFieldEvents) {
+			response.Events = endpointStatus.Events
+		}
+		if containsField(fields, EndpointStatusFieldUptime) {
+			response.Uptime = getUptimes(endpointStatus.Key)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
+
+// getUptimes returns the uptime of an endpoint over the last 7d, 24h and 1h
+//
+// Durations for which the uptime couldn't be retrieved (e.g. endpoints from remote instances) are omitted.
+func getUptimes(key string) map[string]float64 {
+	now := time.Now()
+	uptimes := make(map[string]float64)
+	for duration, from := range map[string]time.Time{
+		"7d":  now.Add(-7 * 24 * time.Hour),
+		"24h": now.Add(-24 * time.Hour),
+		"1h":  now.Add(-2 * time.Hour), // Because uptime metrics are stored by hour, we have to cheat a little
+	} {
+		if uptime, err := store.Get().GetUptimeByKey(key, from, now); err == nil {
+			uptimes[duration] = uptime
+		}
+	}
+	return uptimes
+}
+
+// buildNextEndpointsPageURL returns the URL of the request with the endpointsPage query parameter set to the next page
+func buildNextEndpointsPageURL(c *fiber.Ctx, endpointsPage int) string {
+	query := url.Values{}
+	c.Request().URI().QueryArgs().VisitAll(func(key, value []byte) {
+		query.Add(string(key), string(value))
+	})
+	query.Set("endpointsPage", strconv.Itoa(endpointsPage+1))
+	return c.Path() + "?" + query.Encode()
+}
+
 func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*core.EndpointStatus, error) {
 	if remoteConfig == nil || len(remoteConfig.Instances) == 0 {
 		return nil, nil
@@ -83,25 +242,67 @@ func getEndpointStatusesFromRemoteInstances(remoteConfig *remote.Config) ([]*cor
 }
 
 // EndpointStatus retrieves a single core.EndpointStatus by group and endpoint name
-func EndpointStatus(c *fiber.Ctx) error {
-	page, pageSize := extractPageAndPageSizeFromRequest(c)
-	endpointStatus, err := store.Get().GetEndpointStatusByKey(c.Params("key"), paging.NewEndpointStatusParams().WithResults(page, pageSize).WithEvents(1, common.MaximumNumberOfEvents))
-	if err != nil {
-		if err == common.ErrEndpointNotFound {
+func EndpointStatus(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		page, pageSize := extractPageAndPageSizeFromRequest(c)
+		key := c.Params("key")
+		limits, _ := store.Get().GetLimits()
+		endpointStatus, err := store.Get().GetEndpointStatusByKey(key, paging.NewEndpointStatusParams().WithResults(page, pageSize).WithEvents(1, limits.MaximumNumberOfEvents))
+		if err != nil && err != common.ErrEndpointNotFound {
+			log.Printf("[api][EndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		if endpoint := cfg.GetEndpointByKey(key); endpoint != nil && !endpoint.IsEnabled() {
+			// Disabled endpoints that have never been monitored don't have a status in the store
+			if endpointStatus == nil {
+				endpointStatus = core.NewEndpointStatus(endpoint.Group, endpoint.Name)
+			}
+			endpointStatus.Disabled = true
+		} else if err == common.ErrEndpointNotFound {
 			return c.Status(404).SendString(err.Error())
 		}
-		log.Printf("[api][EndpointStatus] Failed to retrieve endpoint status: %s", err.Error())
-		return c.Status(500).SendString(err.Error())
+		if endpointStatus == nil { // XXX: is this check necessary?
+			log.Printf("[api][EndpointStatus] Endpoint with key=%s not found", key)
+			return c.Status(404).SendString("not found")
+		}
+		output, err := json.Marshal(endpointStatus)
+		if err != nil {
+			log.Printf("[api][EndpointStatus] Unable to marshal object to JSON: %s", err.Error())
+			return c.Status(500).SendString("unable to marshal object to JSON")
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(200).Send(output)
 	}
-	if endpointStatus == nil { // XXX: is this check necessary?
-		log.Printf("[api][EndpointStatus] Endpoint with key=%s not found", c.Params("key"))
-		return c.Status(404).SendString("not found")
+}
+
+// markDisabledEndpointStatuses flags the statuses of the endpoints that are disabled in the configuration
+//
+// If includeMissing is true, a status is also added for the disabled endpoints of the group (or of every group, if
+// group is empty) that have never been monitored, and thus have no status in the store.
+func markDisabledEndpointStatuses(cfg *config.Config, endpointStatuses []*core.EndpointStatus, group string, includeMissing bool) []*core.EndpointStatus {
+	disabledEndpoints := make(map[string]*core.Endpoint)
+	for _, endpoint := range cfg.Endpoints {
+		if !endpoint.IsEnabled() && (len(group) == 0 || endpoint.Group == group) {
+			disabledEndpoints[endpoint.Key()] = endpoint
+		}
+	}
+	if len(disabledEndpoints) == 0 {
+		return endpointStatuses
+	}
+	for _, endpointStatus := range endpointStatuses {
+		if _, disabled := disabledEndpoints[endpointStatus.Key]; disabled {
+			endpointStatus.Disabled = true
+			delete(disabledEndpoints, endpointStatus.Key)
+		}
 	}
-	output, err := json.Marshal(endpointStatus)
-	if err != nil {
-		log.Printf("[api][EndpointStatus] Unable to marshal object to JSON: %s", err.Error())
-		return c.Status(500).SendString("unable to marshal object to JSON")
+	if includeMissing {
+		for _, endpoint := range cfg.Endpoints {
+			if _, missing := disabledEndpoints[endpoint.Key()]; missing {
+				endpointStatus := core.NewEndpointStatus(endpoint.Group, endpoint.Name)
+				endpointStatus.Disabled = true
+				endpointStatuses = append(endpointStatuses, endpointStatus)
+			}
+		}
 	}
-	c.Set("Content-Type", "application/json")
-	return c.Status(200).Send(output)
+	return endpointStatuses
 }
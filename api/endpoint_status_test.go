@@ -1,6 +1,7 @@
 package api
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -218,3 +219,144 @@ func TestEndpointStatuses(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointStatusesWithFiltersAndFields(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	otherEndpoint := core.Endpoint{Name: "other", Group: "other-group"}
+	thirdEndpoint := core.Endpoint{Name: "third", Group: "other-group"}
+	now := time.Now().UTC()
+	store.Get().Insert(&testEndpoint, &core.Result{Success: true, Timestamp: now})
+	store.Get().Insert(&otherEndpoint, &core.Result{Success: true, Timestamp: now})
+	store.Get().Insert(&thirdEndpoint, &core.Result{Success: false, Timestamp: now})
+	api := New(&config.Config{})
+	router := api.Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+		ExpectedLink string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "group-filter",
+			Path:         "/api/v1/endpoints/statuses?group=other-group&fields=results",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"other","group":"other-group","key":"other-group_other","results":[{"status":0,"duration":0,"conditionResults":null,"success":true,"timestamp":"` + now.Format(time.RFC3339Nano) + `"}]},{"name":"third","group":"other-group","key":"other-group_third","results":[{"status":0,"duration":0,"conditionResults":null,"success":false,"timestamp":"` + now.Format(time.RFC3339Nano) + `"}]}]`,
+		},
+		{
+			Name:         "first-endpoint-page",
+			Path:         "/api/v1/endpoints/statuses?endpointsPage=1&endpointsPageSize=2&fields=uptime",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"name","group":"group","key":"group_name","uptime":{"1h":1,"24h":1,"7d":1}},{"name":"other","group":"other-group","key":"other-group_other","uptime":{"1h":1,"24h":1,"7d":1}}]`,
+			ExpectedLink: `</api/v1/endpoints/statuses?endpointsPage=2&endpointsPageSize=2&fields=uptime>; rel="next"`,
+		},
+		{
+			Name:         "second-endpoint-page",
+			Path:         "/api/v1/endpoints/statuses?endpointsPage=2&endpointsPageSize=2&fields=uptime",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"third","group":"other-group","key":"other-group_third","uptime":{"1h":0,"24h":0,"7d":0}}]`,
+		},
+		{
+			Name:         "invalid-field",
+			Path:         "/api/v1/endpoints/statuses?fields=results,invalid",
+			ExpectedCode: http.StatusBadRequest,
+			ExpectedBody: "invalid field 'invalid': supported fields are results, events and uptime",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected err to be nil, but was", err)
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Error("expected err to be nil, but was", err)
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n %s\n\ngot:\n %s", scenario.ExpectedBody, string(body))
+			}
+			if link := response.Header.Get("Link"); link != scenario.ExpectedLink {
+				t.Errorf("expected Link header to be %s, got %s", scenario.ExpectedLink, link)
+			}
+		})
+	}
+}
+
+func TestEndpointStatusesWithDisabledEndpoints(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	disabled := false
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*core.Endpoint{
+			{Name: "frontend", Group: "core"},
+			{Name: "backend", Group: "core", Enabled: &disabled},
+			{Name: "database", Group: "core", Enabled: &disabled},
+		},
+	}
+	// The backend was monitored before being disabled, while the database has never been monitored
+	_ = store.Get().Insert(cfg.Endpoints[0], &core.Result{Success: true, Timestamp: time.Time{}})
+	_ = store.Get().Insert(cfg.Endpoints[1], &core.Result{Success: false, Timestamp: time.Time{}})
+	router := New(cfg).Router()
+	type Scenario struct {
+		Name         string
+		Path         string
+		ExpectedCode int
+		ExpectedBody string
+	}
+	scenarios := []Scenario{
+		{
+			Name:         "all-endpoints",
+			Path:         "/api/v1/endpoints/statuses?fields=results",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `[{"name":"backend","group":"core","key":"core_backend","results":[{"status":0,"duration":0,"conditionResults":null,"success":false,"timestamp":"0001-01-01T00:00:00Z"}],"disabled":true},{"name":"frontend","group":"core","key":"core_frontend","results":[{"status":0,"duration":0,"conditionResults":null,"success":true,"timestamp":"0001-01-01T00:00:00Z"}]},{"name":"database","group":"core","key":"core_database","disabled":true}]`,
+		},
+		{
+			Name:         "disabled-endpoint-without-status",
+			Path:         "/api/v1/endpoints/core_database/statuses",
+			ExpectedCode: http.StatusOK,
+			ExpectedBody: `{"name":"database","group":"core","key":"core_database","results":[],"disabled":true}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				return
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			body, err := io.ReadAll(response.Body)
+			if err != nil {
+				t.Error("expected err to be nil, but was", err)
+			}
+			if string(body) != scenario.ExpectedBody {
+				t.Errorf("expected:\n %s\n\ngot:\n %s", scenario.ExpectedBody, string(body))
+			}
+		})
+	}
+	// The events of the backend can't be compared as-is, because the START event is created with the current time
+	response, err := router.Test(httptest.NewRequest("GET", "/api/v1/endpoints/core_backend/statuses", http.NoBody))
+	if err != nil {
+		t.Fatal("expected err to be nil, but was", err)
+	}
+	defer response.Body.Close()
+	var endpointStatus core.EndpointStatus
+	if err = json.NewDecoder(response.Body).Decode(&endpointStatus); err != nil {
+		t.Fatal("expected err to be nil, but was", err)
+	}
+	if !endpointStatus.Disabled || len(endpointStatus.Results) != 1 {
+		t.Errorf("expected the disabled backend to keep its result, got disabled=%t and %d results", endpointStatus.Disabled, len(endpointStatus.Results))
+	}
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GroupHealth is the health of a group of endpoints, as returned by the GroupHealths handler
+type GroupHealth struct {
+	// Name is the name of the group
+	Name string `json:"name"`
+
+	// Health is the health of the group: healthy, degraded, down, or unknown if none of its endpoints has been evaluated
+	// yet
+	Health string `json:"health"`
+
+	// HealthyEndpoints is the number of endpoints of the group whose most recent result was successful
+	HealthyEndpoints int `json:"healthyEndpoints"`
+
+	// EvaluatedEndpoints is the number of endpoints of the group that have been evaluated at least once
+	EvaluatedEndpoints int `json:"evaluatedEndpoints"`
+
+	// TotalEndpoints is the number of enabled endpoints of the group
+	TotalEndpoints int `json:"totalEndpoints"`
+}
+
+// GroupHealths handles requests to retrieve the health of every group of endpoints, rolled up from the most recent
+// result of each of their endpoints according to the health policy of the group
+func GroupHealths(c *fiber.Ctx) error {
+	groupHealths := watchdog.GetGroupHealths()
+	response := make([]GroupHealth, 0, len(groupHealths))
+	for _, groupHealth := range groupHealths {
+		response = append(response, GroupHealth{
+			Name:               groupHealth.Name,
+			Health:             groupHealth.Health.String(),
+			HealthyEndpoints:   groupHealth.HealthyEndpoints,
+			EvaluatedEndpoints: groupHealth.EvaluatedEndpoints,
+			TotalEndpoints:     groupHealth.TotalEndpoints,
+		})
+	}
+	return c.Status(200).JSON(response)
+}
@@ -0,0 +1,62 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestGroupHealths(t *testing.T) {
+	defer store.Get().Clear()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/down" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+	endpoints := []*core.Endpoint{
+		{Name: "frontend", Group: "core", URL: server.URL + "/up", Interval: time.Hour, Conditions: []core.Condition{"[STATUS] == 200"}},
+		{Name: "backend", Group: "core", URL: server.URL + "/down", Interval: time.Hour, Conditions: []core.Condition{"[STATUS] == 200"}},
+	}
+	for _, endpoint := range endpoints {
+		if err := endpoint.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	cfg := &config.Config{
+		Endpoints:       endpoints,
+		EndpointGroups:  []*endpointgroup.Config{{Name: "core", HealthPolicy: endpointgroup.HealthPolicyAll}},
+		Maintenance:     maintenance.GetDefaultConfig(),
+		ShutdownTimeout: 5 * time.Second,
+	}
+	watchdog.Monitor(cfg)
+	time.Sleep(100 * time.Millisecond) // Give the last execution some time to start
+	watchdog.Shutdown(cfg)
+	router := New(cfg).Router()
+	response, err := router.Test(httptest.NewRequest(http.MethodGet, "/api/v1/groups", http.NoBody))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	body, _ := io.ReadAll(response.Body)
+	var groupHealths []GroupHealth
+	if err = json.Unmarshal(body, &groupHealths); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	expected := GroupHealth{Name: "core", Health: "degraded", HealthyEndpoints: 1, EvaluatedEndpoints: 2, TotalEndpoints: 2}
+	if len(groupHealths) != 1 || groupHealths[0] != expected {
+		t.Errorf("expected %+v, got %s", expected, string(body))
+	}
+}
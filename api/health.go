@@ -0,0 +1,58 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// readinessStorageTimeout is the maximum duration that the readiness handler waits for the storage to respond
+	readinessStorageTimeout = 3 * time.Second
+
+	probeStatusUp   = "UP"
+	probeStatusDown = "DOWN"
+)
+
+// probeResponse is the body of the responses of the liveness and readiness handlers
+type probeResponse struct {
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// Liveness handles requests to check whether the process is up
+//
+// This intentionally doesn't check any dependency, because a failing liveness probe causes a restart, which wouldn't
+// fix an unreachable storage anyway.
+func Liveness(c *fiber.Ctx) error {
+	return sendProbeResponse(c, fiber.StatusOK, probeResponse{Status: probeStatusUp})
+}
+
+// Readiness handles requests to check whether Gatus is ready to serve requests, meaning that the configuration has been
+// loaded and that the storage is reachable
+func Readiness(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if cfg == nil {
+			return sendProbeResponse(c, fiber.StatusServiceUnavailable, probeResponse{Status: probeStatusDown, Reason: "configuration not loaded"})
+		}
+		ctx, cancel := context.WithTimeout(c.Context(), readinessStorageTimeout)
+		defer cancel()
+		if err := store.Get().Ping(ctx); err != nil {
+			return sendProbeResponse(c, fiber.StatusServiceUnavailable, probeResponse{Status: probeStatusDown, Reason: "storage unreachable: " + err.Error()})
+		}
+		return sendProbeResponse(c, fiber.StatusOK, probeResponse{Status: probeStatusUp})
+	}
+}
+
+func sendProbeResponse(c *fiber.Ctx, statusCode int, response probeResponse) error {
+	output, err := json.Marshal(response)
+	if err != nil {
+		return c.Status(500).SendString(err.Error())
+	}
+	c.Set("Content-Type", "application/json")
+	return c.Status(statusCode).Send(output)
+}
@@ -0,0 +1,86 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestLivenessAndReadiness(t *testing.T) {
+	defer store.Get().Clear()
+	defer store.Initialize(nil)
+	type Scenario struct {
+		Name           string
+		Path           string
+		CloseStorage   bool
+		ExpectedCode   int
+		ExpectedStatus string
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "liveness",
+			Path:           "/livez",
+			ExpectedCode:   http.StatusOK,
+			ExpectedStatus: probeStatusUp,
+		},
+		{
+			Name:           "readiness",
+			Path:           "/readyz",
+			ExpectedCode:   http.StatusOK,
+			ExpectedStatus: probeStatusUp,
+		},
+		{
+			Name:           "liveness-with-unreachable-storage",
+			Path:           "/livez",
+			CloseStorage:   true,
+			ExpectedCode:   http.StatusOK,
+			ExpectedStatus: probeStatusUp,
+		},
+		{
+			Name:           "readiness-with-unreachable-storage",
+			Path:           "/readyz",
+			CloseStorage:   true,
+			ExpectedCode:   http.StatusServiceUnavailable,
+			ExpectedStatus: probeStatusDown,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := store.Initialize(&storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/test.db"}); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if scenario.CloseStorage {
+				store.Get().Close()
+			} else {
+				defer store.Get().Close()
+			}
+			router := New(&config.Config{}).Router()
+			request := httptest.NewRequest(http.MethodGet, scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("expected response code to be %d, but was %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			var probe probeResponse
+			if err := json.Unmarshal(body, &probe); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if probe.Status != scenario.ExpectedStatus {
+				t.Errorf("expected status to be %s, but was %s", scenario.ExpectedStatus, probe.Status)
+			}
+			if scenario.ExpectedStatus == probeStatusDown && len(probe.Reason) == 0 {
+				t.Error("expected a reason to be provided")
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"sort"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaintenanceStatus is the status of the maintenance windows, as returned by the GetMaintenanceStatus handler
+type MaintenanceStatus struct {
+	// Active is whether at least one maintenance window is ongoing
+	Active bool `json:"active"`
+
+	// Next is the maintenance window that starts next, excluding those that are ongoing
+	Next *MaintenanceWindow `json:"next,omitempty"`
+
+	// Windows are the maintenance windows, sorted by start
+	Windows []*MaintenanceWindow `json:"windows"`
+}
+
+// MaintenanceWindow is the current or next occurrence of a maintenance window, along with what it applies to
+type MaintenanceWindow struct {
+	// Global is whether the window is the global maintenance window, which applies to every endpoint
+	Global bool `json:"global"`
+
+	// Active is whether the window is ongoing
+	Active bool `json:"active"`
+
+	// Start is the start of the ongoing occurrence of the window, or of the next one if it isn't ongoing
+	Start time.Time `json:"start"`
+
+	// End is the end of the ongoing occurrence of the window, or of the next one if it isn't ongoing
+	End time.Time `json:"end"`
+
+	// Endpoints are the keys of the endpoints no alerts are sent for during the window, which are the endpoint the
+	// window is configured on and the endpoints that depend on it. Empty if the window is global.
+	Endpoints []string `json:"endpoints,omitempty"`
+
+	// Groups are the groups of the Endpoints
+	Groups []string `json:"groups,omitempty"`
+}
+
+// GetMaintenanceStatus handles requests to retrieve whether a maintenance window is ongoing, when the next one starts,
+// and which endpoints each window applies to
+func GetMaintenanceStatus(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		now := time.Now()
+		status := MaintenanceStatus{Windows: []*MaintenanceWindow{}}
+		if window := newMaintenanceWindow(cfg.Maintenance, now); window != nil {
+			window.Global = true
+			status.Windows = append(status.Windows, window)
+		}
+		for _, endpoint := range cfg.Endpoints {
+			if len(endpoint.MaintenanceWindows) == 0 {
+				continue
+			}
+			endpointKeys, groups := scopeOfMaintenanceWindowsOf(endpoint, cfg.Endpoints)
+			for _, maintenanceWindow := range endpoint.MaintenanceWindows {
+				if window := newMaintenanceWindow(maintenanceWindow, now); window != nil {
+					window.Endpoints, window.Groups = endpointKeys, groups
+					status.Windows = append(status.Windows, window)
+				}
+			}
+		}
+		sort.SliceStable(status.Windows, func(i, j int) bool {
+			return status.Windows[i].Start.Before(status.Windows[j].Start)
+		})
+		for _, window := range status.Windows {
+			if window.Active {
+				status.Active = true
+			} else if status.Next == nil {
+				status.Next = window
+			}
+		}
+		return c.Status(200).JSON(status)
+	}
+}
+
+// newMaintenanceWindow returns the ongoing or next occurrence of the maintenance window passed, or nil if the window
+// is disabled
+func newMaintenanceWindow(maintenanceConfig *maintenance.Config, now time.Time) *MaintenanceWindow {
+	if maintenanceConfig == nil {
+		return nil
+	}
+	start, end, ok := maintenanceConfig.GetCurrentOrNextWindow(now)
+	if !ok {
+		return nil
+	}
+	return &MaintenanceWindow{Active: !now.Before(start), Start: start, End: end}
+}
+
+// scopeOfMaintenanceWindowsOf returns the keys and the groups of the endpoints that the maintenance windows of the
+// endpoint passed apply to, which are the endpoint itself and every endpoint that depends on it, either directly or
+// transitively
+func scopeOfMaintenanceWindowsOf(endpoint *core.Endpoint, endpoints []*core.Endpoint) (keys []string, groups []string) {
+	seenGroups := make(map[string]bool)
+	for _, candidate := range endpoints {
+		if candidate != endpoint && !dependsOn(candidate, endpoint, make(map[*core.Endpoint]bool)) {
+			continue
+		}
+		keys = append(keys, candidate.Key())
+		if len(candidate.Group) > 0 && !seenGroups[candidate.Group] {
+			seenGroups[candidate.Group] = true
+			groups = append(groups, candidate.Group)
+		}
+	}
+	return keys, groups
+}
+
+// dependsOn returns whether the endpoint passed depends on the dependency passed, either directly or transitively
+func dependsOn(endpoint, dependency *core.Endpoint, visited map[*core.Endpoint]bool) bool {
+	if visited[endpoint] {
+		return false
+	}
+	visited[endpoint] = true
+	for _, directDependency := range endpoint.Dependencies() {
+		if directDependency == dependency || dependsOn(directDependency, dependency, visited) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+func TestGetMaintenanceStatus(t *testing.T) {
+	no := false
+	now := time.Now().UTC()
+	database := &core.Endpoint{Name: "database", Group: "storage", MaintenanceWindows: []*maintenance.Config{
+		{Start: fmt.Sprintf("%02d:00", (now.Hour()+3)%24), Duration: time.Hour},
+		{Enabled: &no},
+	}}
+	backend := &core.Endpoint{Name: "backend", Group: "core"}
+	backend.SetDependencies([]*core.Endpoint{database})
+	frontend := &core.Endpoint{Name: "frontend", Group: "core"}
+	frontend.SetDependencies([]*core.Endpoint{backend})
+	unrelated := &core.Endpoint{Name: "unrelated", Group: "other"}
+	cfg := &config.Config{
+		Maintenance: &maintenance.Config{Start: fmt.Sprintf("%02d:00", now.Hour()), Duration: 2 * time.Hour},
+		Endpoints:   []*core.Endpoint{frontend, backend, database, unrelated},
+	}
+	if err := cfg.Maintenance.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, maintenanceWindow := range database.MaintenanceWindows {
+		if err := maintenanceWindow.ValidateAndSetDefaults(); err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+	}
+	router := New(cfg).Router()
+	response, err := router.Test(httptest.NewRequest(http.MethodGet, "/api/v1/maintenance/status", http.NoBody))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("expected status code %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	body, _ := io.ReadAll(response.Body)
+	var status MaintenanceStatus
+	if err = json.Unmarshal(body, &status); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !status.Active {
+		t.Error("expected a maintenance window to be active, since the global one is ongoing")
+	}
+	if len(status.Windows) != 2 {
+		t.Fatalf("expected 2 windows, since the disabled one should be omitted, got %s", string(body))
+	}
+	if global := status.Windows[0]; !global.Global || !global.Active || len(global.Endpoints) != 0 {
+		t.Errorf("expected the first window to be the active global window, got %+v", global)
+	}
+	if status.Next == nil || status.Next.Global || status.Next.Active {
+		t.Fatalf("expected the next window to be the one of the database, got %s", string(body))
+	}
+	if expectedStart := now.Truncate(time.Hour).Add(3 * time.Hour); !status.Next.Start.Equal(expectedStart) || !status.Next.End.Equal(expectedStart.Add(time.Hour)) {
+		t.Errorf("expected the next window to last from %v to %v, got %v to %v", expectedStart, expectedStart.Add(time.Hour), status.Next.Start, status.Next.End)
+	}
+	if expectedEndpoints := []string{"core_frontend", "core_backend", "storage_database"}; !reflect.DeepEqual(status.Next.Endpoints, expectedEndpoints) {
+		t.Errorf("expected the next window to apply to %v, got %v", expectedEndpoints, status.Next.Endpoints)
+	}
+	if expectedGroups := []string{"core", "storage"}; !reflect.DeepEqual(status.Next.Groups, expectedGroups) {
+		t.Errorf("expected the next window to apply to the groups %v, got %v", expectedGroups, status.Next.Groups)
+	}
+}
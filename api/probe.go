@@ -0,0 +1,70 @@
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/limiter"
+)
+
+const (
+	// probeRateLimitMaximumRequests is the maximum number of probes a single client may request per
+	// probeRateLimitExpiration
+	probeRateLimitMaximumRequests = 10
+
+	// probeRateLimitExpiration is the duration after which the number of probes requested by a client is reset
+	probeRateLimitExpiration = time.Minute
+)
+
+// ProbeRequest is the inline definition of the endpoint to probe
+type ProbeRequest struct {
+	URL        string            `json:"url"`
+	Method     string            `json:"method,omitempty"`
+	Headers    map[string]string `json:"headers,omitempty"`
+	Body       string            `json:"body,omitempty"`
+	Conditions []core.Condition  `json:"conditions"`
+}
+
+// ProbeRateLimiter limits the number of probes that a single client may request, since each probe sends a request to
+// an arbitrary URL
+func ProbeRateLimiter(securityConfig *security.Config) fiber.Handler {
+	return limiter.New(limiter.Config{
+		Max:          probeRateLimitMaximumRequests,
+		Expiration:   probeRateLimitExpiration,
+		KeyGenerator: securityConfig.ClientIP,
+	})
+}
+
+// ProbeEndpoint handles requests to evaluate the health of an endpoint that isn't part of the configuration
+//
+// The endpoint is evaluated once through the same path as configured endpoints, but the result is not persisted.
+func ProbeEndpoint(c *fiber.Ctx) error {
+	var request ProbeRequest
+	if err := json.Unmarshal(c.Body(), &request); err != nil {
+		return c.Status(400).SendString("invalid probe request: " + err.Error())
+	}
+	endpoint := &core.Endpoint{
+		Name:       "probe",
+		URL:        request.URL,
+		Method:     request.Method,
+		Headers:    request.Headers,
+		Body:       request.Body,
+		Conditions: request.Conditions,
+		// The templates of the headers must not be able to read what Gatus has access to, since the probe would
+		// allow a client to send it anywhere
+		Untrusted: true,
+	}
+	if endpoint.Type() == core.EndpointTypeEXEC {
+		// Commands may only be run if they're in the configuration, and only if GATUS_ALLOW_EXEC is set to true
+		return c.Status(400).SendString("invalid endpoint: endpoints of type EXEC cannot be probed")
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		return c.Status(400).SendString("invalid endpoint: " + err.Error())
+	}
+	defer endpoint.Close()
+	result := endpoint.EvaluateHealth()
+	return c.Status(200).JSON(result)
+}
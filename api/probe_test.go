@@ -0,0 +1,139 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestProbeEndpoint(t *testing.T) {
+	// Endpoints of type EXEC must be refused even if they're allowed in the configuration
+	t.Setenv(config.AllowExecEnvironmentVariable, "true")
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		if signature := r.Header.Get("X-Signature"); len(signature) > 0 && signature != "ZXhhbXBsZS5vcmc=" {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"status":"UP"}`))}
+	})})
+	api := New(newConfigWithSecurity())
+	router := api.Router()
+	type Scenario struct {
+		Name            string
+		Body            string
+		ExpectedCode    int
+		ExpectedSuccess bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:            "success",
+			Body:            `{"url":"https://example.org/health","conditions":["[STATUS] == 200","[BODY].status == UP"]}`,
+			ExpectedCode:    http.StatusOK,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:            "failure",
+			Body:            `{"url":"https://example.org/health","method":"POST","conditions":["[STATUS] == 201"]}`,
+			ExpectedCode:    http.StatusOK,
+			ExpectedSuccess: false,
+		},
+		{
+			Name:         "no-conditions",
+			Body:         `{"url":"https://example.org/health"}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:            "header-template",
+			Body:            `{"url":"https://example.org/health","headers":{"X-Signature":"{{ base64 \"example.org\" }}"},"conditions":["[STATUS] == 200"]}`,
+			ExpectedCode:    http.StatusOK,
+			ExpectedSuccess: true,
+		},
+		{
+			Name:         "header-template-with-file",
+			Body:         `{"url":"https://example.org/health","headers":{"X-Leak":"{{ file \"/etc/passwd\" }}"},"conditions":["[STATUS] == 200"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "header-template-with-env",
+			Body:         `{"url":"https://example.org/health","headers":{"X-Leak":"{{ env \"GATUS_ALLOW_EXEC\" }}"},"conditions":["[STATUS] == 200"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "exec",
+			Body:         `{"url":"exec:///bin/sh -c id","conditions":["[STATUS] == 0"]}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "invalid-json",
+			Body:         `{"url":`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/endpoints/probe", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var result core.Result
+			if err := json.Unmarshal(body, &result); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if result.Success != scenario.ExpectedSuccess {
+				t.Errorf("expected success to be %v, got %v", scenario.ExpectedSuccess, result.Success)
+			}
+			if len(result.ConditionResults) == 0 {
+				t.Error("expected condition results to be returned")
+			}
+		})
+	}
+	// Nothing should've been persisted
+	if endpointStatuses, _ := store.Get().GetAllEndpointStatuses(nil); len(endpointStatuses) != 0 {
+		t.Errorf("expected no endpoint statuses to have been persisted, got %d", len(endpointStatuses))
+	}
+}
+
+func TestProbeEndpointIsRateLimited(t *testing.T) {
+	api := New(newConfigWithSecurity())
+	router := api.Router()
+	for i := 0; i < probeRateLimitMaximumRequests; i++ {
+		request := httptest.NewRequest(http.MethodPost, "/api/v1/endpoints/probe", strings.NewReader(`{}`))
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := router.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if response.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected %d, got %d", http.StatusBadRequest, response.StatusCode)
+		}
+	}
+	request := httptest.NewRequest(http.MethodPost, "/api/v1/endpoints/probe", strings.NewReader(`{}`))
+	request.SetBasicAuth("john.doe", "hunter2")
+	response, err := router.Test(request)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if response.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("expected %d, got %d", http.StatusTooManyRequests, response.StatusCode)
+	}
+}
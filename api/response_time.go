@@ -0,0 +1,100 @@
+package api
+
+import (
+	"log"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ResponseTimeReport is the summary of the response times of an endpoint over a window
+type ResponseTimeReport struct {
+	// Window is the period the report covers (e.g. 7d)
+	Window string `json:"window"`
+
+	// Checks is the number of checks performed during the window
+	Checks uint64 `json:"checks"`
+
+	// P50 is the median response time, in milliseconds
+	P50 int64 `json:"p50"`
+
+	// P90 is the 90th percentile of the response time, in milliseconds
+	P90 int64 `json:"p90"`
+
+	// P95 is the 95th percentile of the response time, in milliseconds
+	P95 int64 `json:"p95"`
+
+	// P99 is the 99th percentile of the response time, in milliseconds
+	P99 int64 `json:"p99"`
+
+	// Target is the response time target, in milliseconds. Zero if the endpoint has no target.
+	Target int64 `json:"target,omitempty"`
+
+	// FractionUnderTarget is the fraction of checks whose response time was under Target, between 0 and 1.
+	// Nil if the endpoint has no target.
+	FractionUnderTarget *float64 `json:"fractionUnderTarget,omitempty"`
+}
+
+// EndpointResponseTime returns the percentiles of the response time of an endpoint over the window passed through the
+// window query parameter, as well as the fraction of checks that were under the response time target of the endpoint.
+//
+// The target configured through the endpoint's ui.response-time-target can be overridden with the target query
+// parameter (e.g. 250ms).
+//
+// Valid values for window -> 30d, 7d, 24h (default), 1h
+func EndpointResponseTime(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		window := c.Query("window", "24h")
+		var from time.Time
+		switch window {
+		case "30d":
+			from = time.Now().Add(-30 * 24 * time.Hour)
+		case "7d":
+			from = time.Now().Add(-7 * 24 * time.Hour)
+		case "24h":
+			from = time.Now().Add(-24 * time.Hour)
+		case "1h":
+			from = time.Now().Add(-2 * time.Hour) // Because response time metrics are stored by hour, we have to cheat a little
+		default:
+			return c.Status(400).SendString("Windows supported: 30d, 7d, 24h, 1h")
+		}
+		key := c.Params("key")
+		var target time.Duration
+		if endpoint := cfg.GetEndpointByKey(key); endpoint != nil && endpoint.UIConfig != nil {
+			target = endpoint.UIConfig.ResponseTimeTarget
+		}
+		if targetParameter := c.Query("target"); len(targetParameter) > 0 {
+			var err error
+			if target, err = time.ParseDuration(targetParameter); err != nil || target < 0 {
+				return c.Status(400).SendString("target must be a valid duration (e.g. 250ms)")
+			}
+		}
+		histogram, err := store.Get().GetResponseTimeHistogramByKey(key, from, time.Now())
+		if err != nil {
+			if err == common.ErrEndpointNotFound {
+				return c.Status(404).SendString(err.Error())
+			} else if err == common.ErrInvalidTimeRange {
+				return c.Status(400).SendString(err.Error())
+			}
+			log.Printf("[api][EndpointResponseTime] Failed to retrieve response time histogram: %s", err.Error())
+			return c.Status(500).SendString(err.Error())
+		}
+		report := ResponseTimeReport{
+			Window: window,
+			Checks: histogram.Total(),
+			P50:    histogram.Percentile(50).Milliseconds(),
+			P90:    histogram.Percentile(90).Milliseconds(),
+			P95:    histogram.Percentile(95).Milliseconds(),
+			P99:    histogram.Percentile(99).Milliseconds(),
+		}
+		if target > 0 {
+			fractionUnderTarget := histogram.FractionUnder(target)
+			report.Target = target.Milliseconds()
+			report.FractionUnderTarget = &fractionUnderTarget
+		}
+		return c.Status(200).JSON(report)
+	}
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/core/ui"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+)
+
+func TestEndpointResponseTime(t *testing.T) {
+	defer store.Get().Clear()
+	defer cache.Clear()
+	cfg := &config.Config{
+		Metrics: true,
+		Endpoints: []*core.Endpoint{
+			{
+				Name:     "frontend",
+				Group:    "core",
+				UIConfig: &ui.Config{ResponseTimeTarget: 100 * time.Millisecond},
+			},
+			{
+				Name:  "backend",
+				Group: "core",
+			},
+		},
+	}
+	for i := 0; i < 9; i++ {
+		watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &core.Result{Success: true, Duration: 50 * time.Millisecond, Timestamp: time.Now()})
+	}
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[0], &core.Result{Success: true, Duration: 450 * time.Millisecond, Timestamp: time.Now()})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &core.Result{Success: false, Duration: time.Second, Timestamp: time.Now().Add(-20 * 24 * time.Hour)})
+	watchdog.UpdateEndpointStatuses(cfg.Endpoints[1], &core.Result{Success: false, Duration: time.Second, Timestamp: time.Now()})
+	api := New(cfg)
+	router := api.Router()
+	type Scenario struct {
+		Name           string
+		Path           string
+		ExpectedCode   int
+		ExpectedReport *ResponseTimeReport
+	}
+	ninetyPercent, oneHundredPercent := 0.9, 1.0
+	scenarios := []Scenario{
+		{
+			Name:           "response-time-with-target",
+			Path:           "/api/v1/endpoints/core_frontend/response-time",
+			ExpectedCode:   http.StatusOK,
+			ExpectedReport: &ResponseTimeReport{Window: "24h", Checks: 10, P50: 39, P90: 50, P95: 450, P99: 490, Target: 100, FractionUnderTarget: &ninetyPercent},
+		},
+		{
+			Name:           "response-time-with-target-override",
+			Path:           "/api/v1/endpoints/core_frontend/response-time?window=7d&target=500ms",
+			ExpectedCode:   http.StatusOK,
+			ExpectedReport: &ResponseTimeReport{Window: "7d", Checks: 10, P50: 39, P90: 50, P95: 450, P99: 490, Target: 500, FractionUnderTarget: &oneHundredPercent},
+		},
+		{
+			Name:           "response-time-without-target",
+			Path:           "/api/v1/endpoints/core_backend/response-time?window=1h",
+			ExpectedCode:   http.StatusOK,
+			ExpectedReport: &ResponseTimeReport{Window: "1h", Checks: 1, P50: 875, P90: 975, P95: 988, P99: 998},
+		},
+		{
+			Name:           "response-time-over-30d",
+			Path:           "/api/v1/endpoints/core_backend/response-time?window=30d",
+			ExpectedCode:   http.StatusOK,
+			ExpectedReport: &ResponseTimeReport{Window: "30d", Checks: 2, P50: 875, P90: 975, P95: 988, P99: 998},
+		},
+		{
+			Name:         "response-time-with-invalid-window",
+			Path:         "/api/v1/endpoints/core_frontend/response-time?window=90d",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "response-time-with-invalid-target",
+			Path:         "/api/v1/endpoints/core_frontend/response-time?target=fast",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "response-time-for-invalid-key",
+			Path:         "/api/v1/endpoints/invalid_key/response-time",
+			ExpectedCode: http.StatusNotFound,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", scenario.Path, http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if scenario.ExpectedReport == nil {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var report ResponseTimeReport
+			if err := json.Unmarshal(body, &report); err != nil {
+				t.Fatal("failed to unmarshal response:", err.Error())
+			}
+			if report.Window != scenario.ExpectedReport.Window || report.Checks != scenario.ExpectedReport.Checks || report.Target != scenario.ExpectedReport.Target {
+				t.Errorf("expected %+v, got %+v", scenario.ExpectedReport, report)
+			}
+			if report.P50 != scenario.ExpectedReport.P50 || report.P90 != scenario.ExpectedReport.P90 || report.P95 != scenario.ExpectedReport.P95 || report.P99 != scenario.ExpectedReport.P99 {
+				t.Errorf("expected percentiles %+v, got %+v", scenario.ExpectedReport, report)
+			}
+			if (report.FractionUnderTarget == nil) != (scenario.ExpectedReport.FractionUnderTarget == nil) {
+				t.Fatalf("expected fractionUnderTarget %v, got %v", scenario.ExpectedReport.FractionUnderTarget, report.FractionUnderTarget)
+			}
+			if report.FractionUnderTarget != nil && *report.FractionUnderTarget != *scenario.ExpectedReport.FractionUnderTarget {
+				t.Errorf("expected fractionUnderTarget %f, got %f", *scenario.ExpectedReport.FractionUnderTarget, *report.FractionUnderTarget)
+			}
+		})
+	}
+}
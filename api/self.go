@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/watchdog"
+	"github.com/gofiber/fiber/v2"
+)
+
+// selfHealthResponse is the body of the responses of the SelfHealth handler
+type selfHealthResponse struct {
+	Status     string                     `json:"status"`
+	Goroutines int                        `json:"goroutines"`
+	Memory     selfHealthMemoryResponse   `json:"memory"`
+	Storage    selfHealthStorageResponse  `json:"storage"`
+	Alerting   selfHealthAlertingResponse `json:"alerting"`
+}
+
+type selfHealthMemoryResponse struct {
+	// HeapAlloc is the number of bytes of allocated heap objects
+	HeapAlloc uint64 `json:"heap-alloc"`
+	// Sys is the number of bytes of memory obtained from the OS
+	Sys uint64 `json:"sys"`
+}
+
+type selfHealthStorageResponse struct {
+	Reachable bool `json:"reachable"`
+	// Latency is the number of milliseconds the storage took to respond to a ping
+	Latency int64  `json:"latency"`
+	Error   string `json:"error,omitempty"`
+}
+
+type selfHealthAlertingResponse struct {
+	// FailedAlerts is the number of alerts that could not be sent since the application started
+	FailedAlerts uint64 `json:"failed-alerts"`
+	// OpenCircuitBreakers are the alert types of the providers whose circuit breaker is not closed
+	OpenCircuitBreakers []string `json:"open-circuit-breakers"`
+}
+
+// SelfHealth handles requests to retrieve the health of Gatus itself, which is what the endpoint configured through
+// self-monitoring targets
+//
+// Responds with a 503 if the storage is unreachable or if the circuit breaker of an alerting provider is not closed.
+func SelfHealth(cfg *config.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		response := selfHealthResponse{Status: probeStatusUp, Goroutines: runtime.NumGoroutine()}
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		response.Memory = selfHealthMemoryResponse{HeapAlloc: memStats.HeapAlloc, Sys: memStats.Sys}
+		ctx, cancel := context.WithTimeout(c.Context(), readinessStorageTimeout)
+		defer cancel()
+		start := time.Now()
+		err := store.Get().Ping(ctx)
+		response.Storage.Latency = time.Since(start).Milliseconds()
+		if err != nil {
+			response.Status, response.Storage.Error = probeStatusDown, err.Error()
+		} else {
+			response.Storage.Reachable = true
+		}
+		response.Alerting.FailedAlerts = watchdog.GetNumberOfFailedAlerts()
+		response.Alerting.OpenCircuitBreakers = []string{}
+		if cfg.Alerting != nil {
+			for _, alertType := range cfg.Alerting.GetOpenCircuitBreakers() {
+				response.Alerting.OpenCircuitBreakers = append(response.Alerting.OpenCircuitBreakers, string(alertType))
+			}
+		}
+		if len(response.Alerting.OpenCircuitBreakers) > 0 {
+			response.Status = probeStatusDown
+		}
+		output, err := json.Marshal(response)
+		if err != nil {
+			return c.Status(500).SendString(err.Error())
+		}
+		statusCode := fiber.StatusOK
+		if response.Status == probeStatusDown {
+			statusCode = fiber.StatusServiceUnavailable
+		}
+		c.Set("Content-Type", "application/json")
+		return c.Status(statusCode).Send(output)
+	}
+}
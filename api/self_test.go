@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestSelfHealth(t *testing.T) {
+	defer store.Get().Clear()
+	defer store.Initialize(nil)
+	type Scenario struct {
+		Name                        string
+		CloseStorage                bool
+		OpenCircuitBreaker          bool
+		ExpectedCode                int
+		ExpectedStatus              string
+		ExpectedOpenCircuitBreakers int
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "healthy",
+			ExpectedCode:   http.StatusOK,
+			ExpectedStatus: probeStatusUp,
+		},
+		{
+			Name:           "unreachable-storage",
+			CloseStorage:   true,
+			ExpectedCode:   http.StatusServiceUnavailable,
+			ExpectedStatus: probeStatusDown,
+		},
+		{
+			Name:                        "open-circuit-breaker",
+			OpenCircuitBreaker:          true,
+			ExpectedCode:                http.StatusServiceUnavailable,
+			ExpectedStatus:              probeStatusDown,
+			ExpectedOpenCircuitBreakers: 1,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if err := store.Initialize(&storage.Config{Type: storage.TypeSQLite, Path: t.TempDir() + "/test.db"}); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if scenario.CloseStorage {
+				store.Get().Close()
+			} else {
+				defer store.Get().Close()
+			}
+			cfg := &config.Config{Alerting: &alerting.Config{CircuitBreaker: &alerting.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: alerting.DefaultCircuitBreakerCooldown}}}
+			if scenario.OpenCircuitBreaker {
+				cfg.Alerting.GetCircuitBreaker(alert.TypeSlack).RecordFailure()
+			}
+			router := New(cfg).Router()
+			request := httptest.NewRequest(http.MethodGet, "/api/v1/self/health", http.NoBody)
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Errorf("expected response code to be %d, but was %d", scenario.ExpectedCode, response.StatusCode)
+			}
+			body, _ := io.ReadAll(response.Body)
+			var health selfHealthResponse
+			if err := json.Unmarshal(body, &health); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if health.Status != scenario.ExpectedStatus {
+				t.Errorf("expected status to be %s, but was %s", scenario.ExpectedStatus, health.Status)
+			}
+			if health.Storage.Reachable == scenario.CloseStorage {
+				t.Errorf("expected storage.reachable to be %v", !scenario.CloseStorage)
+			}
+			if health.Goroutines == 0 || health.Memory.HeapAlloc == 0 {
+				t.Error("expected process metrics to be set")
+			}
+			if len(health.Alerting.OpenCircuitBreakers) != scenario.ExpectedOpenCircuitBreakers {
+				t.Errorf("expected %d open circuit breakers, got %v", scenario.ExpectedOpenCircuitBreakers, health.Alerting.OpenCircuitBreakers)
+			}
+		})
+	}
+}
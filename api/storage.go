@@ -0,0 +1,42 @@
+package api
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetStorageLimits handles requests to retrieve the maximum number of results and events kept for each endpoint
+func GetStorageLimits(c *fiber.Ctx) error {
+	limits, err := store.Get().GetLimits()
+	if err != nil {
+		log.Printf("[api][GetStorageLimits] Failed to retrieve storage limits: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	return c.Status(200).JSON(limits)
+}
+
+// UpdateStorageLimits handles requests to change the maximum number of results and events kept for each endpoint
+//
+// Limits that are omitted from the request body are left unchanged.
+func UpdateStorageLimits(c *fiber.Ctx) error {
+	limits, err := store.Get().GetLimits()
+	if err != nil {
+		log.Printf("[api][UpdateStorageLimits] Failed to retrieve storage limits: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	if err = json.Unmarshal(c.Body(), &limits); err != nil {
+		return c.Status(400).SendString("invalid storage limits: " + err.Error())
+	}
+	if err = limits.Validate(); err != nil {
+		return c.Status(400).SendString(err.Error())
+	}
+	if err = store.Get().SetLimits(limits); err != nil {
+		log.Printf("[api][UpdateStorageLimits] Failed to update storage limits: %s", err.Error())
+		return c.Status(500).SendString(err.Error())
+	}
+	log.Printf("[api][UpdateStorageLimits] Set maximum number of results to %d and maximum number of events to %d", limits.MaximumNumberOfResults, limits.MaximumNumberOfEvents)
+	return c.Status(200).JSON(limits)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+)
+
+func TestStorageLimits(t *testing.T) {
+	defer store.Get().Clear()
+	api := New(newConfigWithSecurity())
+	router := api.Router()
+	type Scenario struct {
+		Name           string
+		Method         string
+		Body           string
+		ExpectedCode   int
+		ExpectedLimits common.Limits
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "default-limits",
+			Method:         http.MethodGet,
+			ExpectedCode:   http.StatusOK,
+			ExpectedLimits: common.DefaultLimits(),
+		},
+		{
+			Name:         "update-with-invalid-body",
+			Method:       http.MethodPut,
+			Body:         "not-json",
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:         "update-with-limit-out-of-bounds",
+			Method:       http.MethodPut,
+			Body:         `{"maximumNumberOfResults":100000}`,
+			ExpectedCode: http.StatusBadRequest,
+		},
+		{
+			Name:           "update-one-limit",
+			Method:         http.MethodPut,
+			Body:           `{"maximumNumberOfResults":500}`,
+			ExpectedCode:   http.StatusOK,
+			ExpectedLimits: common.Limits{MaximumNumberOfResults: 500, MaximumNumberOfEvents: common.MaximumNumberOfEvents},
+		},
+		{
+			Name:           "update-both-limits",
+			Method:         http.MethodPut,
+			Body:           `{"maximumNumberOfResults":1000,"maximumNumberOfEvents":10}`,
+			ExpectedCode:   http.StatusOK,
+			ExpectedLimits: common.Limits{MaximumNumberOfResults: 1000, MaximumNumberOfEvents: 10},
+		},
+		{
+			Name:           "updated-limits",
+			Method:         http.MethodGet,
+			ExpectedCode:   http.StatusOK,
+			ExpectedLimits: common.Limits{MaximumNumberOfResults: 1000, MaximumNumberOfEvents: 10},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			request := httptest.NewRequest(scenario.Method, "/api/v1/config/storage", strings.NewReader(scenario.Body))
+			request.Header.Set("Content-Type", "application/json")
+			request.SetBasicAuth("john.doe", "hunter2")
+			response, err := router.Test(request)
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			defer response.Body.Close()
+			if response.StatusCode != scenario.ExpectedCode {
+				t.Fatalf("%s %s should have returned %d, but returned %d instead", request.Method, request.URL, scenario.ExpectedCode, response.StatusCode)
+			}
+			if response.StatusCode != http.StatusOK {
+				return
+			}
+			body, _ := io.ReadAll(response.Body)
+			var limits common.Limits
+			if err := json.Unmarshal(body, &limits); err != nil {
+				t.Fatal("expected body to be valid JSON, got error:", err.Error())
+			}
+			if limits != scenario.ExpectedLimits {
+				t.Errorf("expected limits %+v, got %+v", scenario.ExpectedLimits, limits)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package api
 
 import (
+	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/TwiN/gatus/v5/security"
+	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/gofiber/fiber/v2"
 )
@@ -14,8 +18,13 @@ const (
 	// DefaultPageSize is the default page siZE to use if none is specified or an invalid value is provided
 	DefaultPageSize = 20
 
-	// MaximumPageSize is the maximum page size allowed
+	// MaximumPageSize is the maximum page size allowed, unless the store keeps more results or events for each endpoint
+	//
+	// See maximumPageSize
 	MaximumPageSize = common.MaximumNumberOfResults
+
+	// MaximumEndpointsPageSize is the maximum endpoint page size allowed
+	MaximumEndpointsPageSize = 100
 )
 
 func extractPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
@@ -38,11 +47,72 @@ func extractPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int) {
 		if err != nil {
 			pageSize = DefaultPageSize
 		}
-		if pageSize > MaximumPageSize {
-			pageSize = MaximumPageSize
+		if maximum := maximumPageSize(); pageSize > maximum {
+			pageSize = maximum
 		} else if pageSize < 1 {
 			pageSize = DefaultPageSize
 		}
 	}
 	return
 }
+
+// maximumPageSize returns the maximum page size allowed, which is raised above MaximumPageSize if the limits of the
+// store have been raised, so that every result and event kept can be retrieved
+func maximumPageSize() int {
+	limits, err := store.Get().GetLimits()
+	if err != nil {
+		return MaximumPageSize
+	}
+	maximum := MaximumPageSize
+	if limits.MaximumNumberOfResults > maximum {
+		maximum = limits.MaximumNumberOfResults
+	}
+	if limits.MaximumNumberOfEvents > maximum {
+		maximum = limits.MaximumNumberOfEvents
+	}
+	return maximum
+}
+
+// extractEndpointsPageAndPageSizeFromRequest returns the endpoint page requested through the endpointsPage and
+// endpointsPageSize query parameters, and whether an endpoint page was requested at all
+func extractEndpointsPageAndPageSizeFromRequest(c *fiber.Ctx) (page, pageSize int, ok bool) {
+	pageParameter, pageSizeParameter := c.Query("endpointsPage"), c.Query("endpointsPageSize")
+	if len(pageParameter) == 0 && len(pageSizeParameter) == 0 {
+		return 0, 0, false
+	}
+	var err error
+	if page, err = strconv.Atoi(pageParameter); err != nil || page < 1 {
+		page = DefaultPage
+	}
+	if pageSize, err = strconv.Atoi(pageSizeParameter); err != nil || pageSize < 1 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaximumEndpointsPageSize {
+		pageSize = MaximumEndpointsPageSize
+	}
+	return page, pageSize, true
+}
+
+// extractTimeFromRequest parses the RFC3339 time of a query parameter, or returns defaultValue if the query parameter
+// is not set
+func extractTimeFromRequest(c *fiber.Ctx, parameter string, defaultValue time.Time) (time.Time, error) {
+	value := c.Query(parameter)
+	if len(value) == 0 {
+		return defaultValue, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid value for parameter '%s': expected a time in the RFC3339 format", parameter)
+	}
+	return t, nil
+}
+
+// RequireSecurity refuses the requests sent to a route if security isn't configured, since protected routes are
+// otherwise accessible to anyone
+func RequireSecurity(securityConfig *security.Config) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if securityConfig == nil {
+			return c.Status(403).SendString("this route requires security to be configured")
+		}
+		return c.Next()
+	}
+}
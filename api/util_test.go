@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/gofiber/fiber/v2"
 	"github.com/valyala/fasthttp"
 )
@@ -71,3 +73,17 @@ func TestExtractPageAndPageSizeFromRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestExtractPageAndPageSizeFromRequestWithRaisedStorageLimits(t *testing.T) {
+	defer store.Get().SetLimits(common.DefaultLimits())
+	if err := store.Get().SetLimits(common.Limits{MaximumNumberOfResults: 500, MaximumNumberOfEvents: 50}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	app := fiber.New()
+	c := app.AcquireCtx(&fasthttp.RequestCtx{})
+	defer app.ReleaseCtx(c)
+	c.Request().SetRequestURI("/api/v1/statuses?page=1&pageSize=999999")
+	if _, pageSize := extractPageAndPageSizeFromRequest(c); pageSize != 500 {
+		t.Errorf("expected the page size to be capped at the maximum number of results, which is %d, got %d", 500, pageSize)
+	}
+}
@@ -1,8 +1,8 @@
 package client
 
 import (
+	"context"
 	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"golang.org/x/net/websocket"
@@ -25,6 +25,10 @@ var (
 
 	whoisClient              = whois.NewClient().WithReferralCache(true)
 	whoisExpirationDateCache = gocache.NewCache().WithMaxSize(10000).WithDefaultTTL(24 * time.Hour)
+
+	// reverseDNSCache caches the names that IPs resolve to for a short time, so that endpoints checked frequently
+	// don't add the latency of a PTR lookup to every check
+	reverseDNSCache = gocache.NewCache().WithMaxSize(10000).WithDefaultTTL(5 * time.Minute)
 )
 
 // GetHTTPClient returns the shared HTTP client, or the client from the configuration passed
@@ -38,6 +42,21 @@ func GetHTTPClient(config *Config) *http.Client {
 	return config.getHTTPClient()
 }
 
+// GetHTTPClientForIP returns an HTTP client from the configuration passed that connects to the IP passed, regardless
+// of what the host of the requests resolves to
+//
+// The Host header and the server name used for TLS are still derived from the URL of the request.
+// Unlike GetHTTPClient, the client returned is not cached, and must not be reused.
+func GetHTTPClientForIP(config *Config, ip string) *http.Client {
+	if injectedHTTPClient != nil {
+		return injectedHTTPClient
+	}
+	if config == nil {
+		config = &defaultConfig
+	}
+	return config.buildHTTPClient(ip)
+}
+
 // GetDomainExpiration retrieves the duration until the domain provided expires
 func GetDomainExpiration(hostname string) (domainExpiration time.Duration, err error) {
 	var retrievedCachedValue bool
@@ -69,7 +88,7 @@ func GetDomainExpiration(hostname string) (domainExpiration time.Duration, err e
 
 // CanCreateTCPConnection checks whether a connection can be established with a TCP endpoint
 func CanCreateTCPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("tcp", address, config.Timeout)
+	conn, err := config.dial("tcp", address)
 	if err != nil {
 		return false
 	}
@@ -79,7 +98,7 @@ func CanCreateTCPConnection(address string, config *Config) bool {
 
 // CanCreateUDPConnection checks whether a connection can be established with a UDP endpoint
 func CanCreateUDPConnection(address string, config *Config) bool {
-	conn, err := net.DialTimeout("udp", address, config.Timeout)
+	conn, err := config.dial("udp", address)
 	if err != nil {
 		return false
 	}
@@ -113,12 +132,14 @@ func CanCreateSCTPConnection(address string, config *Config) bool {
 }
 
 // CanPerformStartTLS checks whether a connection can be established to an address using the STARTTLS protocol
-func CanPerformStartTLS(address string, config *Config) (connected bool, certificate *x509.Certificate, err error) {
+//
+// The certificates of the state returned are the ones presented by the server, starting with its own.
+func CanPerformStartTLS(address string, config *Config) (connected bool, state *tls.ConnectionState, err error) {
 	hostAndPort := strings.Split(address, ":")
 	if len(hostAndPort) != 2 {
 		return false, nil, errors.New("invalid address for starttls, format must be host:port")
 	}
-	connection, err := net.DialTimeout("tcp", address, config.Timeout)
+	connection, err := config.dial("tcp", address)
 	if err != nil {
 		return
 	}
@@ -126,47 +147,48 @@ func CanPerformStartTLS(address string, config *Config) (connected bool, certifi
 	if err != nil {
 		return
 	}
-	err = smtpClient.StartTLS(&tls.Config{
-		InsecureSkipVerify: config.Insecure,
-		ServerName:         hostAndPort[0],
-	})
+	tlsConfig := config.getTLSConfig()
+	if len(tlsConfig.ServerName) == 0 {
+		tlsConfig.ServerName = hostAndPort[0]
+	}
+	err = smtpClient.StartTLS(tlsConfig)
 	if err != nil {
 		return
 	}
-	if state, ok := smtpClient.TLSConnectionState(); ok {
-		certificate = state.PeerCertificates[0]
+	if connectionState, ok := smtpClient.TLSConnectionState(); ok {
+		state = &connectionState
 	} else {
 		return false, nil, errors.New("could not get TLS connection state")
 	}
-	return true, certificate, nil
+	return true, state, nil
 }
 
-// CanPerformTLS checks whether a connection can be established to an address using the TLS protocol
-func CanPerformTLS(address string, config *Config) (connected bool, certificate *x509.Certificate, err error) {
-	connection, err := tls.DialWithDialer(&net.Dialer{Timeout: config.Timeout}, "tcp", address, &tls.Config{
-		InsecureSkipVerify: config.Insecure,
-	})
+// CanPerformTLS checks whether a connection can be established to an address using the TLS protocol, without sending
+// any data once the handshake is complete
+//
+// The certificates of the state returned are the ones presented by the server, starting with its own.
+func CanPerformTLS(address string, config *Config) (connected bool, state *tls.ConnectionState, err error) {
+	tlsConfig := config.getTLSConfig()
+	tlsConfig.NextProtos = config.ALPN
+	connection, err := tls.DialWithDialer(&net.Dialer{Timeout: config.Timeout}, config.network("tcp"), address, tlsConfig)
 	if err != nil {
-		return
+		return false, nil, config.wrapIPVersionError(err)
 	}
 	defer connection.Close()
-	verifiedChains := connection.ConnectionState().VerifiedChains
-	// If config.Insecure is set to true, verifiedChains will be an empty list []
-	// We should get the parsed certificates from PeerCertificates, it can't be empty on the client side
+	// Unlike VerifiedChains, which is empty if config.Insecure is set to true and may include a root from the local
+	// trust store, PeerCertificates is exactly what the server presented, and it can't be empty on the client side
 	// Reference: https://pkg.go.dev/crypto/tls#PeerCertificates
-	if len(verifiedChains) == 0 || len(verifiedChains[0]) == 0 {
-		peerCertificates := connection.ConnectionState().PeerCertificates
-		return true, peerCertificates[0], nil
-	}
-	return true, verifiedChains[0][0], nil
+	connectionState := connection.ConnectionState()
+	return true, &connectionState, nil
 }
 
 // Ping checks if an address can be pinged and returns the round-trip time if the address can be pinged
 //
 // Note that this function takes at least 100ms, even if the address is 127.0.0.1
 func Ping(address string, config *Config) (bool, time.Duration) {
-	pinger, err := ping.NewPinger(address)
-	if err != nil {
+	pinger := ping.New(address)
+	pinger.SetNetwork(config.network("ip"))
+	if err := pinger.Resolve(); err != nil {
 		return false, 0
 	}
 	pinger.Count = 1
@@ -177,8 +199,7 @@ func Ping(address string, config *Config) (bool, time.Duration) {
 	// Note that for this to work on Linux, Gatus must run with sudo privileges.
 	// See https://github.com/prometheus-community/pro-bing#linux
 	pinger.SetPrivileged(runtime.GOOS != "darwin")
-	err = pinger.Run()
-	if err != nil {
+	if err := pinger.Run(); err != nil {
 		return false, 0
 	}
 	if pinger.Statistics() != nil {
@@ -191,6 +212,31 @@ func Ping(address string, config *Config) (bool, time.Duration) {
 	return true, 0
 }
 
+// QueryUDP sends `body` to a UDP endpoint and returns the response from the server
+//
+// Since UDP is connectionless, the endpoint is only considered as connected if a response is received before the
+// timeout.
+func QueryUDP(address string, config *Config, body string) (bool, []byte, error) {
+	const MaximumDatagramSize = 65535 // in bytes
+	conn, err := config.dial("udp", address)
+	if err != nil {
+		return false, nil, fmt.Errorf("error dialing udp: %w", err)
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(config.Timeout)); err != nil {
+		return false, nil, fmt.Errorf("error setting udp deadline: %w", err)
+	}
+	if _, err = conn.Write([]byte(body)); err != nil {
+		return false, nil, fmt.Errorf("error writing udp payload: %w", err)
+	}
+	response := make([]byte, MaximumDatagramSize)
+	n, err := conn.Read(response)
+	if err != nil {
+		return false, nil, fmt.Errorf("error reading udp response: %w", err)
+	}
+	return true, response[:n], nil
+}
+
 // Open a websocket connection, write `body` and return a message from the server
 func QueryWebSocket(address string, config *Config, body string) (bool, []byte, error) {
 	const (
@@ -202,6 +248,13 @@ func QueryWebSocket(address string, config *Config, body string) (bool, []byte,
 	if err != nil {
 		return false, nil, fmt.Errorf("error configuring websocket connection: %w", err)
 	}
+	if config.HasCustomCA() {
+		wsConfig.TlsConfig = config.getTLSConfig()
+	}
+	if config.HasIPVersion() {
+		// The websocket library always dials over tcp, so connections to addresses of the other version are rejected
+		wsConfig.Dialer = &net.Dialer{Control: config.controlIPVersion}
+	}
 	// Dial URL
 	ws, err := websocket.DialConfig(wsConfig)
 	if err != nil {
@@ -222,6 +275,40 @@ func QueryWebSocket(address string, config *Config, body string) (bool, []byte,
 	return connected, msg[:n], nil
 }
 
+// LookupIP returns the IPs the hostname passed resolves to, restricted to the IP version of the configuration, if any
+func LookupIP(hostname string, config *Config) ([]net.IP, error) {
+	if config == nil {
+		config = &defaultConfig
+	}
+	ips, err := net.DefaultResolver.LookupIP(context.Background(), config.network("ip"), hostname)
+	if err != nil {
+		return nil, config.wrapIPVersionError(err)
+	}
+	return ips, nil
+}
+
+// LookupReverseDNS returns the name the IP passed resolves to through a reverse DNS (PTR) lookup, without the trailing
+// dot, or an empty string if the IP has no PTR record
+//
+// Names are cached for a short time.
+func LookupReverseDNS(ip string) (string, error) {
+	if v, exists := reverseDNSCache.Get(ip); exists {
+		return v.(string), nil
+	}
+	var name string
+	names, err := net.DefaultResolver.LookupAddr(context.Background(), ip)
+	if err != nil {
+		var dnsError *net.DNSError
+		if !errors.As(err, &dnsError) || !dnsError.IsNotFound {
+			return "", fmt.Errorf("error performing reverse DNS lookup of %s: %w", ip, err)
+		}
+	} else if len(names) > 0 {
+		name = strings.TrimSuffix(names[0], ".")
+	}
+	reverseDNSCache.Set(ip, name)
+	return name, nil
+}
+
 // InjectHTTPClient is used to inject a custom HTTP client for testing purposes
 func InjectHTTPClient(httpClient *http.Client) {
 	injectedHTTPClient = httpClient
@@ -2,8 +2,12 @@ package client
 
 import (
 	"bytes"
+	"crypto/tls"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -196,6 +200,105 @@ func TestCanCreateTCPConnection(t *testing.T) {
 	}
 }
 
+func TestLookupIP(t *testing.T) {
+	if ips, err := LookupIP("127.0.0.1", &Config{IPVersion: IPVersion4}); err != nil || len(ips) != 1 || ips[0].String() != "127.0.0.1" {
+		t.Errorf("expected 127.0.0.1, got %v (error: %v)", ips, err)
+	}
+	if ips, err := LookupIP("::1", nil); err != nil || len(ips) != 1 || ips[0].String() != "::1" {
+		t.Errorf("expected ::1, got %v (error: %v)", ips, err)
+	}
+	if _, err := LookupIP("127.0.0.1", &Config{IPVersion: IPVersion6}); err == nil || err.Error() != "127.0.0.1 has no IPv6 address" {
+		t.Error("expected error because 127.0.0.1 is not an IPv6 address, got", err)
+	}
+}
+
+func TestLookupReverseDNS(t *testing.T) {
+	defer reverseDNSCache.Clear()
+	if name, err := LookupReverseDNS("127.0.0.1"); err != nil || len(name) == 0 {
+		t.Errorf("expected 127.0.0.1 to resolve to a name, got %q (error: %v)", name, err)
+	}
+	// The names are cached, so a cached name should be returned without performing a lookup
+	reverseDNSCache.Set("192.0.2.1", "host.example.internal")
+	if name, err := LookupReverseDNS("192.0.2.1"); err != nil || name != "host.example.internal" {
+		t.Errorf("expected host.example.internal, got %q (error: %v)", name, err)
+	}
+}
+
+func TestCanPerformTLSWithIPVersion(t *testing.T) {
+	if _, _, err := CanPerformTLS("127.0.0.1:443", &Config{Timeout: time.Second, IPVersion: IPVersion6}); err == nil || err.Error() != "127.0.0.1 has no IPv6 address" {
+		t.Error("expected error because 127.0.0.1 is not an IPv6 address, got", err)
+	}
+}
+
+func TestCanPerformTLSWithServerNameAndALPN(t *testing.T) {
+	var serverName string
+	var protocols []string
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{
+		GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			serverName, protocols = hello.ServerName, hello.SupportedProtos
+			return nil, nil
+		},
+	}
+	server.StartTLS()
+	defer server.Close()
+	address := strings.TrimPrefix(server.URL, "https://")
+	connected, state, err := CanPerformTLS(address, &Config{Insecure: true, Timeout: 5 * time.Second, TLSServerName: "ldap.example.org", ALPN: []string{"http/1.1"}})
+	if err != nil || !connected {
+		t.Fatalf("expected to connect, got connected=%v; err=%v", connected, err)
+	}
+	if serverName != "ldap.example.org" {
+		t.Errorf("expected the server name sent through SNI to be ldap.example.org, got %q", serverName)
+	}
+	if len(protocols) != 1 || protocols[0] != "http/1.1" {
+		t.Errorf("expected the client to offer http/1.1 through ALPN, got %v", protocols)
+	}
+	if state.NegotiatedProtocol != "http/1.1" {
+		t.Errorf("expected http/1.1 to be negotiated, got %q", state.NegotiatedProtocol)
+	}
+	if len(state.PeerCertificates) == 0 || !state.PeerCertificates[0].Equal(server.Certificate()) {
+		t.Error("expected the certificate presented by the server to be returned")
+	}
+}
+
+func TestQueryUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer conn.Close()
+	go func() {
+		buffer := make([]byte, 1024)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+			// Only reply to the "ping" payload to simulate a server ignoring unexpected payloads
+			if string(buffer[:n]) == "ping" {
+				_, _ = conn.WriteTo([]byte("pong"), addr)
+			}
+		}
+	}()
+	connected, body, err := QueryUDP(conn.LocalAddr().String(), &Config{Timeout: 2 * time.Second}, "ping")
+	if err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if !connected {
+		t.Error("expected to be connected, because the server replied")
+	}
+	if string(body) != "pong" {
+		t.Errorf("expected body to be pong, got %s", body)
+	}
+	connected, _, err = QueryUDP(conn.LocalAddr().String(), &Config{Timeout: 100 * time.Millisecond}, "unexpected")
+	if err == nil {
+		t.Error("expected an error, because the server didn't reply")
+	}
+	if connected {
+		t.Error("expected not to be connected, because the server didn't reply")
+	}
+}
+
 // This test checks if a HTTP client configured with `configureOAuth2()` automatically
 // performs a Client Credentials OAuth2 flow and adds the obtained token as a `Authorization`
 // header to all outgoing HTTP calls.
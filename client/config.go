@@ -1,14 +1,22 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
+	"os"
 	"regexp"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -17,12 +25,24 @@ import (
 
 const (
 	defaultHTTPTimeout = 10 * time.Second
+
+	// IPVersion4 is the value of Config.IPVersion that restricts connections to IPv4
+	IPVersion4 = "ipv4"
+
+	// IPVersion6 is the value of Config.IPVersion that restricts connections to IPv6
+	IPVersion6 = "ipv6"
 )
 
 var (
 	ErrInvalidDNSResolver        = errors.New("invalid DNS resolver specified. Required format is {proto}://{ip}:{port}")
 	ErrInvalidDNSResolverPort    = errors.New("invalid DNS resolver port")
 	ErrInvalidClientOAuth2Config = errors.New("invalid oauth2 configuration: must define all fields for client credentials flow (token-url, client-id, client-secret, scopes)")
+	ErrInvalidClientCA           = errors.New("invalid CA bundle: must contain at least one PEM-encoded certificate")
+	ErrInvalidClientCertificate  = errors.New("invalid client certificate configuration: must define both a client certificate (client-certificate-file or client-certificate) and a private key (client-private-key-file or client-private-key)")
+	ErrInvalidClientIPVersion    = errors.New("invalid ip-version: must be either " + IPVersion4 + " or " + IPVersion6)
+	ErrInvalidKeepAlivePeriod    = errors.New("invalid keep-alive-period: must not be negative")
+	ErrInvalidPinnedCertificate  = errors.New("invalid pinned-certificates: each pin must be the SHA-256 fingerprint of a certificate in hexadecimal, optionally separated by colons")
+	ErrPinnedCertificateMismatch = errors.New("the certificate presented by the server does not match any of the pinned certificates")
 
 	defaultConfig = Config{
 		Insecure:       false,
@@ -42,6 +62,13 @@ type Config struct {
 	// Insecure determines whether to skip verifying the server's certificate chain and host name
 	Insecure bool `yaml:"insecure,omitempty"`
 
+	// PinnedCertificates are the SHA-256 fingerprints of the certificates the server is expected to present (e.g.
+	// AB:CD:...), which is a safer alternative to Insecure for servers with a self-signed certificate.
+	//
+	// If set, the certificate chain and host name of the server aren't verified against the trusted certificate
+	// authorities; instead, the handshake only succeeds if the certificate presented by the server matches one of them.
+	PinnedCertificates []string `yaml:"pinned-certificates,omitempty"`
+
 	// IgnoreRedirect determines whether to ignore redirects (true) or follow them (false, default)
 	IgnoreRedirect bool `yaml:"ignore-redirect,omitempty"`
 
@@ -58,9 +85,85 @@ type Config struct {
 	// See configureOAuth2 for more details.
 	OAuth2Config *OAuth2Config `yaml:"oauth2,omitempty"`
 
+	// UserAgent is the user agent to use for requests, unless a User-Agent header is explicitly configured
+	UserAgent string `yaml:"user-agent,omitempty"`
+
+	// CAFile is the path to a file containing the PEM-encoded certificates of the certificate authorities to trust
+	// instead of the system's
+	CAFile string `yaml:"ca-file,omitempty"`
+
+	// CA is the PEM-encoded certificates of the certificate authorities to trust instead of the system's
+	//
+	// If CAFile is also set, the certificates of both are trusted.
+	CA string `yaml:"ca,omitempty"`
+
+	// ClientCertificateFile is the path to a file containing the PEM-encoded certificate to present to the server
+	// for mutual TLS authentication
+	ClientCertificateFile string `yaml:"client-certificate-file,omitempty"`
+
+	// ClientPrivateKeyFile is the path to a file containing the PEM-encoded private key of ClientCertificateFile
+	ClientPrivateKeyFile string `yaml:"client-private-key-file,omitempty"`
+
+	// ClientCertificate is the PEM-encoded certificate to present to the server for mutual TLS authentication
+	//
+	// Takes precedence over ClientCertificateFile.
+	ClientCertificate string `yaml:"client-certificate,omitempty"`
+
+	// ClientPrivateKey is the PEM-encoded private key of the client certificate
+	//
+	// Takes precedence over ClientPrivateKeyFile.
+	ClientPrivateKey string `yaml:"client-private-key,omitempty"`
+
+	// TLSServerName is the name sent to the server through SNI and used to verify the certificate it presents, instead
+	// of the host of the endpoint. Useful to monitor a server that presents a certificate based on SNI by its IP.
+	TLSServerName string `yaml:"tls-server-name,omitempty"`
+
+	// ALPN are the application protocols to negotiate during the TLS handshake, in order of preference (e.g. h2), for
+	// servers that refuse handshakes without one. Only used by endpoints of type TLS.
+	ALPN []string `yaml:"alpn,omitempty"`
+
+	// IPVersion restricts connections to IPv4 (ipv4) or IPv6 (ipv6), rather than using whichever the host resolves to
+	// first. Useful to monitor each stack of a dual-stack host separately.
+	IPVersion string `yaml:"ip-version,omitempty"`
+
+	// KeepAlive is whether the connection used by a check is kept open so that the next check can reuse it, rather than
+	// establishing a new connection every time. HTTP only.
+	//
+	// Connections are always reused if possible, but since the body of the response is only read if a condition needs
+	// it, they're usually closed before the response is fully read. With KeepAlive, the rest of the body is discarded
+	// instead, which leaves the connection open.
+	KeepAlive bool `yaml:"keep-alive,omitempty"`
+
+	// KeepAlivePeriod is the interval between the TCP keep-alive probes sent on the connections, which prevents idle
+	// connections from being dropped by the network (e.g. by a NAT) between checks. Defaults to the default of Go
+	// (15s). HTTP only.
+	KeepAlivePeriod time.Duration `yaml:"keep-alive-period,omitempty"`
+
+	// TransportTimeoutOnly makes the HTTP client apply Timeout to establishing the connection (dial and TLS
+	// handshake) rather than to the entire request.
+	//
+	// This is set when the deadline of the request is managed by the caller through the request's context.
+	TransportTimeoutOnly bool `yaml:"-"`
+
+	// rootCAs is the pool of certificates built from CAFile and CA by ValidateAndSetDefaults
+	rootCAs *x509.CertPool
+
+	// certificates is the client certificate loaded from ClientCertificate (or ClientCertificateFile) and
+	// ClientPrivateKey (or ClientPrivateKeyFile) by ValidateAndSetDefaults
+	certificates []tls.Certificate
+
+	// pinnedFingerprints are the decoded fingerprints of PinnedCertificates, set by ValidateAndSetDefaults
+	pinnedFingerprints [][]byte
+
 	httpClient *http.Client
 }
 
+// GlobalConfig is the configuration that applies to the clients of every endpoint
+type GlobalConfig struct {
+	// DefaultUserAgent is the user agent to use for endpoints that don't have a user agent configured
+	DefaultUserAgent string `yaml:"default-user-agent,omitempty"`
+}
+
 // DNSResolverConfig is the parsed configuration from the DNSResolver config string.
 type DNSResolverConfig struct {
 	Protocol string
@@ -90,9 +193,215 @@ func (c *Config) ValidateAndSetDefaults() error {
 	if c.HasOAuth2Config() && !c.OAuth2Config.isValid() {
 		return ErrInvalidClientOAuth2Config
 	}
+	if c.HasCustomCA() {
+		rootCAs, err := c.loadCA()
+		if err != nil {
+			return err
+		}
+		c.rootCAs = rootCAs
+	}
+	if c.HasClientCertificate() {
+		certificate, err := c.loadClientCertificate()
+		if err != nil {
+			return err
+		}
+		c.certificates = []tls.Certificate{certificate}
+	}
+	if c.HasIPVersion() && c.IPVersion != IPVersion4 && c.IPVersion != IPVersion6 {
+		return ErrInvalidClientIPVersion
+	}
+	if c.KeepAlivePeriod < 0 {
+		return ErrInvalidKeepAlivePeriod
+	}
+	if c.HasPinnedCertificates() {
+		pinnedFingerprints, err := parsePinnedCertificates(c.PinnedCertificates)
+		if err != nil {
+			return err
+		}
+		c.pinnedFingerprints = pinnedFingerprints
+	}
+	return nil
+}
+
+// HasIPVersion returns whether connections are restricted to an IP version
+func (c *Config) HasIPVersion() bool {
+	return len(c.IPVersion) > 0
+}
+
+// network returns the network passed (e.g. tcp) restricted to the IP version of the configuration, if any (e.g. tcp6)
+func (c *Config) network(network string) string {
+	switch c.IPVersion {
+	case IPVersion4:
+		return strings.TrimRight(network, "46") + "4"
+	case IPVersion6:
+		return strings.TrimRight(network, "46") + "6"
+	}
+	return network
+}
+
+// ipVersionName returns the human-readable name of the IP version of the configuration (e.g. IPv6)
+func (c *Config) ipVersionName() string {
+	if c.IPVersion == IPVersion6 {
+		return "IPv6"
+	}
+	return "IPv4"
+}
+
+// wrapIPVersionError returns a clearer error if err was caused by the host not having an address of the IP version
+// of the configuration
+func (c *Config) wrapIPVersionError(err error) error {
+	var addrErr *net.AddrError
+	if c.HasIPVersion() && errors.As(err, &addrErr) && addrErr.Err == "no suitable address found" {
+		return fmt.Errorf("%s has no %s address", addrErr.Addr, c.ipVersionName())
+	}
+	return err
+}
+
+// dialContext establishes a connection with the dialer passed, restricted to the IP version of the configuration
+func (c *Config) dialContext(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	connection, err := dialer.DialContext(ctx, c.network(network), address)
+	if err != nil {
+		return nil, c.wrapIPVersionError(err)
+	}
+	return connection, nil
+}
+
+// dial establishes a connection within the timeout of the configuration, restricted to its IP version
+func (c *Config) dial(network, address string) (net.Conn, error) {
+	return c.dialContext(context.Background(), &net.Dialer{Timeout: c.Timeout}, network, address)
+}
+
+// controlIPVersion rejects connections to addresses that aren't of the IP version of the configuration, for dialers
+// whose network cannot be restricted
+func (c *Config) controlIPVersion(network, address string, _ syscall.RawConn) error {
+	if network != c.network(network) {
+		return fmt.Errorf("%s is not an %s address", address, c.ipVersionName())
+	}
 	return nil
 }
 
+// ReferencedFiles returns the paths of the files that the configuration is read from
+func (c *Config) ReferencedFiles() []string {
+	var files []string
+	for _, file := range []string{c.CAFile, c.ClientCertificateFile, c.ClientPrivateKeyFile} {
+		if len(file) > 0 {
+			files = append(files, file)
+		}
+	}
+	return files
+}
+
+// HasCustomCA returns whether custom certificate authorities are configured
+func (c *Config) HasCustomCA() bool {
+	return len(c.CAFile) > 0 || len(c.CA) > 0
+}
+
+// loadCA builds a pool from the certificates of CAFile and CA
+func (c *Config) loadCA() (*x509.CertPool, error) {
+	var bundle []byte
+	if len(c.CAFile) > 0 {
+		fileContent, err := os.ReadFile(c.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		bundle = append(bundle, fileContent...)
+		bundle = append(bundle, '\n')
+	}
+	bundle = append(bundle, c.CA...)
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(bundle) {
+		return nil, ErrInvalidClientCA
+	}
+	return rootCAs, nil
+}
+
+// HasClientCertificate returns whether a client certificate or its private key is configured
+func (c *Config) HasClientCertificate() bool {
+	return len(c.ClientCertificateFile) > 0 || len(c.ClientCertificate) > 0 || len(c.ClientPrivateKeyFile) > 0 || len(c.ClientPrivateKey) > 0
+}
+
+// loadClientCertificate loads the client certificate from ClientCertificate or ClientCertificateFile, and its private
+// key from ClientPrivateKey or ClientPrivateKeyFile
+func (c *Config) loadClientCertificate() (tls.Certificate, error) {
+	certificatePEM, err := readInlineOrFile(c.ClientCertificate, c.ClientCertificateFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	privateKeyPEM, err := readInlineOrFile(c.ClientPrivateKey, c.ClientPrivateKeyFile)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	if len(certificatePEM) == 0 || len(privateKeyPEM) == 0 {
+		return tls.Certificate{}, ErrInvalidClientCertificate
+	}
+	certificate, err := tls.X509KeyPair(certificatePEM, privateKeyPEM)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+	return certificate, nil
+}
+
+// readInlineOrFile returns the inline value passed if it is set, or the content of the file passed otherwise
+func readInlineOrFile(inline, file string) ([]byte, error) {
+	if len(inline) > 0 {
+		return []byte(inline), nil
+	}
+	if len(file) > 0 {
+		return os.ReadFile(file)
+	}
+	return nil, nil
+}
+
+// HasPinnedCertificates returns whether the certificate of the server is pinned
+func (c *Config) HasPinnedCertificates() bool {
+	return len(c.PinnedCertificates) > 0
+}
+
+// parsePinnedCertificates decodes the fingerprints passed, ignoring colons and case
+func parsePinnedCertificates(pins []string) ([][]byte, error) {
+	fingerprints := make([][]byte, 0, len(pins))
+	for _, pin := range pins {
+		fingerprint, err := hex.DecodeString(strings.ReplaceAll(strings.TrimSpace(pin), ":", ""))
+		if err != nil || len(fingerprint) != sha256.Size {
+			return nil, ErrInvalidPinnedCertificate
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	return fingerprints, nil
+}
+
+// verifyPinnedCertificate returns an error unless the certificate presented by the server, which is the first of the
+// raw certificates passed, matches one of the pinned fingerprints
+func (c *Config) verifyPinnedCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return ErrPinnedCertificateMismatch
+	}
+	fingerprint := sha256.Sum256(rawCerts[0])
+	for _, pinnedFingerprint := range c.pinnedFingerprints {
+		if bytes.Equal(fingerprint[:], pinnedFingerprint) {
+			return nil
+		}
+	}
+	return ErrPinnedCertificateMismatch
+}
+
+// getTLSConfig returns the TLS configuration to use for connections established with the configuration
+func (c *Config) getTLSConfig() *tls.Config {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.Insecure,
+		RootCAs:            c.rootCAs,
+		Certificates:       c.certificates,
+		ServerName:         c.TLSServerName,
+	}
+	if len(c.pinnedFingerprints) > 0 {
+		// The certificate is pinned, so the usual verification, which would reject a self-signed certificate, is
+		// replaced by checking that the certificate presented is the one expected
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyPeerCertificate = c.verifyPinnedCertificate
+	}
+	return tlsConfig
+}
+
 // HasCustomDNSResolver returns whether a custom DNSResolver is configured
 func (c *Config) HasCustomDNSResolver() bool {
 	return len(c.DNSResolver) > 0
@@ -138,51 +447,97 @@ func (c *OAuth2Config) isValid() bool {
 // GetHTTPClient return an HTTP client matching the Config's parameters.
 func (c *Config) getHTTPClient() *http.Client {
 	if c.httpClient == nil {
-		c.httpClient = &http.Client{
-			Timeout: c.Timeout,
-			Transport: &http.Transport{
-				MaxIdleConns:        100,
-				MaxIdleConnsPerHost: 20,
-				Proxy:               http.ProxyFromEnvironment,
-				TLSClientConfig: &tls.Config{
-					InsecureSkipVerify: c.Insecure,
+		c.httpClient = c.buildHTTPClient("")
+	}
+	return c.httpClient
+}
+
+// buildHTTPClient builds an HTTP client based on the configuration
+//
+// If an IP is passed, every connection is established with that IP regardless of what the host of the request
+// resolves to, and connections are not reused, since the client is not meant to be shared.
+func (c *Config) buildHTTPClient(ip string) *http.Client {
+	httpClient := &http.Client{
+		Timeout: c.Timeout,
+		Transport: &http.Transport{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 20,
+			Proxy:               http.ProxyFromEnvironment,
+			TLSClientConfig:     c.getTLSConfig(),
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if c.IgnoreRedirect {
+				// Don't follow redirects
+				return http.ErrUseLastResponse
+			}
+			// Follow redirects
+			return nil
+		},
+	}
+	if c.TransportTimeoutOnly {
+		httpClient.Timeout = 0
+		transport := httpClient.Transport.(*http.Transport)
+		transport.TLSHandshakeTimeout = c.Timeout
+		dialer := &net.Dialer{Timeout: c.Timeout, KeepAlive: c.KeepAlivePeriod}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return c.dialContext(ctx, dialer, network, addr)
+		}
+	}
+	if c.HasCustomDNSResolver() {
+		dnsResolver, err := c.parseDNSResolver()
+		if err != nil {
+			// We're ignoring the error, because it should have been validated on startup ValidateAndSetDefaults.
+			// It shouldn't happen, but if it does, we'll log it... Better safe than sorry ;)
+			log.Println("[client][getHTTPClient] THIS SHOULD NOT HAPPEN. Silently ignoring invalid DNS resolver due to error:", err.Error())
+		} else {
+			dialer := &net.Dialer{
+				Timeout:   c.dialTimeout(),
+				KeepAlive: c.KeepAlivePeriod,
+				Resolver: &net.Resolver{
+					PreferGo: true,
+					Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+						d := net.Dialer{}
+						return d.DialContext(ctx, dnsResolver.Protocol, dnsResolver.Host+":"+dnsResolver.Port)
+					},
 				},
-			},
-			CheckRedirect: func(req *http.Request, via []*http.Request) error {
-				if c.IgnoreRedirect {
-					// Don't follow redirects
-					return http.ErrUseLastResponse
-				}
-				// Follow redirects
-				return nil
-			},
+			}
+			httpClient.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return c.dialContext(ctx, dialer, network, addr)
+			}
 		}
-		if c.HasCustomDNSResolver() {
-			dnsResolver, err := c.parseDNSResolver()
+	}
+	if len(ip) > 0 {
+		transport := httpClient.Transport.(*http.Transport)
+		transport.Proxy = nil
+		transport.DisableKeepAlives = true
+		dialer := &net.Dialer{Timeout: c.dialTimeout(), KeepAlive: c.KeepAlivePeriod}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			_, port, err := net.SplitHostPort(addr)
 			if err != nil {
-				// We're ignoring the error, because it should have been validated on startup ValidateAndSetDefaults.
-				// It shouldn't happen, but if it does, we'll log it... Better safe than sorry ;)
-				log.Println("[client][getHTTPClient] THIS SHOULD NOT HAPPEN. Silently ignoring invalid DNS resolver due to error:", err.Error())
-			} else {
-				dialer := &net.Dialer{
-					Resolver: &net.Resolver{
-						PreferGo: true,
-						Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
-							d := net.Dialer{}
-							return d.DialContext(ctx, dnsResolver.Protocol, dnsResolver.Host+":"+dnsResolver.Port)
-						},
-					},
-				}
-				c.httpClient.Transport.(*http.Transport).DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
-					return dialer.DialContext(ctx, network, addr)
-				}
+				return nil, err
 			}
+			return c.dialContext(ctx, dialer, network, net.JoinHostPort(ip, port))
 		}
-		if c.HasOAuth2Config() {
-			c.httpClient = configureOAuth2(c.httpClient, *c.OAuth2Config)
+	}
+	if transport := httpClient.Transport.(*http.Transport); (c.HasIPVersion() || c.KeepAlivePeriod > 0) && transport.DialContext == nil {
+		dialer := &net.Dialer{Timeout: c.dialTimeout(), KeepAlive: c.KeepAlivePeriod}
+		transport.DialContext = func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return c.dialContext(ctx, dialer, network, addr)
 		}
 	}
-	return c.httpClient
+	if c.HasOAuth2Config() {
+		httpClient = configureOAuth2(httpClient, *c.OAuth2Config)
+	}
+	return httpClient
+}
+
+// dialTimeout returns the timeout for establishing a connection, if the HTTP client doesn't already have a timeout
+// covering the entire request
+func (c *Config) dialTimeout() time.Duration {
+	if c.TransportTimeoutOnly {
+		return c.Timeout
+	}
+	return 0
 }
 
 // configureOAuth2 returns an HTTP client that will obtain and refresh tokens as necessary.
@@ -1,7 +1,20 @@
 package client
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"math/big"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
 	"testing"
 	"time"
 )
@@ -79,3 +92,283 @@ func TestConfig_ValidateAndSetDefaults_withCustomDNSResolver(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_ValidateAndSetDefaultsWithCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ca := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	caFile := t.TempDir() + "/ca.pem"
+	if err := os.WriteFile(caFile, []byte(ca), 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		name                string
+		cfg                 *Config
+		expectedErr         error
+		expectedRequestFail bool
+	}{
+		{
+			name:                "no-ca",
+			cfg:                 &Config{},
+			expectedRequestFail: true,
+		},
+		{
+			name: "inline-ca",
+			cfg:  &Config{CA: ca},
+		},
+		{
+			name: "ca-file",
+			cfg:  &Config{CAFile: caFile},
+		},
+		{
+			name:        "invalid-inline-ca",
+			cfg:         &Config{CA: "not a certificate"},
+			expectedErr: ErrInvalidClientCA,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr != nil {
+				return
+			}
+			response, err := scenario.cfg.getHTTPClient().Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+			if scenario.expectedRequestFail && err == nil {
+				t.Error("expected the certificate of the server to not be trusted")
+			}
+			if !scenario.expectedRequestFail && err != nil {
+				t.Error("expected the certificate of the server to be trusted, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithPinnedCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	fingerprint := sha256.Sum256(server.Certificate().Raw)
+	pin := hex.EncodeToString(fingerprint[:])
+	var colonSeparatedPin []string
+	for i := 0; i < len(pin); i += 2 {
+		colonSeparatedPin = append(colonSeparatedPin, strings.ToUpper(pin[i:i+2]))
+	}
+	otherPin := strings.Repeat("ab", sha256.Size)
+	scenarios := []struct {
+		name                string
+		cfg                 *Config
+		expectedErr         error
+		expectedRequestFail bool
+	}{
+		{
+			name: "pinned",
+			cfg:  &Config{PinnedCertificates: []string{pin}},
+		},
+		{
+			name: "pinned-with-colons",
+			cfg:  &Config{PinnedCertificates: []string{strings.Join(colonSeparatedPin, ":")}},
+		},
+		{
+			name: "pinned-among-others",
+			cfg:  &Config{PinnedCertificates: []string{otherPin, pin}},
+		},
+		{
+			name:                "other-certificate-pinned",
+			cfg:                 &Config{PinnedCertificates: []string{otherPin}},
+			expectedRequestFail: true,
+		},
+		{
+			name:                "other-certificate-pinned-with-insecure",
+			cfg:                 &Config{PinnedCertificates: []string{otherPin}, Insecure: true},
+			expectedRequestFail: true,
+		},
+		{
+			name:        "invalid-pin",
+			cfg:         &Config{PinnedCertificates: []string{"not a fingerprint"}},
+			expectedErr: ErrInvalidPinnedCertificate,
+		},
+		{
+			name:        "pin-too-short",
+			cfg:         &Config{PinnedCertificates: []string{"abcdef"}},
+			expectedErr: ErrInvalidPinnedCertificate,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr != nil {
+				return
+			}
+			response, err := scenario.cfg.getHTTPClient().Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+			if scenario.expectedRequestFail && (err == nil || !errors.Is(err, ErrPinnedCertificateMismatch)) {
+				t.Error("expected the certificate of the server to not match the pins, got", err)
+			}
+			if !scenario.expectedRequestFail && err != nil {
+				t.Error("expected the certificate of the server to match the pins, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithMissingCAFile(t *testing.T) {
+	cfg := &Config{CAFile: t.TempDir() + "/does-not-exist.pem"}
+	if err := cfg.ValidateAndSetDefaults(); err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected error because the CA file does not exist, got", err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour)}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	encodedPrivateKey, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	dir := t.TempDir()
+	certificateFile, privateKeyFile := dir+"/client.pem", dir+"/client-key.pem"
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificate})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: encodedPrivateKey})
+	if err = os.WriteFile(certificateFile, certificatePEM, 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if err = os.WriteFile(privateKeyFile, privateKeyPEM, 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		name                string
+		cfg                 *Config
+		expectedErr         error
+		expectedRequestFail bool
+	}{
+		{
+			name:                "no-client-certificate",
+			cfg:                 &Config{Insecure: true},
+			expectedRequestFail: true,
+		},
+		{
+			name: "client-certificate",
+			cfg:  &Config{Insecure: true, ClientCertificateFile: certificateFile, ClientPrivateKeyFile: privateKeyFile},
+		},
+		{
+			name:        "client-certificate-without-private-key",
+			cfg:         &Config{ClientCertificateFile: certificateFile},
+			expectedErr: ErrInvalidClientCertificate,
+		},
+		{
+			name: "inline-client-certificate",
+			cfg:  &Config{Insecure: true, ClientCertificate: string(certificatePEM), ClientPrivateKey: string(privateKeyPEM)},
+		},
+		{
+			name: "inline-client-certificate-with-private-key-file",
+			cfg:  &Config{Insecure: true, ClientCertificate: string(certificatePEM), ClientPrivateKeyFile: privateKeyFile},
+		},
+		{
+			name:        "inline-private-key-without-client-certificate",
+			cfg:         &Config{ClientPrivateKey: string(privateKeyPEM)},
+			expectedErr: ErrInvalidClientCertificate,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr != nil {
+				return
+			}
+			response, err := scenario.cfg.getHTTPClient().Get(server.URL)
+			if err == nil {
+				response.Body.Close()
+			}
+			if scenario.expectedRequestFail && err == nil {
+				t.Error("expected the request to fail without a client certificate")
+			}
+			if !scenario.expectedRequestFail && err != nil {
+				t.Error("expected the client certificate to be accepted, got", err.Error())
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithMissingClientCertificateFile(t *testing.T) {
+	cfg := &Config{ClientCertificateFile: t.TempDir() + "/does-not-exist.pem", ClientPrivateKeyFile: t.TempDir() + "/does-not-exist-key.pem"}
+	if err := cfg.ValidateAndSetDefaults(); err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected error because the client certificate file does not exist, got", err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithIPVersion(t *testing.T) {
+	for _, ipVersion := range []string{"", IPVersion4, IPVersion6} {
+		if err := (&Config{IPVersion: ipVersion}).ValidateAndSetDefaults(); err != nil {
+			t.Errorf("expected no error for ip-version %q, got %v", ipVersion, err)
+		}
+	}
+	if err := (&Config{IPVersion: "ipv5"}).ValidateAndSetDefaults(); err != ErrInvalidClientIPVersion {
+		t.Errorf("expected %v, got %v", ErrInvalidClientIPVersion, err)
+	}
+}
+
+func TestConfig_ValidateAndSetDefaultsWithKeepAlivePeriod(t *testing.T) {
+	if err := (&Config{KeepAlive: true, KeepAlivePeriod: 30 * time.Second}).ValidateAndSetDefaults(); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	if err := (&Config{KeepAlivePeriod: -time.Second}).ValidateAndSetDefaults(); err != ErrInvalidKeepAlivePeriod {
+		t.Errorf("expected %v, got %v", ErrInvalidKeepAlivePeriod, err)
+	}
+}
+
+func TestConfig_getHTTPClientWithIPVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ipv4Config := &Config{IPVersion: IPVersion4}
+	ipv4Config.ValidateAndSetDefaults()
+	response, err := ipv4Config.getHTTPClient().Get(server.URL)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	response.Body.Close()
+	// The address of the server is an IPv4 address, so connections restricted to IPv6 must fail
+	ipv6Config := &Config{IPVersion: IPVersion6}
+	ipv6Config.ValidateAndSetDefaults()
+	if _, err = ipv6Config.getHTTPClient().Get(server.URL); err == nil || !strings.Contains(err.Error(), "127.0.0.1 has no IPv6 address") {
+		t.Error("expected error because the server has no IPv6 address, got", err)
+	}
+}
+
+func TestConfig_ReferencedFiles(t *testing.T) {
+	cfg := &Config{CAFile: "ca.pem", ClientCertificateFile: "client.pem", ClientPrivateKeyFile: "client-key.pem"}
+	if files := cfg.ReferencedFiles(); len(files) != 3 || files[0] != "ca.pem" || files[1] != "client.pem" || files[2] != "client-key.pem" {
+		t.Error("expected ca.pem, client.pem and client-key.pem, got", files)
+	}
+	if files := (&Config{}).ReferencedFiles(); len(files) != 0 {
+		t.Error("expected no files, got", files)
+	}
+}
@@ -0,0 +1,250 @@
+package client
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrMailboxAuthenticationFailed is the error returned when the server of a mailbox rejects the credentials
+	ErrMailboxAuthenticationFailed = errors.New("mailbox authentication failed")
+
+	// ErrMailboxUnexpectedResponse is the error returned when the server of a mailbox replies with something other
+	// than a positive response
+	ErrMailboxUnexpectedResponse = errors.New("unexpected response from mailbox server")
+)
+
+// MailboxRequest is the information required to check a mailbox through CheckIMAP or CheckPOP3
+type MailboxRequest struct {
+	// Address is the address of the server in the format host:port
+	Address string
+
+	// ImplicitTLS determines whether the connection is encrypted with TLS as soon as it is established (e.g. imaps)
+	ImplicitTLS bool
+
+	// StartTLS determines whether the connection is upgraded to TLS after the greeting (e.g. imap with STARTTLS)
+	StartTLS bool
+
+	// Username is the username to authenticate with. If empty, no authentication is performed.
+	Username string
+
+	// Password is the password to authenticate with
+	Password string
+}
+
+// MailboxResponse is the outcome of CheckIMAP or CheckPOP3
+type MailboxResponse struct {
+	// Connected is whether the greeting of the server was received
+	Connected bool
+
+	// Greeting is the greeting sent by the server when the connection was established
+	Greeting []byte
+
+	// Certificates are the certificates presented by the server, starting with its own, if the connection was
+	// encrypted with TLS
+	Certificates []*x509.Certificate
+}
+
+// mailboxConnection is a line-based connection to the server of a mailbox
+type mailboxConnection struct {
+	connection net.Conn
+	reader     *bufio.Reader
+}
+
+func dialMailbox(request *MailboxRequest, config *Config) (*mailboxConnection, []*x509.Certificate, error) {
+	connection, err := net.DialTimeout("tcp", request.Address, config.Timeout)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error connecting to mailbox server: %w", err)
+	}
+	if err = connection.SetDeadline(time.Now().Add(config.Timeout)); err != nil {
+		connection.Close()
+		return nil, nil, fmt.Errorf("error setting mailbox deadline: %w", err)
+	}
+	c := &mailboxConnection{connection: connection, reader: bufio.NewReader(connection)}
+	var certificates []*x509.Certificate
+	if request.ImplicitTLS {
+		if certificates, err = c.upgradeToTLS(request.Address, config); err != nil {
+			connection.Close()
+			return nil, nil, err
+		}
+	}
+	return c, certificates, nil
+}
+
+// upgradeToTLS performs a TLS handshake over the connection and returns the certificates presented by the server
+func (c *mailboxConnection) upgradeToTLS(address string, config *Config) ([]*x509.Certificate, error) {
+	tlsConfig := config.getTLSConfig()
+	tlsConfig.ServerName, _, _ = net.SplitHostPort(address)
+	tlsConnection := tls.Client(c.connection, tlsConfig)
+	if err := tlsConnection.Handshake(); err != nil {
+		return nil, fmt.Errorf("error performing tls handshake with mailbox server: %w", err)
+	}
+	c.connection = tlsConnection
+	c.reader = bufio.NewReader(tlsConnection)
+	peerCertificates := tlsConnection.ConnectionState().PeerCertificates
+	if len(peerCertificates) == 0 {
+		return nil, errors.New("could not get TLS connection state")
+	}
+	return peerCertificates, nil
+}
+
+func (c *mailboxConnection) readLine() (string, error) {
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("error reading from mailbox server: %w", err)
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+func (c *mailboxConnection) writeLine(line string) error {
+	if _, err := c.connection.Write([]byte(line + "\r\n")); err != nil {
+		return fmt.Errorf("error writing to mailbox server: %w", err)
+	}
+	return nil
+}
+
+func (c *mailboxConnection) Close() error {
+	return c.connection.Close()
+}
+
+// CheckIMAP connects to an IMAP server, authenticates if credentials are provided and selects the INBOX
+func CheckIMAP(request *MailboxRequest, config *Config) (*MailboxResponse, error) {
+	response := &MailboxResponse{}
+	c, certificates, err := dialMailbox(request, config)
+	if err != nil {
+		return response, err
+	}
+	defer c.Close()
+	response.Certificates = certificates
+	greeting, err := c.readLine()
+	if err != nil {
+		return response, err
+	}
+	if !strings.HasPrefix(greeting, "* OK") && !strings.HasPrefix(greeting, "* PREAUTH") {
+		return response, fmt.Errorf("%w: %s", ErrMailboxUnexpectedResponse, greeting)
+	}
+	response.Connected = true
+	response.Greeting = []byte(greeting)
+	if request.StartTLS {
+		if _, err = c.sendIMAPCommand("a1", "STARTTLS"); err != nil {
+			return response, err
+		}
+		if response.Certificates, err = c.upgradeToTLS(request.Address, config); err != nil {
+			return response, err
+		}
+	}
+	if len(request.Username) > 0 {
+		if status, err := c.sendIMAPCommand("a2", "LOGIN "+quoteIMAPString(request.Username)+" "+quoteIMAPString(request.Password)); err != nil {
+			if len(status) > 0 {
+				return response, fmt.Errorf("%w: %s", ErrMailboxAuthenticationFailed, status)
+			}
+			return response, err
+		}
+		if _, err = c.sendIMAPCommand("a3", "SELECT INBOX"); err != nil {
+			return response, err
+		}
+	}
+	_, _ = c.sendIMAPCommand("a4", "LOGOUT")
+	return response, nil
+}
+
+// sendIMAPCommand sends a command and waits for its tagged response
+//
+// If the server replied with something other than OK, the status of the tagged response is returned along with the
+// error.
+func (c *mailboxConnection) sendIMAPCommand(tag, command string) (string, error) {
+	if err := c.writeLine(tag + " " + command); err != nil {
+		return "", err
+	}
+	for {
+		line, err := c.readLine()
+		if err != nil {
+			return "", err
+		}
+		// Skip untagged responses
+		if !strings.HasPrefix(line, tag+" ") {
+			continue
+		}
+		status := strings.TrimPrefix(line, tag+" ")
+		if !strings.HasPrefix(status, "OK") {
+			return status, fmt.Errorf("%w to %s: %s", ErrMailboxUnexpectedResponse, strings.Fields(command)[0], status)
+		}
+		return status, nil
+	}
+}
+
+// quoteIMAPString returns a string as an IMAP quoted string
+func quoteIMAPString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// CheckPOP3 connects to a POP3 server, authenticates if credentials are provided and retrieves the status of the
+// maildrop
+func CheckPOP3(request *MailboxRequest, config *Config) (*MailboxResponse, error) {
+	response := &MailboxResponse{}
+	c, certificates, err := dialMailbox(request, config)
+	if err != nil {
+		return response, err
+	}
+	defer c.Close()
+	response.Certificates = certificates
+	greeting, err := c.readLine()
+	if err != nil {
+		return response, err
+	}
+	if !strings.HasPrefix(greeting, "+OK") {
+		return response, fmt.Errorf("%w: %s", ErrMailboxUnexpectedResponse, greeting)
+	}
+	response.Connected = true
+	response.Greeting = []byte(greeting)
+	if request.StartTLS {
+		if _, err = c.sendPOP3Command("STLS"); err != nil {
+			return response, err
+		}
+		if response.Certificates, err = c.upgradeToTLS(request.Address, config); err != nil {
+			return response, err
+		}
+	}
+	if len(request.Username) > 0 {
+		if status, err := c.sendPOP3Command("USER " + request.Username); err != nil {
+			if len(status) > 0 {
+				return response, fmt.Errorf("%w: %s", ErrMailboxAuthenticationFailed, status)
+			}
+			return response, err
+		}
+		if status, err := c.sendPOP3Command("PASS " + request.Password); err != nil {
+			if len(status) > 0 {
+				return response, fmt.Errorf("%w: %s", ErrMailboxAuthenticationFailed, status)
+			}
+			return response, err
+		}
+		if _, err = c.sendPOP3Command("STAT"); err != nil {
+			return response, err
+		}
+	}
+	_, _ = c.sendPOP3Command("QUIT")
+	return response, nil
+}
+
+// sendPOP3Command sends a command and waits for its response
+//
+// If the server replied with something other than +OK, the response is returned along with the error.
+func (c *mailboxConnection) sendPOP3Command(command string) (string, error) {
+	if err := c.writeLine(command); err != nil {
+		return "", err
+	}
+	line, err := c.readLine()
+	if err != nil {
+		return "", err
+	}
+	if !strings.HasPrefix(line, "+OK") {
+		return line, fmt.Errorf("%w to %s: %s", ErrMailboxUnexpectedResponse, strings.Fields(command)[0], line)
+	}
+	return line, nil
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// startMailboxServer starts a server that replies to each command received with the response returned by respond
+//
+// If respond returns "STARTTLS", the connection is upgraded to TLS.
+func startMailboxServer(t *testing.T, greeting string, respond func(command string) string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{generateMailboxServerCertificate(t)}}
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(connection net.Conn) {
+				defer connection.Close()
+				_, _ = connection.Write([]byte(greeting + "\r\n"))
+				reader := bufio.NewReader(connection)
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					response := respond(strings.TrimRight(line, "\r\n"))
+					if response == "STARTTLS" {
+						command := strings.Fields(line)
+						if len(command) > 1 {
+							// IMAP commands are tagged
+							_, _ = connection.Write([]byte(command[0] + " OK Begin TLS negotiation now\r\n"))
+						} else {
+							_, _ = connection.Write([]byte("+OK Begin TLS negotiation now\r\n"))
+						}
+						tlsConnection := tls.Server(connection, tlsConfig)
+						connection = tlsConnection
+						reader = bufio.NewReader(tlsConnection)
+						continue
+					}
+					_, _ = connection.Write([]byte(response + "\r\n"))
+				}
+			}(connection)
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func generateMailboxServerCertificate(t *testing.T) tls.Certificate {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	template := &x509.Certificate{SerialNumber: big.NewInt(1), NotBefore: time.Now(), NotAfter: time.Now().Add(time.Hour), IPAddresses: []net.IP{net.ParseIP("127.0.0.1")}}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	return tls.Certificate{Certificate: [][]byte{certificate}, PrivateKey: privateKey}
+}
+
+func respondToIMAPCommand(command string) string {
+	fields := strings.Fields(command)
+	switch fields[1] {
+	case "STARTTLS":
+		return "STARTTLS"
+	case "LOGIN":
+		if fields[2] == `"john.doe"` && fields[3] == `"hunter2"` {
+			return fields[0] + " OK LOGIN completed"
+		}
+		return fields[0] + " NO [AUTHENTICATIONFAILED] Invalid credentials"
+	case "SELECT":
+		return "* 1 EXISTS\r\n" + fields[0] + " OK [READ-WRITE] SELECT completed"
+	default:
+		return fields[0] + " OK " + fields[1] + " completed"
+	}
+}
+
+func respondToPOP3Command(command string) string {
+	switch {
+	case command == "STLS":
+		return "STARTTLS"
+	case command == "PASS hunter2":
+		return "+OK maildrop locked and ready"
+	case strings.HasPrefix(command, "PASS"):
+		return "-ERR invalid password"
+	default:
+		return "+OK"
+	}
+}
+
+func TestCheckIMAP(t *testing.T) {
+	address := startMailboxServer(t, "* OK IMAP4rev1 Service Ready", respondToIMAPCommand)
+	scenarios := []struct {
+		name            string
+		request         *MailboxRequest
+		expectedErr     error
+		wantCertificate bool
+	}{
+		{
+			name:    "without-authentication",
+			request: &MailboxRequest{Address: address},
+		},
+		{
+			name:    "with-authentication",
+			request: &MailboxRequest{Address: address, Username: "john.doe", Password: "hunter2"},
+		},
+		{
+			name:        "with-invalid-credentials",
+			request:     &MailboxRequest{Address: address, Username: "john.doe", Password: "wrong"},
+			expectedErr: ErrMailboxAuthenticationFailed,
+		},
+		{
+			name:            "with-starttls",
+			request:         &MailboxRequest{Address: address, StartTLS: true, Username: "john.doe", Password: "hunter2"},
+			wantCertificate: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := CheckIMAP(scenario.request, &Config{Insecure: true, Timeout: 5 * time.Second})
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if !response.Connected {
+				t.Error("expected to be connected")
+			}
+			if string(response.Greeting) != "* OK IMAP4rev1 Service Ready" {
+				t.Errorf("expected greeting to be '* OK IMAP4rev1 Service Ready', got '%s'", response.Greeting)
+			}
+			if scenario.wantCertificate != (len(response.Certificates) > 0) {
+				t.Errorf("expected certificate=%v, got %v", scenario.wantCertificate, len(response.Certificates) > 0)
+			}
+		})
+	}
+}
+
+func TestCheckPOP3(t *testing.T) {
+	address := startMailboxServer(t, "+OK POP3 server ready", respondToPOP3Command)
+	scenarios := []struct {
+		name            string
+		request         *MailboxRequest
+		expectedErr     error
+		wantCertificate bool
+	}{
+		{
+			name:    "without-authentication",
+			request: &MailboxRequest{Address: address},
+		},
+		{
+			name:    "with-authentication",
+			request: &MailboxRequest{Address: address, Username: "john.doe", Password: "hunter2"},
+		},
+		{
+			name:        "with-invalid-credentials",
+			request:     &MailboxRequest{Address: address, Username: "john.doe", Password: "wrong"},
+			expectedErr: ErrMailboxAuthenticationFailed,
+		},
+		{
+			name:            "with-starttls",
+			request:         &MailboxRequest{Address: address, StartTLS: true, Username: "john.doe", Password: "hunter2"},
+			wantCertificate: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := CheckPOP3(scenario.request, &Config{Insecure: true, Timeout: 5 * time.Second})
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if !response.Connected {
+				t.Error("expected to be connected")
+			}
+			if string(response.Greeting) != "+OK POP3 server ready" {
+				t.Errorf("expected greeting to be '+OK POP3 server ready', got '%s'", response.Greeting)
+			}
+			if scenario.wantCertificate != (len(response.Certificates) > 0) {
+				t.Errorf("expected certificate=%v, got %v", scenario.wantCertificate, len(response.Certificates) > 0)
+			}
+		})
+	}
+}
+
+func TestCheckIMAPWithUnreachableServer(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+	response, err := CheckIMAP(&MailboxRequest{Address: address}, &Config{Timeout: time.Second})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if errors.Is(err, ErrMailboxAuthenticationFailed) {
+		t.Error("expected connection error to be distinct from authentication failure")
+	}
+	if response.Connected {
+		t.Error("expected to not be connected")
+	}
+}
+
+func TestCheckIMAPWithImplicitTLS(t *testing.T) {
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", &tls.Config{Certificates: []tls.Certificate{generateMailboxServerCertificate(t)}})
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		connection, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer connection.Close()
+		_, _ = connection.Write([]byte("* OK IMAP4rev1 Service Ready\r\n"))
+		reader := bufio.NewReader(connection)
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			_, _ = connection.Write([]byte(respondToIMAPCommand(strings.TrimRight(line, "\r\n")) + "\r\n"))
+		}
+	}()
+	response, err := CheckIMAP(&MailboxRequest{Address: listener.Addr().String(), ImplicitTLS: true}, &Config{Insecure: true, Timeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if !response.Connected || len(response.Certificates) == 0 {
+		t.Error("expected to be connected over TLS")
+	}
+}
@@ -0,0 +1,183 @@
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// sshClientVersion is the identification string sent to SSH servers
+	sshClientVersion = "SSH-2.0-Gatus"
+
+	// maximumSSHBannerLength is the maximum length of the identification string, as well as of the lines the server
+	// may send before it
+	// Reference: https://www.rfc-editor.org/rfc/rfc4253#section-4.2
+	maximumSSHBannerLength = 255
+
+	// maximumSSHBannerLines is the maximum number of lines read while waiting for the identification string
+	maximumSSHBannerLines = 32
+)
+
+var (
+	// ErrSSHAuthenticationFailed is the error returned when the SSH server rejects the credentials
+	ErrSSHAuthenticationFailed = errors.New("ssh authentication failed")
+
+	// ErrSSHInvalidBanner is the error returned when the server doesn't send a valid SSH identification string
+	ErrSSHInvalidBanner = errors.New("invalid ssh identification string")
+
+	// ErrSSHHostKeyMismatch is the error returned when the host key presented by the server doesn't match the
+	// fingerprint expected
+	ErrSSHHostKeyMismatch = errors.New("the host key presented by the server does not match the host key fingerprint")
+)
+
+// SSHRequest is the information required to check an SSH server through CheckSSH
+type SSHRequest struct {
+	// Address is the address of the server in the format host:port
+	Address string
+
+	// Username is the username to authenticate with. If empty, only the identification string of the server is read.
+	Username string
+
+	// Password is the password to authenticate with
+	Password string
+
+	// PrivateKey is the PEM-encoded private key to authenticate with
+	PrivateKey []byte
+
+	// HostKeyFingerprint is the SHA-256 fingerprint of the host key the server is expected to present, in the format
+	// used by ssh-keygen (e.g. SHA256:...). If empty, any host key is accepted.
+	HostKeyFingerprint string
+}
+
+// SSHResponse is the outcome of CheckSSH
+type SSHResponse struct {
+	// Connected is whether a connection to the server was established
+	Connected bool
+
+	// Banner is the identification string sent by the server (e.g. SSH-2.0-OpenSSH_9.6)
+	Banner []byte
+
+	// Version is the version of the SSH protocol advertised by the server (e.g. 2.0)
+	Version string
+}
+
+// CheckSSH connects to an SSH server and reads its identification string. If credentials are provided, the handshake
+// is completed and the client authenticates with them.
+func CheckSSH(request *SSHRequest, config *Config) (*SSHResponse, error) {
+	response := &SSHResponse{}
+	connection, err := config.dial("tcp", request.Address)
+	if err != nil {
+		return response, fmt.Errorf("error connecting to ssh server: %w", err)
+	}
+	defer connection.Close()
+	response.Connected = true
+	if err = connection.SetDeadline(time.Now().Add(config.Timeout)); err != nil {
+		return response, fmt.Errorf("error setting ssh deadline: %w", err)
+	}
+	reader := bufio.NewReaderSize(connection, maximumSSHBannerLength+1)
+	banner, err := readSSHBanner(reader)
+	if err != nil {
+		return response, err
+	}
+	response.Banner = []byte(banner)
+	response.Version = strings.SplitN(banner, "-", 3)[1]
+	if len(request.Username) == 0 {
+		// Identify ourselves so that the server doesn't log that it never received an identification string
+		_, _ = connection.Write([]byte(sshClientVersion + "\r\n"))
+		return response, nil
+	}
+	return response, performSSHHandshake(request, &replayedConn{Conn: connection, reader: io.MultiReader(strings.NewReader(banner+"\r\n"), reader)})
+}
+
+// readSSHBanner reads the identification string of the server, skipping the lines it may send before it
+func readSSHBanner(reader *bufio.Reader) (string, error) {
+	for i := 0; i < maximumSSHBannerLines; i++ {
+		line, err := reader.ReadSlice('\n')
+		if err == bufio.ErrBufferFull {
+			return "", fmt.Errorf("%w: line is longer than %d characters", ErrSSHInvalidBanner, maximumSSHBannerLength)
+		} else if err == io.EOF {
+			return "", fmt.Errorf("%w: connection closed before it was received", ErrSSHInvalidBanner)
+		} else if err != nil {
+			return "", fmt.Errorf("error reading from ssh server: %w", err)
+		}
+		line = bytes.TrimRight(line, "\r\n")
+		if !bytes.HasPrefix(line, []byte("SSH-")) {
+			continue
+		}
+		if len(bytes.SplitN(line, []byte("-"), 3)) != 3 {
+			return "", fmt.Errorf("%w: %s", ErrSSHInvalidBanner, line)
+		}
+		return string(line), nil
+	}
+	return "", fmt.Errorf("%w: none received after %d lines", ErrSSHInvalidBanner, maximumSSHBannerLines)
+}
+
+// performSSHHandshake performs the SSH handshake over the connection passed and authenticates with the credentials
+// of the request
+//
+// The deadline of the connection must already be set, since it's what bounds the duration of the handshake.
+func performSSHHandshake(request *SSHRequest, connection net.Conn) error {
+	var authMethods []ssh.AuthMethod
+	if len(request.PrivateKey) > 0 {
+		signer, err := ssh.ParsePrivateKey(request.PrivateKey)
+		if err != nil {
+			return fmt.Errorf("error parsing ssh private key: %w", err)
+		}
+		authMethods = append(authMethods, ssh.PublicKeys(signer))
+	}
+	if len(request.Password) > 0 {
+		authMethods = append(authMethods, ssh.Password(request.Password), ssh.KeyboardInteractive(func(_, _ string, questions []string, _ []bool) ([]string, error) {
+			answers := make([]string, len(questions))
+			for i := range answers {
+				answers[i] = request.Password
+			}
+			return answers, nil
+		}))
+	}
+	// The host key is only verified once the key exchange has completed, so if the handshake fails after the host key
+	// was accepted, it can only be because the server rejected the credentials
+	var hostKeyAccepted bool
+	var hostKeyErr error
+	sshConnection, channels, requests, err := ssh.NewClientConn(connection, request.Address, &ssh.ClientConfig{
+		User: request.Username,
+		Auth: authMethods,
+		HostKeyCallback: func(_ string, _ net.Addr, key ssh.PublicKey) error {
+			if len(request.HostKeyFingerprint) > 0 && ssh.FingerprintSHA256(key) != request.HostKeyFingerprint {
+				hostKeyErr = fmt.Errorf("%w: got %s", ErrSSHHostKeyMismatch, ssh.FingerprintSHA256(key))
+				return hostKeyErr
+			}
+			hostKeyAccepted = true
+			return nil
+		},
+		ClientVersion: sshClientVersion,
+	})
+	if err != nil {
+		if hostKeyErr != nil {
+			// The error returned by the handshake doesn't wrap the error of the callback
+			return hostKeyErr
+		} else if hostKeyAccepted {
+			return fmt.Errorf("%w: %s", ErrSSHAuthenticationFailed, err.Error())
+		}
+		return err
+	}
+	return ssh.NewClient(sshConnection, channels, requests).Close()
+}
+
+// replayedConn is a connection whose reads are served by reader, which allows what was already read from the
+// connection to be read again
+type replayedConn struct {
+	net.Conn
+	reader io.Reader
+}
+
+func (c *replayedConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// startSSHServer starts an SSH server that accepts the user john.doe with the password hunter2 or the private key
+// returned, and returns its address, the fingerprint of its host key and the PEM-encoded private key
+func startSSHServer(t *testing.T) (string, string, []byte) {
+	_, hostPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	_, clientPrivateKey, _ := ed25519.GenerateKey(rand.Reader)
+	clientSigner, _ := ssh.NewSignerFromKey(clientPrivateKey)
+	clientPrivateKeyBytes, err := x509.MarshalPKCS8PrivateKey(clientPrivateKey)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	serverConfig := &ssh.ServerConfig{
+		PasswordCallback: func(metadata ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if metadata.User() == "john.doe" && string(password) == "hunter2" {
+				return nil, nil
+			}
+			return nil, errors.New("invalid credentials")
+		},
+		PublicKeyCallback: func(metadata ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if metadata.User() == "john.doe" && bytes.Equal(key.Marshal(), clientSigner.PublicKey().Marshal()) {
+				return nil, nil
+			}
+			return nil, errors.New("invalid key")
+		},
+		ServerVersion: "SSH-2.0-OpenSSH_9.6",
+	}
+	serverConfig.AddHostKey(hostSigner)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func(connection net.Conn) {
+				defer connection.Close()
+				sshConnection, channels, requests, err := ssh.NewServerConn(connection, serverConfig)
+				if err != nil {
+					return
+				}
+				defer sshConnection.Close()
+				go ssh.DiscardRequests(requests)
+				for channel := range channels {
+					_ = channel.Reject(ssh.Prohibited, "no channels allowed")
+				}
+			}(connection)
+		}
+	}()
+	return listener.Addr().String(), ssh.FingerprintSHA256(hostSigner.PublicKey()), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: clientPrivateKeyBytes})
+}
+
+// startBannerServer starts a server that sends the lines passed as soon as a connection is established
+func startBannerServer(t *testing.T, lines string) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		for {
+			connection, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = connection.Write([]byte(lines))
+			connection.Close()
+		}
+	}()
+	return listener.Addr().String()
+}
+
+func TestCheckSSH(t *testing.T) {
+	address, hostKeyFingerprint, privateKey := startSSHServer(t)
+	scenarios := []struct {
+		name        string
+		request     *SSHRequest
+		expectedErr error
+	}{
+		{
+			name:    "without-authentication",
+			request: &SSHRequest{Address: address},
+		},
+		{
+			name:    "with-password",
+			request: &SSHRequest{Address: address, Username: "john.doe", Password: "hunter2"},
+		},
+		{
+			name:    "with-private-key",
+			request: &SSHRequest{Address: address, Username: "john.doe", PrivateKey: privateKey},
+		},
+		{
+			name:    "with-host-key-fingerprint",
+			request: &SSHRequest{Address: address, Username: "john.doe", Password: "hunter2", HostKeyFingerprint: hostKeyFingerprint},
+		},
+		{
+			name:        "with-invalid-credentials",
+			request:     &SSHRequest{Address: address, Username: "john.doe", Password: "wrong"},
+			expectedErr: ErrSSHAuthenticationFailed,
+		},
+		{
+			name:        "with-other-host-key-fingerprint",
+			request:     &SSHRequest{Address: address, Username: "john.doe", Password: "hunter2", HostKeyFingerprint: "SHA256:AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"},
+			expectedErr: ErrSSHHostKeyMismatch,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := CheckSSH(scenario.request, &Config{Timeout: 5 * time.Second})
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if !response.Connected {
+				t.Error("expected to be connected")
+			}
+			if string(response.Banner) != "SSH-2.0-OpenSSH_9.6" {
+				t.Errorf("expected banner to be 'SSH-2.0-OpenSSH_9.6', got '%s'", response.Banner)
+			}
+			if response.Version != "2.0" {
+				t.Errorf("expected version to be '2.0', got '%s'", response.Version)
+			}
+		})
+	}
+}
+
+func TestCheckSSHWithBanner(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		lines          string
+		expectedBanner string
+		expectedErr    error
+	}{
+		{
+			name:           "banner",
+			lines:          "SSH-2.0-dropbear_2022.83\r\n",
+			expectedBanner: "SSH-2.0-dropbear_2022.83",
+		},
+		{
+			name:           "banner-with-comments",
+			lines:          "SSH-1.99-Cisco-1.25 Cisco\r\n",
+			expectedBanner: "SSH-1.99-Cisco-1.25 Cisco",
+		},
+		{
+			name:           "banner-preceded-by-other-lines",
+			lines:          "Authorized access only\r\n\r\nSSH-2.0-OpenSSH_9.6\r\n",
+			expectedBanner: "SSH-2.0-OpenSSH_9.6",
+		},
+		{
+			name:        "no-banner",
+			lines:       "220 smtp.example.com ESMTP\r\n",
+			expectedErr: ErrSSHInvalidBanner,
+		},
+		{
+			name:        "invalid-banner",
+			lines:       "SSH-2.0\r\n",
+			expectedErr: ErrSSHInvalidBanner,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			response, err := CheckSSH(&SSHRequest{Address: startBannerServer(t, scenario.lines)}, &Config{Timeout: 5 * time.Second})
+			if scenario.expectedErr != nil {
+				if !errors.Is(err, scenario.expectedErr) {
+					t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if string(response.Banner) != scenario.expectedBanner {
+				t.Errorf("expected banner to be '%s', got '%s'", scenario.expectedBanner, response.Banner)
+			}
+		})
+	}
+}
+
+func TestCheckSSHWithUnreachableServer(t *testing.T) {
+	response, err := CheckSSH(&SSHRequest{Address: "127.0.0.1:1"}, &Config{Timeout: time.Second})
+	if err == nil {
+		t.Error("expected an error")
+	}
+	if response.Connected {
+		t.Error("expected not to be connected")
+	}
+}
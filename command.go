@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+var (
+	ErrUnknownCommand                      = errors.New("unknown command")
+	ErrMissingBackupFile                   = errors.New("path of the backup file must be specified")
+	ErrBackupNotSupportedWithMemoryStorage = errors.New("backup and restore require a storage of type sqlite or postgres, because memory storage is not persisted")
+)
+
+// runCommand runs the command with the given name and arguments
+//
+// Commands are used to perform one-off operations instead of starting Gatus (e.g. `gatus backup --output file`)
+func runCommand(name string, args []string) error {
+	switch name {
+	case "backup":
+		return backup(args)
+	case "restore":
+		return restore(args)
+	default:
+		return fmt.Errorf("%w: %s (available commands: backup, restore)", ErrUnknownCommand, name)
+	}
+}
+
+// backup writes a backup of the configured storage to the file passed with --output
+func backup(args []string) error {
+	flagSet := flag.NewFlagSet("backup", flag.ContinueOnError)
+	output := flagSet.String("output", "", "Path of the file to write the backup to")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if len(*output) == 0 {
+		return ErrMissingBackupFile
+	}
+	if err := initializeStorageForCommand(); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	file, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err = store.Backup(store.Get(), file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	if err = file.Close(); err != nil {
+		return err
+	}
+	log.Printf("[main][backup] Backup written to %s", *output)
+	return nil
+}
+
+// restore restores the backup from the file passed with --input into the configured storage
+func restore(args []string) error {
+	flagSet := flag.NewFlagSet("restore", flag.ContinueOnError)
+	input := flagSet.String("input", "", "Path of the file to read the backup from")
+	if err := flagSet.Parse(args); err != nil {
+		return err
+	}
+	if len(*input) == 0 {
+		return ErrMissingBackupFile
+	}
+	file, err := os.Open(*input)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err = initializeStorageForCommand(); err != nil {
+		return err
+	}
+	defer store.Get().Close()
+	if err = store.Restore(store.Get(), file); err != nil {
+		return err
+	}
+	log.Printf("[main][restore] Backup restored from %s", *input)
+	return nil
+}
+
+// initializeStorageForCommand initializes the storage provider defined in the configuration
+//
+// Unlike initializeStorage, the endpoint statuses of endpoints that are no longer configured are left untouched.
+func initializeStorageForCommand() error {
+	cfg, err := loadConfiguration()
+	if err != nil {
+		return err
+	}
+	if cfg.Storage == nil || cfg.Storage.Type == storage.TypeMemory {
+		return ErrBackupNotSupportedWithMemoryStorage
+	}
+	return store.Initialize(cfg.Storage)
+}
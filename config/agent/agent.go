@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+// ResultsPath is the path of the API of the central instance to which agents report the results of their checks
+const ResultsPath = "/api/v1/agents/results"
+
+var (
+	ErrNoName             = errors.New("agent name must not be empty")
+	ErrNoToken            = errors.New("agent token must not be empty")
+	ErrInvalidURL         = errors.New("agent.url must be a valid http or https url")
+	ErrDuplicateAgentName = errors.New("agents[].name must be unique")
+)
+
+// Config is the configuration of Gatus when it runs as an agent, in which case it only performs the checks of the
+// endpoints that have its name in their agents, and reports their results to a central instance of Gatus instead of
+// handling them itself
+type Config struct {
+	// Name of the agent, which must be one of the agents configured on the central instance
+	Name string `yaml:"name"`
+
+	// URL of the central instance to which the results are reported (e.g. https://status.example.org)
+	URL string `yaml:"url"`
+
+	// Token with which the agent authenticates to the central instance
+	Token string `yaml:"token"`
+
+	// ClientConfig is the configuration of the client used to report the results
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the configuration of the agent and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Name) == 0 {
+		return ErrNoName
+	}
+	if len(c.Token) == 0 {
+		return ErrNoToken
+	}
+	parsedURL, err := url.Parse(c.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrInvalidURL
+	}
+	c.URL = strings.TrimSuffix(c.URL, "/")
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	} else if err := c.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Report sends the result of a check of the endpoint passed to the central instance
+func (c *Config) Report(endpoint *core.Endpoint, result *core.Result) error {
+	body, err := json.Marshal(NewReport(endpoint, result))
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequest(http.MethodPost, c.URL+ResultsPath, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Authorization", "Bearer "+c.Token)
+	response, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("central instance returned status code %d", response.StatusCode)
+	}
+	return nil
+}
+
+// Agent is an agent allowed to report the results of the checks it performs to this instance
+type Agent struct {
+	// Name of the agent, which is what endpoints reference in their agents
+	Name string `yaml:"name"`
+
+	// Token with which the agent authenticates
+	Token string `yaml:"token"`
+}
+
+// ValidateAndSetDefaults validates the agent
+func (a *Agent) ValidateAndSetDefaults() error {
+	if len(a.Name) == 0 {
+		return ErrNoName
+	}
+	if len(a.Token) == 0 {
+		return ErrNoToken
+	}
+	return nil
+}
+
+// Authenticate returns the agent whose token is the one passed, or nil if there is none
+func Authenticate(agents []*Agent, token string) *Agent {
+	if len(token) == 0 {
+		return nil
+	}
+	for _, agent := range agents {
+		if subtle.ConstantTimeCompare([]byte(agent.Token), []byte(token)) == 1 {
+			return agent
+		}
+	}
+	return nil
+}
+
+// Report is what an agent sends to the central instance for each result
+type Report struct {
+	// Key is the key of the endpoint the result is for
+	Key string `json:"key"`
+
+	// Result of the check
+	Result *core.Result `json:"result"`
+
+	// The fields below are those of the result that aren't part of its JSON representation, but that are still used
+	// by the central instance (e.g. for metrics)
+
+	Connected             bool          `json:"connected,omitempty"`
+	IP                    string        `json:"ip,omitempty"`
+	DNSRCode              string        `json:"dnsRCode,omitempty"`
+	CertificateExpiration time.Duration `json:"certificateExpiration,omitempty"`
+	DomainExpiration      time.Duration `json:"domainExpiration,omitempty"`
+}
+
+// NewReport creates a report of the result of a check of the endpoint passed
+func NewReport(endpoint *core.Endpoint, result *core.Result) *Report {
+	return &Report{
+		Key:                   endpoint.Key(),
+		Result:                result,
+		Connected:             result.Connected,
+		IP:                    result.IP,
+		DNSRCode:              result.DNSRCode,
+		CertificateExpiration: result.CertificateExpiration,
+		DomainExpiration:      result.DomainExpiration,
+	}
+}
+
+// ToResult returns the result of the report, recorded as performed by the agent passed
+func (r *Report) ToResult(agentName string) *core.Result {
+	result := r.Result
+	if result == nil {
+		result = &core.Result{}
+	}
+	result.Connected = r.Connected
+	result.IP = r.IP
+	result.DNSRCode = r.DNSRCode
+	result.CertificateExpiration = r.CertificateExpiration
+	result.DomainExpiration = r.DomainExpiration
+	result.Agent = agentName
+	return result
+}
@@ -0,0 +1,102 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      *Config
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			config: &Config{Name: "eu-west", URL: "https://status.example.org/", Token: "token"},
+		},
+		{
+			name:        "no-name",
+			config:      &Config{URL: "https://status.example.org", Token: "token"},
+			expectedErr: ErrNoName,
+		},
+		{
+			name:        "no-token",
+			config:      &Config{Name: "eu-west", URL: "https://status.example.org"},
+			expectedErr: ErrNoToken,
+		},
+		{
+			name:        "invalid-url",
+			config:      &Config{Name: "eu-west", URL: "status.example.org", Token: "token"},
+			expectedErr: ErrInvalidURL,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil {
+				if scenario.config.URL != "https://status.example.org" {
+					t.Errorf("expected the trailing slash of the URL to be removed, got %s", scenario.config.URL)
+				}
+				if scenario.config.ClientConfig == nil {
+					t.Error("expected the client config to be set to the default one")
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticate(t *testing.T) {
+	agents := []*Agent{{Name: "eu-west", Token: "eu-west-token"}, {Name: "us-east", Token: "us-east-token"}}
+	if agent := Authenticate(agents, "us-east-token"); agent == nil || agent.Name != "us-east" {
+		t.Errorf("expected agent us-east to be authenticated, got %v", agent)
+	}
+	if agent := Authenticate(agents, "invalid"); agent != nil {
+		t.Errorf("expected no agent to be authenticated with an invalid token, got %s", agent.Name)
+	}
+	if agent := Authenticate([]*Agent{{Name: "no-token"}}, ""); agent != nil {
+		t.Errorf("expected no agent to be authenticated without a token, got %s", agent.Name)
+	}
+}
+
+func TestConfig_Report(t *testing.T) {
+	var receivedReport Report
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != ResultsPath || r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&receivedReport); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	cfg := &Config{Name: "eu-west", URL: server.URL, Token: "token"}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint := &core.Endpoint{Name: "frontend", Group: "core"}
+	if err := cfg.Report(endpoint, &core.Result{Success: true, HTTPStatus: 200, IP: "127.0.0.1", CertificateExpiration: time.Hour}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if receivedReport.Key != "core_frontend" {
+		t.Errorf("expected key %s, got %s", "core_frontend", receivedReport.Key)
+	}
+	result := receivedReport.ToResult("eu-west")
+	if !result.Success || result.HTTPStatus != 200 || result.IP != "127.0.0.1" || result.CertificateExpiration != time.Hour || result.Agent != "eu-west" {
+		t.Errorf("expected the result to be reported as is and recorded as performed by eu-west, got %+v", result)
+	}
+	cfg.Token = "invalid"
+	if err := cfg.Report(endpoint, &core.Result{}); err == nil {
+		t.Error("expected an error when the central instance rejects the report")
+	}
+}
@@ -13,13 +13,23 @@ import (
 	"github.com/TwiN/deepmerge"
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/agent"
 	"github.com/TwiN/gatus/v5/config/connectivity"
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
 	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/ratelimit"
 	"github.com/TwiN/gatus/v5/config/remote"
+	"github.com/TwiN/gatus/v5/config/selfmonitoring"
+	"github.com/TwiN/gatus/v5/config/source"
 	"github.com/TwiN/gatus/v5/config/ui"
+	"github.com/TwiN/gatus/v5/config/vault"
 	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/resultwebhook"
 	"github.com/TwiN/gatus/v5/security"
 	"github.com/TwiN/gatus/v5/storage"
 	"github.com/TwiN/gatus/v5/util"
@@ -34,6 +44,21 @@ const (
 	// DefaultFallbackConfigurationFilePath is the default fallback path that will be used to search for the
 	// configuration file if DefaultConfigurationFilePath didn't work
 	DefaultFallbackConfigurationFilePath = "config/config.yml"
+
+	// AllowExecEnvironmentVariable is the environment variable that must be set to true for endpoints of type EXEC to
+	// be allowed, since they execute commands on the host Gatus is running on
+	AllowExecEnvironmentVariable = "GATUS_ALLOW_EXEC"
+
+	// DefaultShutdownTimeout is the default maximum duration to wait for the checks in progress to complete when
+	// shutting down
+	DefaultShutdownTimeout = 30 * time.Second
+
+	// LogLevelDebug is the log level with which the debug logs are enabled and the HTTP exchanges of every endpoint of
+	// type HTTP are logged
+	LogLevelDebug = "DEBUG"
+
+	// LogLevelInfo is the default log level
+	LogLevelInfo = "INFO"
 )
 
 var (
@@ -46,6 +71,39 @@ var (
 	// ErrInvalidSecurityConfig is an error returned when the security configuration is invalid
 	ErrInvalidSecurityConfig = errors.New("invalid security configuration")
 
+	// ErrDuplicateEndpointGroup is an error returned when two endpoint groups have the same name
+	ErrDuplicateEndpointGroup = errors.New("endpoint group names must be unique")
+
+	// ErrUndefinedEndpointGroup is an error returned when endpoint groups are configured, but an endpoint references a
+	// group that isn't one of them
+	ErrUndefinedEndpointGroup = errors.New("endpoint references a group that is not defined in endpoint-groups")
+
+	// ErrMetricsWithPushgateway is an error returned when both metrics and metrics-pushgateway are configured
+	ErrMetricsWithPushgateway = errors.New("metrics and metrics-pushgateway are mutually exclusive")
+
+	// ErrUnknownEndpointDependency is an error returned when an endpoint depends on an endpoint that doesn't exist
+	ErrUnknownEndpointDependency = errors.New("endpoint depends on an endpoint that does not exist")
+
+	// ErrExecNotAllowed is an error returned when an endpoint of type EXEC is configured without the
+	// AllowExecEnvironmentVariable being set to true
+	ErrExecNotAllowed = errors.New("endpoints of type EXEC are disabled unless the " + AllowExecEnvironmentVariable + " environment variable is set to true")
+
+	// ErrInvalidShutdownTimeout is an error returned when the shutdown timeout is negative
+	ErrInvalidShutdownTimeout = errors.New("shutdown-timeout must not be negative")
+
+	// ErrInvalidLogLevel is an error returned when the log level is neither DEBUG nor INFO
+	ErrInvalidLogLevel = errors.New("log-level must be DEBUG or INFO")
+
+	// ErrInvalidStartupGrace is an error returned when the startup grace is negative
+	ErrInvalidStartupGrace = errors.New("startup-grace must not be negative")
+
+	// ErrUndefinedAgent is an error returned when an endpoint references an agent that isn't defined in agents
+	ErrUndefinedAgent = errors.New("endpoint references an agent that is not defined in agents")
+
+	// ErrUndefinedVariableInCondition is an error returned when a condition references a variable that is neither set
+	// as an environment variable nor defined in variables
+	ErrUndefinedVariableInCondition = errors.New("condition references a variable that is neither set as an environment variable nor defined in variables")
+
 	// errEarlyReturn is returned to break out of a loop from a callback early
 	errEarlyReturn = errors.New("early escape")
 )
@@ -55,9 +113,31 @@ type Config struct {
 	// Debug Whether to enable debug logs
 	Debug bool `yaml:"debug,omitempty"`
 
+	// LogLevel is the level of the logs, which is either INFO (default) or DEBUG
+	//
+	// DEBUG enables the debug logs, as Debug does, and logs the request sent and the response received by each check
+	// of every endpoint of type HTTP, as if each of them had debug enabled.
+	LogLevel string `yaml:"log-level,omitempty"`
+
 	// Metrics Whether to expose metrics at /metrics
 	Metrics bool `yaml:"metrics,omitempty"`
 
+	// MetricsRemoteWrite is the configuration for pushing metrics to a Prometheus remote write endpoint.
+	// Independent of Metrics, which only controls whether metrics are exposed at /metrics.
+	MetricsRemoteWrite *metrics.RemoteWriteConfig `yaml:"metrics-remote-write,omitempty"`
+
+	// MetricsPushgateway is the configuration for pushing metrics to a Prometheus Pushgateway.
+	// Mutually exclusive with Metrics, since the same series would otherwise be collected twice.
+	MetricsPushgateway *metrics.PushgatewayConfig `yaml:"metrics-pushgateway,omitempty"`
+
+	// ResultWebhook is the configuration of the webhook to which the result of every check is sent.
+	// Independent of alerting, which only sends a request when an alert is triggered or resolved.
+	ResultWebhook *resultwebhook.Config `yaml:"result-webhook,omitempty"`
+
+	// MetricsLabels is the list of labels identifying the endpoint of each metric.
+	// Dropping high-cardinality labels such as key reduces the number of series, but also the granularity of metrics.
+	MetricsLabels []string `yaml:"metrics-labels,omitempty"`
+
 	// SkipInvalidConfigUpdate Whether to make the application ignore invalid configuration
 	// if the configuration file is updated while the application is running
 	SkipInvalidConfigUpdate bool `yaml:"skip-invalid-config-update,omitempty"`
@@ -67,6 +147,14 @@ type Config struct {
 	// Disabling this may lead to inaccurate response times
 	DisableMonitoringLock bool `yaml:"disable-monitoring-lock,omitempty"`
 
+	// ShutdownTimeout is the maximum duration to wait for the checks in progress, and the alerts they trigger or
+	// resolve, to complete when shutting down or reloading the configuration
+	ShutdownTimeout time.Duration `yaml:"shutdown-timeout,omitempty"`
+
+	// StartupGrace is the duration after the application starts during which the results of the endpoints are
+	// recorded, but don't trigger alerts. Endpoints with their own startup-grace are not affected.
+	StartupGrace time.Duration `yaml:"startup-grace,omitempty"`
+
 	// Security Configuration for securing access to Gatus
 	Security *security.Config `yaml:"security,omitempty"`
 
@@ -76,6 +164,9 @@ type Config struct {
 	// Endpoints List of endpoints to monitor
 	Endpoints []*core.Endpoint `yaml:"endpoints,omitempty"`
 
+	// EndpointGroups List of endpoint groups whose configuration is inherited by the endpoints in them
+	EndpointGroups []*endpointgroup.Config `yaml:"endpoint-groups,omitempty"`
+
 	// Storage is the configuration for how the data is stored
 	Storage *storage.Config `yaml:"storage,omitempty"`
 
@@ -95,8 +186,38 @@ type Config struct {
 	// Connectivity is the configuration for connectivity
 	Connectivity *connectivity.Config `yaml:"connectivity,omitempty"`
 
-	configPath      string    // path to the file or directory from which config was loaded
-	lastFileModTime time.Time // last modification time
+	// HostRateLimit is the configuration of the rate limit applied to the checks of endpoints targeting the same host
+	HostRateLimit *ratelimit.Config `yaml:"host-rate-limit,omitempty"`
+
+	// Client is the configuration that applies to the client of every endpoint
+	Client *client.GlobalConfig `yaml:"client,omitempty"`
+
+	// SelfMonitoring is the configuration for monitoring Gatus itself. If set, an endpoint targeting the health API of
+	// Gatus is added to the endpoints.
+	SelfMonitoring *selfmonitoring.Config `yaml:"self-monitoring,omitempty"`
+
+	// ConfigSources are the sources from which documents that are merged with the configuration are fetched (e.g. a
+	// list of endpoints managed by another system). The configuration is reloaded whenever one of them changes.
+	ConfigSources []*source.Config `yaml:"config-sources,omitempty"`
+
+	// Vault is the configuration for reading the secrets referenced by the configuration (vault://<path>#<field>)
+	// from HashiCorp Vault
+	Vault *vault.Config `yaml:"vault,omitempty"`
+
+	// Agent is the configuration of Gatus when it runs as an agent, in which case it only performs the checks of the
+	// endpoints assigned to it and reports their results to a central instance
+	Agent *agent.Config `yaml:"agent,omitempty"`
+
+	// Agents are the agents allowed to report the results of the checks of the endpoints assigned to them
+	Agents []*agent.Agent `yaml:"agents,omitempty"`
+
+	// Variables are the default values of the variables referenced by the configuration (${NAME}), which are used
+	// when there's no environment variable with the same name
+	Variables map[string]string `yaml:"variables,omitempty"`
+
+	configPath        string    // path to the file or directory from which config was loaded
+	lastFileModTime   time.Time // last modification time
+	secretsExpiration time.Time // time at which the secrets read from Vault must be read again, if any
 }
 
 func (config *Config) GetEndpointByKey(key string) *core.Endpoint {
@@ -111,9 +232,19 @@ func (config *Config) GetEndpointByKey(key string) *core.Endpoint {
 }
 
 // HasLoadedConfigurationBeenModified returns whether one of the file that the
-// configuration has been loaded from has been modified since it was last read
+// configuration has been loaded from, or one of the files referenced by the
+// endpoints (e.g. body-file, ca-file), has been modified since it was last read
 func (config Config) HasLoadedConfigurationBeenModified() bool {
 	lastMod := config.lastFileModTime.Unix()
+	for _, endpoint := range config.Endpoints {
+		for _, file := range endpoint.ReferencedFiles() {
+			// os.Stat follows symlinks, which means that secrets mounted as volumes in Kubernetes are
+			// considered modified when the symlink is updated to point to the new version of the secret
+			if fileInfo, err := os.Stat(file); err == nil && lastMod < fileInfo.ModTime().Unix() {
+				return true
+			}
+		}
+	}
 	fileInfo, err := os.Stat(config.configPath)
 	if err != nil {
 		return false
@@ -130,6 +261,27 @@ func (config Config) HasLoadedConfigurationBeenModified() bool {
 	return !fileInfo.ModTime().IsZero() && config.lastFileModTime.Unix() < fileInfo.ModTime().Unix()
 }
 
+// HaveSecretsExpired returns whether the secrets read from Vault when the configuration was loaded must be read again
+// because their lease is about to expire
+func (config *Config) HaveSecretsExpired() bool {
+	return !config.secretsExpiration.IsZero() && time.Now().After(config.secretsExpiration)
+}
+
+// HaveConfigSourcesBeenModified returns whether the document of one of the config sources has changed since the
+// configuration was loaded
+//
+// Each source is only fetched again once its refresh interval has elapsed.
+func (config *Config) HaveConfigSourcesBeenModified() bool {
+	modified := false
+	for _, configSource := range config.ConfigSources {
+		// Every source is checked, so that they all consider the documents fetched as the ones that were loaded
+		if configSource.IsModified() {
+			modified = true
+		}
+	}
+	return modified
+}
+
 // UpdateLastFileModTime refreshes Config.lastFileModTime
 func (config *Config) UpdateLastFileModTime() {
 	config.lastFileModTime = time.Now()
@@ -222,26 +374,90 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 	// Replace $$ with __GATUS_LITERAL_DOLLAR_SIGN__ to prevent os.ExpandEnv from treating "$$" as if it was an
 	// environment variable. This allows Gatus to support literal "$" in the configuration file.
 	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "$$", "__GATUS_LITERAL_DOLLAR_SIGN__"))
-	// Expand environment variables
-	yamlBytes = []byte(os.ExpandEnv(string(yamlBytes)))
+	// Expand environment variables, as well as the variables defined in the configuration
+	if yamlBytes, err = expandVariables(yamlBytes); err != nil {
+		return nil, err
+	}
 	// Replace __GATUS_LITERAL_DOLLAR_SIGN__ with "$" to restore the literal "$" in the configuration file
 	yamlBytes = []byte(strings.ReplaceAll(string(yamlBytes), "__GATUS_LITERAL_DOLLAR_SIGN__", "$"))
+	// Replace the references to secrets stored in Vault by their value
+	var secretsLeaseDuration time.Duration
+	if vault.HasReferences(yamlBytes) {
+		if yamlBytes, secretsLeaseDuration, err = resolveVaultReferences(yamlBytes); err != nil {
+			return nil, err
+		}
+	}
+	// Merge the documents fetched from the config sources with the configuration
+	var configSources []*source.Config
+	if yamlBytes, configSources, err = resolveConfigSources(yamlBytes); err != nil {
+		return nil, err
+	}
 	// Parse configuration file
 	if err = yaml.Unmarshal(yamlBytes, &config); err != nil {
 		return
 	}
+	if config != nil {
+		// The sources that were fetched are kept rather than the ones parsed with the rest of the configuration,
+		// since they're the ones that know which documents were loaded
+		config.ConfigSources = configSources
+	}
+	if config != nil && secretsLeaseDuration > 0 {
+		// The configuration is reloaded before the secrets expire, so that they can be read again
+		config.secretsExpiration = time.Now().Add(secretsLeaseDuration * 2 / 3)
+	}
 	// Check if the configuration file at least has endpoints configured
 	if config == nil || config.Endpoints == nil || len(config.Endpoints) == 0 {
 		err = ErrNoEndpointInConfig
 	} else {
+		// The log level must be validated before anything else, since it determines whether the debug logs are enabled
+		if err := validateLogLevelConfig(config); err != nil {
+			return nil, err
+		}
+		// Endpoint groups must be applied next, since the endpoints may inherit alerts from their group
+		if err := validateAndApplyEndpointGroupsConfig(config); err != nil {
+			return nil, err
+		}
+		// The endpoint monitoring Gatus must be added before the alerts are validated, and it is built from the web
+		// configuration
+		if err := validateWebConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateAndApplySelfMonitoringConfig(config); err != nil {
+			return nil, err
+		}
+		// Alerts with targets must be expanded before the default alert of the provider of each target is applied
+		if err := expandAlertTargets(config.Endpoints); err != nil {
+			return nil, err
+		}
 		validateAlertingConfig(config.Alerting, config.Endpoints, config.Debug)
+		if err := validateAlertingCircuitBreakerConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateAlertingRoutesConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateAlertingLocaleConfig(config); err != nil {
+			return nil, err
+		}
 		if err := validateSecurityConfig(config); err != nil {
 			return nil, err
 		}
 		if err := validateEndpointsConfig(config); err != nil {
 			return nil, err
 		}
-		if err := validateWebConfig(config); err != nil {
+		if err := validateMetricsRemoteWriteConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateMetricsPushgatewayConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateResultWebhookConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateAgentsConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateMetricsLabelsConfig(config); err != nil {
 			return nil, err
 		}
 		if err := validateUIConfig(config); err != nil {
@@ -259,10 +475,123 @@ func parseAndValidateConfigBytes(yamlBytes []byte) (config *Config, err error) {
 		if err := validateConnectivityConfig(config); err != nil {
 			return nil, err
 		}
+		if err := validateHostRateLimitConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateVaultConfig(config); err != nil {
+			return nil, err
+		}
+		if err := validateShutdownTimeoutConfig(config); err != nil {
+			return nil, err
+		}
 	}
 	return
 }
 
+// resolveVaultReferences replaces the values of the configuration referencing secrets stored in Vault by the value of
+// said secrets, and returns the resulting configuration along with the shortest lease duration of the secrets
+func resolveVaultReferences(yamlBytes []byte) ([]byte, time.Duration, error) {
+	var vaultOnlyConfig struct {
+		Vault *vault.Config `yaml:"vault"`
+	}
+	if err := yaml.Unmarshal(yamlBytes, &vaultOnlyConfig); err != nil {
+		return nil, 0, err
+	}
+	if vaultOnlyConfig.Vault == nil {
+		return nil, 0, vault.ErrReferenceWithoutConfig
+	}
+	if err := vaultOnlyConfig.Vault.ValidateAndSetDefaults(); err != nil {
+		return nil, 0, err
+	}
+	return vaultOnlyConfig.Vault.ResolveReferences(yamlBytes)
+}
+
+// expandVariables replaces the references to variables (${NAME} or $NAME) by the value of the environment variable
+// with the same name or, if there is none, by the default value defined in variables.
+//
+// A reference to a variable that is defined nowhere is replaced by an empty string, except in conditions, in which
+// case an error is returned, since a threshold silently becoming empty would make the condition meaningless.
+func expandVariables(yamlBytes []byte) ([]byte, error) {
+	type conditionsOnlyConfig struct {
+		Name       string   `yaml:"name"`
+		Conditions []string `yaml:"conditions"`
+	}
+	var variablesOnlyConfig struct {
+		Variables      map[string]string       `yaml:"variables"`
+		Endpoints      []*conditionsOnlyConfig `yaml:"endpoints"`
+		EndpointGroups []*conditionsOnlyConfig `yaml:"endpoint-groups"`
+	}
+	// The configuration may only be valid YAML once expanded, in which case there's nothing more to do than
+	// expanding the environment variables
+	if err := yaml.Unmarshal(yamlBytes, &variablesOnlyConfig); err != nil {
+		return []byte(os.ExpandEnv(string(yamlBytes))), nil
+	}
+	lookup := func(name string) (string, bool) {
+		if value, exists := os.LookupEnv(name); exists {
+			return value, true
+		}
+		if value, exists := variablesOnlyConfig.Variables[name]; exists {
+			return os.ExpandEnv(value), true
+		}
+		return "", false
+	}
+	for _, withConditions := range append(variablesOnlyConfig.Endpoints, variablesOnlyConfig.EndpointGroups...) {
+		if withConditions == nil {
+			continue
+		}
+		for _, condition := range withConditions.Conditions {
+			var undefinedVariable string
+			os.Expand(condition, func(name string) string {
+				if _, exists := lookup(name); !exists && len(undefinedVariable) == 0 {
+					undefinedVariable = name
+				}
+				return ""
+			})
+			if len(undefinedVariable) > 0 {
+				return nil, fmt.Errorf("%w: %s in condition '%s' of %s", ErrUndefinedVariableInCondition, undefinedVariable, condition, withConditions.Name)
+			}
+		}
+	}
+	return []byte(os.Expand(string(yamlBytes), func(name string) string {
+		value, _ := lookup(name)
+		return value
+	})), nil
+}
+
+// resolveConfigSources merges the documents fetched from the config sources of the configuration passed with it, and
+// returns the resulting configuration along with the sources
+func resolveConfigSources(yamlBytes []byte) ([]byte, []*source.Config, error) {
+	var sourcesOnlyConfig struct {
+		ConfigSources []*source.Config `yaml:"config-sources"`
+	}
+	if err := yaml.Unmarshal(yamlBytes, &sourcesOnlyConfig); err != nil {
+		return nil, nil, err
+	}
+	for _, configSource := range sourcesOnlyConfig.ConfigSources {
+		if err := configSource.ValidateAndSetDefaults(); err != nil {
+			return nil, nil, err
+		}
+		document, err := configSource.Fetch()
+		if err != nil {
+			return nil, nil, err
+		}
+		if yamlBytes, err = deepmerge.YAML(yamlBytes, document); err != nil {
+			return nil, nil, fmt.Errorf("error merging config source %s: %w", configSource.URL, err)
+		}
+	}
+	return yamlBytes, sourcesOnlyConfig.ConfigSources, nil
+}
+
+func validateShutdownTimeoutConfig(config *Config) error {
+	if config.ShutdownTimeout < 0 {
+		return ErrInvalidShutdownTimeout
+	}
+	if config.ShutdownTimeout == 0 {
+		config.ShutdownTimeout = DefaultShutdownTimeout
+	}
+	return nil
+}
+
 func validateConnectivityConfig(config *Config) error {
 	if config.Connectivity != nil {
 		return config.Connectivity.ValidateAndSetDefaults()
@@ -270,6 +599,20 @@ func validateConnectivityConfig(config *Config) error {
 	return nil
 }
 
+func validateHostRateLimitConfig(config *Config) error {
+	if config.HostRateLimit != nil {
+		return config.HostRateLimit.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateVaultConfig(config *Config) error {
+	if config.Vault != nil {
+		return config.Vault.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
 func validateRemoteConfig(config *Config) error {
 	if config.Remote != nil {
 		if err := config.Remote.ValidateAndSetDefaults(); err != nil {
@@ -314,6 +657,111 @@ func validateUIConfig(config *Config) error {
 	return nil
 }
 
+func validateAlertingCircuitBreakerConfig(config *Config) error {
+	if config.Alerting != nil && config.Alerting.CircuitBreaker != nil {
+		return config.Alerting.CircuitBreaker.ValidateAndSetDefaults()
+	}
+	return nil
+}
+
+func validateAlertingRoutesConfig(config *Config) error {
+	if config.Alerting == nil || len(config.Alerting.Routes) == 0 {
+		return nil
+	}
+	var alertTypes []alert.Type
+	isAlertTypeAdded := make(map[alert.Type]bool)
+	for _, endpoint := range config.Endpoints {
+		for _, endpointAlert := range endpoint.Alerts {
+			if !isAlertTypeAdded[endpointAlert.Type] {
+				alertTypes = append(alertTypes, endpointAlert.Type)
+				isAlertTypeAdded[endpointAlert.Type] = true
+			}
+		}
+	}
+	return config.Alerting.ValidateRoutes(alertTypes)
+}
+
+func validateAlertingLocaleConfig(config *Config) error {
+	if config.Alerting == nil {
+		return i18n.SetLocale(i18n.DefaultLocale)
+	}
+	if err := i18n.SetLocale(config.Alerting.Locale); err != nil {
+		return fmt.Errorf("%w: %s", err, config.Alerting.Locale)
+	}
+	return nil
+}
+
+func validateMetricsRemoteWriteConfig(config *Config) error {
+	if config.MetricsRemoteWrite != nil {
+		if err := config.MetricsRemoteWrite.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid metrics-remote-write config: %w", err)
+		}
+	}
+	return nil
+}
+
+// validateAgentsConfig validates the configuration of the agent, if Gatus runs as one, as well as the agents
+// referenced by the endpoints otherwise
+func validateAgentsConfig(config *Config) error {
+	if config.Agent != nil {
+		if err := config.Agent.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid agent config: %w", err)
+		}
+		// The endpoints assigned to other agents are none of the concern of this one
+		return nil
+	}
+	agentNames := make(map[string]bool)
+	for _, agentConfig := range config.Agents {
+		if err := agentConfig.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid agents config: %w", err)
+		}
+		if agentNames[agentConfig.Name] {
+			return fmt.Errorf("%w: %s", agent.ErrDuplicateAgentName, agentConfig.Name)
+		}
+		agentNames[agentConfig.Name] = true
+	}
+	for _, endpoint := range config.Endpoints {
+		for _, agentName := range endpoint.Agents {
+			if !agentNames[agentName] {
+				return fmt.Errorf("%w: endpoint %s references agent %s", ErrUndefinedAgent, endpoint.Key(), agentName)
+			}
+		}
+	}
+	return nil
+}
+
+func validateResultWebhookConfig(config *Config) error {
+	if config.ResultWebhook != nil {
+		if err := config.ResultWebhook.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid result-webhook config: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateMetricsPushgatewayConfig(config *Config) error {
+	if config.MetricsPushgateway != nil {
+		if config.Metrics {
+			return ErrMetricsWithPushgateway
+		}
+		if err := config.MetricsPushgateway.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid metrics-pushgateway config: %w", err)
+		}
+	}
+	return nil
+}
+
+func validateMetricsLabelsConfig(config *Config) error {
+	if len(config.MetricsLabels) == 0 {
+		config.MetricsLabels = metrics.DefaultLabels
+		return nil
+	}
+	if err := metrics.ValidateLabels(config.MetricsLabels); err != nil {
+		return fmt.Errorf("invalid metrics-labels config: %w", err)
+	}
+	return nil
+}
+
 func validateWebConfig(config *Config) error {
 	if config.Web == nil {
 		config.Web = web.GetDefaultConfig()
@@ -323,19 +771,120 @@ func validateWebConfig(config *Config) error {
 	return nil
 }
 
+// validateAndApplySelfMonitoringConfig validates the self-monitoring configuration and, if set, adds the endpoint
+// monitoring Gatus to the endpoints
+func validateAndApplySelfMonitoringConfig(config *Config) error {
+	if config.SelfMonitoring == nil {
+		return nil
+	}
+	if err := config.SelfMonitoring.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	config.Endpoints = append(config.Endpoints, config.SelfMonitoring.Endpoint(config.Web))
+	return nil
+}
+
+func validateAndApplyEndpointGroupsConfig(config *Config) error {
+	if len(config.EndpointGroups) == 0 {
+		return nil
+	}
+	endpointGroups := make(map[string]*endpointgroup.Config, len(config.EndpointGroups))
+	for _, endpointGroup := range config.EndpointGroups {
+		if err := endpointGroup.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if _, exists := endpointGroups[endpointGroup.Name]; exists {
+			return fmt.Errorf("%w: %s", ErrDuplicateEndpointGroup, endpointGroup.Name)
+		}
+		endpointGroups[endpointGroup.Name] = endpointGroup
+	}
+	for _, endpoint := range config.Endpoints {
+		if len(endpoint.Group) == 0 {
+			continue
+		}
+		endpointGroup, exists := endpointGroups[endpoint.Group]
+		if !exists {
+			return fmt.Errorf("%w: endpoint %s references group %s", ErrUndefinedEndpointGroup, endpoint.Name, endpoint.Group)
+		}
+		endpointGroup.ApplyTo(endpoint)
+	}
+	log.Printf("[config][validateAndApplyEndpointGroupsConfig] Validated %d endpoint groups", len(config.EndpointGroups))
+	return nil
+}
+
+func validateLogLevelConfig(config *Config) error {
+	config.LogLevel = strings.ToUpper(config.LogLevel)
+	switch config.LogLevel {
+	case "":
+		config.LogLevel = LogLevelInfo
+	case LogLevelDebug:
+		config.Debug = true
+	case LogLevelInfo:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidLogLevel, config.LogLevel)
+	}
+	return nil
+}
+
 func validateEndpointsConfig(config *Config) error {
+	if config.StartupGrace < 0 {
+		return ErrInvalidStartupGrace
+	}
 	for _, endpoint := range config.Endpoints {
 		if config.Debug {
 			log.Printf("[config][validateEndpointsConfig] Validating endpoint '%s'", endpoint.Name)
 		}
+		if config.Client != nil && len(config.Client.DefaultUserAgent) > 0 {
+			if endpoint.ClientConfig == nil {
+				endpoint.ClientConfig = client.GetDefaultConfig()
+			}
+			if len(endpoint.ClientConfig.UserAgent) == 0 {
+				endpoint.ClientConfig.UserAgent = config.Client.DefaultUserAgent
+			}
+		}
+		if endpoint.StartupGrace == 0 {
+			endpoint.StartupGrace = config.StartupGrace
+		}
+		if config.LogLevel == LogLevelDebug && endpoint.Type() == core.EndpointTypeHTTP {
+			endpoint.Debug = true
+		}
 		if err := endpoint.ValidateAndSetDefaults(); err != nil {
 			return fmt.Errorf("invalid endpoint %s: %w", endpoint.DisplayName(), err)
 		}
+		if endpoint.Type() == core.EndpointTypeEXEC && os.Getenv(AllowExecEnvironmentVariable) != "true" {
+			return fmt.Errorf("invalid endpoint %s: %w", endpoint.DisplayName(), ErrExecNotAllowed)
+		}
+	}
+	if err := resolveEndpointDependencies(config.Endpoints); err != nil {
+		return err
 	}
 	log.Printf("[config][validateEndpointsConfig] Validated %d endpoints", len(config.Endpoints))
 	return nil
 }
 
+// resolveEndpointDependencies sets the dependencies of each endpoint from the keys in its DependsOn
+func resolveEndpointDependencies(endpoints []*core.Endpoint) error {
+	endpointsByKey := make(map[string]*core.Endpoint, len(endpoints))
+	for _, endpoint := range endpoints {
+		endpointsByKey[endpoint.Key()] = endpoint
+	}
+	for _, endpoint := range endpoints {
+		if len(endpoint.DependsOn) == 0 {
+			continue
+		}
+		dependencies := make([]*core.Endpoint, 0, len(endpoint.DependsOn))
+		for _, key := range endpoint.DependsOn {
+			dependency, exists := endpointsByKey[key]
+			if !exists {
+				return fmt.Errorf("invalid endpoint %s: %w: %s", endpoint.DisplayName(), ErrUnknownEndpointDependency, key)
+			}
+			dependencies = append(dependencies, dependency)
+		}
+		endpoint.SetDependencies(dependencies)
+	}
+	return nil
+}
+
 func validateSecurityConfig(config *Config) error {
 	if config.Security != nil {
 		if config.Security.IsValid() {
@@ -351,6 +900,23 @@ func validateSecurityConfig(config *Config) error {
 	return nil
 }
 
+// expandAlertTargets replaces every alert with targets by one alert per target, so that each target is triggered and
+// resolved independently
+func expandAlertTargets(endpoints []*core.Endpoint) error {
+	for _, endpoint := range endpoints {
+		var alerts []*alert.Alert
+		for _, endpointAlert := range endpoint.Alerts {
+			expandedAlerts, err := endpointAlert.ExpandTargets()
+			if err != nil {
+				return fmt.Errorf("invalid endpoint %s: %w", endpoint.DisplayName(), err)
+			}
+			alerts = append(alerts, expandedAlerts...)
+		}
+		endpoint.Alerts = alerts
+	}
+	return nil
+}
+
 // validateAlertingConfig validates the alerting configuration
 // Note that the alerting configuration has to be validated before the endpoint configuration, because the default alert
 // returned by provider.AlertProvider.GetDefaultAlert() must be parsed before core.Endpoint.ValidateAndSetDefaults()
@@ -361,12 +927,18 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*core.E
 		return
 	}
 	alertTypes := []alert.Type{
+		alert.TypeAlertmanager,
+		alert.TypeAMQP,
 		alert.TypeCustom,
+		alert.TypeDatadog,
 		alert.TypeDiscord,
 		alert.TypeGitHub,
 		alert.TypeGitLab,
 		alert.TypeGoogleChat,
 		alert.TypeEmail,
+		alert.TypeGotify,
+		alert.TypeLine,
+		alert.TypeLogAnalytics,
 		alert.TypeMatrix,
 		alert.TypeMattermost,
 		alert.TypeMessagebird,
@@ -374,10 +946,14 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*core.E
 		alert.TypeOpsgenie,
 		alert.TypePagerDuty,
 		alert.TypePushover,
+		alert.TypeSignal,
 		alert.TypeSlack,
+		alert.TypeSplunk,
+		alert.TypeSquadcast,
 		alert.TypeTeams,
 		alert.TypeTelegram,
 		alert.TypeTwilio,
+		alert.TypeVictorOps,
 		alert.TypeWecom,
 	}
 	var validProviders, invalidProviders []alert.Type
@@ -408,5 +984,13 @@ func validateAlertingConfig(alertingConfig *alerting.Config, endpoints []*core.E
 			invalidProviders = append(invalidProviders, alertType)
 		}
 	}
+	// The global default alert is parsed last, so that the default alert of each provider takes precedence over it
+	if alertingConfig.DefaultAlert != nil {
+		for _, endpoint := range endpoints {
+			for _, endpointAlert := range endpoint.Alerts {
+				provider.ParseWithDefaultAlert(alertingConfig.DefaultAlert, endpointAlert)
+			}
+		}
+	}
 	log.Printf("[config][validateAlertingConfig] configuredProviders=%s; ignoredProviders=%s", validProviders, invalidProviders)
 }
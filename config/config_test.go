@@ -3,19 +3,29 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/i18n"
 	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/alerting/provider/alertmanager"
+	"github.com/TwiN/gatus/v5/alerting/provider/amqp"
 	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/alerting/provider/datadog"
 	"github.com/TwiN/gatus/v5/alerting/provider/discord"
 	"github.com/TwiN/gatus/v5/alerting/provider/email"
 	"github.com/TwiN/gatus/v5/alerting/provider/github"
 	"github.com/TwiN/gatus/v5/alerting/provider/googlechat"
+	"github.com/TwiN/gatus/v5/alerting/provider/gotify"
+	"github.com/TwiN/gatus/v5/alerting/provider/line"
+	"github.com/TwiN/gatus/v5/alerting/provider/loganalytics"
 	"github.com/TwiN/gatus/v5/alerting/provider/matrix"
 	"github.com/TwiN/gatus/v5/alerting/provider/mattermost"
 	"github.com/TwiN/gatus/v5/alerting/provider/messagebird"
@@ -23,13 +33,22 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/opsgenie"
 	"github.com/TwiN/gatus/v5/alerting/provider/pagerduty"
 	"github.com/TwiN/gatus/v5/alerting/provider/pushover"
+	"github.com/TwiN/gatus/v5/alerting/provider/signal"
 	"github.com/TwiN/gatus/v5/alerting/provider/slack"
+	"github.com/TwiN/gatus/v5/alerting/provider/splunk"
+	"github.com/TwiN/gatus/v5/alerting/provider/squadcast"
 	"github.com/TwiN/gatus/v5/alerting/provider/teams"
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
+	"github.com/TwiN/gatus/v5/alerting/provider/victorops"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
+	"github.com/TwiN/gatus/v5/config/ratelimit"
+	"github.com/TwiN/gatus/v5/config/selfmonitoring"
+	"github.com/TwiN/gatus/v5/config/vault"
 	"github.com/TwiN/gatus/v5/config/web"
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
 	"github.com/TwiN/gatus/v5/storage"
 	"gopkg.in/yaml.v3"
 )
@@ -295,6 +314,37 @@ func TestConfig_HasLoadedConfigurationBeenModified(t *testing.T) {
 			t.Errorf("expected config.HasLoadedConfigurationBeenModified() to return true because a new file has been added in the directory")
 		}
 	})
+	t.Run("referenced-file", func(t *testing.T) {
+		referencedFilesDir := t.TempDir()
+		bodyFilePath := filepath.Join(referencedFilesDir, "body.json")
+		_ = os.WriteFile(bodyFilePath, []byte(`{"version":1}`), 0644)
+		configFilePath := filepath.Join(referencedFilesDir, "config.yaml")
+		_ = os.WriteFile(configFilePath, []byte(`endpoints:
+  - name: website
+    url: https://twin.sh/health
+    method: POST
+    body-file: `+bodyFilePath+`
+    conditions:
+      - "[STATUS] == 200"
+`), 0644)
+		config, err := LoadConfiguration(configFilePath)
+		if err != nil {
+			t.Fatalf("failed to load configuration: %v", err)
+		}
+		if config.Endpoints[0].Body != `{"version":1}` {
+			t.Errorf("expected body to have been read from the body file, got %s", config.Endpoints[0].Body)
+		}
+		if config.HasLoadedConfigurationBeenModified() {
+			t.Errorf("expected config.HasLoadedConfigurationBeenModified() to return false because nothing has happened since it was created")
+		}
+		time.Sleep(time.Second) // Because the file mod time only has second precision, we have to wait for a second
+		if err = os.WriteFile(bodyFilePath, []byte(`{"version":2}`), 0644); err != nil {
+			t.Fatalf("failed to overwrite body file: %v", err)
+		}
+		if !config.HasLoadedConfigurationBeenModified() {
+			t.Errorf("expected config.HasLoadedConfigurationBeenModified() to return true because the body file has been modified")
+		}
+	})
 }
 
 func TestParseAndValidateConfigBytes(t *testing.T) {
@@ -621,6 +671,215 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithDefaultUserAgent(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+client:
+  default-user-agent: Default/1.0
+endpoints:
+  - name: default
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: client-override
+    url: https://twin.sh/health
+    client:
+      user-agent: Override/1.0
+    conditions:
+      - "[STATUS] == 200"
+  - name: header-override
+    url: https://twin.sh/health
+    client:
+      user-agent: Override/1.0
+    headers:
+      User-Agent: Header/1.0
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if userAgent := config.Endpoints[0].Headers["User-Agent"]; userAgent != "Default/1.0" {
+		t.Errorf("User-Agent should've been %s, got %s", "Default/1.0", userAgent)
+	}
+	if config.Endpoints[0].ClientConfig.Timeout != 10*time.Second {
+		t.Errorf("Timeout should've been %s, got %s", 10*time.Second, config.Endpoints[0].ClientConfig.Timeout)
+	}
+	if userAgent := config.Endpoints[1].Headers["User-Agent"]; userAgent != "Override/1.0" {
+		t.Errorf("User-Agent should've been %s, got %s", "Override/1.0", userAgent)
+	}
+	if userAgent := config.Endpoints[2].Headers["User-Agent"]; userAgent != "Header/1.0" {
+		t.Errorf("User-Agent should've been %s, got %s", "Header/1.0", userAgent)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithEndpointGroups(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+endpoint-groups:
+  - name: core
+    interval: 30s
+    client:
+      timeout: 5s
+    headers:
+      Authorization: Bearer group
+      X-Group: core
+    conditions:
+      - "[STATUS] == 200"
+    alerts:
+      - type: slack
+        failure-threshold: 5
+endpoints:
+  - name: inherits
+    group: core
+    url: https://twin.sh/health
+  - name: overrides
+    group: core
+    url: https://twin.sh/health
+    interval: 2m
+    client:
+      timeout: 1s
+    headers:
+      Authorization: Bearer endpoint
+    conditions:
+      - "[RESPONSE_TIME] < 500"
+    alerts:
+      - type: slack
+  - name: no-group
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	inherits, overrides, noGroup := config.Endpoints[0], config.Endpoints[1], config.Endpoints[2]
+	if config.EndpointGroups[0].HealthPolicy != endpointgroup.HealthPolicyAll {
+		t.Errorf("HealthPolicy should've defaulted to %s, got %s", endpointgroup.HealthPolicyAll, config.EndpointGroups[0].HealthPolicy)
+	}
+	if inherits.Interval != 30*time.Second {
+		t.Errorf("Interval should've been %s, got %s", 30*time.Second, inherits.Interval)
+	}
+	if inherits.ClientConfig.Timeout != 5*time.Second {
+		t.Errorf("Timeout should've been %s, got %s", 5*time.Second, inherits.ClientConfig.Timeout)
+	}
+	if inherits.Headers["Authorization"] != "Bearer group" || inherits.Headers["X-Group"] != "core" {
+		t.Errorf("Headers should've been inherited, got %v", inherits.Headers)
+	}
+	if len(inherits.Conditions) != 1 {
+		t.Errorf("Conditions should've been inherited, got %v", inherits.Conditions)
+	}
+	if len(inherits.Alerts) != 1 || inherits.Alerts[0].FailureThreshold != 5 {
+		t.Errorf("Alerts should've been inherited, got %v", inherits.Alerts)
+	}
+	if overrides.Interval != 2*time.Minute {
+		t.Errorf("Interval should've been %s, got %s", 2*time.Minute, overrides.Interval)
+	}
+	if overrides.ClientConfig.Timeout != time.Second {
+		t.Errorf("Timeout should've been %s, got %s", time.Second, overrides.ClientConfig.Timeout)
+	}
+	if overrides.Headers["Authorization"] != "Bearer endpoint" || overrides.Headers["X-Group"] != "core" {
+		t.Errorf("Headers should've been merged, got %v", overrides.Headers)
+	}
+	if len(overrides.Conditions) != 2 || overrides.Conditions[0] != "[STATUS] == 200" || overrides.Conditions[1] != "[RESPONSE_TIME] < 500" {
+		t.Errorf("Conditions should've been appended, got %v", overrides.Conditions)
+	}
+	if len(overrides.Alerts) != 1 || overrides.Alerts[0].FailureThreshold != 3 {
+		t.Errorf("Alerts should've been replaced, got %v", overrides.Alerts)
+	}
+	if inherits.Alerts[0] == config.EndpointGroups[0].Alerts[0] {
+		t.Error("Each endpoint should have its own copy of the alerts of the group")
+	}
+	if noGroup.Interval != 60*time.Second {
+		t.Errorf("Interval should've been %s, got %s", 60*time.Second, noGroup.Interval)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithInvalidEndpointGroups(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		config      string
+		expectedErr error
+	}{
+		{
+			name: "undefined-group",
+			config: `
+endpoint-groups:
+  - name: core
+endpoints:
+  - name: website
+    group: typo
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedErr: ErrUndefinedEndpointGroup,
+		},
+		{
+			name: "duplicate-group",
+			config: `
+endpoint-groups:
+  - name: core
+  - name: core
+endpoints:
+  - name: website
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedErr: ErrDuplicateEndpointGroup,
+		},
+		{
+			name: "group-with-no-name",
+			config: `
+endpoint-groups:
+  - interval: 30s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedErr: endpointgroup.ErrEndpointGroupWithNoName,
+		},
+		{
+			name: "group-with-invalid-health-policy",
+			config: `
+endpoint-groups:
+  - name: core
+    health-policy: majority
+endpoints:
+  - name: website
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedErr: endpointgroup.ErrEndpointGroupWithInvalidHealthPolicy,
+		},
+		{
+			name: "group-with-health-quorum-but-not-quorum-policy",
+			config: `
+endpoint-groups:
+  - name: core
+    health-quorum: 2
+endpoints:
+  - name: website
+    group: core
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"`,
+			expectedErr: endpointgroup.ErrEndpointGroupWithInvalidHealthQuorum,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			_, err := parseAndValidateConfigBytes([]byte(scenario.config))
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
 func TestParseAndValidateConfigBytesWithMetricsAndHostAndPort(t *testing.T) {
 	config, err := parseAndValidateConfigBytes([]byte(`
 metrics: true
@@ -659,6 +918,134 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithMetricsLabels(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-labels: [group, name]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.MetricsLabels) != 2 || config.MetricsLabels[0] != "group" || config.MetricsLabels[1] != "name" {
+		t.Errorf("expected metrics labels to be [group name], got %v", config.MetricsLabels)
+	}
+	config, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.MetricsLabels) != len(metrics.DefaultLabels) {
+		t.Errorf("expected metrics labels to default to %v, got %v", metrics.DefaultLabels, config.MetricsLabels)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-labels: [type]
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, metrics.ErrMetricsLabelsWithoutAnIdentifyingLabel) {
+		t.Errorf("expected error %v, got %v", metrics.ErrMetricsLabelsWithoutAnIdentifyingLabel, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithMetricsPushgateway(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+metrics-pushgateway:
+  url: https://pushgateway.example.com
+  grouping:
+    instance: gatus-1
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.MetricsPushgateway == nil || config.MetricsPushgateway.Job != metrics.DefaultPushgatewayJob {
+		t.Errorf("expected pushgateway job to default to %s", metrics.DefaultPushgatewayJob)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+metrics: true
+metrics-pushgateway:
+  url: https://pushgateway.example.com
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrMetricsWithPushgateway) {
+		t.Errorf("expected error %v, got %v", ErrMetricsWithPushgateway, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithEndpointDependencies(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: database
+    group: core
+    url: tcp://127.0.0.1:5432
+    maintenance-windows:
+      - start: "23:00"
+        duration: 1h
+    conditions:
+      - "[CONNECTED] == true"
+  - name: website
+    url: https://twin.sh/health
+    depends-on: [core_database]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints[0].MaintenanceWindows) != 1 {
+		t.Fatalf("expected 1 maintenance window, got %d", len(config.Endpoints[0].MaintenanceWindows))
+	}
+	if dependencies := config.Endpoints[1].Dependencies(); len(dependencies) != 1 || dependencies[0] != config.Endpoints[0] {
+		t.Errorf("expected website to depend on core/database, got %v", dependencies)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    depends-on: [core_database]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrUnknownEndpointDependency) {
+		t.Errorf("expected error %v, got %v", ErrUnknownEndpointDependency, err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    maintenance-windows:
+      - start: "25:00"
+        duration: 1h
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err == nil {
+		t.Error("expected an error because the maintenance window is invalid")
+	}
+}
+
 func TestParseAndValidateBadConfigBytes(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 badconfig:
@@ -1180,29 +1567,29 @@ endpoints:
 
 }
 
-func TestParseAndValidateConfigBytesWithAlertingAndDefaultAlertAndMultipleAlertsOfSameTypeWithOverriddenParameters(t *testing.T) {
+func TestParseAndValidateConfigBytesWithAlertingAndGlobalDefaultAlert(t *testing.T) {
 	config, err := parseAndValidateConfigBytes([]byte(`
 alerting:
+  default-alert:
+    failure-threshold: 7
+    success-threshold: 4
   slack:
-    webhook-url: "https://example.com"
+    webhook-url: "http://example.com"
     default-alert:
-      enabled: true
-      description: "description"
+      failure-threshold: 10
+  discord:
+    webhook-url: "http://example.org"
 
 endpoints:
- - name: website
-   url: https://twin.sh/health
-   alerts:
-     - type: slack
-       failure-threshold: 10
-     - type: slack
-       failure-threshold: 20
-       description: "wow"
-     - type: slack
-       enabled: false
-       failure-threshold: 30
-   conditions:
-     - "[STATUS] == 200"
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: slack
+      - type: discord
+      - type: discord
+        failure-threshold: 1
+    conditions:
+      - "[STATUS] == 200"
 `))
 	if err != nil {
 		t.Error("expected no error, got", err.Error())
@@ -1210,31 +1597,139 @@ endpoints:
 	if config == nil {
 		t.Fatal("Config shouldn't have been nil")
 	}
-	// Alerting providers
-	if config.Alerting == nil {
-		t.Fatal("config.Alerting shouldn't have been nil")
-	}
-	if config.Alerting.Slack == nil || !config.Alerting.Slack.IsValid() {
-		t.Fatal("Slack alerting config should've been valid")
-	}
-	// Endpoints
-	if len(config.Endpoints) != 1 {
-		t.Error("There should've been 2 endpoints")
-	}
-	if config.Endpoints[0].Alerts[0].Type != alert.TypeSlack {
-		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[0].Type)
+	alerts := config.Endpoints[0].Alerts
+	if alerts[0].FailureThreshold != 10 {
+		t.Errorf("The default alert of the provider should've taken precedence over the global default alert, expected failure threshold %d, got %d", 10, alerts[0].FailureThreshold)
 	}
-	if config.Endpoints[0].Alerts[1].Type != alert.TypeSlack {
-		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[1].Type)
+	if alerts[0].SuccessThreshold != 4 {
+		t.Errorf("The global default alert should've been used for values not set by the default alert of the provider, expected success threshold %d, got %d", 4, alerts[0].SuccessThreshold)
 	}
-	if config.Endpoints[0].Alerts[2].Type != alert.TypeSlack {
-		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[2].Type)
+	if alerts[1].FailureThreshold != 7 || alerts[1].SuccessThreshold != 4 {
+		t.Errorf("The global default alert should've been used, expected thresholds 7 and 4, got %d and %d", alerts[1].FailureThreshold, alerts[1].SuccessThreshold)
 	}
-	if !config.Endpoints[0].Alerts[0].IsEnabled() {
-		t.Error("The alert should've been enabled")
+	if alerts[2].FailureThreshold != 1 {
+		t.Errorf("The value set on the alert should've taken precedence over the global default alert, expected failure threshold %d, got %d", 1, alerts[2].FailureThreshold)
 	}
-	if !config.Endpoints[0].Alerts[1].IsEnabled() {
-		t.Error("The alert should've been enabled")
+}
+
+func TestParseAndValidateConfigBytesWithAlertTargets(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "http://example.com"
+    default-alert:
+      success-threshold: 5
+  pagerduty:
+    integration-key: "00000000000000000000000000000000"
+
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - description: "website is down"
+        send-on-resolved: true
+        targets:
+          - type: pagerduty
+            failure-threshold: 1
+            severity: critical
+          - type: slack
+            failure-threshold: 3
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	alerts := config.Endpoints[0].Alerts
+	if len(alerts) != 3 {
+		t.Fatalf("expected the alert with targets to have been expanded into 2 alerts, got %d alerts", len(alerts))
+	}
+	if alerts[0].Type != alert.TypePagerDuty || alerts[0].FailureThreshold != 1 || alerts[0].SuccessThreshold != 2 || alerts[0].Severity != "critical" {
+		t.Errorf("unexpected pagerduty target: type=%s; failure-threshold=%d; success-threshold=%d; severity=%s", alerts[0].Type, alerts[0].FailureThreshold, alerts[0].SuccessThreshold, alerts[0].Severity)
+	}
+	if alerts[1].Type != alert.TypeSlack || alerts[1].FailureThreshold != 3 || alerts[1].SuccessThreshold != 5 {
+		t.Errorf("unexpected slack target, the default alert of the provider should've been used: type=%s; failure-threshold=%d; success-threshold=%d", alerts[1].Type, alerts[1].FailureThreshold, alerts[1].SuccessThreshold)
+	}
+	for _, targetAlert := range alerts[:2] {
+		if targetAlert.GetDescription() != "website is down" || !targetAlert.IsSendingOnResolved() {
+			t.Errorf("expected the target of type %s to have inherited the fields of the alert", targetAlert.Type)
+		}
+	}
+	if alerts[2].Type != alert.TypeSlack || alerts[2].FailureThreshold != 3 {
+		t.Errorf("expected the alert without targets to be left as is, got type=%s; failure-threshold=%d", alerts[2].Type, alerts[2].FailureThreshold)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: slack
+        targets:
+          - type: pagerduty
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, alert.ErrAlertWithTargetsAndType) {
+		t.Errorf("expected error %v, got %v", alert.ErrAlertWithTargetsAndType, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithAlertingAndDefaultAlertAndMultipleAlertsOfSameTypeWithOverriddenParameters(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+    default-alert:
+      enabled: true
+      description: "description"
+
+endpoints:
+ - name: website
+   url: https://twin.sh/health
+   alerts:
+     - type: slack
+       failure-threshold: 10
+     - type: slack
+       failure-threshold: 20
+       description: "wow"
+     - type: slack
+       enabled: false
+       failure-threshold: 30
+   conditions:
+     - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+	if config == nil {
+		t.Fatal("Config shouldn't have been nil")
+	}
+	// Alerting providers
+	if config.Alerting == nil {
+		t.Fatal("config.Alerting shouldn't have been nil")
+	}
+	if config.Alerting.Slack == nil || !config.Alerting.Slack.IsValid() {
+		t.Fatal("Slack alerting config should've been valid")
+	}
+	// Endpoints
+	if len(config.Endpoints) != 1 {
+		t.Error("There should've been 2 endpoints")
+	}
+	if config.Endpoints[0].Alerts[0].Type != alert.TypeSlack {
+		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[0].Type)
+	}
+	if config.Endpoints[0].Alerts[1].Type != alert.TypeSlack {
+		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[1].Type)
+	}
+	if config.Endpoints[0].Alerts[2].Type != alert.TypeSlack {
+		t.Errorf("The type of the alert should've been %s, but it was %s", alert.TypeSlack, config.Endpoints[0].Alerts[2].Type)
+	}
+	if !config.Endpoints[0].Alerts[0].IsEnabled() {
+		t.Error("The alert should've been enabled")
+	}
+	if !config.Endpoints[0].Alerts[1].IsEnabled() {
+		t.Error("The alert should've been enabled")
 	}
 	if config.Endpoints[0].Alerts[2].IsEnabled() {
 		t.Error("The alert should've been disabled")
@@ -1465,6 +1960,40 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithAlertingLocale(t *testing.T) {
+	defer i18n.SetLocale(i18n.DefaultLocale)
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  locale: zh
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Alerting.Locale != i18n.LocaleChinese {
+		t.Errorf("expected locale to be %s, got %s", i18n.LocaleChinese, config.Alerting.Locale)
+	}
+	if i18n.GetLocale() != i18n.LocaleChinese {
+		t.Errorf("expected locale %s to have been applied, got %s", i18n.LocaleChinese, i18n.GetLocale())
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  locale: xx
+endpoints:
+  - name: example
+    url: https://example.org
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, i18n.ErrUnsupportedLocale) {
+		t.Errorf("expected error %v, got %v", i18n.ErrUnsupportedLocale, err)
+	}
+}
+
 func TestParseAndValidateConfigBytesWithInvalidYAML(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(`
 storage:
@@ -1561,6 +2090,96 @@ endpoints:
 	}
 }
 
+func TestParseAndValidateConfigBytesWithVariables(t *testing.T) {
+	t.Setenv("GATUS_TestParseAndValidateConfigBytesWithVariables_BODY", "overridden")
+	config, err := parseAndValidateConfigBytes([]byte(`
+variables:
+  LATENCY_BUDGET: 500
+  GATUS_TestParseAndValidateConfigBytesWithVariables_BODY: default
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[RESPONSE_TIME] < ${LATENCY_BUDGET}"
+      - "[BODY] == ${GATUS_TestParseAndValidateConfigBytesWithVariables_BODY}"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].Conditions[0] != "[RESPONSE_TIME] < 500" {
+		t.Errorf("expected the variable to be replaced by its default value, got %s", config.Endpoints[0].Conditions[0])
+	}
+	if config.Endpoints[0].Conditions[1] != "[BODY] == overridden" {
+		t.Errorf("expected the environment variable to take precedence over the default value, got %s", config.Endpoints[0].Conditions[1])
+	}
+}
+
+func TestParseAndValidateConfigBytesWithUndefinedVariableInCondition(t *testing.T) {
+	_, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[RESPONSE_TIME] < ${GATUS_TestParseAndValidateConfigBytesWithUndefinedVariableInCondition}"
+`))
+	if !errors.Is(err, ErrUndefinedVariableInCondition) {
+		t.Errorf("expected error %v, got %v", ErrUndefinedVariableInCondition, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithAgents(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+agents:
+  - name: eu-west
+    token: eu-west-token
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    agents: [eu-west]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Agents) != 1 || config.Endpoints[0].AgentFailureThreshold != 1 {
+		t.Error("expected the agent to be parsed, and the agent failure threshold of the endpoint to default to 1")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+agents:
+  - name: eu-west
+    token: eu-west-token
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    agents: [us-east]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, ErrUndefinedAgent) {
+		t.Errorf("expected error %v, got %v", ErrUndefinedAgent, err)
+	}
+	// When running as an agent, the agents referenced by the endpoints don't have to be defined
+	config, err = parseAndValidateConfigBytes([]byte(`
+agent:
+  name: us-east
+  url: https://status.example.org
+  token: us-east-token
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    agents: [us-east]
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Agent == nil || config.Agent.Name != "us-east" {
+		t.Error("expected the agent configuration to be parsed")
+	}
+}
+
 func TestParseAndValidateConfigBytesWithNoEndpoints(t *testing.T) {
 	_, err := parseAndValidateConfigBytes([]byte(``))
 	if err != ErrNoEndpointInConfig {
@@ -1570,32 +2189,48 @@ func TestParseAndValidateConfigBytesWithNoEndpoints(t *testing.T) {
 
 func TestGetAlertingProviderByAlertType(t *testing.T) {
 	alertingConfig := &alerting.Config{
-		Custom:      &custom.AlertProvider{},
-		Discord:     &discord.AlertProvider{},
-		Email:       &email.AlertProvider{},
-		GitHub:      &github.AlertProvider{},
-		GoogleChat:  &googlechat.AlertProvider{},
-		Matrix:      &matrix.AlertProvider{},
-		Mattermost:  &mattermost.AlertProvider{},
-		Messagebird: &messagebird.AlertProvider{},
-		Ntfy:        &ntfy.AlertProvider{},
-		Opsgenie:    &opsgenie.AlertProvider{},
-		PagerDuty:   &pagerduty.AlertProvider{},
-		Pushover:    &pushover.AlertProvider{},
-		Slack:       &slack.AlertProvider{},
-		Telegram:    &telegram.AlertProvider{},
-		Twilio:      &twilio.AlertProvider{},
-		Teams:       &teams.AlertProvider{},
+		Alertmanager: &alertmanager.AlertProvider{},
+		AMQP:         &amqp.AlertProvider{},
+		Custom:       &custom.AlertProvider{},
+		Datadog:      &datadog.AlertProvider{},
+		Discord:      &discord.AlertProvider{},
+		Email:        &email.AlertProvider{},
+		GitHub:       &github.AlertProvider{},
+		GoogleChat:   &googlechat.AlertProvider{},
+		Gotify:       &gotify.AlertProvider{},
+		Line:         &line.AlertProvider{},
+		LogAnalytics: &loganalytics.AlertProvider{},
+		Matrix:       &matrix.AlertProvider{},
+		Mattermost:   &mattermost.AlertProvider{},
+		Messagebird:  &messagebird.AlertProvider{},
+		Ntfy:         &ntfy.AlertProvider{},
+		Opsgenie:     &opsgenie.AlertProvider{},
+		PagerDuty:    &pagerduty.AlertProvider{},
+		Pushover:     &pushover.AlertProvider{},
+		Signal:       &signal.AlertProvider{},
+		Slack:        &slack.AlertProvider{},
+		Splunk:       &splunk.AlertProvider{},
+		Squadcast:    &squadcast.AlertProvider{},
+		Telegram:     &telegram.AlertProvider{},
+		Twilio:       &twilio.AlertProvider{},
+		Teams:        &teams.AlertProvider{},
+		VictorOps:    &victorops.AlertProvider{},
 	}
 	scenarios := []struct {
 		alertType alert.Type
 		expected  provider.AlertProvider
 	}{
+		{alertType: alert.TypeAlertmanager, expected: alertingConfig.Alertmanager},
+		{alertType: alert.TypeAMQP, expected: alertingConfig.AMQP},
 		{alertType: alert.TypeCustom, expected: alertingConfig.Custom},
+		{alertType: alert.TypeDatadog, expected: alertingConfig.Datadog},
 		{alertType: alert.TypeDiscord, expected: alertingConfig.Discord},
 		{alertType: alert.TypeEmail, expected: alertingConfig.Email},
 		{alertType: alert.TypeGitHub, expected: alertingConfig.GitHub},
 		{alertType: alert.TypeGoogleChat, expected: alertingConfig.GoogleChat},
+		{alertType: alert.TypeGotify, expected: alertingConfig.Gotify},
+		{alertType: alert.TypeLine, expected: alertingConfig.Line},
+		{alertType: alert.TypeLogAnalytics, expected: alertingConfig.LogAnalytics},
 		{alertType: alert.TypeMatrix, expected: alertingConfig.Matrix},
 		{alertType: alert.TypeMattermost, expected: alertingConfig.Mattermost},
 		{alertType: alert.TypeMessagebird, expected: alertingConfig.Messagebird},
@@ -1603,10 +2238,14 @@ func TestGetAlertingProviderByAlertType(t *testing.T) {
 		{alertType: alert.TypeOpsgenie, expected: alertingConfig.Opsgenie},
 		{alertType: alert.TypePagerDuty, expected: alertingConfig.PagerDuty},
 		{alertType: alert.TypePushover, expected: alertingConfig.Pushover},
+		{alertType: alert.TypeSignal, expected: alertingConfig.Signal},
 		{alertType: alert.TypeSlack, expected: alertingConfig.Slack},
+		{alertType: alert.TypeSplunk, expected: alertingConfig.Splunk},
+		{alertType: alert.TypeSquadcast, expected: alertingConfig.Squadcast},
 		{alertType: alert.TypeTelegram, expected: alertingConfig.Telegram},
 		{alertType: alert.TypeTwilio, expected: alertingConfig.Twilio},
 		{alertType: alert.TypeTeams, expected: alertingConfig.Teams},
+		{alertType: alert.TypeVictorOps, expected: alertingConfig.VictorOps},
 	}
 	for _, scenario := range scenarios {
 		t.Run(string(scenario.alertType), func(t *testing.T) {
@@ -1616,3 +2255,451 @@ func TestGetAlertingProviderByAlertType(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAndValidateConfigBytesWithExecEndpoint(t *testing.T) {
+	configBytes := []byte(`
+endpoints:
+  - name: disk
+    url: exec:///usr/local/bin/check-disk
+    exec:
+      args: ["/mnt/data"]
+    conditions:
+      - "[EXIT_CODE] == 0"
+`)
+	if _, err := parseAndValidateConfigBytes(configBytes); !errors.Is(err, ErrExecNotAllowed) {
+		t.Errorf("expected error %v, got %v", ErrExecNotAllowed, err)
+	}
+	t.Setenv(AllowExecEnvironmentVariable, "true")
+	config, err := parseAndValidateConfigBytes(configBytes)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].Type() != core.EndpointTypeEXEC {
+		t.Errorf("expected endpoint to be of type %s, got %s", core.EndpointTypeEXEC, config.Endpoints[0].Type())
+	}
+}
+
+func TestParseAndValidateConfigBytesWithShutdownTimeout(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.ShutdownTimeout != DefaultShutdownTimeout {
+		t.Errorf("expected shutdown timeout to default to %s, got %s", DefaultShutdownTimeout, config.ShutdownTimeout)
+	}
+	config, err = parseAndValidateConfigBytes([]byte(`
+shutdown-timeout: 2m
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.ShutdownTimeout != 2*time.Minute {
+		t.Errorf("expected shutdown timeout to be 2m, got %s", config.ShutdownTimeout)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+shutdown-timeout: -1s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != ErrInvalidShutdownTimeout {
+		t.Errorf("expected error %v, got %v", ErrInvalidShutdownTimeout, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithSelfMonitoring(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+web:
+  port: 12345
+alerting:
+  slack:
+    webhook-url: "https://example.com"
+self-monitoring:
+  interval: 30s
+  alerts:
+    - type: slack
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 2 {
+		t.Fatalf("expected the self-monitoring endpoint to be added to the endpoints, got %d endpoints", len(config.Endpoints))
+	}
+	endpoint := config.Endpoints[1]
+	if !selfmonitoring.IsSelfMonitoringEndpoint(endpoint) {
+		t.Fatal("expected the last endpoint to be the self-monitoring endpoint")
+	}
+	if endpoint.URL != "http://127.0.0.1:12345/api/v1/self/health" {
+		t.Errorf("expected URL to target the health API of Gatus, got %s", endpoint.URL)
+	}
+	if endpoint.Interval != 30*time.Second {
+		t.Errorf("expected interval to be 30s, got %s", endpoint.Interval)
+	}
+	if len(endpoint.Alerts) != 1 || endpoint.Alerts[0].FailureThreshold != 3 {
+		t.Error("expected the alerts of the self-monitoring endpoint to have been validated")
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+self-monitoring:
+  interval: 1s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != selfmonitoring.ErrInvalidInterval {
+		t.Errorf("expected error %v, got %v", selfmonitoring.ErrInvalidInterval, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithStartupGrace(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+startup-grace: 2m
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    url: tcp://127.0.0.1:5432
+    startup-grace: 10m
+    conditions:
+      - "[CONNECTED] == true"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Endpoints[0].StartupGrace != 2*time.Minute {
+		t.Errorf("expected endpoint without startup-grace to inherit the global one, got %s", config.Endpoints[0].StartupGrace)
+	}
+	if config.Endpoints[1].StartupGrace != 10*time.Minute {
+		t.Errorf("expected the startup-grace of the endpoint to take precedence over the global one, got %s", config.Endpoints[1].StartupGrace)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+startup-grace: -1s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != ErrInvalidStartupGrace {
+		t.Errorf("expected error %v, got %v", ErrInvalidStartupGrace, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithLogLevel(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		logLevel              string
+		expectedLogLevel      string
+		expectedDebug         bool
+		expectedEndpointDebug bool
+		expectedErr           error
+	}{
+		{
+			name:             "default",
+			expectedLogLevel: LogLevelInfo,
+		},
+		{
+			name:             "info",
+			logLevel:         "INFO",
+			expectedLogLevel: LogLevelInfo,
+		},
+		{
+			name:                  "debug",
+			logLevel:              "debug",
+			expectedLogLevel:      LogLevelDebug,
+			expectedDebug:         true,
+			expectedEndpointDebug: true,
+		},
+		{
+			name:        "invalid",
+			logLevel:    "TRACE",
+			expectedErr: ErrInvalidLogLevel,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+log-level: "%s"
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    url: tcp://127.0.0.1:5432
+    conditions:
+      - "[CONNECTED] == true"
+`, scenario.logLevel)))
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if config.LogLevel != scenario.expectedLogLevel {
+				t.Errorf("expected log level %s, got %s", scenario.expectedLogLevel, config.LogLevel)
+			}
+			if config.Debug != scenario.expectedDebug {
+				t.Errorf("expected debug to be %v, got %v", scenario.expectedDebug, config.Debug)
+			}
+			if config.Endpoints[0].Debug != scenario.expectedEndpointDebug {
+				t.Errorf("expected debug of the HTTP endpoint to be %v, got %v", scenario.expectedEndpointDebug, config.Endpoints[0].Debug)
+			}
+			if config.Endpoints[1].Debug {
+				t.Error("expected debug to not have been enabled for the TCP endpoint")
+			}
+		})
+	}
+}
+
+func TestParseAndValidateConfigBytesWithHostRateLimit(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+host-rate-limit:
+  interval: 5s
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.HostRateLimit == nil {
+		t.Fatal("expected host-rate-limit to be set")
+	}
+	if config.HostRateLimit.Interval != 5*time.Second {
+		t.Errorf("expected interval to be 5s, got %s", config.HostRateLimit.Interval)
+	}
+	if config.HostRateLimit.Burst != 1 {
+		t.Errorf("expected burst to default to 1, got %d", config.HostRateLimit.Burst)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+host-rate-limit:
+  burst: 5
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != ratelimit.ErrInvalidInterval {
+		t.Errorf("expected error %v, got %v", ratelimit.ErrInvalidInterval, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithAlertingRoutes(t *testing.T) {
+	config, err := parseAndValidateConfigBytes([]byte(`
+alerting:
+  discord:
+    webhook-url: https://discord.com/api/webhooks/xxx
+    overrides:
+      - group: apac
+        webhook-url: https://discord.com/api/webhooks/apac
+  routes:
+    - start: "00:00"
+      end: "08:00"
+      timezone: UTC
+      group: apac
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: discord
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Alerting.Routes) != 1 {
+		t.Fatalf("expected 1 route, got %d", len(config.Alerting.Routes))
+	}
+	if route := config.Alerting.GetRoute(alert.TypeDiscord, time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC)); route.GetGroup("") != "apac" {
+		t.Error("expected the route to apply at 04:00")
+	}
+	// Slack isn't configured, so alerts of type slack have nowhere to go outside of the route
+	_, err = parseAndValidateConfigBytes([]byte(`
+alerting:
+  discord:
+    webhook-url: https://discord.com/api/webhooks/xxx
+  routes:
+    - types: [slack]
+      start: "00:00"
+      end: "08:00"
+      provider: discord
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    alerts:
+      - type: slack
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if !errors.Is(err, alerting.ErrRoutesWithGaps) {
+		t.Errorf("expected error %v, got %v", alerting.ErrRoutesWithGaps, err)
+	}
+}
+
+func TestParseAndValidateConfigBytesWithConfigSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`
+endpoints:
+  - name: api
+    url: https://api.example.org/health
+    conditions:
+      - "[STATUS] == 200"
+`))
+	}))
+	defer server.Close()
+	config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+config-sources:
+  - url: %s/endpoints.yaml
+    refresh-interval: 1m
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`, server.URL)))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(config.Endpoints) != 2 || config.Endpoints[0].Name != "website" || config.Endpoints[1].Name != "api" {
+		t.Fatalf("expected the endpoints of the config source to be merged with the local ones, got %d endpoints", len(config.Endpoints))
+	}
+	if len(config.ConfigSources) != 1 || config.ConfigSources[0].RefreshInterval != time.Minute {
+		t.Error("expected the config source to have been kept")
+	}
+	if config.HaveConfigSourcesBeenModified() {
+		t.Error("expected the config source to not have been modified")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithUnavailableConfigSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	_, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+config-sources:
+  - url: %s/endpoints.yaml
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    conditions:
+      - "[STATUS] == 200"
+`, server.URL)))
+	if err == nil {
+		t.Error("expected an error, because the config source is unavailable and no document was fetched from it before")
+	}
+}
+
+func TestParseAndValidateConfigBytesWithVaultReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.root" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gatus":
+			_, _ = w.Write([]byte(`{"lease_duration":0,"data":{"data":{"slack-webhook-url":"https://hooks.slack.com/services/xxx","token":"secret-token"},"metadata":{"version":1}}}`))
+		case "/v1/database/creds/gatus":
+			_, _ = w.Write([]byte(`{"lease_duration":3600,"data":{"password":"p4ssw0rd"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	config, err := parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+vault:
+  address: %s
+  auth:
+    method: token
+    token: s.root
+alerting:
+  slack:
+    webhook-url: vault://secret/data/gatus#slack-webhook-url
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    headers:
+      Authorization: "vault://secret/data/gatus#token"
+    conditions:
+      - "[STATUS] == 200"
+  - name: database
+    url: tcp://127.0.0.1:5432
+    body: vault://database/creds/gatus#password
+    conditions:
+      - "[CONNECTED] == true"
+`, server.URL)))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Alerting.Slack.WebhookURL != "https://hooks.slack.com/services/xxx" {
+		t.Errorf("expected the webhook URL to be read from vault, got %s", config.Alerting.Slack.WebhookURL)
+	}
+	if config.Endpoints[0].Headers["Authorization"] != "secret-token" {
+		t.Errorf("expected the header to be read from vault, got %s", config.Endpoints[0].Headers["Authorization"])
+	}
+	if config.Endpoints[1].Body != "p4ssw0rd" {
+		t.Errorf("expected the body to be read from vault, got %s", config.Endpoints[1].Body)
+	}
+	if config.HaveSecretsExpired() {
+		t.Error("expected the secrets to not have expired yet")
+	}
+	if config.secretsExpiration.IsZero() || time.Until(config.secretsExpiration) > time.Hour {
+		t.Errorf("expected the secrets to expire before the end of their lease, got %s", config.secretsExpiration)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(fmt.Sprintf(`
+vault:
+  address: %s
+  auth:
+    method: token
+    token: s.root
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    headers:
+      Authorization: vault://secret/data/unknown#token
+    conditions:
+      - "[STATUS] == 200"
+`, server.URL)))
+	if err == nil || !strings.Contains(err.Error(), "failed to read secret vault://secret/data/unknown#token") {
+		t.Error("expected an error explaining which secret couldn't be read, got", err)
+	}
+	_, err = parseAndValidateConfigBytes([]byte(`
+endpoints:
+  - name: website
+    url: https://twin.sh/health
+    headers:
+      Authorization: vault://secret/data/gatus#token
+    conditions:
+      - "[STATUS] == 200"
+`))
+	if err != vault.ErrReferenceWithoutConfig {
+		t.Errorf("expected error %v, got %v", vault.ErrReferenceWithoutConfig, err)
+	}
+}
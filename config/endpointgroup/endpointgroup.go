@@ -0,0 +1,90 @@
+package endpointgroup
+
+import (
+	"errors"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+var (
+	ErrEndpointGroupWithNoName              = errors.New("endpoint group must have a name")
+	ErrEndpointGroupWithInvalidHealthPolicy = errors.New("endpoint group health-policy must be either all or quorum")
+	ErrEndpointGroupWithInvalidHealthQuorum = errors.New("endpoint group health-quorum must not be negative, and requires health-policy to be quorum")
+)
+
+// Config is the configuration shared by every endpoint whose group matches the name of the endpoint group
+//
+// The merge semantics are as follows:
+//   - Interval and client are only inherited if the endpoint doesn't set them. The client configuration is inherited
+//     as a whole; it is not merged field by field.
+//   - Headers are merged, and headers defined on the endpoint take precedence over those of the group.
+//   - Conditions are appended: the conditions of the group are evaluated before those of the endpoint.
+//   - Alerts are replaced: if the endpoint defines at least one alert, none of the alerts of the group are inherited.
+type Config struct {
+	Name         string            `yaml:"name"`                 // Name of the group, matched against endpoints[].group
+	Interval     time.Duration     `yaml:"interval,omitempty"`   // Default interval of the endpoints in the group
+	ClientConfig *client.Config    `yaml:"client,omitempty"`     // Default client configuration of the endpoints in the group
+	Headers      map[string]string `yaml:"headers,omitempty"`    // Headers added to the request of every endpoint in the group
+	Conditions   []core.Condition  `yaml:"conditions,omitempty"` // Conditions evaluated for every endpoint in the group
+	Alerts       []*alert.Alert    `yaml:"alerts,omitempty"`     // Default alerts of the endpoints in the group
+
+	// HealthPolicy is how the health of the endpoints in the group is rolled up into the health of the group.
+	// Defaults to HealthPolicyAll.
+	HealthPolicy HealthPolicy `yaml:"health-policy,omitempty"`
+
+	// HealthQuorum is the number of endpoints that must be healthy for the group to be healthy with HealthPolicyQuorum.
+	// If 0, more than half of the endpoints must be healthy.
+	HealthQuorum int `yaml:"health-quorum,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the endpoint group configuration
+func (cfg *Config) ValidateAndSetDefaults() error {
+	if len(cfg.Name) == 0 {
+		return ErrEndpointGroupWithNoName
+	}
+	if len(cfg.HealthPolicy) == 0 {
+		cfg.HealthPolicy = HealthPolicyAll
+	} else if cfg.HealthPolicy != HealthPolicyAll && cfg.HealthPolicy != HealthPolicyQuorum {
+		return ErrEndpointGroupWithInvalidHealthPolicy
+	}
+	if cfg.HealthQuorum < 0 || (cfg.HealthQuorum > 0 && cfg.HealthPolicy != HealthPolicyQuorum) {
+		return ErrEndpointGroupWithInvalidHealthQuorum
+	}
+	return nil
+}
+
+// ApplyTo sets the fields inherited from the endpoint group on the endpoint passed
+//
+// Must be called before the endpoint is validated, and before default alerts are applied.
+func (cfg *Config) ApplyTo(endpoint *core.Endpoint) {
+	if endpoint.Interval == 0 {
+		endpoint.Interval = cfg.Interval
+	}
+	if endpoint.ClientConfig == nil && cfg.ClientConfig != nil {
+		clientConfig := *cfg.ClientConfig
+		endpoint.ClientConfig = &clientConfig
+	}
+	if len(cfg.Headers) > 0 {
+		headers := make(map[string]string, len(cfg.Headers)+len(endpoint.Headers))
+		for name, value := range cfg.Headers {
+			headers[name] = value
+		}
+		for name, value := range endpoint.Headers {
+			headers[name] = value
+		}
+		endpoint.Headers = headers
+	}
+	if len(cfg.Conditions) > 0 {
+		endpoint.Conditions = append(append([]core.Condition{}, cfg.Conditions...), endpoint.Conditions...)
+	}
+	if len(endpoint.Alerts) == 0 && len(cfg.Alerts) > 0 {
+		// Each endpoint must have its own copy of the alerts, because they hold the state of the alert (e.g. triggered)
+		for _, groupAlert := range cfg.Alerts {
+			endpointAlert := *groupAlert
+			endpoint.Alerts = append(endpoint.Alerts, &endpointAlert)
+		}
+	}
+}
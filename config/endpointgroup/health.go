@@ -0,0 +1,70 @@
+package endpointgroup
+
+// HealthPolicy is how the health of the endpoints in a group is rolled up into the health of the group
+type HealthPolicy string
+
+const (
+	// HealthPolicyAll requires every endpoint in the group to be healthy for the group to be healthy
+	HealthPolicyAll HealthPolicy = "all"
+
+	// HealthPolicyQuorum requires a quorum of the endpoints in the group to be healthy for the group to be healthy
+	HealthPolicyQuorum HealthPolicy = "quorum"
+)
+
+// Health is the health of a group of endpoints
+type Health int
+
+const (
+	// HealthUnknown is the health of a group none of whose endpoints has been evaluated yet
+	HealthUnknown Health = iota - 1
+
+	// HealthDown is the health of a group none of whose endpoints is healthy
+	HealthDown
+
+	// HealthDegraded is the health of a group some of whose endpoints are healthy, but not enough for it to be healthy
+	HealthDegraded
+
+	// HealthHealthy is the health of a group whose endpoints are healthy according to its HealthPolicy
+	HealthHealthy
+)
+
+// String returns the name of the health
+func (health Health) String() string {
+	switch health {
+	case HealthDown:
+		return "down"
+	case HealthDegraded:
+		return "degraded"
+	case HealthHealthy:
+		return "healthy"
+	default:
+		return "unknown"
+	}
+}
+
+// RollUp returns the health of the group given the number of its endpoints that are healthy, out of the number of its
+// endpoints that have been evaluated
+//
+// A nil configuration, which is the case of a group that isn't defined in endpoint-groups, uses HealthPolicyAll.
+func (cfg *Config) RollUp(healthyEndpoints, totalEndpoints int) Health {
+	if totalEndpoints == 0 {
+		return HealthUnknown
+	}
+	if healthyEndpoints == 0 {
+		return HealthDown
+	}
+	if cfg != nil && cfg.HealthPolicy == HealthPolicyQuorum {
+		quorum := cfg.HealthQuorum
+		if quorum == 0 {
+			quorum = totalEndpoints/2 + 1
+		}
+		if healthyEndpoints >= quorum {
+			return HealthHealthy
+		}
+		return HealthDegraded
+	}
+	if healthyEndpoints == totalEndpoints {
+		return HealthHealthy
+	}
+	return HealthDegraded
+}
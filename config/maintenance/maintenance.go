@@ -112,6 +112,31 @@ func (c Config) IsUnderMaintenance() bool {
 	return now.After(startOfMaintenancePeriod) && now.Before(endOfMaintenancePeriod)
 }
 
+// GetCurrentOrNextWindow returns the start and the end of the maintenance period that is ongoing at the time passed,
+// or of the next one if none is ongoing
+//
+// Returns false if the maintenance is disabled or has no period scheduled.
+func (c Config) GetCurrentOrNextWindow(now time.Time) (start, end time.Time, ok bool) {
+	if !c.IsEnabled() || c.Duration <= 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	now = now.UTC()
+	// Since a maintenance period lasts at most 24 hours, only the period that started the day before may still be
+	// ongoing, and every day of the week is covered within the 8 days that follow
+	day := now.Truncate(24 * time.Hour).Add(-24 * time.Hour)
+	for i := 0; i < 9; i++ {
+		if len(c.Every) == 0 || c.hasDay(day.Weekday().String()) {
+			start = day.Add(c.durationToStartFromMidnight)
+			end = start.Add(c.Duration)
+			if end.After(now) {
+				return start, end, true
+			}
+		}
+		day = day.Add(24 * time.Hour)
+	}
+	return time.Time{}, time.Time{}, false
+}
+
 func (c Config) hasDay(day string) bool {
 	for _, d := range c.Every {
 		if d == day {
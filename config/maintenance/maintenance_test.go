@@ -276,3 +276,84 @@ func normalizeHour(hour int) int {
 	}
 	return hour
 }
+
+func TestConfig_GetCurrentOrNextWindow(t *testing.T) {
+	no := false
+	monday := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		now           time.Time
+		expectedOk    bool
+		expectedStart time.Time
+	}{
+		{
+			name:       "disabled",
+			cfg:        &Config{Enabled: &no, Start: "23:00", Duration: time.Hour},
+			now:        monday,
+			expectedOk: false,
+		},
+		{
+			name:          "later-today",
+			cfg:           &Config{Start: "23:00", Duration: time.Hour},
+			now:           monday.Add(12 * time.Hour),
+			expectedOk:    true,
+			expectedStart: monday.Add(23 * time.Hour),
+		},
+		{
+			name:          "ongoing",
+			cfg:           &Config{Start: "23:00", Duration: time.Hour},
+			now:           monday.Add(23*time.Hour + 30*time.Minute),
+			expectedOk:    true,
+			expectedStart: monday.Add(23 * time.Hour),
+		},
+		{
+			name:          "ongoing-since-the-day-before",
+			cfg:           &Config{Start: "23:00", Duration: 4 * time.Hour},
+			now:           monday.Add(2 * time.Hour),
+			expectedOk:    true,
+			expectedStart: monday.Add(-time.Hour),
+		},
+		{
+			name:          "tomorrow",
+			cfg:           &Config{Start: "01:00", Duration: time.Hour},
+			now:           monday.Add(12 * time.Hour),
+			expectedOk:    true,
+			expectedStart: monday.Add(25 * time.Hour),
+		},
+		{
+			name:          "next-week",
+			cfg:           &Config{Start: "01:00", Duration: time.Hour, Every: []string{"Monday"}},
+			now:           monday.Add(12 * time.Hour),
+			expectedOk:    true,
+			expectedStart: monday.Add(7*24*time.Hour + time.Hour),
+		},
+		{
+			name:          "later-this-week",
+			cfg:           &Config{Start: "08:00", Duration: time.Hour, Every: []string{"Monday", "Friday"}},
+			now:           monday.Add(12 * time.Hour),
+			expectedOk:    true,
+			expectedStart: monday.Add(4*24*time.Hour + 8*time.Hour),
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			start, end, ok := scenario.cfg.GetCurrentOrNextWindow(scenario.now)
+			if ok != scenario.expectedOk {
+				t.Fatalf("expected ok to be %v, got %v", scenario.expectedOk, ok)
+			}
+			if !ok {
+				return
+			}
+			if !start.Equal(scenario.expectedStart) {
+				t.Errorf("expected start to be %v, got %v", scenario.expectedStart, start)
+			}
+			if !end.Equal(scenario.expectedStart.Add(scenario.cfg.Duration)) {
+				t.Errorf("expected end to be %v, got %v", scenario.expectedStart.Add(scenario.cfg.Duration), end)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	ErrInvalidInterval = errors.New("host-rate-limit.interval must be greater than 0")
+	ErrInvalidBurst    = errors.New("host-rate-limit.burst must not be negative")
+)
+
+// Config is the configuration of the rate limit applied to the checks of endpoints targeting the same host
+//
+// Each host has its own token bucket, which is shared by every endpoint targeting said host: a check consumes a token,
+// and a token is added back every Interval, up to Burst tokens.
+type Config struct {
+	// Interval is the duration it takes for a token to be added back to the bucket of a host, which is the minimum
+	// duration between two checks of the same host once the burst has been used up
+	Interval time.Duration `yaml:"interval"`
+
+	// Burst is the number of checks of the same host that can be performed back to back
+	//
+	// Defaults to 1
+	Burst int `yaml:"burst,omitempty"`
+
+	buckets map[string]*bucket
+	mutex   sync.Mutex
+}
+
+// bucket is the token bucket of a single host
+type bucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// ValidateAndSetDefaults validates the rate limit configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	if c.Burst < 0 {
+		return ErrInvalidBurst
+	}
+	if c.Burst == 0 {
+		c.Burst = 1
+	}
+	return nil
+}
+
+// Wait blocks until a check of the host passed is allowed, or until the context is canceled, and returns how long the
+// check was throttled for
+//
+// If the configuration is nil or if the host is empty, the check is never throttled.
+func (c *Config) Wait(ctx context.Context, host string) time.Duration {
+	if c == nil || len(host) == 0 {
+		return 0
+	}
+	delay := c.reserve(host, time.Now())
+	if delay <= 0 {
+		return 0
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+	return delay
+}
+
+// reserve consumes a token from the bucket of the host passed and returns how long the caller must wait before the
+// token it consumed becomes available
+//
+// The token is consumed even if it isn't available yet, so that concurrent checks of the same host are spaced out
+// rather than all being allowed at once when a token becomes available.
+func (c *Config) reserve(host string, now time.Time) time.Duration {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.buckets == nil {
+		c.buckets = make(map[string]*bucket)
+	}
+	b, exists := c.buckets[host]
+	if !exists {
+		b = &bucket{tokens: float64(c.Burst), lastRefill: now}
+		c.buckets[host] = b
+	}
+	if elapsed := now.Sub(b.lastRefill); elapsed > 0 {
+		b.tokens += float64(elapsed) / float64(c.Interval)
+		if b.tokens > float64(c.Burst) {
+			b.tokens = float64(c.Burst)
+		}
+		b.lastRefill = now
+	}
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens * float64(c.Interval))
+}
@@ -0,0 +1,112 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		cfg           *Config
+		expectedErr   error
+		expectedBurst int
+	}{
+		{
+			name:          "good-config",
+			cfg:           &Config{Interval: time.Second, Burst: 5},
+			expectedBurst: 5,
+		},
+		{
+			name:          "good-config-with-default-burst",
+			cfg:           &Config{Interval: time.Second},
+			expectedBurst: 1,
+		},
+		{
+			name:        "config-without-interval",
+			cfg:         &Config{},
+			expectedErr: ErrInvalidInterval,
+		},
+		{
+			name:        "config-with-negative-interval",
+			cfg:         &Config{Interval: -time.Second},
+			expectedErr: ErrInvalidInterval,
+		},
+		{
+			name:        "config-with-negative-burst",
+			cfg:         &Config{Interval: time.Second, Burst: -1},
+			expectedErr: ErrInvalidBurst,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err == nil && scenario.cfg.Burst != scenario.expectedBurst {
+				t.Errorf("expected burst %d, got %d", scenario.expectedBurst, scenario.cfg.Burst)
+			}
+		})
+	}
+}
+
+func TestConfig_reserve(t *testing.T) {
+	cfg := &Config{Interval: 10 * time.Second, Burst: 2}
+	now := time.Now()
+	if delay := cfg.reserve("example.org", now); delay != 0 {
+		t.Errorf("expected the first check to not be throttled, got %s", delay)
+	}
+	if delay := cfg.reserve("example.org", now); delay != 0 {
+		t.Errorf("expected the second check to be allowed by the burst, got %s", delay)
+	}
+	if delay := cfg.reserve("example.org", now); delay != 10*time.Second {
+		t.Errorf("expected the third check to be throttled for 10s, got %s", delay)
+	}
+	if delay := cfg.reserve("example.org", now); delay != 20*time.Second {
+		t.Errorf("expected the fourth check to be throttled for 20s, got %s", delay)
+	}
+	if delay := cfg.reserve("example.com", now); delay != 0 {
+		t.Errorf("expected a check of another host to not be throttled, got %s", delay)
+	}
+	// The two tokens consumed in advance and one more are added back after 30s
+	if delay := cfg.reserve("example.org", now.Add(30*time.Second)); delay != 0 {
+		t.Errorf("expected the check to not be throttled once the tokens were added back, got %s", delay)
+	}
+	// The bucket never holds more than Burst tokens
+	later := now.Add(time.Hour)
+	cfg.reserve("example.org", later)
+	cfg.reserve("example.org", later)
+	if delay := cfg.reserve("example.org", later); delay != 10*time.Second {
+		t.Errorf("expected the check to be throttled for 10s after the burst was used up, got %s", delay)
+	}
+}
+
+func TestConfig_Wait(t *testing.T) {
+	cfg := &Config{Interval: 50 * time.Millisecond, Burst: 1}
+	if throttled := cfg.Wait(context.Background(), "example.org"); throttled != 0 {
+		t.Errorf("expected the first check to not be throttled, got %s", throttled)
+	}
+	start := time.Now()
+	if throttled := cfg.Wait(context.Background(), "example.org"); throttled <= 0 {
+		t.Errorf("expected the second check to be throttled, got %s", throttled)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected Wait to block for about 50ms, blocked for %s", elapsed)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	start = time.Now()
+	cfg.Wait(ctx, "example.org")
+	if elapsed := time.Since(start); elapsed > 40*time.Millisecond {
+		t.Errorf("expected Wait to return as soon as the context is canceled, blocked for %s", elapsed)
+	}
+	if throttled := cfg.Wait(context.Background(), ""); throttled != 0 {
+		t.Errorf("expected checks without a host to never be throttled, got %s", throttled)
+	}
+	if throttled := (*Config)(nil).Wait(context.Background(), "example.org"); throttled != 0 {
+		t.Errorf("expected checks to never be throttled without a configuration, got %s", throttled)
+	}
+}
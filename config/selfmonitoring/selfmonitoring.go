@@ -0,0 +1,88 @@
+package selfmonitoring
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/web"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+const (
+	// EndpointName is the name of the endpoint monitoring Gatus itself
+	EndpointName = "gatus"
+
+	// EndpointGroup is the group of the endpoint monitoring Gatus itself
+	EndpointGroup = "self-monitoring"
+
+	// HealthPath is the path of the API returning the health of Gatus itself
+	HealthPath = "/api/v1/self/health"
+
+	// DefaultInterval is the default interval at which Gatus monitors itself
+	DefaultInterval = time.Minute
+)
+
+var (
+	ErrInvalidInterval = errors.New("self-monitoring.interval must be 10s or higher")
+)
+
+// Config is the configuration for monitoring Gatus itself
+//
+// If set, an endpoint targeting the health API of Gatus is added to the endpoints to monitor, which means that it is
+// displayed in the dashboard and that it can be alerted on like any other endpoint.
+type Config struct {
+	// Interval is the interval at which Gatus monitors itself
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Conditions are the conditions the health of Gatus must satisfy. Defaults to [STATUS] == 200, which only fails
+	// if the storage is unreachable or if the circuit breaker of an alerting provider is open.
+	Conditions []core.Condition `yaml:"conditions,omitempty"`
+
+	// Alerts are the alerts sent when Gatus is unhealthy
+	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the self-monitoring configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if c.Interval == 0 {
+		c.Interval = DefaultInterval
+	} else if c.Interval < 10*time.Second {
+		return ErrInvalidInterval
+	}
+	if len(c.Conditions) == 0 {
+		c.Conditions = []core.Condition{"[STATUS] == 200"}
+	}
+	return nil
+}
+
+// Endpoint returns the endpoint monitoring Gatus through the health API exposed with the given web configuration
+func (c *Config) Endpoint(webConfig *web.Config) *core.Endpoint {
+	scheme, clientConfig := "http", client.GetDefaultConfig()
+	if webConfig.HasTLS() {
+		// The certificate is unlikely to be valid for the loopback address
+		scheme, clientConfig.Insecure = "https", true
+	}
+	address := webConfig.Address
+	if len(address) == 0 || address == web.DefaultAddress || address == "::" {
+		address = "127.0.0.1"
+	}
+	return &core.Endpoint{
+		Name:         EndpointName,
+		Group:        EndpointGroup,
+		URL:          fmt.Sprintf("%s://%s%s", scheme, net.JoinHostPort(address, strconv.Itoa(webConfig.Port)), HealthPath),
+		Interval:     c.Interval,
+		Conditions:   c.Conditions,
+		Alerts:       c.Alerts,
+		ClientConfig: clientConfig,
+	}
+}
+
+// IsSelfMonitoringEndpoint returns whether the given endpoint is the one monitoring Gatus itself
+func IsSelfMonitoringEndpoint(endpoint *core.Endpoint) bool {
+	return endpoint.Name == EndpointName && endpoint.Group == EndpointGroup
+}
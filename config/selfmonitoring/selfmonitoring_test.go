@@ -0,0 +1,99 @@
+package selfmonitoring
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config/web"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		cfg              *Config
+		expectedErr      error
+		expectedInterval time.Duration
+	}{
+		{
+			name:             "default-interval",
+			cfg:              &Config{},
+			expectedInterval: DefaultInterval,
+		},
+		{
+			name:             "custom-interval",
+			cfg:              &Config{Interval: 30 * time.Second},
+			expectedInterval: 30 * time.Second,
+		},
+		{
+			name:        "interval-too-low",
+			cfg:         &Config{Interval: 5 * time.Second},
+			expectedErr: ErrInvalidInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.Interval != scenario.expectedInterval {
+				t.Errorf("expected interval to be %s, got %s", scenario.expectedInterval, scenario.cfg.Interval)
+			}
+			if len(scenario.cfg.Conditions) != 1 || scenario.cfg.Conditions[0] != "[STATUS] == 200" {
+				t.Errorf("expected default conditions to be set, got %v", scenario.cfg.Conditions)
+			}
+		})
+	}
+}
+
+func TestConfig_Endpoint(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		webConfig   *web.Config
+		expectedURL string
+	}{
+		{
+			name:        "default-address",
+			webConfig:   web.GetDefaultConfig(),
+			expectedURL: "http://127.0.0.1:8080/api/v1/self/health",
+		},
+		{
+			name:        "custom-address",
+			webConfig:   &web.Config{Address: "192.168.1.10", Port: 12345},
+			expectedURL: "http://192.168.1.10:12345/api/v1/self/health",
+		},
+		{
+			name:        "ipv6-address",
+			webConfig:   &web.Config{Address: "::1", Port: 8080},
+			expectedURL: "http://[::1]:8080/api/v1/self/health",
+		},
+		{
+			name:        "tls",
+			webConfig:   &web.Config{Address: web.DefaultAddress, Port: 8443, TLS: &web.TLSConfig{CertificateFile: "cert.pem", PrivateKeyFile: "key.pem"}},
+			expectedURL: "https://127.0.0.1:8443/api/v1/self/health",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{}
+			_ = cfg.ValidateAndSetDefaults()
+			endpoint := cfg.Endpoint(scenario.webConfig)
+			if endpoint.URL != scenario.expectedURL {
+				t.Errorf("expected URL to be %s, got %s", scenario.expectedURL, endpoint.URL)
+			}
+			if endpoint.ClientConfig.Insecure != scenario.webConfig.HasTLS() {
+				t.Errorf("expected client to be insecure only if TLS is enabled")
+			}
+			if !IsSelfMonitoringEndpoint(endpoint) {
+				t.Error("expected endpoint to be the self-monitoring endpoint")
+			}
+			if endpoint.Interval != DefaultInterval {
+				t.Errorf("expected interval to be %s, got %s", DefaultInterval, endpoint.Interval)
+			}
+		})
+	}
+}
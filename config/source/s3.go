@@ -0,0 +1,193 @@
+package source
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultS3Region is the region used if none is configured, nor set through the AWS_REGION or AWS_DEFAULT_REGION
+	// environment variables
+	DefaultS3Region = "us-east-1"
+
+	// emptyPayloadSHA256 is the hex-encoded SHA-256 of an empty payload, which is the payload of every request sent
+	emptyPayloadSHA256 = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Config is the configuration used to fetch a document from Amazon S3, or from a storage compatible with it
+type S3Config struct {
+	// Region of the bucket. Defaults to the value of the AWS_REGION or AWS_DEFAULT_REGION environment variable, or
+	// DefaultS3Region if neither is set.
+	Region string `yaml:"region,omitempty"`
+
+	// Endpoint of a storage compatible with S3 (e.g. https://minio.example.org), in which case path-style URLs are
+	// used. Defaults to the endpoint of Amazon S3 in Region.
+	Endpoint string `yaml:"endpoint,omitempty"`
+
+	// AccessKeyID used to sign requests. Defaults to the value of the AWS_ACCESS_KEY_ID environment variable.
+	//
+	// If there's no access key ID, requests aren't signed, which only works with public buckets.
+	AccessKeyID string `yaml:"access-key-id,omitempty"`
+
+	// SecretAccessKey used to sign requests. Defaults to the value of the AWS_SECRET_ACCESS_KEY environment variable.
+	SecretAccessKey string `yaml:"secret-access-key,omitempty"`
+
+	// SessionToken sent along with requests when using temporary credentials. Defaults to the value of the
+	// AWS_SESSION_TOKEN environment variable.
+	SessionToken string `yaml:"session-token,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the S3 configuration and sets the default values if necessary
+func (c *S3Config) ValidateAndSetDefaults() error {
+	if len(c.Region) == 0 {
+		c.Region = firstNonEmpty(os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION"), DefaultS3Region)
+	}
+	c.Endpoint = strings.TrimSuffix(c.Endpoint, "/")
+	if len(c.AccessKeyID) == 0 {
+		c.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		c.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		c.SessionToken = os.Getenv("AWS_SESSION_TOKEN")
+	}
+	return nil
+}
+
+// newRequest creates the request retrieving the object referenced by the s3:// URL passed
+func (c *S3Config) newRequest(s3URL string) (*http.Request, error) {
+	bucket, key, err := parseS3URL(s3URL)
+	if err != nil {
+		return nil, err
+	}
+	var objectURL string
+	if len(c.Endpoint) > 0 {
+		objectURL = c.Endpoint + "/" + bucket + "/" + encodeS3Key(key)
+	} else {
+		objectURL = "https://" + bucket + ".s3." + c.Region + ".amazonaws.com/" + encodeS3Key(key)
+	}
+	return http.NewRequest(http.MethodGet, objectURL, nil)
+}
+
+// sign signs the request passed with AWS Signature Version 4, unless there's no access key ID
+//
+// Every header of the request is signed, so the request must not be modified afterward.
+// Reference: https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (c *S3Config) sign(request *http.Request, now time.Time) {
+	if len(c.AccessKeyID) == 0 {
+		return
+	}
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	request.Header.Set("X-Amz-Date", amzDate)
+	request.Header.Set("X-Amz-Content-Sha256", emptyPayloadSHA256)
+	if len(c.SessionToken) > 0 {
+		request.Header.Set("X-Amz-Security-Token", c.SessionToken)
+	}
+	host := request.Host
+	if len(host) == 0 {
+		host = request.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for name, values := range request.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	headerNames := make([]string, 0, len(headers))
+	for name := range headers {
+		headerNames = append(headerNames, name)
+	}
+	sort.Strings(headerNames)
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+	canonicalRequest := strings.Join([]string{
+		request.Method,
+		request.URL.EscapedPath(),
+		canonicalQuery(request),
+		canonicalHeaders.String(),
+		signedHeaders,
+		emptyPayloadSHA256,
+	}, "\n")
+	scope := date + "/" + c.Region + "/s3/aws4_request"
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(canonicalRequestHash[:])
+	signingKey := hmacSHA256([]byte("AWS4"+c.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, c.Region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	request.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+c.AccessKeyID+"/"+scope+",SignedHeaders="+signedHeaders+",Signature="+signature)
+}
+
+// canonicalQuery returns the query of the request passed, sorted and encoded as expected by AWS Signature Version 4
+func canonicalQuery(request *http.Request) string {
+	query := request.URL.Query()
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parameters []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			parameters = append(parameters, uriEncode(key, true)+"="+uriEncode(value, true))
+		}
+	}
+	return strings.Join(parameters, "&")
+}
+
+// parseS3URL returns the bucket and the key of the object referenced by an s3:// URL
+func parseS3URL(s3URL string) (bucket, key string, err error) {
+	bucket, key, found := strings.Cut(strings.TrimPrefix(s3URL, "s3://"), "/")
+	if !found || len(bucket) == 0 || len(key) == 0 {
+		return "", "", ErrInvalidS3URL
+	}
+	return bucket, key, nil
+}
+
+// encodeS3Key encodes the key of an object so that it can be used as the path of a URL, and as the canonical URI of
+// AWS Signature Version 4 without being encoded again
+func encodeS3Key(key string) string {
+	return uriEncode(key, false)
+}
+
+// uriEncode encodes every byte of the string passed except the unreserved characters, as well as the slashes unless
+// encodeSlash is true
+func uriEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var encoded strings.Builder
+	for i := 0; i < len(s); i++ {
+		b := s[i]
+		if (b >= 'A' && b <= 'Z') || (b >= 'a' && b <= 'z') || (b >= '0' && b <= '9') || b == '-' || b == '_' || b == '.' || b == '~' || (b == '/' && !encodeSlash) {
+			encoded.WriteByte(b)
+		} else {
+			encoded.WriteByte('%')
+			encoded.WriteByte(hexDigits[b>>4])
+			encoded.WriteByte(hexDigits[b&15])
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, message string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if len(value) > 0 {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,207 @@
+package source
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval at which the document of a source is fetched again
+	DefaultRefreshInterval = 5 * time.Minute
+
+	// MinimumRefreshInterval is the minimum interval at which the document of a source can be fetched again, which is
+	// the interval at which the configuration is checked for changes
+	MinimumRefreshInterval = 30 * time.Second
+
+	// SourcesKey is the key of the configuration under which the sources are configured
+	SourcesKey = "config-sources"
+)
+
+var (
+	ErrNoURL                  = errors.New("config-sources[].url must not be empty")
+	ErrUnsupportedScheme      = errors.New("config-sources[].url must start with http://, https:// or s3://")
+	ErrInvalidRefreshInterval = errors.New("config-sources[].refresh-interval must be at least 30s")
+	ErrInvalidS3URL           = errors.New("config-sources[].url must be in the format s3://<bucket>/<key>")
+	ErrInvalidDocument        = errors.New("document must be a YAML mapping")
+	ErrNestedSources          = errors.New("document must not have " + SourcesKey)
+
+	// lastValidDocuments are the last valid documents fetched, by URL, which are used instead of the document of a
+	// source if it cannot be fetched or is invalid. They are kept across reloads of the configuration.
+	lastValidDocuments      = make(map[string][]byte)
+	lastValidDocumentsMutex sync.RWMutex
+)
+
+// Config is the configuration of a source from which a document that is merged with the configuration is fetched,
+// such as a list of endpoints managed by another system
+type Config struct {
+	// URL of the document (e.g. https://config.example.org/gatus/endpoints.yaml or s3://bucket/gatus/endpoints.yaml)
+	URL string `yaml:"url"`
+
+	// Headers to send with the request fetching the document
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// RefreshInterval is the interval at which the document is fetched again. If it has changed, the configuration
+	// is reloaded.
+	RefreshInterval time.Duration `yaml:"refresh-interval,omitempty"`
+
+	// S3 is the configuration used to fetch the document if URL starts with s3://
+	S3 *S3Config `yaml:"s3,omitempty"`
+
+	// ClientConfig is the configuration of the client used to fetch the document
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+
+	document  []byte    // document fetched last
+	refreshAt time.Time // time at which the document must be fetched again
+}
+
+// ValidateAndSetDefaults validates the configuration of the source and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.URL) == 0 {
+		return ErrNoURL
+	}
+	if c.isS3() {
+		if c.S3 == nil {
+			c.S3 = &S3Config{}
+		}
+		if err := c.S3.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+		if _, _, err := parseS3URL(c.URL); err != nil {
+			return err
+		}
+	} else if !strings.HasPrefix(c.URL, "http://") && !strings.HasPrefix(c.URL, "https://") {
+		return ErrUnsupportedScheme
+	}
+	if c.RefreshInterval == 0 {
+		c.RefreshInterval = DefaultRefreshInterval
+	} else if c.RefreshInterval < MinimumRefreshInterval {
+		return ErrInvalidRefreshInterval
+	}
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	} else {
+		if err := c.ClientConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Fetch fetches the document of the source and validates it
+//
+// If the document cannot be fetched or is invalid, the last valid document fetched from the same URL is returned
+// instead, if any.
+func (c *Config) Fetch() ([]byte, error) {
+	document, err := c.fetch()
+	if err != nil {
+		lastValidDocumentsMutex.RLock()
+		lastValidDocument, exists := lastValidDocuments[c.URL]
+		lastValidDocumentsMutex.RUnlock()
+		if !exists {
+			return nil, fmt.Errorf("failed to fetch config source %s: %w", c.name(), err)
+		}
+		log.Printf("[source][Fetch] Failed to fetch config source %s, using the last valid document instead: %s", c.name(), err.Error())
+		document = lastValidDocument
+	} else {
+		lastValidDocumentsMutex.Lock()
+		lastValidDocuments[c.URL] = document
+		lastValidDocumentsMutex.Unlock()
+	}
+	c.document = document
+	c.refreshAt = time.Now().Add(c.RefreshInterval)
+	return document, nil
+}
+
+// IsModified returns whether the document of the source has changed since it was last fetched
+//
+// The document is only fetched again once the refresh interval has elapsed; until then, or if it cannot be fetched
+// or is invalid, the document is considered unchanged.
+func (c *Config) IsModified() bool {
+	if time.Now().Before(c.refreshAt) {
+		return false
+	}
+	c.refreshAt = time.Now().Add(c.RefreshInterval)
+	document, err := c.fetch()
+	if err != nil {
+		log.Printf("[source][IsModified] Failed to fetch config source %s: %s", c.name(), err.Error())
+		return false
+	}
+	if bytes.Equal(document, c.document) {
+		return false
+	}
+	// The document is updated right away, so that a change is only reported once even if the resulting configuration
+	// is invalid
+	c.document = document
+	return true
+}
+
+// fetch fetches the document of the source and validates it
+func (c *Config) fetch() ([]byte, error) {
+	var request *http.Request
+	var err error
+	if c.isS3() {
+		request, err = c.S3.newRequest(c.URL)
+	} else {
+		request, err = http.NewRequest(http.MethodGet, c.URL, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range c.Headers {
+		request.Header.Set(name, value)
+	}
+	if c.isS3() {
+		c.S3.sign(request, time.Now())
+	}
+	response, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+	document, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err = validateDocument(document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// validateDocument returns an error if the document passed cannot be merged with the configuration
+func validateDocument(document []byte) error {
+	var mapping map[string]interface{}
+	if err := yaml.Unmarshal(document, &mapping); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidDocument, err.Error())
+	}
+	if mapping == nil {
+		return ErrInvalidDocument
+	}
+	if _, exists := mapping[SourcesKey]; exists {
+		return ErrNestedSources
+	}
+	return nil
+}
+
+func (c *Config) isS3() bool {
+	return strings.HasPrefix(c.URL, "s3://")
+}
+
+// name returns the URL of the source without its query, which may have credentials (e.g. pre-signed URLs)
+func (c *Config) name() string {
+	name, _, _ := strings.Cut(c.URL, "?")
+	return name
+}
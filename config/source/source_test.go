@@ -0,0 +1,132 @@
+package source
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name          string
+		config        Config
+		expectedError error
+	}{
+		{
+			name:   "https",
+			config: Config{URL: "https://config.example.org/endpoints.yaml"},
+		},
+		{
+			name:   "s3",
+			config: Config{URL: "s3://bucket/gatus/endpoints.yaml"},
+		},
+		{
+			name:          "no-url",
+			config:        Config{},
+			expectedError: ErrNoURL,
+		},
+		{
+			name:          "unsupported-scheme",
+			config:        Config{URL: "ftp://config.example.org/endpoints.yaml"},
+			expectedError: ErrUnsupportedScheme,
+		},
+		{
+			name:          "s3-without-key",
+			config:        Config{URL: "s3://bucket"},
+			expectedError: ErrInvalidS3URL,
+		},
+		{
+			name:          "refresh-interval-too-short",
+			config:        Config{URL: "https://config.example.org/endpoints.yaml", RefreshInterval: time.Second},
+			expectedError: ErrInvalidRefreshInterval,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.config.ValidateAndSetDefaults(); err != scenario.expectedError {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+			if scenario.expectedError == nil && scenario.config.RefreshInterval != DefaultRefreshInterval {
+				t.Errorf("expected refresh interval to default to %s, got %s", DefaultRefreshInterval, scenario.config.RefreshInterval)
+			}
+		})
+	}
+}
+
+func TestConfig_Fetch(t *testing.T) {
+	statusCode, document := http.StatusOK, "endpoints:\n  - name: website\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(document))
+	}))
+	defer server.Close()
+	config := &Config{URL: server.URL + "/endpoints.yaml", Headers: map[string]string{"Authorization": "Bearer token"}}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	fetchedDocument, err := config.Fetch()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if string(fetchedDocument) != document {
+		t.Errorf("expected %q, got %q", document, string(fetchedDocument))
+	}
+	// The last valid document must be used if the source is unavailable or returns an invalid document
+	for _, scenario := range []struct {
+		statusCode int
+		document   string
+	}{
+		{statusCode: http.StatusInternalServerError, document: "endpoints:\n  - name: other\n"},
+		{statusCode: http.StatusOK, document: "- not a mapping"},
+		{statusCode: http.StatusOK, document: "config-sources:\n  - url: https://example.org\n"},
+	} {
+		statusCode, document = scenario.statusCode, scenario.document
+		fetchedDocument, err = config.Fetch()
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		if string(fetchedDocument) != "endpoints:\n  - name: website\n" {
+			t.Errorf("expected the last valid document to be used, got %q", string(fetchedDocument))
+		}
+	}
+	// Without a previous valid document, the error must be returned
+	otherConfig := &Config{URL: server.URL + "/other.yaml"}
+	if err := otherConfig.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if _, err = otherConfig.Fetch(); err == nil {
+		t.Error("expected an error, because the source is unavailable and no document was fetched from it before")
+	}
+}
+
+func TestConfig_IsModified(t *testing.T) {
+	document := "endpoints:\n  - name: website\n"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(document))
+	}))
+	defer server.Close()
+	config := &Config{URL: server.URL}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if _, err := config.Fetch(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	document = "endpoints:\n  - name: website\n  - name: api\n"
+	if config.IsModified() {
+		t.Error("expected the document to not be fetched again before the refresh interval has elapsed")
+	}
+	config.refreshAt = time.Now().Add(-time.Second)
+	if !config.IsModified() {
+		t.Error("expected the document to have been modified")
+	}
+	config.refreshAt = time.Now().Add(-time.Second)
+	if config.IsModified() {
+		t.Error("expected a modification to only be reported once")
+	}
+}
@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+)
+
+const (
+	// corsWildcard is the origin that allows any origin to access the API
+	corsWildcard = "*"
+)
+
+var (
+	ErrCORSWithNoAllowedOrigins      = errors.New("invalid cors configuration: must specify at least one allowed origin")
+	ErrCORSWithWildcardAndCredential = errors.New("invalid cors configuration: cannot allow credentials with a wildcard origin")
+
+	defaultCORSAllowedMethods = []string{"GET", "HEAD"}
+)
+
+// CORSConfig is the configuration for Cross-Origin Resource Sharing on the API
+//
+// If not set, no CORS headers are sent, meaning that the API can only be accessed from the same origin.
+type CORSConfig struct {
+	AllowedOrigins   []string `yaml:"allowed-origins"`             // Origins allowed to access the API. Use "*" to allow any origin
+	AllowedMethods   []string `yaml:"allowed-methods,omitempty"`   // Methods allowed when accessing the API. Defaults to GET and HEAD
+	AllowedHeaders   []string `yaml:"allowed-headers,omitempty"`   // Headers that may be sent when accessing the API
+	AllowCredentials bool     `yaml:"allow-credentials,omitempty"` // Whether requests with credentials (e.g. cookies) are allowed
+}
+
+// ValidateAndSetDefaults validates the CORS configuration and sets the default values if necessary.
+func (cfg *CORSConfig) ValidateAndSetDefaults() error {
+	if len(cfg.AllowedOrigins) == 0 {
+		return ErrCORSWithNoAllowedOrigins
+	}
+	if cfg.AllowCredentials && cfg.AllowsAnyOrigin() {
+		// Browsers reject credentialed responses with a wildcard origin, so this would never work as intended
+		return ErrCORSWithWildcardAndCredential
+	}
+	if len(cfg.AllowedMethods) == 0 {
+		cfg.AllowedMethods = append([]string{}, defaultCORSAllowedMethods...)
+	}
+	for i, method := range cfg.AllowedMethods {
+		cfg.AllowedMethods[i] = strings.ToUpper(method)
+	}
+	return nil
+}
+
+// AllowsAnyOrigin returns whether any origin is allowed to access the API
+func (cfg *CORSConfig) AllowsAnyOrigin() bool {
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == corsWildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"testing"
+)
+
+func TestCORSConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		Name                   string
+		Config                 *CORSConfig
+		ExpectedError          error
+		ExpectedAllowedMethods []string
+	}{
+		{
+			Name:          "no-allowed-origins",
+			Config:        &CORSConfig{},
+			ExpectedError: ErrCORSWithNoAllowedOrigins,
+		},
+		{
+			Name:          "wildcard-with-credentials",
+			Config:        &CORSConfig{AllowedOrigins: []string{"*"}, AllowCredentials: true},
+			ExpectedError: ErrCORSWithWildcardAndCredential,
+		},
+		{
+			Name:                   "default-methods",
+			Config:                 &CORSConfig{AllowedOrigins: []string{"https://example.org"}},
+			ExpectedAllowedMethods: []string{"GET", "HEAD"},
+		},
+		{
+			Name:                   "custom-methods",
+			Config:                 &CORSConfig{AllowedOrigins: []string{"https://example.org"}, AllowedMethods: []string{"get", "post"}, AllowCredentials: true},
+			ExpectedAllowedMethods: []string{"GET", "POST"},
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			err := scenario.Config.ValidateAndSetDefaults()
+			if err != scenario.ExpectedError {
+				t.Fatalf("expected error %v, got %v", scenario.ExpectedError, err)
+			}
+			if err != nil {
+				return
+			}
+			if len(scenario.Config.AllowedMethods) != len(scenario.ExpectedAllowedMethods) {
+				t.Fatalf("expected allowed methods %v, got %v", scenario.ExpectedAllowedMethods, scenario.Config.AllowedMethods)
+			}
+			for i, method := range scenario.ExpectedAllowedMethods {
+				if scenario.Config.AllowedMethods[i] != method {
+					t.Errorf("expected allowed methods %v, got %v", scenario.ExpectedAllowedMethods, scenario.Config.AllowedMethods)
+				}
+			}
+		})
+	}
+}
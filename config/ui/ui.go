@@ -22,12 +22,13 @@ var (
 
 // Config is the configuration for the UI of Gatus
 type Config struct {
-	Title       string   `yaml:"title,omitempty"`       // Title of the page
-	Description string   `yaml:"description,omitempty"` // Meta description of the page
-	Header      string   `yaml:"header,omitempty"`      // Header is the text at the top of the page
-	Logo        string   `yaml:"logo,omitempty"`        // Logo to display on the page
-	Link        string   `yaml:"link,omitempty"`        // Link to open when clicking on the logo
-	Buttons     []Button `yaml:"buttons,omitempty"`     // Buttons to display below the header
+	Title       string      `yaml:"title,omitempty"`       // Title of the page
+	Description string      `yaml:"description,omitempty"` // Meta description of the page
+	Header      string      `yaml:"header,omitempty"`      // Header is the text at the top of the page
+	Logo        string      `yaml:"logo,omitempty"`        // Logo to display on the page
+	Link        string      `yaml:"link,omitempty"`        // Link to open when clicking on the logo
+	Buttons     []Button    `yaml:"buttons,omitempty"`     // Buttons to display below the header
+	CORS        *CORSConfig `yaml:"cors,omitempty"`        // CORS configuration of the API
 }
 
 // Button is the configuration for a button on the UI
@@ -74,6 +75,11 @@ func (cfg *Config) ValidateAndSetDefaults() error {
 			return err
 		}
 	}
+	if cfg.CORS != nil {
+		if err := cfg.CORS.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	// Validate that the template works
 	t, err := template.ParseFS(static.FileSystem, static.IndexPath)
 	if err != nil {
@@ -0,0 +1,351 @@
+package vault
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ReferencePrefix is the prefix of the configuration values referencing a secret stored in Vault
+	//
+	// Usage: vault://secret/data/gatus/slack#webhook-url
+	ReferencePrefix = "vault://"
+
+	AuthMethodToken      = "token"
+	AuthMethodAppRole    = "approle"
+	AuthMethodKubernetes = "kubernetes"
+
+	// DefaultKubernetesServiceAccountTokenFile is the path of the token of the service account mounted in pods
+	DefaultKubernetesServiceAccountTokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+var (
+	ErrNoAddress                     = errors.New("vault.address must not be empty")
+	ErrNoAuth                        = errors.New("vault.auth must be configured")
+	ErrInvalidAuthMethod             = errors.New("vault.auth.method must be one of " + AuthMethodToken + ", " + AuthMethodAppRole + " or " + AuthMethodKubernetes)
+	ErrTokenAuthWithoutToken         = errors.New("vault.auth.token must be set when using the token auth method")
+	ErrAppRoleAuthWithoutCredentials = errors.New("vault.auth.role-id and vault.auth.secret-id must be set when using the approle auth method")
+	ErrKubernetesAuthWithoutRole     = errors.New("vault.auth.role must be set when using the kubernetes auth method")
+	ErrInvalidReference              = errors.New("secret references must be in the format " + ReferencePrefix + "<path>#<field>")
+	ErrReferenceWithoutConfig        = errors.New("the configuration references secrets stored in Vault, but vault is not configured")
+)
+
+// Config is the configuration for reading the secrets referenced by the configuration from HashiCorp Vault
+type Config struct {
+	// Address is the address of the Vault server (e.g. https://vault.example.org:8200)
+	Address string `yaml:"address"`
+
+	// Namespace is the Vault Enterprise namespace to read secrets from, if any
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Auth is the configuration of the method used to authenticate with Vault
+	Auth *AuthConfig `yaml:"auth"`
+
+	// ClientConfig is the configuration of the client used to communicate with Vault
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// AuthConfig is the configuration of the method used to authenticate with Vault
+type AuthConfig struct {
+	// Method is the auth method to use (token, approle or kubernetes)
+	Method string `yaml:"method"`
+
+	// MountPath is the path at which the auth method is mounted. Defaults to the name of the method.
+	MountPath string `yaml:"mount-path,omitempty"`
+
+	// Token is the token to use with the token auth method
+	Token string `yaml:"token,omitempty"`
+
+	// RoleID is the role ID to use with the approle auth method
+	RoleID string `yaml:"role-id,omitempty"`
+
+	// SecretID is the secret ID to use with the approle auth method
+	SecretID string `yaml:"secret-id,omitempty"`
+
+	// Role is the role to use with the kubernetes auth method
+	Role string `yaml:"role,omitempty"`
+
+	// ServiceAccountTokenFile is the path to the token of the service account to use with the kubernetes auth method
+	//
+	// Defaults to DefaultKubernetesServiceAccountTokenFile
+	ServiceAccountTokenFile string `yaml:"service-account-token-file,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the Vault configuration and sets the default values if necessary
+func (c *Config) ValidateAndSetDefaults() error {
+	if len(c.Address) == 0 {
+		return ErrNoAddress
+	}
+	c.Address = strings.TrimSuffix(c.Address, "/")
+	if c.Auth == nil {
+		return ErrNoAuth
+	}
+	switch c.Auth.Method {
+	case AuthMethodToken:
+		if len(c.Auth.Token) == 0 {
+			return ErrTokenAuthWithoutToken
+		}
+	case AuthMethodAppRole:
+		if len(c.Auth.RoleID) == 0 || len(c.Auth.SecretID) == 0 {
+			return ErrAppRoleAuthWithoutCredentials
+		}
+	case AuthMethodKubernetes:
+		if len(c.Auth.Role) == 0 {
+			return ErrKubernetesAuthWithoutRole
+		}
+		if len(c.Auth.ServiceAccountTokenFile) == 0 {
+			c.Auth.ServiceAccountTokenFile = DefaultKubernetesServiceAccountTokenFile
+		}
+	default:
+		return ErrInvalidAuthMethod
+	}
+	if len(c.Auth.MountPath) == 0 {
+		c.Auth.MountPath = c.Auth.Method
+	}
+	c.Auth.MountPath = strings.Trim(c.Auth.MountPath, "/")
+	if c.ClientConfig == nil {
+		c.ClientConfig = client.GetDefaultConfig()
+	} else {
+		if err := c.ClientConfig.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasReferences returns whether one of the values of the configuration passed references a secret stored in Vault
+func HasReferences(yamlBytes []byte) bool {
+	if !bytes.Contains(yamlBytes, []byte(ReferencePrefix)) {
+		return false
+	}
+	var document yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &document); err != nil {
+		// The error will be returned when the configuration is parsed
+		return false
+	}
+	return hasReferences(&document)
+}
+
+// hasReferences returns whether the node passed, or one of its descendants, references a secret stored in Vault
+func hasReferences(node *yaml.Node) bool {
+	if node.Kind == yaml.ScalarNode {
+		return isReference(node)
+	}
+	for _, child := range node.Content {
+		if hasReferences(child) {
+			return true
+		}
+	}
+	return false
+}
+
+// isReference returns whether the node passed is a string referencing a secret stored in Vault
+func isReference(node *yaml.Node) bool {
+	return node.Kind == yaml.ScalarNode && node.Tag == "!!str" && strings.HasPrefix(node.Value, ReferencePrefix)
+}
+
+// ResolveReferences replaces every value of the YAML document passed that references a secret stored in Vault by the
+// value of said secret, and returns the resulting document along with the shortest lease duration of the secrets
+// read, which is 0 if none of them have to be renewed
+//
+// Every secret is read from Vault at most once, regardless of how many of its fields are referenced.
+func (c *Config) ResolveReferences(yamlBytes []byte) ([]byte, time.Duration, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(yamlBytes, &document); err != nil {
+		return nil, 0, err
+	}
+	token, err := c.login()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to authenticate with vault: %w", err)
+	}
+	r := &resolver{config: c, token: token, secrets: make(map[string]*secret)}
+	if err = r.resolve(&document); err != nil {
+		return nil, 0, err
+	}
+	resolvedYAMLBytes, err := yaml.Marshal(&document)
+	if err != nil {
+		return nil, 0, err
+	}
+	return resolvedYAMLBytes, r.leaseDuration, nil
+}
+
+// secret is a secret read from Vault
+type secret struct {
+	data          map[string]interface{}
+	leaseDuration time.Duration
+}
+
+// resolver resolves the references of a single configuration, caching the secrets read
+type resolver struct {
+	config        *Config
+	token         string
+	secrets       map[string]*secret
+	leaseDuration time.Duration
+}
+
+// resolve walks the node passed and replaces the value of every scalar referencing a secret
+func (r *resolver) resolve(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		if !isReference(node) {
+			return nil
+		}
+		value, err := r.resolveReference(node.Value)
+		if err != nil {
+			return fmt.Errorf("failed to read secret %s: %w", node.Value, err)
+		}
+		node.Value = value
+		// The style of the node is reset, since the secret may not be representable with the original one (e.g. a
+		// multi-line certificate replacing a plain scalar)
+		node.Style = 0
+		return nil
+	}
+	for _, child := range node.Content {
+		if err := r.resolve(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveReference returns the value of the field of the secret referenced by the value passed
+func (r *resolver) resolveReference(reference string) (string, error) {
+	path, field, found := strings.Cut(strings.TrimPrefix(reference, ReferencePrefix), "#")
+	path = strings.Trim(path, "/")
+	if !found || len(path) == 0 || len(field) == 0 {
+		return "", ErrInvalidReference
+	}
+	s, exists := r.secrets[path]
+	if !exists {
+		var err error
+		if s, err = r.config.read(r.token, path); err != nil {
+			return "", err
+		}
+		r.secrets[path] = s
+		if s.leaseDuration > 0 && (r.leaseDuration == 0 || s.leaseDuration < r.leaseDuration) {
+			r.leaseDuration = s.leaseDuration
+		}
+	}
+	value, exists := s.data[field]
+	if !exists {
+		return "", fmt.Errorf("field %s not found in secret", field)
+	}
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case nil:
+		return "", nil
+	default:
+		// Non-string values (e.g. numbers, booleans) are converted back to their JSON representation
+		encodedValue, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(encodedValue), nil
+	}
+}
+
+// response is the response of the Vault API
+type response struct {
+	LeaseDuration int                    `json:"lease_duration"`
+	Data          map[string]interface{} `json:"data"`
+	Auth          *struct {
+		ClientToken string `json:"client_token"`
+	} `json:"auth"`
+	Errors []string `json:"errors"`
+}
+
+// login authenticates with Vault and returns the resulting token
+func (c *Config) login() (string, error) {
+	var body map[string]string
+	switch c.Auth.Method {
+	case AuthMethodToken:
+		return c.Auth.Token, nil
+	case AuthMethodAppRole:
+		body = map[string]string{"role_id": c.Auth.RoleID, "secret_id": c.Auth.SecretID}
+	case AuthMethodKubernetes:
+		jwt, err := os.ReadFile(c.Auth.ServiceAccountTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read service account token: %w", err)
+		}
+		body = map[string]string{"role": c.Auth.Role, "jwt": strings.TrimSpace(string(jwt))}
+	}
+	payload, _ := json.Marshal(body)
+	resp, err := c.request(http.MethodPost, "auth/"+c.Auth.MountPath+"/login", "", payload)
+	if err != nil {
+		return "", err
+	}
+	if resp.Auth == nil || len(resp.Auth.ClientToken) == 0 {
+		return "", errors.New("no token returned")
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+// read reads the secret at the path passed
+//
+// Secrets of the version 2 of the KV secrets engine are unwrapped, so that their fields can be referenced directly.
+func (c *Config) read(token, path string) (*secret, error) {
+	resp, err := c.request(http.MethodGet, path, token, nil)
+	if err != nil {
+		return nil, err
+	}
+	data := resp.Data
+	if nestedData, isKVv2 := data["data"].(map[string]interface{}); isKVv2 {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nestedData
+		}
+	}
+	return &secret{data: data, leaseDuration: time.Duration(resp.LeaseDuration) * time.Second}, nil
+}
+
+// request sends a request to the Vault API and parses its response
+func (c *Config) request(method, path, token string, payload []byte) (*response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewBuffer(payload)
+	}
+	request, err := http.NewRequest(method, c.Address+"/v1/"+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if len(token) > 0 {
+		request.Header.Set("X-Vault-Token", token)
+	}
+	if len(c.Namespace) > 0 {
+		request.Header.Set("X-Vault-Namespace", c.Namespace)
+	}
+	httpResponse, err := client.GetHTTPClient(c.ClientConfig).Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResponse.Body.Close()
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, err
+	}
+	var resp response
+	if len(responseBody) > 0 {
+		if err = json.Unmarshal(responseBody, &resp); err != nil && httpResponse.StatusCode < 400 {
+			return nil, fmt.Errorf("failed to parse response from vault: %w", err)
+		}
+	}
+	if httpResponse.StatusCode > 399 {
+		if len(resp.Errors) > 0 {
+			return nil, fmt.Errorf("vault returned status code %d: %s", httpResponse.StatusCode, strings.Join(resp.Errors, ", "))
+		}
+		return nil, fmt.Errorf("vault returned status code %d", httpResponse.StatusCode)
+	}
+	return &resp, nil
+}
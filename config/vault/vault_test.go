@@ -0,0 +1,285 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		cfg               *Config
+		expectedErr       error
+		expectedMountPath string
+	}{
+		{
+			name:              "token",
+			cfg:               &Config{Address: "https://vault.example.org/", Auth: &AuthConfig{Method: AuthMethodToken, Token: "s.token"}},
+			expectedMountPath: "token",
+		},
+		{
+			name:              "approle-with-custom-mount-path",
+			cfg:               &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: AuthMethodAppRole, RoleID: "role", SecretID: "secret", MountPath: "/gatus-approle/"}},
+			expectedMountPath: "gatus-approle",
+		},
+		{
+			name:              "kubernetes",
+			cfg:               &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: AuthMethodKubernetes, Role: "gatus"}},
+			expectedMountPath: "kubernetes",
+		},
+		{
+			name:        "no-address",
+			cfg:         &Config{Auth: &AuthConfig{Method: AuthMethodToken, Token: "s.token"}},
+			expectedErr: ErrNoAddress,
+		},
+		{
+			name:        "no-auth",
+			cfg:         &Config{Address: "https://vault.example.org"},
+			expectedErr: ErrNoAuth,
+		},
+		{
+			name:        "invalid-auth-method",
+			cfg:         &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: "userpass"}},
+			expectedErr: ErrInvalidAuthMethod,
+		},
+		{
+			name:        "token-without-token",
+			cfg:         &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: AuthMethodToken}},
+			expectedErr: ErrTokenAuthWithoutToken,
+		},
+		{
+			name:        "approle-without-secret-id",
+			cfg:         &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: AuthMethodAppRole, RoleID: "role"}},
+			expectedErr: ErrAppRoleAuthWithoutCredentials,
+		},
+		{
+			name:        "kubernetes-without-role",
+			cfg:         &Config{Address: "https://vault.example.org", Auth: &AuthConfig{Method: AuthMethodKubernetes}},
+			expectedErr: ErrKubernetesAuthWithoutRole,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			err := scenario.cfg.ValidateAndSetDefaults()
+			if !errors.Is(err, scenario.expectedErr) {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if scenario.cfg.Auth.MountPath != scenario.expectedMountPath {
+				t.Errorf("expected mount path %s, got %s", scenario.expectedMountPath, scenario.cfg.Auth.MountPath)
+			}
+			if strings.HasSuffix(scenario.cfg.Address, "/") {
+				t.Errorf("expected the trailing slash of the address to be removed, got %s", scenario.cfg.Address)
+			}
+			if scenario.cfg.ClientConfig == nil {
+				t.Error("expected the client configuration to be set")
+			}
+		})
+	}
+}
+
+// newVaultServer returns a server mimicking the API of Vault, on which the token "s.root" is allowed to read secrets,
+// and on which logging in through the approle and kubernetes auth methods returns said token
+func newVaultServer(t *testing.T, numberOfReads *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON := func(status int, body any) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_ = json.NewEncoder(w).Encode(body)
+		}
+		if r.Method == http.MethodPost {
+			var credentials map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&credentials)
+			switch {
+			case r.URL.Path == "/v1/auth/approle/login" && credentials["role_id"] == "role" && credentials["secret_id"] == "secret",
+				r.URL.Path == "/v1/auth/kubernetes/login" && credentials["role"] == "gatus" && credentials["jwt"] == "service-account-token":
+				writeJSON(http.StatusOK, map[string]any{"auth": map[string]any{"client_token": "s.root"}})
+			default:
+				writeJSON(http.StatusBadRequest, map[string]any{"errors": []string{"invalid credentials"}})
+			}
+			return
+		}
+		if r.Header.Get("X-Vault-Token") != "s.root" {
+			writeJSON(http.StatusForbidden, map[string]any{"errors": []string{"permission denied"}})
+			return
+		}
+		if numberOfReads != nil {
+			*numberOfReads++
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/gatus":
+			writeJSON(http.StatusOK, map[string]any{
+				"lease_duration": 0,
+				"data": map[string]any{
+					"data":     map[string]any{"webhook-url": "https://hooks.slack.com/services/xxx", "enabled": "true", "port": 8080},
+					"metadata": map[string]any{"version": 3},
+				},
+			})
+		case "/v1/database/creds/gatus":
+			writeJSON(http.StatusOK, map[string]any{
+				"lease_duration": 3600,
+				"data":           map[string]any{"username": "v-gatus", "password": "p@ss:word"},
+			})
+		default:
+			writeJSON(http.StatusNotFound, map[string]any{"errors": []string{}})
+		}
+	}))
+}
+
+func TestConfig_ResolveReferences(t *testing.T) {
+	var numberOfReads int
+	server := newVaultServer(t, &numberOfReads)
+	defer server.Close()
+	cfg := &Config{Address: server.URL, Auth: &AuthConfig{Method: AuthMethodToken, Token: "s.root"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	resolvedYAMLBytes, leaseDuration, err := cfg.ResolveReferences([]byte(`
+url: vault://secret/data/gatus#webhook-url
+enabled: "vault://secret/data/gatus#enabled"
+port: vault://secret/data/gatus#port
+username: vault://database/creds/gatus#username
+password: vault://database/creds/gatus#password
+unrelated: "not a reference"
+`))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	var resolved map[string]any
+	if err = yaml.Unmarshal(resolvedYAMLBytes, &resolved); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	expected := map[string]any{
+		"url":       "https://hooks.slack.com/services/xxx",
+		"enabled":   "true",
+		"port":      "8080",
+		"username":  "v-gatus",
+		"password":  "p@ss:word",
+		"unrelated": "not a reference",
+	}
+	for key, expectedValue := range expected {
+		if resolved[key] != expectedValue {
+			t.Errorf("expected %s to be %#v, got %#v", key, expectedValue, resolved[key])
+		}
+	}
+	if numberOfReads != 2 {
+		t.Errorf("expected each secret to be read only once, got %d reads", numberOfReads)
+	}
+	if leaseDuration != time.Hour {
+		t.Errorf("expected the lease duration to be 1h, got %s", leaseDuration)
+	}
+}
+
+func TestConfig_ResolveReferencesWithAuthMethods(t *testing.T) {
+	server := newVaultServer(t, nil)
+	defer server.Close()
+	serviceAccountTokenFile := t.TempDir() + "/token"
+	if err := os.WriteFile(serviceAccountTokenFile, []byte("service-account-token\n"), 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		name        string
+		auth        *AuthConfig
+		expectedErr string
+	}{
+		{
+			name: "approle",
+			auth: &AuthConfig{Method: AuthMethodAppRole, RoleID: "role", SecretID: "secret"},
+		},
+		{
+			name:        "approle-with-invalid-credentials",
+			auth:        &AuthConfig{Method: AuthMethodAppRole, RoleID: "role", SecretID: "wrong"},
+			expectedErr: "failed to authenticate with vault: vault returned status code 400: invalid credentials",
+		},
+		{
+			name: "kubernetes",
+			auth: &AuthConfig{Method: AuthMethodKubernetes, Role: "gatus", ServiceAccountTokenFile: serviceAccountTokenFile},
+		},
+		{
+			name:        "token-without-permission",
+			auth:        &AuthConfig{Method: AuthMethodToken, Token: "s.other"},
+			expectedErr: "failed to read secret vault://secret/data/gatus#webhook-url: vault returned status code 403: permission denied",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			cfg := &Config{Address: server.URL, Auth: scenario.auth}
+			if err := cfg.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			resolvedYAMLBytes, _, err := cfg.ResolveReferences([]byte("url: vault://secret/data/gatus#webhook-url\n"))
+			if len(scenario.expectedErr) > 0 {
+				if err == nil || err.Error() != scenario.expectedErr {
+					t.Fatalf("expected error %q, got %v", scenario.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if !strings.Contains(string(resolvedYAMLBytes), "https://hooks.slack.com/services/xxx") {
+				t.Errorf("expected the reference to be resolved, got %s", string(resolvedYAMLBytes))
+			}
+		})
+	}
+}
+
+func TestConfig_ResolveReferencesWithInvalidReference(t *testing.T) {
+	server := newVaultServer(t, nil)
+	defer server.Close()
+	cfg := &Config{Address: server.URL, Auth: &AuthConfig{Method: AuthMethodToken, Token: "s.root"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		name        string
+		reference   string
+		expectedErr string
+	}{
+		{
+			name:        "no-field",
+			reference:   "vault://secret/data/gatus",
+			expectedErr: "failed to read secret vault://secret/data/gatus: " + ErrInvalidReference.Error(),
+		},
+		{
+			name:        "unknown-field",
+			reference:   "vault://secret/data/gatus#token",
+			expectedErr: "failed to read secret vault://secret/data/gatus#token: field token not found in secret",
+		},
+		{
+			name:        "unknown-secret",
+			reference:   "vault://secret/data/unknown#token",
+			expectedErr: "failed to read secret vault://secret/data/unknown#token: vault returned status code 404",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			_, _, err := cfg.ResolveReferences([]byte("value: " + scenario.reference + "\n"))
+			if err == nil || err.Error() != scenario.expectedErr {
+				t.Errorf("expected error %q, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestHasReferences(t *testing.T) {
+	if !HasReferences([]byte("url: vault://secret/data/gatus#webhook-url")) {
+		t.Error("expected true, got false")
+	}
+	if HasReferences([]byte("url: https://example.org")) {
+		t.Error("expected false, got true")
+	}
+	if HasReferences([]byte("# The webhook URL could also be read from vault://secret/data/gatus#webhook-url\nurl: https://example.org")) {
+		t.Error("expected references in comments to be ignored")
+	}
+}
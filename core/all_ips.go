@@ -0,0 +1,75 @@
+package core
+
+import (
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrAllIPsWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that isn't of type
+	// HTTP is configured to check all the IPs its hostname resolves to
+	ErrAllIPsWithUnsupportedEndpointType = errors.New("checking all IPs is only supported for HTTP endpoints")
+
+	// ErrAllIPsWithInvalidQuorum is the error with which Gatus will panic if the quorum is negative
+	ErrAllIPsWithInvalidQuorum = errors.New("all-ips quorum must not be negative")
+)
+
+// AllIPs is the configuration for an Endpoint whose request is sent to every IP its hostname resolves to, rather than
+// to a single one
+//
+// For convenience, it can also be configured with a boolean (i.e. `all-ips: true`).
+type AllIPs struct {
+	// Enabled defines whether every IP should be checked
+	//
+	// Only set if the configuration was provided as a boolean. Use AllIPs.IsEnabled() to retrieve the value.
+	Enabled *bool `yaml:"-"`
+
+	// Quorum is the number of IPs that must pass every condition for the result to be successful.
+	// If 0, every IP must pass.
+	Quorum int `yaml:"quorum,omitempty"`
+}
+
+// UnmarshalYAML allows AllIPs to be configured either with a boolean or with a map
+func (a *AllIPs) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var enabled bool
+		if err := value.Decode(&enabled); err != nil {
+			return err
+		}
+		a.Enabled = &enabled
+		return nil
+	}
+	// Use an alias to prevent infinite recursion
+	type allIPsAlias AllIPs
+	return value.Decode((*allIPsAlias)(a))
+}
+
+// IsEnabled returns whether every IP should be checked
+func (a *AllIPs) IsEnabled() bool {
+	if a == nil {
+		return false
+	}
+	if a.Enabled == nil {
+		return true
+	}
+	return *a.Enabled
+}
+
+func (a *AllIPs) validateAndSetDefault() error {
+	if a.Quorum < 0 {
+		return ErrAllIPsWithInvalidQuorum
+	}
+	return nil
+}
+
+// isQuorumReached returns whether enough IPs passed for the result to be successful
+func (a *AllIPs) isQuorumReached(successfulIPs, totalIPs int) bool {
+	if totalIPs == 0 {
+		return false
+	}
+	if a.Quorum == 0 {
+		return successfulIPs == totalIPs
+	}
+	return successfulIPs >= a.Quorum
+}
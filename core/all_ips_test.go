@@ -0,0 +1,145 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestAllIPs_UnmarshalYAML(t *testing.T) {
+	scenarios := []struct {
+		name              string
+		yaml              string
+		expectedIsEnabled bool
+		expectedQuorum    int
+	}{
+		{name: "boolean-true", yaml: "all-ips: true", expectedIsEnabled: true},
+		{name: "boolean-false", yaml: "all-ips: false", expectedIsEnabled: false},
+		{name: "map", yaml: "all-ips:\n  quorum: 2", expectedIsEnabled: true, expectedQuorum: 2},
+		{name: "not-set", yaml: "name: test", expectedIsEnabled: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var endpoint Endpoint
+			if err := yaml.Unmarshal([]byte(scenario.yaml), &endpoint); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if endpoint.AllIPs.IsEnabled() != scenario.expectedIsEnabled {
+				t.Errorf("expected IsEnabled to be %v, got %v", scenario.expectedIsEnabled, endpoint.AllIPs.IsEnabled())
+			}
+			if endpoint.AllIPs != nil && endpoint.AllIPs.Quorum != scenario.expectedQuorum {
+				t.Errorf("expected quorum to be %d, got %d", scenario.expectedQuorum, endpoint.AllIPs.Quorum)
+			}
+		})
+	}
+}
+
+func TestAllIPs_isQuorumReached(t *testing.T) {
+	scenarios := []struct {
+		quorum, successfulIPs, totalIPs int
+		expected                        bool
+	}{
+		{quorum: 0, successfulIPs: 3, totalIPs: 3, expected: true},
+		{quorum: 0, successfulIPs: 2, totalIPs: 3, expected: false},
+		{quorum: 2, successfulIPs: 2, totalIPs: 3, expected: true},
+		{quorum: 2, successfulIPs: 1, totalIPs: 3, expected: false},
+		{quorum: 3, successfulIPs: 2, totalIPs: 2, expected: false},
+		{quorum: 0, successfulIPs: 0, totalIPs: 0, expected: false},
+	}
+	for _, scenario := range scenarios {
+		if actual := (&AllIPs{Quorum: scenario.quorum}).isQuorumReached(scenario.successfulIPs, scenario.totalIPs); actual != scenario.expected {
+			t.Errorf("expected %v for quorum=%d, successfulIPs=%d and totalIPs=%d, got %v", scenario.expected, scenario.quorum, scenario.successfulIPs, scenario.totalIPs, actual)
+		}
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithAllIPs(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		endpoint    Endpoint
+		expectedErr error
+	}{
+		{
+			name:     "http",
+			endpoint: Endpoint{Name: "a", URL: "https://example.org", Conditions: []Condition{"[STATUS] == 200"}, AllIPs: &AllIPs{}},
+		},
+		{
+			name:        "tcp",
+			endpoint:    Endpoint{Name: "a", URL: "tcp://example.org:80", Conditions: []Condition{"[CONNECTED] == true"}, AllIPs: &AllIPs{}},
+			expectedErr: ErrAllIPsWithUnsupportedEndpointType,
+		},
+		{
+			name:        "negative-quorum",
+			endpoint:    Endpoint{Name: "a", URL: "https://example.org", Conditions: []Condition{"[STATUS] == 200"}, AllIPs: &AllIPs{Quorum: -1}},
+			expectedErr: ErrAllIPsWithInvalidQuorum,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.endpoint.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithAllIPs(t *testing.T) {
+	// The Host header must be preserved even though the request is sent to the IP directly
+	var expectedHost string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != expectedHost {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	expectedHost = strings.TrimPrefix(server.URL, "http://")
+	scenarios := []struct {
+		name            string
+		condition       Condition
+		expectedSuccess bool
+		expectedError   string
+	}{
+		{
+			name:            "success",
+			condition:       "[STATUS] == 200",
+			expectedSuccess: true,
+		},
+		{
+			name:            "failure",
+			condition:       "[STATUS] == 201",
+			expectedSuccess: false,
+			expectedError:   "1 of 1 IPs failed: 127.0.0.1",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       "all-ips",
+				URL:        server.URL,
+				Conditions: []Condition{scenario.condition},
+				AllIPs:     &AllIPs{},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if len(result.IPResults) != 1 || result.IPResults[0].IP != "127.0.0.1" || result.IPResults[0].Success != scenario.expectedSuccess {
+				t.Errorf("expected a single IP result for 127.0.0.1, got %v", result.IPResults)
+			}
+			if len(result.ConditionResults) != 1 || result.ConditionResults[0].Success != scenario.expectedSuccess {
+				t.Errorf("expected condition results of the IP to be in the result, got %v", result.ConditionResults)
+			}
+			if len(scenario.expectedError) > 0 && (len(result.Errors) != 1 || result.Errors[0] != scenario.expectedError) {
+				t.Errorf("expected error %q, got %v", scenario.expectedError, result.Errors)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package core
+
+import "time"
+
+// Annotation is a note attached to an endpoint at a specific time, such as what responders did during an incident
+//
+// Unlike events, annotations aren't pruned when the maximum number of events is reached.
+type Annotation struct {
+	// Timestamp is the moment at which the annotation was created
+	Timestamp time.Time `json:"timestamp"`
+
+	// Note is the content of the annotation (e.g. "rolling back deploy")
+	Note string `json:"note"`
+
+	// Author is the name of whoever created the annotation, if specified
+	Author string `json:"author,omitempty"`
+}
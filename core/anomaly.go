@@ -0,0 +1,113 @@
+package core
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+const (
+	// ResponseTimeBaselineSize is the maximum number of past results used to compute the response time baseline of an
+	// endpoint
+	ResponseTimeBaselineSize = 50
+
+	// MinimumResponseTimeBaselineSize is the minimum number of past results required to compute the response time
+	// baseline of an endpoint. Until that many results are available, response times are never considered anomalous.
+	MinimumResponseTimeBaselineSize = 10
+
+	// ResponseTimeAnomalyThreshold is the number of standard deviations above the mean of the baseline past which a
+	// response time is considered anomalous
+	ResponseTimeAnomalyThreshold = 3.0
+
+	// minimumResponseTimeStandardDeviation is the lowest standard deviation used to compute z-scores, which prevents
+	// a baseline of identical response times from flagging every variation, no matter how small, as anomalous
+	minimumResponseTimeStandardDeviation = time.Millisecond
+)
+
+// ResponseTimeBaseline is the mean and standard deviation of the recent response times of an endpoint
+type ResponseTimeBaseline struct {
+	// Mean is the average of the response times of the baseline
+	Mean time.Duration `json:"mean"`
+
+	// StandardDeviation is the standard deviation of the response times of the baseline
+	StandardDeviation time.Duration `json:"standardDeviation"`
+
+	// Samples is the number of response times used to compute the baseline
+	Samples int `json:"samples"`
+}
+
+// NewResponseTimeBaseline computes the baseline of the response times passed
+//
+// Returns nil if there are fewer than MinimumResponseTimeBaselineSize response times.
+func NewResponseTimeBaseline(responseTimes []time.Duration) *ResponseTimeBaseline {
+	if len(responseTimes) < MinimumResponseTimeBaselineSize {
+		return nil
+	}
+	var sum float64
+	for _, responseTime := range responseTimes {
+		sum += float64(responseTime)
+	}
+	mean := sum / float64(len(responseTimes))
+	var sumOfSquaredDeviations float64
+	for _, responseTime := range responseTimes {
+		sumOfSquaredDeviations += math.Pow(float64(responseTime)-mean, 2)
+	}
+	return &ResponseTimeBaseline{
+		Mean:              time.Duration(mean),
+		StandardDeviation: time.Duration(math.Sqrt(sumOfSquaredDeviations / float64(len(responseTimes)))),
+		Samples:           len(responseTimes),
+	}
+}
+
+// ZScore returns the number of standard deviations by which the response time passed is above the mean of the baseline
+func (baseline *ResponseTimeBaseline) ZScore(responseTime time.Duration) float64 {
+	standardDeviation := baseline.StandardDeviation
+	if standardDeviation < minimumResponseTimeStandardDeviation {
+		standardDeviation = minimumResponseTimeStandardDeviation
+	}
+	return float64(responseTime-baseline.Mean) / float64(standardDeviation)
+}
+
+// ResponseTimeAnomaly is the detail of the comparison of a response time with the baseline of the endpoint
+type ResponseTimeAnomaly struct {
+	// Baseline is the baseline the response time was compared with
+	Baseline *ResponseTimeBaseline `json:"baseline"`
+
+	// ZScore is the number of standard deviations by which the response time is above the mean of the baseline
+	ZScore float64 `json:"zScore"`
+
+	// Anomalous is whether the ZScore is above ResponseTimeAnomalyThreshold
+	Anomalous bool `json:"anomalous"`
+}
+
+// isAnomalyCheck returns whether the elements of a condition are the ResponseTimePlaceholder compared with
+// AnomalousValue
+func isAnomalyCheck(elements []string) bool {
+	return len(elements) == 2 && strings.TrimSpace(elements[0]) == ResponseTimePlaceholder && strings.TrimSpace(elements[1]) == AnomalousValue
+}
+
+// resolveResponseTimeAnomaly compares the response time of the result with the baseline of the endpoint
+//
+// Returns nil if no baseline is available yet.
+func resolveResponseTimeAnomaly(result *Result) *ResponseTimeAnomaly {
+	if result.responseTimeBaseline == nil {
+		return nil
+	}
+	zScore := result.responseTimeBaseline.ZScore(result.Duration)
+	result.ResponseTimeAnomaly = &ResponseTimeAnomaly{
+		Baseline:  result.responseTimeBaseline,
+		ZScore:    zScore,
+		Anomalous: zScore > ResponseTimeAnomalyThreshold,
+	}
+	return result.ResponseTimeAnomaly
+}
+
+// prettifyAnomalyCheck returns an anomaly check with the response time, its z-score and the baseline it was compared
+// with, all in milliseconds
+func prettifyAnomalyCheck(result *Result, anomaly *ResponseTimeAnomaly, operator string) string {
+	if anomaly == nil {
+		return fmt.Sprintf("%s (%d; no baseline) %s %s", ResponseTimePlaceholder, result.Duration.Milliseconds(), operator, AnomalousValue)
+	}
+	return fmt.Sprintf("%s (%d; z-score=%.2f; baseline=%d±%d) %s %s", ResponseTimePlaceholder, result.Duration.Milliseconds(), anomaly.ZScore, anomaly.Baseline.Mean.Milliseconds(), anomaly.Baseline.StandardDeviation.Milliseconds(), operator, AnomalousValue)
+}
@@ -0,0 +1,82 @@
+package core
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestNewResponseTimeBaseline(t *testing.T) {
+	if baseline := NewResponseTimeBaseline([]time.Duration{time.Second, time.Second}); baseline != nil {
+		t.Error("expected no baseline with fewer than MinimumResponseTimeBaselineSize response times, got", baseline)
+	}
+	var responseTimes []time.Duration
+	for i := 0; i < MinimumResponseTimeBaselineSize; i++ {
+		if i%2 == 0 {
+			responseTimes = append(responseTimes, 150*time.Millisecond)
+		} else {
+			responseTimes = append(responseTimes, 250*time.Millisecond)
+		}
+	}
+	baseline := NewResponseTimeBaseline(responseTimes)
+	if baseline == nil {
+		t.Fatal("expected baseline, got nil")
+	}
+	if baseline.Mean != 200*time.Millisecond {
+		t.Errorf("expected mean to be %s, got %s", 200*time.Millisecond, baseline.Mean)
+	}
+	if baseline.StandardDeviation != 50*time.Millisecond {
+		t.Errorf("expected standard deviation to be %s, got %s", 50*time.Millisecond, baseline.StandardDeviation)
+	}
+	if baseline.Samples != MinimumResponseTimeBaselineSize {
+		t.Errorf("expected %d samples, got %d", MinimumResponseTimeBaselineSize, baseline.Samples)
+	}
+}
+
+func TestResponseTimeBaseline_ZScore(t *testing.T) {
+	baseline := &ResponseTimeBaseline{Mean: 200 * time.Millisecond, StandardDeviation: 50 * time.Millisecond, Samples: 50}
+	if zScore := baseline.ZScore(300 * time.Millisecond); zScore != 2 {
+		t.Errorf("expected z-score to be 2, got %f", zScore)
+	}
+	if zScore := baseline.ZScore(100 * time.Millisecond); zScore != -2 {
+		t.Errorf("expected z-score to be -2, got %f", zScore)
+	}
+	// A baseline of identical response times must not cause a division by zero
+	baseline = &ResponseTimeBaseline{Mean: 200 * time.Millisecond, Samples: 50}
+	if zScore := baseline.ZScore(205 * time.Millisecond); zScore != 5 {
+		t.Errorf("expected z-score to be 5, got %f", zScore)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithResponseTimeAnomaly(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("OK"))}
+	})})
+	endpoint := Endpoint{
+		Name:       "anomaly",
+		URL:        "https://twin.sh/health",
+		Conditions: []Condition{"[RESPONSE_TIME] != anomalous"},
+	}
+	if !endpoint.NeedsResponseTimeBaseline() {
+		t.Fatal("endpoint should've needed the response time baseline")
+	}
+	// A negative mean guarantees that the response time will be anomalous
+	endpoint.SetResponseTimeBaseline(&ResponseTimeBaseline{Mean: -time.Hour, StandardDeviation: time.Millisecond, Samples: 50})
+	endpoint.ValidateAndSetDefaults()
+	result := endpoint.EvaluateHealth()
+	if result.Success {
+		t.Error("result should've been a failure")
+	}
+	if result.ResponseTimeAnomaly == nil {
+		t.Fatal("result should've had the response time anomaly detail")
+	}
+	if !result.ResponseTimeAnomaly.Anomalous {
+		t.Error("response time should've been anomalous")
+	}
+}
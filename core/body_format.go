@@ -0,0 +1,43 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+// isJSON returns whether the body passed is a valid JSON document
+func isJSON(body []byte) bool {
+	return json.Valid(bytes.TrimSpace(body))
+}
+
+// isXML returns whether the body passed is a well-formed XML document, which must have exactly one root element
+//
+// Since the decoder is strict, most HTML documents (e.g. error pages returned by a proxy) are not considered as XML,
+// as they usually have unclosed elements such as <br> or <meta>.
+func isXML(body []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	var depth, numberOfRootElements int
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return errors.Is(err, io.EOF) && depth == 0 && numberOfRootElements == 1
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				numberOfRootElements++
+			}
+			depth++
+		case xml.EndElement:
+			depth--
+		case xml.CharData:
+			// Only whitespace is allowed outside the root element
+			if depth == 0 && len(bytes.TrimSpace(t)) > 0 {
+				return false
+			}
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import "testing"
+
+func TestIsJSON(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		body     string
+		expected bool
+	}{
+		{name: "object", body: `{"status":"UP"}`, expected: true},
+		{name: "array", body: `[1, 2, 3]`, expected: true},
+		{name: "string", body: `"UP"`, expected: true},
+		{name: "surrounded-by-whitespace", body: "\n {} \n", expected: true},
+		{name: "truncated", body: `{"status":"UP"`, expected: false},
+		{name: "plain-text", body: `UP`, expected: false},
+		{name: "html", body: `<html><body>Bad Gateway</body></html>`, expected: false},
+		{name: "empty", body: ``, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := isJSON([]byte(scenario.body)); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsXML(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		body     string
+		expected bool
+	}{
+		{name: "element", body: `<status>UP</status>`, expected: true},
+		{name: "with-declaration", body: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<health><status>UP</status></health>\n", expected: true},
+		{name: "self-closing", body: `<status value="UP"/>`, expected: true},
+		{name: "unclosed-element", body: `<health><status>UP</status>`, expected: false},
+		{name: "mismatched-element", body: `<health><status>UP</health></status>`, expected: false},
+		{name: "multiple-root-elements", body: `<status>UP</status><status>DOWN</status>`, expected: false},
+		{name: "text-outside-root-element", body: `<status>UP</status> trailing`, expected: false},
+		{name: "html-with-unclosed-elements", body: `<!DOCTYPE html><html><head><meta charset="utf-8"></head><body>Bad Gateway<br></body></html>`, expected: false},
+		{name: "json", body: `{"status":"UP"}`, expected: false},
+		{name: "plain-text", body: `UP`, expected: false},
+		{name: "empty", body: ``, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := isXML([]byte(scenario.body)); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
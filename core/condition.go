@@ -3,6 +3,8 @@ package core
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
@@ -18,11 +20,28 @@ const (
 	// Values that could replace the placeholder: 200, 404, 500, ...
 	StatusPlaceholder = "[STATUS]"
 
+	// StatusClassPlaceholder is a placeholder for the class of a HTTP status.
+	//
+	// Values that could replace the placeholder: 1xx, 2xx, 3xx, 4xx, 5xx
+	StatusClassPlaceholder = "[STATUS_CLASS]"
+
 	// IPPlaceholder is a placeholder for an IP.
 	//
 	// Values that could replace the placeholder: 127.0.0.1, 10.0.0.1, ...
 	IPPlaceholder = "[IP]"
 
+	// IPVersionPlaceholder is a placeholder for the version of the IP of the connection the request was sent through,
+	// or of the IP resolved from the URL if the connection doesn't expose it.
+	//
+	// Values that could replace the placeholder: 4, 6
+	IPVersionPlaceholder = "[IP_VERSION]"
+
+	// ReverseDNSPlaceholder is a placeholder for the name that the IP of the connection resolves to through a reverse
+	// DNS (PTR) lookup, without the trailing dot, or an empty string if it has no PTR record.
+	//
+	// Values that could replace the placeholder: host-1.example.internal, ...
+	ReverseDNSPlaceholder = "[REVERSE_DNS]"
+
 	// DNSRCodePlaceholder is a placeholder for DNS_RCODE
 	//
 	// Values that could replace the placeholder: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
@@ -48,8 +67,144 @@ const (
 	// Values that could replace the placeholder: 4461677039 (~52 days)
 	CertificateExpirationPlaceholder = "[CERTIFICATE_EXPIRATION]"
 
+	// CertificateChainExpirationPlaceholder is a placeholder for the duration before the first of the certificates
+	// presented by the server, including the intermediates, expires, in milliseconds.
+	//
+	// Values that could replace the placeholder: 4461677039 (~52 days)
+	CertificateChainExpirationPlaceholder = "[CERTIFICATE_CHAIN_EXPIRATION]"
+
+	// CertificateFingerprintPlaceholder is a placeholder for the hex-encoded SHA-256 of the certificate presented by
+	// the server, which can be pinned to detect unexpected certificate changes.
+	//
+	// Values that could replace the placeholder: 3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5, ...
+	CertificateFingerprintPlaceholder = "[CERTIFICATE_FINGERPRINT]"
+
+	// CertificatePublicKeyFingerprintPlaceholder is a placeholder for the hex-encoded SHA-256 of the public key
+	// (SubjectPublicKeyInfo) of the certificate presented by the server, which only changes if the key is rotated.
+	//
+	// Values that could replace the placeholder: 8e0a8a2ab1d2b1f4f8f7c3c5e0a3a5e0b0e5c3f2a1f4d4c9e2c9f0f1a8a9b2c3, ...
+	CertificatePublicKeyFingerprintPlaceholder = "[CERTIFICATE_PUBLIC_KEY_FINGERPRINT]"
+
+	// CertificateKeySizePlaceholder is a placeholder for the size in bits of the public key of the certificate presented
+	// by the server, which is 0 if the type of the key is not supported.
+	//
+	// Values that could replace the placeholder: 2048, 4096 (RSA), 256, 384 (ECDSA), ...
+	CertificateKeySizePlaceholder = "[CERTIFICATE_KEY_SIZE]"
+
+	// CertificateSignatureAlgorithmPlaceholder is a placeholder for the algorithm with which the certificate presented
+	// by the server is signed.
+	//
+	// Values that could replace the placeholder: SHA256-RSA, SHA384-RSA, ECDSA-SHA256, SHA256-RSAPSS, SHA1-RSA, ...
+	CertificateSignatureAlgorithmPlaceholder = "[CERTIFICATE_SIG_ALGORITHM]"
+
+	// TLSVersionPlaceholder is a placeholder for the version of TLS negotiated with the server
+	//
+	// Values that could replace the placeholder: TLS 1.0, TLS 1.1, TLS 1.2, TLS 1.3
+	TLSVersionPlaceholder = "[TLS_VERSION]"
+
+	// TLSCipherPlaceholder is a placeholder for the name of the cipher suite negotiated with the server
+	//
+	// Values that could replace the placeholder: TLS_AES_128_GCM_SHA256, TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256, ...
+	TLSCipherPlaceholder = "[TLS_CIPHER]"
+
 	// DomainExpirationPlaceholder is a placeholder for the duration before the domain expires, in milliseconds.
 	DomainExpirationPlaceholder = "[DOMAIN_EXPIRATION]"
+
+	// BodySHA256Placeholder is a placeholder for the hex-encoded SHA-256 of the entire response body
+	//
+	// Values that could replace the placeholder: e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855, ...
+	BodySHA256Placeholder = "[BODY_SHA256]"
+
+	// BodyIsJSONPlaceholder is a placeholder for whether the response body is a valid JSON document
+	//
+	// Values that could replace the placeholder: true, false
+	BodyIsJSONPlaceholder = "[BODY_IS_JSON]"
+
+	// BodyIsXMLPlaceholder is a placeholder for whether the response body is a well-formed XML document
+	//
+	// Values that could replace the placeholder: true, false
+	BodyIsXMLPlaceholder = "[BODY_IS_XML]"
+
+	// BodyDecodedPlaceholder is a placeholder for whether the response body was read and decoded according to its
+	// Content-Encoding without error, which catches responses advertising a compression their body doesn't match.
+	//
+	// Values that could replace the placeholder: true, false
+	BodyDecodedPlaceholder = "[BODY_DECODED]"
+
+	// ContentEncodingPlaceholder is a placeholder for the Content-Encoding of the response, or an empty string if the
+	// response isn't encoded.
+	//
+	// Values that could replace the placeholder: gzip, br, deflate, zstd, ...
+	ContentEncodingPlaceholder = "[CONTENT_ENCODING]"
+
+	// GraphQLErrorsPlaceholder is a placeholder for the number of errors returned in the "errors" array of a GraphQL
+	// response.
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	GraphQLErrorsPlaceholder = "[GRAPHQL_ERRORS]"
+
+	// DNSAnswersPlaceholder is a placeholder for the values of every record in the answer section of a DNS query,
+	// separated by commas. Meant to be used with HasOperator.
+	//
+	// Values that could replace the placeholder: 203.0.113.10, 203.0.113.10,203.0.113.11, ...
+	DNSAnswersPlaceholder = "[DNS_ANSWERS]"
+
+	// ChangedPlaceholder is a placeholder for whether the resource has changed since the previous check, which is the
+	// case unless the server replied to the conditional request with 304 Not Modified.
+	//
+	// Values that could replace the placeholder: true, false
+	ChangedPlaceholder = "[CHANGED]"
+
+	// JWTValidPlaceholder is a placeholder for whether the JSON Web Token of the response has a valid signature and is
+	// within its validity period (exp and nbf claims). Requires the endpoint to have a JWT configuration.
+	//
+	// Values that could replace the placeholder: true, false
+	JWTValidPlaceholder = "[JWT_VALID]"
+
+	// JWTPlaceholder is a placeholder for the claims of the JSON Web Token of the response, which are accessed the
+	// same way as the BodyPlaceholder (e.g. [JWT].iss). Requires the endpoint to have a JWT configuration, and only
+	// resolves if the signature of the token is valid.
+	//
+	// Values that could replace the placeholder: {"iss":"https://auth.example.org","exp":1700000000}, ...
+	JWTPlaceholder = "[JWT]"
+
+	// HeaderPlaceholder is a placeholder for the value of a header of the response, whose name follows a dot (e.g.
+	// [HEADER].Content-Type). If the header has several values, they're joined with a comma. HTTP only.
+	//
+	// Combined with HasFunctionPrefix, it checks whether the response has the header: has([HEADER].Strict-Transport-Security)
+	// Combined with LengthFunctionPrefix and no header name, it resolves into the number of headers: len([HEADER])
+	//
+	// Values that could replace the placeholder: text/html; charset=utf-8, max-age=31536000, ...
+	HeaderPlaceholder = "[HEADER]"
+
+	// ExitCodePlaceholder is a placeholder for the exit code of the command of an endpoint of type EXEC, which is -1
+	// if the command couldn't be executed or was killed.
+	//
+	// Values that could replace the placeholder: 0, 1, 2, ...
+	ExitCodePlaceholder = "[EXIT_CODE]"
+
+	// SSHVersionPlaceholder is a placeholder for the version of the SSH protocol advertised by the server in its
+	// identification string. SSH only.
+	//
+	// Values that could replace the placeholder: 2.0, 1.99
+	SSHVersionPlaceholder = "[SSH_VERSION]"
+)
+
+// Operators
+const (
+	// HasOperator is the operator used to check whether a list contains a value
+	//
+	// Works with the DNSAnswersPlaceholder and with JSONPaths of the BodyPlaceholder that lead to an array.
+	//
+	// Usage: [DNS_ANSWERS] has 203.0.113.10, [BODY].roles has admin, [BODY].items has 5
+	HasOperator = "has"
+
+	// BetweenOperator is the operator used to check whether a numerical value is within a range, bounds included
+	//
+	// Durations are converted to milliseconds, like with the other numerical operators.
+	//
+	// Usage: [RESPONSE_TIME] between 50 100, [BODY].temperature between 18 24, [CERTIFICATE_EXPIRATION] between 24h 720h
+	BetweenOperator = "between"
 )
 
 // Functions
@@ -80,6 +235,24 @@ const (
 
 // Other constants
 const (
+	// AbsentValue is the value that a JSONPath of the BodyPlaceholder can be compared with to check whether said path
+	// does not exist
+	//
+	// Usage: [BODY].errors == absent, [BODY].data.id != absent
+	AbsentValue = "absent"
+
+	// AnomalousValue is the value that the ResponseTimePlaceholder can be compared with to check whether the response
+	// time is statistically unusual compared to the recent response times of the endpoint
+	//
+	// Usage: [RESPONSE_TIME] != anomalous
+	AnomalousValue = "anomalous"
+
+	// IncreasingValue is the value that the ResponseTimePlaceholder can be compared with to check whether the response
+	// time increased at every one of the recent checks of the endpoint. See ResponseTimeTrendConfig.
+	//
+	// Usage: [RESPONSE_TIME] != increasing
+	IncreasingValue = "increasing"
+
 	// InvalidConditionElementSuffix is the suffix that will be appended to an invalid condition
 	InvalidConditionElementSuffix = "(INVALID)"
 
@@ -89,6 +262,13 @@ const (
 	// This is only used for aesthetic purposes; it does not influence whether the condition evaluation results in a
 	// success or a failure
 	maximumLengthBeforeTruncatingWhenComparedWithPattern = 25
+
+	// maximumLengthBeforeTruncatingList is the maximum length the resolved list of a condition using HasOperator can
+	// have when the condition is displayed.
+	//
+	// This is only used for aesthetic purposes; it does not influence whether the condition evaluation results in a
+	// success or a failure
+	maximumLengthBeforeTruncatingList = 100
 )
 
 // Condition is a condition that needs to be met in order for an Endpoint to be considered healthy.
@@ -109,7 +289,45 @@ func (c Condition) evaluate(result *Result, dontResolveFailedConditions bool) bo
 	condition := string(c)
 	success := false
 	conditionToDisplay := condition
-	if strings.Contains(condition, " == ") {
+	if strings.Contains(condition, " == ") && isAbsenceCheck(strings.Split(condition, " == ")) {
+		element := strings.TrimSpace(strings.Split(condition, " == ")[0])
+		isPresent, resolvedElement := resolvePresence(element, result)
+		success = !isPresent
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyAbsenceCheck(element, resolvedElement, "==")
+		}
+	} else if strings.Contains(condition, " != ") && isAbsenceCheck(strings.Split(condition, " != ")) {
+		element := strings.TrimSpace(strings.Split(condition, " != ")[0])
+		isPresent, resolvedElement := resolvePresence(element, result)
+		success = isPresent
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyAbsenceCheck(element, resolvedElement, "!=")
+		}
+	} else if strings.Contains(condition, " == ") && isAnomalyCheck(strings.Split(condition, " == ")) {
+		anomaly := resolveResponseTimeAnomaly(result)
+		success = anomaly != nil && anomaly.Anomalous
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyAnomalyCheck(result, anomaly, "==")
+		}
+	} else if strings.Contains(condition, " != ") && isAnomalyCheck(strings.Split(condition, " != ")) {
+		anomaly := resolveResponseTimeAnomaly(result)
+		success = anomaly == nil || !anomaly.Anomalous
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyAnomalyCheck(result, anomaly, "!=")
+		}
+	} else if strings.Contains(condition, " == ") && isTrendCheck(strings.Split(condition, " == ")) {
+		trend := resolveResponseTimeTrend(result)
+		success = trend != nil && trend.Increasing
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyTrendCheck(result, trend, "==")
+		}
+	} else if strings.Contains(condition, " != ") && isTrendCheck(strings.Split(condition, " != ")) {
+		trend := resolveResponseTimeTrend(result)
+		success = trend == nil || !trend.Increasing
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyTrendCheck(result, trend, "!=")
+		}
+	} else if strings.Contains(condition, " == ") {
 		parameters, resolvedParameters := sanitizeAndResolve(strings.Split(condition, " == "), result)
 		success = isEqual(resolvedParameters[0], resolvedParameters[1])
 		if !success && !dontResolveFailedConditions {
@@ -121,30 +339,79 @@ func (c Condition) evaluate(result *Result, dontResolveFailedConditions bool) bo
 		if !success && !dontResolveFailedConditions {
 			conditionToDisplay = prettify(parameters, resolvedParameters, "!=")
 		}
+	} else if strings.Contains(condition, " ~= ") {
+		parameters, resolvedParameters := sanitizeAndResolve(strings.Split(condition, " ~= "), result)
+		success = strings.EqualFold(resolvedParameters[0], resolvedParameters[1])
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettify(parameters, resolvedParameters, "~=")
+		}
 	} else if strings.Contains(condition, " <= ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " <= "), result)
-		success = resolvedParameters[0] <= resolvedParameters[1]
+		parameters, resolvedParameters, err := sanitizeAndResolveNumerical(strings.Split(condition, " <= "), result)
+		if err != nil {
+			result.AddError(fmt.Sprintf("invalid condition: %s: %s", condition, err.Error()))
+		}
+		success = err == nil && resolvedParameters[0] <= resolvedParameters[1]
 		if !success && !dontResolveFailedConditions {
 			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, "<=")
 		}
 	} else if strings.Contains(condition, " >= ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " >= "), result)
-		success = resolvedParameters[0] >= resolvedParameters[1]
+		parameters, resolvedParameters, err := sanitizeAndResolveNumerical(strings.Split(condition, " >= "), result)
+		if err != nil {
+			result.AddError(fmt.Sprintf("invalid condition: %s: %s", condition, err.Error()))
+		}
+		success = err == nil && resolvedParameters[0] >= resolvedParameters[1]
 		if !success && !dontResolveFailedConditions {
 			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, ">=")
 		}
 	} else if strings.Contains(condition, " > ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " > "), result)
-		success = resolvedParameters[0] > resolvedParameters[1]
+		parameters, resolvedParameters, err := sanitizeAndResolveNumerical(strings.Split(condition, " > "), result)
+		if err != nil {
+			result.AddError(fmt.Sprintf("invalid condition: %s: %s", condition, err.Error()))
+		}
+		success = err == nil && resolvedParameters[0] > resolvedParameters[1]
 		if !success && !dontResolveFailedConditions {
 			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, ">")
 		}
 	} else if strings.Contains(condition, " < ") {
-		parameters, resolvedParameters := sanitizeAndResolveNumerical(strings.Split(condition, " < "), result)
-		success = resolvedParameters[0] < resolvedParameters[1]
+		parameters, resolvedParameters, err := sanitizeAndResolveNumerical(strings.Split(condition, " < "), result)
+		if err != nil {
+			result.AddError(fmt.Sprintf("invalid condition: %s: %s", condition, err.Error()))
+		}
+		success = err == nil && resolvedParameters[0] < resolvedParameters[1]
 		if !success && !dontResolveFailedConditions {
 			conditionToDisplay = prettifyNumericalParameters(parameters, resolvedParameters, "<")
 		}
+	} else if strings.Contains(condition, " "+BetweenOperator+" ") {
+		elements := strings.Split(condition, " "+BetweenOperator+" ")
+		element := strings.TrimSpace(elements[0])
+		if len(elements) != 2 {
+			result.AddError(fmt.Sprintf("invalid condition: %s", condition))
+			return false
+		}
+		lowerBound, upperBound, err := parseRange(elements[1])
+		if err != nil {
+			result.AddError(fmt.Sprintf("invalid condition: %s: %s", condition, err.Error()))
+			return false
+		}
+		_, resolvedElements := sanitizeAndResolve([]string{element}, result)
+		value, isNumerical := parseNumericalValue(resolvedElements[0])
+		success = isNumerical && value >= lowerBound && value <= upperBound
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyRangeCheck(element, resolvedElements[0], strings.TrimSpace(elements[1]))
+		}
+	} else if strings.Contains(condition, " "+HasOperator+" ") {
+		elements := strings.Split(condition, " "+HasOperator+" ")
+		element := strings.TrimSpace(elements[0])
+		if !isListElement(element) {
+			result.AddError(fmt.Sprintf("invalid condition: %s", condition))
+			return false
+		}
+		list, err := resolveList(element, result)
+		_, resolvedValue := sanitizeAndResolve(elements[1:], result)
+		success = err == nil && containsElement(list, resolvedValue[0])
+		if !success && !dontResolveFailedConditions {
+			conditionToDisplay = prettifyListCheck(element, list, err, strings.TrimSpace(elements[1]), resolvedValue[0])
+		}
 	} else {
 		result.AddError(fmt.Sprintf("invalid condition: %s", condition))
 		return false
@@ -152,7 +419,7 @@ func (c Condition) evaluate(result *Result, dontResolveFailedConditions bool) bo
 	if !success {
 		//log.Printf("[Condition][evaluate] Condition '%s' did not succeed because '%s' is false", condition, condition)
 	}
-	result.ConditionResults = append(result.ConditionResults, &ConditionResult{Condition: conditionToDisplay, Success: success})
+	result.ConditionResults = append(result.ConditionResults, &ConditionResult{Condition: conditionToDisplay, Success: success, condition: c})
 	return success
 }
 
@@ -162,18 +429,87 @@ func (c Condition) hasBodyPlaceholder() bool {
 	return strings.Contains(string(c), BodyPlaceholder)
 }
 
+// hasJWTPlaceholder checks whether the condition has a JWTValidPlaceholder or a JWTPlaceholder
+func (c Condition) hasJWTPlaceholder() bool {
+	return strings.Contains(string(c), JWTValidPlaceholder) || strings.Contains(string(c), JWTPlaceholder)
+}
+
 // hasDomainExpirationPlaceholder checks whether the condition has a DomainExpirationPlaceholder
 // Used for determining whether a whois operation is necessary
 func (c Condition) hasDomainExpirationPlaceholder() bool {
 	return strings.Contains(string(c), DomainExpirationPlaceholder)
 }
 
+// hasGraphQLErrorsPlaceholder checks whether the condition has a GraphQLErrorsPlaceholder
+// Used for determining whether the response body should be read or not
+func (c Condition) hasGraphQLErrorsPlaceholder() bool {
+	return strings.Contains(string(c), GraphQLErrorsPlaceholder)
+}
+
+// hasBodyFormatPlaceholder checks whether the condition has a BodyIsJSONPlaceholder or a BodyIsXMLPlaceholder
+// Used for determining whether the response body should be read or not
+func (c Condition) hasBodyFormatPlaceholder() bool {
+	return strings.Contains(string(c), BodyIsJSONPlaceholder) || strings.Contains(string(c), BodyIsXMLPlaceholder)
+}
+
+// hasBodySHA256Placeholder checks whether the condition has a BodySHA256Placeholder
+// Used for determining whether the response body should be hashed or not
+func (c Condition) hasBodySHA256Placeholder() bool {
+	return strings.Contains(string(c), BodySHA256Placeholder)
+}
+
+// hasBodyDecodedPlaceholder checks whether the condition has a BodyDecodedPlaceholder
+// Used for determining whether the response body should be read or not
+func (c Condition) hasBodyDecodedPlaceholder() bool {
+	return strings.Contains(string(c), BodyDecodedPlaceholder)
+}
+
+// hasChangedPlaceholder checks whether the condition has a ChangedPlaceholder
+// Used for determining whether a conditional request should be sent or not
+func (c Condition) hasChangedPlaceholder() bool {
+	return strings.Contains(string(c), ChangedPlaceholder)
+}
+
+// matchesScope checks whether the condition is the condition or contains the placeholder an alert is scoped to
+func (c Condition) matchesScope(scope string) bool {
+	if string(c) == scope {
+		return true
+	}
+	return strings.HasPrefix(scope, "[") && !strings.Contains(scope, " ") && strings.Contains(string(c), scope)
+}
+
+// isAnomalyCheck checks whether the condition compares the ResponseTimePlaceholder with AnomalousValue
+// Used for determining whether the response time baseline of the endpoint must be computed
+func (c Condition) isAnomalyCheck() bool {
+	condition := string(c)
+	return isAnomalyCheck(strings.Split(condition, " == ")) || isAnomalyCheck(strings.Split(condition, " != "))
+}
+
+// isTrendCheck checks whether the condition compares the ResponseTimePlaceholder with IncreasingValue
+// Used for determining whether the previous response times of the endpoint must be retrieved
+func (c Condition) isTrendCheck() bool {
+	condition := string(c)
+	return isTrendCheck(strings.Split(condition, " == ")) || isTrendCheck(strings.Split(condition, " != "))
+}
+
 // hasIPPlaceholder checks whether the condition has an IPPlaceholder
 // Used for determining whether an IP lookup is necessary
 func (c Condition) hasIPPlaceholder() bool {
 	return strings.Contains(string(c), IPPlaceholder)
 }
 
+// hasConnectedIPPlaceholder checks whether the condition has a placeholder resolved from the IP of the connection, i.e.
+// IPVersionPlaceholder or ReverseDNSPlaceholder
+func (c Condition) hasConnectedIPPlaceholder() bool {
+	return strings.Contains(string(c), IPVersionPlaceholder) || c.hasReverseDNSPlaceholder()
+}
+
+// hasReverseDNSPlaceholder checks whether the condition has a ReverseDNSPlaceholder
+// Used for determining whether a reverse DNS lookup is necessary
+func (c Condition) hasReverseDNSPlaceholder() bool {
+	return strings.Contains(string(c), ReverseDNSPlaceholder)
+}
+
 // isEqual compares two strings.
 //
 // Supports the "pat" and the "any" functions.
@@ -227,6 +563,140 @@ func isEqual(first, second string) bool {
 	return first == second
 }
 
+// isListElement returns whether the element of a condition using HasOperator refers to a list
+func isListElement(element string) bool {
+	return strings.ToUpper(element) == DNSAnswersPlaceholder || strings.HasPrefix(element, BodyPlaceholder)
+}
+
+// resolveList returns the list that the element of a condition using HasOperator refers to
+//
+// If the element is a JSONPath of the BodyPlaceholder, an error is returned if the path doesn't lead to an array.
+func resolveList(element string, result *Result) ([]string, error) {
+	if strings.ToUpper(element) == DNSAnswersPlaceholder {
+		return result.DNSAnswers, nil
+	}
+	return jsonpath.EvalArray(strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), "."), result.Body)
+}
+
+// containsElement returns whether one of the elements of a list is equal to the value passed
+//
+// Elements and values that are both numbers are compared numerically (e.g. 5 is equal to 5.0). Otherwise, because the
+// comparison is made using isEqual, the value may use the "pat" and the "any" functions.
+func containsElement(list []string, value string) bool {
+	numericalValue, valueErr := strconv.ParseFloat(value, 64)
+	for _, element := range list {
+		if isEqual(element, value) {
+			return true
+		}
+		if valueErr == nil {
+			if numericalElement, err := strconv.ParseFloat(element, 64); err == nil && numericalElement == numericalValue {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prettifyListCheck returns a condition using HasOperator with the content of the list between parentheses
+//
+// The content of the list is truncated if it is too long, and if the list couldn't be resolved, the element is marked
+// as invalid.
+func prettifyListCheck(element string, list []string, err error, value, resolvedValue string) string {
+	if value != resolvedValue {
+		value += " (" + resolvedValue + ")"
+	}
+	if err != nil {
+		return element + " " + InvalidConditionElementSuffix + " " + HasOperator + " " + value
+	}
+	resolvedList := strings.Join(list, ",")
+	if strings.HasPrefix(element, BodyPlaceholder) {
+		resolvedList = "[" + resolvedList + "]"
+	}
+	if len(resolvedList) > maximumLengthBeforeTruncatingList {
+		resolvedList = fmt.Sprintf("%.100s...(truncated)", resolvedList)
+	}
+	return element + " (" + resolvedList + ") " + HasOperator + " " + value
+}
+
+// isAbsenceCheck returns whether the elements of a condition are a JSONPath of the BodyPlaceholder compared with
+// AbsentValue
+func isAbsenceCheck(elements []string) bool {
+	return len(elements) == 2 && strings.HasPrefix(strings.TrimSpace(elements[0]), BodyPlaceholder) && strings.TrimSpace(elements[1]) == AbsentValue
+}
+
+// resolvePresence returns whether the JSONPath of the BodyPlaceholder passed exists in the body of the result and, if
+// it does, the value at said path. If no path is specified, the body is considered present if it is not empty.
+func resolvePresence(element string, result *Result) (bool, string) {
+	path := strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), ".")
+	if len(path) == 0 && len(strings.TrimSpace(string(result.Body))) == 0 {
+		return false, ""
+	}
+	resolvedElement, _, err := jsonpath.Eval(path, result.Body)
+	if err != nil {
+		return false, ""
+	}
+	return true, resolvedElement
+}
+
+// prettifyAbsenceCheck returns an absence check with the value that was found, or AbsentValue if nothing was found
+func prettifyAbsenceCheck(element, resolvedElement, operator string) string {
+	if operator == "!=" {
+		return element + " (" + AbsentValue + ") " + operator + " " + AbsentValue
+	}
+	if len(resolvedElement) > maximumLengthBeforeTruncatingWhenComparedWithPattern {
+		resolvedElement = fmt.Sprintf("%.25s...(truncated)", resolvedElement)
+	}
+	return element + " (" + resolvedElement + ") " + operator + " " + AbsentValue
+}
+
+// statusClass returns the class of a HTTP status (e.g. 2xx for 204), or an empty string if the status is not a valid
+// HTTP status
+func statusClass(status int) string {
+	if status < 100 || status > 599 {
+		return ""
+	}
+	return strconv.Itoa(status/100) + "xx"
+}
+
+// ipVersion returns the version of the IP passed (4 or 6), or an empty string if it isn't a valid IP
+func ipVersion(ip string) string {
+	parsedIP := net.ParseIP(ip)
+	if parsedIP == nil {
+		return ""
+	} else if parsedIP.To4() != nil {
+		return "4"
+	}
+	return "6"
+}
+
+// resolveHeader resolves an element using the HeaderPlaceholder, optionally wrapped in the has or the len function
+func resolveHeader(element string, result *Result) string {
+	checkingForLength, checkingForExistence := false, false
+	if strings.HasPrefix(element, LengthFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
+		checkingForLength = true
+		element = strings.TrimSuffix(strings.TrimPrefix(element, LengthFunctionPrefix), FunctionSuffix)
+	} else if strings.HasPrefix(element, HasFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
+		checkingForExistence = true
+		element = strings.TrimSuffix(strings.TrimPrefix(element, HasFunctionPrefix), FunctionSuffix)
+	}
+	name := strings.TrimPrefix(strings.TrimPrefix(element, HeaderPlaceholder), ".")
+	if len(name) == 0 {
+		if checkingForLength {
+			return strconv.Itoa(len(result.Headers))
+		}
+		return element + " " + InvalidConditionElementSuffix
+	}
+	values, exists := result.Headers[http.CanonicalHeaderKey(name)]
+	if checkingForExistence {
+		return strconv.FormatBool(exists)
+	}
+	value := strings.Join(values, ",")
+	if checkingForLength {
+		return strconv.Itoa(len(value))
+	}
+	return value
+}
+
 // sanitizeAndResolve sanitizes and resolves a list of elements and returns the list of parameters as well as a list
 // of resolved parameters
 func sanitizeAndResolve(elements []string, result *Result) ([]string, []string) {
@@ -239,8 +709,14 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 		switch strings.ToUpper(element) {
 		case StatusPlaceholder:
 			element = strconv.Itoa(result.HTTPStatus)
+		case StatusClassPlaceholder:
+			element = statusClass(result.HTTPStatus)
 		case IPPlaceholder:
 			element = result.IP
+		case IPVersionPlaceholder:
+			element = ipVersion(result.connectedOrResolvedIP())
+		case ReverseDNSPlaceholder:
+			element = result.ReverseDNS
 		case ResponseTimePlaceholder:
 			element = strconv.Itoa(int(result.Duration.Milliseconds()))
 		case BodyPlaceholder:
@@ -251,11 +727,57 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 			element = strconv.FormatBool(result.Connected)
 		case CertificateExpirationPlaceholder:
 			element = strconv.FormatInt(result.CertificateExpiration.Milliseconds(), 10)
+		case CertificateChainExpirationPlaceholder:
+			element = strconv.FormatInt(result.CertificateChainExpiration.Milliseconds(), 10)
 		case DomainExpirationPlaceholder:
 			element = strconv.FormatInt(result.DomainExpiration.Milliseconds(), 10)
+		case CertificateFingerprintPlaceholder:
+			element = result.CertificateFingerprint
+		case CertificatePublicKeyFingerprintPlaceholder:
+			element = result.CertificatePublicKeyFingerprint
+		case CertificateKeySizePlaceholder:
+			element = strconv.Itoa(result.CertificateKeySize)
+		case CertificateSignatureAlgorithmPlaceholder:
+			element = result.CertificateSignatureAlgorithm
+		case TLSVersionPlaceholder:
+			element = result.TLSVersion
+		case TLSCipherPlaceholder:
+			element = result.TLSCipher
+		case GraphQLErrorsPlaceholder:
+			element = strconv.Itoa(countGraphQLErrors(result.Body))
+		case BodySHA256Placeholder:
+			element = result.GetBodySHA256()
+		case BodyIsJSONPlaceholder:
+			element = strconv.FormatBool(isJSON(result.Body))
+		case BodyIsXMLPlaceholder:
+			element = strconv.FormatBool(isXML(result.Body))
+		case BodyDecodedPlaceholder:
+			element = strconv.FormatBool(result.BodyDecoded)
+		case ContentEncodingPlaceholder:
+			element = result.ContentEncoding
+		case DNSAnswersPlaceholder:
+			element = strings.Join(result.DNSAnswers, ",")
+		case ChangedPlaceholder:
+			element = strconv.FormatBool(result.Changed)
+		case ExitCodePlaceholder:
+			element = strconv.Itoa(result.ExitCode)
+		case SSHVersionPlaceholder:
+			element = result.SSHVersion
+		case JWTValidPlaceholder:
+			element = strconv.FormatBool(result.JWTValid)
+		case JWTPlaceholder:
+			element = string(result.JWTClaims)
 		default:
-			// if contains the BodyPlaceholder, then evaluate json path
-			if strings.Contains(element, BodyPlaceholder) {
+			if strings.Contains(element, HeaderPlaceholder) {
+				element = resolveHeader(element, result)
+				break
+			}
+			// if contains the BodyPlaceholder or the JWTPlaceholder, then evaluate json path
+			placeholder, document := BodyPlaceholder, result.Body
+			if strings.Contains(element, JWTPlaceholder) {
+				placeholder, document = JWTPlaceholder, result.JWTClaims
+			}
+			if strings.Contains(element, placeholder) {
 				checkingForLength := false
 				checkingForExistence := false
 				if strings.HasPrefix(element, LengthFunctionPrefix) && strings.HasSuffix(element, FunctionSuffix) {
@@ -266,7 +788,7 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 					checkingForExistence = true
 					element = strings.TrimSuffix(strings.TrimPrefix(element, HasFunctionPrefix), FunctionSuffix)
 				}
-				resolvedElement, resolvedElementLength, err := jsonpath.Eval(strings.TrimPrefix(strings.TrimPrefix(element, BodyPlaceholder), "."), result.Body)
+				resolvedElement, resolvedElementLength, err := jsonpath.Eval(strings.TrimPrefix(strings.TrimPrefix(element, placeholder), "."), document)
 				if checkingForExistence {
 					if err != nil {
 						element = "false"
@@ -298,8 +820,21 @@ func sanitizeAndResolve(elements []string, result *Result) ([]string, []string)
 	return parameters, resolvedParameters
 }
 
-func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []string, resolvedNumericalParameters []int64) {
+// sanitizeAndResolveNumerical resolves the parameters of a condition using a numerical operator and converts them to
+// numbers, with durations being converted to milliseconds
+//
+// Values that can't be converted default to 0, unless both parameters are placeholders (e.g.
+// [RESPONSE_TIME] < [BODY].budget), in which case an error is returned, since there is no literal value to tell which
+// of the two was meant to be a number.
+func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []string, resolvedNumericalParameters []int64, err error) {
 	parameters, resolvedParameters := sanitizeAndResolve(list, result)
+	if len(parameters) == 2 && isPlaceholderElement(parameters[0]) && isPlaceholderElement(parameters[1]) {
+		for i, element := range resolvedParameters {
+			if _, isNumerical := parseNumericalValue(element); err == nil && !isNumerical && len(element) > 0 && !strings.HasSuffix(element, InvalidConditionElementSuffix) {
+				err = fmt.Errorf("%s resolved to %s, which is neither a number nor a duration", parameters[i], element)
+			}
+		}
+	}
 	for _, element := range resolvedParameters {
 		if duration, err := time.ParseDuration(element); duration != 0 && err == nil {
 			// If the string is a duration, convert it to milliseconds
@@ -318,13 +853,63 @@ func sanitizeAndResolveNumerical(list []string, result *Result) (parameters []st
 			resolvedNumericalParameters = append(resolvedNumericalParameters, number)
 		}
 	}
-	return parameters, resolvedNumericalParameters
+	return parameters, resolvedNumericalParameters, err
+}
+
+// isPlaceholderElement returns whether an element of a condition refers to a placeholder, whether directly (e.g.
+// [STATUS]) or through a function (e.g. len([BODY].data))
+func isPlaceholderElement(element string) bool {
+	openingBracketIndex := strings.Index(element, "[")
+	return openingBracketIndex != -1 && strings.Contains(element[openingBracketIndex:], "]")
 }
 
 func prettifyNumericalParameters(parameters []string, resolvedParameters []int64, operator string) string {
 	return prettify(parameters, []string{strconv.Itoa(int(resolvedParameters[0])), strconv.Itoa(int(resolvedParameters[1]))}, operator)
 }
 
+// parseNumericalValue parses a resolved value as a number, converting durations to milliseconds
+func parseNumericalValue(value string) (float64, bool) {
+	if duration, err := time.ParseDuration(value); duration != 0 && err == nil {
+		return float64(duration.Milliseconds()), true
+	}
+	if number, err := strconv.ParseFloat(value, 64); err == nil {
+		return number, true
+	}
+	return 0, false
+}
+
+// parseRange parses the bounds of a condition using BetweenOperator (e.g. "50 100")
+//
+// An error is returned if there aren't exactly two bounds, if one of them isn't a number or if the lower bound is
+// greater than the upper bound.
+func parseRange(rangeToParse string) (float64, float64, error) {
+	bounds := strings.Fields(rangeToParse)
+	if len(bounds) != 2 {
+		return 0, 0, errors.New("a range must have exactly two bounds")
+	}
+	lowerBound, isLowerBoundNumerical := parseNumericalValue(bounds[0])
+	upperBound, isUpperBoundNumerical := parseNumericalValue(bounds[1])
+	if !isLowerBoundNumerical || !isUpperBoundNumerical {
+		return 0, 0, errors.New("the bounds of a range must be numbers or durations")
+	}
+	if lowerBound > upperBound {
+		return 0, 0, errors.New("the lower bound of a range must not be greater than its upper bound")
+	}
+	return lowerBound, upperBound, nil
+}
+
+// prettifyRangeCheck returns a string representation of a condition using BetweenOperator with the value of its
+// element between parentheses (e.g. "[RESPONSE_TIME] (150) between 50 100")
+func prettifyRangeCheck(element, resolvedElement, bounds string) string {
+	if strings.HasSuffix(resolvedElement, InvalidConditionElementSuffix) {
+		return resolvedElement + " " + BetweenOperator + " " + bounds
+	}
+	if element == resolvedElement {
+		return element + " " + BetweenOperator + " " + bounds
+	}
+	return element + " (" + resolvedElement + ") " + BetweenOperator + " " + bounds
+}
+
 // prettify returns a string representation of a condition with its parameters resolved between parentheses
 func prettify(parameters []string, resolvedParameters []string, operator string) string {
 	// Since, in the event of an invalid path, the resolvedParameters also contain the condition itself,
@@ -347,7 +932,7 @@ func prettify(parameters []string, resolvedParameters []string, operator string)
 	if parameters[0] == resolvedParameters[0] && parameters[1] != resolvedParameters[1] {
 		return parameters[0] + " " + operator + " " + parameters[1] + " (" + resolvedParameters[1] + ")"
 	}
-	// Both elements are placeholders...?
+	// Both elements are placeholders
 	if parameters[0] != resolvedParameters[0] && parameters[1] != resolvedParameters[1] {
 		return parameters[0] + " (" + resolvedParameters[0] + ") " + operator + " " + parameters[1] + " (" + resolvedParameters[1] + ")"
 	}
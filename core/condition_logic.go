@@ -0,0 +1,25 @@
+package core
+
+// ConditionLogic is how the outcomes of the conditions of an endpoint are combined into the outcome of its result
+type ConditionLogic string
+
+const (
+	// ConditionLogicAll requires every condition of an endpoint to pass for its result to be successful
+	ConditionLogicAll ConditionLogic = "all"
+
+	// ConditionLogicAny requires at least one condition of an endpoint to pass for its result to be successful
+	ConditionLogicAny ConditionLogic = "any"
+)
+
+// isValid returns whether the condition logic is one of the supported condition logics
+func (logic ConditionLogic) isValid() bool {
+	return logic == ConditionLogicAll || logic == ConditionLogicAny
+}
+
+// isSatisfied returns whether enough conditions passed for the result to be successful
+func (logic ConditionLogic) isSatisfied(successfulConditions, totalConditions int) bool {
+	if logic == ConditionLogicAny {
+		return successfulConditions > 0
+	}
+	return successfulConditions == totalConditions
+}
@@ -7,4 +7,7 @@ type ConditionResult struct {
 
 	// Success whether the condition was met (successful) or not (failed)
 	Success bool `json:"success"`
+
+	// condition is the Condition that was evaluated, as configured. Unlike Condition, it is never prettified.
+	condition Condition
 }
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"strconv"
 	"testing"
 	"time"
@@ -37,6 +38,18 @@ func TestCondition_Validate(t *testing.T) {
 		{condition: "[CERTIFICATE_EXPIRATION] > 48h", expectedErr: nil},
 		{condition: "[DOMAIN_EXPIRATION] > 720h", expectedErr: nil},
 		{condition: "raw == raw", expectedErr: nil},
+		{condition: "[DNS_ANSWERS] has 203.0.113.10", expectedErr: nil},
+		{condition: "[BODY].roles has admin", expectedErr: nil},
+		{condition: "[RESPONSE_TIME] < [BODY].budget", expectedErr: nil},
+		{condition: "[RESPONSE_TIME] < [CONNECTED]", expectedErr: errors.New("invalid condition: [RESPONSE_TIME] < [CONNECTED]: [CONNECTED] resolved to false, which is neither a number nor a duration")},
+		{condition: "[RESPONSE_TIME] between 50 100", expectedErr: nil},
+		{condition: "[BODY].temperature between -5.5 24", expectedErr: nil},
+		{condition: "[CERTIFICATE_EXPIRATION] between 24h 720h", expectedErr: nil},
+		{condition: "[RESPONSE_TIME] between 50", expectedErr: errors.New("invalid condition: [RESPONSE_TIME] between 50: a range must have exactly two bounds")},
+		{condition: "[RESPONSE_TIME] between 50 100 150", expectedErr: errors.New("invalid condition: [RESPONSE_TIME] between 50 100 150: a range must have exactly two bounds")},
+		{condition: "[RESPONSE_TIME] between low high", expectedErr: errors.New("invalid condition: [RESPONSE_TIME] between low high: the bounds of a range must be numbers or durations")},
+		{condition: "[RESPONSE_TIME] between 100 50", expectedErr: errors.New("invalid condition: [RESPONSE_TIME] between 100 50: the lower bound of a range must not be greater than its upper bound")},
+		{condition: "[STATUS] has 200", expectedErr: errors.New("invalid condition: [STATUS] has 200")},
 		{condition: "[STATUS] ? 201", expectedErr: errors.New("invalid condition: [STATUS] ? 201")},
 		{condition: "[STATUS]==201", expectedErr: errors.New("invalid condition: [STATUS]==201")},
 		{condition: "[STATUS] = = 201", expectedErr: errors.New("invalid condition: [STATUS] = = 201")},
@@ -70,6 +83,34 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: true,
 			ExpectedOutput:  "[IP] == 127.0.0.1",
 		},
+		{
+			Name:            "ip-version",
+			Condition:       Condition("[IP_VERSION] == 6"),
+			Result:          &Result{IP: "127.0.0.1", ConnectedIP: "::1"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[IP_VERSION] == 6",
+		},
+		{
+			Name:            "ip-version-of-resolved-ip",
+			Condition:       Condition("[IP_VERSION] == 6"),
+			Result:          &Result{IP: "127.0.0.1"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[IP_VERSION] (4) == 6",
+		},
+		{
+			Name:            "reverse-dns",
+			Condition:       Condition("[REVERSE_DNS] == pat(*.internal)"),
+			Result:          &Result{ReverseDNS: "host-1.example.internal"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[REVERSE_DNS] == pat(*.internal)",
+		},
+		{
+			Name:            "reverse-dns-failure",
+			Condition:       Condition("[REVERSE_DNS] == pat(*.internal)"),
+			Result:          &Result{ReverseDNS: "host-1.example.org"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[REVERSE_DNS] (host-1.example.org) == pat(*.internal)",
+		},
 		{
 			Name:            "status",
 			Condition:       Condition("[STATUS] == 200"),
@@ -84,6 +125,328 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[STATUS] (500) == 200",
 		},
+		{
+			Name:            "status-class",
+			Condition:       Condition("[STATUS_CLASS] == 2xx"),
+			Result:          &Result{HTTPStatus: 204},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[STATUS_CLASS] == 2xx",
+		},
+		{
+			Name:            "status-class-failure",
+			Condition:       Condition("[STATUS_CLASS] == 2xx"),
+			Result:          &Result{HTTPStatus: 503},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[STATUS_CLASS] (5xx) == 2xx",
+		},
+		{
+			Name:            "status-class-with-no-status",
+			Condition:       Condition("[STATUS_CLASS] == 2xx"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[STATUS_CLASS] () == 2xx",
+		},
+		{
+			Name:            "dns-answers-has",
+			Condition:       Condition("[DNS_ANSWERS] has 203.0.113.10"),
+			Result:          &Result{DNSAnswers: []string{"203.0.113.11", "203.0.113.10"}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DNS_ANSWERS] has 203.0.113.10",
+		},
+		{
+			Name:            "dns-answers-has-failure",
+			Condition:       Condition("[DNS_ANSWERS] has 203.0.113.10"),
+			Result:          &Result{DNSAnswers: []string{"203.0.113.11", "203.0.113.12"}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_ANSWERS] (203.0.113.11,203.0.113.12) has 203.0.113.10",
+		},
+		{
+			Name:            "dns-answers-has-with-no-answers",
+			Condition:       Condition("[DNS_ANSWERS] has 203.0.113.10"),
+			Result:          &Result{},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_ANSWERS] () has 203.0.113.10",
+		},
+		{
+			Name:            "dns-answers-has-pattern",
+			Condition:       Condition("[DNS_ANSWERS] has pat(203.0.113.*)"),
+			Result:          &Result{DNSAnswers: []string{"198.51.100.1", "203.0.113.10"}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[DNS_ANSWERS] has pat(203.0.113.*)",
+		},
+		{
+			Name:            "dns-answers-has-failure-with-truncated-answers",
+			Condition:       Condition("[DNS_ANSWERS] has 203.0.113.10"),
+			Result:          &Result{DNSAnswers: []string{"198.51.100.1", "198.51.100.2", "198.51.100.3", "198.51.100.4", "198.51.100.5", "198.51.100.6", "198.51.100.7", "198.51.100.8", "198.51.100.9"}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[DNS_ANSWERS] (198.51.100.1,198.51.100.2,198.51.100.3,198.51.100.4,198.51.100.5,198.51.100.6,198.51.100.7,198.51.10...(truncated)) has 203.0.113.10",
+		},
+		{
+			Name:            "body-array-has-string",
+			Condition:       Condition("[BODY].roles has admin"),
+			Result:          &Result{Body: []byte(`{"roles":["user","admin"]}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].roles has admin",
+		},
+		{
+			Name:            "body-array-has-string-failure",
+			Condition:       Condition("[BODY].roles has admin"),
+			Result:          &Result{Body: []byte(`{"roles":["user","viewer"]}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].roles ([user,viewer]) has admin",
+		},
+		{
+			Name:            "body-array-has-number",
+			Condition:       Condition("[BODY].items has 5"),
+			Result:          &Result{Body: []byte(`{"items":[1,5.0,10]}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].items has 5",
+		},
+		{
+			Name:            "body-array-has-number-failure",
+			Condition:       Condition("[BODY].items has 5"),
+			Result:          &Result{Body: []byte(`{"items":[1,"50",10]}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].items ([1,50,10]) has 5",
+		},
+		{
+			Name:            "body-array-has-value-of-other-path",
+			Condition:       Condition("[BODY].ids has [BODY].id"),
+			Result:          &Result{Body: []byte(`{"id":2,"ids":[1,2,3]}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].ids has [BODY].id",
+		},
+		{
+			Name:            "body-array-has-pattern",
+			Condition:       Condition("[BODY].data.names has pat(john*)"),
+			Result:          &Result{Body: []byte(`{"data":{"names":["jane.doe","john.doe"]}}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].data.names has pat(john*)",
+		},
+		{
+			Name:            "body-root-array-has",
+			Condition:       Condition("[BODY] has blue"),
+			Result:          &Result{Body: []byte(`["red","blue"]`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] has blue",
+		},
+		{
+			Name:            "body-array-has-failure-with-truncated-array",
+			Condition:       Condition("[BODY].names has zoe"),
+			Result:          &Result{Body: []byte(`{"names":["alexander","benjamin","charlotte","elizabeth","frederick","gabriella","harrison","isabella","jonathan","katherine","leonardo"]}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].names ([alexander,benjamin,charlotte,elizabeth,frederick,gabriella,harrison,isabella,jonathan,katherine,leo...(truncated)) has zoe",
+		},
+		{
+			Name:            "body-has-with-path-that-is-not-an-array",
+			Condition:       Condition("[BODY].roles has admin"),
+			Result:          &Result{Body: []byte(`{"roles":"admin"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].roles (INVALID) has admin",
+		},
+		{
+			Name:            "body-has-with-missing-path",
+			Condition:       Condition("[BODY].roles has admin"),
+			Result:          &Result{Body: []byte(`{}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].roles (INVALID) has admin",
+		},
+		{
+			Name:            "case-insensitive-equality",
+			Condition:       Condition("[BODY].status ~= healthy"),
+			Result:          &Result{Body: []byte("{\"status\":\"HEALTHY\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].status ~= healthy",
+		},
+		{
+			Name:            "case-insensitive-equality-failure",
+			Condition:       Condition("[BODY].status ~= healthy"),
+			Result:          &Result{Body: []byte("{\"status\":\"DOWN\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].status (DOWN) ~= healthy",
+		},
+		{
+			Name:            "case-sensitive-equality-failure",
+			Condition:       Condition("[BODY].status == healthy"),
+			Result:          &Result{Body: []byte("{\"status\":\"HEALTHY\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].status (HEALTHY) == healthy",
+		},
+		{
+			Name:            "absent-json-path",
+			Condition:       Condition("[BODY].error == absent"),
+			Result:          &Result{Body: []byte("{\"status\":\"UP\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].error == absent",
+		},
+		{
+			Name:            "absent-json-path-failure",
+			Condition:       Condition("[BODY].error == absent"),
+			Result:          &Result{Body: []byte("{\"error\":\"database is unreachable\"}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].error (database is unreachable) == absent",
+		},
+		{
+			Name:            "absent-nested-json-path-with-missing-parent",
+			Condition:       Condition("[BODY].data.error == absent"),
+			Result:          &Result{Body: []byte("{\"status\":\"UP\"}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].data.error == absent",
+		},
+		{
+			Name:            "absent-json-path-with-invalid-body",
+			Condition:       Condition("[BODY].error == absent"),
+			Result:          &Result{Body: []byte("not json")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].error == absent",
+		},
+		{
+			Name:            "absent-body",
+			Condition:       Condition("[BODY] == absent"),
+			Result:          &Result{Body: []byte("")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY] == absent",
+		},
+		{
+			Name:            "not-absent-json-path",
+			Condition:       Condition("[BODY].data.id != absent"),
+			Result:          &Result{Body: []byte("{\"data\":{\"id\":1}}")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].data.id != absent",
+		},
+		{
+			Name:            "not-absent-json-path-failure",
+			Condition:       Condition("[BODY].data.id != absent"),
+			Result:          &Result{Body: []byte("{\"data\":{}}")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].data.id (absent) != absent",
+		},
+		{
+			Name:            "body-sha256",
+			Condition:       Condition("[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+			Result:          &Result{Body: []byte("hello")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Name:            "body-sha256-failure",
+			Condition:       Condition("[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"),
+			Result:          &Result{BodySHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_SHA256] (e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855) == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+		},
+		{
+			Name:            "between",
+			Condition:       Condition("[RESPONSE_TIME] between 50 100"),
+			Result:          &Result{Duration: 75 * time.Millisecond},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] between 50 100",
+		},
+		{
+			Name:            "between-inclusive-bounds",
+			Condition:       Condition("[BODY].temperature between 18 24"),
+			Result:          &Result{Body: []byte(`{"temperature":24}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY].temperature between 18 24",
+		},
+		{
+			Name:            "between-float",
+			Condition:       Condition("[BODY].temperature between 18.5 24"),
+			Result:          &Result{Body: []byte(`{"temperature":18.4}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].temperature (18.4) between 18.5 24",
+		},
+		{
+			Name:            "between-failure",
+			Condition:       Condition("[RESPONSE_TIME] between 50 100"),
+			Result:          &Result{Duration: 150 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (150) between 50 100",
+		},
+		{
+			Name:            "between-duration",
+			Condition:       Condition("[CERTIFICATE_EXPIRATION] between 24h 720h"),
+			Result:          &Result{CertificateExpiration: 48 * time.Hour},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_EXPIRATION] between 24h 720h",
+		},
+		{
+			Name:            "two-placeholders",
+			Condition:       Condition("[RESPONSE_TIME] < [BODY].budget"),
+			Result:          &Result{Duration: 150 * time.Millisecond, Body: []byte(`{"budget":200}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] < [BODY].budget",
+		},
+		{
+			Name:            "two-placeholders-failure",
+			Condition:       Condition("[RESPONSE_TIME] < [BODY].budget"),
+			Result:          &Result{Duration: 250 * time.Millisecond, Body: []byte(`{"budget":"200ms"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (250) < [BODY].budget (200)",
+		},
+		{
+			Name:            "two-placeholders-with-non-numerical-value",
+			Condition:       Condition("[RESPONSE_TIME] < [BODY].budget"),
+			Result:          &Result{Duration: 150 * time.Millisecond, Body: []byte(`{"budget":"unlimited"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (150) < [BODY].budget (0)",
+		},
+		{
+			Name:            "between-non-numerical-value",
+			Condition:       Condition("[BODY].temperature between -5 5"),
+			Result:          &Result{Body: []byte(`{"temperature":"cold"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].temperature (cold) between -5 5",
+		},
+		{
+			Name:            "between-invalid-path",
+			Condition:       Condition("[BODY].temperature between -5 5"),
+			Result:          &Result{Body: []byte(`{}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY].temperature " + InvalidConditionElementSuffix + " between -5 5",
+		},
+		{
+			Name:            "body-is-json",
+			Condition:       Condition("[BODY_IS_JSON] == true"),
+			Result:          &Result{Body: []byte(`{"status":"UP"}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_IS_JSON] == true",
+		},
+		{
+			Name:            "body-is-json-failure",
+			Condition:       Condition("[BODY_IS_JSON] == true"),
+			Result:          &Result{Body: []byte("<html><body>Bad Gateway</body></html>")},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_IS_JSON] (false) == true",
+		},
+		{
+			Name:            "body-is-xml",
+			Condition:       Condition("[BODY_IS_XML] == true"),
+			Result:          &Result{Body: []byte("<health><status>UP</status></health>")},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[BODY_IS_XML] == true",
+		},
+		{
+			Name:            "body-is-xml-failure",
+			Condition:       Condition("[BODY_IS_XML] == true"),
+			Result:          &Result{Body: []byte(`{"status":"UP"}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[BODY_IS_XML] (false) == true",
+		},
+		{
+			Name:            "changed",
+			Condition:       Condition("[CHANGED] == false"),
+			Result:          &Result{HTTPStatus: 304, Changed: false},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CHANGED] == false",
+		},
+		{
+			Name:            "changed-failure",
+			Condition:       Condition("[CHANGED] == false"),
+			Result:          &Result{HTTPStatus: 200, Changed: true},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CHANGED] (true) == false",
+		},
 		{
 			Name:            "status-using-less-than",
 			Condition:       Condition("[STATUS] < 300"),
@@ -350,6 +713,13 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[CERTIFICATE_EXPIRATION] (1209600000) > 2419200000",
 		},
+		{
+			Name:            "certificate-chain-expiration-greater-than-duration-failure",
+			Condition:       Condition("[CERTIFICATE_CHAIN_EXPIRATION] > 48h"),
+			Result:          &Result{CertificateExpiration: 24 * time.Hour * 60, CertificateChainExpiration: 24 * time.Hour},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CERTIFICATE_CHAIN_EXPIRATION] (86400000) > 48h (172800000)",
+		},
 		{
 			Name:            "certificate-expiration-greater-than-duration",
 			Condition:       Condition("[CERTIFICATE_EXPIRATION] > 12h"),
@@ -364,6 +734,146 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess: false,
 			ExpectedOutput:  "[CERTIFICATE_EXPIRATION] (86400000) > 48h (172800000)",
 		},
+		{
+			Name:            "certificate-fingerprint",
+			Condition:       Condition("[CERTIFICATE_FINGERPRINT] == 3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5"),
+			Result:          &Result{CertificateFingerprint: "3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_FINGERPRINT] == 3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5",
+		},
+		{
+			Name:            "certificate-fingerprint-failure",
+			Condition:       Condition("[CERTIFICATE_FINGERPRINT] == 3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5"),
+			Result:          &Result{CertificateFingerprint: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CERTIFICATE_FINGERPRINT] (e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855) == 3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5",
+		},
+		{
+			Name:            "certificate-fingerprint-with-any",
+			Condition:       Condition("[CERTIFICATE_FINGERPRINT] == any(3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5, e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855)"),
+			Result:          &Result{CertificateFingerprint: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_FINGERPRINT] == any(3a1489d420f5ef9d8715bb4ffb9c0d3e56b6bad5fdba66c8a3ed1ec5af61dbc5, e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855)",
+		},
+		{
+			Name:            "header",
+			Condition:       Condition("[HEADER].Content-Type == application/json"),
+			Result:          &Result{Headers: http.Header{"Content-Type": []string{"application/json"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HEADER].Content-Type == application/json",
+		},
+		{
+			Name:            "header-with-multiple-values",
+			Condition:       Condition("[HEADER].Vary == Accept,Origin"),
+			Result:          &Result{Headers: http.Header{"Vary": []string{"Accept", "Origin"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HEADER].Vary == Accept,Origin",
+		},
+		{
+			Name:            "header-with-non-canonical-name",
+			Condition:       Condition("[HEADER].x-frame-options == DENY"),
+			Result:          &Result{Headers: http.Header{"X-Frame-Options": []string{"DENY"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[HEADER].x-frame-options == DENY",
+		},
+		{
+			Name:            "header-failure",
+			Condition:       Condition("[HEADER].Content-Type == application/json"),
+			Result:          &Result{Headers: http.Header{"Content-Type": []string{"text/html"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[HEADER].Content-Type (text/html) == application/json",
+		},
+		{
+			Name:            "has-header",
+			Condition:       Condition("has([HEADER].Strict-Transport-Security) == true"),
+			Result:          &Result{Headers: http.Header{"Strict-Transport-Security": []string{"max-age=31536000"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "has([HEADER].Strict-Transport-Security) == true",
+		},
+		{
+			Name:            "has-header-failure",
+			Condition:       Condition("has([HEADER].Strict-Transport-Security) == true"),
+			Result:          &Result{Headers: http.Header{"Content-Type": []string{"text/html"}}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "has([HEADER].Strict-Transport-Security) (false) == true",
+		},
+		{
+			Name:            "has-header-absent",
+			Condition:       Condition("has([HEADER].Server) == false"),
+			Result:          &Result{},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "has([HEADER].Server) == false",
+		},
+		{
+			Name:            "len-header",
+			Condition:       Condition("len([HEADER]) < 3"),
+			Result:          &Result{Headers: http.Header{"Content-Type": []string{"text/html"}, "Server": []string{"nginx"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "len([HEADER]) < 3",
+		},
+		{
+			Name:            "len-header-value",
+			Condition:       Condition("len([HEADER].Server) == 5"),
+			Result:          &Result{Headers: http.Header{"Server": []string{"nginx"}}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "len([HEADER].Server) == 5",
+		},
+		{
+			Name:            "tls-version",
+			Condition:       Condition("[TLS_VERSION] == TLS 1.3"),
+			Result:          &Result{TLSVersion: "TLS 1.3"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[TLS_VERSION] == TLS 1.3",
+		},
+		{
+			Name:            "tls-version-failure",
+			Condition:       Condition("[TLS_VERSION] == TLS 1.3"),
+			Result:          &Result{TLSVersion: "TLS 1.2"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[TLS_VERSION] (TLS 1.2) == TLS 1.3",
+		},
+		{
+			Name:            "tls-cipher",
+			Condition:       Condition("[TLS_CIPHER] != TLS_RSA_WITH_AES_128_CBC_SHA"),
+			Result:          &Result{TLSCipher: "TLS_AES_128_GCM_SHA256"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[TLS_CIPHER] != TLS_RSA_WITH_AES_128_CBC_SHA",
+		},
+		{
+			Name:            "certificate-key-size",
+			Condition:       Condition("[CERTIFICATE_KEY_SIZE] >= 2048"),
+			Result:          &Result{CertificateKeySize: 4096},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_KEY_SIZE] >= 2048",
+		},
+		{
+			Name:            "certificate-key-size-failure",
+			Condition:       Condition("[CERTIFICATE_KEY_SIZE] >= 2048"),
+			Result:          &Result{CertificateKeySize: 1024},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CERTIFICATE_KEY_SIZE] (1024) >= 2048",
+		},
+		{
+			Name:            "certificate-signature-algorithm",
+			Condition:       Condition("[CERTIFICATE_SIG_ALGORITHM] == any(SHA256-RSA, ECDSA-SHA256)"),
+			Result:          &Result{CertificateSignatureAlgorithm: "ECDSA-SHA256"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_SIG_ALGORITHM] == any(SHA256-RSA, ECDSA-SHA256)",
+		},
+		{
+			Name:            "certificate-signature-algorithm-failure",
+			Condition:       Condition("[CERTIFICATE_SIG_ALGORITHM] != SHA1-RSA"),
+			Result:          &Result{CertificateSignatureAlgorithm: "SHA1-RSA"},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[CERTIFICATE_SIG_ALGORITHM] (SHA1-RSA) != SHA1-RSA",
+		},
+		{
+			Name:            "certificate-public-key-fingerprint",
+			Condition:       Condition("[CERTIFICATE_PUBLIC_KEY_FINGERPRINT] == e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"),
+			Result:          &Result{CertificatePublicKeyFingerprint: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[CERTIFICATE_PUBLIC_KEY_FINGERPRINT] == e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+		},
 		{
 			Name:            "no-placeholders",
 			Condition:       Condition("1 == 2"),
@@ -626,6 +1136,83 @@ func TestCondition_evaluate(t *testing.T) {
 			ExpectedSuccess:             false,
 			ExpectedOutput:              "has([BODY].errors) == false",
 		},
+		{
+			Name:            "graphql-errors",
+			Condition:       Condition("[GRAPHQL_ERRORS] == 0"),
+			Result:          &Result{Body: []byte(`{"data":{"user":{"name":"john"}}}`)},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[GRAPHQL_ERRORS] == 0",
+		},
+		{
+			Name:            "graphql-errors-failure",
+			Condition:       Condition("[GRAPHQL_ERRORS] == 0"),
+			Result:          &Result{HTTPStatus: 200, Body: []byte(`{"data":null,"errors":[{"message":"not found"},{"message":"unauthorized"}]}`)},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[GRAPHQL_ERRORS] (2) == 0",
+		},
+		{
+			Name:            "response-time-not-anomalous",
+			Condition:       Condition("[RESPONSE_TIME] != anomalous"),
+			Result:          &Result{Duration: 250 * time.Millisecond, responseTimeBaseline: &ResponseTimeBaseline{Mean: 200 * time.Millisecond, StandardDeviation: 50 * time.Millisecond, Samples: 50}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] != anomalous",
+		},
+		{
+			Name:            "response-time-not-anomalous-failure",
+			Condition:       Condition("[RESPONSE_TIME] != anomalous"),
+			Result:          &Result{Duration: 410 * time.Millisecond, responseTimeBaseline: &ResponseTimeBaseline{Mean: 200 * time.Millisecond, StandardDeviation: 50 * time.Millisecond, Samples: 50}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (410; z-score=4.20; baseline=200±50) != anomalous",
+		},
+		{
+			Name:            "response-time-not-anomalous-without-baseline",
+			Condition:       Condition("[RESPONSE_TIME] != anomalous"),
+			Result:          &Result{Duration: 10 * time.Second},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] != anomalous",
+		},
+		{
+			Name:            "response-time-anomalous",
+			Condition:       Condition("[RESPONSE_TIME] == anomalous"),
+			Result:          &Result{Duration: 410 * time.Millisecond, responseTimeBaseline: &ResponseTimeBaseline{Mean: 200 * time.Millisecond, StandardDeviation: 50 * time.Millisecond, Samples: 50}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] == anomalous",
+		},
+		{
+			Name:            "response-time-anomalous-without-baseline-failure",
+			Condition:       Condition("[RESPONSE_TIME] == anomalous"),
+			Result:          &Result{Duration: 410 * time.Millisecond},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (410; no baseline) == anomalous",
+		},
+		{
+			Name:            "response-time-not-increasing",
+			Condition:       Condition("[RESPONSE_TIME] != increasing"),
+			Result:          &Result{Duration: 250 * time.Millisecond, previousResponseTimes: []time.Duration{100 * time.Millisecond, 300 * time.Millisecond}, responseTimeTrendConfig: &ResponseTimeTrendConfig{Checks: 3}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] != increasing",
+		},
+		{
+			Name:            "response-time-not-increasing-failure",
+			Condition:       Condition("[RESPONSE_TIME] != increasing"),
+			Result:          &Result{Duration: 300 * time.Millisecond, previousResponseTimes: []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, responseTimeTrendConfig: &ResponseTimeTrendConfig{Checks: 3}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (300; slope=+100/check over 3 checks) != increasing",
+		},
+		{
+			Name:            "response-time-not-increasing-without-enough-results",
+			Condition:       Condition("[RESPONSE_TIME] != increasing"),
+			Result:          &Result{Duration: 300 * time.Millisecond, previousResponseTimes: []time.Duration{200 * time.Millisecond}, responseTimeTrendConfig: &ResponseTimeTrendConfig{Checks: 3}},
+			ExpectedSuccess: true,
+			ExpectedOutput:  "[RESPONSE_TIME] != increasing",
+		},
+		{
+			Name:            "response-time-increasing-without-enough-results-failure",
+			Condition:       Condition("[RESPONSE_TIME] == increasing"),
+			Result:          &Result{Duration: 300 * time.Millisecond, responseTimeTrendConfig: &ResponseTimeTrendConfig{Checks: 3}},
+			ExpectedSuccess: false,
+			ExpectedOutput:  "[RESPONSE_TIME] (300; not enough results) == increasing",
+		},
 	}
 	for _, scenario := range scenarios {
 		t.Run(scenario.Name, func(t *testing.T) {
@@ -651,3 +1238,15 @@ func TestCondition_evaluateWithInvalidOperator(t *testing.T) {
 		t.Error("condition was invalid, result should've had an error")
 	}
 }
+
+func TestCondition_evaluateWithTwoPlaceholdersThatAreNotComparable(t *testing.T) {
+	condition := Condition("[RESPONSE_TIME] < [BODY].budget")
+	result := &Result{Duration: 150 * time.Millisecond, Body: []byte(`{"budget":"unlimited"}`)}
+	if condition.evaluate(result, false) {
+		t.Error("condition should've been a failure, because [BODY].budget isn't a number")
+	}
+	expectedErr := "invalid condition: [RESPONSE_TIME] < [BODY].budget: [BODY].budget resolved to unlimited, which is neither a number nor a duration"
+	if len(result.Errors) != 1 || result.Errors[0] != expectedErr {
+		t.Errorf("expected error %q, got %v", expectedErr, result.Errors)
+	}
+}
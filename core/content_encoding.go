@@ -0,0 +1,79 @@
+package core
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// contentEncodingOf returns the Content-Encoding of the response passed
+//
+// If the client transparently decompressed the body, which it only does with gzip, and only if the request didn't
+// have an explicit Accept-Encoding header, the Content-Encoding header is removed from the response, so gzip is
+// returned instead.
+func contentEncodingOf(response *http.Response) string {
+	if response.Uncompressed {
+		return "gzip"
+	}
+	return strings.ToLower(strings.TrimSpace(response.Header.Get("Content-Encoding")))
+}
+
+// isEncoded returns whether the content encoding passed means that the body must be decoded
+func isEncoded(contentEncoding string) bool {
+	return len(contentEncoding) > 0 && contentEncoding != "identity"
+}
+
+// newContentDecoder returns a reader decoding the body passed according to the content encoding passed
+//
+// If multiple encodings were applied (e.g. "gzip, br"), they are decoded in the reverse order. The closer returned
+// must be called once the body has been read.
+func newContentDecoder(body io.Reader, contentEncoding string) (io.Reader, func(), error) {
+	var closers []func()
+	closeAll := func() {
+		for _, closer := range closers {
+			closer()
+		}
+	}
+	encodings := strings.Split(contentEncoding, ",")
+	for i := len(encodings) - 1; i >= 0; i-- {
+		switch encoding := strings.TrimSpace(encodings[i]); encoding {
+		case "", "identity":
+		case "gzip", "x-gzip":
+			gzipReader, err := gzip.NewReader(body)
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			closers = append(closers, func() { _ = gzipReader.Close() })
+			body = gzipReader
+		case "deflate":
+			zlibReader, err := zlib.NewReader(body)
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			closers = append(closers, func() { _ = zlibReader.Close() })
+			body = zlibReader
+		case "br":
+			body = brotli.NewReader(body)
+		case "zstd":
+			zstdDecoder, err := zstd.NewReader(body, zstd.WithDecoderConcurrency(1))
+			if err != nil {
+				closeAll()
+				return nil, nil, err
+			}
+			closers = append(closers, zstdDecoder.Close)
+			body = zstdDecoder
+		default:
+			closeAll()
+			return nil, nil, fmt.Errorf("unsupported content encoding %s", encoding)
+		}
+	}
+	return body, closeAll, nil
+}
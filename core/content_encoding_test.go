@@ -0,0 +1,199 @@
+package core
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+func TestEndpoint_EvaluateHealthWithContentEncoding(t *testing.T) {
+	body := []byte(`{"status":"UP"}`)
+	scenarios := []struct {
+		name                    string
+		contentEncoding         string
+		responseBody            []byte
+		acceptEncoding          string
+		conditions              []Condition
+		expectedSuccess         bool
+		expectedBodyDecoded     bool
+		expectedContentEncoding string
+		expectedErrorMatch      string
+	}{
+		{
+			name:                    "identity",
+			responseBody:            body,
+			conditions:              []Condition{"[BODY_DECODED] == true", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "",
+		},
+		{
+			name:                    "gzip-transparently-decompressed",
+			contentEncoding:         "gzip",
+			responseBody:            encodeTestBody(t, "gzip", body),
+			conditions:              []Condition{"[BODY_DECODED] == true", "[CONTENT_ENCODING] == gzip", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "gzip",
+		},
+		{
+			name:                    "corrupt-gzip-transparently-decompressed",
+			contentEncoding:         "gzip",
+			responseBody:            corruptTestBody(encodeTestBody(t, "gzip", body)),
+			conditions:              []Condition{"[STATUS] == 200", "[BODY].status == UP"},
+			expectedSuccess:         false,
+			expectedBodyDecoded:     false,
+			expectedContentEncoding: "gzip",
+			expectedErrorMatch:      "error decoding response body with content encoding gzip",
+		},
+		{
+			name:                    "gzip-with-explicit-accept-encoding",
+			contentEncoding:         "gzip",
+			responseBody:            encodeTestBody(t, "gzip", body),
+			acceptEncoding:          "gzip",
+			conditions:              []Condition{"[BODY_DECODED] == true", "[CONTENT_ENCODING] == gzip", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "gzip",
+		},
+		{
+			name:                    "not-gzip-with-explicit-accept-encoding",
+			contentEncoding:         "gzip",
+			responseBody:            body,
+			acceptEncoding:          "gzip",
+			conditions:              []Condition{"[BODY_DECODED] == true"},
+			expectedSuccess:         false,
+			expectedBodyDecoded:     false,
+			expectedContentEncoding: "gzip",
+			expectedErrorMatch:      "error decoding response body with content encoding gzip",
+		},
+		{
+			name:                    "deflate",
+			contentEncoding:         "deflate",
+			responseBody:            encodeTestBody(t, "deflate", body),
+			acceptEncoding:          "deflate",
+			conditions:              []Condition{"[BODY_DECODED] == true", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "deflate",
+		},
+		{
+			name:                    "brotli",
+			contentEncoding:         "br",
+			responseBody:            encodeTestBody(t, "br", body),
+			acceptEncoding:          "br",
+			conditions:              []Condition{"[BODY_DECODED] == true", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "br",
+		},
+		{
+			name:                    "corrupt-zstd",
+			contentEncoding:         "zstd",
+			responseBody:            corruptTestBody(encodeTestBody(t, "zstd", body)),
+			acceptEncoding:          "zstd",
+			conditions:              []Condition{"[STATUS] == 200", "[BODY].status == UP"},
+			expectedSuccess:         false,
+			expectedBodyDecoded:     false,
+			expectedContentEncoding: "zstd",
+			expectedErrorMatch:      "error decoding response body with content encoding zstd",
+		},
+		{
+			name:                    "multiple-encodings",
+			contentEncoding:         "deflate, gzip",
+			responseBody:            encodeTestBody(t, "gzip", encodeTestBody(t, "deflate", body)),
+			acceptEncoding:          "gzip, deflate",
+			conditions:              []Condition{"[BODY_DECODED] == true", "[BODY].status == UP"},
+			expectedSuccess:         true,
+			expectedBodyDecoded:     true,
+			expectedContentEncoding: "deflate, gzip",
+		},
+		{
+			name:                    "unsupported-encoding",
+			contentEncoding:         "compress",
+			responseBody:            body,
+			acceptEncoding:          "compress",
+			conditions:              []Condition{"[BODY_DECODED] == true"},
+			expectedSuccess:         false,
+			expectedBodyDecoded:     false,
+			expectedContentEncoding: "compress",
+			expectedErrorMatch:      "unsupported content encoding compress",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(scenario.contentEncoding) > 0 {
+					w.Header().Set("Content-Encoding", scenario.contentEncoding)
+				}
+				_, _ = w.Write(scenario.responseBody)
+			}))
+			defer server.Close()
+			endpoint := Endpoint{Name: "name", URL: server.URL, Conditions: scenario.conditions}
+			if len(scenario.acceptEncoding) > 0 {
+				endpoint.Headers = map[string]string{"Accept-Encoding": scenario.acceptEncoding}
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if result.BodyDecoded != scenario.expectedBodyDecoded {
+				t.Errorf("expected BodyDecoded to be %v, got %v", scenario.expectedBodyDecoded, result.BodyDecoded)
+			}
+			if result.ContentEncoding != scenario.expectedContentEncoding {
+				t.Errorf("expected ContentEncoding to be %q, got %q", scenario.expectedContentEncoding, result.ContentEncoding)
+			}
+			if len(scenario.expectedErrorMatch) > 0 && (len(result.Errors) != 1 || !strings.Contains(result.Errors[0], scenario.expectedErrorMatch)) {
+				t.Errorf("expected an error containing %q, got %v", scenario.expectedErrorMatch, result.Errors)
+			}
+			if len(scenario.expectedErrorMatch) == 0 && len(result.Errors) != 0 {
+				t.Errorf("expected no errors, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+// encodeTestBody returns the body passed encoded with the content encoding passed
+func encodeTestBody(t *testing.T, contentEncoding string, body []byte) []byte {
+	var buffer bytes.Buffer
+	var writer interface {
+		Write([]byte) (int, error)
+		Close() error
+	}
+	switch contentEncoding {
+	case "gzip":
+		writer = gzip.NewWriter(&buffer)
+	case "deflate":
+		writer = zlib.NewWriter(&buffer)
+	case "br":
+		writer = brotli.NewWriter(&buffer)
+	case "zstd":
+		zstdWriter, err := zstd.NewWriter(&buffer)
+		if err != nil {
+			t.Fatal("expected no error, got", err.Error())
+		}
+		writer = zstdWriter
+	}
+	_, _ = writer.Write(body)
+	_ = writer.Close()
+	return buffer.Bytes()
+}
+
+// corruptTestBody returns a copy of the encoded body passed whose payload, but not header, has been altered
+func corruptTestBody(encodedBody []byte) []byte {
+	corruptedBody := append([]byte{}, encodedBody...)
+	for i := 12; i < len(corruptedBody)-4; i++ {
+		corruptedBody[i] ^= 0xff
+	}
+	return corruptedBody
+}
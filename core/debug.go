@@ -0,0 +1,84 @@
+package core
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/TwiN/gatus/v5/util"
+)
+
+const (
+	// debugBodyMaxLength is the maximum length, in bytes, of the excerpts of the bodies logged for an endpoint with
+	// Debug enabled
+	debugBodyMaxLength = 1024
+
+	redactedValue = "<redacted>"
+)
+
+// sensitiveHeaders are the headers whose value is always redacted from the logs, regardless of what it looks like
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// logHTTPExchange logs the request sent to the endpoint and the response it received, or the error that prevented a
+// response from being received
+//
+// Secrets are redacted from the URL, the headers and the bodies, and the bodies are truncated to debugBodyMaxLength.
+func (endpoint *Endpoint) logHTTPExchange(request *http.Request, response *http.Response, responseBody []byte, err error) {
+	lines := []string{fmt.Sprintf("> %s %s %s", request.Method, endpoint.redactURL(request.URL.String()), request.Proto)}
+	lines = append(lines, formatHeaders(">", request.Header)...)
+	if len(endpoint.Body) > 0 {
+		lines = append(lines, ">", "> "+util.Excerpt([]byte(endpoint.Body), debugBodyMaxLength))
+	}
+	if err != nil {
+		lines = append(lines, "< "+endpoint.redactURL(err.Error()))
+	} else {
+		lines = append(lines, fmt.Sprintf("< %s %s", response.Proto, response.Status))
+		lines = append(lines, formatHeaders("<", response.Header)...)
+		if len(responseBody) > 0 {
+			lines = append(lines, "<", "< "+util.Excerpt(responseBody, debugBodyMaxLength))
+		}
+	}
+	log.Printf("[core][logHTTPExchange] HTTP exchange of group=%s; endpoint=%s\n%s", endpoint.Group, endpoint.Name, strings.Join(lines, "\n"))
+}
+
+// redactURL redacts the secrets from the URL, or from the text containing the URL, passed
+//
+// The URL, or its hostname, are redacted as a whole if the endpoint is configured to hide them.
+func (endpoint *Endpoint) redactURL(text string) string {
+	if endpoint.UIConfig != nil && endpoint.UIConfig.HideURL {
+		text = strings.ReplaceAll(text, endpoint.URL, redactedValue)
+	}
+	if endpoint.UIConfig != nil && endpoint.UIConfig.HideHostname {
+		if hostname, err := endpoint.Hostname(); err == nil && len(hostname) > 0 {
+			text = strings.ReplaceAll(text, hostname, redactedValue)
+		}
+	}
+	return util.RedactSecrets(text)
+}
+
+// formatHeaders formats the headers passed, sorted by name, with each line prefixed by the given prefix
+func formatHeaders(prefix string, headers http.Header) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var lines []string
+	for _, name := range names {
+		for _, value := range headers[name] {
+			if sensitiveHeaders[http.CanonicalHeaderKey(name)] {
+				lines = append(lines, fmt.Sprintf("%s %s: %s", prefix, name, redactedValue))
+			} else {
+				lines = append(lines, fmt.Sprintf("%s %s", prefix, util.RedactSecrets(name+": "+value)))
+			}
+		}
+	}
+	return lines
+}
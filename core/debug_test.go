@@ -0,0 +1,91 @@
+package core
+
+import (
+	"bytes"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/core/ui"
+)
+
+func TestEndpoint_EvaluateHealthWithDebug(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"status":"DOWN","token":"hunter2"}`))
+	}))
+	defer server.Close()
+	var buffer bytes.Buffer
+	log.SetOutput(&buffer)
+	defer log.SetOutput(os.Stderr)
+	endpoint := Endpoint{
+		Name:       "name",
+		Group:      "group",
+		URL:        server.URL + "/health?api_key=hunter2",
+		Method:     http.MethodPost,
+		Body:       `{"password":"hunter2"}`,
+		Headers:    map[string]string{"Authorization": "Bearer hunter2", "X-Request-Id": "42"},
+		Conditions: []Condition{"[STATUS] == 200"},
+		Debug:      true,
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint.EvaluateHealth()
+	output := buffer.String()
+	if strings.Contains(output, "hunter2") || strings.Contains(output, "session=abc") {
+		t.Errorf("expected secrets to have been redacted, got:\n%s", output)
+	}
+	for _, expected := range []string{
+		"HTTP exchange of group=group; endpoint=name",
+		"> POST " + server.URL + "/health?api_key=<redacted> HTTP/1.1",
+		"> Authorization: <redacted>",
+		"> X-Request-Id: 42",
+		`> {"password":"<redacted>"}`,
+		"< HTTP/1.1 503 Service Unavailable",
+		"< Content-Type: application/json",
+		"< Set-Cookie: <redacted>",
+		`< {"status":"DOWN","token":"<redacted>"}`,
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("expected output to contain %q, got:\n%s", expected, output)
+		}
+	}
+	// Nothing must be logged if debug is disabled
+	buffer.Reset()
+	endpoint.Debug = false
+	endpoint.EvaluateHealth()
+	if strings.Contains(buffer.String(), "HTTP exchange") {
+		t.Errorf("expected nothing to be logged, got:\n%s", buffer.String())
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithDebugAndError(t *testing.T) {
+	var buffer bytes.Buffer
+	log.SetOutput(&buffer)
+	defer log.SetOutput(os.Stderr)
+	endpoint := Endpoint{Name: "name", URL: "http://127.0.0.1:1/health", Conditions: []Condition{"[CONNECTED] == true"}, Debug: true, UIConfig: &ui.Config{HideURL: true}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint.EvaluateHealth()
+	output := buffer.String()
+	if strings.Contains(output, endpoint.URL) {
+		t.Errorf("expected the URL to have been redacted, got:\n%s", output)
+	}
+	if !strings.Contains(output, "> GET <redacted> HTTP/1.1") || !strings.Contains(output, "connection refused") {
+		t.Errorf("expected the request and the error to have been logged, got:\n%s", output)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithDebug(t *testing.T) {
+	endpoint := Endpoint{Name: "name", URL: "tcp://127.0.0.1:22", Conditions: []Condition{"[CONNECTED] == true"}, Debug: true}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointDebugWithUnsupportedEndpointType {
+		t.Errorf("expected error %v, got %v", ErrEndpointDebugWithUnsupportedEndpointType, err)
+	}
+}
@@ -1,10 +1,15 @@
 package core
 
 import (
+	"bytes"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"strings"
 
+	"github.com/TwiN/gatus/v5/client"
 	"github.com/miekg/dns"
 )
 
@@ -14,10 +19,19 @@ var (
 
 	// ErrDNSWithInvalidQueryType is the error with which gatus will panic if a dns is configured with invalid query type
 	ErrDNSWithInvalidQueryType = errors.New("invalid query type")
+
+	// ErrDNSOverHTTPSWithInvalidMethod is the error with which gatus will panic if a dns over https endpoint is
+	// configured with a method other than GET or POST
+	ErrDNSOverHTTPSWithInvalidMethod = errors.New("method must be either GET or POST for DNS over HTTPS")
 )
 
 const (
 	dnsPort = 53
+
+	// dnsOverHTTPSPrefix is the prefix of the URL of a DNS endpoint that must be queried using DNS over HTTPS (RFC 8484)
+	dnsOverHTTPSPrefix = "doh://"
+
+	dnsMessageContentType = "application/dns-message"
 )
 
 // DNS is the configuration for a Endpoint of type DNS
@@ -46,41 +60,121 @@ func (d *DNS) query(url string, result *Result) {
 	if !strings.Contains(url, ":") {
 		url = fmt.Sprintf("%s:%d", url, dnsPort)
 	}
-	queryType := dns.StringToType[d.QueryType]
 	c := new(dns.Client)
-	m := new(dns.Msg)
-	m.SetQuestion(d.QueryName, queryType)
-	r, _, err := c.Exchange(m, url)
+	r, _, err := c.Exchange(d.buildMessage(), url)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	d.processResponse(r, result)
+}
+
+// queryOverHTTPS sends the query to a DNS over HTTPS resolver using the message format defined in RFC 8484
+//
+// The url is expected to be in the format doh://resolver/dns-query, and the method must be either GET or POST.
+func (d *DNS) queryOverHTTPS(url, method string, headers map[string]string, config *client.Config, result *Result) {
+	url = "https://" + strings.TrimPrefix(url, dnsOverHTTPSPrefix)
+	m := d.buildMessage()
+	// RFC 8484 recommends using an ID of 0 to maximize cache friendliness
+	m.Id = 0
+	packedMessage, err := m.Pack()
 	if err != nil {
 		result.AddError(err.Error())
 		return
 	}
+	var request *http.Request
+	if method == http.MethodPost {
+		request, err = http.NewRequest(http.MethodPost, url, bytes.NewBuffer(packedMessage))
+		if err == nil {
+			request.Header.Set("Content-Type", dnsMessageContentType)
+		}
+	} else {
+		separator := "?"
+		if strings.Contains(url, "?") {
+			separator = "&"
+		}
+		request, err = http.NewRequest(http.MethodGet, url+separator+"dns="+base64.RawURLEncoding.EncodeToString(packedMessage), nil)
+	}
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	request.Header.Set("Accept", dnsMessageContentType)
+	response, err := client.GetHTTPClient(config).Do(request)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	defer response.Body.Close()
+	result.HTTPStatus = response.StatusCode
+	if response.StatusCode != http.StatusOK {
+		result.AddError(fmt.Sprintf("DNS over HTTPS resolver returned status code %d", response.StatusCode))
+		return
+	}
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	r := new(dns.Msg)
+	if err = r.Unpack(body); err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	d.processResponse(r, result)
+}
+
+func (d *DNS) buildMessage() *dns.Msg {
+	m := new(dns.Msg)
+	m.SetQuestion(d.QueryName, dns.StringToType[d.QueryType])
+	return m
+}
+
+func (d *DNS) processResponse(r *dns.Msg, result *Result) {
 	result.Connected = true
 	result.DNSRCode = dns.RcodeToString[r.Rcode]
 	for _, rr := range r.Answer {
-		switch rr.Header().Rrtype {
-		case dns.TypeA:
-			if a, ok := rr.(*dns.A); ok {
-				result.Body = []byte(a.A.String())
-			}
-		case dns.TypeAAAA:
-			if aaaa, ok := rr.(*dns.AAAA); ok {
-				result.Body = []byte(aaaa.AAAA.String())
-			}
-		case dns.TypeCNAME:
-			if cname, ok := rr.(*dns.CNAME); ok {
-				result.Body = []byte(cname.Target)
-			}
-		case dns.TypeMX:
-			if mx, ok := rr.(*dns.MX); ok {
-				result.Body = []byte(mx.Mx)
-			}
-		case dns.TypeNS:
-			if ns, ok := rr.(*dns.NS); ok {
-				result.Body = []byte(ns.Ns)
-			}
-		default:
+		value, supported := dnsAnswerValue(rr)
+		if !supported {
 			result.Body = []byte("query type is not supported yet")
+			continue
+		}
+		result.DNSAnswers = append(result.DNSAnswers, value)
+		result.Body = []byte(value)
+	}
+}
+
+// dnsAnswerValue returns the value of a record from the answer section of a DNS response, and whether the type of
+// the record is supported
+func dnsAnswerValue(rr dns.RR) (string, bool) {
+	switch rr.Header().Rrtype {
+	case dns.TypeA:
+		if a, ok := rr.(*dns.A); ok {
+			return a.A.String(), true
+		}
+	case dns.TypeAAAA:
+		if aaaa, ok := rr.(*dns.AAAA); ok {
+			return aaaa.AAAA.String(), true
+		}
+	case dns.TypeCNAME:
+		if cname, ok := rr.(*dns.CNAME); ok {
+			return cname.Target, true
+		}
+	case dns.TypeMX:
+		if mx, ok := rr.(*dns.MX); ok {
+			return mx.Mx, true
+		}
+	case dns.TypeNS:
+		if ns, ok := rr.(*dns.NS); ok {
+			return ns.Ns, true
+		}
+	case dns.TypeTXT:
+		if txt, ok := rr.(*dns.TXT); ok {
+			return strings.Join(txt.Txt, ""), true
 		}
 	}
+	return "", false
 }
@@ -1,10 +1,18 @@
 package core
 
 import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
+	"github.com/TwiN/gatus/v5/client"
 	"github.com/TwiN/gatus/v5/pattern"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/miekg/dns"
 )
 
 func TestIntegrationQuery(t *testing.T) {
@@ -126,3 +134,158 @@ func TestEndpoint_ValidateAndSetDefaultsWithInvalidDNSQueryType(t *testing.T) {
 		t.Fatal("Should've returned an error because endpoint's dns query type is invalid, it needs to be a valid query name like A, AAAA, CNAME...")
 	}
 }
+
+func TestDNS_queryOverHTTPS(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	buildResponse := func(t *testing.T, request *dns.Msg) *http.Response {
+		response := new(dns.Msg)
+		response.SetReply(request)
+		switch request.Question[0].Qtype {
+		case dns.TypeA:
+			response.Answer = append(response.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: request.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: 60},
+				A:   net.ParseIP("93.184.216.34"),
+			})
+		case dns.TypeTXT:
+			response.Answer = append(response.Answer, &dns.TXT{
+				Hdr: dns.RR_Header{Name: request.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: 60},
+				Txt: []string{"v=spf1 -all"},
+			})
+		default:
+			response.Rcode = dns.RcodeNameError
+		}
+		packedResponse, err := response.Pack()
+		if err != nil {
+			t.Fatal("failed to pack response:", err)
+		}
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(packedResponse))}
+	}
+	scenarios := []struct {
+		Name             string
+		DNS              DNS
+		Method           string
+		MockRoundTripper test.MockRoundTripper
+		ExpectedDNSCode  string
+		ExpectedBody     string
+		ExpectedError    bool
+	}{
+		{
+			Name:   "get",
+			DNS:    DNS{QueryType: "A", QueryName: "example.com."},
+			Method: http.MethodGet,
+			MockRoundTripper: func(r *http.Request) *http.Response {
+				if r.Method != http.MethodGet || r.URL.Host != "dns.google" || r.URL.Path != "/dns-query" {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				if r.Header.Get("Accept") != dnsMessageContentType {
+					return &http.Response{StatusCode: http.StatusNotAcceptable, Body: http.NoBody}
+				}
+				packedRequest, err := base64.RawURLEncoding.DecodeString(r.URL.Query().Get("dns"))
+				if err != nil {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				request := new(dns.Msg)
+				if err := request.Unpack(packedRequest); err != nil {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				return buildResponse(t, request)
+			},
+			ExpectedDNSCode: "NOERROR",
+			ExpectedBody:    "93.184.216.34",
+		},
+		{
+			Name:   "post",
+			DNS:    DNS{QueryType: "TXT", QueryName: "example.com."},
+			Method: http.MethodPost,
+			MockRoundTripper: func(r *http.Request) *http.Response {
+				if r.Method != http.MethodPost || r.Header.Get("Content-Type") != dnsMessageContentType {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				packedRequest, _ := io.ReadAll(r.Body)
+				request := new(dns.Msg)
+				if err := request.Unpack(packedRequest); err != nil {
+					return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+				}
+				return buildResponse(t, request)
+			},
+			ExpectedDNSCode: "NOERROR",
+			ExpectedBody:    "v=spf1 -all",
+		},
+		{
+			Name:   "nxdomain",
+			DNS:    DNS{QueryType: "MX", QueryName: "example.com."},
+			Method: http.MethodPost,
+			MockRoundTripper: func(r *http.Request) *http.Response {
+				packedRequest, _ := io.ReadAll(r.Body)
+				request := new(dns.Msg)
+				_ = request.Unpack(packedRequest)
+				return buildResponse(t, request)
+			},
+			ExpectedDNSCode: "NXDOMAIN",
+		},
+		{
+			Name:   "error-status-code",
+			DNS:    DNS{QueryType: "A", QueryName: "example.com."},
+			Method: http.MethodGet,
+			MockRoundTripper: func(r *http.Request) *http.Response {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Body: http.NoBody}
+			},
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			client.InjectHTTPClient(&http.Client{Transport: scenario.MockRoundTripper})
+			result := &Result{}
+			scenario.DNS.queryOverHTTPS("doh://dns.google/dns-query", scenario.Method, nil, nil, result)
+			if scenario.ExpectedError != (len(result.Errors) > 0) {
+				t.Errorf("expected error to be %v, got errors %v", scenario.ExpectedError, result.Errors)
+			}
+			if result.DNSRCode != scenario.ExpectedDNSCode {
+				t.Errorf("expected DNSRCode to be %s, got %s", scenario.ExpectedDNSCode, result.DNSRCode)
+			}
+			if string(result.Body) != scenario.ExpectedBody {
+				t.Errorf("expected body to be %s, got %s", scenario.ExpectedBody, string(result.Body))
+			}
+		})
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithDNSOverHTTPSAndInvalidMethod(t *testing.T) {
+	endpoint := &Endpoint{
+		Name:       "doh",
+		URL:        "doh://dns.google/dns-query",
+		Method:     http.MethodPut,
+		DNS:        &DNS{QueryType: "A", QueryName: "example.com"},
+		Conditions: []Condition{"[DNS_RCODE] == NOERROR"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrDNSOverHTTPSWithInvalidMethod {
+		t.Fatalf("expected error %v, got %v", ErrDNSOverHTTPSWithInvalidMethod, err)
+	}
+	endpoint.Method = ""
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err)
+	}
+	if endpoint.Method != http.MethodGet {
+		t.Errorf("expected method to default to GET, got %s", endpoint.Method)
+	}
+}
+
+func TestDNS_processResponse(t *testing.T) {
+	response := new(dns.Msg)
+	response.Answer = []dns.RR{
+		&dns.A{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP("203.0.113.10")},
+		&dns.A{Hdr: dns.RR_Header{Name: "example.com.", Rrtype: dns.TypeA, Class: dns.ClassINET}, A: net.ParseIP("203.0.113.11")},
+	}
+	result := &Result{}
+	(&DNS{QueryType: "A", QueryName: "example.com."}).processResponse(response, result)
+	if result.DNSRCode != "NOERROR" {
+		t.Errorf("expected DNSRCode to be NOERROR, got %s", result.DNSRCode)
+	}
+	if len(result.DNSAnswers) != 2 || result.DNSAnswers[0] != "203.0.113.10" || result.DNSAnswers[1] != "203.0.113.11" {
+		t.Errorf("expected DNSAnswers to be [203.0.113.10 203.0.113.11], got %v", result.DNSAnswers)
+	}
+	if string(result.Body) != "203.0.113.11" {
+		t.Errorf("expected body to be the last answer, got %s", string(result.Body))
+	}
+}
@@ -2,19 +2,25 @@ package core
 
 import (
 	"bytes"
-	"crypto/x509"
-	"encoding/json"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
+	"net/http/httptrace"
 	"net/url"
+	"os"
 	"strings"
+	"text/template"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting/alert"
 	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/config/maintenance"
 	"github.com/TwiN/gatus/v5/core/ui"
 	"github.com/TwiN/gatus/v5/util"
 )
@@ -34,6 +40,10 @@ const (
 	// GatusUserAgent is the default user agent that Gatus uses to send requests.
 	GatusUserAgent = "Gatus/1.0"
 
+	// maximumDrainedBodySize is the maximum number of bytes of a response body discarded so that the connection can be
+	// reused. See client.Config.KeepAlive.
+	maximumDrainedBodySize = 256 * 1024
+
 	EndpointTypeDNS      EndpointType = "DNS"
 	EndpointTypeTCP      EndpointType = "TCP"
 	EndpointTypeSCTP     EndpointType = "SCTP"
@@ -43,6 +53,10 @@ const (
 	EndpointTypeTLS      EndpointType = "TLS"
 	EndpointTypeHTTP     EndpointType = "HTTP"
 	EndpointTypeWS       EndpointType = "WEBSOCKET"
+	EndpointTypeIMAP     EndpointType = "IMAP"
+	EndpointTypePOP3     EndpointType = "POP3"
+	EndpointTypeSSH      EndpointType = "SSH"
+	EndpointTypeEXEC     EndpointType = "EXEC"
 	EndpointTypeUNKNOWN  EndpointType = "UNKNOWN"
 )
 
@@ -65,11 +79,58 @@ var (
 	// ErrInvalidConditionFormat is the error with which Gatus will panic if a condition has an invalid format
 	ErrInvalidConditionFormat = errors.New("invalid condition format: does not match '<VALUE> <COMPARATOR> <VALUE>'")
 
+	// ErrEndpointWithInvalidTimeout is the error with which Gatus will panic if an endpoint has a negative timeout
+	ErrEndpointWithInvalidTimeout = errors.New("endpoint timeout must not be negative")
+
+	// ErrEndpointWithInvalidStartupGrace is the error with which Gatus will panic if an endpoint has a negative startup
+	// grace
+	ErrEndpointWithInvalidStartupGrace = errors.New("endpoint startup-grace must not be negative")
+
+	// ErrEndpointWithInvalidExpectedFailureStatus is the error with which Gatus will panic if one of the expected
+	// failure statuses of an endpoint is not a valid HTTP status
+	ErrEndpointWithInvalidExpectedFailureStatus = errors.New("endpoint expected-failure-statuses must only contain HTTP statuses between 100 and 599")
+
+	// ErrEndpointWithBodyAndBodyFile is the error with which Gatus will panic if an endpoint has both a body and a body
+	// file
+	ErrEndpointWithBodyAndBodyFile = errors.New("body and body-file are mutually exclusive")
+
+	// ErrEndpointWithInvalidHeaderTemplate is the error with which Gatus will panic if the value of a header of an
+	// endpoint has template actions that cannot be parsed
+	ErrEndpointWithInvalidHeaderTemplate = errors.New("endpoint header has an invalid template")
+
+	// ErrEndpointTimeoutWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that is not
+	// of type HTTP has a timeout. Other endpoint types only support client.timeout.
+	ErrEndpointTimeoutWithUnsupportedEndpointType = errors.New("timeout is only supported for endpoints of type HTTP; use client.timeout instead")
+
+	// ErrEndpointDebugWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that is not of
+	// type HTTP has debug enabled
+	ErrEndpointDebugWithUnsupportedEndpointType = errors.New("debug is only supported for endpoints of type HTTP")
+
+	// ErrEndpointUnknownOnNetworkErrorWithUnsupportedEndpointType is the error with which Gatus will panic if an
+	// endpoint that is not of type HTTP has unknown-on-network-error enabled
+	ErrEndpointUnknownOnNetworkErrorWithUnsupportedEndpointType = errors.New("unknown-on-network-error is only supported for endpoints of type HTTP")
+
+	// ErrEndpointIPVersionWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint whose
+	// connections cannot be restricted to an IP version has a client.ip-version
+	ErrEndpointIPVersionWithUnsupportedEndpointType = errors.New("client.ip-version is only supported for endpoints of type HTTP, TCP, UDP, TLS, STARTTLS, ICMP, WS and SSH")
+
 	// ErrInvalidEndpointIntervalForDomainExpirationPlaceholder is the error with which Gatus will panic if an endpoint
 	// has both an interval smaller than 5 minutes and a condition with DomainExpirationPlaceholder.
 	// This is because the free whois service we are using should not be abused, especially considering the fact that
 	// the data takes a while to be updated.
 	ErrInvalidEndpointIntervalForDomainExpirationPlaceholder = errors.New("the minimum interval for an endpoint with a condition using the " + DomainExpirationPlaceholder + " placeholder is 300s (5m)")
+
+	// ErrEndpointWithAlertScopedToUnknownCondition is the error with which Gatus will panic if an alert is scoped to a
+	// condition or a placeholder that doesn't match any of the conditions of its endpoint
+	ErrEndpointWithAlertScopedToUnknownCondition = errors.New("alert conditions must match at least one of the conditions of the endpoint")
+
+	// ErrEndpointWithInvalidAgentFailureThreshold is the error with which Gatus will panic if an endpoint has an agent
+	// failure threshold that is negative, or greater than its number of agents
+	ErrEndpointWithInvalidAgentFailureThreshold = errors.New("endpoint agent-failure-threshold must be between 1 and the number of agents")
+
+	// ErrEndpointWithInvalidConditionLogic is the error with which Gatus will panic if an endpoint has a condition
+	// logic other than all and any
+	ErrEndpointWithInvalidConditionLogic = errors.New("endpoint condition-logic must be either all or any")
 )
 
 // Endpoint is the configuration of a monitored
@@ -89,14 +150,33 @@ type Endpoint struct {
 	// DNS is the configuration of DNS monitoring
 	DNS *DNS `yaml:"dns,omitempty"`
 
+	// Mailbox is the configuration of IMAP and POP3 monitoring
+	Mailbox *Mailbox `yaml:"mailbox,omitempty"`
+
+	// SSH is the configuration of SSH monitoring
+	SSH *SSH `yaml:"ssh,omitempty"`
+
+	// Exec is the configuration of the command executed by endpoints of type EXEC
+	Exec *Exec `yaml:"exec,omitempty"`
+
 	// Method of the request made to the url of the endpoint
 	Method string `yaml:"method,omitempty"`
 
 	// Body of the request
 	Body string `yaml:"body,omitempty"`
 
-	// GraphQL is whether to wrap the body in a query param ({"query":"$body"})
-	GraphQL bool `yaml:"graphql,omitempty"`
+	// BodyFile is the path to a file containing the body of the request
+	//
+	// The file is read when the configuration is loaded, and its content replaces Body.
+	BodyFile string `yaml:"body-file,omitempty"`
+
+	// GraphQL is the configuration for querying the endpoint using GraphQL
+	//
+	// If set to true rather than to a map, the body is wrapped in a query param ({"query":"$body"})
+	GraphQL *GraphQL `yaml:"graphql,omitempty"`
+
+	// JWT is the configuration for validating the JSON Web Token returned in the response
+	JWT *JWT `yaml:"jwt,omitempty"`
 
 	// Headers of the request
 	Headers map[string]string `yaml:"headers,omitempty"`
@@ -104,9 +184,49 @@ type Endpoint struct {
 	// Interval is the duration to wait between every status check
 	Interval time.Duration `yaml:"interval,omitempty"`
 
+	// Timeout is the deadline for the entire request, including reading the response body.
+	//
+	// It is applied to each check through the request's context. When set, client.timeout only applies to
+	// establishing the connection. If only one of the two is set, it is used for both.
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+
+	// StartupGrace is the duration after Gatus starts during which the results of the endpoint are recorded, but
+	// don't trigger alerts, giving the dependencies of the endpoint time to come up.
+	// If 0, the startup-grace of the configuration is used.
+	StartupGrace time.Duration `yaml:"startup-grace,omitempty"`
+
+	// Debug is whether to log the request sent and the response received by each check of the endpoint, with their
+	// secrets redacted, which helps figuring out why a condition fails. HTTP only.
+	Debug bool `yaml:"debug,omitempty"`
+
+	// ExpectedFailureStatuses are the HTTP statuses with which a failed result is an expected failure, which is
+	// recorded as a failure but doesn't trigger alerts (e.g. 401 for a health check protected by authentication)
+	ExpectedFailureStatuses []int `yaml:"expected-failure-statuses,omitempty"`
+
+	// UnknownOnNetworkError defines whether a result that failed because the request couldn't be sent (e.g. DNS
+	// resolution failure, connection refused, timeout) is unknown rather than a failure of the endpoint itself.
+	// HTTP only.
+	//
+	// Unknown results are still recorded as unsuccessful, but don't trigger alerts unless AlertOnUnknown is set.
+	UnknownOnNetworkError bool `yaml:"unknown-on-network-error,omitempty"`
+
+	// AlertOnUnknown defines whether unknown results count towards the failure threshold of the alerts of the endpoint
+	AlertOnUnknown bool `yaml:"alert-on-unknown,omitempty"`
+
 	// Conditions used to determine the health of the endpoint
 	Conditions []Condition `yaml:"conditions"`
 
+	// ConditionLogic is how the outcomes of the conditions are combined: either every condition must pass (all), or
+	// at least one of them (any). Defaults to ConditionLogicAll.
+	ConditionLogic ConditionLogic `yaml:"condition-logic,omitempty"`
+
+	// ResponseTimeTrend is the configuration of the detection of an increasing response time, used by the conditions
+	// comparing the ResponseTimePlaceholder with IncreasingValue
+	ResponseTimeTrend *ResponseTimeTrendConfig `yaml:"response-time-trend,omitempty"`
+
+	// AllIPs is the configuration for sending the request to every IP the hostname resolves to, instead of only one
+	AllIPs *AllIPs `yaml:"all-ips,omitempty"`
+
 	// Alerts is the alerting configuration for the endpoint in case of failure
 	Alerts []*alert.Alert `yaml:"alerts,omitempty"`
 
@@ -116,11 +236,68 @@ type Endpoint struct {
 	// UIConfig is the configuration for the UI
 	UIConfig *ui.Config `yaml:"ui,omitempty"`
 
+	// MaintenanceWindows is a list of maintenance windows during which no alerts are sent for the endpoint, nor for the
+	// endpoints that depend on it
+	MaintenanceWindows []*maintenance.Config `yaml:"maintenance-windows,omitempty"`
+
+	// DependsOn is a list of keys of the endpoints the endpoint depends on (e.g. core_database).
+	// No alerts are sent for the endpoint while one of them is under maintenance.
+	DependsOn []string `yaml:"depends-on,omitempty"`
+
+	// Agents are the names of the agents that perform the checks of the endpoint, in which case the endpoint isn't
+	// checked by Gatus itself, and the result of each check is reported by the agent that performed it
+	Agents []string `yaml:"agents,omitempty"`
+
+	// AgentFailureThreshold is the number of agents whose most recent result must be unsuccessful for the endpoint to
+	// be considered unhealthy by its alerts. Defaults to 1.
+	AgentFailureThreshold int `yaml:"agent-failure-threshold,omitempty"`
+
 	// NumberOfFailuresInARow is the number of unsuccessful evaluations in a row
 	NumberOfFailuresInARow int `yaml:"-"`
 
 	// NumberOfSuccessesInARow is the number of successful evaluations in a row
 	NumberOfSuccessesInARow int `yaml:"-"`
+
+	// Untrusted is whether the endpoint was defined by a client of the API rather than in the configuration, in which
+	// case the templates of its headers can neither read environment variables nor files
+	Untrusted bool `yaml:"-"`
+
+	// responseTimeBaseline is the baseline of the recent response times of the endpoint
+	//
+	// See SetResponseTimeBaseline
+	responseTimeBaseline *ResponseTimeBaseline
+
+	// previousResponseTimes are the response times of the previous results of the endpoint
+	//
+	// See SetPreviousResponseTimes
+	previousResponseTimes []time.Duration
+
+	// lastETag and lastModified are the validators of the last response that wasn't 304 Not Modified. They are sent
+	// with the next request so that the server only has to send the resource again if it changed.
+	//
+	// See needsConditionalRequest
+	lastETag     string
+	lastModified string
+
+	// dependencies are the endpoints referenced by DependsOn
+	//
+	// See SetDependencies
+	dependencies []*Endpoint
+
+	// headerTemplates are the parsed templates of the values of the headers that have template actions, by header name
+	//
+	// See renderHeaders
+	headerTemplates map[string]*template.Template
+}
+
+// IsCheckedByAgent returns whether the checks of the endpoint are performed by the agent with the name passed
+func (endpoint *Endpoint) IsCheckedByAgent(name string) bool {
+	for _, agentName := range endpoint.Agents {
+		if agentName == name {
+			return true
+		}
+	}
+	return false
 }
 
 // IsEnabled returns whether the endpoint is enabled or not
@@ -152,6 +329,14 @@ func (endpoint Endpoint) Type() EndpointType {
 		return EndpointTypeHTTP
 	case strings.HasPrefix(endpoint.URL, "ws://") || strings.HasPrefix(endpoint.URL, "wss://"):
 		return EndpointTypeWS
+	case strings.HasPrefix(endpoint.URL, "imap://") || strings.HasPrefix(endpoint.URL, "imaps://"):
+		return EndpointTypeIMAP
+	case strings.HasPrefix(endpoint.URL, "pop3://") || strings.HasPrefix(endpoint.URL, "pop3s://"):
+		return EndpointTypePOP3
+	case strings.HasPrefix(endpoint.URL, "ssh://"):
+		return EndpointTypeSSH
+	case strings.HasPrefix(endpoint.URL, execPrefix):
+		return EndpointTypeEXEC
 	default:
 		return EndpointTypeUNKNOWN
 	}
@@ -160,13 +345,58 @@ func (endpoint Endpoint) Type() EndpointType {
 // ValidateAndSetDefaults validates the endpoint's configuration and sets the default value of args that have one
 func (endpoint *Endpoint) ValidateAndSetDefaults() error {
 	// Set default values
+	if endpoint.Timeout < 0 {
+		return ErrEndpointWithInvalidTimeout
+	}
+	if endpoint.StartupGrace < 0 {
+		return ErrEndpointWithInvalidStartupGrace
+	}
+	for _, status := range endpoint.ExpectedFailureStatuses {
+		if status < 100 || status > 599 {
+			return ErrEndpointWithInvalidExpectedFailureStatus
+		}
+	}
+	if endpoint.ResponseTimeTrend != nil || endpoint.NeedsPreviousResponseTimes() {
+		if endpoint.ResponseTimeTrend == nil {
+			endpoint.ResponseTimeTrend = &ResponseTimeTrendConfig{}
+		}
+		if err := endpoint.ResponseTimeTrend.ValidateAndSetDefaults(); err != nil {
+			return err
+		}
+	}
 	if endpoint.ClientConfig == nil {
 		endpoint.ClientConfig = client.GetDefaultConfig()
+		if endpoint.Timeout > 0 {
+			endpoint.ClientConfig.Timeout = endpoint.Timeout
+		}
 	} else {
+		if endpoint.ClientConfig.Timeout == 0 && endpoint.Timeout > 0 {
+			endpoint.ClientConfig.Timeout = endpoint.Timeout
+		}
 		if err := endpoint.ClientConfig.ValidateAndSetDefaults(); err != nil {
 			return err
 		}
 	}
+	if endpoint.Timeout > 0 {
+		// The deadline of the request is enforced through its context, so the client's timeout must only apply to
+		// establishing the connection
+		endpoint.ClientConfig.TransportTimeoutOnly = true
+	}
+	for _, maintenanceWindow := range endpoint.MaintenanceWindows {
+		if err := maintenanceWindow.ValidateAndSetDefaults(); err != nil {
+			return fmt.Errorf("invalid maintenance window: %w", err)
+		}
+	}
+	if len(endpoint.BodyFile) > 0 {
+		if len(endpoint.Body) > 0 {
+			return ErrEndpointWithBodyAndBodyFile
+		}
+		body, err := os.ReadFile(endpoint.BodyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read body file: %w", err)
+		}
+		endpoint.Body = string(body)
+	}
 	if endpoint.UIConfig == nil {
 		endpoint.UIConfig = ui.GetDefaultConfig()
 	} else {
@@ -178,20 +408,32 @@ func (endpoint *Endpoint) ValidateAndSetDefaults() error {
 		endpoint.Interval = 1 * time.Minute
 	}
 	if len(endpoint.Method) == 0 {
-		endpoint.Method = http.MethodGet
+		// For backward compatibility, endpoints configured with `graphql: true` still default to GET
+		if endpoint.GraphQL.IsEnabled() && endpoint.GraphQL.Enabled == nil {
+			endpoint.Method = http.MethodPost
+		} else {
+			endpoint.Method = http.MethodGet
+		}
 	}
 	if len(endpoint.Headers) == 0 {
 		endpoint.Headers = make(map[string]string)
 	}
 	// Automatically add user agent header if there isn't one specified in the endpoint configuration
 	if _, userAgentHeaderExists := endpoint.Headers[UserAgentHeader]; !userAgentHeaderExists {
-		endpoint.Headers[UserAgentHeader] = GatusUserAgent
+		if len(endpoint.ClientConfig.UserAgent) > 0 {
+			endpoint.Headers[UserAgentHeader] = endpoint.ClientConfig.UserAgent
+		} else {
+			endpoint.Headers[UserAgentHeader] = GatusUserAgent
+		}
 	}
 	// Automatically add "Content-Type: application/json" header if there's no Content-Type set
-	// and endpoint.GraphQL is set to true
-	if _, contentTypeHeaderExists := endpoint.Headers[ContentTypeHeader]; !contentTypeHeaderExists && endpoint.GraphQL {
+	// and endpoint.GraphQL is enabled
+	if _, contentTypeHeaderExists := endpoint.Headers[ContentTypeHeader]; !contentTypeHeaderExists && endpoint.GraphQL.IsEnabled() {
 		endpoint.Headers[ContentTypeHeader] = "application/json"
 	}
+	if err := endpoint.parseHeaderTemplates(!endpoint.Untrusted); err != nil {
+		return err
+	}
 	for _, endpointAlert := range endpoint.Alerts {
 		if err := endpointAlert.ValidateAndSetDefaults(); err != nil {
 			return err
@@ -217,7 +459,90 @@ func (endpoint *Endpoint) ValidateAndSetDefaults() error {
 			return fmt.Errorf("%v: %w", ErrInvalidConditionFormat, err)
 		}
 	}
+	if len(endpoint.ConditionLogic) == 0 {
+		endpoint.ConditionLogic = ConditionLogicAll
+	} else if !endpoint.ConditionLogic.isValid() {
+		return ErrEndpointWithInvalidConditionLogic
+	}
+	for _, endpointAlert := range endpoint.Alerts {
+		for _, scope := range endpointAlert.Conditions {
+			if !endpoint.hasConditionMatchingScope(scope) {
+				return fmt.Errorf("%w: %s", ErrEndpointWithAlertScopedToUnknownCondition, scope)
+			}
+		}
+	}
+	if endpoint.GraphQL.IsEnabled() {
+		if err := endpoint.GraphQL.validateAndSetDefault(endpoint.Body); err != nil {
+			return err
+		}
+	}
+	if endpoint.Timeout > 0 && endpoint.Type() != EndpointTypeHTTP {
+		return ErrEndpointTimeoutWithUnsupportedEndpointType
+	}
+	if endpoint.Debug && endpoint.Type() != EndpointTypeHTTP {
+		return ErrEndpointDebugWithUnsupportedEndpointType
+	}
+	if endpoint.UnknownOnNetworkError && endpoint.Type() != EndpointTypeHTTP {
+		return ErrEndpointUnknownOnNetworkErrorWithUnsupportedEndpointType
+	}
+	if len(endpoint.Agents) > 0 && endpoint.AgentFailureThreshold == 0 {
+		endpoint.AgentFailureThreshold = 1
+	} else if endpoint.AgentFailureThreshold < 0 || endpoint.AgentFailureThreshold > len(endpoint.Agents) {
+		return ErrEndpointWithInvalidAgentFailureThreshold
+	}
+	if endpoint.ClientConfig.HasIPVersion() {
+		switch endpoint.Type() {
+		case EndpointTypeHTTP, EndpointTypeTCP, EndpointTypeUDP, EndpointTypeTLS, EndpointTypeSTARTTLS, EndpointTypeICMP, EndpointTypeWS, EndpointTypeSSH:
+		default:
+			return ErrEndpointIPVersionWithUnsupportedEndpointType
+		}
+	}
+	if endpoint.AllIPs.IsEnabled() {
+		if endpoint.Type() != EndpointTypeHTTP {
+			return ErrAllIPsWithUnsupportedEndpointType
+		}
+		if err := endpoint.AllIPs.validateAndSetDefault(); err != nil {
+			return err
+		}
+	}
+	if endpoint.Mailbox != nil {
+		if endpoint.Type() != EndpointTypeIMAP && endpoint.Type() != EndpointTypePOP3 {
+			return ErrMailboxWithUnsupportedEndpointType
+		}
+		if err := endpoint.Mailbox.validateAndSetDefault(endpoint.URL); err != nil {
+			return err
+		}
+	}
+	if endpoint.SSH != nil {
+		if endpoint.Type() != EndpointTypeSSH {
+			return ErrSSHWithUnsupportedEndpointType
+		}
+		if err := endpoint.SSH.validateAndSetDefault(); err != nil {
+			return err
+		}
+	}
+	if endpoint.JWT != nil {
+		if endpoint.Type() != EndpointTypeHTTP {
+			return ErrJWTWithUnsupportedEndpointType
+		}
+		if err := endpoint.JWT.validateAndSetDefault(endpoint.ClientConfig); err != nil {
+			return err
+		}
+	} else if endpoint.needsJWT() {
+		return ErrJWTPlaceholderWithNoJWT
+	}
+	if endpoint.Exec != nil && endpoint.Type() != EndpointTypeEXEC {
+		return ErrExecWithUnsupportedEndpointType
+	}
+	if endpoint.Type() == EndpointTypeEXEC {
+		if err := endpoint.Exec.validateAndSetDefault(endpoint.URL); err != nil {
+			return err
+		}
+	}
 	if endpoint.DNS != nil {
+		if endpoint.isDNSOverHTTPS() && endpoint.Method != http.MethodGet && endpoint.Method != http.MethodPost {
+			return ErrDNSOverHTTPSWithInvalidMethod
+		}
 		return endpoint.DNS.validateAndSetDefault()
 	}
 	if endpoint.Type() == EndpointTypeUNKNOWN {
@@ -231,6 +556,18 @@ func (endpoint *Endpoint) ValidateAndSetDefaults() error {
 	return nil
 }
 
+// ReferencedFiles returns the paths of the files that the configuration of the endpoint is read from
+func (endpoint *Endpoint) ReferencedFiles() []string {
+	var files []string
+	if len(endpoint.BodyFile) > 0 {
+		files = append(files, endpoint.BodyFile)
+	}
+	if endpoint.ClientConfig != nil {
+		files = append(files, endpoint.ClientConfig.ReferencedFiles()...)
+	}
+	return files
+}
+
 // DisplayName returns an identifier made up of the Name and, if not empty, the Group.
 func (endpoint Endpoint) DisplayName() string {
 	if len(endpoint.Group) > 0 {
@@ -240,23 +577,37 @@ func (endpoint Endpoint) DisplayName() string {
 }
 
 // Key returns the unique key for the Endpoint
-func (endpoint Endpoint) Key() string {
+func (endpoint *Endpoint) Key() string {
 	return util.ConvertGroupAndEndpointNameToKey(endpoint.Group, endpoint.Name)
 }
 
+// Hostname returns the hostname targeted by the endpoint, which is extracted from its URL
+func (endpoint *Endpoint) Hostname() (string, error) {
+	if endpoint.isDNSOverHTTPS() {
+		urlObject, err := url.Parse("https://" + strings.TrimPrefix(endpoint.URL, dnsOverHTTPSPrefix))
+		if err != nil {
+			return "", err
+		}
+		return urlObject.Hostname(), nil
+	}
+	if endpoint.DNS != nil {
+		return strings.TrimSuffix(endpoint.URL, ":53"), nil
+	}
+	urlObject, err := url.Parse(endpoint.URL)
+	if err != nil {
+		return "", err
+	}
+	return urlObject.Hostname(), nil
+}
+
 // EvaluateHealth sends a request to the endpoint's URL and evaluates the conditions of the endpoint.
 func (endpoint *Endpoint) EvaluateHealth() *Result {
-	result := &Result{Success: true, Errors: []string{}}
+	result := &Result{Success: true, Errors: []string{}, responseTimeBaseline: endpoint.responseTimeBaseline, previousResponseTimes: endpoint.previousResponseTimes, responseTimeTrendConfig: endpoint.ResponseTimeTrend}
 	// Parse or extract hostname from URL
-	if endpoint.DNS != nil {
-		result.Hostname = strings.TrimSuffix(endpoint.URL, ":53")
+	if hostname, err := endpoint.Hostname(); err != nil {
+		result.AddError(err.Error())
 	} else {
-		urlObject, err := url.Parse(endpoint.URL)
-		if err != nil {
-			result.AddError(err.Error())
-		} else {
-			result.Hostname = urlObject.Hostname()
-		}
+		result.Hostname = hostname
 	}
 	// Retrieve IP if necessary
 	if endpoint.needsToRetrieveIP() {
@@ -269,19 +620,20 @@ func (endpoint *Endpoint) EvaluateHealth() *Result {
 			result.AddError(err.Error())
 		}
 	}
-	// Call the endpoint (if there's no errors)
-	if len(result.Errors) == 0 {
-		endpoint.call(result)
+	if endpoint.AllIPs.IsEnabled() && len(result.Errors) == 0 {
+		endpoint.evaluateHealthOfEachIP(result)
 	} else {
-		result.Success = false
-	}
-	// Evaluate the conditions
-	for _, condition := range endpoint.Conditions {
-		success := condition.evaluate(result, endpoint.UIConfig.DontResolveFailedConditions)
-		if !success {
+		// Call the endpoint (if there's no errors)
+		if len(result.Errors) == 0 {
+			endpoint.call(result)
+			endpoint.getReverseDNS(result)
+		} else {
 			result.Success = false
 		}
+		endpoint.evaluateConditions(result)
 	}
+	result.ExpectedFailure = !result.Success && endpoint.isExpectedFailureStatus(result.HTTPStatus)
+	result.Unknown = !result.Success && result.networkError && endpoint.UnknownOnNetworkError
 	result.Timestamp = time.Now()
 	// Clean up parameters that we don't need to keep in the results
 	if endpoint.UIConfig.HideURL {
@@ -298,45 +650,201 @@ func (endpoint *Endpoint) EvaluateHealth() *Result {
 	return result
 }
 
+// isExpectedFailureStatus returns whether the HTTP status passed is one of the expected failure statuses of the endpoint
+func (endpoint *Endpoint) isExpectedFailureStatus(status int) bool {
+	for _, expectedFailureStatus := range endpoint.ExpectedFailureStatuses {
+		if status == expectedFailureStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// hasConditionMatchingScope returns whether at least one of the conditions of the endpoint is the condition, or contains
+// the placeholder, passed
+func (endpoint *Endpoint) hasConditionMatchingScope(scope string) bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.matchesScope(scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// evaluateConditions evaluates the conditions of the endpoint against the result passed, and combines their outcomes
+// according to the condition logic of the endpoint
+func (endpoint *Endpoint) evaluateConditions(result *Result) {
+	var successfulConditions int
+	for _, condition := range endpoint.Conditions {
+		if condition.evaluate(result, endpoint.UIConfig.DontResolveFailedConditions) {
+			successfulConditions++
+		}
+	}
+	if !endpoint.ConditionLogic.isSatisfied(successfulConditions, len(endpoint.Conditions)) {
+		result.Success = false
+	}
+}
+
+// evaluateHealthOfEachIP sends the request to every IP the hostname resolves to and evaluates the conditions for each
+// of them individually.
+//
+// The result passed is successful if the quorum is reached, and the per-condition outcomes of the result are those of
+// an IP that failed, if the quorum isn't reached, or of an IP that succeeded otherwise.
+func (endpoint *Endpoint) evaluateHealthOfEachIP(result *Result) {
+	ips, err := client.LookupIP(result.Hostname, endpoint.ClientConfig)
+	if err != nil {
+		result.AddError(err.Error())
+	} else if len(ips) == 0 {
+		result.AddError("no IP found for " + result.Hostname)
+	}
+	if len(result.Errors) > 0 {
+		result.Success = false
+		endpoint.evaluateConditions(result)
+		return
+	}
+	var successfulIPResult, failedIPResult *Result
+	var failedIPs []string
+	for _, ip := range ips {
+		ipResult := &Result{Success: true, Errors: []string{}, Hostname: result.Hostname, IP: ip.String(), DomainExpiration: result.DomainExpiration, responseTimeBaseline: result.responseTimeBaseline, previousResponseTimes: result.previousResponseTimes, responseTimeTrendConfig: result.responseTimeTrendConfig}
+		endpoint.call(ipResult)
+		endpoint.getReverseDNS(ipResult)
+		if len(ipResult.Errors) > 0 {
+			ipResult.Success = false
+		}
+		endpoint.evaluateConditions(ipResult)
+		result.IPResults = append(result.IPResults, &IPResult{
+			IP:         ipResult.IP,
+			HTTPStatus: ipResult.HTTPStatus,
+			Duration:   ipResult.Duration,
+			Errors:     ipResult.Errors,
+			Success:    ipResult.Success,
+		})
+		if ipResult.Success {
+			if successfulIPResult == nil {
+				successfulIPResult = ipResult
+			}
+		} else {
+			failedIPs = append(failedIPs, ipResult.IP)
+			if failedIPResult == nil {
+				failedIPResult = ipResult
+			}
+		}
+	}
+	result.Success = endpoint.AllIPs.isQuorumReached(len(ips)-len(failedIPs), len(ips))
+	representativeIPResult := successfulIPResult
+	if !result.Success || representativeIPResult == nil {
+		representativeIPResult = failedIPResult
+	}
+	result.HTTPStatus = representativeIPResult.HTTPStatus
+	result.Headers = representativeIPResult.Headers
+	result.Connected = representativeIPResult.Connected
+	result.networkError = representativeIPResult.networkError
+	result.Duration = representativeIPResult.Duration
+	result.CertificateExpiration = representativeIPResult.CertificateExpiration
+	result.CertificateChainExpiration = representativeIPResult.CertificateChainExpiration
+	result.CertificateFingerprint = representativeIPResult.CertificateFingerprint
+	result.CertificatePublicKeyFingerprint = representativeIPResult.CertificatePublicKeyFingerprint
+	result.CertificateKeySize = representativeIPResult.CertificateKeySize
+	result.CertificateSignatureAlgorithm = representativeIPResult.CertificateSignatureAlgorithm
+	result.TLSVersion = representativeIPResult.TLSVersion
+	result.TLSCipher = representativeIPResult.TLSCipher
+	result.Body = representativeIPResult.Body
+	result.IP = representativeIPResult.IP
+	result.ConditionResults = representativeIPResult.ConditionResults
+	for _, ipResultError := range representativeIPResult.Errors {
+		result.AddError(ipResultError)
+	}
+	if len(failedIPs) > 0 {
+		result.AddError(fmt.Sprintf("%d of %d IPs failed: %s", len(failedIPs), len(ips), strings.Join(failedIPs, ", ")))
+	}
+}
+
 func (endpoint *Endpoint) getIP(result *Result) {
-	if ips, err := net.LookupIP(result.Hostname); err != nil {
+	if ips, err := client.LookupIP(result.Hostname, endpoint.ClientConfig); err != nil {
 		result.AddError(err.Error())
+		result.networkError = isNetworkError(err)
 		return
 	} else {
 		result.IP = ips[0].String()
 	}
 }
 
+// getReverseDNS retrieves the name that the IP of the connection resolves to, if a condition requires it
+func (endpoint *Endpoint) getReverseDNS(result *Result) {
+	ip := result.connectedOrResolvedIP()
+	if !endpoint.needsToRetrieveReverseDNS() || len(ip) == 0 {
+		return
+	}
+	var err error
+	if result.ReverseDNS, err = client.LookupReverseDNS(ip); err != nil {
+		result.AddError(err.Error())
+	}
+}
+
 func (endpoint *Endpoint) call(result *Result) {
 	var request *http.Request
 	var response *http.Response
 	var err error
-	var certificate *x509.Certificate
+	var tlsConnectionState *tls.ConnectionState
 	endpointType := endpoint.Type()
+	needsConditionalRequest := endpointType == EndpointTypeHTTP && endpoint.needsConditionalRequest()
 	if endpointType == EndpointTypeHTTP {
-		request = endpoint.buildHTTPRequest()
+		if request, err = endpoint.buildHTTPRequest(); err != nil {
+			result.AddError(err.Error())
+			return
+		}
+		if needsConditionalRequest {
+			if len(endpoint.lastETag) > 0 {
+				request.Header.Set("If-None-Match", endpoint.lastETag)
+			}
+			if len(endpoint.lastModified) > 0 {
+				request.Header.Set("If-Modified-Since", endpoint.lastModified)
+			}
+		}
+		if endpoint.Timeout > 0 {
+			ctx, cancel := context.WithTimeout(request.Context(), endpoint.Timeout)
+			defer cancel()
+			request = request.WithContext(ctx)
+		}
 	}
 	startTime := time.Now()
 	if endpointType == EndpointTypeDNS {
-		endpoint.DNS.query(endpoint.URL, result)
+		if endpoint.isDNSOverHTTPS() {
+			headers, err := endpoint.renderHeaders()
+			if err != nil {
+				result.AddError(err.Error())
+				return
+			}
+			endpoint.DNS.queryOverHTTPS(endpoint.URL, endpoint.Method, headers, endpoint.ClientConfig, result)
+		} else {
+			endpoint.DNS.query(endpoint.URL, result)
+		}
 		result.Duration = time.Since(startTime)
 	} else if endpointType == EndpointTypeSTARTTLS || endpointType == EndpointTypeTLS {
 		if endpointType == EndpointTypeSTARTTLS {
-			result.Connected, certificate, err = client.CanPerformStartTLS(strings.TrimPrefix(endpoint.URL, "starttls://"), endpoint.ClientConfig)
+			result.Connected, tlsConnectionState, err = client.CanPerformStartTLS(strings.TrimPrefix(endpoint.URL, "starttls://"), endpoint.ClientConfig)
 		} else {
-			result.Connected, certificate, err = client.CanPerformTLS(strings.TrimPrefix(endpoint.URL, "tls://"), endpoint.ClientConfig)
+			result.Connected, tlsConnectionState, err = client.CanPerformTLS(strings.TrimPrefix(endpoint.URL, "tls://"), endpoint.ClientConfig)
 		}
 		if err != nil {
 			result.AddError(err.Error())
 			return
 		}
 		result.Duration = time.Since(startTime)
-		result.CertificateExpiration = time.Until(certificate.NotAfter)
+		result.setTLSConnectionState(tlsConnectionState)
 	} else if endpointType == EndpointTypeTCP {
 		result.Connected = client.CanCreateTCPConnection(strings.TrimPrefix(endpoint.URL, "tcp://"), endpoint.ClientConfig)
 		result.Duration = time.Since(startTime)
 	} else if endpointType == EndpointTypeUDP {
-		result.Connected = client.CanCreateUDPConnection(strings.TrimPrefix(endpoint.URL, "udp://"), endpoint.ClientConfig)
+		if len(endpoint.Body) > 0 {
+			// Since UDP is connectionless, we can only know whether the endpoint is reachable if it replies
+			result.Connected, result.Body, err = client.QueryUDP(strings.TrimPrefix(endpoint.URL, "udp://"), endpoint.ClientConfig, endpoint.Body)
+			if err != nil {
+				result.AddError(err.Error())
+			}
+		} else {
+			result.Connected = client.CanCreateUDPConnection(strings.TrimPrefix(endpoint.URL, "udp://"), endpoint.ClientConfig)
+		}
 		result.Duration = time.Since(startTime)
 	} else if endpointType == EndpointTypeSCTP {
 		result.Connected = client.CanCreateSCTPConnection(strings.TrimPrefix(endpoint.URL, "sctp://"), endpoint.ClientConfig)
@@ -346,28 +854,133 @@ func (endpoint *Endpoint) call(result *Result) {
 	} else if endpointType == EndpointTypeWS {
 		result.Connected, result.Body, err = client.QueryWebSocket(endpoint.URL, endpoint.ClientConfig, endpoint.Body)
 		result.Duration = time.Since(startTime)
+	} else if endpointType == EndpointTypeIMAP || endpointType == EndpointTypePOP3 {
+		endpoint.checkMailbox(result)
+	} else if endpointType == EndpointTypeSSH {
+		endpoint.checkSSH(result)
+	} else if endpointType == EndpointTypeEXEC {
+		endpoint.execute(result)
 	} else {
-		response, err = client.GetHTTPClient(endpoint.ClientConfig).Do(request)
+		var httpClient *http.Client
+		if endpoint.AllIPs.IsEnabled() {
+			// The result is for one of the IPs the hostname resolves to, so the request must be sent to that IP
+			httpClient = client.GetHTTPClientForIP(endpoint.ClientConfig, result.IP)
+		} else {
+			httpClient = client.GetHTTPClient(endpoint.ClientConfig)
+		}
+		if endpoint.needsConnectedIP() {
+			request = request.WithContext(httptrace.WithClientTrace(request.Context(), &httptrace.ClientTrace{
+				GotConn: func(info httptrace.GotConnInfo) {
+					if ip, _, err := net.SplitHostPort(info.Conn.RemoteAddr().String()); err == nil {
+						result.ConnectedIP = ip
+					}
+				},
+			}))
+		}
+		response, err = httpClient.Do(request)
 		result.Duration = time.Since(startTime)
 		if err != nil {
+			result.networkError = isNetworkError(err)
+			err = endpoint.wrapHTTPError(request, err)
+			if endpoint.Debug {
+				endpoint.logHTTPExchange(request, nil, nil, err)
+			}
 			result.AddError(err.Error())
 			return
 		}
 		defer response.Body.Close()
-		if response.TLS != nil && len(response.TLS.PeerCertificates) > 0 {
-			certificate = response.TLS.PeerCertificates[0]
-			result.CertificateExpiration = time.Until(certificate.NotAfter)
+		if endpoint.ClientConfig.KeepAlive {
+			// Whatever is left of the body must be read for the connection to be reused by the next check
+			defer drainBody(response.Body)
+		}
+		if response.TLS != nil {
+			result.setTLSConnectionState(response.TLS)
 		}
 		result.HTTPStatus = response.StatusCode
 		result.Connected = response.StatusCode > 0
-		// Only read the Body if there's a condition that uses the BodyPlaceholder
-		if endpoint.needsToReadBody() {
-			result.Body, err = io.ReadAll(response.Body)
-			if err != nil {
-				result.AddError("error reading response body:" + err.Error())
+		result.Headers = response.Header
+		if needsConditionalRequest {
+			result.Changed = response.StatusCode != http.StatusNotModified
+			if result.Changed {
+				endpoint.lastETag = response.Header.Get("ETag")
+				endpoint.lastModified = response.Header.Get("Last-Modified")
 			}
 		}
+		result.ContentEncoding = contentEncodingOf(response)
+		// Only read the Body if there's a condition that uses the BodyPlaceholder, the BodySHA256Placeholder or the
+		// BodyDecodedPlaceholder, or if it needs to be logged or to contain the JWT
+		needsToReadBody, needsToHashBody := endpoint.needsToReadBody() || endpoint.Debug || (endpoint.JWT != nil && len(endpoint.JWT.Path) > 0), endpoint.needsToHashBody()
+		if needsToReadBody || needsToHashBody || endpoint.needsToDecodeBody() {
+			var reader io.Reader = response.Body
+			// The client only decodes the body itself if the request didn't explicitly set an Accept-Encoding header
+			if isEncoded(result.ContentEncoding) && !response.Uncompressed {
+				var closeDecoder func()
+				if reader, closeDecoder, err = newContentDecoder(reader, result.ContentEncoding); err == nil {
+					defer closeDecoder()
+				}
+			}
+			hasher := sha256.New()
+			if err == nil {
+				if needsToHashBody {
+					// Hash the body as it is read, so that it doesn't need to be kept in memory unless it's also needed
+					reader = io.TeeReader(reader, hasher)
+				}
+				if needsToReadBody {
+					result.Body, err = io.ReadAll(reader)
+				} else {
+					_, err = io.Copy(io.Discard, reader)
+				}
+			}
+			if err != nil && isEncoded(result.ContentEncoding) {
+				// A body that can't be decoded is a failure even if no condition depends on it, since the endpoint
+				// advertised an encoding its body doesn't match
+				result.AddError(fmt.Sprintf("error decoding response body with content encoding %s: %s", result.ContentEncoding, endpoint.wrapHTTPError(request, err).Error()))
+				result.Success = false
+			} else if err != nil {
+				result.AddError("error reading response body:" + endpoint.wrapHTTPError(request, err).Error())
+			} else {
+				result.BodyDecoded = true
+				if needsToHashBody {
+					result.BodySHA256 = hex.EncodeToString(hasher.Sum(nil))
+				}
+			}
+		}
+		if endpoint.JWT != nil {
+			endpoint.JWT.verify(request.Context(), response, result.Body, result)
+		}
+		if endpoint.Debug {
+			endpoint.logHTTPExchange(request, response, result.Body, nil)
+		}
+	}
+}
+
+// drainBody discards what is left of a response body, up to maximumDrainedBodySize, so that the connection it was
+// received through can be reused. If there's more left than that, the connection is closed instead.
+func drainBody(body io.Reader) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maximumDrainedBodySize))
+}
+
+// wrapHTTPError makes it explicit when an error was caused by the endpoint's timeout being reached
+func (endpoint *Endpoint) wrapHTTPError(request *http.Request, err error) error {
+	if endpoint.Timeout > 0 && errors.Is(request.Context().Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("request timed out after %s (endpoint timeout): %w", endpoint.Timeout, err)
 	}
+	return err
+}
+
+// isNetworkError returns whether the error passed was caused by the request failing to be sent or answered at the
+// network level (e.g. DNS resolution failure, connection refused, timeout), rather than by the response
+func isNetworkError(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netError net.Error
+	if errors.As(err, &netError) && netError.Timeout() {
+		return true
+	}
+	var opError *net.OpError
+	var dnsError *net.DNSError
+	return errors.As(err, &opError) || errors.As(err, &dnsError)
 }
 
 // Close HTTP connections between watchdog and endpoints to avoid dangling socket file descriptors
@@ -379,31 +992,84 @@ func (endpoint *Endpoint) Close() {
 	}
 }
 
-func (endpoint *Endpoint) buildHTTPRequest() *http.Request {
+func (endpoint *Endpoint) buildHTTPRequest() (*http.Request, error) {
+	headers, err := endpoint.renderHeaders()
+	if err != nil {
+		return nil, err
+	}
 	var bodyBuffer *bytes.Buffer
-	if endpoint.GraphQL {
-		graphQlBody := map[string]string{
-			"query": endpoint.Body,
-		}
-		body, _ := json.Marshal(graphQlBody)
-		bodyBuffer = bytes.NewBuffer(body)
+	if endpoint.GraphQL.IsEnabled() {
+		bodyBuffer = bytes.NewBuffer(endpoint.GraphQL.buildRequestBody(endpoint.Body))
 	} else {
 		bodyBuffer = bytes.NewBuffer([]byte(endpoint.Body))
 	}
 	request, _ := http.NewRequest(endpoint.Method, endpoint.URL, bodyBuffer)
-	for k, v := range endpoint.Headers {
+	for k, v := range headers {
 		request.Header.Set(k, v)
 		if k == HostHeader {
 			request.Host = v
 		}
 	}
-	return request
+	return request, nil
 }
 
-// needsToReadBody checks if there's any condition that requires the response Body to be read
+// isDNSOverHTTPS checks whether the endpoint is a DNS endpoint that must be queried using DNS over HTTPS
+func (endpoint *Endpoint) isDNSOverHTTPS() bool {
+	return endpoint.DNS != nil && strings.HasPrefix(endpoint.URL, dnsOverHTTPSPrefix)
+}
+
+// needsToHashBody checks if there's any condition that requires the response Body to be hashed
+func (endpoint *Endpoint) needsToHashBody() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.hasBodySHA256Placeholder() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsConditionalRequest checks if there's any condition that requires the request to be conditional, meaning that
+// the validators of the previous response are sent through the If-None-Match and If-Modified-Since headers
+func (endpoint *Endpoint) needsConditionalRequest() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.hasChangedPlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsToReadBody checks if there's any condition that requires the response Body to be read, or any alert that
+// includes an excerpt of it
 func (endpoint *Endpoint) needsToReadBody() bool {
 	for _, condition := range endpoint.Conditions {
-		if condition.hasBodyPlaceholder() {
+		if condition.hasBodyPlaceholder() || condition.hasGraphQLErrorsPlaceholder() || condition.hasBodyFormatPlaceholder() {
+			return true
+		}
+	}
+	for _, endpointAlert := range endpoint.Alerts {
+		if endpointAlert.IncludeBodyExcerpt {
+			return true
+		}
+	}
+	return false
+}
+
+// needsToDecodeBody checks if there's any condition that requires the response Body to be decoded, even if it doesn't
+// need to be kept
+func (endpoint *Endpoint) needsToDecodeBody() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.hasBodyDecodedPlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsJWT checks if there's any condition that uses the JWTValidPlaceholder or the JWTPlaceholder
+func (endpoint *Endpoint) needsJWT() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.hasJWTPlaceholder() {
 			return true
 		}
 	}
@@ -420,10 +1086,97 @@ func (endpoint *Endpoint) needsToRetrieveDomainExpiration() bool {
 	return false
 }
 
-// needsToRetrieveIP checks if there's any condition that requires an IP lookup
+// NeedsResponseTimeBaseline checks if there's any condition that requires the response time baseline of the endpoint
+func (endpoint *Endpoint) NeedsResponseTimeBaseline() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.isAnomalyCheck() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetResponseTimeBaseline sets the baseline that the response time of the next evaluations will be compared with
+//
+// The baseline is computed from the results in the storage, which is why it must be provided by the caller.
+func (endpoint *Endpoint) SetResponseTimeBaseline(baseline *ResponseTimeBaseline) {
+	endpoint.responseTimeBaseline = baseline
+}
+
+// NeedsPreviousResponseTimes checks if there's any condition that requires the response times of the previous results
+// of the endpoint
+func (endpoint *Endpoint) NeedsPreviousResponseTimes() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.isTrendCheck() {
+			return true
+		}
+	}
+	return false
+}
+
+// SetPreviousResponseTimes sets the response times of the previous results of the endpoint, from the oldest to the
+// most recent one, which the trend of the response time of the next evaluations will be computed from
+//
+// The response times are retrieved from the storage, which is why they must be provided by the caller.
+func (endpoint *Endpoint) SetPreviousResponseTimes(responseTimes []time.Duration) {
+	endpoint.previousResponseTimes = responseTimes
+}
+
+// SetDependencies sets the endpoints referenced by DependsOn
+//
+// Because the endpoints are only known once the whole configuration has been loaded, they must be provided by the
+// caller.
+func (endpoint *Endpoint) SetDependencies(dependencies []*Endpoint) {
+	endpoint.dependencies = dependencies
+}
+
+// Dependencies returns the endpoints referenced by DependsOn
+func (endpoint *Endpoint) Dependencies() []*Endpoint {
+	return endpoint.dependencies
+}
+
+// IsUnderMaintenance returns whether the endpoint is in one of its maintenance windows
+//
+// Note that this doesn't take into account the maintenance windows of the endpoints it depends on.
+func (endpoint *Endpoint) IsUnderMaintenance() bool {
+	for _, maintenanceWindow := range endpoint.MaintenanceWindows {
+		if maintenanceWindow != nil && maintenanceWindow.IsUnderMaintenance() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsToRetrieveIP checks if there's any condition that requires an IP lookup, or if the connections of the endpoint
+// are restricted to an IP version
 func (endpoint *Endpoint) needsToRetrieveIP() bool {
+	if endpoint.ClientConfig != nil && endpoint.ClientConfig.HasIPVersion() {
+		// Resolving the hostname beforehand reports a clear error if it has no address of the IP version required
+		return true
+	}
+	for _, condition := range endpoint.Conditions {
+		// The IP of the connection isn't known for every type of endpoint, in which case the resolved IP is used instead
+		if condition.hasIPPlaceholder() || condition.hasConnectedIPPlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsConnectedIP checks if there's any condition that requires the IP of the connection the request was sent through
+func (endpoint *Endpoint) needsConnectedIP() bool {
+	for _, condition := range endpoint.Conditions {
+		if condition.hasConnectedIPPlaceholder() {
+			return true
+		}
+	}
+	return false
+}
+
+// needsToRetrieveReverseDNS checks if there's any condition that requires a reverse DNS lookup
+func (endpoint *Endpoint) needsToRetrieveReverseDNS() bool {
 	for _, condition := range endpoint.Conditions {
-		if condition.hasIPPlaceholder() {
+		if condition.hasReverseDNSPlaceholder() {
 			return true
 		}
 	}
@@ -19,6 +19,14 @@ type EndpointStatus struct {
 	// Events is a list of events
 	Events []*Event `json:"events,omitempty"`
 
+	// Annotations are the annotations created since the oldest of the events
+	Annotations []*Annotation `json:"annotations,omitempty"`
+
+	// Disabled is whether the monitoring of the endpoint has been disabled through Endpoint.Enabled
+	//
+	// Not persisted, as it is derived from the configuration when the endpoint status is retrieved through the API.
+	Disabled bool `json:"disabled,omitempty"`
+
 	// Uptime information on the endpoint's uptime
 	//
 	// Used by the memory store.
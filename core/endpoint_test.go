@@ -2,11 +2,18 @@ package core
 
 import (
 	"bytes"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -325,6 +332,36 @@ func TestEndpoint_Type(t *testing.T) {
 			},
 			want: EndpointTypeWS,
 		},
+		{
+			args: args{
+				URL: "imap://example.com:143",
+			},
+			want: EndpointTypeIMAP,
+		},
+		{
+			args: args{
+				URL: "imaps://example.com",
+			},
+			want: EndpointTypeIMAP,
+		},
+		{
+			args: args{
+				URL: "pop3://example.com:110",
+			},
+			want: EndpointTypePOP3,
+		},
+		{
+			args: args{
+				URL: "pop3s://example.com",
+			},
+			want: EndpointTypePOP3,
+		},
+		{
+			args: args{
+				URL: "ssh://example.com:22",
+			},
+			want: EndpointTypeSSH,
+		},
 		{
 			args: args{
 				URL: "invalid://example.org",
@@ -435,6 +472,103 @@ func TestEndpoint_ValidateAndSetDefaultsWithClientConfig(t *testing.T) {
 	}
 }
 
+func TestEndpoint_ValidateAndSetDefaultsWithTimeout(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		timeout               time.Duration
+		clientConfig          *client.Config
+		expectedClientTimeout time.Duration
+	}{
+		{
+			name:                  "timeout-only",
+			timeout:               30 * time.Second,
+			expectedClientTimeout: 30 * time.Second,
+		},
+		{
+			name:                  "timeout-with-client-config-without-timeout",
+			timeout:               30 * time.Second,
+			clientConfig:          &client.Config{Insecure: true},
+			expectedClientTimeout: 30 * time.Second,
+		},
+		{
+			name:                  "timeout-and-client-timeout",
+			timeout:               30 * time.Second,
+			clientConfig:          &client.Config{Timeout: 5 * time.Second},
+			expectedClientTimeout: 5 * time.Second,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:         "website-health",
+				URL:          "https://twin.sh/health",
+				Timeout:      scenario.timeout,
+				ClientConfig: scenario.clientConfig,
+				Conditions:   []Condition{Condition("[STATUS] == 200")},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if endpoint.ClientConfig.Timeout != scenario.expectedClientTimeout {
+				t.Errorf("expected client timeout to be %s, got %s", scenario.expectedClientTimeout, endpoint.ClientConfig.Timeout)
+			}
+			if !endpoint.ClientConfig.TransportTimeoutOnly {
+				t.Error("expected client timeout to only apply to the transport, because the endpoint has a timeout")
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name            string
+		timeout         time.Duration
+		clientTimeout   time.Duration
+		expectedSuccess bool
+		expectedError   string
+	}{
+		{
+			name:            "endpoint-timeout-longer-than-client-timeout",
+			timeout:         5 * time.Second,
+			clientTimeout:   50 * time.Millisecond,
+			expectedSuccess: true,
+		},
+		{
+			name:            "endpoint-timeout-reached",
+			timeout:         50 * time.Millisecond,
+			clientTimeout:   5 * time.Second,
+			expectedSuccess: false,
+			expectedError:   "request timed out after 50ms (endpoint timeout)",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:         "slow",
+				URL:          server.URL,
+				Timeout:      scenario.timeout,
+				ClientConfig: &client.Config{Timeout: scenario.clientTimeout},
+				Conditions:   []Condition{Condition("[STATUS] == 200")},
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if len(scenario.expectedError) > 0 && (len(result.Errors) == 0 || !strings.HasPrefix(result.Errors[0], scenario.expectedError)) {
+				t.Errorf("expected error to start with %q, got %v", scenario.expectedError, result.Errors)
+			}
+		})
+	}
+}
+
 func TestEndpoint_ValidateAndSetDefaultsWithDNS(t *testing.T) {
 	endpoint := &Endpoint{
 		Name: "dns-test",
@@ -483,6 +617,69 @@ func TestEndpoint_ValidateAndSetDefaultsWithSimpleErrors(t *testing.T) {
 			},
 			expectedErr: ErrEndpointWithNoCondition,
 		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-mailbox-and-unsupported-type",
+				URL:        "https://example.com",
+				Mailbox:    &Mailbox{Username: "john.doe", Password: "hunter2"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrMailboxWithUnsupportedEndpointType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-mailbox-username-and-no-password",
+				URL:        "imaps://imap.example.com",
+				Mailbox:    &Mailbox{Username: "john.doe"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrMailboxWithUsernameAndNoPassword,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-mailbox-starttls-and-implicit-tls",
+				URL:        "pop3s://pop.example.com",
+				Mailbox:    &Mailbox{StartTLS: true},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrMailboxWithStartTLSAndImplicitTLS,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-ssh-and-unsupported-type",
+				URL:        "tcp://example.com:22",
+				SSH:        &SSH{Username: "john.doe", Password: "hunter2"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrSSHWithUnsupportedEndpointType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-ssh-username-and-no-credentials",
+				URL:        "ssh://example.com",
+				SSH:        &SSH{Username: "john.doe"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrSSHWithUsernameAndNoCredentials,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-ssh-password-and-no-username",
+				URL:        "ssh://example.com",
+				SSH:        &SSH{Password: "hunter2"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrSSHWithCredentialsAndNoUsername,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "endpoint-with-ssh-invalid-host-key-fingerprint",
+				URL:        "ssh://example.com",
+				SSH:        &SSH{HostKeyFingerprint: "MD5:16:27:ac:a5:76:28:2d:36:63:1b:56:4d:eb:df:a6:48"},
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrSSHWithInvalidHostKeyFingerprint,
+		},
 		{
 			endpoint: &Endpoint{
 				Name:       "domain-expiration-with-bad-interval",
@@ -492,6 +689,52 @@ func TestEndpoint_ValidateAndSetDefaultsWithSimpleErrors(t *testing.T) {
 			},
 			expectedErr: ErrInvalidEndpointIntervalForDomainExpirationPlaceholder,
 		},
+		{
+			endpoint: &Endpoint{
+				Name:       "negative-timeout",
+				URL:        "https://example.com",
+				Timeout:    -time.Second,
+				Conditions: []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: ErrEndpointWithInvalidTimeout,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:       "timeout-with-tcp",
+				URL:        "tcp://example.com:80",
+				Timeout:    time.Second,
+				Conditions: []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: ErrEndpointTimeoutWithUnsupportedEndpointType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:         "ip-version-with-tcp",
+				URL:          "tcp://example.com:80",
+				ClientConfig: &client.Config{IPVersion: client.IPVersion6},
+				Conditions:   []Condition{Condition("[CONNECTED] == true")},
+			},
+			expectedErr: nil,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:         "ip-version-with-dns",
+				URL:          "1.1.1.1",
+				DNS:          &DNS{QueryType: "A", QueryName: "example.com."},
+				ClientConfig: &client.Config{IPVersion: client.IPVersion6},
+				Conditions:   []Condition{Condition("[DNS_RCODE] == NOERROR")},
+			},
+			expectedErr: ErrEndpointIPVersionWithUnsupportedEndpointType,
+		},
+		{
+			endpoint: &Endpoint{
+				Name:         "invalid-ip-version",
+				URL:          "https://example.com",
+				ClientConfig: &client.Config{IPVersion: "ipv5"},
+				Conditions:   []Condition{Condition("[STATUS] == 200")},
+			},
+			expectedErr: client.ErrInvalidClientIPVersion,
+		},
 		{
 			endpoint: &Endpoint{
 				Name:       "domain-expiration-with-good-interval",
@@ -519,7 +762,7 @@ func TestEndpoint_buildHTTPRequest(t *testing.T) {
 		Conditions: []Condition{condition},
 	}
 	endpoint.ValidateAndSetDefaults()
-	request := endpoint.buildHTTPRequest()
+	request, _ := endpoint.buildHTTPRequest()
 	if request.Method != "GET" {
 		t.Error("request.Method should've been GET, but was", request.Method)
 	}
@@ -542,7 +785,7 @@ func TestEndpoint_buildHTTPRequestWithCustomUserAgent(t *testing.T) {
 		},
 	}
 	endpoint.ValidateAndSetDefaults()
-	request := endpoint.buildHTTPRequest()
+	request, _ := endpoint.buildHTTPRequest()
 	if request.Method != "GET" {
 		t.Error("request.Method should've been GET, but was", request.Method)
 	}
@@ -566,7 +809,7 @@ func TestEndpoint_buildHTTPRequestWithHostHeader(t *testing.T) {
 		},
 	}
 	endpoint.ValidateAndSetDefaults()
-	request := endpoint.buildHTTPRequest()
+	request, _ := endpoint.buildHTTPRequest()
 	if request.Method != "POST" {
 		t.Error("request.Method should've been POST, but was", request.Method)
 	}
@@ -582,7 +825,7 @@ func TestEndpoint_buildHTTPRequestWithGraphQLEnabled(t *testing.T) {
 		URL:        "https://twin.sh/graphql",
 		Method:     "POST",
 		Conditions: []Condition{condition},
-		GraphQL:    true,
+		GraphQL:    &GraphQL{},
 		Body: `{
   users(gender: "female") {
     id
@@ -593,7 +836,7 @@ func TestEndpoint_buildHTTPRequestWithGraphQLEnabled(t *testing.T) {
 }`,
 	}
 	endpoint.ValidateAndSetDefaults()
-	request := endpoint.buildHTTPRequest()
+	request, _ := endpoint.buildHTTPRequest()
 	if request.Method != "POST" {
 		t.Error("request.Method should've been POST, but was", request.Method)
 	}
@@ -606,6 +849,105 @@ func TestEndpoint_buildHTTPRequestWithGraphQLEnabled(t *testing.T) {
 	}
 }
 
+func TestEndpoint_buildHTTPRequestWithGraphQLConfig(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "website-graphql",
+		URL:        "https://twin.sh/graphql",
+		Conditions: []Condition{"[STATUS] == 200", "[GRAPHQL_ERRORS] == 0"},
+		GraphQL: &GraphQL{
+			Query:         "query GetUser($id: ID!) { user(id: $id) { name } }",
+			Variables:     map[string]interface{}{"id": "1"},
+			OperationName: "GetUser",
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	request, _ := endpoint.buildHTTPRequest()
+	if request.Method != "POST" {
+		t.Error("request.Method should've defaulted to POST, but was", request.Method)
+	}
+	if contentType := request.Header.Get(ContentTypeHeader); contentType != "application/json" {
+		t.Error("request.Header.Content-Type should've been application/json, but was", contentType)
+	}
+	body, _ := io.ReadAll(request.Body)
+	expectedBody := `{"operationName":"GetUser","query":"query GetUser($id: ID!) { user(id: $id) { name } }","variables":{"id":"1"}}`
+	if string(body) != expectedBody {
+		t.Errorf("expected request body to be %s, got %s", expectedBody, string(body))
+	}
+	if !endpoint.needsToReadBody() {
+		t.Error("expected body to need to be read, because [GRAPHQL_ERRORS] requires the body")
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithGraphQLAndNoQuery(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "website-graphql",
+		URL:        "https://twin.sh/graphql",
+		Conditions: []Condition{"[STATUS] == 200"},
+		GraphQL:    &GraphQL{},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrGraphQLWithNoQuery {
+		t.Errorf("expected error %v, got %v", ErrGraphQLWithNoQuery, err)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithBodyFile(t *testing.T) {
+	bodyFile := t.TempDir() + "/body.json"
+	if err := os.WriteFile(bodyFile, []byte(`{"name":"[ENDPOINT_NAME]"}`), 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint := Endpoint{
+		Name:       "website",
+		URL:        "https://twin.sh/health",
+		Method:     http.MethodPost,
+		BodyFile:   bodyFile,
+		Conditions: []Condition{"[STATUS] == 200"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoint.Body != `{"name":"[ENDPOINT_NAME]"}` {
+		t.Errorf("expected body to have been read from the body file, got %s", endpoint.Body)
+	}
+	if files := endpoint.ReferencedFiles(); len(files) != 1 || files[0] != bodyFile {
+		t.Errorf("expected the body file to be referenced, got %v", files)
+	}
+	endpoint.BodyFile = t.TempDir() + "/does-not-exist.json"
+	endpoint.Body = ""
+	if err := endpoint.ValidateAndSetDefaults(); err == nil || !errors.Is(err, os.ErrNotExist) {
+		t.Error("expected error because the body file does not exist, got", err)
+	}
+	endpoint.BodyFile = bodyFile
+	endpoint.Body = "body"
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointWithBodyAndBodyFile {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithBodyAndBodyFile, err)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithAlertScopedToConditions(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "website",
+		URL:        "https://twin.sh/health",
+		Conditions: []Condition{"[STATUS] == 200", "[CERTIFICATE_EXPIRATION] > 48h"},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack, Conditions: []string{"[STATUS] == 200"}},
+			{Type: alert.TypePagerDuty, Conditions: []string{"[CERTIFICATE_EXPIRATION]"}},
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint.Alerts[0].Conditions = []string{"[STATUS] == 201"}
+	if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithAlertScopedToUnknownCondition) {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithAlertScopedToUnknownCondition, err)
+	}
+	endpoint.Alerts[0].Conditions = []string{"[RESPONSE_TIME]"}
+	if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithAlertScopedToUnknownCondition) {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithAlertScopedToUnknownCondition, err)
+	}
+}
+
 func TestIntegrationEvaluateHealth(t *testing.T) {
 	condition := Condition("[STATUS] == 200")
 	bodyCondition := Condition("[BODY].status == UP")
@@ -699,6 +1041,23 @@ func TestIntegrationEvaluateHealthForICMP(t *testing.T) {
 	}
 }
 
+func TestEndpoint_EvaluateHealthWithIPVersion(t *testing.T) {
+	endpoint := Endpoint{
+		Name:         "tcp-test",
+		URL:          "tcp://127.0.0.1:1",
+		ClientConfig: &client.Config{IPVersion: client.IPVersion6},
+		Conditions:   []Condition{"[CONNECTED] == true"},
+	}
+	endpoint.ValidateAndSetDefaults()
+	result := endpoint.EvaluateHealth()
+	if result.Success {
+		t.Error("Because 127.0.0.1 is not an IPv6 address, this should have been a failure")
+	}
+	if len(result.Errors) != 1 || result.Errors[0] != "127.0.0.1 has no IPv6 address" {
+		t.Errorf("Expected error '127.0.0.1 has no IPv6 address', got %v", result.Errors)
+	}
+}
+
 func TestEndpoint_DisplayName(t *testing.T) {
 	if endpoint := (Endpoint{Name: "n"}); endpoint.DisplayName() != "n" {
 		t.Error("endpoint.DisplayName() should've been 'n', but was", endpoint.DisplayName())
@@ -708,6 +1067,34 @@ func TestEndpoint_DisplayName(t *testing.T) {
 	}
 }
 
+func TestEndpoint_Hostname(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		endpoint         Endpoint
+		expectedHostname string
+	}{
+		{name: "http", endpoint: Endpoint{URL: "https://twin.sh/health"}, expectedHostname: "twin.sh"},
+		{name: "http-with-port", endpoint: Endpoint{URL: "http://127.0.0.1:8080/health"}, expectedHostname: "127.0.0.1"},
+		{name: "tcp", endpoint: Endpoint{URL: "tcp://example.org:5432"}, expectedHostname: "example.org"},
+		{name: "dns", endpoint: Endpoint{URL: "8.8.8.8:53", DNS: &DNS{QueryType: "A", QueryName: "example.com."}}, expectedHostname: "8.8.8.8"},
+		{name: "dns-over-https", endpoint: Endpoint{URL: "doh://cloudflare-dns.com/dns-query", DNS: &DNS{QueryType: "A", QueryName: "example.com."}}, expectedHostname: "cloudflare-dns.com"},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			hostname, err := scenario.endpoint.Hostname()
+			if err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if hostname != scenario.expectedHostname {
+				t.Errorf("expected %s, got %s", scenario.expectedHostname, hostname)
+			}
+		})
+	}
+	if _, err := (&Endpoint{URL: "http://[::1"}).Hostname(); err == nil {
+		t.Error("expected an error for an invalid URL")
+	}
+}
+
 func TestEndpoint_getIP(t *testing.T) {
 	endpoint := Endpoint{
 		Name:       "invalid-url-test",
@@ -743,6 +1130,373 @@ func TestEndpoint_needsToReadBody(t *testing.T) {
 	if !(&Endpoint{Conditions: []Condition{bodyConditionWithLength, statusCondition}}).needsToReadBody() {
 		t.Error("expected true, got false")
 	}
+	if !(&Endpoint{Conditions: []Condition{statusCondition}, Alerts: []*alert.Alert{{Type: alert.TypeSlack, IncludeBodyExcerpt: true}}}).needsToReadBody() {
+		t.Error("expected true, got false")
+	}
+	if !(&Endpoint{Conditions: []Condition{statusCondition, Condition("[BODY_IS_JSON] == true")}}).needsToReadBody() {
+		t.Error("expected true, got false")
+	}
+	if !(&Endpoint{Conditions: []Condition{Condition("[BODY_IS_XML] == true")}}).needsToReadBody() {
+		t.Error("expected true, got false")
+	}
+}
+
+func TestEndpoint_needsToHashBody(t *testing.T) {
+	statusCondition := Condition("[STATUS] == 200")
+	bodySHA256Condition := Condition("[BODY_SHA256] == e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	if (&Endpoint{Conditions: []Condition{statusCondition}}).needsToHashBody() {
+		t.Error("expected false, got true")
+	}
+	if !(&Endpoint{Conditions: []Condition{statusCondition, bodySHA256Condition}}).needsToHashBody() {
+		t.Error("expected true, got false")
+	}
+	if (&Endpoint{Conditions: []Condition{bodySHA256Condition}}).needsToReadBody() {
+		t.Error("expected the body to not need to be kept in memory only to be hashed")
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithExpectedFailureStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/protected" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                    string
+		path                    string
+		expectedFailureStatuses []int
+		expectedExpectedFailure bool
+	}{
+		{
+			name:                    "expected-failure",
+			path:                    "/protected",
+			expectedFailureStatuses: []int{401, 403},
+			expectedExpectedFailure: true,
+		},
+		{
+			name:                    "unexpected-failure",
+			path:                    "/broken",
+			expectedFailureStatuses: []int{401, 403},
+			expectedExpectedFailure: false,
+		},
+		{
+			name:                    "no-expected-failure-statuses",
+			path:                    "/protected",
+			expectedExpectedFailure: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "protected", URL: server.URL + scenario.path, Conditions: []Condition{"[STATUS] == 200"}, ExpectedFailureStatuses: scenario.expectedFailureStatuses}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success {
+				t.Error("expected the result to not be successful")
+			}
+			if result.ExpectedFailure != scenario.expectedExpectedFailure {
+				t.Errorf("expected ExpectedFailure to be %v, got %v", scenario.expectedExpectedFailure, result.ExpectedFailure)
+			}
+		})
+	}
+	// A successful result is never an expected failure, even if its status is one of the expected failure statuses
+	endpoint := Endpoint{Name: "protected", URL: server.URL + "/protected", Conditions: []Condition{"[STATUS] == 401"}, ExpectedFailureStatuses: []int{401}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if result := endpoint.EvaluateHealth(); !result.Success || result.ExpectedFailure {
+		t.Errorf("expected a successful result that isn't an expected failure, got success=%v; expectedFailure=%v", result.Success, result.ExpectedFailure)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithInvalidExpectedFailureStatus(t *testing.T) {
+	endpoint := Endpoint{Name: "protected", URL: "https://twin.sh/health", Conditions: []Condition{"[STATUS] == 200"}, ExpectedFailureStatuses: []int{401, 1000}}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointWithInvalidExpectedFailureStatus {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithInvalidExpectedFailureStatus, err)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithConditionLogic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name            string
+		conditionLogic  ConditionLogic
+		conditions      []Condition
+		expectedSuccess bool
+	}{
+		{
+			name:            "default-with-one-condition-failing",
+			conditions:      []Condition{"[STATUS] == 200", "[STATUS] == 202"},
+			expectedSuccess: false,
+		},
+		{
+			name:            "all-with-every-condition-passing",
+			conditionLogic:  ConditionLogicAll,
+			conditions:      []Condition{"[STATUS] == 202", "[RESPONSE_TIME] < 5000"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "any-with-one-condition-passing",
+			conditionLogic:  ConditionLogicAny,
+			conditions:      []Condition{"[STATUS] == 200", "[STATUS] == 202"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "any-with-every-condition-failing",
+			conditionLogic:  ConditionLogicAny,
+			conditions:      []Condition{"[STATUS] == 200", "[STATUS] == 204"},
+			expectedSuccess: false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "name", URL: server.URL, Conditions: scenario.conditions, ConditionLogic: scenario.conditionLogic}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v", scenario.expectedSuccess, result.Success)
+			}
+			if len(result.ConditionResults) != len(scenario.conditions) {
+				t.Fatalf("expected %d condition results, got %d", len(scenario.conditions), len(result.ConditionResults))
+			}
+			for i, condition := range scenario.conditions {
+				if expectedConditionSuccess := condition == "[STATUS] == 202" || condition == "[RESPONSE_TIME] < 5000"; result.ConditionResults[i].Success != expectedConditionSuccess {
+					t.Errorf("expected condition %s to have success=%v, got %v", condition, expectedConditionSuccess, result.ConditionResults[i].Success)
+				}
+			}
+		})
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithConditionLogic(t *testing.T) {
+	endpoint := Endpoint{Name: "name", URL: "https://twin.sh/health", Conditions: []Condition{"[STATUS] == 200"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoint.ConditionLogic != ConditionLogicAll {
+		t.Errorf("expected condition logic to default to %s, got %s", ConditionLogicAll, endpoint.ConditionLogic)
+	}
+	endpoint = Endpoint{Name: "name", URL: "https://twin.sh/health", Conditions: []Condition{"[STATUS] == 200"}, ConditionLogic: "some"}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointWithInvalidConditionLogic {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithInvalidConditionLogic, err)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithUnknownOnNetworkError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Closing the listener right away guarantees that the connection will be refused
+	closedAddress := listener.Addr().String()
+	_ = listener.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                  string
+		url                   string
+		unknownOnNetworkError bool
+		expectedUnknown       bool
+	}{
+		{
+			name:                  "connection-refused",
+			url:                   "http://" + closedAddress,
+			unknownOnNetworkError: true,
+			expectedUnknown:       true,
+		},
+		{
+			name:                  "connection-refused-without-unknown-on-network-error",
+			url:                   "http://" + closedAddress,
+			unknownOnNetworkError: false,
+			expectedUnknown:       false,
+		},
+		{
+			name:                  "unhealthy-response",
+			url:                   server.URL,
+			unknownOnNetworkError: true,
+			expectedUnknown:       false,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "unknown", URL: scenario.url, Conditions: []Condition{"[STATUS] == 200"}, UnknownOnNetworkError: scenario.unknownOnNetworkError}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success {
+				t.Error("expected the result to not be successful")
+			}
+			if result.Unknown != scenario.expectedUnknown {
+				t.Errorf("expected Unknown to be %v, got %v", scenario.expectedUnknown, result.Unknown)
+			}
+		})
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithUnknownOnNetworkErrorAndUnsupportedEndpointType(t *testing.T) {
+	endpoint := Endpoint{Name: "tcp", URL: "tcp://127.0.0.1:22", Conditions: []Condition{"[CONNECTED] == true"}, UnknownOnNetworkError: true}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointUnknownOnNetworkErrorWithUnsupportedEndpointType {
+		t.Errorf("expected error %v, got %v", ErrEndpointUnknownOnNetworkErrorWithUnsupportedEndpointType, err)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithAgents(t *testing.T) {
+	endpoint := Endpoint{Name: "website", URL: "https://example.org", Conditions: []Condition{"[STATUS] == 200"}, Agents: []string{"eu-west", "us-east"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoint.AgentFailureThreshold != 1 {
+		t.Errorf("expected agent-failure-threshold to default to 1, got %d", endpoint.AgentFailureThreshold)
+	}
+	if !endpoint.IsCheckedByAgent("us-east") || endpoint.IsCheckedByAgent("ap-south") {
+		t.Error("expected the endpoint to only be checked by its agents")
+	}
+	for _, threshold := range []int{-1, 3} {
+		endpoint := Endpoint{Name: "website", URL: "https://example.org", Conditions: []Condition{"[STATUS] == 200"}, Agents: []string{"eu-west", "us-east"}, AgentFailureThreshold: threshold}
+		if err := endpoint.ValidateAndSetDefaults(); err != ErrEndpointWithInvalidAgentFailureThreshold {
+			t.Errorf("expected error %v with agent-failure-threshold=%d, got %v", ErrEndpointWithInvalidAgentFailureThreshold, threshold, err)
+		}
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithHeaderConditions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Strict-Transport-Security", "max-age=31536000")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name: "security-headers",
+		URL:  server.URL,
+		Conditions: []Condition{
+			"has([HEADER].Strict-Transport-Security) == true",
+			"[HEADER].X-Content-Type-Options == nosniff",
+			"has([HEADER].X-Powered-By) == false",
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if !result.Success {
+		for _, conditionResult := range result.ConditionResults {
+			t.Log(conditionResult.Condition)
+		}
+		t.Error("expected every condition to succeed")
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithKeepAlive(t *testing.T) {
+	var numberOfConnections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		// The body isn't needed by the conditions, so it's only read for the connection to be reused
+		_, _ = w.Write(bytes.Repeat([]byte("a"), 128*1024))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&numberOfConnections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:         "keep-alive",
+		URL:          server.URL,
+		Conditions:   []Condition{"[STATUS] == 200"},
+		ClientConfig: &client.Config{KeepAlive: true, KeepAlivePeriod: 30 * time.Second},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 3; i++ {
+		if result := endpoint.EvaluateHealth(); !result.Success {
+			t.Fatal("expected the check to succeed, got errors", result.Errors)
+		}
+	}
+	if actual := atomic.LoadInt32(&numberOfConnections); actual != 1 {
+		t.Errorf("expected the connection to be reused by every check, got %d connections", actual)
+	}
+}
+
+func TestIsNetworkError(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "deadline-exceeded", err: fmt.Errorf("request failed: %w", context.DeadlineExceeded), expected: true},
+		{name: "connection-refused", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, expected: true},
+		{name: "dns-error", err: &net.DNSError{Err: "no such host", Name: "example.invalid", IsNotFound: true}, expected: true},
+		{name: "other-error", err: errors.New("unsupported protocol scheme"), expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if actual := isNetworkError(scenario.err); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithBodySHA256(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+	scenarios := []struct {
+		name                 string
+		conditions           []Condition
+		expectedSuccess      bool
+		expectedBodyToBeRead bool
+	}{
+		{
+			name:            "match",
+			conditions:      []Condition{"[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "drift",
+			conditions:      []Condition{"[BODY_SHA256] == e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+			expectedSuccess: false,
+		},
+		{
+			name:                 "match-with-body-condition",
+			conditions:           []Condition{"[BODY] == hello", "[BODY_SHA256] == 2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"},
+			expectedSuccess:      true,
+			expectedBodyToBeRead: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{Name: "static-asset", URL: server.URL, Conditions: scenario.conditions}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := &Result{Success: true}
+			endpoint.call(result)
+			endpoint.evaluateConditions(result)
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if (len(result.Body) > 0) != scenario.expectedBodyToBeRead {
+				t.Errorf("expected body to be read: %v, got body %q", scenario.expectedBodyToBeRead, result.Body)
+			}
+		})
+	}
 }
 
 func TestEndpoint_needsToRetrieveDomainExpiration(t *testing.T) {
@@ -761,4 +1515,86 @@ func TestEndpoint_needsToRetrieveIP(t *testing.T) {
 	if !(&Endpoint{Conditions: []Condition{"[STATUS] == 200", "[IP] == 127.0.0.1"}}).needsToRetrieveIP() {
 		t.Error("expected true, got false")
 	}
+	if !(&Endpoint{Conditions: []Condition{"[STATUS] == 200", "[IP_VERSION] == 4"}}).needsToRetrieveIP() {
+		t.Error("expected true, got false")
+	}
+}
+
+func TestEndpoint_needsToRetrieveReverseDNS(t *testing.T) {
+	if (&Endpoint{Conditions: []Condition{"[STATUS] == 200", "[IP_VERSION] == 4"}}).needsToRetrieveReverseDNS() {
+		t.Error("expected false, got true")
+	}
+	if !(&Endpoint{Conditions: []Condition{"[STATUS] == 200", "[REVERSE_DNS] == pat(*.internal)"}}).needsToRetrieveReverseDNS() {
+		t.Error("expected true, got false")
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithConnectedIP(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:       "connected-ip",
+		URL:        strings.Replace(server.URL, "127.0.0.1", "localhost", 1),
+		Conditions: []Condition{"[STATUS] == 200", "[IP_VERSION] == 4", "[REVERSE_DNS] != \"\""},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if result.ConnectedIP != "127.0.0.1" {
+		t.Errorf("expected the connected IP to be 127.0.0.1, got %q", result.ConnectedIP)
+	}
+	if !result.Success {
+		t.Errorf("expected success, got errors %v and condition results %+v", result.Errors, result.ConditionResults)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithChanged(t *testing.T) {
+	etag := `"v1"`
+	var lastIfNoneMatch, lastIfModifiedSince string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lastIfNoneMatch, lastIfModifiedSince = r.Header.Get("If-None-Match"), r.Header.Get("If-Modified-Since")
+		if lastIfNoneMatch == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Header().Set("Last-Modified", "Wed, 21 Oct 2015 07:28:00 GMT")
+		_, _ = w.Write([]byte("hello"))
+	}))
+	defer server.Close()
+	endpoint := Endpoint{Name: "static-asset", URL: server.URL, Conditions: []Condition{"[STATUS] == any(200, 304)", "[CHANGED] == false"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	check := func(expectedSuccess bool, expectedIfNoneMatch, expectedIfModifiedSince string) {
+		t.Helper()
+		result := &Result{Success: true}
+		endpoint.call(result)
+		endpoint.evaluateConditions(result)
+		if result.Success != expectedSuccess {
+			t.Errorf("expected success to be %v, got %v (status: %d, changed: %v)", expectedSuccess, result.Success, result.HTTPStatus, result.Changed)
+		}
+		if lastIfNoneMatch != expectedIfNoneMatch || lastIfModifiedSince != expectedIfModifiedSince {
+			t.Errorf("expected If-None-Match=%q and If-Modified-Since=%q, got %q and %q", expectedIfNoneMatch, expectedIfModifiedSince, lastIfNoneMatch, lastIfModifiedSince)
+		}
+	}
+	// The first request can't be conditional, so the resource is considered to have changed
+	check(false, "", "")
+	check(true, etag, "Wed, 21 Oct 2015 07:28:00 GMT")
+	// The resource changes
+	etag = `"v2"`
+	check(false, `"v1"`, "Wed, 21 Oct 2015 07:28:00 GMT")
+	check(true, `"v2"`, "Wed, 21 Oct 2015 07:28:00 GMT")
+	// Endpoints without a condition using [CHANGED] shouldn't send conditional requests
+	endpointWithoutChanged := Endpoint{Name: "static-asset", URL: server.URL, Conditions: []Condition{"[STATUS] == 200"}}
+	if err := endpointWithoutChanged.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 2; i++ {
+		endpointWithoutChanged.call(&Result{Success: true})
+		if lastIfNoneMatch != "" || lastIfModifiedSince != "" {
+			t.Errorf("expected no conditional headers, got If-None-Match=%q and If-Modified-Since=%q", lastIfNoneMatch, lastIfModifiedSince)
+		}
+	}
 }
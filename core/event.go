@@ -9,6 +9,11 @@ type Event struct {
 
 	// Timestamp is the moment at which the event happened
 	Timestamp time.Time `json:"timestamp"`
+
+	// FailedConditions is the list of conditions that caused the endpoint to become unhealthy
+	//
+	// Only set for events of type EventUnhealthy.
+	FailedConditions []string `json:"failedConditions,omitempty"`
 }
 
 // EventType is, uh, the types of events?
@@ -32,6 +37,11 @@ func NewEventFromResult(result *Result) *Event {
 		event.Type = EventHealthy
 	} else {
 		event.Type = EventUnhealthy
+		for _, conditionResult := range result.ConditionResults {
+			if !conditionResult.Success {
+				event.FailedConditions = append(event.FailedConditions, conditionResult.Condition)
+			}
+		}
 	}
 	return event
 }
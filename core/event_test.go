@@ -10,3 +10,21 @@ func TestNewEventFromResult(t *testing.T) {
 		t.Error("expected event.Type to be EventUnhealthy")
 	}
 }
+
+func TestNewEventFromResult_WithFailedConditions(t *testing.T) {
+	result := &Result{
+		Success: false,
+		ConditionResults: []*ConditionResult{
+			{Condition: "[CONNECTED] == true", Success: true},
+			{Condition: "[STATUS] == 200", Success: false},
+		},
+	}
+	event := NewEventFromResult(result)
+	if len(event.FailedConditions) != 1 || event.FailedConditions[0] != "[STATUS] == 200" {
+		t.Errorf("expected event.FailedConditions to be [[STATUS] == 200], got %v", event.FailedConditions)
+	}
+	result.Success = true
+	if event = NewEventFromResult(result); len(event.FailedConditions) != 0 {
+		t.Errorf("expected no failed conditions for a healthy event, got %v", event.FailedConditions)
+	}
+}
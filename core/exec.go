@@ -0,0 +1,100 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	// execPrefix is the prefix of the URL of an endpoint of type EXEC, which is followed by the command to execute
+	execPrefix = "exec://"
+
+	// execDefaultPath is the PATH of the environment in which the commands are executed, unless overridden by Exec.Env
+	execDefaultPath = "/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"
+
+	// execWaitDelay is how long to wait for the output of a command to be closed after it has been killed, which may
+	// not happen right away if the command started processes of its own that inherited its output
+	execWaitDelay = time.Second
+)
+
+var (
+	// ErrExecWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that isn't of type
+	// EXEC has an exec configuration
+	ErrExecWithUnsupportedEndpointType = errors.New("exec is only supported for endpoints with a url starting with " + execPrefix)
+
+	// ErrExecWithNoCommand is the error with which Gatus will panic if the url of an endpoint of type EXEC doesn't
+	// have a command
+	ErrExecWithNoCommand = errors.New("you must specify the command to execute after " + execPrefix)
+)
+
+// Exec is the configuration for an Endpoint of type EXEC
+type Exec struct {
+	// Args are the arguments passed to the command
+	Args []string `yaml:"args,omitempty"`
+
+	// Env are the environment variables the command is executed with.
+	//
+	// The environment of Gatus is not inherited: unless set here, PATH is the only environment variable available to
+	// the command.
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+func (e *Exec) validateAndSetDefault(endpointURL string) error {
+	if len(strings.TrimSpace(strings.TrimPrefix(endpointURL, execPrefix))) == 0 {
+		return ErrExecWithNoCommand
+	}
+	return nil
+}
+
+// environment returns the environment variables the command is executed with, in the format key=value
+func (e *Exec) environment() []string {
+	env := []string{"PATH=" + execDefaultPath}
+	if e == nil {
+		return env
+	}
+	for key, value := range e.Env {
+		if key == "PATH" {
+			env[0] = "PATH=" + value
+			continue
+		}
+		env = append(env, key+"="+value)
+	}
+	return env
+}
+
+// execute runs the command of the endpoint and records its output, its exit code and its duration in the result
+//
+// The command is killed if it didn't exit before the timeout of the client configuration.
+func (endpoint *Endpoint) execute(result *Result) {
+	var args []string
+	if endpoint.Exec != nil {
+		args = endpoint.Exec.Args
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), endpoint.ClientConfig.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, strings.TrimPrefix(endpoint.URL, execPrefix), args...)
+	cmd.Env = endpoint.Exec.environment()
+	cmd.WaitDelay = execWaitDelay
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	startTime := time.Now()
+	err := cmd.Run()
+	result.Duration = time.Since(startTime)
+	result.Body = stdout.Bytes()
+	result.ExitCode = -1
+	if cmd.ProcessState != nil {
+		result.Connected = true
+		result.ExitCode = cmd.ProcessState.ExitCode()
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.AddError(fmt.Sprintf("command killed after %s (client timeout)", endpoint.ClientConfig.Timeout))
+	} else if err != nil && !errors.As(err, new(*exec.ExitError)) {
+		// A non-zero exit code is not an error in itself, since it can be checked with ExitCodePlaceholder
+		result.AddError(err.Error())
+	}
+}
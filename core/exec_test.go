@@ -0,0 +1,101 @@
+package core
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+func TestEndpoint_EvaluateHealthWithExec(t *testing.T) {
+	scenarios := []struct {
+		name            string
+		args            []string
+		env             map[string]string
+		timeout         time.Duration
+		conditions      []Condition
+		expectedSuccess bool
+		expectedError   string
+	}{
+		{
+			name:            "success",
+			args:            []string{"-c", `echo '{"status":"UP"}'`},
+			conditions:      []Condition{"[EXIT_CODE] == 0", "[BODY].status == UP", "[RESPONSE_TIME] < 5000"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "non-zero-exit-code",
+			args:            []string{"-c", "exit 3"},
+			conditions:      []Condition{"[EXIT_CODE] == 3"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "restricted-environment",
+			args:            []string{"-c", `echo "$HOME-$CUSTOM"`},
+			env:             map[string]string{"CUSTOM": "value"},
+			conditions:      []Condition{"[BODY] == -value"},
+			expectedSuccess: true,
+		},
+		{
+			name:            "timeout",
+			args:            []string{"-c", "sleep 5"},
+			timeout:         100 * time.Millisecond,
+			conditions:      []Condition{"[EXIT_CODE] == 0"},
+			expectedSuccess: false,
+			expectedError:   "command killed after 100ms (client timeout)",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			endpoint := Endpoint{
+				Name:       "exec",
+				URL:        "exec:///bin/sh",
+				Exec:       &Exec{Args: scenario.args, Env: scenario.env},
+				Conditions: scenario.conditions,
+			}
+			if scenario.timeout > 0 {
+				endpoint.ClientConfig = &client.Config{Timeout: scenario.timeout}
+			}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			startTime := time.Now()
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if len(scenario.expectedError) > 0 && (len(result.Errors) == 0 || result.Errors[0] != scenario.expectedError) {
+				t.Errorf("expected error %q, got %v", scenario.expectedError, result.Errors)
+			}
+			if scenario.timeout > 0 && time.Since(startTime) > 3*time.Second {
+				t.Error("expected the command to have been killed on timeout")
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithExecAndUnknownCommand(t *testing.T) {
+	endpoint := Endpoint{Name: "exec", URL: "exec:///does/not/exist", Conditions: []Condition{"[EXIT_CODE] == -1"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if !result.Success || result.Connected {
+		t.Errorf("expected the exit code to be -1 and connected to be false, got success=%v, connected=%v", result.Success, result.Connected)
+	}
+	if len(result.Errors) == 0 || !strings.Contains(result.Errors[0], "no such file or directory") {
+		t.Errorf("expected an error about the command not existing, got %v", result.Errors)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithExec(t *testing.T) {
+	endpoint := Endpoint{Name: "exec", URL: "exec://", Conditions: []Condition{"[EXIT_CODE] == 0"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrExecWithNoCommand {
+		t.Errorf("expected error %v, got %v", ErrExecWithNoCommand, err)
+	}
+	endpoint = Endpoint{Name: "exec", URL: "https://example.org", Exec: &Exec{}, Conditions: []Condition{"[STATUS] == 200"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != ErrExecWithUnsupportedEndpointType {
+		t.Errorf("expected error %v, got %v", ErrExecWithUnsupportedEndpointType, err)
+	}
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrGraphQLWithNoQuery is the error with which Gatus will panic if an endpoint is configured with GraphQL, but
+	// without a query
+	ErrGraphQLWithNoQuery = errors.New("you must specify a query for GraphQL")
+)
+
+// GraphQL is the configuration for an Endpoint that is queried using GraphQL
+//
+// For backward compatibility, it can also be configured with a boolean (i.e. `graphql: true`), in which case the
+// Endpoint's body is used as the query.
+type GraphQL struct {
+	// Enabled defines whether the GraphQL configuration is enabled
+	//
+	// Only set if the configuration was provided as a boolean. Use GraphQL.IsEnabled() to retrieve the value.
+	Enabled *bool `yaml:"-"`
+
+	// Query is the GraphQL query to send. If empty, the Endpoint's body is used instead.
+	Query string `yaml:"query,omitempty"`
+
+	// Variables is a map of variables to send along with the query
+	Variables map[string]interface{} `yaml:"variables,omitempty"`
+
+	// OperationName is the name of the operation to execute, if the query contains multiple operations
+	OperationName string `yaml:"operation-name,omitempty"`
+}
+
+// UnmarshalYAML allows GraphQL to be configured either with a boolean or with a map
+func (g *GraphQL) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		var enabled bool
+		if err := value.Decode(&enabled); err != nil {
+			return err
+		}
+		g.Enabled = &enabled
+		return nil
+	}
+	// Use an alias to prevent infinite recursion
+	type graphQLAlias GraphQL
+	return value.Decode((*graphQLAlias)(g))
+}
+
+// IsEnabled returns whether GraphQL is enabled
+func (g *GraphQL) IsEnabled() bool {
+	if g == nil {
+		return false
+	}
+	if g.Enabled == nil {
+		return true
+	}
+	return *g.Enabled
+}
+
+func (g *GraphQL) validateAndSetDefault(body string) error {
+	if len(g.Query) == 0 && len(body) == 0 {
+		return ErrGraphQLWithNoQuery
+	}
+	return nil
+}
+
+// buildRequestBody builds the JSON payload of a GraphQL request
+func (g *GraphQL) buildRequestBody(body string) []byte {
+	graphQLBody := map[string]interface{}{}
+	if len(g.Query) > 0 {
+		graphQLBody["query"] = g.Query
+	} else {
+		graphQLBody["query"] = body
+	}
+	if len(g.Variables) > 0 {
+		graphQLBody["variables"] = g.Variables
+	}
+	if len(g.OperationName) > 0 {
+		graphQLBody["operationName"] = g.OperationName
+	}
+	payload, _ := json.Marshal(graphQLBody)
+	return payload
+}
+
+// countGraphQLErrors returns the number of elements in the "errors" array of a GraphQL response body
+//
+// If the body is not a valid GraphQL response, 0 is returned.
+func countGraphQLErrors(body []byte) int {
+	var response struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return 0
+	}
+	return len(response.Errors)
+}
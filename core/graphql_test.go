@@ -0,0 +1,122 @@
+package core
+
+import (
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestGraphQL_UnmarshalYAML(t *testing.T) {
+	scenarios := []struct {
+		name                  string
+		yaml                  string
+		expectedEnabled       bool
+		expectedQuery         string
+		expectedOperationName string
+	}{
+		{
+			name:            "bool-true",
+			yaml:            "graphql: true",
+			expectedEnabled: true,
+		},
+		{
+			name:            "bool-false",
+			yaml:            "graphql: false",
+			expectedEnabled: false,
+		},
+		{
+			name:                  "map",
+			yaml:                  "graphql:\n  query: \"{ users { id } }\"\n  operation-name: ListUsers\n  variables:\n    limit: 10",
+			expectedEnabled:       true,
+			expectedQuery:         "{ users { id } }",
+			expectedOperationName: "ListUsers",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var endpoint Endpoint
+			if err := yaml.Unmarshal([]byte(scenario.yaml), &endpoint); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if endpoint.GraphQL.IsEnabled() != scenario.expectedEnabled {
+				t.Errorf("expected enabled to be %v, got %v", scenario.expectedEnabled, endpoint.GraphQL.IsEnabled())
+			}
+			if scenario.expectedEnabled {
+				if endpoint.GraphQL.Query != scenario.expectedQuery {
+					t.Errorf("expected query to be %s, got %s", scenario.expectedQuery, endpoint.GraphQL.Query)
+				}
+				if endpoint.GraphQL.OperationName != scenario.expectedOperationName {
+					t.Errorf("expected operation name to be %s, got %s", scenario.expectedOperationName, endpoint.GraphQL.OperationName)
+				}
+			}
+		})
+	}
+}
+
+func TestGraphQL_IsEnabled(t *testing.T) {
+	var nilGraphQL *GraphQL
+	if nilGraphQL.IsEnabled() {
+		t.Error("expected nil GraphQL to not be enabled")
+	}
+	if !(&GraphQL{Query: "{ users { id } }"}).IsEnabled() {
+		t.Error("expected GraphQL to be enabled")
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithGraphQLSetsDefaultMethod(t *testing.T) {
+	scenarios := []struct {
+		name           string
+		yaml           string
+		expectedMethod string
+	}{
+		{
+			name:           "bool-true",
+			yaml:           "graphql: true\nbody: \"{ users { id } }\"",
+			expectedMethod: "GET",
+		},
+		{
+			name:           "bool-true-with-method",
+			yaml:           "graphql: true\nmethod: POST\nbody: \"{ users { id } }\"",
+			expectedMethod: "POST",
+		},
+		{
+			name:           "map",
+			yaml:           "graphql:\n  query: \"{ users { id } }\"",
+			expectedMethod: "POST",
+		},
+		{
+			name:           "map-with-method",
+			yaml:           "graphql:\n  query: \"{ users { id } }\"\nmethod: GET",
+			expectedMethod: "GET",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			var endpoint Endpoint
+			if err := yaml.Unmarshal([]byte(scenario.yaml), &endpoint); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			endpoint.Name = "graphql"
+			endpoint.URL = "https://example.org/graphql"
+			endpoint.Conditions = []Condition{"[STATUS] == 200"}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if endpoint.Method != scenario.expectedMethod {
+				t.Errorf("expected method to be %s, got %s", scenario.expectedMethod, endpoint.Method)
+			}
+		})
+	}
+}
+
+func TestCountGraphQLErrors(t *testing.T) {
+	if n := countGraphQLErrors([]byte(`{"data":{"id":1}}`)); n != 0 {
+		t.Errorf("expected 0 errors, got %d", n)
+	}
+	if n := countGraphQLErrors([]byte(`{"errors":[{"message":"a"},{"message":"b"}]}`)); n != 2 {
+		t.Errorf("expected 2 errors, got %d", n)
+	}
+	if n := countGraphQLErrors([]byte(`not json`)); n != 0 {
+		t.Errorf("expected 0 errors, got %d", n)
+	}
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// headerTemplateFuncs are the functions available in the templates of the values of the headers of an endpoint
+var headerTemplateFuncs = template.FuncMap{
+	"now": time.Now,
+	// hmacSHA256 returns the hex-encoded HMAC-SHA256 of a message computed with a key
+	"hmacSHA256": func(key, message string) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write([]byte(message))
+		return hex.EncodeToString(mac.Sum(nil))
+	},
+	"base64": func(s string) string {
+		return base64.StdEncoding.EncodeToString([]byte(s))
+	},
+}
+
+// localHeaderTemplateFuncs are the functions available in the templates of the values of the headers of an endpoint
+// that read from the host Gatus runs on, which is why they're only available to the endpoints of the configuration
+var localHeaderTemplateFuncs = template.FuncMap{
+	// env returns the value of an environment variable when the header is rendered, rather than when the
+	// configuration is loaded like with ${NAME}
+	"env": func(name string) (string, error) {
+		value, exists := os.LookupEnv(name)
+		if !exists {
+			return "", fmt.Errorf("environment variable %s is not set", name)
+		}
+		return value, nil
+	},
+	// file returns the content of a file without its surrounding whitespace, which allows the use of tokens that are
+	// rotated on disk (e.g. Kubernetes projected service account tokens)
+	"file": func(path string) (string, error) {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(string(content)), nil
+	},
+}
+
+// headerTemplateData is the data the templates of the values of the headers of an endpoint are rendered with
+type headerTemplateData struct {
+	Endpoint *Endpoint
+}
+
+// parseHeaderTemplates parses the values of the headers of the endpoint that have template actions
+//
+// If allowLocalAccess is false, the functions of localHeaderTemplateFuncs are not available to the templates.
+func (endpoint *Endpoint) parseHeaderTemplates(allowLocalAccess bool) error {
+	endpoint.headerTemplates = nil
+	for name, value := range endpoint.Headers {
+		if !strings.Contains(value, "{{") {
+			continue
+		}
+		headerTemplate := template.New(name).Funcs(headerTemplateFuncs)
+		if allowLocalAccess {
+			headerTemplate = headerTemplate.Funcs(localHeaderTemplateFuncs)
+		}
+		headerTemplate, err := headerTemplate.Option("missingkey=error").Parse(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrEndpointWithInvalidHeaderTemplate, err.Error())
+		}
+		if endpoint.headerTemplates == nil {
+			endpoint.headerTemplates = make(map[string]*template.Template)
+		}
+		endpoint.headerTemplates[name] = headerTemplate
+	}
+	return nil
+}
+
+// renderHeaders returns the headers of the endpoint, with the values that have template actions rendered
+func (endpoint *Endpoint) renderHeaders() (map[string]string, error) {
+	if len(endpoint.headerTemplates) == 0 {
+		return endpoint.Headers, nil
+	}
+	headers := make(map[string]string, len(endpoint.Headers))
+	for name, value := range endpoint.Headers {
+		if headerTemplate, exists := endpoint.headerTemplates[name]; exists {
+			var rendered strings.Builder
+			if err := headerTemplate.Execute(&rendered, headerTemplateData{Endpoint: endpoint}); err != nil {
+				return nil, fmt.Errorf("failed to render header %s: %w", name, err)
+			}
+			value = rendered.String()
+		}
+		headers[name] = value
+	}
+	return headers, nil
+}
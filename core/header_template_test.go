@@ -0,0 +1,125 @@
+package core
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpoint_buildHTTPRequestWithHeaderTemplates(t *testing.T) {
+	t.Setenv("GATUS_TEST_API_KEY", "key")
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("token-1\n"), 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint := Endpoint{
+		Name: "api",
+		URL:  "https://example.org/health",
+		Headers: map[string]string{
+			"Authorization": `Bearer {{ file "` + tokenFile + `" }}`,
+			"X-Api-Key":     `{{ env "GATUS_TEST_API_KEY" }}`,
+			"X-Signature":   `{{ hmacSHA256 "secret" .Endpoint.Name }}`,
+			"X-Basic":       `{{ base64 "user:password" }}`,
+			"X-Timestamp":   `{{ (now).Unix }}`,
+			"X-Static":      "static",
+		},
+		Conditions: []Condition{"[STATUS] == 200"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	request, err := endpoint.buildHTTPRequest()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	expectedHeaders := map[string]string{
+		"Authorization": "Bearer token-1",
+		"X-Api-Key":     "key",
+		"X-Signature":   "1a126da5a08d45bffb3ea84cc1c9a7373752e10fc2224fbe84183e929323d540",
+		"X-Basic":       "dXNlcjpwYXNzd29yZA==",
+		"X-Static":      "static",
+	}
+	for name, expectedValue := range expectedHeaders {
+		if value := request.Header.Get(name); value != expectedValue {
+			t.Errorf("expected header %s to be %q, got %q", name, expectedValue, value)
+		}
+	}
+	if timestamp, err := strconv.ParseInt(request.Header.Get("X-Timestamp"), 10, 64); err != nil || time.Since(time.Unix(timestamp, 0)) > time.Minute {
+		t.Errorf("expected X-Timestamp to be the current unix timestamp, got %q", request.Header.Get("X-Timestamp"))
+	}
+	// The headers must be rendered for every request, so that rotated tokens are picked up
+	if err = os.WriteFile(tokenFile, []byte("token-2"), 0o600); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if request, _ = endpoint.buildHTTPRequest(); request.Header.Get("Authorization") != "Bearer token-2" {
+		t.Errorf("expected the rotated token to be used, got %q", request.Header.Get("Authorization"))
+	}
+	// The headers of the endpoint itself must be left untouched
+	if endpoint.Headers["X-Static"] != "static" || !strings.Contains(endpoint.Headers["Authorization"], "{{") {
+		t.Error("expected the headers of the endpoint to be left untouched, got", endpoint.Headers)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithInvalidHeaderTemplate(t *testing.T) {
+	endpoint := Endpoint{
+		Name:       "api",
+		URL:        "https://example.org/health",
+		Headers:    map[string]string{"Authorization": "Bearer {{ env "},
+		Conditions: []Condition{"[STATUS] == 200"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithInvalidHeaderTemplate) {
+		t.Errorf("expected error %v, got %v", ErrEndpointWithInvalidHeaderTemplate, err)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithHeaderTemplateThatFailsToRender(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:       "api",
+		URL:        server.URL,
+		Headers:    map[string]string{"Authorization": `Bearer {{ env "GATUS_TEST_UNSET_VARIABLE" }}`},
+		Conditions: []Condition{"[STATUS] == 200"},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if result.Success {
+		t.Error("expected the check to fail, since the header couldn't be rendered")
+	}
+	if len(result.Errors) != 1 || !strings.Contains(result.Errors[0], "failed to render header Authorization") || !strings.Contains(result.Errors[0], "environment variable GATUS_TEST_UNSET_VARIABLE is not set") {
+		t.Errorf("expected an error explaining why the header couldn't be rendered, got %v", result.Errors)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithUntrustedEndpointAndLocalHeaderTemplate(t *testing.T) {
+	for _, value := range []string{`{{ env "HOME" }}`, `{{ file "/etc/passwd" }}`} {
+		endpoint := Endpoint{
+			Name:       "api",
+			URL:        "https://example.org/health",
+			Headers:    map[string]string{"Authorization": value},
+			Conditions: []Condition{"[STATUS] == 200"},
+			Untrusted:  true,
+		}
+		if err := endpoint.ValidateAndSetDefaults(); !errors.Is(err, ErrEndpointWithInvalidHeaderTemplate) {
+			t.Errorf("expected error %v for %s, got %v", ErrEndpointWithInvalidHeaderTemplate, value, err)
+		}
+	}
+	endpoint := Endpoint{
+		Name:       "api",
+		URL:        "https://example.org/health",
+		Headers:    map[string]string{"X-Basic": `{{ base64 "user:password" }}`},
+		Conditions: []Condition{"[STATUS] == 200"},
+		Untrusted:  true,
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Error("expected no error, got", err.Error())
+	}
+}
@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/jsonpath"
+	"github.com/coreos/go-oidc/v3/oidc"
+)
+
+var (
+	// ErrJWTWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that isn't of type HTTP
+	// has a jwt configuration
+	ErrJWTWithUnsupportedEndpointType = errors.New("jwt is only supported for endpoints of type HTTP")
+
+	// ErrJWTWithNoOrMultipleSources is the error with which Gatus will panic if the jwt configuration of an endpoint
+	// doesn't specify exactly one of header and path
+	ErrJWTWithNoOrMultipleSources = errors.New("you must specify either a header or a path for jwt, but not both")
+
+	// ErrJWTWithNoOrMultipleKeys is the error with which Gatus will panic if the jwt configuration of an endpoint
+	// doesn't specify exactly one of jwks-url and public-key
+	ErrJWTWithNoOrMultipleKeys = errors.New("you must specify either a jwks-url or a public-key for jwt, but not both")
+
+	// ErrJWTWithInvalidPublicKey is the error with which Gatus will panic if the public key of the jwt configuration of
+	// an endpoint isn't a PEM-encoded RSA, ECDSA or Ed25519 public key or certificate
+	ErrJWTWithInvalidPublicKey = errors.New("jwt public-key must be a PEM-encoded RSA, ECDSA or Ed25519 public key or certificate")
+
+	// ErrJWTPlaceholderWithNoJWT is the error with which Gatus will panic if an endpoint has a condition using the
+	// JWTValidPlaceholder or the JWTPlaceholder, but no jwt configuration
+	ErrJWTPlaceholderWithNoJWT = errors.New("conditions using the " + JWTValidPlaceholder + " or " + JWTPlaceholder + " placeholders require jwt to be configured")
+)
+
+// JWT is the configuration for validating a JSON Web Token returned by an Endpoint of type HTTP
+//
+// The token is extracted from either a header or the body of the response, and its signature is verified against
+// either the keys of a JWKS URL or a public key. See JWTValidPlaceholder and JWTPlaceholder.
+type JWT struct {
+	// Header is the name of the response header containing the token (e.g. Authorization).
+	// The "Bearer " prefix, if any, is removed.
+	Header string `yaml:"header,omitempty"`
+
+	// Path is the JSONPath of the token in the response body (e.g. access_token), using the same syntax as the
+	// conditions using the BodyPlaceholder
+	Path string `yaml:"path,omitempty"`
+
+	// JWKSURL is the URL of the JSON Web Key Set used to verify the signature of the token
+	//
+	// The keys are cached, and only fetched again when a token is signed with a key that isn't cached, which is how
+	// rotated keys are picked up.
+	JWKSURL string `yaml:"jwks-url,omitempty"`
+
+	// PublicKey is the PEM-encoded public key, or certificate, used to verify the signature of the token
+	PublicKey string `yaml:"public-key,omitempty"`
+
+	// keySet is the key set the signature of the token is verified with
+	keySet oidc.KeySet
+}
+
+func (j *JWT) validateAndSetDefault(clientConfig *client.Config) error {
+	if (len(j.Header) == 0) == (len(j.Path) == 0) {
+		return ErrJWTWithNoOrMultipleSources
+	}
+	if (len(j.JWKSURL) == 0) == (len(j.PublicKey) == 0) {
+		return ErrJWTWithNoOrMultipleKeys
+	}
+	if len(j.JWKSURL) > 0 {
+		j.keySet = oidc.NewRemoteKeySet(oidc.ClientContext(context.Background(), client.GetHTTPClient(clientConfig)), j.JWKSURL)
+	} else {
+		publicKey, err := parsePublicKey(j.PublicKey)
+		if err != nil {
+			return ErrJWTWithInvalidPublicKey
+		}
+		j.keySet = &oidc.StaticKeySet{PublicKeys: []crypto.PublicKey{publicKey}}
+	}
+	return nil
+}
+
+// parsePublicKey parses a PEM-encoded public key or certificate
+func parsePublicKey(encodedPublicKey string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(encodedPublicKey))
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+	if block.Type == "CERTIFICATE" {
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		return certificate.PublicKey, nil
+	}
+	return x509.ParsePKIXPublicKey(block.Bytes)
+}
+
+// verify extracts the token from the response and verifies its signature and its validity period
+//
+// The claims of the token are only set on the result if its signature is valid, so that conditions on the claims
+// can't be satisfied by a forged token.
+func (j *JWT) verify(ctx context.Context, response *http.Response, body []byte, result *Result) {
+	token, err := j.extractToken(response, body)
+	if err != nil {
+		result.AddError("invalid JWT: " + err.Error())
+		return
+	}
+	payload, err := j.keySet.VerifySignature(ctx, token)
+	if err != nil {
+		result.AddError("invalid JWT: " + err.Error())
+		return
+	}
+	result.JWTClaims = payload
+	var claims struct {
+		Expiration *float64 `json:"exp"`
+		NotBefore  *float64 `json:"nbf"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		result.AddError("invalid JWT: malformed claims: " + err.Error())
+		return
+	}
+	now := time.Now()
+	if claims.Expiration != nil && !now.Before(time.Unix(int64(*claims.Expiration), 0)) {
+		result.AddError(fmt.Sprintf("invalid JWT: token expired at %s", time.Unix(int64(*claims.Expiration), 0).UTC().Format(time.RFC3339)))
+		return
+	}
+	if claims.NotBefore != nil && now.Before(time.Unix(int64(*claims.NotBefore), 0)) {
+		result.AddError(fmt.Sprintf("invalid JWT: token not valid before %s", time.Unix(int64(*claims.NotBefore), 0).UTC().Format(time.RFC3339)))
+		return
+	}
+	result.JWTValid = true
+}
+
+// extractToken returns the token from the header or from the body of the response, depending on the configuration
+func (j *JWT) extractToken(response *http.Response, body []byte) (string, error) {
+	var token string
+	if len(j.Header) > 0 {
+		token = strings.TrimSpace(response.Header.Get(j.Header))
+		if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+			token = strings.TrimSpace(token[7:])
+		}
+	} else {
+		var err error
+		if token, _, err = jsonpath.Eval(j.Path, body); err != nil {
+			return "", fmt.Errorf("failed to extract token from body: %w", err)
+		}
+	}
+	if len(token) == 0 {
+		return "", errors.New("no token found in response")
+	}
+	return token, nil
+}
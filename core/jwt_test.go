@@ -0,0 +1,241 @@
+package core
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEndpoint_EvaluateHealthWithJWT(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(buildTestJWKS("key-1", &key.PublicKey))
+	}))
+	defer jwksServer.Close()
+	now := time.Now()
+	validToken := signTestJWT(key, "key-1", map[string]interface{}{"iss": "https://auth.example.org", "exp": now.Add(time.Hour).Unix()})
+	scenarios := []struct {
+		name               string
+		token              string
+		jwt                JWT
+		conditions         []Condition
+		expectedSuccess    bool
+		expectedJWTValid   bool
+		expectedErrorMatch string
+	}{
+		{
+			name:             "valid-token-in-header-with-jwks-url",
+			token:            validToken,
+			jwt:              JWT{Header: "Authorization", JWKSURL: jwksServer.URL},
+			conditions:       []Condition{"[JWT_VALID] == true", "[JWT].iss == https://auth.example.org", "[JWT].exp > 0"},
+			expectedSuccess:  true,
+			expectedJWTValid: true,
+		},
+		{
+			name:             "valid-token-in-body-with-public-key",
+			token:            validToken,
+			jwt:              JWT{Path: "access_token", PublicKey: encodeTestPublicKey(&key.PublicKey)},
+			conditions:       []Condition{"[JWT_VALID] == true", "[JWT].iss == https://auth.example.org"},
+			expectedSuccess:  true,
+			expectedJWTValid: true,
+		},
+		{
+			name:               "expired-token",
+			token:              signTestJWT(key, "key-1", map[string]interface{}{"iss": "https://auth.example.org", "exp": now.Add(-time.Hour).Unix()}),
+			jwt:                JWT{Header: "Authorization", JWKSURL: jwksServer.URL},
+			conditions:         []Condition{"[JWT_VALID] == true"},
+			expectedSuccess:    false,
+			expectedJWTValid:   false,
+			expectedErrorMatch: "token expired",
+		},
+		{
+			name:               "token-not-yet-valid",
+			token:              signTestJWT(key, "key-1", map[string]interface{}{"nbf": now.Add(time.Hour).Unix()}),
+			jwt:                JWT{Header: "Authorization", JWKSURL: jwksServer.URL},
+			conditions:         []Condition{"[JWT_VALID] == true"},
+			expectedSuccess:    false,
+			expectedJWTValid:   false,
+			expectedErrorMatch: "token not valid before",
+		},
+		{
+			name:               "token-signed-with-another-key",
+			token:              signTestJWT(otherKey, "key-2", map[string]interface{}{"iss": "https://auth.example.org"}),
+			jwt:                JWT{Header: "Authorization", JWKSURL: jwksServer.URL},
+			conditions:         []Condition{"[JWT_VALID] == false", "[JWT].iss == https://auth.example.org"},
+			expectedSuccess:    false,
+			expectedJWTValid:   false,
+			expectedErrorMatch: "invalid JWT",
+		},
+		{
+			name:               "no-token",
+			jwt:                JWT{Header: "Authorization", JWKSURL: jwksServer.URL},
+			conditions:         []Condition{"[JWT_VALID] == false"},
+			expectedSuccess:    true,
+			expectedJWTValid:   false,
+			expectedErrorMatch: "no token found",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if len(scenario.token) > 0 {
+					w.Header().Set("Authorization", "Bearer "+scenario.token)
+				}
+				_ = json.NewEncoder(w).Encode(map[string]string{"access_token": scenario.token})
+			}))
+			defer server.Close()
+			jwt := scenario.jwt
+			endpoint := Endpoint{Name: "name", URL: server.URL, JWT: &jwt, Conditions: scenario.conditions}
+			if err := endpoint.ValidateAndSetDefaults(); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			result := endpoint.EvaluateHealth()
+			if result.Success != scenario.expectedSuccess {
+				t.Errorf("expected success to be %v, got %v (errors: %v)", scenario.expectedSuccess, result.Success, result.Errors)
+			}
+			if result.JWTValid != scenario.expectedJWTValid {
+				t.Errorf("expected JWTValid to be %v, got %v (errors: %v)", scenario.expectedJWTValid, result.JWTValid, result.Errors)
+			}
+			if len(scenario.expectedErrorMatch) > 0 && (len(result.Errors) != 1 || !strings.Contains(result.Errors[0], scenario.expectedErrorMatch)) {
+				t.Errorf("expected an error containing %q, got %v", scenario.expectedErrorMatch, result.Errors)
+			}
+			if len(scenario.expectedErrorMatch) == 0 && len(result.Errors) != 0 {
+				t.Errorf("expected no errors, got %v", result.Errors)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithJWTCachesJWKS(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	var jwksRequests int
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jwksRequests++
+		_, _ = w.Write(buildTestJWKS("key-1", &key.PublicKey))
+	}))
+	defer jwksServer.Close()
+	token := signTestJWT(key, "key-1", map[string]interface{}{"iss": "https://auth.example.org"})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Token", token)
+	}))
+	defer server.Close()
+	endpoint := Endpoint{Name: "name", URL: server.URL, JWT: &JWT{Header: "X-Token", JWKSURL: jwksServer.URL}, Conditions: []Condition{"[JWT_VALID] == true"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 3; i++ {
+		if result := endpoint.EvaluateHealth(); !result.Success {
+			t.Errorf("expected success, got errors %v", result.Errors)
+		}
+	}
+	if jwksRequests != 1 {
+		t.Errorf("expected the JWKS to have been fetched once, got %d requests", jwksRequests)
+	}
+	// A token signed with a key that isn't cached must cause the JWKS to be fetched again, so that rotated keys are
+	// picked up
+	token = signTestJWT(key, "key-2", map[string]interface{}{"iss": "https://auth.example.org"})
+	endpoint.EvaluateHealth()
+	if jwksRequests != 2 {
+		t.Errorf("expected the JWKS to have been fetched again, got %d requests", jwksRequests)
+	}
+}
+
+func TestEndpoint_ValidateAndSetDefaultsWithJWT(t *testing.T) {
+	key, _ := rsa.GenerateKey(rand.Reader, 2048)
+	scenarios := []struct {
+		name        string
+		endpoint    Endpoint
+		expectedErr error
+	}{
+		{
+			name:     "valid",
+			endpoint: Endpoint{URL: "https://example.org/token", JWT: &JWT{Path: "access_token", PublicKey: encodeTestPublicKey(&key.PublicKey)}, Conditions: []Condition{"[JWT_VALID] == true"}},
+		},
+		{
+			name:        "placeholder-without-jwt",
+			endpoint:    Endpoint{URL: "https://example.org/token", Conditions: []Condition{"[JWT].iss == https://auth.example.org"}},
+			expectedErr: ErrJWTPlaceholderWithNoJWT,
+		},
+		{
+			name:        "unsupported-endpoint-type",
+			endpoint:    Endpoint{URL: "tcp://example.org:443", JWT: &JWT{Header: "Authorization", JWKSURL: "https://example.org/jwks"}, Conditions: []Condition{"[CONNECTED] == true"}},
+			expectedErr: ErrJWTWithUnsupportedEndpointType,
+		},
+		{
+			name:        "no-source",
+			endpoint:    Endpoint{URL: "https://example.org/token", JWT: &JWT{JWKSURL: "https://example.org/jwks"}, Conditions: []Condition{"[JWT_VALID] == true"}},
+			expectedErr: ErrJWTWithNoOrMultipleSources,
+		},
+		{
+			name:        "multiple-sources",
+			endpoint:    Endpoint{URL: "https://example.org/token", JWT: &JWT{Header: "Authorization", Path: "access_token", JWKSURL: "https://example.org/jwks"}, Conditions: []Condition{"[JWT_VALID] == true"}},
+			expectedErr: ErrJWTWithNoOrMultipleSources,
+		},
+		{
+			name:        "no-key",
+			endpoint:    Endpoint{URL: "https://example.org/token", JWT: &JWT{Header: "Authorization"}, Conditions: []Condition{"[JWT_VALID] == true"}},
+			expectedErr: ErrJWTWithNoOrMultipleKeys,
+		},
+		{
+			name:        "multiple-keys",
+			endpoint:    Endpoint{URL: "https://example.org/token", JWT: &JWT{Header: "Authorization", JWKSURL: "https://example.org/jwks", PublicKey: encodeTestPublicKey(&key.PublicKey)}, Conditions: []Condition{"[JWT_VALID] == true"}},
+			expectedErr: ErrJWTWithNoOrMultipleKeys,
+		},
+		{
+			name:        "invalid-public-key",
+			endpoint:    Endpoint{URL: "https://example.org/token", JWT: &JWT{Header: "Authorization", PublicKey: "not-a-key"}, Conditions: []Condition{"[JWT_VALID] == true"}},
+			expectedErr: ErrJWTWithInvalidPublicKey,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			scenario.endpoint.Name = "name"
+			if err := scenario.endpoint.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+// signTestJWT returns a JWT signed with RS256 by the key passed
+func signTestJWT(key *rsa.PrivateKey, keyID string, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	payload, _ := json.Marshal(claims)
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hash[:])
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// buildTestJWKS returns a JSON Web Key Set containing the public key passed
+func buildTestJWKS(keyID string, publicKey *rsa.PublicKey) []byte {
+	jwks, _ := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": keyID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+		}},
+	})
+	return jwks
+}
+
+// encodeTestPublicKey returns the PEM encoding of the public key passed
+func encodeTestPublicKey(publicKey *rsa.PublicKey) string {
+	encodedPublicKey, _ := x509.MarshalPKIXPublicKey(publicKey)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encodedPublicKey}))
+}
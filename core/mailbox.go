@@ -0,0 +1,100 @@
+package core
+
+import (
+	"errors"
+	"net"
+	"net/url"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+)
+
+var (
+	// ErrMailboxWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that isn't of type
+	// IMAP or POP3 has a mailbox configuration
+	ErrMailboxWithUnsupportedEndpointType = errors.New("mailbox is only supported for IMAP and POP3 endpoints")
+
+	// ErrMailboxWithUsernameAndNoPassword is the error with which Gatus will panic if a mailbox has a username but
+	// no password
+	ErrMailboxWithUsernameAndNoPassword = errors.New("you must specify a password for the mailbox if a username is specified")
+
+	// ErrMailboxWithStartTLSAndImplicitTLS is the error with which Gatus will panic if a mailbox has STARTTLS enabled
+	// while its URL uses a scheme that already implies TLS (imaps:// or pop3s://)
+	ErrMailboxWithStartTLSAndImplicitTLS = errors.New("starttls cannot be enabled for imaps:// and pop3s:// endpoints")
+)
+
+// mailboxDefaultPorts are the ports used for each mailbox scheme if the URL of the endpoint doesn't have one
+var mailboxDefaultPorts = map[string]string{
+	"imap":  "143",
+	"imaps": "993",
+	"pop3":  "110",
+	"pop3s": "995",
+}
+
+// Mailbox is the configuration for an Endpoint of type IMAP or POP3
+type Mailbox struct {
+	// Username is the username to authenticate with. If empty, the endpoint is only checked for reachability.
+	Username string `yaml:"username,omitempty"`
+
+	// Password is the password to authenticate with
+	Password string `yaml:"password,omitempty"`
+
+	// StartTLS determines whether the connection is upgraded to TLS using STARTTLS (STLS for POP3)
+	// Only supported for the imap:// and pop3:// schemes.
+	StartTLS bool `yaml:"starttls,omitempty"`
+}
+
+func (m *Mailbox) validateAndSetDefault(endpointURL string) error {
+	if len(m.Username) > 0 && len(m.Password) == 0 {
+		return ErrMailboxWithUsernameAndNoPassword
+	}
+	if m.StartTLS && isMailboxImplicitTLS(endpointURL) {
+		return ErrMailboxWithStartTLSAndImplicitTLS
+	}
+	return nil
+}
+
+func isMailboxImplicitTLS(endpointURL string) bool {
+	if parsedURL, err := url.Parse(endpointURL); err == nil {
+		return parsedURL.Scheme == "imaps" || parsedURL.Scheme == "pop3s"
+	}
+	return false
+}
+
+// checkMailbox connects to the IMAP or POP3 server of the endpoint and records the outcome in the result
+func (endpoint *Endpoint) checkMailbox(result *Result) {
+	parsedURL, err := url.Parse(endpoint.URL)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	address := parsedURL.Host
+	if len(parsedURL.Port()) == 0 {
+		address = net.JoinHostPort(parsedURL.Hostname(), mailboxDefaultPorts[parsedURL.Scheme])
+	}
+	request := &client.MailboxRequest{
+		Address:     address,
+		ImplicitTLS: isMailboxImplicitTLS(endpoint.URL),
+	}
+	if endpoint.Mailbox != nil {
+		request.StartTLS = endpoint.Mailbox.StartTLS
+		request.Username = endpoint.Mailbox.Username
+		request.Password = endpoint.Mailbox.Password
+	}
+	var response *client.MailboxResponse
+	startTime := time.Now()
+	if endpoint.Type() == EndpointTypeIMAP {
+		response, err = client.CheckIMAP(request, endpoint.ClientConfig)
+	} else {
+		response, err = client.CheckPOP3(request, endpoint.ClientConfig)
+	}
+	result.Duration = time.Since(startTime)
+	result.Connected = response.Connected
+	result.Body = response.Greeting
+	if len(response.Certificates) > 0 {
+		result.setCertificates(response.Certificates)
+	}
+	if err != nil {
+		result.AddError(err.Error())
+	}
+}
@@ -0,0 +1,121 @@
+package core
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// ResponseTimeHistogramBuckets are the upper bounds, in milliseconds, of the buckets of a ResponseTimeHistogram.
+//
+// Response times above the last upper bound are counted in an additional bucket.
+var ResponseTimeHistogramBuckets = []int64{5, 10, 25, 50, 75, 100, 150, 200, 250, 300, 400, 500, 750, 1000, 1500, 2000, 3000, 5000, 7500, 10000, 15000, 30000, 60000}
+
+// ResponseTimeHistogram is the number of checks whose response time fell in each of the ResponseTimeHistogramBuckets
+//
+// Unlike the response times themselves, histograms can be added together, which allows the percentiles of the response
+// time over a long period to be computed from the histograms of each hour of that period.
+type ResponseTimeHistogram struct {
+	// Counts are the number of checks in each bucket, with the last one being for response times above the last
+	// upper bound of ResponseTimeHistogramBuckets
+	Counts []uint64
+}
+
+// NewResponseTimeHistogram creates an empty ResponseTimeHistogram
+func NewResponseTimeHistogram() *ResponseTimeHistogram {
+	return &ResponseTimeHistogram{Counts: make([]uint64, len(ResponseTimeHistogramBuckets)+1)}
+}
+
+// ResponseTimeHistogramBucket returns the index of the bucket of a ResponseTimeHistogram a response time falls in
+func ResponseTimeHistogramBucket(responseTime time.Duration) int {
+	milliseconds := responseTime.Milliseconds()
+	return sort.Search(len(ResponseTimeHistogramBuckets), func(i int) bool {
+		return milliseconds <= ResponseTimeHistogramBuckets[i]
+	})
+}
+
+// Add adds a number of checks to a bucket of the histogram
+//
+// Buckets that don't exist are ignored.
+func (h *ResponseTimeHistogram) Add(bucket int, count uint64) {
+	if bucket >= 0 && bucket < len(h.Counts) {
+		h.Counts[bucket] += count
+	}
+}
+
+// Merge adds counts, such as the Counts of another histogram, to the histogram
+func (h *ResponseTimeHistogram) Merge(counts []uint64) {
+	for bucket, count := range counts {
+		h.Add(bucket, count)
+	}
+}
+
+// Total returns the number of checks in the histogram
+func (h *ResponseTimeHistogram) Total() uint64 {
+	var total uint64
+	for _, count := range h.Counts {
+		total += count
+	}
+	return total
+}
+
+// bucketBounds returns the lower and upper bounds, in milliseconds, of a bucket
+//
+// Since the last bucket has no upper bound, its lower bound is returned as both.
+func bucketBounds(bucket int) (float64, float64) {
+	var lower float64
+	if bucket > 0 {
+		lower = float64(ResponseTimeHistogramBuckets[bucket-1])
+	}
+	if bucket == len(ResponseTimeHistogramBuckets) {
+		return lower, lower
+	}
+	return lower, float64(ResponseTimeHistogramBuckets[bucket])
+}
+
+// Percentile returns an estimate of the response time under which the percentage passed (e.g. 95) of checks fell
+//
+// The response times are assumed to be evenly distributed within each bucket.
+func (h *ResponseTimeHistogram) Percentile(percentage float64) time.Duration {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	rank := percentage / 100 * float64(total)
+	var cumulated float64
+	for bucket, count := range h.Counts {
+		if count == 0 {
+			continue
+		}
+		if cumulated+float64(count) >= rank {
+			lower, upper := bucketBounds(bucket)
+			milliseconds := lower + (upper-lower)*math.Max(rank-cumulated, 0)/float64(count)
+			return time.Duration(math.Round(milliseconds)) * time.Millisecond
+		}
+		cumulated += float64(count)
+	}
+	lower, _ := bucketBounds(len(h.Counts) - 1)
+	return time.Duration(lower) * time.Millisecond
+}
+
+// FractionUnder returns an estimate of the fraction of checks whose response time was lower than or equal to target
+//
+// The estimate is exact if target is one of the upper bounds of ResponseTimeHistogramBuckets. Otherwise, the response
+// times are assumed to be evenly distributed within the bucket target falls in.
+func (h *ResponseTimeHistogram) FractionUnder(target time.Duration) float64 {
+	total := h.Total()
+	if total == 0 {
+		return 0
+	}
+	targetMilliseconds := float64(target.Milliseconds())
+	var under float64
+	for bucket, count := range h.Counts {
+		lower, upper := bucketBounds(bucket)
+		if upper <= targetMilliseconds && bucket < len(ResponseTimeHistogramBuckets) {
+			under += float64(count)
+		} else if lower < targetMilliseconds && bucket < len(ResponseTimeHistogramBuckets) {
+			under += float64(count) * (targetMilliseconds - lower) / (upper - lower)
+		}
+	}
+	return under / float64(total)
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResponseTimeHistogramBucket(t *testing.T) {
+	scenarios := []struct {
+		responseTime   time.Duration
+		expectedBucket int
+	}{
+		{responseTime: 0, expectedBucket: 0},
+		{responseTime: 5 * time.Millisecond, expectedBucket: 0},
+		{responseTime: 6 * time.Millisecond, expectedBucket: 1},
+		{responseTime: 100 * time.Millisecond, expectedBucket: 5},
+		{responseTime: 60 * time.Second, expectedBucket: len(ResponseTimeHistogramBuckets) - 1},
+		{responseTime: time.Hour, expectedBucket: len(ResponseTimeHistogramBuckets)},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.responseTime.String(), func(t *testing.T) {
+			if bucket := ResponseTimeHistogramBucket(scenario.responseTime); bucket != scenario.expectedBucket {
+				t.Errorf("expected bucket %d, got %d", scenario.expectedBucket, bucket)
+			}
+		})
+	}
+}
+
+func TestResponseTimeHistogram(t *testing.T) {
+	histogram := NewResponseTimeHistogram()
+	if histogram.Percentile(99) != 0 || histogram.FractionUnder(time.Second) != 0 {
+		t.Error("expected an empty histogram to have a percentile and a fraction under target of 0")
+	}
+	// 90 checks between 75ms and 100ms, 10 checks between 400ms and 500ms
+	histogram.Add(ResponseTimeHistogramBucket(90*time.Millisecond), 90)
+	other := NewResponseTimeHistogram()
+	other.Add(ResponseTimeHistogramBucket(450*time.Millisecond), 10)
+	other.Add(-1, 1000)
+	other.Add(len(other.Counts), 1000)
+	histogram.Merge(other.Counts)
+	if total := histogram.Total(); total != 100 {
+		t.Errorf("expected 100 checks, got %d", total)
+	}
+	if p50 := histogram.Percentile(50); p50 != 89*time.Millisecond {
+		t.Errorf("expected p50 to be 89ms, got %s", p50)
+	}
+	if p90 := histogram.Percentile(90); p90 != 100*time.Millisecond {
+		t.Errorf("expected p90 to be 100ms, got %s", p90)
+	}
+	if p95 := histogram.Percentile(95); p95 != 450*time.Millisecond {
+		t.Errorf("expected p95 to be 450ms, got %s", p95)
+	}
+	if fraction := histogram.FractionUnder(100 * time.Millisecond); fraction != 0.9 {
+		t.Errorf("expected 0.9 of the checks to be under 100ms, got %f", fraction)
+	}
+	if fraction := histogram.FractionUnder(450 * time.Millisecond); fraction != 0.95 {
+		t.Errorf("expected 0.95 of the checks to be under 450ms, got %f", fraction)
+	}
+	// Response times above the last bucket are never under the target, since their upper bound is unknown
+	histogram.Add(len(ResponseTimeHistogramBuckets), 100)
+	if fraction := histogram.FractionUnder(time.Hour); fraction != 0.5 {
+		t.Errorf("expected 0.5 of the checks to be under 1h, got %f", fraction)
+	}
+	if p99 := histogram.Percentile(99); p99 != 60*time.Second {
+		t.Errorf("expected p99 to be 60s, got %s", p99)
+	}
+}
@@ -1,6 +1,15 @@
 package core
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"net/http"
 	"time"
 )
 
@@ -14,12 +23,28 @@ type Result struct {
 	// Possible values: NOERROR, FORMERR, SERVFAIL, NXDOMAIN, NOTIMP, REFUSED
 	DNSRCode string `json:"-"`
 
+	// DNSAnswers are the values of every record in the answer section of a DNS query, in the order they were received
+	//
+	// Note that this field is not persisted by the sql storage.
+	DNSAnswers []string `json:"-"`
+
 	// Hostname extracted from Endpoint.URL
 	Hostname string `json:"hostname,omitempty"`
 
 	// IP resolved from the Endpoint URL
 	IP string `json:"-"`
 
+	// ConnectedIP is the IP of the connection the request was sent through, which may differ from IP if the hostname
+	// resolves to multiple IPs
+	//
+	// Only set for HTTP endpoints with a condition that needs it. See Result.connectedOrResolvedIP.
+	ConnectedIP string `json:"-"`
+
+	// ReverseDNS is the name that the IP of the connection resolves to through a reverse DNS (PTR) lookup
+	//
+	// Only set if a condition uses ReverseDNSPlaceholder.
+	ReverseDNS string `json:"-"`
+
 	// Connected whether a connection to the host was established successfully
 	Connected bool `json:"-"`
 
@@ -35,20 +60,152 @@ type Result struct {
 	// Success whether the result signifies a success or not
 	Success bool `json:"success"`
 
+	// ExpectedFailure is whether the result is a failure caused by one of the expected failure statuses of the
+	// endpoint, in which case it doesn't trigger alerts
+	ExpectedFailure bool `json:"expectedFailure,omitempty"`
+
+	// Unknown is whether the result is a failure caused by a network error rather than by the endpoint itself, in
+	// which case it only triggers alerts if the endpoint is configured to. See Endpoint.UnknownOnNetworkError.
+	Unknown bool `json:"unknown,omitempty"`
+
+	// Agent is the name of the agent that performed the check, or an empty string if the check was performed by Gatus
+	// itself. See Endpoint.Agents.
+	Agent string `json:"agent,omitempty"`
+
 	// Timestamp when the request was sent
 	Timestamp time.Time `json:"timestamp"`
 
 	// CertificateExpiration is the duration before the certificate expires
 	CertificateExpiration time.Duration `json:"-"`
 
+	// CertificateChainExpiration is the duration before the first of the certificates presented by the server, which
+	// include the intermediates in addition to its own certificate, expires
+	CertificateChainExpiration time.Duration `json:"-"`
+
+	// CertificateFingerprint is the hex-encoded SHA-256 of the certificate
+	CertificateFingerprint string `json:"-"`
+
+	// CertificatePublicKeyFingerprint is the hex-encoded SHA-256 of the public key (SubjectPublicKeyInfo) of the
+	// certificate, which, unlike CertificateFingerprint, doesn't change if the certificate is renewed with the same key
+	CertificatePublicKeyFingerprint string `json:"-"`
+
+	// CertificateKeySize is the size of the public key of the certificate, in bits (e.g. 2048 for RSA, 256 for ECDSA
+	// P-256)
+	CertificateKeySize int `json:"-"`
+
+	// CertificateSignatureAlgorithm is the algorithm with which the certificate is signed (e.g. SHA256-RSA)
+	CertificateSignatureAlgorithm string `json:"-"`
+
+	// TLSVersion is the version of TLS negotiated with the server (e.g. TLS 1.3)
+	TLSVersion string `json:"-"`
+
+	// TLSCipher is the name of the cipher suite negotiated with the server (e.g. TLS_AES_128_GCM_SHA256)
+	TLSCipher string `json:"-"`
+
+	// SSHVersion is the version of the SSH protocol advertised by the server in its identification string (e.g. 2.0)
+	SSHVersion string `json:"-"`
+
 	// DomainExpiration is the duration before the domain expires
 	DomainExpiration time.Duration `json:"-"`
 
+	// IPResults are the results for each IP the hostname resolves to
+	//
+	// Only set if the endpoint is configured to check all IPs (see AllIPs).
+	IPResults []*IPResult `json:"ipResults,omitempty"`
+
 	// Body is the response body
 	//
-	// Note that this field is not persisted in the storage.
+	// Note that this field is not persisted by the sql storage.
 	// It is used for health evaluation as well as debugging purposes.
 	Body []byte `json:"-"`
+
+	// BodySHA256 is the hex-encoded SHA-256 of the response body
+	//
+	// Only set if computed while reading the body, which allows the body to be hashed without being kept in memory.
+	// See GetBodySHA256.
+	BodySHA256 string `json:"-"`
+
+	// BodyDecoded is whether the response body was read and decoded according to its Content-Encoding without error
+	//
+	// Only set if the body was read.
+	BodyDecoded bool `json:"-"`
+
+	// Headers are the headers of the response. HTTP only.
+	Headers http.Header `json:"-"`
+
+	// ContentEncoding is the Content-Encoding of the response, which is gzip if it was transparently decompressed
+	ContentEncoding string `json:"-"`
+
+	// ExitCode is the exit code of the command of an endpoint of type EXEC, or -1 if it couldn't be executed
+	ExitCode int `json:"-"`
+
+	// JWTValid is whether the JSON Web Token of the response has a valid signature and is within its validity period
+	//
+	// Only set if the endpoint has a JWT configuration.
+	JWTValid bool `json:"-"`
+
+	// JWTClaims are the JSON-encoded claims of the JSON Web Token of the response
+	//
+	// Only set if the endpoint has a JWT configuration and the signature of the token is valid.
+	JWTClaims []byte `json:"-"`
+
+	// Changed is whether the resource has changed since the previous check of the endpoint
+	//
+	// Only set if a condition uses ChangedPlaceholder. See Endpoint.needsConditionalRequest.
+	Changed bool `json:"-"`
+
+	// ResponseTimeAnomaly is the detail of the comparison of the response time with the baseline of the endpoint
+	//
+	// Only set if the endpoint has a condition comparing the ResponseTimePlaceholder with AnomalousValue, and if
+	// enough results were available to compute the baseline.
+	// Note that this field is not persisted by the sql storage.
+	ResponseTimeAnomaly *ResponseTimeAnomaly `json:"responseTimeAnomaly,omitempty"`
+
+	// ResponseTimeTrend is the detail of the trend of the previous response times of the endpoint and of the response
+	// time of the result
+	//
+	// Only set if the endpoint has a condition comparing the ResponseTimePlaceholder with IncreasingValue, and if
+	// enough results were available to compute the trend.
+	// Note that this field is not persisted by the sql storage.
+	ResponseTimeTrend *ResponseTimeTrend `json:"responseTimeTrend,omitempty"`
+
+	// networkError is whether the result failed because of a network error. See isNetworkError.
+	networkError bool
+
+	// responseTimeBaseline is the baseline that the response time is compared with
+	responseTimeBaseline *ResponseTimeBaseline
+
+	// previousResponseTimes are the response times of the previous results of the endpoint, from the oldest to the
+	// most recent one, and responseTimeTrendConfig is the configuration the trend of the response time is computed with
+	previousResponseTimes   []time.Duration
+	responseTimeTrendConfig *ResponseTimeTrendConfig
+}
+
+// connectedOrResolvedIP returns the IP of the connection the request was sent through, or the IP resolved from the
+// Endpoint URL if the IP of the connection isn't known
+func (result *Result) connectedOrResolvedIP() string {
+	if len(result.ConnectedIP) > 0 {
+		return result.ConnectedIP
+	}
+	return result.IP
+}
+
+// IPResult is the result of the evaluation of an Endpoint for a single IP its hostname resolves to
+type IPResult struct {
+	// IP the request was sent to
+	IP string `json:"ip"`
+
+	// HTTPStatus is the HTTP response status code
+	HTTPStatus int `json:"status,omitempty"`
+
+	// Duration time that the request took
+	Duration time.Duration `json:"duration"`
+
+	// Errors encountered during the evaluation of the Endpoint's health for this IP
+	Errors []string `json:"errors,omitempty"`
+
+	// Success whether every condition was met for this IP
+	Success bool `json:"success"`
 }
 
 // AddError adds an error to the result's list of errors.
@@ -62,3 +219,103 @@ func (r *Result) AddError(error string) {
 	}
 	r.Errors = append(r.Errors, error)
 }
+
+// RestoreConditions restores the conditions that the condition results were evaluated from, which aren't part of the
+// JSON representation of the result (e.g. for a result reported by an agent), from the conditions of the endpoint
+//
+// Since conditions are evaluated in the order in which they're configured, the condition results are expected to be in
+// that same order. Returns false and leaves the result untouched if the number of condition results doesn't match.
+func (r *Result) RestoreConditions(conditions []Condition) bool {
+	if len(r.ConditionResults) != len(conditions) {
+		return false
+	}
+	for i, conditionResult := range r.ConditionResults {
+		conditionResult.condition = conditions[i]
+	}
+	return true
+}
+
+// HasFailedConditionInScope returns whether at least one of the conditions that failed is one of the conditions, or
+// contains one of the placeholders, in the scope passed
+func (r *Result) HasFailedConditionInScope(scope []string) bool {
+	for _, conditionResult := range r.ConditionResults {
+		if conditionResult.Success {
+			continue
+		}
+		for _, s := range scope {
+			if conditionResult.condition.matchesScope(s) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// setCertificates records the expiration, the fingerprints, the key size and the signature algorithm of the
+// certificate of the server, which is the first of the certificates it presented, as well as the expiration of the
+// chain formed by all of them
+func (r *Result) setCertificates(certificates []*x509.Certificate) {
+	certificate := certificates[0]
+	r.CertificateExpiration = time.Until(certificate.NotAfter)
+	r.CertificateChainExpiration = r.CertificateExpiration
+	for _, intermediate := range certificates[1:] {
+		if expiration := time.Until(intermediate.NotAfter); expiration < r.CertificateChainExpiration {
+			r.CertificateChainExpiration = expiration
+		}
+	}
+	fingerprint := sha256.Sum256(certificate.Raw)
+	r.CertificateFingerprint = hex.EncodeToString(fingerprint[:])
+	publicKeyFingerprint := sha256.Sum256(certificate.RawSubjectPublicKeyInfo)
+	r.CertificatePublicKeyFingerprint = hex.EncodeToString(publicKeyFingerprint[:])
+	r.CertificateKeySize = publicKeySize(certificate.PublicKey)
+	r.CertificateSignatureAlgorithm = certificate.SignatureAlgorithm.String()
+}
+
+// publicKeySize returns the size of a public key in bits, or 0 if the type of the key is not supported
+func publicKeySize(publicKey interface{}) int {
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		return key.N.BitLen()
+	case *ecdsa.PublicKey:
+		return key.Curve.Params().BitSize
+	case ed25519.PublicKey:
+		return 256
+	}
+	return 0
+}
+
+// setTLSConnectionState records the version and the cipher suite negotiated with the server, as well as the
+// certificates it presented, if any
+func (r *Result) setTLSConnectionState(state *tls.ConnectionState) {
+	r.TLSVersion = tlsVersionName(state.Version)
+	r.TLSCipher = tls.CipherSuiteName(state.CipherSuite)
+	if len(state.PeerCertificates) > 0 {
+		r.setCertificates(state.PeerCertificates)
+	}
+}
+
+// tlsVersionName returns the name of a version of TLS (e.g. TLS 1.3)
+func tlsVersionName(version uint16) string {
+	switch version {
+	case tls.VersionTLS10:
+		return "TLS 1.0"
+	case tls.VersionTLS11:
+		return "TLS 1.1"
+	case tls.VersionTLS12:
+		return "TLS 1.2"
+	case tls.VersionTLS13:
+		return "TLS 1.3"
+	}
+	return fmt.Sprintf("0x%04X", version)
+}
+
+// GetBodySHA256 returns the hex-encoded SHA-256 of the response body
+//
+// If it wasn't computed while reading the body, it is computed from Body.
+func (r *Result) GetBodySHA256() string {
+	if len(r.BodySHA256) > 0 {
+		return r.BodySHA256
+	}
+	sum := sha256.Sum256(r.Body)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,7 +1,23 @@
 package core
 
 import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
 )
 
 func TestResult_AddError(t *testing.T) {
@@ -19,3 +35,137 @@ func TestResult_AddError(t *testing.T) {
 		t.Error("should've had 2 error")
 	}
 }
+
+func TestResult_RestoreConditions(t *testing.T) {
+	conditions := []Condition{"[STATUS] == 200", "[BODY].status == UP"}
+	result := &Result{ConditionResults: []*ConditionResult{{Condition: "[STATUS] (500) == 200"}, {Condition: "[BODY].status == UP", Success: true}}}
+	if !result.RestoreConditions(conditions) {
+		t.Fatal("expected the conditions to have been restored")
+	}
+	if !result.HasFailedConditionInScope([]string{"[STATUS]"}) || result.HasFailedConditionInScope([]string{"[BODY]"}) {
+		t.Error("expected only the condition on [STATUS] to be failing")
+	}
+	if (&Result{ConditionResults: []*ConditionResult{{Condition: "[STATUS] == 200"}}}).RestoreConditions(conditions) {
+		t.Error("expected the conditions not to be restored when the number of condition results doesn't match")
+	}
+}
+
+func TestResult_setCertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:         "tls-test",
+		URL:          server.URL,
+		ClientConfig: &client.Config{Insecure: true},
+		Conditions:   []Condition{Condition("[CERTIFICATE_FINGERPRINT] == " + sha256Hex(server.Certificate().Raw))},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if !result.Success {
+		t.Errorf("expected the certificate fingerprint to match, got %s", result.ConditionResults[0].Condition)
+	}
+	if result.CertificatePublicKeyFingerprint != sha256Hex(server.Certificate().RawSubjectPublicKeyInfo) {
+		t.Errorf("expected public key fingerprint to be the SHA-256 of the SubjectPublicKeyInfo, got %s", result.CertificatePublicKeyFingerprint)
+	}
+	if result.CertificateExpiration <= time.Hour {
+		t.Error("expected the certificate expiration to be set, got", result.CertificateExpiration)
+	}
+}
+
+func TestResult_setCertificatesWithIntermediates(t *testing.T) {
+	now := time.Now()
+	result := &Result{}
+	result.setCertificates([]*x509.Certificate{
+		{NotAfter: now.Add(90 * 24 * time.Hour)},
+		{NotAfter: now.Add(7 * 24 * time.Hour)},
+		{NotAfter: now.Add(365 * 24 * time.Hour)},
+	})
+	if result.CertificateExpiration < 89*24*time.Hour {
+		t.Error("expected the certificate expiration to be the one of the leaf, got", result.CertificateExpiration)
+	}
+	if result.CertificateChainExpiration > 7*24*time.Hour || result.CertificateChainExpiration < 6*24*time.Hour {
+		t.Error("expected the certificate chain expiration to be the one of the intermediate expiring first, got", result.CertificateChainExpiration)
+	}
+	result = &Result{}
+	result.setCertificates([]*x509.Certificate{{NotAfter: now.Add(24 * time.Hour)}})
+	if result.CertificateChainExpiration != result.CertificateExpiration {
+		t.Errorf("expected the certificate chain expiration to be the one of the leaf without intermediates, got %s", result.CertificateChainExpiration)
+	}
+}
+
+func TestResult_setTLSConnectionState(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	endpoint := Endpoint{
+		Name:         "tls-test",
+		URL:          "tls://" + strings.TrimPrefix(server.URL, "https://"),
+		ClientConfig: &client.Config{Insecure: true},
+		Conditions: []Condition{
+			"[CONNECTED] == true",
+			"[TLS_VERSION] == TLS 1.3",
+			"[TLS_CIPHER] == any(TLS_AES_128_GCM_SHA256, TLS_AES_256_GCM_SHA384, TLS_CHACHA20_POLY1305_SHA256)",
+			Condition("[CERTIFICATE_FINGERPRINT] == " + sha256Hex(server.Certificate().Raw)),
+			Condition("[CERTIFICATE_KEY_SIZE] == " + strconv.Itoa(publicKeySize(server.Certificate().PublicKey))),
+			Condition("[CERTIFICATE_SIG_ALGORITHM] == " + server.Certificate().SignatureAlgorithm.String()),
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if !result.Success {
+		for _, conditionResult := range result.ConditionResults {
+			t.Log(conditionResult.Condition)
+		}
+		t.Error("expected every condition to succeed")
+	}
+	if result.Duration <= 0 {
+		t.Error("expected the duration of the handshake to be recorded, got", result.Duration)
+	}
+}
+
+func TestPublicKeySize(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	ecdsaKey, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	ed25519PublicKey, _, _ := ed25519.GenerateKey(rand.Reader)
+	scenarios := []struct {
+		name      string
+		publicKey interface{}
+		expected  int
+	}{
+		{name: "rsa", publicKey: &rsaKey.PublicKey, expected: 2048},
+		{name: "ecdsa", publicKey: &ecdsaKey.PublicKey, expected: 384},
+		{name: "ed25519", publicKey: ed25519PublicKey, expected: 256},
+		{name: "unsupported", publicKey: "not-a-key", expected: 0},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if size := publicKeySize(scenario.publicKey); size != scenario.expected {
+				t.Errorf("expected %d, got %d", scenario.expected, size)
+			}
+		})
+	}
+}
+
+func TestTLSVersionName(t *testing.T) {
+	if name := tlsVersionName(tls.VersionTLS12); name != "TLS 1.2" {
+		t.Errorf("expected TLS 1.2, got %s", name)
+	}
+	if name := tlsVersionName(0x0304); name != "TLS 1.3" {
+		t.Errorf("expected TLS 1.3, got %s", name)
+	}
+	if name := tlsVersionName(0x0300); name != "0x0300" {
+		t.Errorf("expected unknown versions to be formatted as hexadecimal, got %s", name)
+	}
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
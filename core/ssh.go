@@ -0,0 +1,102 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"golang.org/x/crypto/ssh"
+)
+
+// sshDefaultPort is the port used if the URL of an endpoint of type SSH doesn't have one
+const sshDefaultPort = "22"
+
+var (
+	// ErrSSHWithUnsupportedEndpointType is the error with which Gatus will panic if an endpoint that isn't of type SSH
+	// has an SSH configuration
+	ErrSSHWithUnsupportedEndpointType = errors.New("ssh is only supported for SSH endpoints")
+
+	// ErrSSHWithUsernameAndNoCredentials is the error with which Gatus will panic if an SSH configuration has a
+	// username but neither a password nor a private key
+	ErrSSHWithUsernameAndNoCredentials = errors.New("you must specify a password or a private key for ssh if a username is specified")
+
+	// ErrSSHWithCredentialsAndNoUsername is the error with which Gatus will panic if an SSH configuration has a
+	// password or a private key but no username
+	ErrSSHWithCredentialsAndNoUsername = errors.New("you must specify a username for ssh if a password or a private key is specified")
+
+	// ErrSSHWithInvalidPrivateKey is the error with which Gatus will panic if the private key of an SSH configuration
+	// cannot be parsed
+	ErrSSHWithInvalidPrivateKey = errors.New("invalid ssh private key")
+
+	// ErrSSHWithInvalidHostKeyFingerprint is the error with which Gatus will panic if the host key fingerprint of an
+	// SSH configuration isn't in the format used by ssh-keygen
+	ErrSSHWithInvalidHostKeyFingerprint = errors.New("ssh host-key-fingerprint must be in the format SHA256:<base64>, as printed by ssh-keygen -lf")
+)
+
+// SSH is the configuration for an Endpoint of type SSH
+type SSH struct {
+	// Username is the username to authenticate with. If empty, only the identification string of the server is read,
+	// without performing the handshake.
+	Username string `yaml:"username,omitempty"`
+
+	// Password is the password to authenticate with
+	Password string `yaml:"password,omitempty"`
+
+	// PrivateKey is the PEM-encoded private key to authenticate with
+	PrivateKey string `yaml:"private-key,omitempty"`
+
+	// HostKeyFingerprint is the SHA-256 fingerprint of the host key the server is expected to present during the
+	// handshake (e.g. SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s). If empty, any host key is accepted.
+	HostKeyFingerprint string `yaml:"host-key-fingerprint,omitempty"`
+}
+
+func (s *SSH) validateAndSetDefault() error {
+	if len(s.Username) > 0 && len(s.Password) == 0 && len(s.PrivateKey) == 0 {
+		return ErrSSHWithUsernameAndNoCredentials
+	}
+	if len(s.Username) == 0 && (len(s.Password) > 0 || len(s.PrivateKey) > 0) {
+		return ErrSSHWithCredentialsAndNoUsername
+	}
+	if len(s.PrivateKey) > 0 {
+		if _, err := ssh.ParsePrivateKey([]byte(s.PrivateKey)); err != nil {
+			return fmt.Errorf("%w: %s", ErrSSHWithInvalidPrivateKey, err.Error())
+		}
+	}
+	if len(s.HostKeyFingerprint) > 0 && !strings.HasPrefix(s.HostKeyFingerprint, "SHA256:") {
+		return ErrSSHWithInvalidHostKeyFingerprint
+	}
+	return nil
+}
+
+// checkSSH connects to the SSH server of the endpoint and records the outcome in the result
+func (endpoint *Endpoint) checkSSH(result *Result) {
+	parsedURL, err := url.Parse(endpoint.URL)
+	if err != nil {
+		result.AddError(err.Error())
+		return
+	}
+	address := parsedURL.Host
+	if len(parsedURL.Port()) == 0 {
+		address = net.JoinHostPort(parsedURL.Hostname(), sshDefaultPort)
+	}
+	request := &client.SSHRequest{Address: address}
+	if endpoint.SSH != nil {
+		request.Username = endpoint.SSH.Username
+		request.Password = endpoint.SSH.Password
+		request.PrivateKey = []byte(endpoint.SSH.PrivateKey)
+		request.HostKeyFingerprint = endpoint.SSH.HostKeyFingerprint
+	}
+	startTime := time.Now()
+	response, err := client.CheckSSH(request, endpoint.ClientConfig)
+	result.Duration = time.Since(startTime)
+	result.Connected = response.Connected
+	result.Body = response.Banner
+	result.SSHVersion = response.Version
+	if err != nil {
+		result.AddError(err.Error())
+	}
+}
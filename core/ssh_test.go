@@ -0,0 +1,50 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSSH_validateAndSetDefault(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		ssh         *SSH
+		expectedErr error
+	}{
+		{
+			name: "banner-only",
+			ssh:  &SSH{},
+		},
+		{
+			name: "password",
+			ssh:  &SSH{Username: "john.doe", Password: "hunter2", HostKeyFingerprint: "SHA256:uNiVztksCsDhcc0u9e8BujQXVUpKZIDTMczCvj3tD2s"},
+		},
+		{
+			name:        "invalid-private-key",
+			ssh:         &SSH{Username: "john.doe", PrivateKey: "not a private key"},
+			expectedErr: ErrSSHWithInvalidPrivateKey,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.ssh.validateAndSetDefault(); !errors.Is(err, scenario.expectedErr) {
+				t.Errorf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithSSH(t *testing.T) {
+	// Nothing listens on port 1, so the connection must be refused
+	endpoint := Endpoint{Name: "ssh", URL: "ssh://127.0.0.1:1", Conditions: []Condition{"[CONNECTED] == true"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	result := endpoint.EvaluateHealth()
+	if result.Success || result.Connected {
+		t.Error("expected the result to be unsuccessful, since the connection must have been refused")
+	}
+	if len(result.Errors) != 1 {
+		t.Errorf("expected 1 error, got %v", result.Errors)
+	}
+}
@@ -0,0 +1,130 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultResponseTimeTrendChecks is the default number of checks, including the current one, over which the trend
+	// of the response time of an endpoint is computed
+	DefaultResponseTimeTrendChecks = 5
+
+	// MinimumResponseTimeTrendChecks is the lowest number of checks over which the trend of the response time of an
+	// endpoint can be computed
+	MinimumResponseTimeTrendChecks = 3
+)
+
+var (
+	// ErrInvalidResponseTimeTrendChecks is the error with which Gatus will panic if the number of checks of a response
+	// time trend is too low for the trend to be meaningful
+	ErrInvalidResponseTimeTrendChecks = fmt.Errorf("endpoint response-time-trend.checks must be at least %d", MinimumResponseTimeTrendChecks)
+
+	// ErrInvalidResponseTimeTrendMinimumSlope is the error with which Gatus will panic if the minimum slope of a
+	// response time trend is negative
+	ErrInvalidResponseTimeTrendMinimumSlope = errors.New("endpoint response-time-trend.minimum-slope must not be negative")
+)
+
+// ResponseTimeTrendConfig is the configuration of the detection of an increasing response time, which is used by the
+// conditions comparing the ResponseTimePlaceholder with IncreasingValue
+type ResponseTimeTrendConfig struct {
+	// Checks is the number of checks, including the current one, over which the trend is computed.
+	// Defaults to DefaultResponseTimeTrendChecks.
+	Checks int `yaml:"checks,omitempty"`
+
+	// MinimumSlope is the minimum average increase of the response time per check for the trend to be considered
+	// increasing, which prevents response times increasing by a few milliseconds at every check from being flagged.
+	MinimumSlope time.Duration `yaml:"minimum-slope,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the configuration and sets the default values if necessary
+func (config *ResponseTimeTrendConfig) ValidateAndSetDefaults() error {
+	if config.Checks == 0 {
+		config.Checks = DefaultResponseTimeTrendChecks
+	} else if config.Checks < MinimumResponseTimeTrendChecks {
+		return ErrInvalidResponseTimeTrendChecks
+	}
+	if config.MinimumSlope < 0 {
+		return ErrInvalidResponseTimeTrendMinimumSlope
+	}
+	return nil
+}
+
+// ResponseTimeTrend is the detail of the trend of the recent response times of an endpoint
+type ResponseTimeTrend struct {
+	// ResponseTimes are the response times the trend was computed from, from the oldest to the most recent one
+	ResponseTimes []time.Duration `json:"responseTimes"`
+
+	// Slope is the average increase of the response time per check, based on a linear regression of ResponseTimes
+	Slope time.Duration `json:"slope"`
+
+	// Increasing is whether the response time increased at every check, with a Slope of at least the minimum slope
+	// of the configuration
+	Increasing bool `json:"increasing"`
+}
+
+// NewResponseTimeTrend computes the trend of the response times passed, which must be sorted from the oldest to the
+// most recent one
+//
+// Only the last config.Checks response times are used, and nil is returned if there are fewer than that.
+func NewResponseTimeTrend(responseTimes []time.Duration, config *ResponseTimeTrendConfig) *ResponseTimeTrend {
+	if len(responseTimes) < config.Checks {
+		return nil
+	}
+	responseTimes = responseTimes[len(responseTimes)-config.Checks:]
+	isMonotonicallyIncreasing := true
+	for i := 1; i < len(responseTimes); i++ {
+		if responseTimes[i] <= responseTimes[i-1] {
+			isMonotonicallyIncreasing = false
+			break
+		}
+	}
+	// Least squares slope, with the index of each check as x and its response time as y
+	meanX := float64(len(responseTimes)-1) / 2
+	var meanY float64
+	for _, responseTime := range responseTimes {
+		meanY += float64(responseTime)
+	}
+	meanY /= float64(len(responseTimes))
+	var covariance, variance float64
+	for i, responseTime := range responseTimes {
+		covariance += (float64(i) - meanX) * (float64(responseTime) - meanY)
+		variance += (float64(i) - meanX) * (float64(i) - meanX)
+	}
+	slope := time.Duration(covariance / variance)
+	return &ResponseTimeTrend{
+		ResponseTimes: responseTimes,
+		Slope:         slope,
+		Increasing:    isMonotonicallyIncreasing && slope >= config.MinimumSlope,
+	}
+}
+
+// isTrendCheck returns whether the elements of a condition are the ResponseTimePlaceholder compared with
+// IncreasingValue
+func isTrendCheck(elements []string) bool {
+	return len(elements) == 2 && strings.TrimSpace(elements[0]) == ResponseTimePlaceholder && strings.TrimSpace(elements[1]) == IncreasingValue
+}
+
+// resolveResponseTimeTrend computes the trend of the previous response times of the endpoint and of the response time
+// of the result
+//
+// Returns nil if there aren't enough previous response times yet.
+func resolveResponseTimeTrend(result *Result) *ResponseTimeTrend {
+	if result.responseTimeTrendConfig == nil {
+		return nil
+	}
+	responseTimes := append(append([]time.Duration{}, result.previousResponseTimes...), result.Duration)
+	result.ResponseTimeTrend = NewResponseTimeTrend(responseTimes, result.responseTimeTrendConfig)
+	return result.ResponseTimeTrend
+}
+
+// prettifyTrendCheck returns a trend check with the response time, the slope of the trend and the number of checks it
+// was computed over, all in milliseconds
+func prettifyTrendCheck(result *Result, trend *ResponseTimeTrend, operator string) string {
+	if trend == nil {
+		return fmt.Sprintf("%s (%d; not enough results) %s %s", ResponseTimePlaceholder, result.Duration.Milliseconds(), operator, IncreasingValue)
+	}
+	return fmt.Sprintf("%s (%d; slope=%+d/check over %d checks) %s %s", ResponseTimePlaceholder, result.Duration.Milliseconds(), trend.Slope.Milliseconds(), len(trend.ResponseTimes), operator, IncreasingValue)
+}
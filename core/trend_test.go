@@ -0,0 +1,128 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestResponseTimeTrendConfig_ValidateAndSetDefaults(t *testing.T) {
+	config := &ResponseTimeTrendConfig{}
+	if err := config.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if config.Checks != DefaultResponseTimeTrendChecks {
+		t.Errorf("expected checks to default to %d, got %d", DefaultResponseTimeTrendChecks, config.Checks)
+	}
+	if err := (&ResponseTimeTrendConfig{Checks: 2}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidResponseTimeTrendChecks) {
+		t.Errorf("expected error %v, got %v", ErrInvalidResponseTimeTrendChecks, err)
+	}
+	if err := (&ResponseTimeTrendConfig{MinimumSlope: -time.Millisecond}).ValidateAndSetDefaults(); !errors.Is(err, ErrInvalidResponseTimeTrendMinimumSlope) {
+		t.Errorf("expected error %v, got %v", ErrInvalidResponseTimeTrendMinimumSlope, err)
+	}
+}
+
+func TestNewResponseTimeTrend(t *testing.T) {
+	scenarios := []struct {
+		name               string
+		responseTimes      []time.Duration
+		config             *ResponseTimeTrendConfig
+		expectedIncreasing bool
+		expectedSlope      time.Duration
+	}{
+		{
+			name:               "increasing",
+			responseTimes:      []time.Duration{100 * time.Millisecond, 150 * time.Millisecond, 200 * time.Millisecond, 250 * time.Millisecond},
+			config:             &ResponseTimeTrendConfig{Checks: 4},
+			expectedIncreasing: true,
+			expectedSlope:      50 * time.Millisecond,
+		},
+		{
+			name:               "increasing-only-over-the-last-checks",
+			responseTimes:      []time.Duration{900 * time.Millisecond, 100 * time.Millisecond, 150 * time.Millisecond, 200 * time.Millisecond},
+			config:             &ResponseTimeTrendConfig{Checks: 3},
+			expectedIncreasing: true,
+			expectedSlope:      50 * time.Millisecond,
+		},
+		{
+			name:               "not-monotonic",
+			responseTimes:      []time.Duration{100 * time.Millisecond, 300 * time.Millisecond, 200 * time.Millisecond, 400 * time.Millisecond},
+			config:             &ResponseTimeTrendConfig{Checks: 4},
+			expectedIncreasing: false,
+			expectedSlope:      80 * time.Millisecond,
+		},
+		{
+			name:               "slope-below-minimum",
+			responseTimes:      []time.Duration{100 * time.Millisecond, 101 * time.Millisecond, 102 * time.Millisecond},
+			config:             &ResponseTimeTrendConfig{Checks: 3, MinimumSlope: 10 * time.Millisecond},
+			expectedIncreasing: false,
+			expectedSlope:      time.Millisecond,
+		},
+		{
+			name:               "decreasing",
+			responseTimes:      []time.Duration{300 * time.Millisecond, 200 * time.Millisecond, 100 * time.Millisecond},
+			config:             &ResponseTimeTrendConfig{Checks: 3},
+			expectedIncreasing: false,
+			expectedSlope:      -100 * time.Millisecond,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			trend := NewResponseTimeTrend(scenario.responseTimes, scenario.config)
+			if trend == nil {
+				t.Fatal("expected trend, got nil")
+			}
+			if trend.Increasing != scenario.expectedIncreasing {
+				t.Errorf("expected increasing to be %v, got %v", scenario.expectedIncreasing, trend.Increasing)
+			}
+			if trend.Slope != scenario.expectedSlope {
+				t.Errorf("expected slope to be %s, got %s", scenario.expectedSlope, trend.Slope)
+			}
+			if len(trend.ResponseTimes) != scenario.config.Checks {
+				t.Errorf("expected the trend to be computed over %d response times, got %d", scenario.config.Checks, len(trend.ResponseTimes))
+			}
+		})
+	}
+	if trend := NewResponseTimeTrend([]time.Duration{time.Second, 2 * time.Second}, &ResponseTimeTrendConfig{Checks: 3}); trend != nil {
+		t.Error("expected no trend with fewer response times than checks, got", trend)
+	}
+}
+
+func TestEndpoint_EvaluateHealthWithResponseTimeTrend(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewBufferString("OK"))}
+	})})
+	endpoint := Endpoint{
+		Name:       "trend",
+		URL:        "https://twin.sh/health",
+		Conditions: []Condition{"[RESPONSE_TIME] != increasing"},
+	}
+	if !endpoint.NeedsPreviousResponseTimes() {
+		t.Fatal("endpoint should've needed the previous response times")
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if endpoint.ResponseTimeTrend == nil || endpoint.ResponseTimeTrend.Checks != DefaultResponseTimeTrendChecks {
+		t.Fatal("expected the response time trend configuration to be set to its default")
+	}
+	// Negative response times guarantee that the response time of the check will be higher than the previous ones
+	endpoint.SetPreviousResponseTimes([]time.Duration{-4 * time.Hour, -3 * time.Hour, -2 * time.Hour, -time.Hour})
+	result := endpoint.EvaluateHealth()
+	if result.Success {
+		t.Error("result should've been a failure")
+	}
+	if result.ResponseTimeTrend == nil {
+		t.Fatal("result should've had the response time trend detail")
+	}
+	if !result.ResponseTimeTrend.Increasing {
+		t.Error("response time should've been increasing")
+	}
+}
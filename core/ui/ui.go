@@ -1,6 +1,9 @@
 package ui
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Config is the UI configuration for core.Endpoint
 type Config struct {
@@ -15,6 +18,10 @@ type Config struct {
 
 	// Badge is the configuration for the badges generated
 	Badge *Badge `yaml:"badge"`
+
+	// ResponseTimeTarget is the response time under which checks are considered to meet the latency objective of the
+	// endpoint, as reported by the response time report
+	ResponseTimeTarget time.Duration `yaml:"response-time-target,omitempty"`
 }
 
 type Badge struct {
@@ -27,9 +34,13 @@ type ResponseTime struct {
 
 var (
 	ErrInvalidBadgeResponseTimeConfig = errors.New("invalid response time badge configuration: expected parameter 'response-time' to have 5 ascending numerical values")
+	ErrInvalidResponseTimeTarget      = errors.New("invalid response time target: must not be negative")
 )
 
 func (config *Config) ValidateAndSetDefaults() error {
+	if config.ResponseTimeTarget < 0 {
+		return ErrInvalidResponseTimeTarget
+	}
 	if config.Badge != nil {
 		if len(config.Badge.ResponseTime.Thresholds) != 5 {
 			return ErrInvalidBadgeResponseTimeConfig
@@ -7,6 +7,15 @@ type Uptime struct {
 	//
 	// Used only if the storage type is memory
 	HourlyStatistics map[int64]*HourlyUptimeStatistics `json:"-"`
+
+	// DailyResponseTimeHistograms is a map containing the number of executions in each bucket of
+	// ResponseTimeHistogramBuckets (value) for every daily unix timestamps (key)
+	//
+	// Since they're much smaller than HourlyStatistics, they're retained for longer, which allows the percentiles of
+	// the response time to be computed over windows that exceed the retention of the hourly statistics.
+	//
+	// Used only if the storage type is memory
+	DailyResponseTimeHistograms map[int64][]uint64 `json:"-"`
 }
 
 // HourlyUptimeStatistics is a struct containing all metrics collected over the course of an hour
@@ -14,11 +23,15 @@ type HourlyUptimeStatistics struct {
 	TotalExecutions             uint64 // Total number of checks
 	SuccessfulExecutions        uint64 // Number of successful executions
 	TotalExecutionsResponseTime uint64 // Total response time for all executions in milliseconds
+
+	// ResponseTimeHistogram is the number of executions in each bucket of ResponseTimeHistogramBuckets
+	ResponseTimeHistogram []uint64
 }
 
 // NewUptime creates a new Uptime
 func NewUptime() *Uptime {
 	return &Uptime{
-		HourlyStatistics: make(map[int64]*HourlyUptimeStatistics),
+		HourlyStatistics:            make(map[int64]*HourlyUptimeStatistics),
+		DailyResponseTimeHistograms: make(map[int64][]uint64),
 	}
 }
@@ -20,8 +20,68 @@ func Eval(path string, b []byte) (string, int, error) {
 	return walk(path, object)
 }
 
+// EvalArray returns the elements of the array at the given path
+//
+// Strings are returned as-is, objects and arrays are returned as minified JSON, and every other value is formatted
+// the same way as Eval formats it.
+func EvalArray(path string, b []byte) ([]string, error) {
+	var object interface{}
+	if err := json.Unmarshal(b, &object); err != nil {
+		return nil, err
+	}
+	value := object
+	if len(path) != 0 {
+		var err error
+		if value, err = resolve(path, object); err != nil {
+			return nil, err
+		}
+	}
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("value at path '%s' is not an array", path)
+	}
+	elements := make([]string, 0, len(array))
+	for _, element := range array {
+		switch element := element.(type) {
+		case string:
+			elements = append(elements, element)
+		case map[string]interface{}, []interface{}, nil:
+			b, err := json.Marshal(element)
+			if err != nil {
+				return nil, err
+			}
+			elements = append(elements, string(b))
+		default:
+			elements = append(elements, fmt.Sprintf("%v", element))
+		}
+	}
+	return elements, nil
+}
+
 // walk traverses the object and returns the value as a string as well as its length
 func walk(path string, object interface{}) (string, int, error) {
+	value, err := resolve(path, object)
+	if err != nil {
+		return "", 0, err
+	}
+	switch value := value.(type) {
+	case map[string]interface{}:
+		// Since it's a map, we'll treat it as a string by re-marshaling it to JSON.
+		// Note that the output JSON will be minified.
+		b, err := json.Marshal(value)
+		return string(b), len(b), err
+	case string:
+		return value, len(value), nil
+	case []interface{}:
+		return fmt.Sprintf("%v", value), len(value), nil
+	default:
+		newValue := fmt.Sprintf("%v", value)
+		return newValue, len(newValue), nil
+	}
+}
+
+// resolve traverses the object and returns the value at the end of the path
+func resolve(path string, object interface{}) (interface{}, error) {
 	var keys []string
 	startOfCurrentKey, bracketDepth := 0, 0
 	for i := range path {
@@ -45,24 +105,20 @@ func walk(path string, object interface{}) (string, int, error) {
 		newPath := strings.Replace(path, fmt.Sprintf("%s.", currentKey), "", 1)
 		if path == newPath {
 			// If the path hasn't changed, it means we're at the end of the path
-			// So we'll treat it as a string by re-marshaling it to JSON since it's a map.
-			// Note that the output JSON will be minified.
-			b, err := json.Marshal(value)
-			return string(b), len(b), err
+			return value, nil
 		}
-		return walk(newPath, value)
+		return resolve(newPath, value)
 	case string:
 		if len(keys) > 1 {
-			return "", 0, fmt.Errorf("couldn't walk through '%s', because '%s' was a string instead of an object", keys[1], currentKey)
+			return nil, fmt.Errorf("couldn't walk through '%s', because '%s' was a string instead of an object", keys[1], currentKey)
 		}
-		return value, len(value), nil
+		return value, nil
 	case []interface{}:
-		return fmt.Sprintf("%v", value), len(value), nil
+		return value, nil
 	case interface{}:
-		newValue := fmt.Sprintf("%v", value)
-		return newValue, len(newValue), nil
+		return value, nil
 	default:
-		return "", 0, fmt.Errorf("couldn't walk through '%s' because type was '%T', but expected 'map[string]interface{}'", currentKey, value)
+		return nil, fmt.Errorf("couldn't walk through '%s' because type was '%T', but expected 'map[string]interface{}'", currentKey, value)
 	}
 }
 
@@ -194,3 +194,79 @@ func TestEval(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalArray(t *testing.T) {
+	type Scenario struct {
+		Name           string
+		Path           string
+		Data           string
+		ExpectedOutput []string
+		ExpectedError  bool
+	}
+	scenarios := []Scenario{
+		{
+			Name:           "strings",
+			Path:           "roles",
+			Data:           `{"roles": ["user", "admin"]}`,
+			ExpectedOutput: []string{"user", "admin"},
+		},
+		{
+			Name:           "mixed-types",
+			Path:           "data.items",
+			Data:           `{"data": {"items": [5, 1.5, true, null, {"id": 1}, [1, 2]]}}`,
+			ExpectedOutput: []string{"5", "1.5", "true", "null", `{"id":1}`, "[1,2]"},
+		},
+		{
+			Name:           "root-array",
+			Path:           "",
+			Data:           `["a", "b"]`,
+			ExpectedOutput: []string{"a", "b"},
+		},
+		{
+			Name:           "nested-array-with-index",
+			Path:           "data[1]",
+			Data:           `{"data": [["a"], ["b", "c"]]}`,
+			ExpectedOutput: []string{"b", "c"},
+		},
+		{
+			Name:           "empty-array",
+			Path:           "roles",
+			Data:           `{"roles": []}`,
+			ExpectedOutput: []string{},
+		},
+		{
+			Name:          "not-an-array",
+			Path:          "roles",
+			Data:          `{"roles": "admin"}`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-path",
+			Path:          "roles",
+			Data:          `{}`,
+			ExpectedError: true,
+		},
+		{
+			Name:          "invalid-data",
+			Path:          "roles",
+			Data:          "invalid data",
+			ExpectedError: true,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			output, err := EvalArray(scenario.Path, []byte(scenario.Data))
+			if (err != nil) != scenario.ExpectedError {
+				t.Fatalf("Expected error to be %v, got '%v'", scenario.ExpectedError, err)
+			}
+			if len(output) != len(scenario.ExpectedOutput) {
+				t.Fatalf("Expected output to be %v, but was %v", scenario.ExpectedOutput, output)
+			}
+			for i := range output {
+				if output[i] != scenario.ExpectedOutput[i] {
+					t.Errorf("Expected output to be %v, but was %v", scenario.ExpectedOutput, output)
+				}
+			}
+		})
+	}
+}
@@ -9,11 +9,19 @@ import (
 
 	"github.com/TwiN/gatus/v5/config"
 	"github.com/TwiN/gatus/v5/controller"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/resultwebhook"
 	"github.com/TwiN/gatus/v5/storage/store"
 	"github.com/TwiN/gatus/v5/watchdog"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatalln("Failed to run command:", err.Error())
+		}
+		return
+	}
 	cfg, err := loadConfiguration()
 	if err != nil {
 		panic(err)
@@ -26,7 +34,12 @@ func main() {
 	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
 	go func() {
 		<-signalChannel
-		log.Println("Received termination signal, attempting to gracefully shut down")
+		log.Printf("Received termination signal, attempting to gracefully shut down (waiting up to %s for checks in progress)", cfg.ShutdownTimeout)
+		go func() {
+			<-signalChannel
+			log.Println("Received second termination signal, shutting down immediately")
+			os.Exit(1)
+		}()
 		stop(cfg)
 		save()
 		done <- true
@@ -37,12 +50,19 @@ func main() {
 
 func start(cfg *config.Config) {
 	go controller.Handle(cfg)
+	metrics.SetLabels(cfg.MetricsLabels)
+	metrics.StartRemoteWrite(cfg.MetricsRemoteWrite)
+	metrics.StartPushgateway(cfg.MetricsPushgateway)
+	resultwebhook.Start(cfg.ResultWebhook)
 	watchdog.Monitor(cfg)
 	go listenToConfigurationFileChanges(cfg)
 }
 
 func stop(cfg *config.Config) {
 	watchdog.Shutdown(cfg)
+	metrics.StopRemoteWrite()
+	metrics.StopPushgateway()
+	resultwebhook.Stop()
 	controller.Shutdown()
 }
 
@@ -87,8 +107,16 @@ func initializeStorage(cfg *config.Config) {
 func listenToConfigurationFileChanges(cfg *config.Config) {
 	for {
 		time.Sleep(30 * time.Second)
-		if cfg.HasLoadedConfigurationBeenModified() {
-			log.Println("[main][listenToConfigurationFileChanges] Configuration file has been modified")
+		configurationModified := cfg.HasLoadedConfigurationBeenModified()
+		configSourcesModified := !configurationModified && cfg.HaveConfigSourcesBeenModified()
+		if configurationModified || configSourcesModified || cfg.HaveSecretsExpired() {
+			if configurationModified {
+				log.Println("[main][listenToConfigurationFileChanges] Configuration file has been modified")
+			} else if configSourcesModified {
+				log.Println("[main][listenToConfigurationFileChanges] Config source has been modified")
+			} else {
+				log.Println("[main][listenToConfigurationFileChanges] Secrets read from Vault are about to expire, reloading configuration")
+			}
 			stop(cfg)
 			time.Sleep(time.Second) // Wait a bit to make sure everything is done.
 			save()
@@ -1,8 +1,15 @@
 package metrics
 
 import (
+	"errors"
+	"fmt"
+	"log"
 	"strconv"
+	"time"
 
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -10,14 +17,42 @@ import (
 
 const namespace = "gatus" // The prefix of the metrics
 
+const (
+	LabelKey   = "key"
+	LabelGroup = "group"
+	LabelName  = "name"
+	LabelType  = "type"
+)
+
+var (
+	// DefaultLabels are the labels identifying the endpoint of a metric if none are configured
+	DefaultLabels = []string{LabelKey, LabelGroup, LabelName, LabelType}
+
+	ErrMetricsLabelsWithUnknownLabel          = errors.New("unknown metrics label, supported labels are key, group, name and type")
+	ErrMetricsLabelsWithDuplicateLabel        = errors.New("metrics labels must not contain duplicates")
+	ErrMetricsLabelsWithoutAnIdentifyingLabel = errors.New("metrics labels must contain at least one of key, group or name")
+	supportedLabels                           = map[string]bool{LabelKey: true, LabelGroup: true, LabelName: true, LabelType: true}
+	identifyingLabels                         = map[string]bool{LabelKey: true, LabelGroup: true, LabelName: true}
+	endpointLabels                            = DefaultLabels // The labels identifying the endpoint of a metric
+)
+
 var (
 	initializedMetrics bool // Whether the metrics have been initialized
 
 	resultTotal                        *prometheus.CounterVec
 	resultDurationSeconds              *prometheus.GaugeVec
 	resultConnectedTotal               *prometheus.CounterVec
+	resultUnknownTotal                 *prometheus.CounterVec
 	resultCodeTotal                    *prometheus.CounterVec
 	resultCertificateExpirationSeconds *prometheus.GaugeVec
+	resultSkippedTotal                 *prometheus.CounterVec
+
+	alertingCircuitBreakerState *prometheus.GaugeVec
+
+	groupHealth *prometheus.GaugeVec
+
+	hostRateLimitThrottledTotal        *prometheus.CounterVec
+	hostRateLimitThrottledSecondsTotal *prometheus.CounterVec
 )
 
 func initializePrometheusMetrics() {
@@ -25,27 +60,133 @@ func initializePrometheusMetrics() {
 		Namespace: namespace,
 		Name:      "results_total",
 		Help:      "Number of results per endpoint",
-	}, []string{"key", "group", "name", "type", "success"})
+	}, withEndpointLabels("success"))
 	resultDurationSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "results_duration_seconds",
 		Help:      "Duration of the request in seconds",
-	}, []string{"key", "group", "name", "type"})
+	}, withEndpointLabels())
 	resultConnectedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "results_connected_total",
 		Help:      "Total number of results in which a connection was successfully established",
-	}, []string{"key", "group", "name", "type"})
+	}, withEndpointLabels())
+	resultUnknownTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "results_unknown_total",
+		Help:      "Total number of results that are unknown because of a network error",
+	}, withEndpointLabels())
 	resultCodeTotal = promauto.NewCounterVec(prometheus.CounterOpts{
 		Namespace: namespace,
 		Name:      "results_code_total",
 		Help:      "Total number of results by code",
-	}, []string{"key", "group", "name", "type", "code"})
+	}, withEndpointLabels("code"))
 	resultCertificateExpirationSeconds = promauto.NewGaugeVec(prometheus.GaugeOpts{
 		Namespace: namespace,
 		Name:      "results_certificate_expiration_seconds",
 		Help:      "Number of seconds until the certificate expires",
-	}, []string{"key", "group", "name", "type"})
+	}, withEndpointLabels())
+	resultSkippedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "results_skipped_total",
+		Help:      "Total number of checks skipped because the previous check of the endpoint was still in progress",
+	}, withEndpointLabels())
+	alertingCircuitBreakerState = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "alerting_circuit_breaker_state",
+		Help:      "State of the circuit breaker of the alerting provider (0 = closed, 1 = half-open, 2 = open)",
+	}, []string{"type"})
+	groupHealth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "group_health",
+		Help:      "Health of the group of endpoints (0 = down, 1 = degraded, 2 = healthy)",
+	}, []string{LabelGroup})
+	hostRateLimitThrottledTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "host_rate_limit_throttled_total",
+		Help:      "Total number of checks delayed by the rate limit of the host they target",
+	}, []string{"host"})
+	hostRateLimitThrottledSecondsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "host_rate_limit_throttled_seconds_total",
+		Help:      "Total number of seconds checks were delayed for by the rate limit of the host they target",
+	}, []string{"host"})
+}
+
+// ValidateLabels validates the labels identifying the endpoint of a metric
+func ValidateLabels(labels []string) error {
+	hasIdentifyingLabel := false
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		if !supportedLabels[label] {
+			return fmt.Errorf("%w: %s", ErrMetricsLabelsWithUnknownLabel, label)
+		}
+		if seen[label] {
+			return fmt.Errorf("%w: %s", ErrMetricsLabelsWithDuplicateLabel, label)
+		}
+		seen[label] = true
+		if identifyingLabels[label] {
+			hasIdentifyingLabel = true
+		}
+	}
+	if !hasIdentifyingLabel {
+		return ErrMetricsLabelsWithoutAnIdentifyingLabel
+	}
+	return nil
+}
+
+// SetLabels sets the labels identifying the endpoint of a metric. If no labels are passed, DefaultLabels are used.
+//
+// The labels are expected to have been validated with ValidateLabels. Because Prometheus doesn't allow a metric to
+// change its labels once it has been registered, new labels are ignored if the metrics have already been initialized.
+func SetLabels(labels []string) {
+	if len(labels) == 0 {
+		labels = DefaultLabels
+	}
+	if equalLabels(labels, endpointLabels) {
+		return
+	}
+	if initializedMetrics {
+		log.Printf("[metrics][SetLabels] Ignoring metrics labels %v, because the metrics have already been initialized with %v. Gatus must be restarted for the new labels to take effect.", labels, endpointLabels)
+		return
+	}
+	endpointLabels = labels
+}
+
+func equalLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// withEndpointLabels returns the labels identifying the endpoint of a metric followed by the additional labels passed
+func withEndpointLabels(additionalLabels ...string) []string {
+	return append(append([]string{}, endpointLabels...), additionalLabels...)
+}
+
+// endpointLabelValues returns the values of the labels identifying the endpoint of a metric followed by the additional
+// values passed
+func endpointLabelValues(endpoint *core.Endpoint, additionalValues ...string) []string {
+	values := make([]string, 0, len(endpointLabels)+len(additionalValues))
+	for _, label := range endpointLabels {
+		switch label {
+		case LabelKey:
+			values = append(values, endpoint.Key())
+		case LabelGroup:
+			values = append(values, endpoint.Group)
+		case LabelName:
+			values = append(values, endpoint.Name)
+		case LabelType:
+			values = append(values, string(endpoint.Type()))
+		}
+	}
+	return append(values, additionalValues...)
 }
 
 // PublishMetricsForEndpoint publishes metrics for the given endpoint and its result.
@@ -55,19 +196,97 @@ func PublishMetricsForEndpoint(endpoint *core.Endpoint, result *core.Result) {
 		initializePrometheusMetrics()
 		initializedMetrics = true
 	}
-	endpointType := endpoint.Type()
-	resultTotal.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType), strconv.FormatBool(result.Success)).Inc()
-	resultDurationSeconds.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType)).Set(result.Duration.Seconds())
+	resultTotal.WithLabelValues(endpointLabelValues(endpoint, strconv.FormatBool(result.Success))...).Inc()
+	resultDurationSeconds.WithLabelValues(endpointLabelValues(endpoint)...).Set(result.Duration.Seconds())
 	if result.Connected {
-		resultConnectedTotal.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType)).Inc()
+		resultConnectedTotal.WithLabelValues(endpointLabelValues(endpoint)...).Inc()
+	}
+	if result.Unknown {
+		resultUnknownTotal.WithLabelValues(endpointLabelValues(endpoint)...).Inc()
 	}
 	if result.DNSRCode != "" {
-		resultCodeTotal.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType), result.DNSRCode).Inc()
+		resultCodeTotal.WithLabelValues(endpointLabelValues(endpoint, result.DNSRCode)...).Inc()
 	}
 	if result.HTTPStatus != 0 {
-		resultCodeTotal.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType), strconv.Itoa(result.HTTPStatus)).Inc()
+		resultCodeTotal.WithLabelValues(endpointLabelValues(endpoint, strconv.Itoa(result.HTTPStatus))...).Inc()
 	}
 	if result.CertificateExpiration != 0 {
-		resultCertificateExpirationSeconds.WithLabelValues(endpoint.Key(), endpoint.Group, endpoint.Name, string(endpointType)).Set(result.CertificateExpiration.Seconds())
+		resultCertificateExpirationSeconds.WithLabelValues(endpointLabelValues(endpoint)...).Set(result.CertificateExpiration.Seconds())
+	}
+}
+
+// UnpublishMetricsForEndpoint removes the metrics of the given endpoint, so that endpoints that are no longer monitored
+// don't keep exposing the metrics of their last result
+func UnpublishMetricsForEndpoint(endpoint *core.Endpoint) {
+	if !initializedMetrics {
+		return
+	}
+	labels := prometheus.Labels{}
+	values := endpointLabelValues(endpoint)
+	for i, label := range endpointLabels {
+		if identifyingLabels[label] {
+			labels[label] = values[i]
+		}
+	}
+	resultTotal.DeletePartialMatch(labels)
+	resultDurationSeconds.DeletePartialMatch(labels)
+	resultConnectedTotal.DeletePartialMatch(labels)
+	resultUnknownTotal.DeletePartialMatch(labels)
+	resultCodeTotal.DeletePartialMatch(labels)
+	resultCertificateExpirationSeconds.DeletePartialMatch(labels)
+	resultSkippedTotal.DeletePartialMatch(labels)
+}
+
+// PublishMetricsForSkippedCheck publishes that a check of the given endpoint was skipped because the previous one was
+// still in progress
+func PublishMetricsForSkippedCheck(endpoint *core.Endpoint) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	resultSkippedTotal.WithLabelValues(endpointLabelValues(endpoint)...).Inc()
+}
+
+// PublishMetricsForAlertingCircuitBreaker publishes the state of the circuit breaker of an alerting provider
+func PublishMetricsForAlertingCircuitBreaker(alertType alert.Type, state alerting.CircuitBreakerState) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	alertingCircuitBreakerState.WithLabelValues(string(alertType)).Set(float64(state))
+}
+
+// PublishMetricsForGroupHealth publishes the health of a group of endpoints
+//
+// Since a group whose health is unknown has no meaningful value, its metric is removed instead.
+func PublishMetricsForGroupHealth(group string, health endpointgroup.Health) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
+	}
+	if health == endpointgroup.HealthUnknown {
+		groupHealth.DeleteLabelValues(group)
+		return
+	}
+	groupHealth.WithLabelValues(group).Set(float64(health))
+}
+
+// UnpublishMetricsForGroupHealth removes the health of the groups that are no longer monitored
+func UnpublishMetricsForGroupHealth(groups ...string) {
+	if !initializedMetrics {
+		return
+	}
+	for _, group := range groups {
+		groupHealth.DeleteLabelValues(group)
+	}
+}
+
+// PublishMetricsForHostRateLimit publishes that a check of the host passed was delayed by the host rate limit
+func PublishMetricsForHostRateLimit(host string, throttled time.Duration) {
+	if !initializedMetrics {
+		initializePrometheusMetrics()
+		initializedMetrics = true
 	}
+	hostRateLimitThrottledTotal.WithLabelValues(host).Inc()
+	hostRateLimitThrottledSecondsTotal.WithLabelValues(host).Add(throttled.Seconds())
 }
@@ -2,9 +2,12 @@ package metrics
 
 import (
 	"bytes"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -113,4 +116,137 @@ gatus_results_total{group="http-ep-group",key="http-ep-group_http-ep-name",name=
 	if err != nil {
 		t.Errorf("Expected no errors but got: %v", err)
 	}
+	UnpublishMetricsForEndpoint(httpEndpoint)
+	err = testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_results_code_total Total number of results by code
+# TYPE gatus_results_code_total counter
+gatus_results_code_total{code="NOERROR",group="dns-ep-group",key="dns-ep-group_dns-ep-name",name="dns-ep-name",type="DNS"} 1
+# HELP gatus_results_connected_total Total number of results in which a connection was successfully established
+# TYPE gatus_results_connected_total counter
+gatus_results_connected_total{group="dns-ep-group",key="dns-ep-group_dns-ep-name",name="dns-ep-name",type="DNS"} 1
+# HELP gatus_results_duration_seconds Duration of the request in seconds
+# TYPE gatus_results_duration_seconds gauge
+gatus_results_duration_seconds{group="dns-ep-group",key="dns-ep-group_dns-ep-name",name="dns-ep-name",type="DNS"} 0.05
+# HELP gatus_results_total Number of results per endpoint
+# TYPE gatus_results_total counter
+gatus_results_total{group="dns-ep-group",key="dns-ep-group_dns-ep-name",name="dns-ep-name",success="true",type="DNS"} 1
+`), "gatus_results_code_total", "gatus_results_connected_total", "gatus_results_duration_seconds", "gatus_results_total", "gatus_results_certificate_expiration_seconds")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestPublishMetricsForEndpointWithUnknownResult(t *testing.T) {
+	endpoint := &core.Endpoint{Name: "unknown-ep-name", Group: "unknown-ep-group", URL: "https://example.org"}
+	PublishMetricsForEndpoint(endpoint, &core.Result{Success: true})
+	PublishMetricsForEndpoint(endpoint, &core.Result{Success: false, Unknown: true})
+	PublishMetricsForEndpoint(endpoint, &core.Result{Success: false, Unknown: true})
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_results_unknown_total Total number of results that are unknown because of a network error
+# TYPE gatus_results_unknown_total counter
+gatus_results_unknown_total{group="unknown-ep-group",key="unknown-ep-group_unknown-ep-name",name="unknown-ep-name",type="HTTP"} 2
+`), "gatus_results_unknown_total")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+	UnpublishMetricsForEndpoint(endpoint)
+	if count := testutil.CollectAndCount(resultUnknownTotal, "gatus_results_unknown_total"); count != 0 {
+		t.Errorf("expected no series after unpublishing the endpoint, got %d", count)
+	}
+}
+
+func TestPublishMetricsForSkippedCheck(t *testing.T) {
+	endpoint := &core.Endpoint{Name: "slow", Group: "core", URL: "https://example.org/slow"}
+	PublishMetricsForSkippedCheck(endpoint)
+	PublishMetricsForSkippedCheck(endpoint)
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_results_skipped_total Total number of checks skipped because the previous check of the endpoint was still in progress
+# TYPE gatus_results_skipped_total counter
+gatus_results_skipped_total{group="core",key="core_slow",name="slow",type="HTTP"} 2
+`), "gatus_results_skipped_total")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+	UnpublishMetricsForEndpoint(endpoint)
+	if count := testutil.CollectAndCount(resultSkippedTotal, "gatus_results_skipped_total"); count != 0 {
+		t.Errorf("expected no series after unpublishing the endpoint, got %d", count)
+	}
+}
+
+func TestPublishMetricsForHostRateLimit(t *testing.T) {
+	PublishMetricsForHostRateLimit("example.org", 1500*time.Millisecond)
+	PublishMetricsForHostRateLimit("example.org", 500*time.Millisecond)
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_host_rate_limit_throttled_seconds_total Total number of seconds checks were delayed for by the rate limit of the host they target
+# TYPE gatus_host_rate_limit_throttled_seconds_total counter
+gatus_host_rate_limit_throttled_seconds_total{host="example.org"} 2
+# HELP gatus_host_rate_limit_throttled_total Total number of checks delayed by the rate limit of the host they target
+# TYPE gatus_host_rate_limit_throttled_total counter
+gatus_host_rate_limit_throttled_total{host="example.org"} 2
+`), "gatus_host_rate_limit_throttled_total", "gatus_host_rate_limit_throttled_seconds_total")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestPublishMetricsForGroupHealth(t *testing.T) {
+	PublishMetricsForGroupHealth("core", endpointgroup.HealthDegraded)
+	PublishMetricsForGroupHealth("internal", endpointgroup.HealthHealthy)
+	PublishMetricsForGroupHealth("unknown", endpointgroup.HealthUnknown)
+	err := testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_group_health Health of the group of endpoints (0 = down, 1 = degraded, 2 = healthy)
+# TYPE gatus_group_health gauge
+gatus_group_health{group="core"} 1
+gatus_group_health{group="internal"} 2
+`), "gatus_group_health")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+	UnpublishMetricsForGroupHealth("internal")
+	PublishMetricsForGroupHealth("core", endpointgroup.HealthDown)
+	err = testutil.GatherAndCompare(prometheus.Gatherers{prometheus.DefaultGatherer}, bytes.NewBufferString(`
+# HELP gatus_group_health Health of the group of endpoints (0 = down, 1 = degraded, 2 = healthy)
+# TYPE gatus_group_health gauge
+gatus_group_health{group="core"} 0
+`), "gatus_group_health")
+	if err != nil {
+		t.Errorf("Expected no errors but got: %v", err)
+	}
+}
+
+func TestEndpointLabelValues(t *testing.T) {
+	defer func(labels []string) { endpointLabels = labels }(endpointLabels)
+	endpoint := &core.Endpoint{Name: "name", Group: "group", URL: "https://example.org"}
+	if values := strings.Join(endpointLabelValues(endpoint, "200"), ","); values != "group_name,group,name,HTTP,200" {
+		t.Errorf("expected group_name,group,name,HTTP,200, got %s", values)
+	}
+	endpointLabels = []string{LabelGroup, LabelType}
+	if labels := strings.Join(withEndpointLabels("code"), ","); labels != "group,type,code" {
+		t.Errorf("expected group,type,code, got %s", labels)
+	}
+	if values := strings.Join(endpointLabelValues(endpoint, "200"), ","); values != "group,HTTP,200" {
+		t.Errorf("expected group,HTTP,200, got %s", values)
+	}
+}
+
+func TestValidateLabels(t *testing.T) {
+	scenarios := []struct {
+		labels        []string
+		expectedError error
+	}{
+		{labels: []string{"key", "group", "name", "type"}, expectedError: nil},
+		{labels: []string{"group", "name"}, expectedError: nil},
+		{labels: []string{"name"}, expectedError: nil},
+		{labels: []string{"type"}, expectedError: ErrMetricsLabelsWithoutAnIdentifyingLabel},
+		{labels: []string{}, expectedError: ErrMetricsLabelsWithoutAnIdentifyingLabel},
+		{labels: []string{"name", "name"}, expectedError: ErrMetricsLabelsWithDuplicateLabel},
+		{labels: []string{"name", "url"}, expectedError: ErrMetricsLabelsWithUnknownLabel},
+	}
+	for _, scenario := range scenarios {
+		t.Run(strings.Join(scenario.labels, ","), func(t *testing.T) {
+			if err := ValidateLabels(scenario.labels); !errors.Is(err, scenario.expectedError) {
+				t.Errorf("expected error %v, got %v", scenario.expectedError, err)
+			}
+		})
+	}
 }
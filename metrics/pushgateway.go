@@ -0,0 +1,173 @@
+package metrics
+
+import (
+	"errors"
+	"log"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	// DefaultPushgatewayInterval is the default interval at which metrics are pushed to the Pushgateway
+	DefaultPushgatewayInterval = 30 * time.Second
+
+	// DefaultPushgatewayJob is the default value of the job label of the metrics pushed to the Pushgateway
+	DefaultPushgatewayJob = "gatus"
+)
+
+var (
+	ErrPushgatewayWithInvalidURL      = errors.New("pushgateway url must be a valid http or https url")
+	ErrPushgatewayWithInvalidInterval = errors.New("pushgateway interval must be at least 1s")
+	ErrPushgatewayWithInvalidGrouping = errors.New("pushgateway grouping must not contain the job label or labels with an empty value")
+
+	pushgatewayMutex  sync.Mutex
+	activePushgateway *pushgatewayPusher
+)
+
+// PushgatewayConfig is the configuration for pushing metrics to a Prometheus Pushgateway
+type PushgatewayConfig struct {
+	// URL of the Pushgateway (e.g. https://pushgateway.example.com)
+	URL string `yaml:"url"`
+
+	// Job is the value of the job label of the metrics pushed
+	Job string `yaml:"job,omitempty"`
+
+	// Grouping is the grouping key of the metrics pushed, in addition to the job label (e.g. instance: gatus-1)
+	Grouping map[string]string `yaml:"grouping,omitempty"`
+
+	// Interval at which metrics are gathered and pushed
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the Pushgateway
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the Pushgateway configuration and sets the default values if necessary.
+func (cfg *PushgatewayConfig) ValidateAndSetDefaults() error {
+	parsedURL, err := url.Parse(cfg.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrPushgatewayWithInvalidURL
+	}
+	if len(cfg.Job) == 0 {
+		cfg.Job = DefaultPushgatewayJob
+	}
+	for name, value := range cfg.Grouping {
+		if name == "job" || len(name) == 0 || len(value) == 0 {
+			return ErrPushgatewayWithInvalidGrouping
+		}
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultPushgatewayInterval
+	} else if cfg.Interval < time.Second {
+		return ErrPushgatewayWithInvalidInterval
+	}
+	if cfg.ClientConfig == nil {
+		cfg.ClientConfig = client.GetDefaultConfig()
+	} else if err := cfg.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StartPushgateway starts pushing the metrics of Gatus to the Pushgateway at the interval configured
+//
+// Does nothing if cfg is nil.
+func StartPushgateway(cfg *PushgatewayConfig) {
+	if cfg == nil {
+		return
+	}
+	pushgatewayMutex.Lock()
+	defer pushgatewayMutex.Unlock()
+	if activePushgateway != nil {
+		activePushgateway.stop()
+	}
+	activePushgateway = newPushgatewayPusher(cfg, prometheus.DefaultGatherer)
+	go activePushgateway.run()
+}
+
+// StopPushgateway stops pushing the metrics of Gatus to the Pushgateway, if it was started, and deletes the metrics
+// that were pushed so that the Pushgateway doesn't keep exposing them once Gatus is no longer running
+func StopPushgateway() {
+	pushgatewayMutex.Lock()
+	defer pushgatewayMutex.Unlock()
+	if activePushgateway != nil {
+		activePushgateway.stop()
+		if err := activePushgateway.delete(); err != nil {
+			log.Printf("[metrics][StopPushgateway] Failed to delete metrics from pushgateway: %s", err.Error())
+		}
+		activePushgateway = nil
+	}
+}
+
+// pushgatewayPusher periodically gathers the metrics of Gatus and pushes them to a Pushgateway
+type pushgatewayPusher struct {
+	cfg    *PushgatewayConfig
+	pusher *push.Pusher
+
+	done    chan struct{}
+	stopped chan struct{} // Closed once run has returned
+}
+
+func newPushgatewayPusher(cfg *PushgatewayConfig, gatherer prometheus.Gatherer) *pushgatewayPusher {
+	pusher := push.New(cfg.URL, cfg.Job).Gatherer(gatusGatherer(gatherer)).Client(client.GetHTTPClient(cfg.ClientConfig))
+	for name, value := range cfg.Grouping {
+		pusher = pusher.Grouping(name, value)
+	}
+	return &pushgatewayPusher{cfg: cfg, pusher: pusher, done: make(chan struct{}), stopped: make(chan struct{})}
+}
+
+func (p *pushgatewayPusher) run() {
+	defer close(p.stopped)
+	// Push right away, since the metrics are kept in memory across configuration reloads
+	p.push()
+	ticker := time.NewTicker(p.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			p.push()
+		}
+	}
+}
+
+// stop stops pushing metrics and waits for the push in progress, if any, to complete, so that the metrics can safely
+// be deleted afterward
+func (p *pushgatewayPusher) stop() {
+	close(p.done)
+	<-p.stopped
+}
+
+// push replaces the metrics of the grouping key in the Pushgateway by the current metrics of Gatus
+func (p *pushgatewayPusher) push() {
+	if err := p.pusher.Push(); err != nil {
+		log.Printf("[metrics][push] Failed to push metrics to pushgateway: %s", err.Error())
+	}
+}
+
+// delete removes the metrics of the grouping key from the Pushgateway
+func (p *pushgatewayPusher) delete() error {
+	return p.pusher.Delete()
+}
+
+// gatusGatherer returns a gatherer that only keeps the metrics in the gatus namespace
+func gatusGatherer(gatherer prometheus.Gatherer) prometheus.Gatherer {
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		metricFamilies, err := gatherer.Gather()
+		var gatusMetricFamilies []*dto.MetricFamily
+		for _, metricFamily := range metricFamilies {
+			if strings.HasPrefix(metricFamily.GetName(), namespace+"_") {
+				gatusMetricFamilies = append(gatusMetricFamilies, metricFamily)
+			}
+		}
+		return gatusMetricFamilies, err
+	})
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestPushgatewayConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *PushgatewayConfig
+		expectedErr error
+	}{
+		{name: "valid", cfg: &PushgatewayConfig{URL: "https://pushgateway.example.com"}},
+		{name: "valid-with-grouping", cfg: &PushgatewayConfig{URL: "https://pushgateway.example.com", Grouping: map[string]string{"instance": "gatus-1"}}},
+		{name: "no-url", cfg: &PushgatewayConfig{}, expectedErr: ErrPushgatewayWithInvalidURL},
+		{name: "invalid-url-scheme", cfg: &PushgatewayConfig{URL: "pushgateway.example.com"}, expectedErr: ErrPushgatewayWithInvalidURL},
+		{name: "invalid-interval", cfg: &PushgatewayConfig{URL: "https://pushgateway.example.com", Interval: time.Millisecond}, expectedErr: ErrPushgatewayWithInvalidInterval},
+		{name: "grouping-with-job", cfg: &PushgatewayConfig{URL: "https://pushgateway.example.com", Grouping: map[string]string{"job": "gatus"}}, expectedErr: ErrPushgatewayWithInvalidGrouping},
+		{name: "grouping-with-empty-value", cfg: &PushgatewayConfig{URL: "https://pushgateway.example.com", Grouping: map[string]string{"instance": ""}}, expectedErr: ErrPushgatewayWithInvalidGrouping},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil {
+				if scenario.cfg.Interval != DefaultPushgatewayInterval {
+					t.Errorf("expected interval to be %s, got %s", DefaultPushgatewayInterval, scenario.cfg.Interval)
+				}
+				if scenario.cfg.Job != DefaultPushgatewayJob {
+					t.Errorf("expected job to be %s, got %s", DefaultPushgatewayJob, scenario.cfg.Job)
+				}
+				if scenario.cfg.ClientConfig == nil {
+					t.Error("expected client config to have been set")
+				}
+			}
+		})
+	}
+}
+
+func TestPushgatewayPusher(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Namespace: namespace, Name: "test_total"})
+	registry.MustRegister(counter, prometheus.NewCounter(prometheus.CounterOpts{Name: "not_gatus_total"}))
+	counter.Inc()
+	cfg := &PushgatewayConfig{URL: "https://pushgateway.example.com", Grouping: map[string]string{"instance": "gatus-1"}}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	var requests []string
+	var body string
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Body != nil {
+			b, _ := io.ReadAll(r.Body)
+			body = string(b)
+		}
+		return &http.Response{StatusCode: http.StatusAccepted, Body: http.NoBody}
+	})})
+	pusher := newPushgatewayPusher(cfg, registry)
+	pusher.push()
+	if err := pusher.delete(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(requests) != 2 || requests[0] != "PUT /metrics/job/gatus/instance/gatus-1" || requests[1] != "DELETE /metrics/job/gatus/instance/gatus-1" {
+		t.Errorf("expected a push followed by a delete of the grouping key, got %v", requests)
+	}
+	if !strings.Contains(body, "gatus_test_total") || strings.Contains(body, "not_gatus_total") {
+		t.Errorf("expected only the metrics in the gatus namespace to have been pushed, got %s", body)
+	}
+}
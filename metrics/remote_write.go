@@ -0,0 +1,286 @@
+package metrics
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	// DefaultRemoteWriteInterval is the default interval at which metrics are pushed
+	DefaultRemoteWriteInterval = 30 * time.Second
+
+	// DefaultRemoteWriteMaximumPendingBatches is the default maximum number of batches waiting to be sent
+	DefaultRemoteWriteMaximumPendingBatches = 10
+
+	remoteWriteVersion = "0.1.0"
+)
+
+var (
+	ErrRemoteWriteWithInvalidURL      = errors.New("remote-write url must be a valid http or https url")
+	ErrRemoteWriteWithInvalidInterval = errors.New("remote-write interval must be at least 1s")
+
+	remoteWriteDroppedBatchesTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "remote_write_dropped_batches_total",
+		Help:      "Number of batches dropped because too many batches were waiting to be sent",
+	})
+
+	remoteWriterMutex  sync.Mutex
+	activeRemoteWriter *remoteWriter
+)
+
+// RemoteWriteConfig is the configuration for pushing metrics to a Prometheus remote write endpoint
+// (e.g. Mimir, Thanos, Cortex)
+type RemoteWriteConfig struct {
+	// URL of the remote write endpoint (e.g. https://mimir.example.com/api/v1/push)
+	URL string `yaml:"url"`
+
+	// Interval at which metrics are gathered and pushed
+	Interval time.Duration `yaml:"interval,omitempty"`
+
+	// Headers to add to every request (e.g. X-Scope-OrgID)
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// BasicAuth is the basic authentication to use
+	BasicAuth *RemoteWriteBasicAuthConfig `yaml:"basic-auth,omitempty"`
+
+	// BearerToken is the token to send in the Authorization header
+	BearerToken string `yaml:"bearer-token,omitempty"`
+
+	// MaximumPendingBatches is the maximum number of batches waiting to be sent.
+	// When it is reached, the oldest batch is dropped.
+	MaximumPendingBatches int `yaml:"maximum-pending-batches,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the remote write endpoint
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// RemoteWriteBasicAuthConfig is the basic authentication configuration for the remote write endpoint
+type RemoteWriteBasicAuthConfig struct {
+	Username string `yaml:"username"`
+	Password string `yaml:"password"`
+}
+
+// ValidateAndSetDefaults validates the remote write configuration and sets the default values if necessary.
+func (cfg *RemoteWriteConfig) ValidateAndSetDefaults() error {
+	parsedURL, err := url.Parse(cfg.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrRemoteWriteWithInvalidURL
+	}
+	if cfg.Interval == 0 {
+		cfg.Interval = DefaultRemoteWriteInterval
+	} else if cfg.Interval < time.Second {
+		return ErrRemoteWriteWithInvalidInterval
+	}
+	if cfg.MaximumPendingBatches <= 0 {
+		cfg.MaximumPendingBatches = DefaultRemoteWriteMaximumPendingBatches
+	}
+	if cfg.ClientConfig == nil {
+		cfg.ClientConfig = client.GetDefaultConfig()
+	} else if err := cfg.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// StartRemoteWrite starts pushing the metrics of Gatus at the interval configured
+//
+// Does nothing if cfg is nil.
+func StartRemoteWrite(cfg *RemoteWriteConfig) {
+	if cfg == nil {
+		return
+	}
+	remoteWriterMutex.Lock()
+	defer remoteWriterMutex.Unlock()
+	if activeRemoteWriter != nil {
+		activeRemoteWriter.stop()
+	}
+	if err := prometheus.Register(remoteWriteDroppedBatchesTotal); err != nil {
+		if _, alreadyRegistered := err.(prometheus.AlreadyRegisteredError); !alreadyRegistered {
+			log.Printf("[metrics][StartRemoteWrite] Failed to register %s: %s", "remote_write_dropped_batches_total", err.Error())
+		}
+	}
+	activeRemoteWriter = newRemoteWriter(cfg, prometheus.DefaultGatherer)
+	go activeRemoteWriter.run()
+}
+
+// StopRemoteWrite stops pushing the metrics of Gatus, if it was started
+func StopRemoteWrite() {
+	remoteWriterMutex.Lock()
+	defer remoteWriterMutex.Unlock()
+	if activeRemoteWriter != nil {
+		activeRemoteWriter.stop()
+		activeRemoteWriter = nil
+	}
+}
+
+// remoteWriter periodically gathers the metrics of Gatus and sends them to a remote write endpoint
+type remoteWriter struct {
+	cfg      *RemoteWriteConfig
+	gatherer prometheus.Gatherer
+
+	// pendingBatches are the snappy-compressed write requests waiting to be sent, from oldest to newest
+	pendingBatches [][]byte
+
+	done chan struct{}
+}
+
+func newRemoteWriter(cfg *RemoteWriteConfig, gatherer prometheus.Gatherer) *remoteWriter {
+	return &remoteWriter{cfg: cfg, gatherer: gatherer, done: make(chan struct{})}
+}
+
+func (w *remoteWriter) run() {
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.gatherAndEnqueue(time.Now())
+			w.flush()
+		}
+	}
+}
+
+func (w *remoteWriter) stop() {
+	close(w.done)
+}
+
+// gatherAndEnqueue gathers the metrics of Gatus and adds them as a new batch
+//
+// If there are already too many batches waiting to be sent, the oldest one is dropped.
+func (w *remoteWriter) gatherAndEnqueue(now time.Time) {
+	metricFamilies, err := w.gatherer.Gather()
+	if err != nil {
+		log.Printf("[metrics][gatherAndEnqueue] Failed to gather some metrics: %s", err.Error())
+	}
+	writeRequest := encodeWriteRequest(metricFamilies, now)
+	if len(writeRequest) == 0 {
+		return
+	}
+	if len(w.pendingBatches) >= w.cfg.MaximumPendingBatches {
+		w.pendingBatches = w.pendingBatches[1:]
+		remoteWriteDroppedBatchesTotal.Inc()
+	}
+	w.pendingBatches = append(w.pendingBatches, snappy.Encode(nil, writeRequest))
+}
+
+// flush sends the pending batches from oldest to newest, and stops at the first batch that fails to be sent
+func (w *remoteWriter) flush() {
+	for len(w.pendingBatches) > 0 {
+		if err := w.send(w.pendingBatches[0]); err != nil {
+			log.Printf("[metrics][flush] Failed to send batch, %d batch(es) pending: %s", len(w.pendingBatches), err.Error())
+			return
+		}
+		w.pendingBatches = w.pendingBatches[1:]
+	}
+}
+
+func (w *remoteWriter) send(batch []byte) error {
+	request, err := http.NewRequest(http.MethodPost, w.cfg.URL, bytes.NewReader(batch))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("X-Prometheus-Remote-Write-Version", remoteWriteVersion)
+	for name, value := range w.cfg.Headers {
+		request.Header.Set(name, value)
+	}
+	if w.cfg.BasicAuth != nil {
+		request.SetBasicAuth(w.cfg.BasicAuth.Username, w.cfg.BasicAuth.Password)
+	} else if len(w.cfg.BearerToken) > 0 {
+		request.Header.Set("Authorization", "Bearer "+w.cfg.BearerToken)
+	}
+	response, err := client.GetHTTPClient(w.cfg.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 299 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("remote write endpoint returned status code %d: %s", response.StatusCode, string(body))
+	}
+	return nil
+}
+
+// encodeWriteRequest encodes the metric families of Gatus into a Prometheus remote write request
+//
+// Only the metrics in the gatus namespace are included, and every sample uses the timestamp passed.
+func encodeWriteRequest(metricFamilies []*dto.MetricFamily, now time.Time) []byte {
+	var writeRequest []byte
+	timestamp := now.UnixMilli()
+	for _, metricFamily := range metricFamilies {
+		if !strings.HasPrefix(metricFamily.GetName(), namespace+"_") {
+			continue
+		}
+		for _, metric := range metricFamily.GetMetric() {
+			var value float64
+			switch {
+			case metric.GetCounter() != nil:
+				value = metric.GetCounter().GetValue()
+			case metric.GetGauge() != nil:
+				value = metric.GetGauge().GetValue()
+			case metric.GetUntyped() != nil:
+				value = metric.GetUntyped().GetValue()
+			default:
+				// Gatus doesn't expose any histogram or summary
+				continue
+			}
+			labels := map[string]string{"__name__": metricFamily.GetName()}
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			timeSeries := encodeTimeSeries(labels, value, timestamp)
+			writeRequest = protowire.AppendTag(writeRequest, 1, protowire.BytesType)
+			writeRequest = protowire.AppendBytes(writeRequest, timeSeries)
+		}
+	}
+	return writeRequest
+}
+
+// encodeTimeSeries encodes a single time series with a single sample
+//
+// Remote write requires the labels to be sorted by name.
+func encodeTimeSeries(labels map[string]string, value float64, timestamp int64) []byte {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var timeSeries []byte
+	for _, name := range names {
+		var label []byte
+		label = protowire.AppendTag(label, 1, protowire.BytesType)
+		label = protowire.AppendString(label, name)
+		label = protowire.AppendTag(label, 2, protowire.BytesType)
+		label = protowire.AppendString(label, labels[name])
+		timeSeries = protowire.AppendTag(timeSeries, 1, protowire.BytesType)
+		timeSeries = protowire.AppendBytes(timeSeries, label)
+	}
+	var sample []byte
+	sample = protowire.AppendTag(sample, 1, protowire.Fixed64Type)
+	sample = protowire.AppendFixed64(sample, math.Float64bits(value))
+	sample = protowire.AppendTag(sample, 2, protowire.VarintType)
+	sample = protowire.AppendVarint(sample, uint64(timestamp))
+	timeSeries = protowire.AppendTag(timeSeries, 2, protowire.BytesType)
+	timeSeries = protowire.AppendBytes(timeSeries, sample)
+	return timeSeries
+}
@@ -0,0 +1,200 @@
+package metrics
+
+import (
+	"io"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/klauspost/compress/snappy"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestRemoteWriteConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *RemoteWriteConfig
+		expectedErr error
+	}{
+		{name: "valid", cfg: &RemoteWriteConfig{URL: "https://mimir.example.com/api/v1/push"}},
+		{name: "no-url", cfg: &RemoteWriteConfig{}, expectedErr: ErrRemoteWriteWithInvalidURL},
+		{name: "invalid-url-scheme", cfg: &RemoteWriteConfig{URL: "mimir.example.com/api/v1/push"}, expectedErr: ErrRemoteWriteWithInvalidURL},
+		{name: "invalid-interval", cfg: &RemoteWriteConfig{URL: "https://mimir.example.com/api/v1/push", Interval: time.Millisecond}, expectedErr: ErrRemoteWriteWithInvalidInterval},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil {
+				if scenario.cfg.Interval != DefaultRemoteWriteInterval {
+					t.Errorf("expected interval to be %s, got %s", DefaultRemoteWriteInterval, scenario.cfg.Interval)
+				}
+				if scenario.cfg.MaximumPendingBatches != DefaultRemoteWriteMaximumPendingBatches {
+					t.Errorf("expected maximum pending batches to be %d, got %d", DefaultRemoteWriteMaximumPendingBatches, scenario.cfg.MaximumPendingBatches)
+				}
+				if scenario.cfg.ClientConfig == nil {
+					t.Error("expected client config to have been set")
+				}
+			}
+		})
+	}
+}
+
+func TestEncodeWriteRequest(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Namespace: namespace, Name: "test_gauge"}, []string{"name", "group"})
+	gauge.WithLabelValues("endpoint", "group").Set(1.5)
+	registry.MustRegister(gauge)
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "not_gatus_total"}))
+	metricFamilies, err := registry.Gather()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	now := time.Now()
+	timeSeries := decodeWriteRequest(t, encodeWriteRequest(metricFamilies, now))
+	if len(timeSeries) != 1 {
+		t.Fatalf("expected only the series in the gatus namespace to be encoded, got %d series", len(timeSeries))
+	}
+	expectedLabels := []string{"__name__", "gatus_test_gauge", "group", "group", "name", "endpoint"}
+	if len(timeSeries[0].labels) != len(expectedLabels) {
+		t.Fatalf("expected labels %v, got %v", expectedLabels, timeSeries[0].labels)
+	}
+	for i := range expectedLabels {
+		if timeSeries[0].labels[i] != expectedLabels[i] {
+			t.Errorf("expected labels %v, got %v", expectedLabels, timeSeries[0].labels)
+			break
+		}
+	}
+	if timeSeries[0].value != 1.5 {
+		t.Errorf("expected value to be 1.5, got %f", timeSeries[0].value)
+	}
+	if timeSeries[0].timestamp != now.UnixMilli() {
+		t.Errorf("expected timestamp to be %d, got %d", now.UnixMilli(), timeSeries[0].timestamp)
+	}
+}
+
+func TestRemoteWriter(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	registry := prometheus.NewRegistry()
+	counter := prometheus.NewCounter(prometheus.CounterOpts{Namespace: namespace, Name: "test_total"})
+	registry.MustRegister(counter)
+	cfg := &RemoteWriteConfig{URL: "https://mimir.example.com/api/v1/push", BearerToken: "token", Headers: map[string]string{"X-Scope-OrgID": "gatus"}, MaximumPendingBatches: 2}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	writer := newRemoteWriter(cfg, registry)
+	// Simulate the remote write endpoint being down
+	var numberOfRequests int
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		numberOfRequests++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+	})})
+	droppedBatchesBefore := testutil.ToFloat64(remoteWriteDroppedBatchesTotal)
+	for i := 0; i < 3; i++ {
+		counter.Inc()
+		writer.gatherAndEnqueue(time.Now())
+		writer.flush()
+	}
+	if len(writer.pendingBatches) != 2 {
+		t.Errorf("expected 2 pending batches, got %d", len(writer.pendingBatches))
+	}
+	if dropped := testutil.ToFloat64(remoteWriteDroppedBatchesTotal) - droppedBatchesBefore; dropped != 1 {
+		t.Errorf("expected 1 batch to have been dropped, got %f", dropped)
+	}
+	if numberOfRequests != 3 {
+		t.Errorf("expected only the oldest batch to be retried on each flush, got %d requests", numberOfRequests)
+	}
+	// Simulate the remote write endpoint coming back up
+	var values []float64
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("Content-Type") != "application/x-protobuf" || r.Header.Get("X-Prometheus-Remote-Write-Version") != remoteWriteVersion {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+		}
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("X-Scope-OrgID") != "gatus" {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+		}
+		compressed, _ := io.ReadAll(r.Body)
+		body, err := snappy.Decode(nil, compressed)
+		if err != nil {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+		}
+		for _, timeSeries := range decodeWriteRequest(t, body) {
+			values = append(values, timeSeries.value)
+		}
+		return &http.Response{StatusCode: http.StatusNoContent, Body: http.NoBody}
+	})})
+	writer.flush()
+	if len(writer.pendingBatches) != 0 {
+		t.Errorf("expected no pending batches, got %d", len(writer.pendingBatches))
+	}
+	// The oldest batch (value=1) should've been dropped, and the others should've been sent in order
+	if len(values) != 2 || values[0] != 2 || values[1] != 3 {
+		t.Errorf("expected values [2 3] to have been sent, got %v", values)
+	}
+}
+
+type decodedTimeSeries struct {
+	labels    []string // name, value, name, value, ...
+	value     float64
+	timestamp int64
+}
+
+// decodeWriteRequest decodes the subset of the remote write protocol used by encodeWriteRequest
+func decodeWriteRequest(t *testing.T, writeRequest []byte) []decodedTimeSeries {
+	var result []decodedTimeSeries
+	for _, timeSeries := range consumeMessages(t, writeRequest) {
+		var decoded decodedTimeSeries
+		for number, fields := range consumeFields(t, timeSeries) {
+			for _, field := range fields {
+				if number == 1 {
+					label := consumeFields(t, field)
+					decoded.labels = append(decoded.labels, string(label[1][0]), string(label[2][0]))
+				} else if number == 2 {
+					sample := consumeFields(t, field)
+					value, _ := protowire.ConsumeFixed64(sample[1][0])
+					timestamp, _ := protowire.ConsumeVarint(sample[2][0])
+					decoded.value = math.Float64frombits(value)
+					decoded.timestamp = int64(timestamp)
+				}
+			}
+		}
+		result = append(result, decoded)
+	}
+	return result
+}
+
+func consumeMessages(t *testing.T, b []byte) [][]byte {
+	return consumeFields(t, b)[1]
+}
+
+// consumeFields returns the raw value of each field by field number
+func consumeFields(t *testing.T, b []byte) map[protowire.Number][][]byte {
+	fields := make(map[protowire.Number][][]byte)
+	for len(b) > 0 {
+		number, fieldType, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			t.Fatal("invalid tag")
+		}
+		b = b[n:]
+		var value []byte
+		switch fieldType {
+		case protowire.BytesType:
+			value, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(number, fieldType, b)
+			value = b[:n]
+		}
+		if n < 0 {
+			t.Fatal("invalid field value")
+		}
+		fields[number] = append(fields[number], value)
+		b = b[n:]
+	}
+	return fields
+}
@@ -0,0 +1,345 @@
+package resultwebhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/util"
+)
+
+const (
+	// DefaultBatchSize is the default maximum number of results sent in a single request
+	DefaultBatchSize = 100
+
+	// DefaultFlushInterval is the default interval at which pending results are sent
+	DefaultFlushInterval = 10 * time.Second
+
+	// DefaultMaximumPendingResults is the default maximum number of results waiting to be sent
+	DefaultMaximumPendingResults = 10000
+)
+
+var (
+	ErrInvalidURL                    = errors.New("result-webhook.url must be a valid http or https url")
+	ErrInvalidBatchSize              = errors.New("result-webhook.batch-size must not be negative")
+	ErrInvalidFlushInterval          = errors.New("result-webhook.flush-interval must be at least 1s")
+	ErrInvalidMinimumRequestInterval = errors.New("result-webhook.minimum-request-interval must not be negative")
+
+	webhookMutex  sync.Mutex
+	activeWebhook *webhook
+)
+
+// Config is the configuration of the webhook to which the result of every check is sent
+//
+// Unlike alerting, which only sends a request when an alert is triggered or resolved, this sends every result, which
+// makes it possible to feed the results of Gatus into an external pipeline.
+type Config struct {
+	// URL to which the results are sent with a POST request
+	URL string `yaml:"url"`
+
+	// Headers to add to every request (e.g. Authorization)
+	Headers map[string]string `yaml:"headers,omitempty"`
+
+	// Secret is the secret with which the body of each request is signed, so that the receiver can verify that the
+	// request comes from Gatus. The signature is sent in the SignatureHeader.
+	Secret string `yaml:"secret,omitempty"`
+
+	// SignatureHeader is the name of the header in which the signature is sent. Defaults to util.DefaultSignatureHeader.
+	SignatureHeader string `yaml:"signature-header,omitempty"`
+
+	// BatchSize is the maximum number of results sent in a single request.
+	// Pending results are sent as soon as there are enough of them to fill a batch.
+	BatchSize int `yaml:"batch-size,omitempty"`
+
+	// FlushInterval is the interval at which pending results are sent, even if there aren't enough of them to fill a
+	// batch. Batches that failed to be sent are retried at the next flush.
+	FlushInterval time.Duration `yaml:"flush-interval,omitempty"`
+
+	// MinimumRequestInterval is the minimum duration between two requests, which rate limits the webhook when many
+	// results are pending, such as after the webhook has been unreachable for a while
+	MinimumRequestInterval time.Duration `yaml:"minimum-request-interval,omitempty"`
+
+	// MaximumPendingResults is the maximum number of results waiting to be sent.
+	// When it is reached, the oldest result is dropped.
+	MaximumPendingResults int `yaml:"maximum-pending-results,omitempty"`
+
+	// ClientConfig is the configuration of the client used to communicate with the webhook
+	ClientConfig *client.Config `yaml:"client,omitempty"`
+}
+
+// ValidateAndSetDefaults validates the result webhook configuration and sets the default values if necessary
+func (cfg *Config) ValidateAndSetDefaults() error {
+	parsedURL, err := url.Parse(cfg.URL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || len(parsedURL.Host) == 0 {
+		return ErrInvalidURL
+	}
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = DefaultBatchSize
+	} else if cfg.BatchSize < 0 {
+		return ErrInvalidBatchSize
+	}
+	if cfg.FlushInterval == 0 {
+		cfg.FlushInterval = DefaultFlushInterval
+	} else if cfg.FlushInterval < time.Second {
+		return ErrInvalidFlushInterval
+	}
+	if cfg.MinimumRequestInterval < 0 {
+		return ErrInvalidMinimumRequestInterval
+	}
+	if len(cfg.SignatureHeader) == 0 {
+		cfg.SignatureHeader = util.DefaultSignatureHeader
+	}
+	if cfg.MaximumPendingResults <= 0 {
+		cfg.MaximumPendingResults = DefaultMaximumPendingResults
+	}
+	if cfg.MaximumPendingResults < cfg.BatchSize {
+		cfg.MaximumPendingResults = cfg.BatchSize
+	}
+	if cfg.ClientConfig == nil {
+		cfg.ClientConfig = client.GetDefaultConfig()
+	} else if err := cfg.ClientConfig.ValidateAndSetDefaults(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Event is what is sent to the webhook for each result
+type Event struct {
+	// Key is the key of the endpoint the result is for
+	Key string `json:"key"`
+
+	// Name is the name of the endpoint the result is for
+	Name string `json:"name"`
+
+	// Group is the group of the endpoint the result is for
+	Group string `json:"group,omitempty"`
+
+	// Success is whether the result was successful
+	Success bool `json:"success"`
+
+	// Result is the result of the check
+	Result *core.Result `json:"result"`
+}
+
+// Start starts sending the results published to the webhook configured
+//
+// Does nothing if cfg is nil.
+func Start(cfg *Config) {
+	if cfg == nil {
+		return
+	}
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+	if activeWebhook != nil {
+		activeWebhook.stop()
+	}
+	activeWebhook = newWebhook(cfg)
+	go activeWebhook.run()
+}
+
+// Stop stops sending results to the webhook, if it was started
+//
+// Results that are still pending are discarded.
+func Stop() {
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+	if activeWebhook != nil {
+		activeWebhook.stop()
+		activeWebhook = nil
+	}
+}
+
+// Publish queues the result of a check of an endpoint to be sent to the webhook
+//
+// Does nothing if the webhook wasn't started.
+func Publish(endpoint *core.Endpoint, result *core.Result) {
+	webhookMutex.Lock()
+	defer webhookMutex.Unlock()
+	if activeWebhook == nil {
+		return
+	}
+	// The event is serialized right away, because the result may be modified once the check is over
+	event, err := json.Marshal(&Event{Key: endpoint.Key(), Name: endpoint.Name, Group: endpoint.Group, Success: result.Success, Result: result})
+	if err != nil {
+		log.Printf("[resultwebhook][Publish] Failed to serialize result of endpoint with key=%s: %s", endpoint.Key(), err.Error())
+		return
+	}
+	activeWebhook.enqueue(event)
+}
+
+// webhook sends the results published to it in batches
+type webhook struct {
+	cfg *Config
+
+	// pendingEvents are the serialized events waiting to be sent, from oldest to newest
+	pendingEvents [][]byte
+
+	// droppedEvents is the number of events dropped since the last flush because too many events were pending
+	droppedEvents int
+
+	// lastRequest is when the last request was sent, which is used to enforce Config.MinimumRequestInterval
+	lastRequest time.Time
+
+	// failing is whether the last batch failed to be sent, in which case the pending events are only retried at the
+	// next flush interval rather than as soon as a batch is full
+	failing bool
+
+	mutex sync.Mutex
+
+	flushRequested chan struct{}
+	done           chan struct{}
+}
+
+func newWebhook(cfg *Config) *webhook {
+	return &webhook{cfg: cfg, flushRequested: make(chan struct{}, 1), done: make(chan struct{})}
+}
+
+func (w *webhook) run() {
+	ticker := time.NewTicker(w.cfg.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushRequested:
+			if !w.failing {
+				w.flush()
+			}
+		}
+	}
+}
+
+func (w *webhook) stop() {
+	close(w.done)
+}
+
+// enqueue adds an event to the pending events, and requests a flush if there are enough of them to fill a batch
+//
+// If there are already too many events waiting to be sent, the oldest one is dropped.
+func (w *webhook) enqueue(event []byte) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if len(w.pendingEvents) >= w.cfg.MaximumPendingResults {
+		w.pendingEvents = w.pendingEvents[1:]
+		w.droppedEvents++
+	}
+	w.pendingEvents = append(w.pendingEvents, event)
+	if len(w.pendingEvents) >= w.cfg.BatchSize {
+		select {
+		case w.flushRequested <- struct{}{}:
+		default:
+			// A flush has already been requested
+		}
+	}
+}
+
+// flush sends the pending events in batches from oldest to newest, and stops at the first batch that fails to be
+// sent, which will be retried at the next flush
+func (w *webhook) flush() {
+	w.mutex.Lock()
+	if w.droppedEvents > 0 {
+		log.Printf("[resultwebhook][flush] Dropped %d result(s) because too many results were waiting to be sent", w.droppedEvents)
+		w.droppedEvents = 0
+	}
+	w.mutex.Unlock()
+	for {
+		batch := w.dequeueBatch()
+		if len(batch) == 0 {
+			w.failing = false
+			return
+		}
+		if !w.waitForMinimumRequestInterval() {
+			w.requeueBatch(batch)
+			return
+		}
+		if err := w.send(batch); err != nil {
+			w.requeueBatch(batch)
+			w.failing = true
+			log.Printf("[resultwebhook][flush] Failed to send %d result(s): %s", len(batch), err.Error())
+			return
+		}
+	}
+}
+
+// dequeueBatch removes up to Config.BatchSize of the oldest pending events and returns them
+func (w *webhook) dequeueBatch() [][]byte {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	size := len(w.pendingEvents)
+	if size > w.cfg.BatchSize {
+		size = w.cfg.BatchSize
+	}
+	batch := w.pendingEvents[:size:size]
+	w.pendingEvents = w.pendingEvents[size:]
+	return batch
+}
+
+// requeueBatch puts a batch that couldn't be sent back in front of the pending events
+//
+// Because events may have been enqueued in the meantime, the oldest events are dropped if there are too many of them.
+func (w *webhook) requeueBatch(batch [][]byte) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	pendingEvents := append(batch, w.pendingEvents...)
+	if excess := len(pendingEvents) - w.cfg.MaximumPendingResults; excess > 0 {
+		pendingEvents = pendingEvents[excess:]
+		w.droppedEvents += excess
+	}
+	w.pendingEvents = pendingEvents
+}
+
+// waitForMinimumRequestInterval blocks until the minimum request interval has elapsed since the last request
+//
+// Returns false if the webhook was stopped while waiting.
+func (w *webhook) waitForMinimumRequestInterval() bool {
+	delay := time.Until(w.lastRequest.Add(w.cfg.MinimumRequestInterval))
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func (w *webhook) send(batch [][]byte) error {
+	w.lastRequest = time.Now()
+	body := append([]byte{'['}, bytes.Join(batch, []byte{','})...)
+	body = append(body, ']')
+	request, err := http.NewRequest(http.MethodPost, w.cfg.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for name, value := range w.cfg.Headers {
+		request.Header.Set(name, value)
+	}
+	if len(w.cfg.Secret) > 0 {
+		request.Header.Set(w.cfg.SignatureHeader, util.SignPayload(w.cfg.Secret, body))
+	}
+	response, err := client.GetHTTPClient(w.cfg.ClientConfig).Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode > 299 {
+		responseBody, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("result webhook returned status code %d: %s", response.StatusCode, string(responseBody))
+	}
+	return nil
+}
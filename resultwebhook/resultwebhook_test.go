@@ -0,0 +1,150 @@
+package resultwebhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+	"github.com/TwiN/gatus/v5/util"
+)
+
+func TestConfig_ValidateAndSetDefaults(t *testing.T) {
+	scenarios := []struct {
+		name        string
+		cfg         *Config
+		expectedErr error
+	}{
+		{name: "valid", cfg: &Config{URL: "https://example.com/results"}},
+		{name: "no-url", cfg: &Config{}, expectedErr: ErrInvalidURL},
+		{name: "invalid-url-scheme", cfg: &Config{URL: "example.com/results"}, expectedErr: ErrInvalidURL},
+		{name: "invalid-batch-size", cfg: &Config{URL: "https://example.com/results", BatchSize: -1}, expectedErr: ErrInvalidBatchSize},
+		{name: "invalid-flush-interval", cfg: &Config{URL: "https://example.com/results", FlushInterval: time.Millisecond}, expectedErr: ErrInvalidFlushInterval},
+		{name: "invalid-minimum-request-interval", cfg: &Config{URL: "https://example.com/results", MinimumRequestInterval: -time.Second}, expectedErr: ErrInvalidMinimumRequestInterval},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if err := scenario.cfg.ValidateAndSetDefaults(); err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if scenario.expectedErr == nil {
+				if scenario.cfg.BatchSize != DefaultBatchSize {
+					t.Errorf("expected batch size to be %d, got %d", DefaultBatchSize, scenario.cfg.BatchSize)
+				}
+				if scenario.cfg.FlushInterval != DefaultFlushInterval {
+					t.Errorf("expected flush interval to be %s, got %s", DefaultFlushInterval, scenario.cfg.FlushInterval)
+				}
+				if scenario.cfg.MaximumPendingResults != DefaultMaximumPendingResults {
+					t.Errorf("expected maximum pending results to be %d, got %d", DefaultMaximumPendingResults, scenario.cfg.MaximumPendingResults)
+				}
+				if scenario.cfg.ClientConfig == nil {
+					t.Error("expected client config to have been set")
+				}
+			}
+		})
+	}
+}
+
+func TestPublish(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	cfg := &Config{URL: "https://example.com/results", BatchSize: 2, MaximumPendingResults: 3, Headers: map[string]string{"Authorization": "Bearer token"}, Secret: "secret"}
+	if err := cfg.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint := &core.Endpoint{Name: "frontend", Group: "core"}
+	// Publishing without a started webhook must be a no-op
+	Publish(endpoint, &core.Result{Success: true})
+	w := newWebhook(cfg)
+	webhookMutex.Lock()
+	activeWebhook = w
+	webhookMutex.Unlock()
+	defer Stop()
+	// Simulate the webhook being down
+	var numberOfRequests int
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		numberOfRequests++
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: http.NoBody}
+	})})
+	for i := 1; i <= 4; i++ {
+		Publish(endpoint, &core.Result{Success: i%2 == 0, Duration: time.Duration(i) * time.Millisecond})
+	}
+	w.flush()
+	if numberOfRequests != 1 {
+		t.Errorf("expected flush to stop at the first batch that failed to be sent, got %d requests", numberOfRequests)
+	}
+	if len(w.pendingEvents) != 3 {
+		t.Errorf("expected the oldest result to have been dropped and the failed batch to be pending, got %d pending results", len(w.pendingEvents))
+	}
+	if !w.failing {
+		t.Error("expected the webhook to be failing")
+	}
+	// Simulate the webhook coming back up
+	var events []Event
+	var batchSizes []int
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		if r.Header.Get("Content-Type") != "application/json" || r.Header.Get("Authorization") != "Bearer token" {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+		}
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("X-Gatus-Signature") != util.SignPayload("secret", body) {
+			return &http.Response{StatusCode: http.StatusUnauthorized, Body: http.NoBody}
+		}
+		var batch []Event
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return &http.Response{StatusCode: http.StatusBadRequest, Body: http.NoBody}
+		}
+		batchSizes = append(batchSizes, len(batch))
+		events = append(events, batch...)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})})
+	w.flush()
+	if len(w.pendingEvents) != 0 {
+		t.Errorf("expected no pending results, got %d", len(w.pendingEvents))
+	}
+	if w.failing {
+		t.Error("expected the webhook to no longer be failing")
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != 2 || batchSizes[1] != 1 {
+		t.Errorf("expected results to have been sent in batches of sizes [2 1], got %v", batchSizes)
+	}
+	// The oldest result should've been dropped, and the others should've been sent in order
+	if len(events) != 3 {
+		t.Fatalf("expected 3 results to have been sent, got %d", len(events))
+	}
+	for i, event := range events {
+		expectedDuration := time.Duration(i+2) * time.Millisecond
+		if event.Key != "core_frontend" || event.Name != "frontend" || event.Group != "core" {
+			t.Errorf("expected event to identify the endpoint, got key=%s name=%s group=%s", event.Key, event.Name, event.Group)
+		}
+		if event.Success != (i%2 == 0) || event.Result.Success != event.Success {
+			t.Errorf("expected success of event #%d to be %v, got %v", i, i%2 == 0, event.Success)
+		}
+		if event.Result.Duration != expectedDuration {
+			t.Errorf("expected duration of event #%d to be %s, got %s", i, expectedDuration, event.Result.Duration)
+		}
+	}
+}
+
+func TestWebhook_waitForMinimumRequestInterval(t *testing.T) {
+	w := newWebhook(&Config{MinimumRequestInterval: 50 * time.Millisecond})
+	if !w.waitForMinimumRequestInterval() {
+		t.Error("expected the first request not to wait")
+	}
+	w.lastRequest = time.Now()
+	start := time.Now()
+	if !w.waitForMinimumRequestInterval() {
+		t.Error("expected the wait to succeed")
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("expected to wait for the minimum request interval, waited %s", elapsed)
+	}
+	w.lastRequest = time.Now()
+	w.stop()
+	if w.waitForMinimumRequestInterval() {
+		t.Error("expected the wait to be interrupted by stop")
+	}
+}
@@ -3,6 +3,7 @@ package security
 import (
 	"encoding/base64"
 	"log"
+	"net"
 	"net/http"
 
 	g8 "github.com/TwiN/g8/v2"
@@ -16,6 +17,10 @@ const (
 	cookieNameState   = "gatus_state"
 	cookieNameNonce   = "gatus_nonce"
 	cookieNameSession = "gatus_session"
+
+	// localsKeyUsername is the key of the locals in which the username of the user authenticated through basic
+	// authentication is stored
+	localsKeyUsername = "username"
 )
 
 // Config is the security configuration for Gatus
@@ -23,11 +28,21 @@ type Config struct {
 	Basic *BasicConfig `yaml:"basic,omitempty"`
 	OIDC  *OIDCConfig  `yaml:"oidc,omitempty"`
 
-	gate *g8.Gate
+	// TrustedProxies is a list of IPs or CIDRs of the reverse proxies whose X-Forwarded-For and X-Real-IP headers
+	// should be used to determine the IP of the client
+	TrustedProxies []string `yaml:"trusted-proxies,omitempty"`
+
+	gate                 *g8.Gate
+	trustedProxyNetworks []*net.IPNet
 }
 
 // IsValid returns whether the security configuration is valid or not
 func (c *Config) IsValid() bool {
+	trustedProxyNetworks, err := parseTrustedProxies(c.TrustedProxies)
+	if err != nil {
+		return false
+	}
+	c.trustedProxyNetworks = trustedProxyNetworks
 	return (c.Basic != nil && c.Basic.isValid()) || (c.OIDC != nil && c.OIDC.isValid())
 }
 
@@ -75,6 +90,7 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 			}
 		}
 		router.Use(basicauth.New(basicauth.Config{
+			ContextUsername: localsKeyUsername,
 			Authorizer: func(username, password string) bool {
 				if len(c.Basic.PasswordBcryptHashBase64Encoded) > 0 {
 					if username != c.Basic.Username || bcrypt.CompareHashAndPassword(decodedBcryptHash, []byte(password)) != nil {
@@ -84,6 +100,9 @@ func (c *Config) ApplySecurityMiddleware(router fiber.Router) error {
 				return true
 			},
 			Unauthorized: func(ctx *fiber.Ctx) error {
+				if len(ctx.Get(fiber.HeaderAuthorization)) > 0 {
+					log.Printf("[security][ApplySecurityMiddleware] Failed basic authentication attempt from %s", c.ClientIP(ctx))
+				}
 				ctx.Set("WWW-Authenticate", "Basic")
 				return ctx.Status(401).SendString("Unauthorized")
 			},
@@ -108,3 +127,24 @@ func (c *Config) IsAuthenticated(ctx *fiber.Ctx) bool {
 	}
 	return false
 }
+
+// GetUsername returns the name of the user the request was authenticated as, which is either the username used for basic
+// authentication or the subject of the OIDC session.
+// If the request isn't authenticated, it will return an empty string.
+func (c *Config) GetUsername(ctx *fiber.Ctx) string {
+	if c.gate != nil {
+		request, err := adaptor.ConvertRequest(ctx, false)
+		if err != nil {
+			log.Printf("[GetUsername] Unexpected error converting request: %v", err)
+			return ""
+		}
+		if subject, hasSession := sessions.Get(c.gate.ExtractTokenFromRequest(request)); hasSession {
+			if username, ok := subject.(string); ok {
+				return username
+			}
+		}
+		return ""
+	}
+	username, _ := ctx.Locals(localsKeyUsername).(string)
+	return username
+}
@@ -1,6 +1,7 @@
 package security
 
 import (
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -97,6 +98,57 @@ func TestConfig_ApplySecurityMiddleware(t *testing.T) {
 	})
 }
 
+func TestConfig_GetUsername(t *testing.T) {
+	t.Run("basic", func(t *testing.T) {
+		c := &Config{Basic: &BasicConfig{
+			Username:                        "john.doe",
+			PasswordBcryptHashBase64Encoded: "JDJhJDA4JDFoRnpPY1hnaFl1OC9ISlFsa21VS09wOGlPU1ZOTDlHZG1qeTFvb3dIckRBUnlHUmNIRWlT",
+		}}
+		app := fiber.New()
+		if err := c.ApplySecurityMiddleware(app); err != nil {
+			t.Error("expected no error, got", err)
+		}
+		app.Get("/test", func(ctx *fiber.Ctx) error {
+			return ctx.SendString(c.GetUsername(ctx))
+		})
+		request := httptest.NewRequest("GET", "/test", http.NoBody)
+		request.SetBasicAuth("john.doe", "hunter2")
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if body, _ := io.ReadAll(response.Body); string(body) != "john.doe" {
+			t.Errorf("expected username to be john.doe, but was %s", body)
+		}
+	})
+	t.Run("oidc", func(t *testing.T) {
+		c := &Config{OIDC: &OIDCConfig{
+			IssuerURL:       "https://sso.gatus.io/",
+			RedirectURL:     "http://localhost:80/authorization-code/callback",
+			Scopes:          []string{"openid"},
+			AllowedSubjects: []string{"user1@example.com"},
+		}}
+		app := fiber.New()
+		if err := c.ApplySecurityMiddleware(app); err != nil {
+			t.Error("expected no error, got", err)
+		}
+		app.Get("/test", func(ctx *fiber.Ctx) error {
+			return ctx.SendString(c.GetUsername(ctx))
+		})
+		sessions.Set("TestConfig_GetUsername", "user1@example.com")
+		defer sessions.Delete("TestConfig_GetUsername")
+		request := httptest.NewRequest("GET", "/test", http.NoBody)
+		request.AddCookie(&http.Cookie{Name: cookieNameSession, Value: "TestConfig_GetUsername"})
+		response, err := app.Test(request)
+		if err != nil {
+			t.Fatal("expected no error, got", err)
+		}
+		if body, _ := io.ReadAll(response.Body); string(body) != "user1@example.com" {
+			t.Errorf("expected username to be user1@example.com, but was %s", body)
+		}
+	})
+}
+
 func TestConfig_RegisterHandlers(t *testing.T) {
 	c := &Config{}
 	app := fiber.New()
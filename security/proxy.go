@@ -0,0 +1,81 @@
+package security
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var (
+	// ErrInvalidTrustedProxy is the error returned when one of the trusted proxies is neither a valid IP nor a valid CIDR
+	ErrInvalidTrustedProxy = errors.New("trusted proxies must be valid IPs or CIDRs")
+)
+
+// parseTrustedProxies parses the trusted proxies into networks
+//
+// Plain IPs are treated as networks containing a single address.
+func parseTrustedProxies(trustedProxies []string) ([]*net.IPNet, error) {
+	var networks []*net.IPNet
+	for _, trustedProxy := range trustedProxies {
+		trustedProxy = strings.TrimSpace(trustedProxy)
+		if !strings.Contains(trustedProxy, "/") {
+			ip := net.ParseIP(trustedProxy)
+			if ip == nil {
+				return nil, ErrInvalidTrustedProxy
+			}
+			if ip.To4() != nil {
+				trustedProxy += "/32"
+			} else {
+				trustedProxy += "/128"
+			}
+		}
+		_, network, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return nil, ErrInvalidTrustedProxy
+		}
+		networks = append(networks, network)
+	}
+	return networks, nil
+}
+
+// isTrustedProxy returns whether the IP passed belongs to one of the trusted proxies
+func (c *Config) isTrustedProxy(ip net.IP) bool {
+	for _, network := range c.trustedProxyNetworks {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ClientIP returns the IP of the client that sent the request
+//
+// The X-Forwarded-For and X-Real-IP headers are only honored if the request was sent by a trusted proxy, otherwise,
+// anybody could spoof their IP by setting these headers themselves.
+func (c *Config) ClientIP(ctx *fiber.Ctx) string {
+	peer := ctx.Context().RemoteIP()
+	if c == nil || !c.isTrustedProxy(peer) {
+		return peer.String()
+	}
+	if forwardedFor := ctx.Get(fiber.HeaderXForwardedFor); len(forwardedFor) > 0 {
+		// Each proxy appends the IP of its own peer, so we walk the list from right to left and return the first IP
+		// that isn't a trusted proxy
+		ips := strings.Split(forwardedFor, ",")
+		for i := len(ips) - 1; i >= 0; i-- {
+			ip := net.ParseIP(strings.TrimSpace(ips[i]))
+			if ip == nil {
+				// If the header was tampered with, we can't trust anything to the left of this entry
+				break
+			}
+			if i == 0 || !c.isTrustedProxy(ip) {
+				return ip.String()
+			}
+		}
+	}
+	if ip := net.ParseIP(strings.TrimSpace(ctx.Get("X-Real-IP"))); ip != nil {
+		return ip.String()
+	}
+	return peer.String()
+}
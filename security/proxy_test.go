@@ -0,0 +1,123 @@
+package security
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestParseTrustedProxies(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		trustedProxies   []string
+		expectedNetworks int
+		expectedErr      error
+	}{
+		{name: "none", trustedProxies: nil, expectedNetworks: 0},
+		{name: "cidrs", trustedProxies: []string{"10.0.0.0/8", "fd00::/8"}, expectedNetworks: 2},
+		{name: "ips", trustedProxies: []string{"127.0.0.1", "::1"}, expectedNetworks: 2},
+		{name: "invalid-ip", trustedProxies: []string{"10.0.0.300"}, expectedErr: ErrInvalidTrustedProxy},
+		{name: "invalid-cidr", trustedProxies: []string{"10.0.0.0/33"}, expectedErr: ErrInvalidTrustedProxy},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			networks, err := parseTrustedProxies(scenario.trustedProxies)
+			if err != scenario.expectedErr {
+				t.Fatalf("expected error %v, got %v", scenario.expectedErr, err)
+			}
+			if len(networks) != scenario.expectedNetworks {
+				t.Errorf("expected %d networks, got %d", scenario.expectedNetworks, len(networks))
+			}
+		})
+	}
+}
+
+func TestConfig_IsValidWithInvalidTrustedProxies(t *testing.T) {
+	c := &Config{Basic: &BasicConfig{Username: "john.doe", PasswordBcryptHashBase64Encoded: "hash"}, TrustedProxies: []string{"invalid"}}
+	if c.IsValid() {
+		t.Error("expected config with invalid trusted proxies to be invalid")
+	}
+}
+
+func TestConfig_ClientIP(t *testing.T) {
+	// Requests sent through app.Test always come from 0.0.0.0
+	scenarios := []struct {
+		name       string
+		config     *Config
+		headers    map[string]string
+		expectedIP string
+	}{
+		{
+			name:       "nil-config",
+			config:     nil,
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:       "untrusted-peer",
+			config:     &Config{TrustedProxies: []string{"10.0.0.0/8"}},
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "1.2.3.4"},
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:       "trusted-peer-without-headers",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0"}},
+			expectedIP: "0.0.0.0",
+		},
+		{
+			name:       "trusted-peer-with-x-forwarded-for",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0"}},
+			headers:    map[string]string{"X-Forwarded-For": "1.2.3.4"},
+			expectedIP: "1.2.3.4",
+		},
+		{
+			name:       "trusted-peer-with-chained-x-forwarded-for",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0", "10.0.0.0/8"}},
+			headers:    map[string]string{"X-Forwarded-For": "6.6.6.6, 1.2.3.4, 10.0.0.2, 10.0.0.1"},
+			expectedIP: "1.2.3.4",
+		},
+		{
+			name:       "trusted-peer-with-only-trusted-x-forwarded-for",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0", "10.0.0.0/8"}},
+			headers:    map[string]string{"X-Forwarded-For": "10.0.0.2, 10.0.0.1"},
+			expectedIP: "10.0.0.2",
+		},
+		{
+			name:       "trusted-peer-with-x-real-ip",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0"}},
+			headers:    map[string]string{"X-Real-IP": "1.2.3.4"},
+			expectedIP: "1.2.3.4",
+		},
+		{
+			name:       "trusted-peer-with-invalid-headers",
+			config:     &Config{TrustedProxies: []string{"0.0.0.0"}},
+			headers:    map[string]string{"X-Forwarded-For": "not-an-ip", "X-Real-IP": "not-an-ip"},
+			expectedIP: "0.0.0.0",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if scenario.config != nil {
+				scenario.config.trustedProxyNetworks, _ = parseTrustedProxies(scenario.config.TrustedProxies)
+			}
+			var actualIP string
+			app := fiber.New()
+			app.Get("/test", func(ctx *fiber.Ctx) error {
+				actualIP = scenario.config.ClientIP(ctx)
+				return ctx.SendStatus(200)
+			})
+			request := httptest.NewRequest("GET", "/test", http.NoBody)
+			for name, value := range scenario.headers {
+				request.Header.Set(name, value)
+			}
+			if _, err := app.Test(request); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if actualIP != scenario.expectedIP {
+				t.Errorf("expected %s, got %s", scenario.expectedIP, actualIP)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package store
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+const (
+	// BackupFormatVersion is the version of the format of the backups created by Backup.
+	// It must be incremented whenever a change that is not backward compatible is made to the format.
+	BackupFormatVersion = 1
+)
+
+var (
+	// ErrUnsupportedBackupVersion is the error returned when restoring a backup whose format version is not supported
+	ErrUnsupportedBackupVersion = errors.New("unsupported backup format version")
+
+	// ErrRestoreIntoNonEmptyStore is the error returned when attempting to restore a backup into a store that already
+	// contains endpoint statuses
+	ErrRestoreIntoNonEmptyStore = errors.New("cannot restore a backup into a store that already contains endpoint statuses")
+)
+
+// backup is the backend-agnostic representation of the content of a Store
+type backup struct {
+	Version               int                     `json:"version"`
+	CreatedAt             time.Time               `json:"createdAt"`
+	AlertingSilencedUntil *time.Time              `json:"alertingSilencedUntil,omitempty"`
+	Limits                *common.Limits          `json:"limits,omitempty"`
+	EndpointStatuses      []*backupEndpointStatus `json:"endpointStatuses"`
+}
+
+type backupEndpointStatus struct {
+	Name        string             `json:"name"`
+	Group       string             `json:"group,omitempty"`
+	Key         string             `json:"key"`
+	Results     []*backupResult    `json:"results"`
+	Events      []*core.Event      `json:"events,omitempty"`
+	Annotations []*core.Annotation `json:"annotations,omitempty"`
+
+	// HourlyUptimeStatistics are the uptime statistics of the endpoint for each hour (key), which are kept for longer
+	// than results and therefore cannot be rebuilt from them
+	HourlyUptimeStatistics map[int64]*backupHourlyUptimeStatistics `json:"hourlyUptimeStatistics,omitempty"`
+}
+
+// backupHourlyUptimeStatistics is a core.HourlyUptimeStatistics, which has no JSON representation of its own
+type backupHourlyUptimeStatistics struct {
+	TotalExecutions             uint64   `json:"totalExecutions"`
+	SuccessfulExecutions        uint64   `json:"successfulExecutions"`
+	TotalExecutionsResponseTime uint64   `json:"totalExecutionsResponseTime"`
+	ResponseTimeHistogram       []uint64 `json:"responseTimeHistogram,omitempty"`
+}
+
+// backupResult is a core.Result, along with the persisted fields that core.Result omits when encoded to JSON
+type backupResult struct {
+	*core.Result
+	DNSRCode              string        `json:"dnsRCode,omitempty"`
+	IP                    string        `json:"ip,omitempty"`
+	Connected             bool          `json:"connected"`
+	CertificateExpiration time.Duration `json:"certificateExpiration,omitempty"`
+	DomainExpiration      time.Duration `json:"domainExpiration,omitempty"`
+}
+
+// Backup writes the gzipped JSON encoding of the endpoint statuses, results, events, annotations, hourly uptime
+// statistics, alerting silence and limits of a Store to the writer passed
+//
+// Maintenance windows aren't part of the backup, because they're defined in the configuration rather than persisted.
+//
+// Because the format doesn't depend on the type of the Store, a backup can be restored into a Store of another type.
+func Backup(s Store, w io.Writer) error {
+	limits, err := s.GetLimits()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve limits: %w", err)
+	}
+	endpointStatuses, err := s.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithResults(1, limits.MaximumNumberOfResults).WithEvents(1, limits.MaximumNumberOfEvents))
+	if err != nil {
+		return fmt.Errorf("failed to retrieve endpoint statuses: %w", err)
+	}
+	b := &backup{
+		Version:          BackupFormatVersion,
+		CreatedAt:        time.Now(),
+		Limits:           &limits,
+		EndpointStatuses: make([]*backupEndpointStatus, 0, len(endpointStatuses)),
+	}
+	silencedUntil, err := s.GetAlertingSilence()
+	if err != nil {
+		return fmt.Errorf("failed to retrieve alerting silence: %w", err)
+	}
+	if !silencedUntil.IsZero() {
+		b.AlertingSilencedUntil = &silencedUntil
+	}
+	for _, endpointStatus := range endpointStatuses {
+		status := &backupEndpointStatus{
+			Name:    endpointStatus.Name,
+			Group:   endpointStatus.Group,
+			Key:     endpointStatus.Key,
+			Results: make([]*backupResult, 0, len(endpointStatus.Results)),
+			Events:  endpointStatus.Events,
+		}
+		if status.Annotations, err = s.GetEndpointAnnotationsByKey(endpointStatus.Key, time.Time{}, time.Now()); err != nil {
+			return fmt.Errorf("failed to retrieve annotations of endpoint with key=%s: %w", endpointStatus.Key, err)
+		}
+		hourlyStatistics, err := s.GetHourlyUptimeStatisticsByKey(endpointStatus.Key, time.Time{}, time.Now())
+		if err != nil {
+			return fmt.Errorf("failed to retrieve uptime of endpoint with key=%s: %w", endpointStatus.Key, err)
+		}
+		status.HourlyUptimeStatistics = make(map[int64]*backupHourlyUptimeStatistics, len(hourlyStatistics))
+		for hourlyUnixTimestamp, hourlyStats := range hourlyStatistics {
+			status.HourlyUptimeStatistics[hourlyUnixTimestamp] = &backupHourlyUptimeStatistics{
+				TotalExecutions:             hourlyStats.TotalExecutions,
+				SuccessfulExecutions:        hourlyStats.SuccessfulExecutions,
+				TotalExecutionsResponseTime: hourlyStats.TotalExecutionsResponseTime,
+				ResponseTimeHistogram:       hourlyStats.ResponseTimeHistogram,
+			}
+		}
+		for _, result := range endpointStatus.Results {
+			status.Results = append(status.Results, &backupResult{
+				Result:                result,
+				DNSRCode:              result.DNSRCode,
+				IP:                    result.IP,
+				Connected:             result.Connected,
+				CertificateExpiration: result.CertificateExpiration,
+				DomainExpiration:      result.DomainExpiration,
+			})
+		}
+		b.EndpointStatuses = append(b.EndpointStatuses, status)
+	}
+	gzipWriter := gzip.NewWriter(w)
+	if err = json.NewEncoder(gzipWriter).Encode(b); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// Restore reads a backup created by Backup from the reader passed and inserts its content into a Store
+//
+// The results are inserted in chronological order, exactly as if they had just been observed. Since the events and the
+// uptime of each endpoint outlive its results, they are then replaced by those of the backup, if it has them.
+//
+// To prevent restored results from being mixed with existing ones, the Store must not contain any endpoint status.
+func Restore(s Store, r io.Reader) error {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer gzipReader.Close()
+	var b backup
+	if err = json.NewDecoder(gzipReader).Decode(&b); err != nil {
+		return fmt.Errorf("failed to decode backup: %w", err)
+	}
+	if b.Version != BackupFormatVersion {
+		return fmt.Errorf("%w: expected %d, got %d", ErrUnsupportedBackupVersion, BackupFormatVersion, b.Version)
+	}
+	existingEndpointStatuses, err := s.GetAllEndpointStatuses(paging.NewEndpointStatusParams())
+	if err != nil {
+		return fmt.Errorf("failed to retrieve endpoint statuses: %w", err)
+	}
+	if len(existingEndpointStatuses) > 0 {
+		return ErrRestoreIntoNonEmptyStore
+	}
+	// The limits must be restored first, otherwise the results in excess of the current limits would be pruned
+	if b.Limits != nil {
+		if err = s.SetLimits(*b.Limits); err != nil {
+			return fmt.Errorf("failed to restore limits: %w", err)
+		}
+	}
+	for _, status := range b.EndpointStatuses {
+		endpoint := &core.Endpoint{Name: status.Name, Group: status.Group}
+		for _, result := range status.Results {
+			if result.Result == nil {
+				continue
+			}
+			result.Result.DNSRCode = result.DNSRCode
+			result.Result.IP = result.IP
+			result.Result.Connected = result.Connected
+			result.Result.CertificateExpiration = result.CertificateExpiration
+			result.Result.DomainExpiration = result.DomainExpiration
+			if err = s.Insert(endpoint, result.Result); err != nil {
+				return fmt.Errorf("failed to insert result of endpoint with key=%s: %w", status.Key, err)
+			}
+		}
+		if len(status.Results) == 0 {
+			// Annotations, events and uptime can only be added to endpoints that have results
+			continue
+		}
+		var hourlyStatistics map[int64]*core.HourlyUptimeStatistics
+		if status.HourlyUptimeStatistics != nil {
+			hourlyStatistics = make(map[int64]*core.HourlyUptimeStatistics, len(status.HourlyUptimeStatistics))
+			for hourlyUnixTimestamp, hourlyStats := range status.HourlyUptimeStatistics {
+				hourlyStatistics[hourlyUnixTimestamp] = &core.HourlyUptimeStatistics{
+					TotalExecutions:             hourlyStats.TotalExecutions,
+					SuccessfulExecutions:        hourlyStats.SuccessfulExecutions,
+					TotalExecutionsResponseTime: hourlyStats.TotalExecutionsResponseTime,
+					ResponseTimeHistogram:       hourlyStats.ResponseTimeHistogram,
+				}
+			}
+		}
+		if err = s.RestoreEndpointHistory(status.Key, status.Events, hourlyStatistics); err != nil {
+			return fmt.Errorf("failed to restore events and uptime of endpoint with key=%s: %w", status.Key, err)
+		}
+		for _, annotation := range status.Annotations {
+			if err = s.InsertEndpointAnnotation(status.Key, annotation); err != nil {
+				return fmt.Errorf("failed to insert annotation of endpoint with key=%s: %w", status.Key, err)
+			}
+		}
+	}
+	if b.AlertingSilencedUntil != nil && time.Now().Before(*b.AlertingSilencedUntil) {
+		if err = s.SetAlertingSilence(*b.AlertingSilencedUntil); err != nil {
+			return fmt.Errorf("failed to restore alerting silence: %w", err)
+		}
+	}
+	return s.Save()
+}
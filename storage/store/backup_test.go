@@ -0,0 +1,177 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+	"github.com/TwiN/gatus/v5/storage/store/memory"
+	"github.com/TwiN/gatus/v5/storage/store/sql"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	sources := initStoresAndBaseScenarios(t, "TestBackupAndRestore-source")
+	defer cleanUp(sources)
+	for _, source := range sources {
+		t.Run(source.Name, func(t *testing.T) {
+			firstResult := testSuccessfulResult
+			firstResult.Timestamp = now.Add(-2 * time.Hour)
+			secondResult := testUnsuccessfulResult
+			secondResult.Timestamp = now.Add(-time.Hour)
+			secondResult.DNSRCode = "NOERROR"
+			_ = source.Store.Insert(&testEndpoint, &firstResult)
+			_ = source.Store.Insert(&testEndpoint, &secondResult)
+			silencedUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+			_ = source.Store.SetAlertingSilence(silencedUntil)
+			limits := common.Limits{MaximumNumberOfResults: 200, MaximumNumberOfEvents: 20}
+			_ = source.Store.SetLimits(limits)
+			buffer := &bytes.Buffer{}
+			if err := Backup(source.Store, buffer); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			// Restore the backup into a store of every type to make sure that the format is backend-agnostic
+			destinations := initStoresAndBaseScenarios(t, "TestBackupAndRestore-destination-"+source.Name)
+			defer cleanUp(destinations)
+			for _, destination := range destinations {
+				if err := Restore(destination.Store, bytes.NewReader(buffer.Bytes())); err != nil {
+					t.Fatalf("expected no error restoring into %s, got %s", destination.Name, err.Error())
+				}
+				endpointStatus, err := destination.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
+				if err != nil {
+					t.Fatalf("expected no error retrieving restored endpoint status from %s, got %s", destination.Name, err.Error())
+				}
+				if endpointStatus.Name != testEndpoint.Name || endpointStatus.Group != testEndpoint.Group {
+					t.Errorf("expected endpoint %s in group %s, got %s in group %s", testEndpoint.Name, testEndpoint.Group, endpointStatus.Name, endpointStatus.Group)
+				}
+				if len(endpointStatus.Results) != 2 {
+					t.Fatalf("expected 2 results in %s, got %d", destination.Name, len(endpointStatus.Results))
+				}
+				if !endpointStatus.Results[0].Success || endpointStatus.Results[1].Success {
+					t.Error("expected results to have been restored in chronological order")
+				}
+				if !endpointStatus.Results[0].Timestamp.Equal(firstResult.Timestamp) {
+					t.Errorf("expected timestamp %s, got %s", firstResult.Timestamp, endpointStatus.Results[0].Timestamp)
+				}
+				if endpointStatus.Results[1].DNSRCode != "NOERROR" || endpointStatus.Results[1].IP != "127.0.0.1" || !endpointStatus.Results[1].Connected || endpointStatus.Results[1].CertificateExpiration != 10*time.Hour {
+					t.Errorf("expected fields omitted from the JSON encoding of results to have been restored, got %+v", endpointStatus.Results[1])
+				}
+				if len(endpointStatus.Results[1].Errors) != 2 || len(endpointStatus.Results[1].ConditionResults) != 3 {
+					t.Error("expected errors and condition results to have been restored")
+				}
+				// START, HEALTHY (first result) and UNHEALTHY (second result)
+				if len(endpointStatus.Events) != 3 {
+					t.Errorf("expected 3 events in %s, got %d", destination.Name, len(endpointStatus.Events))
+				}
+				if uptime, _ := destination.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-3*time.Hour), now); uptime != 0.5 {
+					t.Errorf("expected uptime to have been rebuilt from the restored results, got %f", uptime)
+				}
+				if restoredSilencedUntil, _ := destination.Store.GetAlertingSilence(); !restoredSilencedUntil.Equal(silencedUntil) {
+					t.Errorf("expected alerting to be silenced until %s, got %s", silencedUntil, restoredSilencedUntil)
+				}
+				if restoredLimits, _ := destination.Store.GetLimits(); restoredLimits != limits {
+					t.Errorf("expected limits %+v, got %+v", limits, restoredLimits)
+				}
+			}
+		})
+	}
+}
+
+func TestBackupAndRestoreWithHistoryOlderThanResults(t *testing.T) {
+	sources := initStoresAndBaseScenarios(t, "TestBackupAndRestoreWithHistoryOlderThanResults-source")
+	defer cleanUp(sources)
+	for _, source := range sources {
+		t.Run(source.Name, func(t *testing.T) {
+			// Only keep a single result, so that the events and the uptime of the endpoint cannot be rebuilt from the
+			// results in the backup
+			_ = source.Store.SetLimits(common.Limits{MaximumNumberOfResults: 1, MaximumNumberOfEvents: 50})
+			for i := 15; i > 0; i-- {
+				result := testSuccessfulResult
+				if i%3 == 0 {
+					result = testUnsuccessfulResult
+				}
+				result.Timestamp = now.Add(-time.Duration(i) * time.Hour)
+				_ = source.Store.Insert(&testEndpoint, &result)
+			}
+			sourceStatus, _ := source.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithEvents(1, 50))
+			sourceUptime, _ := source.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+			sourceHourlyStatistics, _ := source.Store.GetHourlyUptimeStatisticsByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now)
+			if len(sourceHourlyStatistics) != 15 {
+				t.Fatalf("expected 15 hours of uptime statistics in the source, got %d", len(sourceHourlyStatistics))
+			}
+			buffer := &bytes.Buffer{}
+			if err := Backup(source.Store, buffer); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			destinations := initStoresAndBaseScenarios(t, "TestBackupAndRestoreWithHistoryOlderThanResults-destination-"+source.Name)
+			defer cleanUp(destinations)
+			for _, destination := range destinations {
+				if err := Restore(destination.Store, bytes.NewReader(buffer.Bytes())); err != nil {
+					t.Fatalf("expected no error restoring into %s, got %s", destination.Name, err.Error())
+				}
+				endpointStatus, err := destination.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithEvents(1, 50))
+				if err != nil {
+					t.Fatalf("expected no error retrieving restored endpoint status from %s, got %s", destination.Name, err.Error())
+				}
+				if len(endpointStatus.Events) != len(sourceStatus.Events) {
+					t.Fatalf("expected %d events in %s, got %d", len(sourceStatus.Events), destination.Name, len(endpointStatus.Events))
+				}
+				for i, event := range endpointStatus.Events {
+					if event.Type != sourceStatus.Events[i].Type || !event.Timestamp.Equal(sourceStatus.Events[i].Timestamp) {
+						t.Errorf("expected event %s at %s in %s, got %s at %s", sourceStatus.Events[i].Type, sourceStatus.Events[i].Timestamp, destination.Name, event.Type, event.Timestamp)
+					}
+				}
+				if uptime, _ := destination.Store.GetUptimeByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now); uptime != sourceUptime {
+					t.Errorf("expected uptime %f in %s, got %f", sourceUptime, destination.Name, uptime)
+				}
+				if hourlyStatistics, _ := destination.Store.GetHourlyUptimeStatisticsByKey(testEndpoint.Key(), now.Add(-24*time.Hour), now); !reflect.DeepEqual(hourlyStatistics, sourceHourlyStatistics) {
+					t.Errorf("expected the hourly uptime statistics of %s to be those of the source", destination.Name)
+				}
+			}
+		})
+	}
+}
+
+func TestRestoreIntoNonEmptyStore(t *testing.T) {
+	source, _ := memory.NewStore()
+	defer source.Close()
+	_ = source.Insert(&testEndpoint, &testSuccessfulResult)
+	buffer := &bytes.Buffer{}
+	if err := Backup(source, buffer); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	destination, err := sql.NewStore("sqlite", t.TempDir()+"/TestRestoreIntoNonEmptyStore.db", false)
+	if err != nil {
+		t.Fatal("failed to create store:", err.Error())
+	}
+	defer destination.Close()
+	_ = destination.Insert(&core.Endpoint{Name: "other"}, &testSuccessfulResult)
+	if err = Restore(destination, buffer); !errors.Is(err, ErrRestoreIntoNonEmptyStore) {
+		t.Errorf("expected error %v, got %v", ErrRestoreIntoNonEmptyStore, err)
+	}
+}
+
+func TestRestoreWithUnsupportedVersion(t *testing.T) {
+	buffer := &bytes.Buffer{}
+	gzipWriter := gzip.NewWriter(buffer)
+	_, _ = gzipWriter.Write([]byte(`{"version":999,"endpointStatuses":[]}`))
+	_ = gzipWriter.Close()
+	destination, _ := memory.NewStore()
+	defer destination.Close()
+	if err := Restore(destination, buffer); !errors.Is(err, ErrUnsupportedBackupVersion) {
+		t.Errorf("expected error %v, got %v", ErrUnsupportedBackupVersion, err)
+	}
+}
+
+func TestRestoreWithInvalidBackup(t *testing.T) {
+	destination, _ := memory.NewStore()
+	defer destination.Close()
+	if err := Restore(destination, bytes.NewBufferString("not a backup")); err == nil {
+		t.Error("expected error, got none")
+	}
+}
@@ -1,8 +1,14 @@
 package common
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	ErrEndpointNotFound = errors.New("endpoint not found")               // When an endpoint does not exist in the store
 	ErrInvalidTimeRange = errors.New("'from' cannot be older than 'to'") // When an invalid time range is provided
+
+	// ErrInvalidLimits is the error returned when limits out of bounds are provided
+	ErrInvalidLimits = fmt.Errorf("the maximum number of results must be between 1 and %d, and the maximum number of events between 1 and %d", UpperBoundOfMaximumNumberOfResults, UpperBoundOfMaximumNumberOfEvents)
 )
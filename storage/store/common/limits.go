@@ -1,9 +1,39 @@
 package common
 
 const (
-	// MaximumNumberOfResults is the maximum number of results that an endpoint can have
+	// MaximumNumberOfResults is the default maximum number of results that an endpoint can have
 	MaximumNumberOfResults = 100
 
-	// MaximumNumberOfEvents is the maximum number of events that an endpoint can have
+	// MaximumNumberOfEvents is the default maximum number of events that an endpoint can have
 	MaximumNumberOfEvents = 50
+
+	// UpperBoundOfMaximumNumberOfResults is the highest value the maximum number of results can be set to, which
+	// prevents the store from growing without bounds
+	UpperBoundOfMaximumNumberOfResults = 10000
+
+	// UpperBoundOfMaximumNumberOfEvents is the highest value the maximum number of events can be set to, which
+	// prevents the store from growing without bounds
+	UpperBoundOfMaximumNumberOfEvents = 1000
 )
+
+// Limits are the maximum number of results and events that a store keeps for each endpoint
+type Limits struct {
+	MaximumNumberOfResults int `json:"maximumNumberOfResults"`
+	MaximumNumberOfEvents  int `json:"maximumNumberOfEvents"`
+}
+
+// DefaultLimits returns the limits a store starts with, unless other limits were persisted
+func DefaultLimits() Limits {
+	return Limits{MaximumNumberOfResults: MaximumNumberOfResults, MaximumNumberOfEvents: MaximumNumberOfEvents}
+}
+
+// Validate returns ErrInvalidLimits if either limit is lower than 1 or higher than its upper bound
+func (l Limits) Validate() error {
+	if l.MaximumNumberOfResults < 1 || l.MaximumNumberOfResults > UpperBoundOfMaximumNumberOfResults {
+		return ErrInvalidLimits
+	}
+	if l.MaximumNumberOfEvents < 1 || l.MaximumNumberOfEvents > UpperBoundOfMaximumNumberOfEvents {
+		return ErrInvalidLimits
+	}
+	return nil
+}
@@ -6,6 +6,12 @@ type EndpointStatusParams struct {
 	EventsPageSize  int // Size of the event page
 	ResultsPage     int // Number of the result page
 	ResultsPageSize int // Size of the result page
+
+	// The following parameters are only used when retrieving multiple endpoint statuses
+
+	Group             string // Group that the endpoints must be part of. If empty, endpoints of every group are returned.
+	EndpointsPage     int    // Number of the endpoint page
+	EndpointsPageSize int    // Size of the endpoint page. If 0, every endpoint is returned.
 }
 
 // NewEndpointStatusParams creates a new EndpointStatusParams
@@ -26,3 +32,27 @@ func (params *EndpointStatusParams) WithResults(page, pageSize int) *EndpointSta
 	params.ResultsPageSize = pageSize
 	return params
 }
+
+// WithGroup sets the value for Group
+func (params *EndpointStatusParams) WithGroup(group string) *EndpointStatusParams {
+	params.Group = group
+	return params
+}
+
+// WithEndpoints sets the values for EndpointsPage and EndpointsPageSize
+func (params *EndpointStatusParams) WithEndpoints(page, pageSize int) *EndpointStatusParams {
+	params.EndpointsPage = page
+	params.EndpointsPageSize = pageSize
+	return params
+}
+
+// GetEndpointsOffsetAndLimit returns the index of the first endpoint of the endpoint page and the maximum number of
+// endpoints in it
+//
+// If no endpoint page is set, the limit returned is -1.
+func (params *EndpointStatusParams) GetEndpointsOffsetAndLimit() (offset, limit int) {
+	if params.EndpointsPage < 1 || params.EndpointsPageSize < 1 {
+		return 0, -1
+	}
+	return (params.EndpointsPage - 1) * params.EndpointsPageSize, params.EndpointsPageSize
+}
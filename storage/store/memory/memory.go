@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"context"
 	"sort"
 	"sync"
 	"time"
@@ -17,6 +18,10 @@ type Store struct {
 	sync.RWMutex
 
 	cache *gocache.Cache
+
+	alertingSilencedUntil time.Time
+
+	limits common.Limits
 }
 
 // NewStore creates a new store using gocache.Cache
@@ -25,7 +30,8 @@ type Store struct {
 // supports eventual persistence.
 func NewStore() (*Store, error) {
 	store := &Store{
-		cache: gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		cache:  gocache.NewCache().WithMaxSize(gocache.NoMaxSize),
+		limits: common.DefaultLimits(),
 	}
 	return store, nil
 }
@@ -33,14 +39,35 @@ func NewStore() (*Store, error) {
 // GetAllEndpointStatuses returns all monitored core.EndpointStatus
 // with a subset of core.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*core.EndpointStatus, error) {
+	if params == nil {
+		params = paging.NewEndpointStatusParams()
+	}
 	endpointStatuses := s.cache.GetAll()
-	pagedEndpointStatuses := make([]*core.EndpointStatus, 0, len(endpointStatuses))
+	filteredEndpointStatuses := make([]*core.EndpointStatus, 0, len(endpointStatuses))
 	for _, v := range endpointStatuses {
-		pagedEndpointStatuses = append(pagedEndpointStatuses, ShallowCopyEndpointStatus(v.(*core.EndpointStatus), params))
+		endpointStatus := v.(*core.EndpointStatus)
+		if len(params.Group) > 0 && endpointStatus.Group != params.Group {
+			continue
+		}
+		filteredEndpointStatuses = append(filteredEndpointStatuses, endpointStatus)
 	}
-	sort.Slice(pagedEndpointStatuses, func(i, j int) bool {
-		return pagedEndpointStatuses[i].Key < pagedEndpointStatuses[j].Key
+	sort.Slice(filteredEndpointStatuses, func(i, j int) bool {
+		return filteredEndpointStatuses[i].Key < filteredEndpointStatuses[j].Key
 	})
+	if offset, limit := params.GetEndpointsOffsetAndLimit(); limit >= 0 {
+		if offset > len(filteredEndpointStatuses) {
+			offset = len(filteredEndpointStatuses)
+		}
+		if offset+limit < len(filteredEndpointStatuses) {
+			filteredEndpointStatuses = filteredEndpointStatuses[offset : offset+limit]
+		} else {
+			filteredEndpointStatuses = filteredEndpointStatuses[offset:]
+		}
+	}
+	pagedEndpointStatuses := make([]*core.EndpointStatus, 0, len(filteredEndpointStatuses))
+	for _, endpointStatus := range filteredEndpointStatuses {
+		pagedEndpointStatuses = append(pagedEndpointStatuses, ShallowCopyEndpointStatus(endpointStatus, params))
+	}
 	return pagedEndpointStatuses, nil
 }
 
@@ -87,6 +114,60 @@ func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error)
 	return float64(successfulExecutions) / float64(totalExecutions), nil
 }
 
+// GetEndpointEventsByKey returns the events of an endpoint that happened during a time range
+func (s *Store) GetEndpointEventsByKey(key string, from, to time.Time, page, pageSize int) ([]*core.Event, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	var events []*core.Event
+	for _, event := range endpointStatus.(*core.EndpointStatus).Events {
+		if !event.Timestamp.Before(from) && !event.Timestamp.After(to) {
+			events = append(events, event)
+		}
+	}
+	start, end := getStartAndEndIndex(len(events), page, pageSize)
+	if start < 0 || end < 0 {
+		return []*core.Event{}, nil
+	}
+	return events[start:end], nil
+}
+
+// GetEndpointAnnotationsByKey returns the annotations of an endpoint that were created during a time range
+func (s *Store) GetEndpointAnnotationsByKey(key string, from, to time.Time) ([]*core.Annotation, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	s.RLock()
+	defer s.RUnlock()
+	annotations := make([]*core.Annotation, 0)
+	for _, annotation := range endpointStatus.(*core.EndpointStatus).Annotations {
+		if !annotation.Timestamp.Before(from) && !annotation.Timestamp.After(to) {
+			annotations = append(annotations, annotation)
+		}
+	}
+	return annotations, nil
+}
+
+// InsertEndpointAnnotation adds an annotation to the endpoint with the specified key
+func (s *Store) InsertEndpointAnnotation(key string, annotation *core.Annotation) error {
+	s.Lock()
+	defer s.Unlock()
+	status, exists := s.cache.Get(key)
+	if !exists {
+		return common.ErrEndpointNotFound
+	}
+	status.(*core.EndpointStatus).Annotations = append(status.(*core.EndpointStatus).Annotations, annotation)
+	return nil
+}
+
 // GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
 func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error) {
 	if from.After(to) {
@@ -139,6 +220,62 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetResponseTimeHistogramByKey returns the histogram of the response times of the checks performed during a time range
+func (s *Store) GetResponseTimeHistogramByKey(key string, from, to time.Time) (*core.ResponseTimeHistogram, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*core.EndpointStatus).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	s.RLock()
+	defer s.RUnlock()
+	histogram := core.NewResponseTimeHistogram()
+	if to.Sub(from) > sevenDays {
+		// Hourly statistics aren't retained for long enough, so the daily histograms are used instead
+		current := from.Truncate(24 * time.Hour)
+		for to.Sub(current) >= 0 {
+			histogram.Merge(endpointStatus.(*core.EndpointStatus).Uptime.DailyResponseTimeHistograms[current.Unix()])
+			current = current.Add(24 * time.Hour)
+		}
+		return histogram, nil
+	}
+	current := from
+	for to.Sub(current) >= 0 {
+		hourlyUnixTimestamp := current.Truncate(time.Hour).Unix()
+		hourlyStats := endpointStatus.(*core.EndpointStatus).Uptime.HourlyStatistics[hourlyUnixTimestamp]
+		if hourlyStats != nil {
+			histogram.Merge(hourlyStats.ResponseTimeHistogram)
+		}
+		current = current.Add(time.Hour)
+	}
+	return histogram, nil
+}
+
+// GetHourlyUptimeStatisticsByKey returns the uptime statistics of an endpoint for each hour (key) during a time range
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*core.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	endpointStatus := s.cache.GetValue(key)
+	if endpointStatus == nil || endpointStatus.(*core.EndpointStatus).Uptime == nil {
+		return nil, common.ErrEndpointNotFound
+	}
+	s.RLock()
+	defer s.RUnlock()
+	hourlyStatistics := make(map[int64]*core.HourlyUptimeStatistics)
+	for hourlyUnixTimestamp, hourlyStats := range endpointStatus.(*core.EndpointStatus).Uptime.HourlyStatistics {
+		if hourlyUnixTimestamp < from.Unix() || hourlyUnixTimestamp > to.Unix() {
+			continue
+		}
+		hourlyStatsCopy := *hourlyStats
+		hourlyStatsCopy.ResponseTimeHistogram = append([]uint64(nil), hourlyStats.ResponseTimeHistogram...)
+		hourlyStatistics[hourlyUnixTimestamp] = &hourlyStatsCopy
+	}
+	return hourlyStatistics, nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 	key := endpoint.Key()
@@ -151,12 +288,87 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 			Timestamp: time.Now(),
 		})
 	}
-	AddResult(status.(*core.EndpointStatus), result)
+	AddResult(status.(*core.EndpointStatus), result, s.limits)
 	s.cache.Set(key, status)
 	s.Unlock()
 	return nil
 }
 
+// RestoreEndpointHistory replaces the events and the hourly uptime statistics of the endpoint with the specified key
+func (s *Store) RestoreEndpointHistory(key string, events []*core.Event, hourlyStatistics map[int64]*core.HourlyUptimeStatistics) error {
+	s.Lock()
+	defer s.Unlock()
+	status, exists := s.cache.Get(key)
+	if !exists {
+		return common.ErrEndpointNotFound
+	}
+	endpointStatus := status.(*core.EndpointStatus)
+	if events != nil {
+		endpointStatus.Events = append([]*core.Event(nil), events...)
+		if len(endpointStatus.Events) > s.limits.MaximumNumberOfEvents {
+			endpointStatus.Events = endpointStatus.Events[len(endpointStatus.Events)-s.limits.MaximumNumberOfEvents:]
+		}
+	}
+	if hourlyStatistics != nil {
+		endpointStatus.Uptime = core.NewUptime()
+		for hourlyUnixTimestamp, hourlyStats := range hourlyStatistics {
+			hourlyStatsCopy := *hourlyStats
+			hourlyStatsCopy.ResponseTimeHistogram = append([]uint64(nil), hourlyStats.ResponseTimeHistogram...)
+			endpointStatus.Uptime.HourlyStatistics[hourlyUnixTimestamp] = &hourlyStatsCopy
+			// The daily response time histograms are rebuilt from the hourly statistics, as they aren't backed up
+			if len(hourlyStats.ResponseTimeHistogram) > 0 {
+				unixTimestampFlooredAtDay := time.Unix(hourlyUnixTimestamp, 0).Truncate(24 * time.Hour).Unix()
+				dailyHistogram := endpointStatus.Uptime.DailyResponseTimeHistograms[unixTimestampFlooredAtDay]
+				if dailyHistogram == nil {
+					dailyHistogram = make([]uint64, len(core.ResponseTimeHistogramBuckets)+1)
+					endpointStatus.Uptime.DailyResponseTimeHistograms[unixTimestampFlooredAtDay] = dailyHistogram
+				}
+				for bucket, executions := range hourlyStats.ResponseTimeHistogram {
+					if bucket < len(dailyHistogram) {
+						dailyHistogram[bucket] += executions
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// GetAlertingSilence returns the time until which all alerts are silenced
+func (s *Store) GetAlertingSilence() (time.Time, error) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.alertingSilencedUntil, nil
+}
+
+// SetAlertingSilence silences all alerts until the time specified
+func (s *Store) SetAlertingSilence(until time.Time) error {
+	s.Lock()
+	s.alertingSilencedUntil = until
+	s.Unlock()
+	return nil
+}
+
+// GetLimits returns the maximum number of results and events kept for each endpoint
+func (s *Store) GetLimits() (common.Limits, error) {
+	s.RLock()
+	defer s.RUnlock()
+	return s.limits, nil
+}
+
+// SetLimits changes the maximum number of results and events kept for each endpoint
+//
+// Since this store isn't persistent, the limits only last until Gatus is restarted.
+func (s *Store) SetLimits(limits common.Limits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	s.Lock()
+	s.limits = limits
+	s.Unlock()
+	return nil
+}
+
 // DeleteAllEndpointStatusesNotInKeys removes all EndpointStatus that are not within the keys provided
 func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 	var keysToDelete []string
@@ -178,6 +390,10 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	s.cache.Clear()
+	s.Lock()
+	s.alertingSilencedUntil = time.Time{}
+	s.limits = common.DefaultLimits()
+	s.Unlock()
 }
 
 // Save persists the cache to the store file
@@ -185,6 +401,11 @@ func (s *Store) Save() error {
 	return nil
 }
 
+// Ping does nothing, because the store is always reachable
+func (s *Store) Ping(_ context.Context) error {
+	return nil
+}
+
 // Close does nothing, because there's nothing to close
 func (s *Store) Close() {
 	return
@@ -9,6 +9,9 @@ import (
 const (
 	numberOfHoursInTenDays = 10 * 24
 	sevenDays              = 7 * 24 * time.Hour
+
+	numberOfDaysInThirtyThreeDays = 33
+	thirtyDays                    = 30 * 24 * time.Hour
 )
 
 // processUptimeAfterResult processes the result by extracting the relevant from the result and recalculating the uptime
@@ -28,6 +31,12 @@ func processUptimeAfterResult(uptime *core.Uptime, result *core.Result) {
 	}
 	hourlyStats.TotalExecutions++
 	hourlyStats.TotalExecutionsResponseTime += uint64(result.Duration.Milliseconds())
+	if len(hourlyStats.ResponseTimeHistogram) != len(core.ResponseTimeHistogramBuckets)+1 {
+		// Statistics recorded before response time histograms were introduced don't have one
+		hourlyStats.ResponseTimeHistogram = make([]uint64, len(core.ResponseTimeHistogramBuckets)+1)
+	}
+	hourlyStats.ResponseTimeHistogram[core.ResponseTimeHistogramBucket(result.Duration)]++
+	processDailyResponseTimeHistogramAfterResult(uptime, result)
 	// Clean up only when we're starting to have too many useless keys
 	// Note that this is only triggered when there are more entries than there should be after
 	// 10 days, despite the fact that we are deleting everything that's older than 7 days.
@@ -41,3 +50,28 @@ func processUptimeAfterResult(uptime *core.Uptime, result *core.Result) {
 		}
 	}
 }
+
+// processDailyResponseTimeHistogramAfterResult adds the response time of the result to the histogram of its day
+//
+// Unlike the hourly statistics, daily histograms are retained for 30 days.
+func processDailyResponseTimeHistogramAfterResult(uptime *core.Uptime, result *core.Result) {
+	if uptime.DailyResponseTimeHistograms == nil {
+		uptime.DailyResponseTimeHistograms = make(map[int64][]uint64)
+	}
+	unixTimestampFlooredAtDay := result.Timestamp.Truncate(24 * time.Hour).Unix()
+	dailyHistogram := uptime.DailyResponseTimeHistograms[unixTimestampFlooredAtDay]
+	if dailyHistogram == nil {
+		dailyHistogram = make([]uint64, len(core.ResponseTimeHistogramBuckets)+1)
+		uptime.DailyResponseTimeHistograms[unixTimestampFlooredAtDay] = dailyHistogram
+	}
+	dailyHistogram[core.ResponseTimeHistogramBucket(result.Duration)]++
+	// Like for the hourly statistics, clean up only when there are more entries than there should be after 33 days
+	if len(uptime.DailyResponseTimeHistograms) > numberOfDaysInThirtyThreeDays {
+		thirtyDaysAgo := time.Now().Add(-(thirtyDays + 24*time.Hour)).Unix()
+		for dailyUnixTimestamp := range uptime.DailyResponseTimeHistograms {
+			if thirtyDaysAgo > dailyUnixTimestamp {
+				delete(uptime.DailyResponseTimeHistograms, dailyUnixTimestamp)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 )
 
 func TestProcessUptimeAfterResult(t *testing.T) {
@@ -50,7 +51,7 @@ func TestAddResultUptimeIsCleaningUpAfterItself(t *testing.T) {
 	// Start 12 days ago
 	timestamp := now.Add(-12 * 24 * time.Hour)
 	for timestamp.Unix() <= now.Unix() {
-		AddResult(status, &core.Result{Timestamp: timestamp, Success: true})
+		AddResult(status, &core.Result{Timestamp: timestamp, Success: true}, common.DefaultLimits())
 		if len(status.Uptime.HourlyStatistics) > numberOfHoursInTenDays {
 			t.Errorf("At no point in time should there be more than %d entries in status.SuccessfulExecutionsPerHour, but there are %d", numberOfHoursInTenDays, len(status.Uptime.HourlyStatistics))
 		}
@@ -59,6 +60,30 @@ func TestAddResultUptimeIsCleaningUpAfterItself(t *testing.T) {
 	}
 }
 
+func TestAddResultDailyResponseTimeHistogramsAreCleaningUpAfterThemselves(t *testing.T) {
+	endpoint := &core.Endpoint{Name: "name", Group: "group"}
+	status := core.NewEndpointStatus(endpoint.Group, endpoint.Name)
+	now := time.Now()
+	now = time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+	// Start 40 days ago
+	timestamp := now.Add(-40 * 24 * time.Hour)
+	for timestamp.Unix() <= now.Unix() {
+		AddResult(status, &core.Result{Timestamp: timestamp, Success: true, Duration: 100 * time.Millisecond}, common.DefaultLimits())
+		if len(status.Uptime.DailyResponseTimeHistograms) > numberOfDaysInThirtyThreeDays {
+			t.Errorf("At no point in time should there be more than %d entries in status.Uptime.DailyResponseTimeHistograms, but there are %d", numberOfDaysInThirtyThreeDays, len(status.Uptime.DailyResponseTimeHistograms))
+		}
+		// Simulate endpoint with an interval of 1 hour
+		timestamp = timestamp.Add(time.Hour)
+	}
+	if len(status.Uptime.HourlyStatistics) > numberOfHoursInTenDays {
+		t.Errorf("expected the hourly statistics to still be retained for 7 days, but there are %d entries", len(status.Uptime.HourlyStatistics))
+	}
+	todayHistogram := status.Uptime.DailyResponseTimeHistograms[now.Truncate(24*time.Hour).Unix()]
+	if todayHistogram == nil || todayHistogram[core.ResponseTimeHistogramBucket(100*time.Millisecond)] == 0 {
+		t.Error("expected the histogram of today to have the response times of the results of today")
+	}
+}
+
 func checkHourlyStatistics(t *testing.T, hourlyUptimeStatistics *core.HourlyUptimeStatistics, expectedTotalExecutionsResponseTime uint64, expectedTotalExecutions uint64, expectedSuccessfulExecutions uint64) {
 	if hourlyUptimeStatistics.TotalExecutionsResponseTime != expectedTotalExecutionsResponseTime {
 		t.Error("TotalExecutionsResponseTime should've been", expectedTotalExecutionsResponseTime, "got", hourlyUptimeStatistics.TotalExecutionsResponseTime)
@@ -29,6 +29,15 @@ func ShallowCopyEndpointStatus(ss *core.EndpointStatus, params *paging.EndpointS
 	} else {
 		shallowCopy.Events = ss.Events[eventsStart:eventsEnd]
 	}
+	if len(shallowCopy.Events) > 0 {
+		// Only the annotations that fall within the events returned are relevant
+		oldestEventTimestamp := shallowCopy.Events[0].Timestamp
+		for _, annotation := range ss.Annotations {
+			if !annotation.Timestamp.Before(oldestEventTimestamp) {
+				shallowCopy.Annotations = append(shallowCopy.Annotations, annotation)
+			}
+		}
+	}
 	return shallowCopy
 }
 
@@ -50,8 +59,9 @@ func getStartAndEndIndex(numberOfResults int, page, pageSize int) (int, int) {
 }
 
 // AddResult adds a Result to EndpointStatus.Results and makes sure that there are
-// no more than MaximumNumberOfResults results in the Results slice
-func AddResult(ss *core.EndpointStatus, result *core.Result) {
+// no more than limits.MaximumNumberOfResults results in the Results slice, and no more than
+// limits.MaximumNumberOfEvents events in the Events slice
+func AddResult(ss *core.EndpointStatus, result *core.Result, limits common.Limits) {
 	if ss == nil {
 		return
 	}
@@ -59,23 +69,23 @@ func AddResult(ss *core.EndpointStatus, result *core.Result) {
 		// Check if there's any change since the last result
 		if ss.Results[len(ss.Results)-1].Success != result.Success {
 			ss.Events = append(ss.Events, core.NewEventFromResult(result))
-			if len(ss.Events) > common.MaximumNumberOfEvents {
-				// Doing ss.Events[1:] would usually be sufficient, but in the case where for some reason, the slice has
-				// more than one extra element, we can get rid of all of them at once and thus returning the slice to a
-				// length of MaximumNumberOfEvents by using ss.Events[len(ss.Events)-MaximumNumberOfEvents:] instead
-				ss.Events = ss.Events[len(ss.Events)-common.MaximumNumberOfEvents:]
-			}
 		}
 	} else {
 		// This is the first result, so we need to add the first healthy/unhealthy event
 		ss.Events = append(ss.Events, core.NewEventFromResult(result))
 	}
+	if len(ss.Events) > limits.MaximumNumberOfEvents {
+		// Doing ss.Events[1:] would usually be sufficient, but if the limit was lowered, the slice has more than one
+		// extra element, so we get rid of all of them at once and thus return the slice to a length of
+		// MaximumNumberOfEvents by using ss.Events[len(ss.Events)-MaximumNumberOfEvents:] instead
+		ss.Events = ss.Events[len(ss.Events)-limits.MaximumNumberOfEvents:]
+	}
 	ss.Results = append(ss.Results, result)
-	if len(ss.Results) > common.MaximumNumberOfResults {
-		// Doing ss.Results[1:] would usually be sufficient, but in the case where for some reason, the slice has more
-		// than one extra element, we can get rid of all of them at once and thus returning the slice to a length of
+	if len(ss.Results) > limits.MaximumNumberOfResults {
+		// Doing ss.Results[1:] would usually be sufficient, but if the limit was lowered, the slice has more than one
+		// extra element, so we get rid of all of them at once and thus return the slice to a length of
 		// MaximumNumberOfResults by using ss.Results[len(ss.Results)-MaximumNumberOfResults:] instead
-		ss.Results = ss.Results[len(ss.Results)-common.MaximumNumberOfResults:]
+		ss.Results = ss.Results[len(ss.Results)-limits.MaximumNumberOfResults:]
 	}
 	processUptimeAfterResult(ss.Uptime, result)
 }
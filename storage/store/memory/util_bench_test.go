@@ -12,7 +12,7 @@ func BenchmarkShallowCopyEndpointStatus(b *testing.B) {
 	endpoint := &testEndpoint
 	status := core.NewEndpointStatus(endpoint.Group, endpoint.Name)
 	for i := 0; i < common.MaximumNumberOfResults; i++ {
-		AddResult(status, &testSuccessfulResult)
+		AddResult(status, &testSuccessfulResult, common.DefaultLimits())
 	}
 	for n := 0; n < b.N; n++ {
 		ShallowCopyEndpointStatus(status, paging.NewEndpointStatusParams().WithResults(1, 20))
@@ -13,7 +13,7 @@ func TestAddResult(t *testing.T) {
 	endpoint := &core.Endpoint{Name: "name", Group: "group"}
 	endpointStatus := core.NewEndpointStatus(endpoint.Group, endpoint.Name)
 	for i := 0; i < (common.MaximumNumberOfResults+common.MaximumNumberOfEvents)*2; i++ {
-		AddResult(endpointStatus, &core.Result{Success: i%2 == 0, Timestamp: time.Now()})
+		AddResult(endpointStatus, &core.Result{Success: i%2 == 0, Timestamp: time.Now()}, common.DefaultLimits())
 	}
 	if len(endpointStatus.Results) != common.MaximumNumberOfResults {
 		t.Errorf("expected endpointStatus.Results to not exceed a length of %d", common.MaximumNumberOfResults)
@@ -21,8 +21,16 @@ func TestAddResult(t *testing.T) {
 	if len(endpointStatus.Events) != common.MaximumNumberOfEvents {
 		t.Errorf("expected endpointStatus.Events to not exceed a length of %d", common.MaximumNumberOfEvents)
 	}
+	// Lowering the limits must prune the excess results and events at once
+	AddResult(endpointStatus, &core.Result{Success: true, Timestamp: time.Now()}, common.Limits{MaximumNumberOfResults: 10, MaximumNumberOfEvents: 5})
+	if len(endpointStatus.Results) != 10 {
+		t.Errorf("expected endpointStatus.Results to have been pruned to a length of 10, got %d", len(endpointStatus.Results))
+	}
+	if len(endpointStatus.Events) != 5 {
+		t.Errorf("expected endpointStatus.Events to have been pruned to a length of 5, got %d", len(endpointStatus.Events))
+	}
 	// Try to add nil endpointStatus
-	AddResult(nil, &core.Result{Timestamp: time.Now()})
+	AddResult(nil, &core.Result{Timestamp: time.Now()}, common.DefaultLimits())
 }
 
 func TestShallowCopyEndpointStatus(t *testing.T) {
@@ -30,7 +38,7 @@ func TestShallowCopyEndpointStatus(t *testing.T) {
 	endpointStatus := core.NewEndpointStatus(endpoint.Group, endpoint.Name)
 	ts := time.Now().Add(-25 * time.Hour)
 	for i := 0; i < 25; i++ {
-		AddResult(endpointStatus, &core.Result{Success: i%2 == 0, Timestamp: ts})
+		AddResult(endpointStatus, &core.Result{Success: i%2 == 0, Timestamp: ts}, common.DefaultLimits())
 		ts = ts.Add(time.Hour)
 	}
 	if len(ShallowCopyEndpointStatus(endpointStatus, paging.NewEndpointStatusParams().WithResults(-1, -1)).Results) != 0 {
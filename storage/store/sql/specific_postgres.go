@@ -15,10 +15,23 @@ func (s *Store) createPostgresSchema() error {
 	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_events (
-			endpoint_event_id  BIGSERIAL PRIMARY KEY,
-			endpoint_id        INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
-			event_type         TEXT      NOT NULL,
-			event_timestamp    TIMESTAMP NOT NULL
+			endpoint_event_id       BIGSERIAL PRIMARY KEY,
+			endpoint_id             INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			event_type              TEXT      NOT NULL,
+			event_timestamp         TIMESTAMP NOT NULL,
+			event_failed_conditions TEXT      NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_annotations (
+			endpoint_annotation_id  BIGSERIAL PRIMARY KEY,
+			endpoint_id             INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			annotation_timestamp    TIMESTAMP NOT NULL,
+			annotation_note         TEXT      NOT NULL,
+			annotation_author       TEXT      NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -38,7 +51,10 @@ func (s *Store) createPostgresSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               BIGINT    NOT NULL,
-			timestamp              TIMESTAMP NOT NULL
+			timestamp              TIMESTAMP NOT NULL,
+			expected_failure       BOOLEAN   NOT NULL DEFAULT FALSE,
+			unknown                BOOLEAN   NOT NULL DEFAULT FALSE,
+			agent                  TEXT      NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -55,6 +71,20 @@ func (s *Store) createPostgresSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_result_ips (
+			endpoint_result_ip_id  BIGSERIAL PRIMARY KEY,
+			endpoint_result_id     BIGINT  NOT NULL REFERENCES endpoint_results(endpoint_result_id) ON DELETE CASCADE,
+			ip                     TEXT    NOT NULL,
+			status                 INTEGER NOT NULL,
+			duration               BIGINT  NOT NULL,
+			errors                 TEXT    NOT NULL,
+			success                BOOLEAN NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_uptimes (
 			endpoint_uptime_id     BIGSERIAL PRIMARY KEY,
@@ -66,7 +96,56 @@ func (s *Store) createPostgresSchema() error {
 			UNIQUE(endpoint_id, hour_unix_timestamp)
 		)
 	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_response_time_buckets (
+			endpoint_response_time_bucket_id BIGSERIAL PRIMARY KEY,
+			endpoint_id                      BIGINT NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			hour_unix_timestamp              BIGINT NOT NULL,
+			bucket                           BIGINT NOT NULL,
+			executions                       BIGINT NOT NULL,
+			UNIQUE(endpoint_id, hour_unix_timestamp, bucket)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_response_time_daily_buckets (
+			endpoint_response_time_daily_bucket_id BIGSERIAL PRIMARY KEY,
+			endpoint_id                            BIGINT NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			day_unix_timestamp                     BIGINT NOT NULL,
+			bucket                                 BIGINT NOT NULL,
+			executions                             BIGINT NOT NULL,
+			UNIQUE(endpoint_id, day_unix_timestamp, bucket)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alerting_silence (
+			alerting_silence_id BIGSERIAL PRIMARY KEY,
+			silenced_until      TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS storage_limits (
+			storage_limits_id         BIGSERIAL PRIMARY KEY,
+			maximum_number_of_results INTEGER NOT NULL,
+			maximum_number_of_events  INTEGER NOT NULL
+		)
+	`)
 	// Silent table modifications
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS domain_expiration BIGINT NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_events ADD IF NOT EXISTS event_failed_conditions TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS expected_failure BOOLEAN NOT NULL DEFAULT FALSE`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS unknown BOOLEAN NOT NULL DEFAULT FALSE`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD IF NOT EXISTS agent TEXT NOT NULL DEFAULT ''`)
 	return err
 }
@@ -15,10 +15,23 @@ func (s *Store) createSQLiteSchema() error {
 	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_events (
-			endpoint_event_id  INTEGER PRIMARY KEY,
-			endpoint_id        INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
-			event_type         TEXT      NOT NULL,
-			event_timestamp    TIMESTAMP NOT NULL
+			endpoint_event_id       INTEGER PRIMARY KEY,
+			endpoint_id             INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			event_type              TEXT      NOT NULL,
+			event_timestamp         TIMESTAMP NOT NULL,
+			event_failed_conditions TEXT      NOT NULL DEFAULT ''
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_annotations (
+			endpoint_annotation_id  INTEGER PRIMARY KEY,
+			endpoint_id             INTEGER   NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			annotation_timestamp    TIMESTAMP NOT NULL,
+			annotation_note         TEXT      NOT NULL,
+			annotation_author       TEXT      NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -38,7 +51,10 @@ func (s *Store) createSQLiteSchema() error {
 			hostname               TEXT      NOT NULL,
 			ip                     TEXT      NOT NULL,
 			duration               INTEGER   NOT NULL,
-			timestamp              TIMESTAMP NOT NULL
+			timestamp              TIMESTAMP NOT NULL,
+			expected_failure       INTEGER   NOT NULL DEFAULT 0,
+			unknown                INTEGER   NOT NULL DEFAULT 0,
+			agent                  TEXT      NOT NULL DEFAULT ''
 		)
 	`)
 	if err != nil {
@@ -55,6 +71,20 @@ func (s *Store) createSQLiteSchema() error {
 	if err != nil {
 		return err
 	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_result_ips (
+			endpoint_result_ip_id  INTEGER PRIMARY KEY,
+			endpoint_result_id     INTEGER NOT NULL REFERENCES endpoint_results(endpoint_result_id) ON DELETE CASCADE,
+			ip                     TEXT    NOT NULL,
+			status                 INTEGER NOT NULL,
+			duration               INTEGER NOT NULL,
+			errors                 TEXT    NOT NULL,
+			success                INTEGER NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
 	_, err = s.db.Exec(`
 		CREATE TABLE IF NOT EXISTS endpoint_uptimes (
 			endpoint_uptime_id    INTEGER PRIMARY KEY,
@@ -66,7 +96,56 @@ func (s *Store) createSQLiteSchema() error {
 			UNIQUE(endpoint_id, hour_unix_timestamp)
 		)
 	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_response_time_buckets (
+			endpoint_response_time_bucket_id INTEGER PRIMARY KEY,
+			endpoint_id                      INTEGER NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			hour_unix_timestamp              INTEGER NOT NULL,
+			bucket                           INTEGER NOT NULL,
+			executions                       INTEGER NOT NULL,
+			UNIQUE(endpoint_id, hour_unix_timestamp, bucket)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS endpoint_response_time_daily_buckets (
+			endpoint_response_time_daily_bucket_id INTEGER PRIMARY KEY,
+			endpoint_id                            INTEGER NOT NULL REFERENCES endpoints(endpoint_id) ON DELETE CASCADE,
+			day_unix_timestamp                     INTEGER NOT NULL,
+			bucket                                 INTEGER NOT NULL,
+			executions                             INTEGER NOT NULL,
+			UNIQUE(endpoint_id, day_unix_timestamp, bucket)
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS alerting_silence (
+			alerting_silence_id INTEGER PRIMARY KEY,
+			silenced_until      TIMESTAMP NOT NULL
+		)
+	`)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`
+		CREATE TABLE IF NOT EXISTS storage_limits (
+			storage_limits_id         INTEGER PRIMARY KEY,
+			maximum_number_of_results INTEGER NOT NULL,
+			maximum_number_of_events  INTEGER NOT NULL
+		)
+	`)
 	// Silent table modifications TODO: Remove this
 	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD domain_expiration INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_events ADD event_failed_conditions TEXT NOT NULL DEFAULT ''`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD expected_failure INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD unknown INTEGER NOT NULL DEFAULT 0`)
+	_, _ = s.db.Exec(`ALTER TABLE endpoint_results ADD agent TEXT NOT NULL DEFAULT ''`)
 	return err
 }
@@ -1,12 +1,14 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/core"
@@ -28,12 +30,15 @@ const (
 	// for aesthetic purposes, I deemed it wasn't worth the performance impact of yet another one-to-many table.
 	arraySeparator = "|~|"
 
-	uptimeCleanUpThreshold  = 10 * 24 * time.Hour                // Maximum uptime age before triggering a clean up
-	eventsCleanUpThreshold  = common.MaximumNumberOfEvents + 10  // Maximum number of events before triggering a clean up
-	resultsCleanUpThreshold = common.MaximumNumberOfResults + 10 // Maximum number of results before triggering a clean up
+	uptimeCleanUpThreshold = 10 * 24 * time.Hour // Maximum uptime age before triggering a clean up
+	cleanUpThreshold       = 10                  // Number of events or results in excess of the limits before triggering a clean up
 
 	uptimeRetention = 7 * 24 * time.Hour
 
+	// responseTimeDailyRetention is how long the daily response time histograms are retained, which is longer than
+	// uptimeRetention so that the percentiles of the response time can be computed over 30 days
+	responseTimeDailyRetention = 30 * 24 * time.Hour
+
 	cacheTTL = 10 * time.Minute
 )
 
@@ -56,6 +61,11 @@ type Store struct {
 	// writeThroughCache is a cache used to drastically decrease read latency by pre-emptively
 	// caching writes as they happen. If nil, writes are not cached.
 	writeThroughCache *gocache.Cache
+
+	// limits are the maximum number of results and events kept for each endpoint, which are persisted in the
+	// storage_limits table and kept in memory so that they don't have to be queried on every insert
+	limits      common.Limits
+	limitsMutex sync.RWMutex
 }
 
 // NewStore initializes the database and creates the schema if it doesn't already exist in the path specified
@@ -86,6 +96,10 @@ func NewStore(driver, path string, caching bool) (*Store, error) {
 		_ = store.db.Close()
 		return nil, err
 	}
+	if err = store.loadLimits(); err != nil {
+		_ = store.db.Close()
+		return nil, err
+	}
 	if caching {
 		store.writeThroughCache = gocache.NewCache().WithMaxSize(10000)
 	}
@@ -100,14 +114,30 @@ func (s *Store) createSchema() error {
 	return s.createPostgresSchema()
 }
 
+// loadLimits loads the persisted limits, or the default limits if none were persisted
+func (s *Store) loadLimits() error {
+	limits := common.DefaultLimits()
+	err := s.db.QueryRow("SELECT maximum_number_of_results, maximum_number_of_events FROM storage_limits ORDER BY storage_limits_id DESC LIMIT 1").Scan(&limits.MaximumNumberOfResults, &limits.MaximumNumberOfEvents)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	s.limitsMutex.Lock()
+	s.limits = limits
+	s.limitsMutex.Unlock()
+	return nil
+}
+
 // GetAllEndpointStatuses returns all monitored core.EndpointStatus
 // with a subset of core.Result defined by the page and pageSize parameters
 func (s *Store) GetAllEndpointStatuses(params *paging.EndpointStatusParams) ([]*core.EndpointStatus, error) {
+	if params == nil {
+		params = paging.NewEndpointStatusParams()
+	}
 	tx, err := s.db.Begin()
 	if err != nil {
 		return nil, err
 	}
-	keys, err := s.getAllEndpointKeys(tx)
+	keys, err := s.getAllEndpointKeys(tx, params)
 	if err != nil {
 		_ = tx.Rollback()
 		return nil, err
@@ -173,6 +203,91 @@ func (s *Store) GetUptimeByKey(key string, from, to time.Time) (float64, error)
 	return uptime, nil
 }
 
+// GetEndpointEventsByKey returns the events of an endpoint that happened during a time range
+func (s *Store) GetEndpointEventsByKey(key string, from, to time.Time, page, pageSize int) ([]*core.Event, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	events, err := s.getEndpointEventsByEndpointIDAndTimeRange(tx, endpointID, from, to, page, pageSize)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return events, nil
+}
+
+// GetEndpointAnnotationsByKey returns the annotations of an endpoint that were created during a time range
+func (s *Store) GetEndpointAnnotationsByKey(key string, from, to time.Time) ([]*core.Annotation, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	annotations, err := s.getEndpointAnnotationsByEndpointIDAndTimeRange(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return annotations, nil
+}
+
+// InsertEndpointAnnotation adds an annotation to the endpoint with the specified key
+//
+// Annotations are stored in a table of their own, so that they aren't deleted along with old events and results.
+func (s *Store) InsertEndpointAnnotation(key string, annotation *core.Annotation) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec(
+		"INSERT INTO endpoint_annotations (endpoint_id, annotation_timestamp, annotation_note, annotation_author) VALUES ($1, $2, $3, $4)",
+		endpointID,
+		annotation.Timestamp.UTC(),
+		annotation.Note,
+		annotation.Author,
+	)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if s.writeThroughCache != nil {
+		// The cached endpoint statuses don't have the new annotation, so they're deleted rather than refreshed
+		_ = s.writeThroughCache.DeleteKeysByPattern(key + "*")
+	}
+	return nil
+}
+
 // GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
 func (s *Store) GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error) {
 	if from.After(to) {
@@ -223,8 +338,59 @@ func (s *Store) GetHourlyAverageResponseTimeByKey(key string, from, to time.Time
 	return hourlyAverageResponseTimes, nil
 }
 
+// GetResponseTimeHistogramByKey returns the histogram of the response times of the checks performed during a time range
+func (s *Store) GetResponseTimeHistogramByKey(key string, from, to time.Time) (*core.ResponseTimeHistogram, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	histogram, err := s.getEndpointResponseTimeHistogram(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return histogram, nil
+}
+
+// GetHourlyUptimeStatisticsByKey returns the uptime statistics of an endpoint for each hour (key) during a time range
+func (s *Store) GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*core.HourlyUptimeStatistics, error) {
+	if from.After(to) {
+		return nil, common.ErrInvalidTimeRange
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	hourlyStatistics, err := s.getEndpointHourlyUptimeStatistics(tx, endpointID, from, to)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+	}
+	return hourlyStatistics, nil
+}
+
 // Insert adds the observed result for the specified endpoint into the store
 func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
+	limits, _ := s.GetLimits()
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
@@ -291,11 +457,11 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 				}
 			}
 		}
-		// Clean up old events if there's more than twice the maximum number of events
+		// Clean up old events if there's more than the maximum number of events plus the clean up threshold
 		// This lets us both keep the table clean without impacting performance too much
-		// (since we're only deleting MaximumNumberOfEvents at a time instead of 1)
-		if numberOfEvents > eventsCleanUpThreshold {
-			if err = s.deleteOldEndpointEvents(tx, endpointID); err != nil {
+		// (since we're only deleting old events every cleanUpThreshold events instead of at every insert)
+		if numberOfEvents > int64(limits.MaximumNumberOfEvents+cleanUpThreshold) {
+			if err = s.deleteOldEndpointEvents(tx, endpointID, limits.MaximumNumberOfEvents); err != nil {
 				log.Printf("[sql][Insert] Failed to delete old events for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
 			}
 		}
@@ -311,8 +477,8 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 	if err != nil {
 		log.Printf("[sql][Insert] Failed to retrieve total number of results for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
 	} else {
-		if numberOfResults > resultsCleanUpThreshold {
-			if err = s.deleteOldEndpointResults(tx, endpointID); err != nil {
+		if numberOfResults > int64(limits.MaximumNumberOfResults+cleanUpThreshold) {
+			if err = s.deleteOldEndpointResults(tx, endpointID, limits.MaximumNumberOfResults); err != nil {
 				log.Printf("[sql][Insert] Failed to delete old results for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
 			}
 		}
@@ -322,6 +488,9 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 	if err = s.updateEndpointUptime(tx, endpointID, result); err != nil {
 		log.Printf("[sql][Insert] Failed to update uptime for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
 	}
+	if err = s.updateEndpointResponseTimeBucket(tx, endpointID, result); err != nil {
+		log.Printf("[sql][Insert] Failed to update response time histogram for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
+	}
 	// Clean up old uptime entries
 	ageOfOldestUptimeEntry, err := s.getAgeOfOldestEndpointUptimeEntry(tx, endpointID)
 	if err != nil {
@@ -331,6 +500,9 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 			if err = s.deleteOldUptimeEntries(tx, endpointID, time.Now().Add(-(uptimeRetention + time.Hour))); err != nil {
 				log.Printf("[sql][Insert] Failed to delete old uptime entries for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
 			}
+			if err = s.deleteOldResponseTimeDailyBuckets(tx, endpointID, time.Now().Add(-(responseTimeDailyRetention + 24*time.Hour))); err != nil {
+				log.Printf("[sql][Insert] Failed to delete old daily response time histograms for group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
+			}
 		}
 	}
 	if s.writeThroughCache != nil {
@@ -352,6 +524,113 @@ func (s *Store) Insert(endpoint *core.Endpoint, result *core.Result) error {
 	return err
 }
 
+// RestoreEndpointHistory replaces the events and the hourly uptime statistics of the endpoint with the specified key
+func (s *Store) RestoreEndpointHistory(key string, events []*core.Event, hourlyStatistics map[int64]*core.HourlyUptimeStatistics) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	endpointID, _, _, err := s.getEndpointIDGroupAndNameByKey(tx, key)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if events != nil {
+		if _, err = tx.Exec("DELETE FROM endpoint_events WHERE endpoint_id = $1", endpointID); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		for _, event := range events {
+			if err = s.insertEndpointEvent(tx, endpointID, event); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+		}
+	}
+	if hourlyStatistics != nil {
+		if err = s.replaceEndpointHourlyUptimeStatistics(tx, endpointID, hourlyStatistics); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	if err = tx.Commit(); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if s.writeThroughCache != nil {
+		// The cached endpoint statuses have the events that were replaced, so they're deleted rather than refreshed
+		_ = s.writeThroughCache.DeleteKeysByPattern(key + "*")
+	}
+	return nil
+}
+
+// GetAlertingSilence returns the time until which all alerts are silenced
+func (s *Store) GetAlertingSilence() (time.Time, error) {
+	var silencedUntil time.Time
+	err := s.db.QueryRow("SELECT silenced_until FROM alerting_silence ORDER BY alerting_silence_id DESC LIMIT 1").Scan(&silencedUntil)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return silencedUntil, nil
+}
+
+// SetAlertingSilence silences all alerts until the time specified
+func (s *Store) SetAlertingSilence(until time.Time) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM alerting_silence"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if !until.IsZero() {
+		if _, err = tx.Exec("INSERT INTO alerting_silence (silenced_until) VALUES ($1)", until.UTC()); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetLimits returns the maximum number of results and events kept for each endpoint
+func (s *Store) GetLimits() (common.Limits, error) {
+	s.limitsMutex.RLock()
+	defer s.limitsMutex.RUnlock()
+	return s.limits, nil
+}
+
+// SetLimits changes and persists the maximum number of results and events kept for each endpoint
+//
+// The excess results and events of each endpoint are deleted the next time a result is inserted for said endpoint.
+func (s *Store) SetLimits(limits common.Limits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	if _, err = tx.Exec("DELETE FROM storage_limits"); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if _, err = tx.Exec("INSERT INTO storage_limits (maximum_number_of_results, maximum_number_of_events) VALUES ($1, $2)", limits.MaximumNumberOfResults, limits.MaximumNumberOfEvents); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = tx.Commit(); err != nil {
+		return err
+	}
+	s.limitsMutex.Lock()
+	s.limits = limits
+	s.limitsMutex.Unlock()
+	return nil
+}
+
 // DeleteAllEndpointStatusesNotInKeys removes all rows owned by an endpoint whose key is not within the keys provided
 func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 	var err error
@@ -385,6 +664,11 @@ func (s *Store) DeleteAllEndpointStatusesNotInKeys(keys []string) int {
 // Clear deletes everything from the store
 func (s *Store) Clear() {
 	_, _ = s.db.Exec("DELETE FROM endpoints")
+	_, _ = s.db.Exec("DELETE FROM alerting_silence")
+	_, _ = s.db.Exec("DELETE FROM storage_limits")
+	s.limitsMutex.Lock()
+	s.limits = common.DefaultLimits()
+	s.limitsMutex.Unlock()
 	if s.writeThroughCache != nil {
 		_ = s.writeThroughCache.DeleteKeysByPattern("*")
 	}
@@ -395,6 +679,11 @@ func (s *Store) Save() error {
 	return nil
 }
 
+// Ping checks whether the database is reachable
+func (s *Store) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 // Close the database handle
 func (s *Store) Close() {
 	_ = s.db.Close()
@@ -423,10 +712,11 @@ func (s *Store) insertEndpoint(tx *sql.Tx, endpoint *core.Endpoint) (int64, erro
 // insertEndpointEvent inserts en event in the store
 func (s *Store) insertEndpointEvent(tx *sql.Tx, endpointID int64, event *core.Event) error {
 	_, err := tx.Exec(
-		"INSERT INTO endpoint_events (endpoint_id, event_type, event_timestamp) VALUES ($1, $2, $3)",
+		"INSERT INTO endpoint_events (endpoint_id, event_type, event_timestamp, event_failed_conditions) VALUES ($1, $2, $3, $4)",
 		endpointID,
 		event.Type,
 		event.Timestamp.UTC(),
+		strings.Join(event.FailedConditions, arraySeparator),
 	)
 	if err != nil {
 		return err
@@ -439,8 +729,8 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *core.
 	var endpointResultID int64
 	err := tx.QueryRow(
 		`
-			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp)
-			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			INSERT INTO endpoint_results (endpoint_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, expected_failure, unknown, agent)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 			RETURNING endpoint_result_id
 		`,
 		endpointID,
@@ -455,11 +745,17 @@ func (s *Store) insertEndpointResult(tx *sql.Tx, endpointID int64, result *core.
 		result.IP,
 		result.Duration,
 		result.Timestamp.UTC(),
+		result.ExpectedFailure,
+		result.Unknown,
+		result.Agent,
 	).Scan(&endpointResultID)
 	if err != nil {
 		return err
 	}
-	return s.insertConditionResults(tx, endpointResultID, result.ConditionResults)
+	if err = s.insertConditionResults(tx, endpointResultID, result.ConditionResults); err != nil {
+		return err
+	}
+	return s.insertIPResults(tx, endpointResultID, result.IPResults)
 }
 
 func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, conditionResults []*core.ConditionResult) error {
@@ -477,6 +773,24 @@ func (s *Store) insertConditionResults(tx *sql.Tx, endpointResultID int64, condi
 	return nil
 }
 
+func (s *Store) insertIPResults(tx *sql.Tx, endpointResultID int64, ipResults []*core.IPResult) error {
+	var err error
+	for _, ipResult := range ipResults {
+		_, err = tx.Exec("INSERT INTO endpoint_result_ips (endpoint_result_id, ip, status, duration, errors, success) VALUES ($1, $2, $3, $4, $5, $6)",
+			endpointResultID,
+			ipResult.IP,
+			ipResult.HTTPStatus,
+			ipResult.Duration,
+			strings.Join(ipResult.Errors, arraySeparator),
+			ipResult.Success,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *core.Result) error {
 	unixTimestampFlooredAtHour := result.Timestamp.Truncate(time.Hour).Unix()
 	var successfulExecutions int
@@ -501,8 +815,49 @@ func (s *Store) updateEndpointUptime(tx *sql.Tx, endpointID int64, result *core.
 	return err
 }
 
-func (s *Store) getAllEndpointKeys(tx *sql.Tx) (keys []string, err error) {
-	rows, err := tx.Query("SELECT endpoint_key FROM endpoints ORDER BY endpoint_key")
+func (s *Store) updateEndpointResponseTimeBucket(tx *sql.Tx, endpointID int64, result *core.Result) error {
+	_, err := tx.Exec(
+		`
+			INSERT INTO endpoint_response_time_buckets (endpoint_id, hour_unix_timestamp, bucket, executions)
+			VALUES ($1, $2, $3, 1)
+			ON CONFLICT(endpoint_id, hour_unix_timestamp, bucket) DO UPDATE SET
+				executions = endpoint_response_time_buckets.executions + 1
+		`,
+		endpointID,
+		result.Timestamp.Truncate(time.Hour).Unix(),
+		core.ResponseTimeHistogramBucket(result.Duration),
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(
+		`
+			INSERT INTO endpoint_response_time_daily_buckets (endpoint_id, day_unix_timestamp, bucket, executions)
+			VALUES ($1, $2, $3, 1)
+			ON CONFLICT(endpoint_id, day_unix_timestamp, bucket) DO UPDATE SET
+				executions = endpoint_response_time_daily_buckets.executions + 1
+		`,
+		endpointID,
+		result.Timestamp.Truncate(24*time.Hour).Unix(),
+		core.ResponseTimeHistogramBucket(result.Duration),
+	)
+	return err
+}
+
+// getAllEndpointKeys returns the keys of the endpoints matching the group and endpoint page of the parameters
+func (s *Store) getAllEndpointKeys(tx *sql.Tx, params *paging.EndpointStatusParams) (keys []string, err error) {
+	query := "SELECT endpoint_key FROM endpoints"
+	var arguments []interface{}
+	if len(params.Group) > 0 {
+		arguments = append(arguments, params.Group)
+		query += " WHERE endpoint_group = $1"
+	}
+	query += " ORDER BY endpoint_key"
+	if offset, limit := params.GetEndpointsOffsetAndLimit(); limit >= 0 {
+		arguments = append(arguments, limit, offset)
+		query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(arguments)-1, len(arguments))
+	}
+	rows, err := tx.Query(query, arguments...)
 	if err != nil {
 		return nil, err
 	}
@@ -532,6 +887,11 @@ func (s *Store) getEndpointStatusByKey(tx *sql.Tx, key string, parameters *pagin
 	if parameters.EventsPageSize > 0 {
 		if endpointStatus.Events, err = s.getEndpointEventsByEndpointID(tx, endpointID, parameters.EventsPage, parameters.EventsPageSize); err != nil {
 			log.Printf("[sql][getEndpointStatusByKey] Failed to retrieve events for key=%s: %s", key, err.Error())
+		} else if len(endpointStatus.Events) > 0 {
+			// Only the annotations that fall within the events returned are relevant
+			if endpointStatus.Annotations, err = s.getEndpointAnnotationsByEndpointIDSince(tx, endpointID, endpointStatus.Events[0].Timestamp); err != nil {
+				log.Printf("[sql][getEndpointStatusByKey] Failed to retrieve annotations for key=%s: %s", key, err.Error())
+			}
 		}
 	}
 	if parameters.ResultsPageSize > 0 {
@@ -567,7 +927,7 @@ func (s *Store) getEndpointIDGroupAndNameByKey(tx *sql.Tx, key string) (id int64
 func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (events []*core.Event, err error) {
 	rows, err := tx.Query(
 		`
-			SELECT event_type, event_timestamp
+			SELECT event_type, event_timestamp, event_failed_conditions
 			FROM endpoint_events
 			WHERE endpoint_id = $1
 			ORDER BY endpoint_event_id ASC
@@ -580,9 +940,104 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 	if err != nil {
 		return nil, err
 	}
+	return scanEndpointEvents(rows)
+}
+
+// getEndpointEventsByEndpointIDAndTimeRange returns the events that happened between from and to, with the first page
+// being the most recent events
+func (s *Store) getEndpointEventsByEndpointIDAndTimeRange(tx *sql.Tx, endpointID int64, from, to time.Time, page, pageSize int) ([]*core.Event, error) {
+	rows, err := tx.Query(
+		`
+			SELECT event_type, event_timestamp, event_failed_conditions
+			FROM endpoint_events
+			WHERE endpoint_id = $1
+				AND event_timestamp >= $2
+				AND event_timestamp <= $3
+			ORDER BY endpoint_event_id DESC
+			LIMIT $4 OFFSET $5
+		`,
+		endpointID,
+		from.UTC(),
+		to.UTC(),
+		pageSize,
+		(page-1)*pageSize,
+	)
+	if err != nil {
+		return nil, err
+	}
+	events, err := scanEndpointEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+	// Events are retrieved from newest to oldest, but they're returned in chronological order
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
+// getEndpointAnnotationsByEndpointIDAndTimeRange returns the annotations that were created between from and to, in
+// chronological order
+func (s *Store) getEndpointAnnotationsByEndpointIDAndTimeRange(tx *sql.Tx, endpointID int64, from, to time.Time) ([]*core.Annotation, error) {
+	rows, err := tx.Query(
+		`
+			SELECT annotation_timestamp, annotation_note, annotation_author
+			FROM endpoint_annotations
+			WHERE endpoint_id = $1
+				AND annotation_timestamp >= $2
+				AND annotation_timestamp <= $3
+			ORDER BY annotation_timestamp ASC, endpoint_annotation_id ASC
+		`,
+		endpointID,
+		from.UTC(),
+		to.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanEndpointAnnotations(rows)
+}
+
+// getEndpointAnnotationsByEndpointIDSince returns the annotations that were created since the time passed, in
+// chronological order
+func (s *Store) getEndpointAnnotationsByEndpointIDSince(tx *sql.Tx, endpointID int64, since time.Time) ([]*core.Annotation, error) {
+	rows, err := tx.Query(
+		`
+			SELECT annotation_timestamp, annotation_note, annotation_author
+			FROM endpoint_annotations
+			WHERE endpoint_id = $1
+				AND annotation_timestamp >= $2
+			ORDER BY annotation_timestamp ASC, endpoint_annotation_id ASC
+		`,
+		endpointID,
+		since.UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanEndpointAnnotations(rows)
+}
+
+func scanEndpointAnnotations(rows *sql.Rows) (annotations []*core.Annotation, err error) {
+	defer rows.Close()
+	annotations = make([]*core.Annotation, 0)
+	for rows.Next() {
+		annotation := &core.Annotation{}
+		_ = rows.Scan(&annotation.Timestamp, &annotation.Note, &annotation.Author)
+		annotations = append(annotations, annotation)
+	}
+	return
+}
+
+func scanEndpointEvents(rows *sql.Rows) (events []*core.Event, err error) {
+	defer rows.Close()
 	for rows.Next() {
 		event := &core.Event{}
-		_ = rows.Scan(&event.Type, &event.Timestamp)
+		var joinedFailedConditions string
+		_ = rows.Scan(&event.Type, &event.Timestamp, &joinedFailedConditions)
+		if len(joinedFailedConditions) != 0 {
+			event.FailedConditions = strings.Split(joinedFailedConditions, arraySeparator)
+		}
 		events = append(events, event)
 	}
 	return
@@ -591,7 +1046,7 @@ func (s *Store) getEndpointEventsByEndpointID(tx *sql.Tx, endpointID int64, page
 func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, page, pageSize int) (results []*core.Result, err error) {
 	rows, err := tx.Query(
 		`
-			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp
+			SELECT endpoint_result_id, success, errors, connected, status, dns_rcode, certificate_expiration, domain_expiration, hostname, ip, duration, timestamp, expected_failure, unknown, agent
 			FROM endpoint_results
 			WHERE endpoint_id = $1
 			ORDER BY endpoint_result_id DESC -- Normally, we'd sort by timestamp, but sorting by endpoint_result_id is faster
@@ -609,7 +1064,7 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		result := &core.Result{}
 		var id int64
 		var joinedErrors string
-		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp)
+		err = rows.Scan(&id, &result.Success, &joinedErrors, &result.Connected, &result.HTTPStatus, &result.DNSRCode, &result.CertificateExpiration, &result.DomainExpiration, &result.Hostname, &result.IP, &result.Duration, &result.Timestamp, &result.ExpectedFailure, &result.Unknown, &result.Agent)
 		if err != nil {
 			log.Printf("[sql][getEndpointResultsByEndpointID] Silently failed to retrieve endpoint result for endpointID=%d: %s", endpointID, err.Error())
 			err = nil
@@ -627,16 +1082,17 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 	}
 	// Get condition results
 	args := make([]interface{}, 0, len(idResultMap))
-	query := `SELECT endpoint_result_id, condition, success
-				FROM endpoint_result_conditions
-				WHERE endpoint_result_id IN (`
+	endpointResultIDs := ""
 	index := 1
 	for endpointResultID := range idResultMap {
-		query += "$" + strconv.Itoa(index) + ","
+		endpointResultIDs += "$" + strconv.Itoa(index) + ","
 		args = append(args, endpointResultID)
 		index++
 	}
-	query = query[:len(query)-1] + ")"
+	endpointResultIDs = endpointResultIDs[:len(endpointResultIDs)-1]
+	query := `SELECT endpoint_result_id, condition, success
+				FROM endpoint_result_conditions
+				WHERE endpoint_result_id IN (` + endpointResultIDs + ")"
 	rows, err = tx.Query(query, args...)
 	if err != nil {
 		return nil, err
@@ -650,6 +1106,27 @@ func (s *Store) getEndpointResultsByEndpointID(tx *sql.Tx, endpointID int64, pag
 		}
 		idResultMap[endpointResultID].ConditionResults = append(idResultMap[endpointResultID].ConditionResults, conditionResult)
 	}
+	// Get IP results
+	query = `SELECT endpoint_result_id, ip, status, duration, errors, success
+				FROM endpoint_result_ips
+				WHERE endpoint_result_id IN (` + endpointResultIDs + ")"
+	rows, err = tx.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		ipResult := &core.IPResult{}
+		var endpointResultID int64
+		var joinedErrors string
+		if err = rows.Scan(&endpointResultID, &ipResult.IP, &ipResult.HTTPStatus, &ipResult.Duration, &joinedErrors, &ipResult.Success); err != nil {
+			return
+		}
+		if len(joinedErrors) != 0 {
+			ipResult.Errors = strings.Split(joinedErrors, arraySeparator)
+		}
+		idResultMap[endpointResultID].IPResults = append(idResultMap[endpointResultID].IPResults, ipResult)
+	}
 	return
 }
 
@@ -734,6 +1211,156 @@ func (s *Store) getEndpointHourlyAverageResponseTimes(tx *sql.Tx, endpointID int
 	return hourlyAverageResponseTimes, nil
 }
 
+func (s *Store) getEndpointResponseTimeHistogram(tx *sql.Tx, endpointID int64, from, to time.Time) (*core.ResponseTimeHistogram, error) {
+	query := `
+		SELECT bucket, SUM(executions)
+		FROM endpoint_response_time_buckets
+		WHERE endpoint_id = $1
+			AND hour_unix_timestamp >= $2
+			AND hour_unix_timestamp <= $3
+		GROUP BY bucket
+	`
+	if to.Sub(from) > uptimeRetention {
+		// Hourly histograms aren't retained for long enough, so the daily histograms are used instead
+		query = `
+			SELECT bucket, SUM(executions)
+			FROM endpoint_response_time_daily_buckets
+			WHERE endpoint_id = $1
+				AND day_unix_timestamp >= $2
+				AND day_unix_timestamp <= $3
+			GROUP BY bucket
+		`
+		from = from.Truncate(24 * time.Hour)
+	}
+	rows, err := tx.Query(query, endpointID, from.Unix(), to.Unix())
+	if err != nil {
+		return nil, err
+	}
+	histogram := core.NewResponseTimeHistogram()
+	var bucket int
+	var executions uint64
+	for rows.Next() {
+		_ = rows.Scan(&bucket, &executions)
+		histogram.Add(bucket, executions)
+	}
+	return histogram, nil
+}
+
+// getEndpointHourlyUptimeStatistics returns the uptime statistics of an endpoint for each hour during a time range,
+// including the response time histogram of each hour
+func (s *Store) getEndpointHourlyUptimeStatistics(tx *sql.Tx, endpointID int64, from, to time.Time) (map[int64]*core.HourlyUptimeStatistics, error) {
+	rows, err := tx.Query(
+		`
+			SELECT hour_unix_timestamp, total_executions, successful_executions, total_response_time
+			FROM endpoint_uptimes
+			WHERE endpoint_id = $1
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Unix(),
+		to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	hourlyStatistics := make(map[int64]*core.HourlyUptimeStatistics)
+	var unixTimestampFlooredAtHour int64
+	for rows.Next() {
+		hourlyStats := &core.HourlyUptimeStatistics{}
+		_ = rows.Scan(&unixTimestampFlooredAtHour, &hourlyStats.TotalExecutions, &hourlyStats.SuccessfulExecutions, &hourlyStats.TotalExecutionsResponseTime)
+		hourlyStatistics[unixTimestampFlooredAtHour] = hourlyStats
+	}
+	rows, err = tx.Query(
+		`
+			SELECT hour_unix_timestamp, bucket, executions
+			FROM endpoint_response_time_buckets
+			WHERE endpoint_id = $1
+				AND hour_unix_timestamp >= $2
+				AND hour_unix_timestamp <= $3
+		`,
+		endpointID,
+		from.Unix(),
+		to.Unix(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var bucket int
+	var executions uint64
+	for rows.Next() {
+		_ = rows.Scan(&unixTimestampFlooredAtHour, &bucket, &executions)
+		hourlyStats, exists := hourlyStatistics[unixTimestampFlooredAtHour]
+		if !exists || bucket < 0 || bucket > len(core.ResponseTimeHistogramBuckets) {
+			continue
+		}
+		if hourlyStats.ResponseTimeHistogram == nil {
+			hourlyStats.ResponseTimeHistogram = make([]uint64, len(core.ResponseTimeHistogramBuckets)+1)
+		}
+		hourlyStats.ResponseTimeHistogram[bucket] = executions
+	}
+	return hourlyStatistics, nil
+}
+
+// replaceEndpointHourlyUptimeStatistics replaces the uptime statistics and the response time histograms of an
+// endpoint by those passed
+func (s *Store) replaceEndpointHourlyUptimeStatistics(tx *sql.Tx, endpointID int64, hourlyStatistics map[int64]*core.HourlyUptimeStatistics) error {
+	if _, err := tx.Exec("DELETE FROM endpoint_uptimes WHERE endpoint_id = $1", endpointID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("DELETE FROM endpoint_response_time_buckets WHERE endpoint_id = $1", endpointID); err != nil {
+		return err
+	}
+	// The daily response time histograms are rebuilt from the hourly statistics, as they aren't backed up
+	if _, err := tx.Exec("DELETE FROM endpoint_response_time_daily_buckets WHERE endpoint_id = $1", endpointID); err != nil {
+		return err
+	}
+	for unixTimestampFlooredAtHour, hourlyStats := range hourlyStatistics {
+		_, err := tx.Exec(
+			"INSERT INTO endpoint_uptimes (endpoint_id, hour_unix_timestamp, total_executions, successful_executions, total_response_time) VALUES ($1, $2, $3, $4, $5)",
+			endpointID,
+			unixTimestampFlooredAtHour,
+			hourlyStats.TotalExecutions,
+			hourlyStats.SuccessfulExecutions,
+			hourlyStats.TotalExecutionsResponseTime,
+		)
+		if err != nil {
+			return err
+		}
+		for bucket, executions := range hourlyStats.ResponseTimeHistogram {
+			if executions == 0 {
+				continue
+			}
+			_, err = tx.Exec(
+				"INSERT INTO endpoint_response_time_buckets (endpoint_id, hour_unix_timestamp, bucket, executions) VALUES ($1, $2, $3, $4)",
+				endpointID,
+				unixTimestampFlooredAtHour,
+				bucket,
+				executions,
+			)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Exec(
+				`
+					INSERT INTO endpoint_response_time_daily_buckets (endpoint_id, day_unix_timestamp, bucket, executions)
+					VALUES ($1, $2, $3, $4)
+					ON CONFLICT(endpoint_id, day_unix_timestamp, bucket) DO UPDATE SET
+						executions = endpoint_response_time_daily_buckets.executions + $4
+				`,
+				endpointID,
+				time.Unix(unixTimestampFlooredAtHour, 0).Truncate(24*time.Hour).Unix(),
+				bucket,
+				executions,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (s *Store) getEndpointID(tx *sql.Tx, endpoint *core.Endpoint) (int64, error) {
 	var id int64
 	err := tx.QueryRow("SELECT endpoint_id FROM endpoints WHERE endpoint_key = $1", endpoint.Key()).Scan(&id)
@@ -796,8 +1423,9 @@ func (s *Store) getLastEndpointResultSuccessValue(tx *sql.Tx, endpointID int64)
 	return success, nil
 }
 
-// deleteOldEndpointEvents deletes endpoint events that are no longer needed
-func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64) error {
+// deleteOldEndpointEvents deletes endpoint events that are no longer needed, keeping the most recent
+// maximumNumberOfEvents events
+func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64, maximumNumberOfEvents int) error {
 	_, err := tx.Exec(
 		`
 			DELETE FROM endpoint_events 
@@ -811,13 +1439,14 @@ func (s *Store) deleteOldEndpointEvents(tx *sql.Tx, endpointID int64) error {
 				)
 		`,
 		endpointID,
-		common.MaximumNumberOfEvents,
+		maximumNumberOfEvents,
 	)
 	return err
 }
 
-// deleteOldEndpointResults deletes endpoint results that are no longer needed
-func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
+// deleteOldEndpointResults deletes endpoint results that are no longer needed, keeping the most recent
+// maximumNumberOfResults results
+func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64, maximumNumberOfResults int) error {
 	_, err := tx.Exec(
 		`
 			DELETE FROM endpoint_results
@@ -831,13 +1460,22 @@ func (s *Store) deleteOldEndpointResults(tx *sql.Tx, endpointID int64) error {
 				)
 		`,
 		endpointID,
-		common.MaximumNumberOfResults,
+		maximumNumberOfResults,
 	)
 	return err
 }
 
 func (s *Store) deleteOldUptimeEntries(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
 	_, err := tx.Exec("DELETE FROM endpoint_uptimes WHERE endpoint_id = $1 AND hour_unix_timestamp < $2", endpointID, maxAge.Unix())
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec("DELETE FROM endpoint_response_time_buckets WHERE endpoint_id = $1 AND hour_unix_timestamp < $2", endpointID, maxAge.Unix())
+	return err
+}
+
+func (s *Store) deleteOldResponseTimeDailyBuckets(tx *sql.Tx, endpointID int64, maxAge time.Time) error {
+	_, err := tx.Exec("DELETE FROM endpoint_response_time_daily_buckets WHERE endpoint_id = $1 AND day_unix_timestamp < $2", endpointID, maxAge.Unix())
 	return err
 }
 
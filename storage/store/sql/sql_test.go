@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -94,6 +95,17 @@ func TestNewStore(t *testing.T) {
 	}
 }
 
+func TestStore_Ping(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_Ping.db", false)
+	if err := store.Ping(context.Background()); err != nil {
+		t.Error("shouldn't have returned any error, got", err.Error())
+	}
+	store.Close()
+	if err := store.Ping(context.Background()); err == nil {
+		t.Error("should've returned an error because the store is closed")
+	}
+}
+
 func TestStore_InsertCleansUpOldUptimeEntriesProperly(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertCleansUpOldUptimeEntriesProperly.db", false)
 	defer store.Close()
@@ -151,9 +163,39 @@ func TestStore_InsertCleansUpOldUptimeEntriesProperly(t *testing.T) {
 	}
 }
 
+func TestStore_GetResponseTimeHistogramByKeyOverThirtyDays(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_GetResponseTimeHistogramByKeyOverThirtyDays.db", false)
+	defer store.Close()
+	now := time.Now().Truncate(time.Hour)
+	store.Insert(&testEndpoint, &core.Result{Timestamp: now, Success: true, Duration: 100 * time.Millisecond})
+	store.Insert(&testEndpoint, &core.Result{Timestamp: now.Add(-20 * 24 * time.Hour), Success: true, Duration: 2 * time.Second})
+	// This entry triggers the clean up, and is older than responseTimeDailyRetention, so it should be deleted
+	store.Insert(&testEndpoint, &core.Result{Timestamp: now.Add(-40 * 24 * time.Hour), Success: true, Duration: 2 * time.Second})
+	histogram, err := store.GetResponseTimeHistogramByKey(testEndpoint.Key(), now.Add(-30*24*time.Hour), now)
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if total := histogram.Total(); total != 2 {
+		t.Errorf("expected histogram of last 30d to have 2 checks, got %d", total)
+	}
+	// The hourly statistics are still only retained for uptimeRetention
+	histogram, _ = store.GetResponseTimeHistogramByKey(testEndpoint.Key(), now.Add(-7*24*time.Hour), now)
+	if total := histogram.Total(); total != 1 {
+		t.Errorf("expected histogram of last 7d to have 1 check, got %d", total)
+	}
+	tx, _ := store.db.Begin()
+	oldest, _ := store.getAgeOfOldestEndpointUptimeEntry(tx, 1)
+	_ = tx.Commit()
+	if oldest > uptimeRetention+time.Hour {
+		t.Errorf("oldest endpoint uptime entry should've been at most %s old, was %s", uptimeRetention, oldest)
+	}
+}
+
 func TestStore_InsertCleansUpEventsAndResultsProperly(t *testing.T) {
 	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertCleansUpEventsAndResultsProperly.db", false)
 	defer store.Close()
+	resultsCleanUpThreshold := common.MaximumNumberOfResults + cleanUpThreshold
+	eventsCleanUpThreshold := common.MaximumNumberOfEvents + cleanUpThreshold
 	for i := 0; i < resultsCleanUpThreshold+eventsCleanUpThreshold; i++ {
 		store.Insert(&testEndpoint, &testSuccessfulResult)
 		store.Insert(&testEndpoint, &testUnsuccessfulResult)
@@ -168,6 +210,120 @@ func TestStore_InsertCleansUpEventsAndResultsProperly(t *testing.T) {
 	store.Clear()
 }
 
+func TestStore_SetLimits(t *testing.T) {
+	path := t.TempDir() + "/TestStore_SetLimits.db"
+	store, _ := NewStore("sqlite", path, false)
+	if limits, _ := store.GetLimits(); limits != common.DefaultLimits() {
+		t.Errorf("expected the default limits, got %+v", limits)
+	}
+	if err := store.SetLimits(common.Limits{MaximumNumberOfResults: 0, MaximumNumberOfEvents: 10}); err != common.ErrInvalidLimits {
+		t.Errorf("expected error %v, got %v", common.ErrInvalidLimits, err)
+	}
+	limits := common.Limits{MaximumNumberOfResults: 5, MaximumNumberOfEvents: 3}
+	if err := store.SetLimits(limits); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for i := 0; i < 20; i++ {
+		store.Insert(&testEndpoint, &testSuccessfulResult)
+		store.Insert(&testEndpoint, &testUnsuccessfulResult)
+	}
+	ss, _ := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 100).WithEvents(1, 100))
+	if len(ss.Results) > limits.MaximumNumberOfResults+cleanUpThreshold {
+		t.Errorf("number of results shouldn't have exceeded %d, reached %d", limits.MaximumNumberOfResults+cleanUpThreshold, len(ss.Results))
+	}
+	if len(ss.Events) > limits.MaximumNumberOfEvents+cleanUpThreshold {
+		t.Errorf("number of events shouldn't have exceeded %d, reached %d", limits.MaximumNumberOfEvents+cleanUpThreshold, len(ss.Events))
+	}
+	// The limits must have been persisted
+	store.Close()
+	store, _ = NewStore("sqlite", path, false)
+	defer store.Close()
+	if persistedLimits, _ := store.GetLimits(); persistedLimits != limits {
+		t.Errorf("expected the limits to have been persisted as %+v, got %+v", limits, persistedLimits)
+	}
+	store.Clear()
+	if clearedLimits, _ := store.GetLimits(); clearedLimits != common.DefaultLimits() {
+		t.Errorf("expected the limits to have been reset by Clear, got %+v", clearedLimits)
+	}
+}
+
+func TestStore_InsertWithIPResults(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithIPResults.db", false)
+	defer store.Close()
+	result := testUnsuccessfulResult
+	result.IPResults = []*core.IPResult{
+		{IP: "127.0.0.1", HTTPStatus: 200, Duration: 150 * time.Millisecond, Success: true},
+		{IP: "127.0.0.2", HTTPStatus: 500, Duration: 750 * time.Millisecond, Errors: []string{"error-1", "error-2"}, Success: false},
+	}
+	if err := store.Insert(&testEndpoint, &result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || len(endpointStatus.Results[0].IPResults) != 2 {
+		t.Fatalf("expected 1 result with 2 IP results, got %v", endpointStatus.Results)
+	}
+	for i, ipResult := range endpointStatus.Results[0].IPResults {
+		expected := result.IPResults[i]
+		if ipResult.IP != expected.IP || ipResult.HTTPStatus != expected.HTTPStatus || ipResult.Duration != expected.Duration || ipResult.Success != expected.Success || len(ipResult.Errors) != len(expected.Errors) {
+			t.Errorf("expected %v, got %v", expected, ipResult)
+		}
+	}
+}
+
+func TestStore_InsertWithExpectedFailure(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithExpectedFailure.db", false)
+	defer store.Close()
+	result := testUnsuccessfulResult
+	result.ExpectedFailure = true
+	if err := store.Insert(&testEndpoint, &result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || !endpointStatus.Results[0].ExpectedFailure || endpointStatus.Results[0].Success {
+		t.Errorf("expected 1 unsuccessful result flagged as an expected failure, got %v", endpointStatus.Results)
+	}
+}
+
+func TestStore_InsertWithUnknown(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithUnknown.db", false)
+	defer store.Close()
+	result := testUnsuccessfulResult
+	result.Unknown = true
+	if err := store.Insert(&testEndpoint, &result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || !endpointStatus.Results[0].Unknown || endpointStatus.Results[0].Success {
+		t.Errorf("expected 1 unsuccessful result flagged as unknown, got %v", endpointStatus.Results)
+	}
+}
+
+func TestStore_InsertWithAgent(t *testing.T) {
+	store, _ := NewStore("sqlite", t.TempDir()+"/TestStore_InsertWithAgent.db", false)
+	defer store.Close()
+	result := testSuccessfulResult
+	result.Agent = "eu-west"
+	if err := store.Insert(&testEndpoint, &result); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpointStatus, err := store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, common.MaximumNumberOfResults))
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || endpointStatus.Results[0].Agent != "eu-west" {
+		t.Errorf("expected 1 result performed by agent eu-west, got %v", endpointStatus.Results)
+	}
+}
+
 func TestStore_Persistence(t *testing.T) {
 	path := t.TempDir() + "/TestStore_Persistence.db"
 	store, _ := NewStore("sqlite", path, false)
@@ -286,6 +442,11 @@ func TestStore_SanityCheck(t *testing.T) {
 	if averageResponseTime, _ := store.GetAverageResponseTimeByKey(testEndpoint.Key(), time.Now().Add(-24*time.Hour), time.Now()); averageResponseTime != 450 {
 		t.Errorf("expected average response time of last 24h to be 450, got %d", averageResponseTime)
 	}
+	if histogram, err := store.GetResponseTimeHistogramByKey(testEndpoint.Key(), time.Now().Add(-24*time.Hour), time.Now()); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	} else if total := histogram.Total(); total != 2 {
+		t.Errorf("expected histogram of last 24h to have 2 checks, got %d", total)
+	}
 	ss, _ := store.GetEndpointStatus(testEndpoint.Group, testEndpoint.Name, paging.NewEndpointStatusParams().WithResults(1, 20).WithEvents(1, 20))
 	if ss == nil {
 		t.Fatalf("Store should've had key '%s', but didn't", testEndpoint.Key())
@@ -325,7 +486,7 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if err := store.updateEndpointUptime(tx, 1, &testSuccessfulResult); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if _, err := store.getAllEndpointKeys(tx); err == nil {
+	if _, err := store.getAllEndpointKeys(tx, paging.NewEndpointStatusParams()); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if _, err := store.getEndpointStatusByKey(tx, testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 20)); err == nil {
@@ -337,10 +498,10 @@ func TestStore_InvalidTransaction(t *testing.T) {
 	if _, err := store.getEndpointResultsByEndpointID(tx, 1, 1, 50); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.deleteOldEndpointEvents(tx, 1); err == nil {
+	if err := store.deleteOldEndpointEvents(tx, 1, common.MaximumNumberOfEvents); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
-	if err := store.deleteOldEndpointResults(tx, 1); err == nil {
+	if err := store.deleteOldEndpointResults(tx, 1, common.MaximumNumberOfResults); err == nil {
 		t.Error("should've returned an error, because the transaction was already committed")
 	}
 	if _, _, err := store.getEndpointUptime(tx, 1, time.Now(), time.Now()); err == nil {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/TwiN/gatus/v5/storage"
+	"github.com/TwiN/gatus/v5/storage/store/common"
 	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 	"github.com/TwiN/gatus/v5/storage/store/memory"
 	"github.com/TwiN/gatus/v5/storage/store/sql"
@@ -27,15 +28,59 @@ type Store interface {
 	// GetUptimeByKey returns the uptime percentage during a time range
 	GetUptimeByKey(key string, from, to time.Time) (float64, error)
 
+	// GetEndpointEventsByKey returns the events of an endpoint that happened during a time range, with the first page
+	// being the most recent events
+	GetEndpointEventsByKey(key string, from, to time.Time, page, pageSize int) ([]*core.Event, error)
+
+	// GetEndpointAnnotationsByKey returns the annotations of an endpoint that were created during a time range, in
+	// chronological order
+	GetEndpointAnnotationsByKey(key string, from, to time.Time) ([]*core.Annotation, error)
+
+	// InsertEndpointAnnotation adds an annotation to the endpoint with the specified key, which is kept regardless of
+	// the maximum number of results and events
+	InsertEndpointAnnotation(key string, annotation *core.Annotation) error
+
 	// GetAverageResponseTimeByKey returns the average response time in milliseconds (value) during a time range
 	GetAverageResponseTimeByKey(key string, from, to time.Time) (int, error)
 
 	// GetHourlyAverageResponseTimeByKey returns a map of hourly (key) average response time in milliseconds (value) during a time range
 	GetHourlyAverageResponseTimeByKey(key string, from, to time.Time) (map[int64]int, error)
 
+	// GetResponseTimeHistogramByKey returns the histogram of the response times of the checks performed during a time range
+	GetResponseTimeHistogramByKey(key string, from, to time.Time) (*core.ResponseTimeHistogram, error)
+
+	// GetHourlyUptimeStatisticsByKey returns the uptime statistics of an endpoint for each hour (key) during a time range
+	GetHourlyUptimeStatisticsByKey(key string, from, to time.Time) (map[int64]*core.HourlyUptimeStatistics, error)
+
 	// Insert adds the observed result for the specified endpoint into the store
 	Insert(endpoint *core.Endpoint, result *core.Result) error
 
+	// RestoreEndpointHistory replaces the events and the hourly uptime statistics of the endpoint with the specified
+	// key, which outlive its results and therefore cannot be rebuilt from them (e.g. when restoring a backup)
+	//
+	// Passing nil events or nil hourly statistics leaves the corresponding history untouched.
+	RestoreEndpointHistory(key string, events []*core.Event, hourlyStatistics map[int64]*core.HourlyUptimeStatistics) error
+
+	// GetAlertingSilence returns the time until which all alerts are silenced
+	//
+	// If alerting is not silenced, a zero time.Time is returned.
+	GetAlertingSilence() (time.Time, error)
+
+	// SetAlertingSilence silences all alerts until the time specified
+	//
+	// Passing a zero time.Time clears the silence.
+	SetAlertingSilence(until time.Time) error
+
+	// GetLimits returns the maximum number of results and events kept for each endpoint
+	GetLimits() (common.Limits, error)
+
+	// SetLimits changes the maximum number of results and events kept for each endpoint, and persists them if the
+	// store is persistent
+	//
+	// The new limits apply to the results inserted from then on, which means that if they are lowered, the excess
+	// results and events of each endpoint are pruned the next time a result is inserted for said endpoint.
+	SetLimits(limits common.Limits) error
+
 	// DeleteAllEndpointStatusesNotInKeys removes all EndpointStatus that are not within the keys provided
 	//
 	// Used to delete endpoints that have been persisted but are no longer part of the configured endpoints
@@ -47,6 +92,9 @@ type Store interface {
 	// Save persists the data if and where it needs to be persisted
 	Save() error
 
+	// Ping checks whether the store is reachable
+	Ping(ctx context.Context) error
+
 	// Close terminates every connection and closes the store, if applicable.
 	// Should only be used before stopping the application.
 	Close()
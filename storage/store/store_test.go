@@ -260,6 +260,45 @@ func TestStore_GetAllEndpointStatuses(t *testing.T) {
 	}
 }
 
+func TestStore_GetAllEndpointStatusesWithGroupAndEndpointPage(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetAllEndpointStatusesWithGroupAndEndpointPage")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			for _, name := range []string{"a", "b", "c"} {
+				endpoint := testEndpoint
+				endpoint.Name = name
+				scenario.Store.Insert(&endpoint, &testSuccessfulResult)
+			}
+			otherGroupEndpoint := testEndpoint
+			otherGroupEndpoint.Group = "other-group"
+			scenario.Store.Insert(&otherGroupEndpoint, &testSuccessfulResult)
+			endpointStatuses, err := scenario.Store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithGroup(testEndpoint.Group))
+			if err != nil {
+				t.Error("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatuses) != 3 {
+				t.Fatal("expected 3 endpoint statuses, got", len(endpointStatuses))
+			}
+			endpointStatuses, err = scenario.Store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithGroup(testEndpoint.Group).WithEndpoints(2, 2))
+			if err != nil {
+				t.Error("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatuses) != 1 || endpointStatuses[0].Name != "c" {
+				t.Fatal("expected only endpoint c on the second page")
+			}
+			endpointStatuses, err = scenario.Store.GetAllEndpointStatuses(paging.NewEndpointStatusParams().WithEndpoints(3, 2))
+			if err != nil {
+				t.Error("shouldn't have returned an error, got", err.Error())
+			}
+			if len(endpointStatuses) != 0 {
+				t.Error("expected no endpoint status on the third page, got", len(endpointStatuses))
+			}
+			scenario.Store.Clear()
+		})
+	}
+}
+
 func TestStore_GetAllEndpointStatusesWithResultsAndEvents(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetAllEndpointStatusesWithResultsAndEvents")
 	defer cleanUp(scenarios)
@@ -362,6 +401,105 @@ func TestStore_GetUptimeByKey(t *testing.T) {
 	}
 }
 
+func TestStore_GetEndpointEventsByKey(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetEndpointEventsByKey")
+	defer cleanUp(scenarios)
+	firstResult := testSuccessfulResult
+	firstResult.Timestamp = now.Add(-time.Minute)
+	secondResult := testUnsuccessfulResult
+	secondResult.Timestamp = now
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if _, err := scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now.Add(-time.Hour), now, 1, 10); err != common.ErrEndpointNotFound {
+				t.Errorf("should've returned not found because there's nothing yet, got %v", err)
+			}
+			scenario.Store.Insert(&testEndpoint, &firstResult)
+			scenario.Store.Insert(&testEndpoint, &secondResult)
+			events, err := scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now.Add(-time.Minute), now, 1, 10)
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(events) != 2 {
+				t.Fatalf("expected 2 events, got %d", len(events))
+			}
+			if events[0].Type != core.EventHealthy || events[1].Type != core.EventUnhealthy {
+				t.Errorf("expected events to be HEALTHY then UNHEALTHY, got %s then %s", events[0].Type, events[1].Type)
+			}
+			if len(events[1].FailedConditions) != 2 || events[1].FailedConditions[0] != "[RESPONSE_TIME] < 500" || events[1].FailedConditions[1] != "[CERTIFICATE_EXPIRATION] < 72h" {
+				t.Errorf("expected the unhealthy event to have the failed conditions of the second result, got %v", events[1].FailedConditions)
+			}
+			if events, _ = scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now.Add(-time.Minute), now, 1, 1); len(events) != 1 || events[0].Type != core.EventUnhealthy {
+				t.Error("expected the first page to contain the most recent event")
+			}
+			if events, _ = scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now.Add(-time.Minute), now, 2, 1); len(events) != 1 || events[0].Type != core.EventHealthy {
+				t.Error("expected the second page to contain the oldest event")
+			}
+			if events, _ = scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now.Add(-30*time.Second), now, 1, 10); len(events) != 1 || events[0].Type != core.EventUnhealthy {
+				t.Error("expected only the events within the time range to be returned")
+			}
+			if _, err = scenario.Store.GetEndpointEventsByKey(testEndpoint.Key(), now, now.Add(-time.Hour), 1, 10); err != common.ErrInvalidTimeRange {
+				t.Error("should've returned an error because the parameter 'from' cannot be older than 'to'")
+			}
+		})
+	}
+}
+
+func TestStore_EndpointAnnotations(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_EndpointAnnotations")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			start := time.Now()
+			if err := scenario.Store.InsertEndpointAnnotation(testEndpoint.Key(), &core.Annotation{Timestamp: start, Note: "deploying"}); err != common.ErrEndpointNotFound {
+				t.Errorf("should've returned not found because there's nothing yet, got %v", err)
+			}
+			scenario.Store.Insert(&testEndpoint, &testSuccessfulResult)
+			params := paging.NewEndpointStatusParams().WithEvents(1, common.MaximumNumberOfEvents)
+			// Retrieve the endpoint status before adding annotations, so that the cache, if any, must be invalidated
+			if endpointStatus, _ := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), params); len(endpointStatus.Annotations) != 0 {
+				t.Errorf("expected no annotations, got %d", len(endpointStatus.Annotations))
+			}
+			if err := scenario.Store.InsertEndpointAnnotation(testEndpoint.Key(), &core.Annotation{Timestamp: start.Add(time.Second), Note: "deploying"}); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			if err := scenario.Store.InsertEndpointAnnotation(testEndpoint.Key(), &core.Annotation{Timestamp: start.Add(2 * time.Second), Note: "rolling back deploy", Author: "john.doe"}); err != nil {
+				t.Fatal("expected no error, got", err.Error())
+			}
+			annotations, err := scenario.Store.GetEndpointAnnotationsByKey(testEndpoint.Key(), start, start.Add(time.Minute))
+			if err != nil {
+				t.Fatal("shouldn't have returned an error, got", err.Error())
+			}
+			if len(annotations) != 2 {
+				t.Fatalf("expected 2 annotations, got %d", len(annotations))
+			}
+			if annotations[0].Note != "deploying" || annotations[1].Note != "rolling back deploy" || annotations[1].Author != "john.doe" {
+				t.Errorf("expected the annotations to be returned in chronological order, got %+v and %+v", annotations[0], annotations[1])
+			}
+			if annotations, _ = scenario.Store.GetEndpointAnnotationsByKey(testEndpoint.Key(), start.Add(1500*time.Millisecond), start.Add(time.Minute)); len(annotations) != 1 || annotations[0].Note != "rolling back deploy" {
+				t.Error("expected only the annotations within the time range to be returned")
+			}
+			if _, err = scenario.Store.GetEndpointAnnotationsByKey(testEndpoint.Key(), now, now.Add(-time.Hour)); err != common.ErrInvalidTimeRange {
+				t.Error("should've returned an error because the parameter 'from' cannot be older than 'to'")
+			}
+			if endpointStatus, _ := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), params); len(endpointStatus.Annotations) != 2 {
+				t.Errorf("expected the endpoint status to have 2 annotations, got %d", len(endpointStatus.Annotations))
+			}
+			// Unlike events, annotations must not be pruned
+			_ = scenario.Store.SetLimits(common.Limits{MaximumNumberOfResults: common.MaximumNumberOfResults, MaximumNumberOfEvents: 10})
+			for i := 0; i < 50; i++ {
+				if i%2 == 0 {
+					scenario.Store.Insert(&testEndpoint, &testUnsuccessfulResult)
+				} else {
+					scenario.Store.Insert(&testEndpoint, &testSuccessfulResult)
+				}
+			}
+			if annotations, _ = scenario.Store.GetEndpointAnnotationsByKey(testEndpoint.Key(), start, start.Add(time.Minute)); len(annotations) != 2 {
+				t.Errorf("expected the annotations to have been kept, got %d annotations", len(annotations))
+			}
+		})
+	}
+}
+
 func TestStore_GetAverageResponseTimeByKey(t *testing.T) {
 	scenarios := initStoresAndBaseScenarios(t, "TestStore_GetAverageResponseTimeByKey")
 	defer cleanUp(scenarios)
@@ -569,6 +707,60 @@ func TestStore_DeleteAllEndpointStatusesNotInKeys(t *testing.T) {
 	}
 }
 
+func TestStore_AlertingSilence(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_AlertingSilence")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if silencedUntil, err := scenario.Store.GetAlertingSilence(); err != nil || !silencedUntil.IsZero() {
+				t.Fatalf("expected no silence, got %s with error %v", silencedUntil, err)
+			}
+			until := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+			if err := scenario.Store.SetAlertingSilence(until); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if silencedUntil, err := scenario.Store.GetAlertingSilence(); err != nil || !silencedUntil.Equal(until) {
+				t.Errorf("expected silence until %s, got %s with error %v", until, silencedUntil, err)
+			}
+			if err := scenario.Store.SetAlertingSilence(time.Time{}); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if silencedUntil, err := scenario.Store.GetAlertingSilence(); err != nil || !silencedUntil.IsZero() {
+				t.Errorf("expected silence to have been cleared, got %s with error %v", silencedUntil, err)
+			}
+		})
+	}
+}
+
+func TestStore_Limits(t *testing.T) {
+	scenarios := initStoresAndBaseScenarios(t, "TestStore_Limits")
+	defer cleanUp(scenarios)
+	for _, scenario := range scenarios {
+		t.Run(scenario.Name, func(t *testing.T) {
+			if limits, err := scenario.Store.GetLimits(); err != nil || limits != common.DefaultLimits() {
+				t.Fatalf("expected the default limits, got %+v with error %v", limits, err)
+			}
+			if err := scenario.Store.SetLimits(common.Limits{MaximumNumberOfResults: common.UpperBoundOfMaximumNumberOfResults + 1, MaximumNumberOfEvents: 10}); err != common.ErrInvalidLimits {
+				t.Errorf("expected error %v, got %v", common.ErrInvalidLimits, err)
+			}
+			limits := common.Limits{MaximumNumberOfResults: 500, MaximumNumberOfEvents: 10}
+			if err := scenario.Store.SetLimits(limits); err != nil {
+				t.Fatal("expected no error, got", err)
+			}
+			if newLimits, err := scenario.Store.GetLimits(); err != nil || newLimits != limits {
+				t.Errorf("expected limits %+v, got %+v with error %v", limits, newLimits, err)
+			}
+			for i := 0; i < 2*common.MaximumNumberOfResults; i++ {
+				_ = scenario.Store.Insert(&testEndpoint, &testSuccessfulResult)
+			}
+			endpointStatus, _ := scenario.Store.GetEndpointStatusByKey(testEndpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 4*common.MaximumNumberOfResults))
+			if len(endpointStatus.Results) != 2*common.MaximumNumberOfResults {
+				t.Errorf("expected results beyond the default limit to have been kept, got %d results", len(endpointStatus.Results))
+			}
+		})
+	}
+}
+
 func TestGet(t *testing.T) {
 	store := Get()
 	if store == nil {
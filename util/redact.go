@@ -0,0 +1,39 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const redactedSecret = "<redacted>"
+
+// secretPattern matches the values of the keys that are likely to hold secrets, whether they're in JSON
+// (e.g. "token": "abc"), in a query string (e.g. api_key=abc) or in a header (e.g. Authorization: Bearer abc)
+var secretPattern = regexp.MustCompile(`(?i)("?[\w-]*(?:token|secret|password|passwd|api[_-]?key|authorization|credential)[\w-]*"?\s*[:=]\s*)("(?:[^"\\]|\\.)*"|(?:bearer|basic)\s+[^\s,;&}]+|[^\s,;&}"]+)`)
+
+// Excerpt returns an excerpt of the given body in which the values of the keys that are likely to hold secrets are
+// redacted, truncated to maxLength bytes on a rune boundary, so that it remains valid UTF-8
+func Excerpt(body []byte, maxLength int) string {
+	excerpt := strings.TrimSpace(RedactSecrets(strings.ToValidUTF8(string(body), string(utf8.RuneError))))
+	if len(excerpt) <= maxLength {
+		return excerpt
+	}
+	// Walk back to the start of the rune the excerpt would otherwise be cut in the middle of
+	end := maxLength
+	for end > 0 && !utf8.RuneStart(excerpt[end]) {
+		end--
+	}
+	return excerpt[:end] + "…"
+}
+
+// RedactSecrets replaces the values of the keys that are likely to hold secrets from the given text
+func RedactSecrets(text string) string {
+	return secretPattern.ReplaceAllStringFunc(text, func(match string) string {
+		submatches := secretPattern.FindStringSubmatch(match)
+		if strings.HasPrefix(submatches[2], `"`) {
+			return submatches[1] + `"` + redactedSecret + `"`
+		}
+		return submatches[1] + redactedSecret
+	})
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestExcerpt(t *testing.T) {
+	scenarios := []struct {
+		name      string
+		body      string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "short-body",
+			body:      "  service unavailable\n",
+			maxLength: 200,
+			expected:  "service unavailable",
+		},
+		{
+			name:      "truncated",
+			body:      "service unavailable",
+			maxLength: 7,
+			expected:  "service…",
+		},
+		{
+			name:      "truncated-on-rune-boundary",
+			body:      "服务不可用",
+			maxLength: 4,
+			expected:  "服…",
+		},
+		{
+			name:      "invalid-utf8",
+			body:      "service\xffunavailable",
+			maxLength: 200,
+			expected:  "service�unavailable",
+		},
+		{
+			name:      "secrets-redacted-before-truncation",
+			body:      `{"token":"abcdefghijklmnopqrstuvwxyz"}`,
+			maxLength: 22,
+			expected:  `{"token":"<redacted>"}`,
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			excerpt := Excerpt([]byte(scenario.body), scenario.maxLength)
+			if excerpt != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, excerpt)
+			}
+			if !utf8.ValidString(excerpt) {
+				t.Errorf("expected excerpt to be valid UTF-8, got %q", excerpt)
+			}
+		})
+	}
+}
+
+func TestRedactSecrets(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		text     string
+		expected string
+	}{
+		{
+			name:     "no-secrets",
+			text:     `{"status":"DOWN"}`,
+			expected: `{"status":"DOWN"}`,
+		},
+		{
+			name:     "json-secrets",
+			text:     `{"error":"unauthorized","access_token":"abc\"def","apiKey": "xyz"}`,
+			expected: `{"error":"unauthorized","access_token":"<redacted>","apiKey": "<redacted>"}`,
+		},
+		{
+			name:     "query-string-secrets",
+			text:     "https://example.org/login?user=john&password=hunter2&lang=en",
+			expected: "https://example.org/login?user=john&password=<redacted>&lang=en",
+		},
+		{
+			name:     "header-secrets",
+			text:     "Authorization: Bearer abc.def\nX-Api-Key: xyz",
+			expected: "Authorization: <redacted>\nX-Api-Key: <redacted>",
+		},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if redacted := RedactSecrets(scenario.text); redacted != scenario.expected {
+				t.Errorf("expected %q, got %q", scenario.expected, redacted)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// DefaultSignatureHeader is the name of the header in which the signature of an outgoing payload is sent by default
+const DefaultSignatureHeader = "X-Gatus-Signature"
+
+// SignPayload returns the signature of a payload, which is the hex-encoded HMAC-SHA256 of the payload computed with the
+// secret passed, prefixed by "sha256=" like the signatures of GitHub webhooks
+func SignPayload(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
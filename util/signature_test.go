@@ -0,0 +1,11 @@
+package util
+
+import "testing"
+
+func TestSignPayload(t *testing.T) {
+	// Example from the documentation of GitHub webhooks, which this signature is meant to be compatible with
+	signature := SignPayload("It's a Secret to Everybody", []byte("Hello, World!"))
+	if expected := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"; signature != expected {
+		t.Errorf("expected %s, got %s", expected, signature)
+	}
+}
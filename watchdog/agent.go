@@ -0,0 +1,139 @@
+package watchdog
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/resultwebhook"
+)
+
+var (
+	// agentRounds are the rounds of checks of the endpoints checked by agents, by endpoint key
+	agentRounds      = make(map[string]*agentRound)
+	agentRoundsMutex sync.Mutex
+)
+
+// agentRound keeps track of the results reported by the agents of an endpoint, so that the results of all of its
+// agents are handled by alerting as a single result, rather than each result reported by an agent being handled as a
+// check of its own
+type agentRound struct {
+	// latestResults are the most recent results reported by each agent
+	latestResults map[string]*core.Result
+
+	// reportedAgents are the agents that reported a result since the previous round was completed
+	reportedAgents map[string]bool
+}
+
+// isCheckedLocally returns whether the checks of the endpoint are performed by this instance, as opposed to by the
+// agents of the endpoint
+//
+// When running as an agent, only the endpoints assigned to the agent are checked.
+func isCheckedLocally(endpoint *core.Endpoint, agentConfig *agent.Config) bool {
+	if agentConfig != nil {
+		return endpoint.IsCheckedByAgent(agentConfig.Name)
+	}
+	return len(endpoint.Agents) == 0
+}
+
+// HandleAgentResult handles the result of a check of an endpoint reported by one of its agents the same way as the
+// result of a check performed by Gatus itself, except that alerting only handles the results of the agents once per
+// round, and considers the endpoint unhealthy only if at least Endpoint.AgentFailureThreshold of its agents reported
+// an unsuccessful result last.
+//
+// A round is completed once every agent of the endpoint has reported a result, or once an agent reports a result
+// again before the others did, in which case the agents that haven't reported yet are late and their previous result
+// is used.
+func HandleAgentResult(endpoint *core.Endpoint, result *core.Result, cfg *config.Config) {
+	if len(result.ConditionResults) > 0 && !result.RestoreConditions(endpoint.Conditions) {
+		log.Printf("[watchdog][HandleAgentResult] Result of group=%s; endpoint=%s from agent=%s has %d condition results while the endpoint has %d conditions; alerts scoped to conditions will not be triggered by it", endpoint.Group, endpoint.Name, result.Agent, len(result.ConditionResults), len(endpoint.Conditions))
+	}
+	enabledMetrics := cfg.Metrics || cfg.MetricsRemoteWrite != nil || cfg.MetricsPushgateway != nil
+	if enabledMetrics {
+		metrics.PublishMetricsForEndpoint(endpoint, result)
+	}
+	UpdateEndpointStatuses(endpoint, result)
+	updateGroupHealth(endpoint, result, enabledMetrics)
+	resultwebhook.Publish(endpoint, result)
+	log.Printf("[watchdog][HandleAgentResult] Received result of group=%s; endpoint=%s from agent=%s; success=%v; errors=%d; duration=%s", endpoint.Group, endpoint.Name, result.Agent, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond))
+	// Alerting may send alerts, so it must not be handled while holding the lock, or every other agent would have to
+	// wait for the alerting providers before reporting their result
+	for _, aggregatedResult := range recordAgentResult(endpoint, result) {
+		handleAlertingIfNecessary(endpoint, aggregatedResult, cfg.Alerting, cfg.Maintenance, enabledMetrics, cfg.Debug)
+	}
+}
+
+// recordAgentResult records the result reported by an agent, and returns the aggregated results of the rounds that
+// were completed by it, which must be handled by alerting
+func recordAgentResult(endpoint *core.Endpoint, result *core.Result) []*core.Result {
+	if !endpoint.IsCheckedByAgent(result.Agent) {
+		return nil
+	}
+	agentRoundsMutex.Lock()
+	defer agentRoundsMutex.Unlock()
+	round, exists := agentRounds[endpoint.Key()]
+	if !exists {
+		round = &agentRound{latestResults: make(map[string]*core.Result), reportedAgents: make(map[string]bool)}
+		agentRounds[endpoint.Key()] = round
+	}
+	var aggregatedResults []*core.Result
+	if round.reportedAgents[result.Agent] {
+		// The agent reported a result again before every other agent did, so the round is completed without them
+		aggregatedResults = append(aggregatedResults, round.aggregate(endpoint))
+		round.reportedAgents = make(map[string]bool)
+	}
+	round.latestResults[result.Agent] = result
+	round.reportedAgents[result.Agent] = true
+	for _, agentName := range endpoint.Agents {
+		if !round.reportedAgents[agentName] {
+			return aggregatedResults
+		}
+	}
+	round.reportedAgents = make(map[string]bool)
+	return append(aggregatedResults, round.aggregate(endpoint))
+}
+
+// aggregate returns the result of the round as it must be handled by alerting, which is only unsuccessful if enough
+// agents of the endpoint reported an unsuccessful result last
+//
+// The aggregated result is based on the most recent unsuccessful result if the endpoint is unhealthy, so that the
+// alerts scoped to the conditions that failed are triggered, and on the most recent result otherwise.
+func (round *agentRound) aggregate(endpoint *core.Endpoint) *core.Result {
+	var latestResult, latestUnsuccessfulResult *core.Result
+	numberOfFailingAgents := 0
+	for _, agentName := range endpoint.Agents {
+		result, reported := round.latestResults[agentName]
+		if !reported {
+			continue
+		}
+		if latestResult == nil || result.Timestamp.After(latestResult.Timestamp) {
+			latestResult = result
+		}
+		if !result.Success {
+			numberOfFailingAgents++
+			if latestUnsuccessfulResult == nil || result.Timestamp.After(latestUnsuccessfulResult.Timestamp) {
+				latestUnsuccessfulResult = result
+			}
+		}
+	}
+	if numberOfFailingAgents >= endpoint.AgentFailureThreshold {
+		aggregatedResult := *latestUnsuccessfulResult
+		return &aggregatedResult
+	}
+	aggregatedResult := *latestResult
+	aggregatedResult.Success = true
+	// The conditions that failed for some of the agents mustn't trigger the alerts scoped to them either
+	aggregatedResult.ConditionResults = nil
+	return &aggregatedResult
+}
+
+// resetAgentResults forgets the results reported by the agents, since the endpoints they're for may have changed
+func resetAgentResults() {
+	agentRoundsMutex.Lock()
+	defer agentRoundsMutex.Unlock()
+	agentRounds = make(map[string]*agentRound)
+}
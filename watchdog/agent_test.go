@@ -0,0 +1,150 @@
+package watchdog
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/custom"
+	"github.com/TwiN/gatus/v5/alerting/provider/discord"
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+)
+
+func TestIsCheckedLocally(t *testing.T) {
+	endpointWithoutAgents := &core.Endpoint{Name: "without-agents"}
+	endpointWithAgents := &core.Endpoint{Name: "with-agents", Agents: []string{"eu-west"}}
+	if !isCheckedLocally(endpointWithoutAgents, nil) || isCheckedLocally(endpointWithAgents, nil) {
+		t.Error("expected only the endpoints without agents to be checked by the central instance")
+	}
+	agentConfig := &agent.Config{Name: "eu-west"}
+	if isCheckedLocally(endpointWithoutAgents, agentConfig) || !isCheckedLocally(endpointWithAgents, agentConfig) {
+		t.Error("expected only the endpoints assigned to the agent to be checked by the agent")
+	}
+	if isCheckedLocally(endpointWithAgents, &agent.Config{Name: "us-east"}) {
+		t.Error("expected the endpoints assigned to other agents not to be checked by the agent")
+	}
+}
+
+func TestRecordAgentResult(t *testing.T) {
+	defer resetAgentResults()
+	endpoint := &core.Endpoint{Name: "frontend", Agents: []string{"eu-west", "us-east", "ap-south"}, AgentFailureThreshold: 2}
+	failedConditions := []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}
+	now := time.Now()
+	// The round is only completed once every agent has reported a result
+	if results := recordAgentResult(endpoint, &core.Result{Agent: "eu-west", ConditionResults: failedConditions, Timestamp: now}); len(results) != 0 {
+		t.Fatalf("expected no round to be completed, got %d", len(results))
+	}
+	if results := recordAgentResult(endpoint, &core.Result{Agent: "us-east", ConditionResults: failedConditions, Timestamp: now.Add(time.Second)}); len(results) != 0 {
+		t.Fatalf("expected no round to be completed, got %d", len(results))
+	}
+	results := recordAgentResult(endpoint, &core.Result{Agent: "ap-south", Success: true, Timestamp: now.Add(2 * time.Second)})
+	if len(results) != 1 {
+		t.Fatalf("expected a round to be completed, got %d", len(results))
+	}
+	if results[0].Success || results[0].Agent != "us-east" || len(results[0].ConditionResults) != 1 {
+		t.Error("expected the endpoint to be unhealthy once 2 agents are failing, based on the most recent unsuccessful result")
+	}
+	// A single failing agent isn't enough for the endpoint to be unhealthy
+	recordAgentResult(endpoint, &core.Result{Agent: "eu-west", Success: true, Timestamp: now.Add(3 * time.Second)})
+	recordAgentResult(endpoint, &core.Result{Agent: "us-east", ConditionResults: failedConditions, Timestamp: now.Add(4 * time.Second)})
+	results = recordAgentResult(endpoint, &core.Result{Agent: "ap-south", Success: true, Timestamp: now.Add(5 * time.Second)})
+	if len(results) != 1 || !results[0].Success || results[0].ConditionResults != nil {
+		t.Error("expected a single failing agent not to be enough for the endpoint to be unhealthy")
+	}
+	// If an agent reports again before the others did, the round is completed with the previous results of the others
+	recordAgentResult(endpoint, &core.Result{Agent: "eu-west", ConditionResults: failedConditions, Timestamp: now.Add(6 * time.Second)})
+	results = recordAgentResult(endpoint, &core.Result{Agent: "eu-west", ConditionResults: failedConditions, Timestamp: now.Add(7 * time.Second)})
+	if len(results) != 1 || results[0].Success {
+		t.Error("expected the round to be completed without the late agents, and the endpoint to be unhealthy")
+	}
+	// Results from agents that don't check the endpoint are ignored
+	if results := recordAgentResult(endpoint, &core.Result{Agent: "sa-east", Timestamp: now.Add(8 * time.Second)}); len(results) != 0 {
+		t.Error("expected the results of agents that don't check the endpoint to be ignored")
+	}
+}
+
+func TestHandleAgentResultHandlesAlertingOncePerRound(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+	defer resetAgentResults()
+	cfg := &config.Config{
+		Alerting:    &alerting.Config{Custom: &custom.AlertProvider{URL: "https://twin.sh/health", Method: "GET"}},
+		Maintenance: maintenance.GetDefaultConfig(),
+	}
+	endpoint := &core.Endpoint{
+		Name:       "endpoint",
+		URL:        "https://example.org/health",
+		Conditions: []core.Condition{"[STATUS] == 200"},
+		Agents:     []string{"eu-west", "us-east", "ap-south"},
+		Alerts:     []*alert.Alert{{Type: alert.TypeCustom, FailureThreshold: 2, SuccessThreshold: 1}},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, agentName := range endpoint.Agents {
+		HandleAgentResult(endpoint, &core.Result{Agent: agentName, Timestamp: time.Now()}, cfg)
+	}
+	if endpoint.NumberOfFailuresInARow != 1 || endpoint.Alerts[0].Triggered {
+		t.Errorf("expected the failing results of the agents to count as a single failure, got %d failures in a row", endpoint.NumberOfFailuresInARow)
+	}
+	for _, agentName := range endpoint.Agents {
+		HandleAgentResult(endpoint, &core.Result{Agent: agentName, Timestamp: time.Now()}, cfg)
+	}
+	if endpoint.NumberOfFailuresInARow != 2 || !endpoint.Alerts[0].Triggered {
+		t.Errorf("expected the alert to have been triggered after 2 rounds, got %d failures in a row", endpoint.NumberOfFailuresInARow)
+	}
+}
+
+func TestHandleAgentResultWithAlertsScopedToConditions(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	defer store.Get().Clear()
+	defer resetAgentResults()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom:  &custom.AlertProvider{URL: "https://twin.sh/health", Method: "GET"},
+			Discord: &discord.AlertProvider{WebhookURL: "https://example.com"},
+		},
+		Maintenance: maintenance.GetDefaultConfig(),
+	}
+	endpoint := &core.Endpoint{
+		Name:       "endpoint",
+		URL:        "https://example.org/health",
+		Conditions: []core.Condition{"[STATUS] == 200", "[BODY].status == UP"},
+		Agents:     []string{"eu-west"},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, FailureThreshold: 1, SuccessThreshold: 1, Conditions: []string{"[STATUS] == 200"}},
+			{Type: alert.TypeDiscord, FailureThreshold: 1, SuccessThreshold: 1, Conditions: []string{"[BODY]"}},
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Results reported by agents are sent as JSON, which doesn't include the conditions as configured
+	report, _ := json.Marshal(agent.NewReport(endpoint, &core.Result{
+		Success: false,
+		ConditionResults: []*core.ConditionResult{
+			{Condition: "[STATUS] (500) == 200", Success: false},
+			{Condition: "[BODY].status == UP", Success: true},
+		},
+	}))
+	var decodedReport agent.Report
+	if err := json.Unmarshal(report, &decodedReport); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	HandleAgentResult(endpoint, decodedReport.ToResult("eu-west"), cfg)
+	if !endpoint.Alerts[0].Triggered {
+		t.Error("expected the alert scoped to the condition that failed to have been triggered")
+	}
+	if endpoint.Alerts[1].Triggered {
+		t.Error("expected the alert scoped to the condition that succeeded not to have been triggered")
+	}
+}
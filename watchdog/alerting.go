@@ -4,81 +4,324 @@ import (
 	"errors"
 	"log"
 	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/config/selfmonitoring"
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/storage/store"
 )
 
+var (
+	// alertStateMutexes guard the state of the alerts of each endpoint, by endpoint key, since said state is modified
+	// both while handling the results of the endpoint and through the API (e.g. to acknowledge alerts)
+	alertStateMutexes      = make(map[string]*sync.Mutex)
+	alertStateMutexesMutex sync.Mutex
+)
+
+// getAlertStateMutex returns the mutex guarding the state of the alerts of the endpoint
+func getAlertStateMutex(endpoint *core.Endpoint) *sync.Mutex {
+	alertStateMutexesMutex.Lock()
+	defer alertStateMutexesMutex.Unlock()
+	mutex, exists := alertStateMutexes[endpoint.Key()]
+	if !exists {
+		mutex = &sync.Mutex{}
+		alertStateMutexes[endpoint.Key()] = mutex
+	}
+	return mutex
+}
+
+// WithAlertState calls f while holding the lock guarding the state of the alerts of the endpoint, which includes the
+// number of failures and successes in a row of the endpoint and of its alerts, and whether they're triggered or
+// acknowledged. It must be used to read or modify said state from outside the watchdog.
+func WithAlertState(endpoint *core.Endpoint, f func()) {
+	mutex := getAlertStateMutex(endpoint)
+	mutex.Lock()
+	defer mutex.Unlock()
+	f()
+}
+
+// IsAlertingSilenced returns whether all alerts are currently silenced
+func IsAlertingSilenced() bool {
+	silencedUntil, err := store.Get().GetAlertingSilence()
+	if err != nil {
+		log.Println("[watchdog][IsAlertingSilenced] Failed to retrieve alerting silence from storage:", err.Error())
+		return false
+	}
+	return time.Now().Before(silencedUntil)
+}
+
 // HandleAlerting takes care of alerts to resolve and alerts to trigger based on result success or failure
-func HandleAlerting(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, debug bool) {
+//
+// Alerts scoped to conditions are triggered and resolved based on the outcome of the conditions they are scoped to
+// rather than on the success of the result.
+func HandleAlerting(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, enabledMetrics, debug bool) {
 	if alertingConfig == nil {
 		return
 	}
+	mutex := getAlertStateMutex(endpoint)
+	mutex.Lock()
+	defer mutex.Unlock()
 	if result.Success {
-		handleAlertsToResolve(endpoint, result, alertingConfig, debug)
+		handleAlertsToResolve(endpoint, result, alertingConfig, enabledMetrics, debug)
 	} else {
-		handleAlertsToTrigger(endpoint, result, alertingConfig, debug)
+		handleAlertsToTrigger(endpoint, result, alertingConfig, enabledMetrics, debug)
 	}
+	handleAlertsScopedToConditions(endpoint, result, alertingConfig, enabledMetrics, debug)
 }
 
-func handleAlertsToTrigger(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, debug bool) {
+func handleAlertsToTrigger(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, enabledMetrics, debug bool) {
 	endpoint.NumberOfSuccessesInARow = 0
 	endpoint.NumberOfFailuresInARow++
 	for _, endpointAlert := range endpoint.Alerts {
-		// If the alert hasn't been triggered, move to the next one
-		if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > endpoint.NumberOfFailuresInARow {
+		if endpointAlert.IsScopedToConditions() {
 			continue
 		}
-		if endpointAlert.Triggered {
-			if debug {
-				log.Printf("[watchdog][handleAlertsToTrigger] Alert for endpoint=%s with description='%s' has already been TRIGGERED, skipping", endpoint.Name, endpointAlert.GetDescription())
-			}
+		// If the alert hasn't been triggered, move to the next one
+		if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > endpoint.NumberOfFailuresInARow {
 			continue
 		}
-		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
-		if alertProvider != nil {
-			log.Printf("[watchdog][handleAlertsToTrigger] Sending %s alert because alert for endpoint=%s with description='%s' has been TRIGGERED", endpointAlert.Type, endpoint.Name, endpointAlert.GetDescription())
-			var err error
-			if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
-				if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
-					err = errors.New("error")
-				}
-			} else {
-				err = alertProvider.Send(endpoint, endpointAlert, result, false)
-			}
-			if err != nil {
-				log.Printf("[watchdog][handleAlertsToTrigger] Failed to send an alert for endpoint=%s: %s", endpoint.Name, err.Error())
-			} else {
-				endpointAlert.Triggered = true
-			}
-		} else {
-			log.Printf("[watchdog][handleAlertsToResolve] Not sending alert of type=%s despite being TRIGGERED, because the provider wasn't configured properly", endpointAlert.Type)
-		}
+		triggerAlert(endpoint, endpointAlert, result, alertingConfig, enabledMetrics, debug)
 	}
 }
 
-func handleAlertsToResolve(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, debug bool) {
+func handleAlertsToResolve(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, enabledMetrics, debug bool) {
 	endpoint.NumberOfSuccessesInARow++
 	for _, endpointAlert := range endpoint.Alerts {
+		if endpointAlert.IsScopedToConditions() {
+			continue
+		}
+		// Once the alert is resolved, the acknowledgement is no longer relevant
+		if endpointAlert.SuccessThreshold <= endpoint.NumberOfSuccessesInARow {
+			endpointAlert.Acknowledgement = nil
+		}
 		if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || endpointAlert.SuccessThreshold > endpoint.NumberOfSuccessesInARow {
 			continue
 		}
-		// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
-		// Further explanation can be found on Alert's Triggered field.
-		endpointAlert.Triggered = false
-		if !endpointAlert.IsSendingOnResolved() {
+		resolveAlert(endpoint, endpointAlert, result, alertingConfig, enabledMetrics)
+	}
+	endpoint.NumberOfFailuresInARow = 0
+}
+
+// handleAlertsScopedToConditions triggers the alerts scoped to conditions if at least one of their conditions failed
+// enough times in a row, and resolves them once none of them failed enough times in a row.
+//
+// If several conditions fail at the same time, every alert scoped to at least one of them is triggered, in addition
+// to the alerts that aren't scoped to conditions.
+func handleAlertsScopedToConditions(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, enabledMetrics, debug bool) {
+	for _, endpointAlert := range endpoint.Alerts {
+		if !endpointAlert.IsScopedToConditions() {
 			continue
 		}
-		alertProvider := alertingConfig.GetAlertingProviderByAlertType(endpointAlert.Type)
-		if alertProvider != nil {
-			log.Printf("[watchdog][handleAlertsToResolve] Sending %s alert because alert for endpoint=%s with description='%s' has been RESOLVED", endpointAlert.Type, endpoint.Name, endpointAlert.GetDescription())
-			err := alertProvider.Send(endpoint, endpointAlert, result, true)
-			if err != nil {
-				log.Printf("[watchdog][handleAlertsToResolve] Failed to send an alert for endpoint=%s: %s", endpoint.Name, err.Error())
+		if result.HasFailedConditionInScope(endpointAlert.Conditions) {
+			endpointAlert.NumberOfSuccessesInARow = 0
+			endpointAlert.NumberOfFailuresInARow++
+			if !endpointAlert.IsEnabled() || endpointAlert.FailureThreshold > endpointAlert.NumberOfFailuresInARow {
+				continue
 			}
+			triggerAlert(endpoint, endpointAlert, result, alertingConfig, enabledMetrics, debug)
 		} else {
-			log.Printf("[watchdog][handleAlertsToResolve] Not sending alert of type=%s despite being RESOLVED, because the provider wasn't configured properly", endpointAlert.Type)
+			endpointAlert.NumberOfSuccessesInARow++
+			endpointAlert.NumberOfFailuresInARow = 0
+			// Once the alert is resolved, the acknowledgement is no longer relevant
+			if endpointAlert.SuccessThreshold <= endpointAlert.NumberOfSuccessesInARow {
+				endpointAlert.Acknowledgement = nil
+			}
+			if !endpointAlert.IsEnabled() || !endpointAlert.Triggered || endpointAlert.SuccessThreshold > endpointAlert.NumberOfSuccessesInARow {
+				continue
+			}
+			resolveAlert(endpoint, endpointAlert, result, alertingConfig, enabledMetrics)
 		}
 	}
-	endpoint.NumberOfFailuresInARow = 0
+}
+
+// triggerAlert sends an alert whose failure threshold has been reached, unless it has already been triggered or has
+// been acknowledged
+//
+// If the alert has already been triggered, a reminder is sent instead if one is due.
+func triggerAlert(endpoint *core.Endpoint, endpointAlert *alert.Alert, result *core.Result, alertingConfig *alerting.Config, enabledMetrics, debug bool) {
+	if endpointAlert.Triggered {
+		if endpointAlert.IsReminderDue(time.Now()) && !endpointAlert.IsAcknowledged() {
+			remindAlert(endpoint, endpointAlert, result, alertingConfig, enabledMetrics)
+			return
+		}
+		if debug {
+			log.Printf("[watchdog][triggerAlert] Alert for endpoint=%s with description='%s' has already been TRIGGERED, skipping", endpoint.Name, endpointAlert.GetDescription())
+		}
+		return
+	}
+	if endpointAlert.IsAcknowledged() {
+		if debug {
+			log.Printf("[watchdog][triggerAlert] Alert for endpoint=%s with description='%s' has been ACKNOWLEDGED, skipping", endpoint.Name, endpointAlert.GetDescription())
+		}
+		return
+	}
+	route := alertingConfig.GetRoute(endpointAlert.Type, time.Now())
+	alertType := route.GetProvider(endpointAlert.Type)
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(alertType)
+	if alertProvider == nil {
+		log.Printf("[watchdog][triggerAlert] Not sending alert of type=%s despite being TRIGGERED, because the provider wasn't configured properly", alertType)
+		return
+	}
+	if handled, err := handleDigestOnTrigger(alertProvider, endpoint, endpointAlert, result, alertingConfig, enabledMetrics); handled {
+		if err != nil {
+			log.Printf("[watchdog][triggerAlert] Failed to send a digest for group=%s: %s", endpoint.Group, err.Error())
+		} else {
+			log.Printf("[watchdog][triggerAlert] Not sending %s alert for endpoint=%s with description='%s', because it is part of the digest of group=%s", endpointAlert.Type, endpoint.Name, endpointAlert.GetDescription(), endpoint.Group)
+			endpointAlert.MarkAsTriggered(time.Now())
+		}
+		return
+	}
+	log.Printf("[watchdog][triggerAlert] Sending %s alert because alert for endpoint=%s with description='%s' has been TRIGGERED", alertType, endpoint.Name, endpointAlert.GetDescription())
+	err := sendAlert(alertProvider, route, endpoint, endpointAlert, result, false, false, alertingConfig, enabledMetrics)
+	if err != nil {
+		log.Printf("[watchdog][triggerAlert] Failed to send an alert for endpoint=%s: %s", endpoint.Name, err.Error())
+	} else {
+		endpointAlert.MarkAsTriggered(time.Now())
+	}
+}
+
+// remindAlert sends a reminder for an alert that has remained triggered for longer than its reminder interval
+//
+// Reminders are sent directly rather than through the digest of the group of the endpoint, since a digest only lists
+// the alerts that have been triggered or resolved since the previous one.
+func remindAlert(endpoint *core.Endpoint, endpointAlert *alert.Alert, result *core.Result, alertingConfig *alerting.Config, enabledMetrics bool) {
+	now := time.Now()
+	route := alertingConfig.GetRoute(endpointAlert.Type, now)
+	alertType := route.GetProvider(endpointAlert.Type)
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(alertType)
+	if alertProvider == nil {
+		log.Printf("[watchdog][remindAlert] Not sending reminder of type=%s, because the provider wasn't configured properly", alertType)
+		return
+	}
+	log.Printf("[watchdog][remindAlert] Sending %s reminder because alert for endpoint=%s with description='%s' has been TRIGGERED for %s", alertType, endpoint.Name, endpointAlert.GetDescription(), now.Sub(endpointAlert.TriggeredAt).Round(time.Second))
+	err := sendAlert(alertProvider, route, endpoint, endpointAlert, result, false, true, alertingConfig, enabledMetrics)
+	if err != nil {
+		log.Printf("[watchdog][remindAlert] Failed to send a reminder for endpoint=%s: %s", endpoint.Name, err.Error())
+	} else {
+		endpointAlert.LastReminderAt = now
+	}
+}
+
+// resolveAlert marks a triggered alert whose success threshold has been reached as resolved, and sends the resolved
+// notification if the alert is configured to do so
+func resolveAlert(endpoint *core.Endpoint, endpointAlert *alert.Alert, result *core.Result, alertingConfig *alerting.Config, enabledMetrics bool) {
+	// Even if the alert provider returns an error, we still set the alert's Triggered variable to false.
+	// Further explanation can be found on Alert's Triggered field.
+	endpointAlert.MarkAsResolved()
+	route := alertingConfig.GetRoute(endpointAlert.Type, time.Now())
+	alertType := route.GetProvider(endpointAlert.Type)
+	alertProvider := alertingConfig.GetAlertingProviderByAlertType(alertType)
+	if alertProvider != nil {
+		if handled, err := handleDigestOnResolve(alertProvider, endpoint, endpointAlert, alertingConfig, enabledMetrics); handled {
+			if err != nil {
+				log.Printf("[watchdog][resolveAlert] Failed to send a digest for group=%s: %s", endpoint.Group, err.Error())
+			}
+			return
+		}
+	}
+	if !endpointAlert.IsSendingOnResolved() {
+		return
+	}
+	if alertProvider != nil {
+		log.Printf("[watchdog][resolveAlert] Sending %s alert because alert for endpoint=%s with description='%s' has been RESOLVED", alertType, endpoint.Name, endpointAlert.GetDescription())
+		err := sendAlert(alertProvider, route, endpoint, endpointAlert, result, true, false, alertingConfig, enabledMetrics)
+		if err != nil {
+			log.Printf("[watchdog][resolveAlert] Failed to send an alert for endpoint=%s: %s", endpoint.Name, err.Error())
+		}
+	} else {
+		log.Printf("[watchdog][resolveAlert] Not sending alert of type=%s despite being RESOLVED, because the provider wasn't configured properly", alertType)
+	}
+}
+
+// alertDescriptionData is the data the description of an alert is rendered with
+type alertDescriptionData struct {
+	// Endpoint is the endpoint the alert is for
+	Endpoint *core.Endpoint
+
+	// Result is the result that caused the alert to be triggered or resolved
+	Result *core.Result
+
+	// FailedConditions are the conditions of the result that failed
+	FailedConditions []string
+}
+
+func newAlertDescriptionData(endpoint *core.Endpoint, result *core.Result) *alertDescriptionData {
+	data := &alertDescriptionData{Endpoint: endpoint, Result: result}
+	if result != nil {
+		for _, conditionResult := range result.ConditionResults {
+			if !conditionResult.Success {
+				data.FailedConditions = append(data.FailedConditions, conditionResult.Condition)
+			}
+		}
+	}
+	return data
+}
+
+// numberOfFailedAlerts is the number of alerts and digests that could not be sent since the application started
+var numberOfFailedAlerts uint64
+
+// GetNumberOfFailedAlerts returns the number of alerts and digests that could not be sent, whether because the provider
+// returned an error or because its circuit breaker was open, since the application started
+func GetNumberOfFailedAlerts() uint64 {
+	return atomic.LoadUint64(&numberOfFailedAlerts)
+}
+
+// sendAlert sends an alert using the given provider, unless the circuit breaker of the provider is open
+//
+// The description of the alert is rendered with the endpoint and the result before being passed to the provider. If
+// the alert is a reminder, the description is also prefixed with how long the alert has been triggered for.
+// If the alert is routed, the provider is the one of the route, and if the route has a group, the provider is passed
+// a copy of the endpoint with that group, so that it uses the override of that group.
+//
+// The alerts of the endpoint monitoring Gatus itself bypass the circuit breaker and aren't counted as failed alerts,
+// since they may be about alerting being broken, in which case failing to send them would otherwise keep the endpoint
+// unhealthy.
+func sendAlert(alertProvider provider.AlertProvider, route *alerting.Route, endpoint *core.Endpoint, endpointAlert *alert.Alert, result *core.Result, resolved, reminder bool, alertingConfig *alerting.Config, enabledMetrics bool) error {
+	alertType := route.GetProvider(endpointAlert.Type)
+	var circuitBreaker *alerting.CircuitBreaker
+	isSelfMonitoringEndpoint := selfmonitoring.IsSelfMonitoringEndpoint(endpoint)
+	if !isSelfMonitoringEndpoint {
+		circuitBreaker = alertingConfig.GetCircuitBreaker(alertType)
+	}
+	if !circuitBreaker.Allow() {
+		atomic.AddUint64(&numberOfFailedAlerts, 1)
+		return alerting.ErrCircuitBreakerOpen
+	}
+	endpointAlert.RenderDescription(newAlertDescriptionData(endpoint, result))
+	if reminder {
+		endpointAlert.RenderReminderDescription(time.Now())
+	}
+	defer endpointAlert.ClearRenderedDescription()
+	var err error
+	if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
+		if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
+			err = errors.New("error")
+		}
+	} else {
+		routedEndpoint := endpoint
+		if group := route.GetGroup(endpoint.Group); group != endpoint.Group {
+			endpointCopy := *endpoint
+			endpointCopy.Group = group
+			routedEndpoint = &endpointCopy
+		}
+		err = alertProvider.Send(routedEndpoint, endpointAlert, result, resolved)
+	}
+	if err != nil {
+		if !isSelfMonitoringEndpoint {
+			atomic.AddUint64(&numberOfFailedAlerts, 1)
+		}
+		circuitBreaker.RecordFailure()
+	} else {
+		circuitBreaker.RecordSuccess()
+	}
+	if circuitBreaker != nil && enabledMetrics {
+		metrics.PublishMetricsForAlertingCircuitBreaker(alertType, circuitBreaker.State())
+	}
+	return err
 }
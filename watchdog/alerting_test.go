@@ -1,8 +1,12 @@
 package watchdog
 
 import (
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/alerting/alert"
@@ -19,7 +23,9 @@ import (
 	"github.com/TwiN/gatus/v5/alerting/provider/telegram"
 	"github.com/TwiN/gatus/v5/alerting/provider/twilio"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/selfmonitoring"
 	"github.com/TwiN/gatus/v5/core"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestHandleAlerting(t *testing.T) {
@@ -51,28 +57,28 @@ func TestHandleAlerting(t *testing.T) {
 	}
 
 	verify(t, endpoint, 0, 0, false, "The alert shouldn't start triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, false, "The alert shouldn't have triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 2, 0, true, "The alert should've triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 3, 0, true, "The alert should still be triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 4, 0, true, "The alert should still be triggered")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, true, "The alert should still be triggered (because endpoint.Alerts[0].SuccessThreshold is 3)")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 2, true, "The alert should still be triggered (because endpoint.Alerts[0].SuccessThreshold is 3)")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 3, false, "The alert should've been resolved")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 4, false, "The alert should no longer be triggered")
 }
 
 func TestHandleAlertingWhenAlertingConfigIsNil(t *testing.T) {
 	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
 	defer os.Clearenv()
-	HandleAlerting(nil, nil, nil, true)
+	HandleAlerting(nil, nil, nil, false, true)
 }
 
 func TestHandleAlertingWithBadAlertProvider(t *testing.T) {
@@ -95,9 +101,9 @@ func TestHandleAlertingWithBadAlertProvider(t *testing.T) {
 	}
 
 	verify(t, endpoint, 0, 0, false, "The alert shouldn't start triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, &alerting.Config{}, false)
+	HandleAlerting(endpoint, &core.Result{Success: false}, &alerting.Config{}, false, false)
 	verify(t, endpoint, 1, 0, false, "The alert shouldn't have triggered")
-	HandleAlerting(endpoint, &core.Result{Success: false}, &alerting.Config{}, false)
+	HandleAlerting(endpoint, &core.Result{Success: false}, &alerting.Config{}, false, false)
 	verify(t, endpoint, 2, 0, false, "The alert shouldn't have triggered, because the provider wasn't configured properly")
 }
 
@@ -131,7 +137,7 @@ func TestHandleAlertingWhenTriggeredAlertIsAlmostResolvedButendpointStartFailing
 	}
 
 	// This test simulate an alert that was already triggered
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 2, 0, true, "The alert was already triggered at the beginning of this test")
 }
 
@@ -165,7 +171,7 @@ func TestHandleAlertingWhenTriggeredAlertIsResolvedButSendOnResolvedIsFalse(t *t
 		NumberOfFailuresInARow: 1,
 	}
 
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "The alert should've been resolved")
 }
 
@@ -197,10 +203,10 @@ func TestHandleAlertingWhenTriggeredAlertIsResolvedPagerDuty(t *testing.T) {
 		NumberOfFailuresInARow: 0,
 	}
 
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, true, "")
 
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "The alert should've been resolved")
 }
 
@@ -233,10 +239,10 @@ func TestHandleAlertingWhenTriggeredAlertIsResolvedPushover(t *testing.T) {
 		NumberOfFailuresInARow: 0,
 	}
 
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, true, "")
 
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "The alert should've been resolved")
 }
 
@@ -391,32 +397,32 @@ func TestHandleAlertingWithProviderThatReturnsAnError(t *testing.T) {
 				},
 			}
 			_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 1, 0, false, "")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 2, 0, false, "The alert should have failed to trigger, because the alert provider is returning an error")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 3, 0, false, "The alert should still not be triggered, because the alert provider is still returning an error")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 4, 0, false, "The alert should still not be triggered, because the alert provider is still returning an error")
 			_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 5, 0, true, "The alert should've been triggered because the alert provider is no longer returning an error")
-			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 0, 1, true, "The alert should've still been triggered")
 			_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
-			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 0, 2, false, "The alert should've been resolved DESPITE THE ALERT PROVIDER RETURNING AN ERROR. See Alert.Triggered for further explanation.")
 			_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
 
 			// Make sure that everything's working as expected after a rough patch
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 1, 0, false, "")
-			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: false}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 2, 0, true, "The alert should have triggered")
-			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 0, 1, true, "The alert should still be triggered")
-			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, true)
+			HandleAlerting(endpoint, &core.Result{Success: true}, scenario.AlertingConfig, false, true)
 			verify(t, endpoint, 0, 2, false, "The alert should have been resolved")
 		})
 	}
@@ -451,30 +457,170 @@ func TestHandleAlertingWithProviderThatOnlyReturnsErrorOnResolve(t *testing.T) {
 		},
 	}
 
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, true, "")
 	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "")
 	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, true, "")
 	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "")
 	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
 
 	// Make sure that everything's working as expected after a rough patch
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 1, 0, true, "")
-	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 2, 0, true, "")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 1, false, "")
-	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
 	verify(t, endpoint, 0, 2, false, "")
 }
 
+func TestHandleAlertingWithCircuitBreaker(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Debug: true,
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+			CircuitBreaker: &alerting.CircuitBreakerConfig{FailureThreshold: 2, Cooldown: time.Hour},
+		},
+	}
+	enabled := true
+	endpoint := &core.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				SendOnResolved:   &enabled,
+				Triggered:        false,
+			},
+		},
+	}
+	circuitBreaker := cfg.Alerting.GetCircuitBreaker(alert.TypeCustom)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 1, 0, false, "The alert shouldn't have triggered, because the provider returned an error")
+	if circuitBreaker.State() != alerting.CircuitBreakerStateClosed {
+		t.Error("The circuit breaker should still be closed after a single failure")
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 2, 0, false, "The alert shouldn't have triggered, because the provider returned an error")
+	if circuitBreaker.State() != alerting.CircuitBreakerStateOpen {
+		t.Error("The circuit breaker should've opened after reaching the failure threshold")
+	}
+	// Even if the provider recovered, alerts shouldn't be sent until the cooldown has elapsed
+	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 3, 0, false, "The alert shouldn't have triggered, because the circuit breaker is open")
+}
+
+func TestHandleAlertingWithCircuitBreakerAndMetrics(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+			CircuitBreaker: &alerting.CircuitBreakerConfig{FailureThreshold: 10, Cooldown: time.Hour},
+		},
+	}
+	enabled := true
+	endpoint := &core.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+			},
+		},
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if hasMetric(t, "gatus_alerting_circuit_breaker_state") {
+		t.Error("The state of the circuit breaker shouldn't have been published, because metrics are disabled")
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, true, cfg.Debug)
+	if !hasMetric(t, "gatus_alerting_circuit_breaker_state") {
+		t.Error("The state of the circuit breaker should've been published, because metrics are enabled")
+	}
+}
+
+// hasMetric returns whether at least one series of the metric with the given name has been published
+func hasMetric(t *testing.T, name string) bool {
+	metricFamilies, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	for _, metricFamily := range metricFamilies {
+		if metricFamily.GetName() == name && len(metricFamily.GetMetric()) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func TestHandleAlertingWithCircuitBreakerAndSelfMonitoringEndpoint(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+			CircuitBreaker: &alerting.CircuitBreakerConfig{FailureThreshold: 1, Cooldown: time.Hour},
+		},
+	}
+	enabled := true
+	endpoint := &core.Endpoint{
+		Name:  selfmonitoring.EndpointName,
+		Group: selfmonitoring.EndpointGroup,
+		URL:   "http://127.0.0.1:8080/api/v1/self/health",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+			},
+		},
+	}
+	numberOfFailedAlerts := GetNumberOfFailedAlerts()
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 1, 0, false, "The alert shouldn't have triggered, because the provider returned an error")
+	if cfg.Alerting.GetCircuitBreaker(alert.TypeCustom).State() != alerting.CircuitBreakerStateClosed {
+		t.Error("The circuit breaker shouldn't have recorded the failure of an alert of the self-monitoring endpoint")
+	}
+	if GetNumberOfFailedAlerts() != numberOfFailedAlerts {
+		t.Error("The failure of an alert of the self-monitoring endpoint shouldn't have been counted")
+	}
+	// The self-monitoring endpoint must still be able to alert when the circuit breaker is open
+	cfg.Alerting.GetCircuitBreaker(alert.TypeCustom).RecordFailure()
+	_ = os.Setenv("MOCK_ALERT_PROVIDER_ERROR", "false")
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 2, 0, true, "The alert should've triggered despite the circuit breaker being open")
+}
+
 func verify(t *testing.T, endpoint *core.Endpoint, expectedNumberOfFailuresInARow, expectedNumberOfSuccessInARow int, expectedTriggered bool, expectedTriggeredReason string) {
 	if endpoint.NumberOfFailuresInARow != expectedNumberOfFailuresInARow {
 		t.Errorf("endpoint.NumberOfFailuresInARow should've been %d, got %d", expectedNumberOfFailuresInARow, endpoint.NumberOfFailuresInARow)
@@ -494,3 +640,248 @@ func verify(t *testing.T, endpoint *core.Endpoint, expectedNumberOfFailuresInARo
 		}
 	}
 }
+
+func TestHandleAlertingWithAcknowledgedAlert(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{
+				URL:    "https://twin.sh/health",
+				Method: "GET",
+			},
+		},
+	}
+	enabled := true
+	endpoint := &core.Endpoint{
+		URL: "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				FailureThreshold: 2,
+				SuccessThreshold: 1,
+				SendOnResolved:   &enabled,
+				Triggered:        false,
+			},
+		},
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 1, 0, false, "The alert shouldn't have triggered")
+	endpoint.Alerts[0].Acknowledge("john.doe", 0)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 2, 0, false, "The alert shouldn't have been sent, because it has been acknowledged")
+	if !endpoint.Alerts[0].IsAcknowledged() {
+		t.Error("The alert should still be acknowledged")
+	}
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 0, 1, false, "The alert should've been resolved")
+	if endpoint.Alerts[0].Acknowledgement != nil {
+		t.Error("The acknowledgement should've been cleared after the alert was resolved")
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	verify(t, endpoint, 2, 0, true, "The alert should've triggered, because the acknowledgement was cleared")
+}
+
+func TestHandleAlertingWithAlertsScopedToConditions(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	statusCode, body := http.StatusOK, `{"status":"UP"}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statusCode)
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom:  &custom.AlertProvider{URL: "https://twin.sh/health", Method: "GET"},
+			Discord: &discord.AlertProvider{WebhookURL: "https://example.com"},
+			Slack:   &slack.AlertProvider{WebhookURL: "https://example.com"},
+		},
+	}
+	endpoint := &core.Endpoint{
+		Name:       "endpoint",
+		URL:        server.URL,
+		Conditions: []core.Condition{"[STATUS] == 200", "[BODY].status == UP"},
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeCustom, FailureThreshold: 1, SuccessThreshold: 1, Conditions: []string{"[STATUS] == 200"}},
+			{Type: alert.TypeDiscord, FailureThreshold: 1, SuccessThreshold: 1, Conditions: []string{"[BODY]"}},
+			{Type: alert.TypeSlack, FailureThreshold: 1, SuccessThreshold: 1},
+		},
+	}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	scenarios := []struct {
+		name              string
+		statusCode        int
+		body              string
+		expectedTriggered []bool
+	}{
+		{name: "healthy", statusCode: http.StatusOK, body: `{"status":"UP"}`, expectedTriggered: []bool{false, false, false}},
+		{name: "body-condition-failing", statusCode: http.StatusOK, body: `{"status":"DOWN"}`, expectedTriggered: []bool{false, true, true}},
+		{name: "both-conditions-failing", statusCode: http.StatusInternalServerError, body: `{"status":"DOWN"}`, expectedTriggered: []bool{true, true, true}},
+		{name: "status-condition-failing", statusCode: http.StatusInternalServerError, body: `{"status":"UP"}`, expectedTriggered: []bool{true, false, true}},
+		{name: "healthy-again", statusCode: http.StatusOK, body: `{"status":"UP"}`, expectedTriggered: []bool{false, false, false}},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			statusCode, body = scenario.statusCode, scenario.body
+			result := endpoint.EvaluateHealth()
+			HandleAlerting(endpoint, result, cfg.Alerting, false, cfg.Debug)
+			for i, expectedTriggered := range scenario.expectedTriggered {
+				if endpoint.Alerts[i].Triggered != expectedTriggered {
+					t.Errorf("expected alert of type %s to have triggered=%v, got %v", endpoint.Alerts[i].Type, expectedTriggered, endpoint.Alerts[i].Triggered)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleAlertingWithReminders(t *testing.T) {
+	var descriptions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		descriptions = append(descriptions, string(body))
+	}))
+	defer server.Close()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Custom: &custom.AlertProvider{URL: server.URL, Method: "POST", Body: "[ALERT_DESCRIPTION]"},
+		},
+	}
+	enabled, description := true, "backend is down"
+	endpoint := &core.Endpoint{
+		Name: "endpoint",
+		URL:  "https://example.com",
+		Alerts: []*alert.Alert{
+			{
+				Type:             alert.TypeCustom,
+				Enabled:          &enabled,
+				Description:      &description,
+				FailureThreshold: 1,
+				SuccessThreshold: 1,
+				ReminderInterval: time.Hour,
+			},
+		},
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if len(descriptions) != 1 || descriptions[0] != description {
+		t.Fatalf("expected only the alert to have been sent, got %v", descriptions)
+	}
+	// Pretend that the alert was triggered long enough ago for a reminder to be due
+	endpoint.Alerts[0].TriggeredAt = time.Now().Add(-2 * time.Hour)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if len(descriptions) != 2 || descriptions[1] != "Still triggered after 2h0m0s: "+description {
+		t.Fatalf("expected a reminder to have been sent, got %v", descriptions)
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if len(descriptions) != 2 {
+		t.Fatalf("expected no reminder to be sent until the reminder interval has elapsed again, got %v", descriptions)
+	}
+	endpoint.Alerts[0].Acknowledge("john.doe", 0)
+	endpoint.Alerts[0].LastReminderAt = time.Now().Add(-2 * time.Hour)
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if len(descriptions) != 2 {
+		t.Fatalf("expected no reminder to be sent for an acknowledged alert, got %v", descriptions)
+	}
+	HandleAlerting(endpoint, &core.Result{Success: true}, cfg.Alerting, false, cfg.Debug)
+	if endpoint.Alerts[0].Triggered || !endpoint.Alerts[0].TriggeredAt.IsZero() || !endpoint.Alerts[0].LastReminderAt.IsZero() {
+		t.Error("expected the alert to have been resolved and its reminder state cleared")
+	}
+}
+
+func TestHandleAlertingWithAlertTargets(t *testing.T) {
+	_ = os.Setenv("MOCK_ALERT_PROVIDER", "true")
+	defer os.Clearenv()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			PagerDuty: &pagerduty.AlertProvider{IntegrationKey: "00000000000000000000000000000000"},
+			Slack:     &slack.AlertProvider{WebhookURL: "https://example.com"},
+		},
+	}
+	enabled := true
+	endpointAlert := &alert.Alert{
+		Enabled:          &enabled,
+		SendOnResolved:   &enabled,
+		SuccessThreshold: 2,
+		Targets: []*alert.Target{
+			{Type: alert.TypePagerDuty, FailureThreshold: 1, SuccessThreshold: 1, Severity: "critical"},
+			{Type: alert.TypeSlack, FailureThreshold: 3},
+		},
+	}
+	alerts, err := endpointAlert.ExpandTargets()
+	if err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	endpoint := &core.Endpoint{Name: "endpoint", URL: "https://example.com", Alerts: alerts}
+	scenarios := []struct {
+		success           bool
+		expectedTriggered []bool
+	}{
+		{success: false, expectedTriggered: []bool{true, false}},
+		{success: false, expectedTriggered: []bool{true, false}},
+		{success: false, expectedTriggered: []bool{true, true}},
+		{success: true, expectedTriggered: []bool{false, true}},
+		{success: true, expectedTriggered: []bool{false, false}},
+	}
+	for i, scenario := range scenarios {
+		HandleAlerting(endpoint, &core.Result{Success: scenario.success}, cfg.Alerting, false, cfg.Debug)
+		for j, expectedTriggered := range scenario.expectedTriggered {
+			if endpoint.Alerts[j].Triggered != expectedTriggered {
+				t.Errorf("#%d: expected alert of type %s to have triggered=%v, got %v", i, endpoint.Alerts[j].Type, expectedTriggered, endpoint.Alerts[j].Triggered)
+			}
+		}
+	}
+}
+
+func TestHandleAlertingWithRoutes(t *testing.T) {
+	var numberOfRequestsByGroup = make(map[string]int)
+	newServer := func(group string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			numberOfRequestsByGroup[group]++
+		}))
+	}
+	defaultServer, apacServer := newServer(""), newServer("apac")
+	defer defaultServer.Close()
+	defer apacServer.Close()
+	cfg := &config.Config{
+		Alerting: &alerting.Config{
+			Discord: &discord.AlertProvider{
+				WebhookURL: defaultServer.URL,
+				Overrides:  []discord.Override{{Group: "apac", WebhookURL: apacServer.URL}},
+			},
+			Routes: []*alerting.Route{
+				// Applies for the whole day, and sends the alerts of type slack to the override of the apac group
+				// of discord, since slack isn't configured
+				{Types: []alert.Type{alert.TypeSlack}, Start: "00:00", End: "00:00", Provider: alert.TypeDiscord, Group: "apac"},
+			},
+		},
+	}
+	if err := cfg.Alerting.ValidateRoutes([]alert.Type{alert.TypeSlack}); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	enabled := true
+	endpoint := &core.Endpoint{
+		Name:  "endpoint",
+		Group: "core",
+		URL:   "https://example.com",
+		Alerts: []*alert.Alert{
+			{Type: alert.TypeSlack, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+			{Type: alert.TypeDiscord, Enabled: &enabled, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled},
+		},
+	}
+	HandleAlerting(endpoint, &core.Result{Success: false}, cfg.Alerting, false, cfg.Debug)
+	if !endpoint.Alerts[0].Triggered || !endpoint.Alerts[1].Triggered {
+		t.Error("expected both alerts to have been triggered")
+	}
+	if numberOfRequestsByGroup["apac"] != 1 || numberOfRequestsByGroup[""] != 1 {
+		t.Errorf("expected the routed alert to be sent to the override of the apac group and the other to the default webhook, got %v", numberOfRequestsByGroup)
+	}
+	if endpoint.Group != "core" {
+		t.Error("expected the group of the endpoint not to be modified by the route, got", endpoint.Group)
+	}
+}
@@ -0,0 +1,190 @@
+package watchdog
+
+import (
+	"errors"
+	"log"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
+)
+
+var (
+	digestMutex sync.Mutex
+
+	// groupDigests are the digests of each group, by alert type and group
+	groupDigests = make(map[alert.Type]map[string]*groupDigest)
+
+	// numberOfEndpointsByGroup is the number of enabled endpoints in each group
+	numberOfEndpointsByGroup = make(map[string]int)
+)
+
+// groupDigest is the state of the digest of a group for a given alert type
+type groupDigest struct {
+	// failingEndpoints are the endpoints of the group whose alert has been triggered, by endpoint key
+	failingEndpoints map[string]*alert.DigestEndpoint
+
+	// sent is whether a digest has been sent, meaning that the alerts of the endpoints of the group are suppressed
+	// until all of them are resolved
+	sent bool
+}
+
+// resetDigests clears the state of every digest and counts the endpoints of each group
+//
+// Must be called before the endpoints start being monitored.
+func resetDigests(endpoints []*core.Endpoint) {
+	digestMutex.Lock()
+	defer digestMutex.Unlock()
+	groupDigests = make(map[alert.Type]map[string]*groupDigest)
+	numberOfEndpointsByGroup = make(map[string]int)
+	for _, endpoint := range endpoints {
+		if endpoint.IsEnabled() {
+			numberOfEndpointsByGroup[endpoint.Group]++
+		}
+	}
+}
+
+// getDigestProvider returns the alert provider as a provider.DigestProvider if it supports digests and has them
+// enabled, and nil otherwise
+func getDigestProvider(alertProvider provider.AlertProvider) provider.DigestProvider {
+	if digestProvider, ok := alertProvider.(provider.DigestProvider); ok && digestProvider.GetDigestConfig() != nil {
+		return digestProvider
+	}
+	return nil
+}
+
+// handleDigestOnTrigger records that the alert of an endpoint has been triggered, and sends a digest instead of the
+// alert if enough endpoints of its group failed within the window of the digest.
+//
+// Returns whether the alert was handled by the digest, in which case it must not be sent.
+func handleDigestOnTrigger(alertProvider provider.AlertProvider, endpoint *core.Endpoint, endpointAlert *alert.Alert, result *core.Result, alertingConfig *alerting.Config, enabledMetrics bool) (bool, error) {
+	digestProvider := getDigestProvider(alertProvider)
+	if digestProvider == nil {
+		return false, nil
+	}
+	digestConfig := digestProvider.GetDigestConfig()
+	digestMutex.Lock()
+	defer digestMutex.Unlock()
+	digest := getOrCreateGroupDigest(endpointAlert.Type, endpoint.Group)
+	if _, exists := digest.failingEndpoints[endpoint.Key()]; !exists {
+		digestEndpoint := &alert.DigestEndpoint{Name: endpoint.Name, FailingSince: time.Now()}
+		for _, conditionResult := range result.ConditionResults {
+			if !conditionResult.Success {
+				digestEndpoint.FailedConditions = append(digestEndpoint.FailedConditions, conditionResult.Condition)
+			}
+		}
+		digest.failingEndpoints[endpoint.Key()] = digestEndpoint
+	}
+	if digest.sent {
+		// A digest has already been sent for this group, so there's no need to send anything else
+		return true, nil
+	}
+	numberOfEndpointsFailingWithinWindow := 0
+	windowStart := time.Now().Add(-digestConfig.GetWindow())
+	for _, failingEndpoint := range digest.failingEndpoints {
+		if failingEndpoint.FailingSince.After(windowStart) {
+			numberOfEndpointsFailingWithinWindow++
+		}
+	}
+	if numberOfEndpointsFailingWithinWindow < digestConfig.Threshold {
+		return false, nil
+	}
+	if err := sendDigest(digestProvider, endpointAlert.Type, digest.build(endpoint.Group, false), alertingConfig, enabledMetrics); err != nil {
+		return true, err
+	}
+	digest.sent = true
+	return true, nil
+}
+
+// handleDigestOnResolve records that the alert of an endpoint has been resolved, and, if a digest was sent for its
+// group, sends a resolved digest once every endpoint of the group has been resolved.
+//
+// Returns whether the alert was handled by the digest, in which case it must not be sent.
+func handleDigestOnResolve(alertProvider provider.AlertProvider, endpoint *core.Endpoint, endpointAlert *alert.Alert, alertingConfig *alerting.Config, enabledMetrics bool) (bool, error) {
+	digestProvider := getDigestProvider(alertProvider)
+	if digestProvider == nil {
+		return false, nil
+	}
+	digestMutex.Lock()
+	defer digestMutex.Unlock()
+	digest := getOrCreateGroupDigest(endpointAlert.Type, endpoint.Group)
+	delete(digest.failingEndpoints, endpoint.Key())
+	if !digest.sent {
+		return false, nil
+	}
+	if len(digest.failingEndpoints) > 0 {
+		return true, nil
+	}
+	// Every endpoint of the group has been resolved, so the alerts of the group must no longer be suppressed, whether
+	// a resolved digest is sent or not
+	digest.sent = false
+	if !endpointAlert.IsSendingOnResolved() {
+		return true, nil
+	}
+	return true, sendDigest(digestProvider, endpointAlert.Type, digest.build(endpoint.Group, true), alertingConfig, enabledMetrics)
+}
+
+func getOrCreateGroupDigest(alertType alert.Type, group string) *groupDigest {
+	if groupDigests[alertType] == nil {
+		groupDigests[alertType] = make(map[string]*groupDigest)
+	}
+	digest, exists := groupDigests[alertType][group]
+	if !exists {
+		digest = &groupDigest{failingEndpoints: make(map[string]*alert.DigestEndpoint)}
+		groupDigests[alertType][group] = digest
+	}
+	return digest
+}
+
+// build returns the digest to send for the group, with the failing endpoints sorted by the time they started failing
+func (digest *groupDigest) build(group string, resolved bool) *alert.Digest {
+	d := &alert.Digest{Group: group, NumberOfEndpoints: numberOfEndpointsByGroup[group], Resolved: resolved}
+	for _, failingEndpoint := range digest.failingEndpoints {
+		d.FailingEndpoints = append(d.FailingEndpoints, failingEndpoint)
+	}
+	sort.Slice(d.FailingEndpoints, func(i, j int) bool {
+		if d.FailingEndpoints[i].FailingSince.Equal(d.FailingEndpoints[j].FailingSince) {
+			return d.FailingEndpoints[i].Name < d.FailingEndpoints[j].Name
+		}
+		return d.FailingEndpoints[i].FailingSince.Before(d.FailingEndpoints[j].FailingSince)
+	})
+	if d.NumberOfEndpoints < len(d.FailingEndpoints) {
+		d.NumberOfEndpoints = len(d.FailingEndpoints)
+	}
+	return d
+}
+
+// sendDigest sends a digest using the given provider, unless the circuit breaker of the provider is open
+func sendDigest(digestProvider provider.DigestProvider, alertType alert.Type, digest *alert.Digest, alertingConfig *alerting.Config, enabledMetrics bool) error {
+	circuitBreaker := alertingConfig.GetCircuitBreaker(alertType)
+	if !circuitBreaker.Allow() {
+		atomic.AddUint64(&numberOfFailedAlerts, 1)
+		return alerting.ErrCircuitBreakerOpen
+	}
+	log.Printf("[watchdog][sendDigest] Sending %s digest for group=%s with %d failing endpoint(s); resolved=%v", alertType, digest.Group, len(digest.FailingEndpoints), digest.Resolved)
+	var err error
+	if os.Getenv("MOCK_ALERT_PROVIDER") == "true" {
+		if os.Getenv("MOCK_ALERT_PROVIDER_ERROR") == "true" {
+			err = errors.New("error")
+		}
+	} else {
+		err = digestProvider.SendDigest(digest)
+	}
+	if err != nil {
+		atomic.AddUint64(&numberOfFailedAlerts, 1)
+		circuitBreaker.RecordFailure()
+	} else {
+		circuitBreaker.RecordSuccess()
+	}
+	if circuitBreaker != nil && enabledMetrics {
+		metrics.PublishMetricsForAlertingCircuitBreaker(alertType, circuitBreaker.State())
+	}
+	return err
+}
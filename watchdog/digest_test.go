@@ -0,0 +1,105 @@
+package watchdog
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/TwiN/gatus/v5/alerting"
+	"github.com/TwiN/gatus/v5/alerting/alert"
+	"github.com/TwiN/gatus/v5/alerting/provider/wecom"
+	"github.com/TwiN/gatus/v5/client"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/test"
+)
+
+func TestHandleAlertingWithDigest(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	var messages []string
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		var body wecom.Body
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		messages = append(messages, body.Markdown.Content)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})})
+	alertingConfig := &alerting.Config{Wecom: &wecom.AlertProvider{WebhookURL: "https://example.com", Digest: &alert.DigestConfig{Threshold: 3}}}
+	enabled := true
+	var endpoints []*core.Endpoint
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		endpoints = append(endpoints, &core.Endpoint{Name: name, Group: "payments", URL: "https://example.com", Alerts: []*alert.Alert{{Type: alert.TypeWecom, FailureThreshold: 1, SuccessThreshold: 1, SendOnResolved: &enabled}}})
+	}
+	resetDigests(endpoints)
+	failingResult := &core.Result{Success: false, ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	// The first two failures are below the threshold, so they should be sent individually
+	HandleAlerting(endpoints[0], failingResult, alertingConfig, false, false)
+	HandleAlerting(endpoints[1], failingResult, alertingConfig, false, false)
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 individual alerts, got %d", len(messages))
+	}
+	// The third failure reaches the threshold, so a digest should be sent instead
+	HandleAlerting(endpoints[2], failingResult, alertingConfig, false, false)
+	if len(messages) != 3 || !strings.Contains(messages[2], "3/5 endpoints in group=payments are down") {
+		t.Fatalf("expected a digest to have been sent, got %v", messages)
+	}
+	for _, name := range []string{"**a**", "**b**", "**c**", "`[STATUS] == 200`"} {
+		if !strings.Contains(messages[2], name) {
+			t.Errorf("expected digest to contain %s, got %s", name, messages[2])
+		}
+	}
+	// Further failures and resolves should only update the state of the digest
+	HandleAlerting(endpoints[3], failingResult, alertingConfig, false, false)
+	for _, endpoint := range endpoints[:3] {
+		HandleAlerting(endpoint, &core.Result{Success: true}, alertingConfig, false, false)
+	}
+	if len(messages) != 3 {
+		t.Fatalf("expected no other message to have been sent while the digest is ongoing, got %v", messages[3:])
+	}
+	if !endpoints[3].Alerts[0].Triggered {
+		t.Error("expected the alert of an endpoint that is part of the digest to be marked as triggered")
+	}
+	// Once every endpoint has been resolved, the digest should be resolved
+	HandleAlerting(endpoints[3], &core.Result{Success: true}, alertingConfig, false, false)
+	if len(messages) != 4 || !strings.Contains(messages[3], "All endpoints in group=payments are back up") {
+		t.Fatalf("expected a resolved digest to have been sent, got %v", messages)
+	}
+	// Since the digest has been resolved, alerts should be sent individually again
+	HandleAlerting(endpoints[4], failingResult, alertingConfig, false, false)
+	if len(messages) != 5 || strings.Contains(messages[4], "group=payments") {
+		t.Errorf("expected an individual alert to have been sent, got %v", messages)
+	}
+}
+
+func TestHandleAlertingWithDigestWithoutSendOnResolved(t *testing.T) {
+	defer client.InjectHTTPClient(nil)
+	var messages []string
+	client.InjectHTTPClient(&http.Client{Transport: test.MockRoundTripper(func(r *http.Request) *http.Response {
+		var body wecom.Body
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		messages = append(messages, body.Markdown.Content)
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}
+	})})
+	alertingConfig := &alerting.Config{Wecom: &wecom.AlertProvider{WebhookURL: "https://example.com", Digest: &alert.DigestConfig{Threshold: 2}}}
+	var endpoints []*core.Endpoint
+	for _, name := range []string{"a", "b", "c"} {
+		endpoints = append(endpoints, &core.Endpoint{Name: name, Group: "payments", URL: "https://example.com", Alerts: []*alert.Alert{{Type: alert.TypeWecom, FailureThreshold: 1, SuccessThreshold: 1}}})
+	}
+	resetDigests(endpoints)
+	failingResult := &core.Result{Success: false, ConditionResults: []*core.ConditionResult{{Condition: "[STATUS] == 200", Success: false}}}
+	HandleAlerting(endpoints[0], failingResult, alertingConfig, false, false)
+	HandleAlerting(endpoints[1], failingResult, alertingConfig, false, false)
+	if len(messages) != 2 || !strings.Contains(messages[1], "2/3 endpoints in group=payments are down") {
+		t.Fatalf("expected a digest to have been sent, got %v", messages)
+	}
+	// Since send-on-resolved is disabled, resolving every endpoint shouldn't send anything
+	HandleAlerting(endpoints[0], &core.Result{Success: true}, alertingConfig, false, false)
+	HandleAlerting(endpoints[1], &core.Result{Success: true}, alertingConfig, false, false)
+	if len(messages) != 2 {
+		t.Fatalf("expected no message to have been sent on resolve, got %v", messages[2:])
+	}
+	// The digest has nevertheless been resolved, so the alerts of the group must no longer be suppressed
+	HandleAlerting(endpoints[2], failingResult, alertingConfig, false, false)
+	if len(messages) != 3 || strings.Contains(messages[2], "group=payments") {
+		t.Errorf("expected an individual alert to have been sent, got %v", messages)
+	}
+}
@@ -0,0 +1,141 @@
+package watchdog
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/metrics"
+)
+
+var (
+	groupHealthMutex sync.RWMutex
+
+	// monitoredGroups are the groups of the endpoints being monitored, by name
+	monitoredGroups = make(map[string]*monitoredGroup)
+
+	// lastSuccessByEndpointKey is whether the most recent result of each endpoint being monitored was successful
+	//
+	// It's kept when the configuration is reloaded, so that the health of the groups doesn't become unknown until
+	// every endpoint has been evaluated again.
+	lastSuccessByEndpointKey = make(map[string]bool)
+)
+
+// monitoredGroup is a group of the endpoints being monitored
+type monitoredGroup struct {
+	// config is the configuration of the group, or nil if the group isn't defined in endpoint-groups
+	config *endpointgroup.Config
+
+	// endpointKeys are the keys of the enabled endpoints of the group
+	endpointKeys []string
+}
+
+// GroupHealth is the health of a group of endpoints, rolled up from the most recent result of each of its endpoints
+type GroupHealth struct {
+	// Name is the name of the group
+	Name string
+
+	// Health is the health of the group according to its health policy
+	Health endpointgroup.Health
+
+	// HealthyEndpoints is the number of endpoints of the group whose most recent result was successful
+	HealthyEndpoints int
+
+	// EvaluatedEndpoints is the number of endpoints of the group that have been evaluated at least once
+	EvaluatedEndpoints int
+
+	// TotalEndpoints is the number of enabled endpoints of the group
+	TotalEndpoints int
+}
+
+// resetGroupHealths sets the groups whose health is rolled up from the endpoints passed
+//
+// Must be called before the endpoints start being monitored. Endpoints without a group aren't part of any group.
+func resetGroupHealths(endpoints []*core.Endpoint, endpointGroups []*endpointgroup.Config, enabledMetrics bool) {
+	groupHealthMutex.Lock()
+	defer groupHealthMutex.Unlock()
+	endpointGroupConfigs := make(map[string]*endpointgroup.Config, len(endpointGroups))
+	for _, endpointGroup := range endpointGroups {
+		endpointGroupConfigs[endpointGroup.Name] = endpointGroup
+	}
+	previousGroups := monitoredGroups
+	monitoredGroups = make(map[string]*monitoredGroup)
+	endpointKeys := make(map[string]bool, len(endpoints))
+	for _, endpoint := range endpoints {
+		if len(endpoint.Group) == 0 || !endpoint.IsEnabled() {
+			continue
+		}
+		group, exists := monitoredGroups[endpoint.Group]
+		if !exists {
+			group = &monitoredGroup{config: endpointGroupConfigs[endpoint.Group]}
+			monitoredGroups[endpoint.Group] = group
+		}
+		group.endpointKeys = append(group.endpointKeys, endpoint.Key())
+		endpointKeys[endpoint.Key()] = true
+	}
+	for key := range lastSuccessByEndpointKey {
+		if !endpointKeys[key] {
+			delete(lastSuccessByEndpointKey, key)
+		}
+	}
+	if enabledMetrics {
+		for name := range previousGroups {
+			if _, exists := monitoredGroups[name]; !exists {
+				metrics.UnpublishMetricsForGroupHealth(name)
+			}
+		}
+		for name, group := range monitoredGroups {
+			metrics.PublishMetricsForGroupHealth(name, group.health(name).Health)
+		}
+	}
+}
+
+// updateGroupHealth records the result of an endpoint, and publishes the resulting health of its group if the metrics
+// are enabled
+func updateGroupHealth(endpoint *core.Endpoint, result *core.Result, enabledMetrics bool) {
+	if len(endpoint.Group) == 0 {
+		return
+	}
+	groupHealthMutex.Lock()
+	defer groupHealthMutex.Unlock()
+	group, exists := monitoredGroups[endpoint.Group]
+	if !exists {
+		return
+	}
+	lastSuccessByEndpointKey[endpoint.Key()] = result.Success
+	if enabledMetrics {
+		metrics.PublishMetricsForGroupHealth(endpoint.Group, group.health(endpoint.Group).Health)
+	}
+}
+
+// GetGroupHealths returns the health of every group of the endpoints being monitored, sorted by name
+func GetGroupHealths() []GroupHealth {
+	groupHealthMutex.RLock()
+	defer groupHealthMutex.RUnlock()
+	groupHealths := make([]GroupHealth, 0, len(monitoredGroups))
+	for name, group := range monitoredGroups {
+		groupHealths = append(groupHealths, group.health(name))
+	}
+	sort.Slice(groupHealths, func(i, j int) bool {
+		return groupHealths[i].Name < groupHealths[j].Name
+	})
+	return groupHealths
+}
+
+// health rolls up the most recent result of each endpoint of the group into the health of the group
+//
+// Endpoints that haven't been evaluated yet are ignored. Must be called with groupHealthMutex held.
+func (group *monitoredGroup) health(name string) GroupHealth {
+	groupHealth := GroupHealth{Name: name, TotalEndpoints: len(group.endpointKeys)}
+	for _, key := range group.endpointKeys {
+		if success, evaluated := lastSuccessByEndpointKey[key]; evaluated {
+			groupHealth.EvaluatedEndpoints++
+			if success {
+				groupHealth.HealthyEndpoints++
+			}
+		}
+	}
+	groupHealth.Health = group.config.RollUp(groupHealth.HealthyEndpoints, groupHealth.EvaluatedEndpoints)
+	return groupHealth
+}
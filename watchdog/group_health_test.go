@@ -0,0 +1,104 @@
+package watchdog
+
+import (
+	"testing"
+
+	"github.com/TwiN/gatus/v5/config/endpointgroup"
+	"github.com/TwiN/gatus/v5/core"
+)
+
+func TestGetGroupHealths(t *testing.T) {
+	defer resetGroupHealths(nil, nil, false)
+	disabled := false
+	frontend := &core.Endpoint{Name: "frontend", Group: "core"}
+	backend := &core.Endpoint{Name: "backend", Group: "core"}
+	nas := &core.Endpoint{Name: "nas", Group: "internal"}
+	monitoring := &core.Endpoint{Name: "monitoring", Group: "internal"}
+	database := &core.Endpoint{Name: "database", Group: "internal"}
+	endpoints := []*core.Endpoint{
+		frontend,
+		backend,
+		nas,
+		monitoring,
+		database,
+		{Name: "disabled", Group: "internal", Enabled: &disabled},
+		{Name: "no-group"},
+	}
+	endpointGroups := []*endpointgroup.Config{{Name: "internal", HealthPolicy: endpointgroup.HealthPolicyQuorum}}
+	resetGroupHealths(endpoints, endpointGroups, false)
+	expectGroupHealths(t, []GroupHealth{
+		{Name: "core", Health: endpointgroup.HealthUnknown, TotalEndpoints: 2},
+		{Name: "internal", Health: endpointgroup.HealthUnknown, TotalEndpoints: 3},
+	})
+	updateGroupHealth(frontend, &core.Result{Success: true}, false)
+	updateGroupHealth(backend, &core.Result{Success: false}, false)
+	updateGroupHealth(nas, &core.Result{Success: false}, false)
+	updateGroupHealth(monitoring, &core.Result{Success: false}, false)
+	updateGroupHealth(database, &core.Result{Success: false}, false)
+	expectGroupHealths(t, []GroupHealth{
+		{Name: "core", Health: endpointgroup.HealthDegraded, HealthyEndpoints: 1, EvaluatedEndpoints: 2, TotalEndpoints: 2},
+		{Name: "internal", Health: endpointgroup.HealthDown, HealthyEndpoints: 0, EvaluatedEndpoints: 3, TotalEndpoints: 3},
+	})
+	updateGroupHealth(backend, &core.Result{Success: true}, false)
+	updateGroupHealth(nas, &core.Result{Success: true}, false)
+	expectGroupHealths(t, []GroupHealth{
+		{Name: "core", Health: endpointgroup.HealthHealthy, HealthyEndpoints: 2, EvaluatedEndpoints: 2, TotalEndpoints: 2},
+		{Name: "internal", Health: endpointgroup.HealthDegraded, HealthyEndpoints: 1, EvaluatedEndpoints: 3, TotalEndpoints: 3},
+	})
+	// With the quorum policy, a majority of the endpoints being healthy is enough
+	updateGroupHealth(monitoring, &core.Result{Success: true}, false)
+	expectGroupHealths(t, []GroupHealth{
+		{Name: "core", Health: endpointgroup.HealthHealthy, HealthyEndpoints: 2, EvaluatedEndpoints: 2, TotalEndpoints: 2},
+		{Name: "internal", Health: endpointgroup.HealthHealthy, HealthyEndpoints: 2, EvaluatedEndpoints: 3, TotalEndpoints: 3},
+	})
+	// The results must be kept across configuration reloads, except for the endpoints that are no longer monitored
+	resetGroupHealths([]*core.Endpoint{frontend, nas, monitoring}, nil, false)
+	expectGroupHealths(t, []GroupHealth{
+		{Name: "core", Health: endpointgroup.HealthHealthy, HealthyEndpoints: 1, EvaluatedEndpoints: 1, TotalEndpoints: 1},
+		{Name: "internal", Health: endpointgroup.HealthHealthy, HealthyEndpoints: 2, EvaluatedEndpoints: 2, TotalEndpoints: 2},
+	})
+	if _, exists := lastSuccessByEndpointKey[backend.Key()]; exists {
+		t.Error("expected the result of the endpoint that is no longer monitored to have been removed")
+	}
+}
+
+func TestEndpointGroupConfig_RollUp(t *testing.T) {
+	scenarios := []struct {
+		name             string
+		config           *endpointgroup.Config
+		healthyEndpoints int
+		totalEndpoints   int
+		expectedHealth   endpointgroup.Health
+	}{
+		{name: "no-endpoints", config: nil, healthyEndpoints: 0, totalEndpoints: 0, expectedHealth: endpointgroup.HealthUnknown},
+		{name: "undefined-group-all-healthy", config: nil, healthyEndpoints: 3, totalEndpoints: 3, expectedHealth: endpointgroup.HealthHealthy},
+		{name: "undefined-group-some-healthy", config: nil, healthyEndpoints: 2, totalEndpoints: 3, expectedHealth: endpointgroup.HealthDegraded},
+		{name: "undefined-group-none-healthy", config: nil, healthyEndpoints: 0, totalEndpoints: 3, expectedHealth: endpointgroup.HealthDown},
+		{name: "all-some-healthy", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyAll}, healthyEndpoints: 2, totalEndpoints: 3, expectedHealth: endpointgroup.HealthDegraded},
+		{name: "quorum-majority-healthy", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyQuorum}, healthyEndpoints: 2, totalEndpoints: 3, expectedHealth: endpointgroup.HealthHealthy},
+		{name: "quorum-half-healthy", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyQuorum}, healthyEndpoints: 2, totalEndpoints: 4, expectedHealth: endpointgroup.HealthDegraded},
+		{name: "quorum-explicit-reached", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyQuorum, HealthQuorum: 1}, healthyEndpoints: 1, totalEndpoints: 4, expectedHealth: endpointgroup.HealthHealthy},
+		{name: "quorum-explicit-not-reached", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyQuorum, HealthQuorum: 3}, healthyEndpoints: 2, totalEndpoints: 4, expectedHealth: endpointgroup.HealthDegraded},
+		{name: "quorum-none-healthy", config: &endpointgroup.Config{HealthPolicy: endpointgroup.HealthPolicyQuorum, HealthQuorum: 1}, healthyEndpoints: 0, totalEndpoints: 4, expectedHealth: endpointgroup.HealthDown},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.name, func(t *testing.T) {
+			if health := scenario.config.RollUp(scenario.healthyEndpoints, scenario.totalEndpoints); health != scenario.expectedHealth {
+				t.Errorf("expected health %s, got %s", scenario.expectedHealth, health)
+			}
+		})
+	}
+}
+
+func expectGroupHealths(t *testing.T, expectedGroupHealths []GroupHealth) {
+	t.Helper()
+	groupHealths := GetGroupHealths()
+	if len(groupHealths) != len(expectedGroupHealths) {
+		t.Fatalf("expected %d groups, got %d", len(expectedGroupHealths), len(groupHealths))
+	}
+	for i := range expectedGroupHealths {
+		if groupHealths[i] != expectedGroupHealths[i] {
+			t.Errorf("expected %+v, got %+v", expectedGroupHealths[i], groupHealths[i])
+		}
+	}
+}
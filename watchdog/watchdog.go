@@ -2,17 +2,23 @@ package watchdog
 
 import (
 	"context"
+	"errors"
 	"log"
 	"sync"
 	"time"
 
 	"github.com/TwiN/gatus/v5/alerting"
 	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/agent"
 	"github.com/TwiN/gatus/v5/config/connectivity"
 	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/config/ratelimit"
 	"github.com/TwiN/gatus/v5/core"
 	"github.com/TwiN/gatus/v5/metrics"
+	"github.com/TwiN/gatus/v5/resultwebhook"
 	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
 )
 
 var (
@@ -22,24 +28,83 @@ var (
 
 	ctx        context.Context
 	cancelFunc context.CancelFunc
+
+	// executions keeps track of the executions in progress since the last call to Monitor, so that they can be waited
+	// for on shutdown
+	executions *sync.WaitGroup
+
+	// executionsMutex ensures that no execution is added to executions once the context has been canceled
+	executionsMutex sync.Mutex
+
+	// checksInProgress keeps track of the keys of the endpoints whose check is in progress, so that a check never
+	// starts while the previous check of the same endpoint is still running, including the ones that were started
+	// before the configuration was reloaded
+	checksInProgress = make(map[string]bool)
+
+	// checksInProgressMutex protects checksInProgress
+	checksInProgressMutex sync.Mutex
+
+	// startTime is the time at which the application started, from which the startup grace of endpoints is measured.
+	// It isn't reset when the configuration is reloaded.
+	startTime = time.Now()
 )
 
 // Monitor loops over each endpoint and starts a goroutine to monitor each endpoint separately
 func Monitor(cfg *config.Config) {
 	ctx, cancelFunc = context.WithCancel(context.Background())
+	executions = &sync.WaitGroup{}
+	resetDigests(cfg.Endpoints)
+	// Metrics must be published if they're either scraped or pushed
+	enabledMetrics := cfg.Metrics || cfg.MetricsRemoteWrite != nil || cfg.MetricsPushgateway != nil
+	resetGroupHealths(cfg.Endpoints, cfg.EndpointGroups, enabledMetrics)
+	resetAgentResults()
 	for _, endpoint := range cfg.Endpoints {
+		if ctx.Err() != nil {
+			// Shutdown was called while the endpoints were still being started
+			return
+		}
+		if !isCheckedLocally(endpoint, cfg.Agent) {
+			// The results of the endpoint are either reported by its agents, or none of this agent's concern
+			continue
+		}
 		if endpoint.IsEnabled() {
 			// To prevent multiple requests from running at the same time, we'll wait for a little before each iteration
 			time.Sleep(777 * time.Millisecond)
-			go monitor(endpoint, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.DisableMonitoringLock, cfg.Metrics, cfg.Debug, ctx)
+			go monitor(endpoint, cfg.Alerting, cfg.Maintenance, cfg.Connectivity, cfg.HostRateLimit, cfg.Agent, cfg.DisableMonitoringLock, enabledMetrics, cfg.Debug, ctx, executions)
+		} else {
+			// The endpoint may have been disabled through a configuration reload, in which case the metrics of its
+			// last result must no longer be exposed
+			metrics.UnpublishMetricsForEndpoint(endpoint)
 		}
 	}
 }
 
 // monitor a single endpoint in a loop
-func monitor(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
+func monitor(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, hostRateLimitConfig *ratelimit.Config, agentConfig *agent.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context, executions *sync.WaitGroup) {
+	run := func() {
+		if !startCheck(endpoint) {
+			log.Printf("[watchdog][monitor] Skipping execution of group=%s; endpoint=%s because the previous execution is still in progress", endpoint.Group, endpoint.Name)
+			if enabledMetrics {
+				metrics.PublishMetricsForSkippedCheck(endpoint)
+			}
+			return
+		}
+		defer finishCheck(endpoint)
+		// The rate limit is waited for before acquiring the monitoring lock, so that a throttled check doesn't prevent
+		// the checks of other hosts from being performed
+		waitForHostRateLimit(endpoint, hostRateLimitConfig, enabledMetrics, debug, ctx)
+		executionsMutex.Lock()
+		if ctx.Err() != nil {
+			executionsMutex.Unlock()
+			return
+		}
+		executions.Add(1)
+		executionsMutex.Unlock()
+		defer executions.Done()
+		execute(endpoint, alertingConfig, maintenanceConfig, connectivityConfig, agentConfig, disableMonitoringLock, enabledMetrics, debug, ctx)
+	}
 	// Run it immediately on start
-	execute(endpoint, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+	run()
 	// Loop for the next executions
 	for {
 		select {
@@ -47,18 +112,22 @@ func monitor(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenan
 			log.Printf("[watchdog][monitor] Canceling current execution of group=%s; endpoint=%s", endpoint.Group, endpoint.Name)
 			return
 		case <-time.After(endpoint.Interval):
-			execute(endpoint, alertingConfig, maintenanceConfig, connectivityConfig, disableMonitoringLock, enabledMetrics, debug)
+			run()
 		}
 	}
 }
 
-func execute(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, disableMonitoringLock, enabledMetrics, debug bool) {
+func execute(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, connectivityConfig *connectivity.Config, agentConfig *agent.Config, disableMonitoringLock, enabledMetrics, debug bool, ctx context.Context) {
 	if !disableMonitoringLock {
 		// By placing the lock here, we prevent multiple endpoints from being monitored at the exact same time, which
 		// could cause performance issues and return inaccurate results
 		monitoringMutex.Lock()
 		defer monitoringMutex.Unlock()
 	}
+	// If Shutdown was called while waiting for the monitoring lock, the execution must not start
+	if ctx.Err() != nil {
+		return
+	}
 	// If there's a connectivity checker configured, check if Gatus has internet connectivity
 	if connectivityConfig != nil && connectivityConfig.Checker != nil && !connectivityConfig.Checker.IsConnected() {
 		log.Println("[watchdog][execute] No connectivity; skipping execution")
@@ -67,27 +136,136 @@ func execute(endpoint *core.Endpoint, alertingConfig *alerting.Config, maintenan
 	if debug {
 		log.Printf("[watchdog][execute] Monitoring group=%s; endpoint=%s", endpoint.Group, endpoint.Name)
 	}
+	if endpoint.NeedsResponseTimeBaseline() {
+		endpoint.SetResponseTimeBaseline(getResponseTimeBaseline(endpoint))
+	}
+	if endpoint.NeedsPreviousResponseTimes() {
+		endpoint.SetPreviousResponseTimes(getPreviousResponseTimes(endpoint, endpoint.ResponseTimeTrend.Checks-1))
+	}
 	result := endpoint.EvaluateHealth()
+	if agentConfig != nil {
+		// The result is handled by the central instance, which is also the one storing it and sending the alerts
+		if err := agentConfig.Report(endpoint, result); err != nil {
+			log.Printf("[watchdog][execute] Failed to report result of group=%s; endpoint=%s: %s", endpoint.Group, endpoint.Name, err.Error())
+		} else {
+			log.Printf("[watchdog][execute] Reported result of group=%s; endpoint=%s; success=%v; errors=%d; duration=%s", endpoint.Group, endpoint.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond))
+		}
+		return
+	}
 	if enabledMetrics {
 		metrics.PublishMetricsForEndpoint(endpoint, result)
 	}
 	UpdateEndpointStatuses(endpoint, result)
+	updateGroupHealth(endpoint, result, enabledMetrics)
+	resultwebhook.Publish(endpoint, result)
 	if debug && !result.Success {
 		log.Printf("[watchdog][execute] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s; body=%s", endpoint.Group, endpoint.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond), result.Body)
 	} else {
 		log.Printf("[watchdog][execute] Monitored group=%s; endpoint=%s; success=%v; errors=%d; duration=%s", endpoint.Group, endpoint.Name, result.Success, len(result.Errors), result.Duration.Round(time.Millisecond))
 	}
-	if !maintenanceConfig.IsUnderMaintenance() {
-		// TODO: Consider moving this after the monitoring lock is unlocked? I mean, how much noise can a single alerting provider cause...
-		HandleAlerting(endpoint, result, alertingConfig, debug)
-	} else if debug {
-		log.Println("[watchdog][execute] Not handling alerting because currently in the maintenance window")
-	}
+	handleAlertingIfNecessary(endpoint, result, alertingConfig, maintenanceConfig, enabledMetrics, debug)
 	if debug {
 		log.Printf("[watchdog][execute] Waiting for interval=%s before monitoring group=%s endpoint=%s again", endpoint.Interval, endpoint.Group, endpoint.Name)
 	}
 }
 
+// startCheck marks the check of an endpoint as in progress, and returns false if it already was
+func startCheck(endpoint *core.Endpoint) bool {
+	checksInProgressMutex.Lock()
+	defer checksInProgressMutex.Unlock()
+	if checksInProgress[endpoint.Key()] {
+		return false
+	}
+	checksInProgress[endpoint.Key()] = true
+	return true
+}
+
+// finishCheck marks the check of an endpoint as no longer in progress
+func finishCheck(endpoint *core.Endpoint) {
+	checksInProgressMutex.Lock()
+	delete(checksInProgress, endpoint.Key())
+	checksInProgressMutex.Unlock()
+}
+
+// handleAlertingIfNecessary handles the alerting of the result of an endpoint, unless alerts must not be sent for it
+// (e.g. during a maintenance window)
+func handleAlertingIfNecessary(endpoint *core.Endpoint, result *core.Result, alertingConfig *alerting.Config, maintenanceConfig *maintenance.Config, enabledMetrics, debug bool) {
+	if maintenanceConfig.IsUnderMaintenance() {
+		if debug {
+			log.Println("[watchdog][handleAlertingIfNecessary] Not handling alerting because currently in the maintenance window")
+		}
+	} else if isEndpointOrDependencyUnderMaintenance(endpoint, make(map[*core.Endpoint]bool)) {
+		if debug {
+			log.Println("[watchdog][handleAlertingIfNecessary] Not handling alerting because the endpoint or one of its dependencies is in a maintenance window")
+		}
+	} else if result.ExpectedFailure {
+		if debug {
+			log.Printf("[watchdog][handleAlertingIfNecessary] Not handling alerting because the result is an expected failure with status=%d", result.HTTPStatus)
+		}
+	} else if result.Unknown && !endpoint.AlertOnUnknown {
+		if debug {
+			log.Println("[watchdog][handleAlertingIfNecessary] Not handling alerting because the result is unknown due to a network error")
+		}
+	} else if isInStartupGrace(endpoint) {
+		if debug {
+			log.Println("[watchdog][handleAlertingIfNecessary] Not handling alerting because the endpoint is still in its startup grace period")
+		}
+	} else if IsAlertingSilenced() {
+		if debug {
+			log.Println("[watchdog][handleAlertingIfNecessary] Not handling alerting because alerting is currently silenced")
+		}
+	} else {
+		// TODO: Consider moving this after the monitoring lock is unlocked? I mean, how much noise can a single alerting provider cause...
+		HandleAlerting(endpoint, result, alertingConfig, enabledMetrics, debug)
+	}
+}
+
+// waitForHostRateLimit blocks until the host rate limit allows the endpoint to be checked
+func waitForHostRateLimit(endpoint *core.Endpoint, hostRateLimitConfig *ratelimit.Config, enabledMetrics, debug bool, ctx context.Context) {
+	if hostRateLimitConfig == nil {
+		return
+	}
+	host, err := endpoint.Hostname()
+	if err != nil {
+		// The error will be reported in the result of the execution
+		return
+	}
+	if throttled := hostRateLimitConfig.Wait(ctx, host); throttled > 0 {
+		if debug {
+			log.Printf("[watchdog][waitForHostRateLimit] Throttled group=%s; endpoint=%s for %s due to the rate limit of host=%s", endpoint.Group, endpoint.Name, throttled.Round(time.Millisecond), host)
+		}
+		if enabledMetrics {
+			metrics.PublishMetricsForHostRateLimit(host, throttled)
+		}
+	}
+}
+
+// isInStartupGrace returns whether the application started less than the startup grace of the endpoint ago
+func isInStartupGrace(endpoint *core.Endpoint) bool {
+	return time.Since(startTime) < endpoint.StartupGrace
+}
+
+// isEndpointOrDependencyUnderMaintenance returns whether the endpoint, or any of the endpoints it depends on either
+// directly or transitively, is in one of its maintenance windows
+//
+// visited keeps track of the endpoints that have already been checked, so that circular dependencies don't cause an
+// infinite loop.
+func isEndpointOrDependencyUnderMaintenance(endpoint *core.Endpoint, visited map[*core.Endpoint]bool) bool {
+	if visited[endpoint] {
+		return false
+	}
+	visited[endpoint] = true
+	if endpoint.IsUnderMaintenance() {
+		return true
+	}
+	for _, dependency := range endpoint.Dependencies() {
+		if isEndpointOrDependencyUnderMaintenance(dependency, visited) {
+			return true
+		}
+	}
+	return false
+}
+
 // UpdateEndpointStatuses updates the slice of endpoint statuses
 func UpdateEndpointStatuses(endpoint *core.Endpoint, result *core.Result) {
 	if err := store.Get().Insert(endpoint, result); err != nil {
@@ -95,11 +273,74 @@ func UpdateEndpointStatuses(endpoint *core.Endpoint, result *core.Result) {
 	}
 }
 
+// getResponseTimeBaseline computes the baseline of the response times of the most recent successful results of an
+// endpoint
+func getResponseTimeBaseline(endpoint *core.Endpoint) *core.ResponseTimeBaseline {
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(endpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, core.ResponseTimeBaselineSize))
+	if err != nil {
+		if !errors.Is(err, common.ErrEndpointNotFound) {
+			log.Printf("[watchdog][getResponseTimeBaseline] Failed to retrieve results of endpoint with key=%s: %s", endpoint.Key(), err.Error())
+		}
+		return nil
+	}
+	var responseTimes []time.Duration
+	for _, result := range endpointStatus.Results {
+		if result.Success {
+			responseTimes = append(responseTimes, result.Duration)
+		}
+	}
+	return core.NewResponseTimeBaseline(responseTimes)
+}
+
+// getPreviousResponseTimes returns the response times of the most recent results of an endpoint, from the oldest to
+// the most recent one
+//
+// Unlike with the baseline, unsuccessful results are included, since a degrading endpoint is expected to fail some of
+// its conditions.
+func getPreviousResponseTimes(endpoint *core.Endpoint, numberOfResults int) []time.Duration {
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(endpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, numberOfResults))
+	if err != nil {
+		if !errors.Is(err, common.ErrEndpointNotFound) {
+			log.Printf("[watchdog][getPreviousResponseTimes] Failed to retrieve results of endpoint with key=%s: %s", endpoint.Key(), err.Error())
+		}
+		return nil
+	}
+	responseTimes := make([]time.Duration, 0, len(endpointStatus.Results))
+	for _, result := range endpointStatus.Results {
+		responseTimes = append(responseTimes, result.Duration)
+	}
+	return responseTimes
+}
+
 // Shutdown stops monitoring all endpoints
+//
+// No new execution is started once Shutdown has been called, but the executions in progress, including the alerts
+// they trigger or resolve, are waited for, up to the shutdown timeout of the configuration.
 func Shutdown(cfg *config.Config) {
+	executionsMutex.Lock()
+	cancelFunc()
+	executionsMutex.Unlock()
+	if !waitForExecutions(executions, cfg.ShutdownTimeout) {
+		log.Printf("[watchdog][Shutdown] Timed out after %s while waiting for the executions in progress to complete", cfg.ShutdownTimeout)
+	}
 	// Disable all the old HTTP connections
 	for _, endpoint := range cfg.Endpoints {
 		endpoint.Close()
 	}
-	cancelFunc()
+}
+
+// waitForExecutions waits for the executions in progress to complete, and returns false if they didn't complete
+// within the timeout passed
+func waitForExecutions(executions *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		executions.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
 }
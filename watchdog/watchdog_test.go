@@ -0,0 +1,128 @@
+package watchdog
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/TwiN/gatus/v5/config"
+	"github.com/TwiN/gatus/v5/config/maintenance"
+	"github.com/TwiN/gatus/v5/core"
+	"github.com/TwiN/gatus/v5/storage/store"
+	"github.com/TwiN/gatus/v5/storage/store/common/paging"
+)
+
+func TestIsEndpointOrDependencyUnderMaintenance(t *testing.T) {
+	activeMaintenanceWindow := &maintenance.Config{Start: time.Now().UTC().Add(-time.Hour).Format("15:04"), Duration: 2 * time.Hour}
+	if err := activeMaintenanceWindow.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	database := &core.Endpoint{Name: "database", MaintenanceWindows: []*maintenance.Config{activeMaintenanceWindow}}
+	backend := &core.Endpoint{Name: "backend"}
+	backend.SetDependencies([]*core.Endpoint{database})
+	frontend := &core.Endpoint{Name: "frontend"}
+	frontend.SetDependencies([]*core.Endpoint{backend})
+	unrelated := &core.Endpoint{Name: "unrelated"}
+	// Circular dependencies shouldn't cause an infinite loop
+	first, second := &core.Endpoint{Name: "first"}, &core.Endpoint{Name: "second"}
+	first.SetDependencies([]*core.Endpoint{second})
+	second.SetDependencies([]*core.Endpoint{first})
+	scenarios := []struct {
+		endpoint *core.Endpoint
+		expected bool
+	}{
+		{endpoint: database, expected: true},
+		{endpoint: backend, expected: true},
+		{endpoint: frontend, expected: true},
+		{endpoint: unrelated, expected: false},
+		{endpoint: first, expected: false},
+	}
+	for _, scenario := range scenarios {
+		t.Run(scenario.endpoint.Name, func(t *testing.T) {
+			if actual := isEndpointOrDependencyUnderMaintenance(scenario.endpoint, make(map[*core.Endpoint]bool)); actual != scenario.expected {
+				t.Errorf("expected %v, got %v", scenario.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIsInStartupGrace(t *testing.T) {
+	defer func(originalStartTime time.Time) { startTime = originalStartTime }(startTime)
+	startTime = time.Now().Add(-time.Minute)
+	if isInStartupGrace(&core.Endpoint{Name: "no-startup-grace"}) {
+		t.Error("expected endpoint without startup grace to not be in its startup grace period")
+	}
+	if !isInStartupGrace(&core.Endpoint{Name: "long-startup-grace", StartupGrace: 5 * time.Minute}) {
+		t.Error("expected endpoint with a startup grace longer than the uptime to be in its startup grace period")
+	}
+	if isInStartupGrace(&core.Endpoint{Name: "short-startup-grace", StartupGrace: 30 * time.Second}) {
+		t.Error("expected endpoint with a startup grace shorter than the uptime to no longer be in its startup grace period")
+	}
+}
+
+func TestShutdownWaitsForExecutionsInProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	endpoint := &core.Endpoint{Name: "slow", URL: server.URL, Interval: time.Hour, Conditions: []core.Condition{"[STATUS] == 200"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	cfg := &config.Config{Endpoints: []*core.Endpoint{endpoint}, Maintenance: maintenance.GetDefaultConfig(), ShutdownTimeout: 5 * time.Second}
+	Monitor(cfg)
+	time.Sleep(100 * time.Millisecond) // Give the execution some time to start
+	Shutdown(cfg)
+	endpointStatus, err := store.Get().GetEndpointStatusByKey(endpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 1))
+	if err != nil {
+		t.Fatal("expected the result of the execution in progress to have been stored before Shutdown returned, got", err.Error())
+	}
+	if len(endpointStatus.Results) != 1 || !endpointStatus.Results[0].Success {
+		t.Errorf("expected one successful result, got %d", len(endpointStatus.Results))
+	}
+	store.Get().Clear()
+}
+
+func TestStartCheck(t *testing.T) {
+	endpoint := &core.Endpoint{Name: "slow", Group: "core"}
+	if !startCheck(endpoint) {
+		t.Fatal("expected the check to start, since no check of the endpoint is in progress")
+	}
+	// Endpoints are recreated when the configuration is reloaded, so the check in progress is tracked by key
+	if startCheck(&core.Endpoint{Name: "slow", Group: "core"}) {
+		t.Error("expected the check to not start, since the previous check of the endpoint is still in progress")
+	}
+	if !startCheck(&core.Endpoint{Name: "other", Group: "core"}) {
+		t.Error("expected the check of another endpoint to start")
+	}
+	finishCheck(&core.Endpoint{Name: "other", Group: "core"})
+	finishCheck(endpoint)
+	if !startCheck(endpoint) {
+		t.Error("expected the check to start, since the previous check of the endpoint has finished")
+	}
+	finishCheck(endpoint)
+}
+
+func TestMonitorSkipsExecutionWhilePreviousExecutionIsInProgress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	endpoint := &core.Endpoint{Name: "skipped", URL: server.URL, Interval: time.Hour, Conditions: []core.Condition{"[STATUS] == 200"}}
+	if err := endpoint.ValidateAndSetDefaults(); err != nil {
+		t.Fatal("expected no error, got", err.Error())
+	}
+	// Simulate an execution that started before the configuration was reloaded and is still in progress
+	startCheck(endpoint)
+	defer finishCheck(endpoint)
+	cfg := &config.Config{Endpoints: []*core.Endpoint{endpoint}, Maintenance: maintenance.GetDefaultConfig(), ShutdownTimeout: 5 * time.Second}
+	Monitor(cfg)
+	time.Sleep(100 * time.Millisecond) // Give the execution some time to start
+	Shutdown(cfg)
+	if _, err := store.Get().GetEndpointStatusByKey(endpoint.Key(), paging.NewEndpointStatusParams().WithResults(1, 1)); err == nil {
+		t.Error("expected the execution to have been skipped, but a result was stored")
+	}
+	store.Get().Clear()
+}